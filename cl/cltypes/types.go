@@ -326,6 +326,57 @@ func (l *LightClientUpdate) HasSyncFinality() bool {
 		utils.SlotToPeriod(l.AttestedHeader.Slot) == utils.SlotToPeriod(l.FinalizedHeader.Slot)
 }
 
+// IsBetterUpdate compares two updates of the same sync committee period, following the light client is_better_update rules.
+func IsBetterUpdate(oldUpdate *LightClientUpdate, newUpdate *LightClientUpdate) bool {
+	var (
+		maxActiveParticipants = len(newUpdate.SyncAggregate.SyncCommiteeBits) * 8 // Bits
+		newActiveParticipants = newUpdate.SyncAggregate.Sum()
+		oldActiveParticipants = oldUpdate.SyncAggregate.Sum()
+		newHasSuperMajority   = newActiveParticipants*3 >= maxActiveParticipants*2
+		oldHasSuperMajority   = oldActiveParticipants*3 >= maxActiveParticipants*2
+	)
+
+	// Compare supermajority (> 2/3) sync committee participation
+	if newHasSuperMajority != oldHasSuperMajority {
+		return newHasSuperMajority && !oldHasSuperMajority
+	}
+
+	if !newHasSuperMajority && newActiveParticipants != oldActiveParticipants {
+		return newActiveParticipants > oldActiveParticipants
+	}
+
+	// Compare presence of relevant sync committee
+	isNewUpdateRelevant := newUpdate.HasNextSyncCommittee() &&
+		utils.SlotToPeriod(newUpdate.AttestedHeader.Slot) == utils.SlotToPeriod(newUpdate.SignatureSlot)
+	isOldUpdateRelevant := oldUpdate.HasNextSyncCommittee() &&
+		utils.SlotToPeriod(oldUpdate.AttestedHeader.Slot) == utils.SlotToPeriod(oldUpdate.SignatureSlot)
+
+	if isNewUpdateRelevant != isOldUpdateRelevant {
+		return isNewUpdateRelevant
+	}
+
+	isNewFinality := newUpdate.IsFinalityUpdate()
+	isOldFinality := oldUpdate.IsFinalityUpdate()
+
+	if isNewFinality != isOldFinality {
+		return isNewFinality
+	}
+
+	// Compare sync committee finality
+	if isNewFinality && newUpdate.HasSyncFinality() != oldUpdate.HasSyncFinality() {
+		return newUpdate.HasSyncFinality()
+	}
+
+	// Tie Breakers
+	if newActiveParticipants != oldActiveParticipants {
+		return newActiveParticipants > oldActiveParticipants
+	}
+	if newUpdate.AttestedHeader.Slot != oldUpdate.AttestedHeader.Slot {
+		return newUpdate.AttestedHeader.Slot < oldUpdate.AttestedHeader.Slot
+	}
+	return newUpdate.SignatureSlot < oldUpdate.SignatureSlot
+}
+
 // LightClientFinalityUpdate is used to update the sync aggreggate every 6 minutes.
 type LightClientFinalityUpdate struct {
 	AttestedHeader  *BeaconBlockHeader
@@ -78,3 +78,17 @@ func DecodeSSZSnappy(dst ssz.Unmarshaler, src []byte) error {
 
 	return nil
 }
+
+// GetBitlistLength returns the number of bits of an SSZ bitlist, the highest set bit of the last byte marks the end of the list.
+// False is returned for malformed bitlists lacking that bit.
+func GetBitlistLength(b []byte) (int, bool) {
+	if len(b) == 0 || b[len(b)-1] == 0 {
+		return 0, false
+	}
+	last := b[len(b)-1]
+	msb := 7
+	for last>>msb == 0 {
+		msb--
+	}
+	return (len(b)-1)*8 + msb, true
+}
@@ -35,3 +35,16 @@ func TestLiteralConverters(t *testing.T) {
 	require.Equal(t, utils.BytesToBytes4([]byte{10, 23, 56, 7, 8, 5}), [4]byte{10, 23, 56, 7})
 	require.Equal(t, utils.Uint64ToLE(600), []byte{0x58, 0x2, 0x0, 0x0, 0x0, 0x0, 0x00, 0x00})
 }
+
+func TestGetBitlistLength(t *testing.T) {
+	length, ok := utils.GetBitlistLength([]byte{0x01})
+	require.True(t, ok)
+	require.Equal(t, 0, length)
+	length, ok = utils.GetBitlistLength([]byte{0xff, 0x05})
+	require.True(t, ok)
+	require.Equal(t, 10, length)
+	_, ok = utils.GetBitlistLength([]byte{0xff, 0x00})
+	require.False(t, ok)
+	_, ok = utils.GetBitlistLength(nil)
+	require.False(t, ok)
+}
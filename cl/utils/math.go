@@ -1,5 +1,7 @@
 package utils
 
+import "math"
+
 func IsPowerOf2(n uint64) bool {
 	return n != 0 && (n&(n-1)) == 0
 }
@@ -10,3 +12,23 @@ func PowerOf2(n uint64) uint64 {
 	}
 	return 1 << n
 }
+
+// IntegerSquareRoot returns the largest integer x such that x*x <= n.
+func IntegerSquareRoot(n uint64) uint64 {
+	x := uint64(math.Sqrt(float64(n)))
+	for x*x > n {
+		x--
+	}
+	for (x+1)*(x+1) <= n {
+		x++
+	}
+	return x
+}
+
+// Min64 returns the smallest of a and b.
+func Min64(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
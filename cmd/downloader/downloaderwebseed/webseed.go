@@ -0,0 +1,216 @@
+package downloaderwebseed
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/anacrolix/torrent"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/ledgerwatch/log/v3"
+)
+
+// Handler serves the files of snapDir by name, with range requests, so that other downloaders
+// can use it as a BEP-19 webseed or as a plain HTTP mirror. Only files for which complete returns
+// true are served, a .torrent file is served once its data file is complete.
+func Handler(snapDir string, complete func(name string) bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		name := strings.TrimPrefix(r.URL.Path, "/")
+		if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+			http.NotFound(w, r)
+			return
+		}
+		if !complete(strings.TrimSuffix(name, ".torrent")) {
+			http.NotFound(w, r)
+			return
+		}
+		f, err := os.Open(filepath.Join(snapDir, name))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeContent(w, r, name, info.ModTime(), f)
+	})
+}
+
+// Completed reports the files which the torrent client has fully downloaded and verified.
+func Completed(client *torrent.Client) func(name string) bool {
+	return func(name string) bool {
+		for _, t := range client.Torrents() {
+			if t.Info() == nil || t.Name() != name {
+				continue
+			}
+			return t.Complete.Bool()
+		}
+		return false
+	}
+}
+
+// Serve runs the webseed server of snapDir on addr until ctx is done.
+func Serve(ctx context.Context, addr, snapDir string, complete func(name string) bool) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           Handler(snapDir, complete),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+	log.Info("[webseed] Started HTTP server", "on", addr)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// normalize makes the urls end with a slash, for BEP-19 clients to append the file name.
+func normalize(urls []string) []string {
+	res := make([]string, 0, len(urls))
+	for _, u := range urls {
+		if u = strings.TrimSpace(u); u == "" {
+			continue
+		}
+		if !strings.HasSuffix(u, "/") {
+			u += "/"
+		}
+		res = append(res, u)
+	}
+	return res
+}
+
+// AddWebSeeds adds urls as webseeds of every torrent of the client, including the ones added
+// later, until ctx is done. The client checks the pieces received from webseeds like the ones
+// received from peers.
+func AddWebSeeds(ctx context.Context, client *torrent.Client, urls []string) {
+	urls = normalize(urls)
+	if len(urls) == 0 {
+		return
+	}
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+		for _, t := range client.Torrents() {
+			t.AddWebSeeds(urls) // no-op for the urls already added
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func get(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// Download fetches the file name and its .torrent from the mirror into snapDir. The .torrent must
+// have the expected infohash and the file must match the piece hashes of the .torrent, otherwise
+// nothing is written.
+func Download(ctx context.Context, mirror, snapDir, name string, infoHash metainfo.Hash) error {
+	mirror = normalize([]string{mirror})[0]
+
+	body, err := get(ctx, mirror+name+".torrent")
+	if err != nil {
+		return err
+	}
+	mi, err := metainfo.Load(body)
+	body.Close()
+	if err != nil {
+		return fmt.Errorf("%s.torrent: %w", name, err)
+	}
+	if mi.HashInfoBytes() != infoHash {
+		return fmt.Errorf("%s.torrent: infohash %x, expected %x", name, mi.HashInfoBytes(), infoHash)
+	}
+	info, err := mi.UnmarshalInfo()
+	if err != nil {
+		return fmt.Errorf("%s.torrent: %w", name, err)
+	}
+	if info.Name != name || info.IsDir() {
+		return fmt.Errorf("%s.torrent: unexpected torrent of %s", name, info.Name)
+	}
+
+	body, err = get(ctx, mirror+name)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	f, err := os.CreateTemp(snapDir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if err := copyVerified(f, body, &info); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	tf, err := os.Create(filepath.Join(snapDir, name+".torrent"))
+	if err != nil {
+		return err
+	}
+	defer tf.Close()
+	if err := mi.Write(tf); err != nil {
+		return err
+	}
+	if err := tf.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(snapDir, name))
+}
+
+// copyVerified copies the data of a single-file torrent from r to w, checking every piece.
+func copyVerified(w io.Writer, r io.Reader, info *metainfo.Info) error {
+	buf := make([]byte, info.PieceLength)
+	var written int64
+	for i := 0; i < info.NumPieces(); i++ {
+		p := info.Piece(i)
+		n, err := io.ReadFull(r, buf[:p.Length()])
+		if err != nil {
+			return fmt.Errorf("%d bytes of %d: %w", written+int64(n), info.Length, err)
+		}
+		if metainfo.Hash(sha1.Sum(buf[:n])) != p.Hash() { //nolint:gosec
+			return fmt.Errorf("wrong hash of piece %d", i)
+		}
+		if _, err := w.Write(buf[:n]); err != nil {
+			return err
+		}
+		written += int64(n)
+	}
+	if n, _ := io.Copy(io.Discard, io.LimitReader(r, 1)); n > 0 {
+		return fmt.Errorf("more than %d bytes", info.Length)
+	}
+	return nil
+}
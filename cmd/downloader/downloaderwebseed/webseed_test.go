@@ -0,0 +1,89 @@
+package downloaderwebseed
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anacrolix/torrent/bencode"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/stretchr/testify/require"
+)
+
+const testSeg = "v1-000000-000500-headers.seg"
+
+// createTestSeg writes a segment and its .torrent, and returns the infohash.
+func createTestSeg(t *testing.T, dir string) metainfo.Hash {
+	data := make([]byte, 100_000)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, testSeg), data, 0644))
+	info := metainfo.Info{PieceLength: 16 * 1024}
+	require.NoError(t, info.BuildFromFilePath(filepath.Join(dir, testSeg)))
+	infoBytes, err := bencode.Marshal(info)
+	require.NoError(t, err)
+	mi := metainfo.MetaInfo{InfoBytes: infoBytes}
+	f, err := os.Create(filepath.Join(dir, testSeg+".torrent"))
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, mi.Write(f))
+	return mi.HashInfoBytes()
+}
+
+func TestWebseed(t *testing.T) {
+	ctx, seedDir := context.Background(), t.TempDir()
+	infoHash := createTestSeg(t, seedDir)
+	complete := true
+	srv := httptest.NewServer(Handler(seedDir, func(name string) bool { return complete && name == testSeg }))
+	defer srv.Close()
+
+	// Range requests, as sent by BEP-19 clients
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/"+testSeg, nil)
+	require.NoError(t, err)
+	req.Header.Set("Range", "bytes=10-19")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	data, err := os.ReadFile(filepath.Join(seedDir, testSeg))
+	require.NoError(t, err)
+	require.Equal(t, data[10:20], b)
+
+	for _, path := range []string{"/../" + testSeg, "/v1-000500-001000-headers.seg", "/"} {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNotFound, resp.StatusCode, path)
+	}
+
+	// Mirror download
+	dir := t.TempDir()
+	require.Error(t, Download(ctx, srv.URL, dir, testSeg, metainfo.Hash{1}))
+	require.NoError(t, Download(ctx, srv.URL, dir, testSeg, infoHash))
+	downloaded, err := os.ReadFile(filepath.Join(dir, testSeg))
+	require.NoError(t, err)
+	require.Equal(t, data, downloaded)
+	mi, err := metainfo.LoadFromFile(filepath.Join(dir, testSeg+".torrent"))
+	require.NoError(t, err)
+	require.Equal(t, infoHash, mi.HashInfoBytes())
+
+	// A corrupted file on the mirror is rejected
+	data[len(data)/2] ^= 0xff
+	require.NoError(t, os.WriteFile(filepath.Join(seedDir, testSeg), data, 0644))
+	dir = t.TempDir()
+	require.Error(t, Download(ctx, srv.URL, dir, testSeg, infoHash))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	// Incomplete files are not served
+	complete = false
+	require.Error(t, Download(ctx, srv.URL, dir, testSeg, infoHash))
+}
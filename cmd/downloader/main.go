@@ -18,12 +18,14 @@ import (
 	downloadercfg2 "github.com/ledgerwatch/erigon-lib/downloader/downloadercfg"
 	proto_downloader "github.com/ledgerwatch/erigon-lib/gointerfaces/downloader"
 	"github.com/ledgerwatch/erigon/cmd/downloader/downloadernat"
+	"github.com/ledgerwatch/erigon/cmd/downloader/downloaderwebseed"
 	"github.com/ledgerwatch/erigon/cmd/utils"
 	"github.com/ledgerwatch/erigon/common/paths"
 	"github.com/ledgerwatch/erigon/p2p/nat"
 	"github.com/ledgerwatch/erigon/params"
 	"github.com/ledgerwatch/erigon/turbo/debug"
 	logging2 "github.com/ledgerwatch/erigon/turbo/logging"
+	"github.com/ledgerwatch/erigon/turbo/snapshotsync/snapcfg"
 	"github.com/ledgerwatch/log/v3"
 	"github.com/pelletier/go-toml/v2"
 	"github.com/spf13/cobra"
@@ -50,6 +52,10 @@ var (
 	targetFile                     string
 	disableIPV6                    bool
 	disableIPV4                    bool
+	webseedAddr                    string
+	webseeds                       []string
+	mirrorURL                      string
+	chain                          string
 )
 
 func init() {
@@ -68,6 +74,8 @@ func init() {
 	rootCmd.Flags().IntVar(&torrentDownloadSlots, "torrent.download.slots", utils.TorrentDownloadSlotsFlag.Value, utils.TorrentDownloadSlotsFlag.Usage)
 	rootCmd.Flags().BoolVar(&disableIPV6, "downloader.disable.ipv6", utils.DisableIPV6.Value, utils.DisableIPV6.Usage)
 	rootCmd.Flags().BoolVar(&disableIPV4, "downloader.disable.ipv4", utils.DisableIPV4.Value, utils.DisableIPV6.Usage)
+	rootCmd.Flags().StringVar(&webseedAddr, "webseed.addr", "", "serve completed snapshot files over HTTP on this address, for other downloaders to use it as webseed or mirror, for example: 0.0.0.0:9094")
+	rootCmd.Flags().StringSliceVar(&webseeds, "webseeds", nil, "comma separated list of HTTP servers to download snapshot files from (BEP-19 webseeds), for example: http://10.0.0.1:9094")
 
 	withDataDir(printTorrentHashes)
 	printTorrentHashes.PersistentFlags().BoolVar(&forceRebuild, "rebuild", false, "Force re-create .torrent files")
@@ -78,6 +86,14 @@ func init() {
 	}
 
 	rootCmd.AddCommand(printTorrentHashes)
+
+	withDataDir(mirrorCmd)
+	mirrorCmd.Flags().StringVar(&chain, utils.ChainFlag.Name, utils.ChainFlag.Value, utils.ChainFlag.Usage)
+	mirrorCmd.Flags().StringVar(&mirrorURL, "from", "", "HTTP server started by downloader --webseed.addr, for example: http://10.0.0.1:9094")
+	if err := mirrorCmd.MarkFlagRequired("from"); err != nil {
+		panic(err)
+	}
+	rootCmd.AddCommand(mirrorCmd)
 }
 
 func withDataDir(cmd *cobra.Command) {
@@ -157,6 +173,14 @@ func Downloader(ctx context.Context) error {
 	defer d.Close()
 	log.Info("[torrent] Start", "my peerID", fmt.Sprintf("%x", d.Torrent().PeerID()))
 	go downloader.MainLoop(ctx, d, false)
+	go downloaderwebseed.AddWebSeeds(ctx, d.Torrent(), webseeds)
+	if webseedAddr != "" {
+		go func() {
+			if err := downloaderwebseed.Serve(ctx, webseedAddr, dirs.Snap, downloaderwebseed.Completed(d.Torrent())); err != nil {
+				log.Error("[webseed] HTTP server", "err", err)
+			}
+		}()
+	}
 
 	bittorrentServer, err := downloader.NewGrpcServer(d)
 	if err != nil {
@@ -245,6 +269,39 @@ var printTorrentHashes = &cobra.Command{
 	},
 }
 
+var mirrorCmd = &cobra.Command{
+	Use:     "mirror",
+	Short:   "Download the preverified snapshot files of the chain, missing in datadir, from the HTTP server of another downloader",
+	Example: "go run ./cmd/downloader mirror --datadir <your_datadir> --chain mainnet --from http://10.0.0.1:9094",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dirs := datadir.New(datadirCli)
+		ctx := cmd.Context()
+		if err := os.MkdirAll(dirs.Snap, 0755); err != nil {
+			return err
+		}
+
+		preverified := snapcfg.KnownCfg(chain, nil, nil).Preverified
+		var downloaded, skipped int
+		for _, p := range preverified {
+			if _, err := os.Stat(filepath.Join(dirs.Snap, p.Name)); err == nil {
+				skipped++
+				continue
+			}
+			var infoHash metainfo.Hash
+			if err := infoHash.FromHexString(p.Hash); err != nil {
+				return fmt.Errorf("%s: %w", p.Name, err)
+			}
+			if err := downloaderwebseed.Download(ctx, mirrorURL, dirs.Snap, p.Name, infoHash); err != nil {
+				return err
+			}
+			downloaded++
+			log.Info("[mirror] Downloaded", "file", p.Name, "progress", fmt.Sprintf("%d/%d", downloaded+skipped, len(preverified)))
+		}
+		log.Info("[mirror] Done", "downloaded", downloaded, "already present", skipped)
+		return nil
+	},
+}
+
 // nolint
 func removePieceCompletionStorage(snapDir string) {
 	_ = os.RemoveAll(filepath.Join(snapDir, "db"))
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 
 	"github.com/ledgerwatch/erigon/cl/cltypes"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/ledgerwatch/erigon/common"
 	"github.com/ledgerwatch/log/v3"
 )
 
@@ -44,3 +46,64 @@ func RetrieveBeaconState(ctx context.Context, uri string) (*state.BeaconState, e
 	}
 	return state.FromBellatrixState(beaconState), nil
 }
+
+// RetrieveBeaconStateFromFiles loads a finalized state and its block from SSZ files on disk.
+// The state is checked against the block and, if given, the block root against a trusted root.
+func RetrieveBeaconStateFromFiles(statePath, blockPath string, trustedRoot *common.Hash) (*state.BeaconState, *cltypes.SignedBeaconBlockBellatrix, error) {
+	log.Info("[Checkpoint Sync] Reading beacon state", "state", statePath, "block", blockPath)
+	encodedState, err := os.ReadFile(statePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("checkpoint sync failed %s", err)
+	}
+	beaconState := &cltypes.BeaconStateBellatrix{}
+	if err := beaconState.UnmarshalSSZ(encodedState); err != nil {
+		return nil, nil, fmt.Errorf("checkpoint sync failed, could not decode state: %s", err)
+	}
+	encodedBlock, err := os.ReadFile(blockPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("checkpoint sync failed %s", err)
+	}
+	signedBlock := &cltypes.SignedBeaconBlockBellatrix{}
+	if err := signedBlock.UnmarshalSSZ(encodedBlock); err != nil {
+		return nil, nil, fmt.Errorf("checkpoint sync failed, could not decode block: %s", err)
+	}
+	s := state.FromBellatrixState(beaconState)
+	if err := VerifyCheckpoint(s, signedBlock, trustedRoot); err != nil {
+		return nil, nil, fmt.Errorf("checkpoint sync failed %s", err)
+	}
+	return s, signedBlock, nil
+}
+
+// VerifyCheckpoint checks that the state is the post state of the block, at the block slot, and that the block root
+// matches the trusted root if one is given.
+func VerifyCheckpoint(s *state.BeaconState, signedBlock *cltypes.SignedBeaconBlockBellatrix, trustedRoot *common.Hash) error {
+	block := signedBlock.Block
+	blockRoot, err := block.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	if trustedRoot != nil && common.Hash(blockRoot) != *trustedRoot {
+		return fmt.Errorf("block root %x does not match trusted root %x", blockRoot, *trustedRoot)
+	}
+	// The state must be the one committed to by the block, states advanced through empty slots cannot be verified.
+	if s.Slot() != block.Slot {
+		return fmt.Errorf("state slot %d does not match block slot %d", s.Slot(), block.Slot)
+	}
+	bodyRoot, err := block.Body.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	header := s.LatestBlockHeader()
+	if header.Slot != block.Slot || header.ProposerIndex != block.ProposerIndex ||
+		header.ParentRoot != block.ParentRoot || header.BodyRoot != bodyRoot {
+		return fmt.Errorf("latest block header of the state does not match block at slot %d", block.Slot)
+	}
+	stateRoot, err := s.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	if stateRoot != block.StateRoot {
+		return fmt.Errorf("state root %x does not match block state root %x", stateRoot, block.StateRoot)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package core_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/rawdb"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/stretchr/testify/require"
+)
+
+// getTestCheckpoint returns the SSZ test block and a state whose latest block header and root match it.
+func getTestCheckpoint(t *testing.T) (*cltypes.BeaconStateBellatrix, *cltypes.SignedBeaconBlockBellatrix) {
+	signedBlock := &cltypes.SignedBeaconBlockBellatrix{}
+	require.NoError(t, signedBlock.UnmarshalSSZ(rawdb.SSZTestBeaconBlock))
+	bodyRoot, err := signedBlock.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	beaconState := &cltypes.BeaconStateBellatrix{
+		Slot:              signedBlock.Block.Slot,
+		BlockRoots:        make([][32]byte, 8192),
+		StateRoots:        make([][32]byte, 8192),
+		RandaoMixes:       make([][32]byte, 65536),
+		Slashings:         make([]uint64, 8192),
+		JustificationBits: make([]byte, 1),
+		CurrentSyncCommittee: &cltypes.SyncCommittee{
+			PubKeys: make([][48]byte, 512),
+		},
+		NextSyncCommittee: &cltypes.SyncCommittee{
+			PubKeys: make([][48]byte, 512),
+		},
+		LatestExecutionPayloadHeader: &cltypes.ExecutionHeader{
+			LogsBloom:     make([]byte, 256),
+			BaseFeePerGas: make([]byte, 32),
+		},
+		LatestBlockHeader: &cltypes.BeaconBlockHeader{
+			Slot:          signedBlock.Block.Slot,
+			ProposerIndex: signedBlock.Block.ProposerIndex,
+			ParentRoot:    signedBlock.Block.ParentRoot,
+			BodyRoot:      bodyRoot,
+		},
+		Fork:                        &cltypes.Fork{},
+		Eth1Data:                    &cltypes.Eth1Data{},
+		PreviousJustifiedCheckpoint: &cltypes.Checkpoint{},
+		CurrentJustifiedCheckpoint:  &cltypes.Checkpoint{},
+		FinalizedCheckpoint:         &cltypes.Checkpoint{},
+	}
+	stateRoot, err := beaconState.HashTreeRoot()
+	require.NoError(t, err)
+	signedBlock.Block.StateRoot = stateRoot
+	return beaconState, signedBlock
+}
+
+func writeSSZFile(t *testing.T, name string, obj cltypes.ObjectSSZ) string {
+	encoded, err := obj.MarshalSSZ()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, encoded, 0600))
+	return path
+}
+
+func TestRetrieveBeaconStateFromFiles(t *testing.T) {
+	beaconState, signedBlock := getTestCheckpoint(t)
+	blockRoot, err := signedBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+	statePath := writeSSZFile(t, "state.ssz", beaconState)
+	blockPath := writeSSZFile(t, "block.ssz", signedBlock)
+
+	s, block, err := core.RetrieveBeaconStateFromFiles(statePath, blockPath, nil)
+	require.NoError(t, err)
+	require.Equal(t, signedBlock.Block.Slot, s.Slot())
+	require.Equal(t, signedBlock.Block.StateRoot, block.Block.StateRoot)
+
+	trustedRoot := common.Hash(blockRoot)
+	_, _, err = core.RetrieveBeaconStateFromFiles(statePath, blockPath, &trustedRoot)
+	require.NoError(t, err)
+
+	wrongRoot := common.HexToHash("0x01")
+	_, _, err = core.RetrieveBeaconStateFromFiles(statePath, blockPath, &wrongRoot)
+	require.Error(t, err)
+
+	_, _, err = core.RetrieveBeaconStateFromFiles(statePath, statePath, nil)
+	require.Error(t, err)
+}
+
+func TestVerifyCheckpoint(t *testing.T) {
+	beaconState, signedBlock := getTestCheckpoint(t)
+	require.NoError(t, core.VerifyCheckpoint(state.FromBellatrixState(beaconState), signedBlock, nil))
+
+	// A state which does not match the block state root.
+	beaconState.Balances = []uint64{1}
+	require.Error(t, core.VerifyCheckpoint(state.FromBellatrixState(beaconState), signedBlock, nil))
+	beaconState.Balances = nil
+
+	// A state advanced through empty slots is refused, even if it records the block state root.
+	beaconState.Slot++
+	beaconState.StateRoots[signedBlock.Block.Slot%clparams.MainnetBeaconConfig.SlotsPerHistoricalRoot] = signedBlock.Block.StateRoot
+	beaconState.LatestBlockHeader.Root = signedBlock.Block.StateRoot
+	require.Error(t, core.VerifyCheckpoint(state.FromBellatrixState(beaconState), signedBlock, nil))
+	beaconState.Slot--
+	beaconState.StateRoots[signedBlock.Block.Slot%clparams.MainnetBeaconConfig.SlotsPerHistoricalRoot] = [32]byte{}
+	beaconState.LatestBlockHeader.Root = [32]byte{}
+	require.NoError(t, core.VerifyCheckpoint(state.FromBellatrixState(beaconState), signedBlock, nil))
+
+	// A state for another block.
+	beaconState.LatestBlockHeader.ProposerIndex++
+	require.Error(t, core.VerifyCheckpoint(state.FromBellatrixState(beaconState), signedBlock, nil))
+}
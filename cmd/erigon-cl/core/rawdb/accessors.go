@@ -79,7 +79,7 @@ func WriteLightClientOptimisticUpdate(tx kv.RwTx, update *cltypes.LightClientOpt
 	return tx.Put(kv.LightClient, kv.LightClientOptimisticUpdate, encoded)
 }
 
-func ReadLightClientUpdate(tx kv.RwTx, period uint32) (*cltypes.LightClientUpdate, error) {
+func ReadLightClientUpdate(tx kv.Tx, period uint32) (*cltypes.LightClientUpdate, error) {
 	key := make([]byte, 4)
 	binary.BigEndian.PutUint32(key, period)
 
@@ -87,6 +87,9 @@ func ReadLightClientUpdate(tx kv.RwTx, period uint32) (*cltypes.LightClientUpdat
 	if err != nil {
 		return nil, err
 	}
+	if len(encoded) == 0 {
+		return nil, nil
+	}
 	update := &cltypes.LightClientUpdate{}
 	if err = update.UnmarshalSSZ(encoded); err != nil {
 		return nil, err
@@ -124,6 +127,30 @@ func ReadLightClientOptimisticUpdate(tx kv.Tx) (*cltypes.LightClientOptimisticUp
 	return update, nil
 }
 
+// WriteLightClientBootstrap stores the bootstrap of a block root, bootstraps are keyed by root in the LightClient bucket.
+func WriteLightClientBootstrap(tx kv.RwTx, blockRoot common.Hash, bootstrap *cltypes.LightClientBootstrap) error {
+	encoded, err := bootstrap.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	return tx.Put(kv.LightClient, blockRoot[:], encoded)
+}
+
+func ReadLightClientBootstrap(tx kv.Tx, blockRoot common.Hash) (*cltypes.LightClientBootstrap, error) {
+	encoded, err := tx.GetOne(kv.LightClient, blockRoot[:])
+	if err != nil {
+		return nil, err
+	}
+	if len(encoded) == 0 {
+		return nil, nil
+	}
+	bootstrap := &cltypes.LightClientBootstrap{}
+	if err = bootstrap.UnmarshalSSZ(encoded); err != nil {
+		return nil, err
+	}
+	return bootstrap, nil
+}
+
 func EncodeSSZ(prefix []byte, object ssz.Marshaler) ([]byte, error) {
 	enc, err := object.MarshalSSZ()
 	if err != nil {
@@ -96,6 +96,10 @@ func (b *BeaconState) FinalizedCheckpoint() *cltypes.Checkpoint {
 	return b.finalizedCheckpoint
 }
 
+func (b *BeaconState) InactivityScores() []uint64 {
+	return b.inactivityScores
+}
+
 func (b *BeaconState) CurrentSyncCommittee() *cltypes.SyncCommittee {
 	return b.currentSyncCommittee
 }
@@ -0,0 +1,137 @@
+package state
+
+import (
+	"math/bits"
+	"sort"
+
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state/state_encoding"
+	"github.com/prysmaticlabs/gohashtree"
+)
+
+// merkleTree caches every layer of a merkleized vector or list so that only the branches of touched leaves are rehashed.
+type merkleTree struct {
+	layers      [][][32]byte // layers[0] holds the leaves, layers[i+1] holds the parents of layers[i].
+	depth       int          // Depth of the full tree, given by the limit of the field.
+	dirtyLeaves map[int]bool
+}
+
+func newMerkleTree(limit uint64) *merkleTree {
+	return &merkleTree{
+		layers:      [][][32]byte{{}},
+		depth:       bits.Len64(limit - 1),
+		dirtyLeaves: map[int]bool{},
+	}
+}
+
+// copy returns an independent copy of the tree, a nil tree stays nil.
+func (m *merkleTree) copy() *merkleTree {
+	if m == nil {
+		return nil
+	}
+	copied := &merkleTree{
+		layers:      make([][][32]byte, len(m.layers)),
+		depth:       m.depth,
+		dirtyLeaves: make(map[int]bool, len(m.dirtyLeaves)),
+	}
+	for i, layer := range m.layers {
+		copied.layers[i] = copySlice(layer)
+	}
+	for idx := range m.dirtyLeaves {
+		copied.dirtyLeaves[idx] = true
+	}
+	return copied
+}
+
+// markLeafDirty schedules the leaf to be recomputed on next root computation, a nil tree is rebuilt from scratch anyway.
+func (m *merkleTree) markLeafDirty(idx int) {
+	if m == nil {
+		return
+	}
+	m.dirtyLeaves[idx] = true
+}
+
+// computeRoot resizes the tree to the given number of leaves, recomputes the dirty leaves with leafFn and rehashes their branches.
+func (m *merkleTree) computeRoot(leavesCount int, leafFn func(idx int) ([32]byte, error)) ([32]byte, error) {
+	leaves := m.layers[0]
+	if leavesCount < len(leaves) {
+		// Shrinking is unusual, just start over.
+		m.layers = [][][32]byte{{}}
+		m.dirtyLeaves = map[int]bool{}
+		leaves = nil
+	}
+	for idx := len(leaves); idx < leavesCount; idx++ {
+		leaves = append(leaves, [32]byte{})
+		m.dirtyLeaves[idx] = true
+	}
+	m.layers[0] = leaves
+
+	dirty := make([]int, 0, len(m.dirtyLeaves))
+	for idx := range m.dirtyLeaves {
+		if idx >= leavesCount {
+			continue
+		}
+		leaf, err := leafFn(idx)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		leaves[idx] = leaf
+		dirty = append(dirty, idx)
+	}
+	m.dirtyLeaves = map[int]bool{}
+	if leavesCount == 0 {
+		return state_encoding.ZeroHashes[m.depth], nil
+	}
+	sort.Ints(dirty)
+
+	level := 0
+	for ; len(m.layers[level]) > 1; level++ {
+		layer := m.layers[level]
+		parentsCount := (len(layer) + 1) / 2
+		if len(m.layers) == level+1 {
+			m.layers = append(m.layers, nil)
+		}
+		parents := m.layers[level+1]
+		for len(parents) < parentsCount {
+			parents = append(parents, [32]byte{})
+		}
+		parents = parents[:parentsCount]
+		m.layers[level+1] = parents
+
+		// Rehash the whole layer at once if most of it changed.
+		if len(dirty)*4 > len(layer) {
+			if len(layer)%2 == 1 {
+				layer = append(layer[:len(layer):len(layer)], state_encoding.ZeroHashes[level])
+			}
+			if err := gohashtree.Hash(parents, layer); err != nil {
+				return [32]byte{}, err
+			}
+			dirty = dirty[:0]
+			for idx := range parents {
+				dirty = append(dirty, idx)
+			}
+			continue
+		}
+		nextDirty := dirty[:0]
+		for _, idx := range dirty {
+			parentIdx := idx / 2
+			if len(nextDirty) > 0 && nextDirty[len(nextDirty)-1] == parentIdx {
+				continue
+			}
+			right := state_encoding.ZeroHashes[level]
+			if 2*parentIdx+1 < len(layer) {
+				right = layer[2*parentIdx+1]
+			}
+			parents[parentIdx] = utils.Keccak256(layer[2*parentIdx][:], right[:])
+			nextDirty = append(nextDirty, parentIdx)
+		}
+		dirty = nextDirty
+	}
+	m.layers = m.layers[:level+1]
+
+	root := m.layers[level][0]
+	for ; level < m.depth; level++ {
+		root = utils.Keccak256(root[:], state_encoding.ZeroHashes[level][:])
+	}
+	return root, nil
+}
@@ -0,0 +1,53 @@
+package state
+
+import (
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state/state_encoding"
+)
+
+// stateTreeDepth is the depth of the state merkle tree (25 leaves padded to 32).
+const stateTreeDepth = 5
+
+// GetLeafBranch computes the merkle branch of a state leaf against the state root, ordered from the bottom of the tree.
+func (b *BeaconState) GetLeafBranch(idx StateLeafIndex) ([][]byte, error) {
+	if err := b.computeDirtyLeaves(); err != nil {
+		return nil, err
+	}
+	currentLayer := make([][32]byte, 1<<stateTreeDepth)
+	copy(currentLayer, b.leaves)
+
+	branch := make([][]byte, 0, stateTreeDepth)
+	position := int(idx)
+	for len(currentLayer) > 1 {
+		sibling := currentLayer[position^1]
+		branch = append(branch, sibling[:])
+		nextLayer := make([][32]byte, len(currentLayer)/2)
+		for i := range nextLayer {
+			nextLayer[i] = utils.Keccak256(currentLayer[2*i][:], currentLayer[2*i+1][:])
+		}
+		currentLayer = nextLayer
+		position /= 2
+	}
+	return branch, nil
+}
+
+// CurrentSyncCommitteeBranch returns the merkle branch of the current sync committee, as used in light client bootstraps.
+func (b *BeaconState) CurrentSyncCommitteeBranch() ([][]byte, error) {
+	return b.GetLeafBranch(CurrentSyncCommitteeLeafIndex)
+}
+
+// NextSyncCommitteeBranch returns the merkle branch of the next sync committee, as used in light client updates.
+func (b *BeaconState) NextSyncCommitteeBranch() ([][]byte, error) {
+	return b.GetLeafBranch(NextSyncCommitteeLeafIndex)
+}
+
+// FinalityBranch returns the merkle branch of the finalized checkpoint root, as used in light client updates.
+func (b *BeaconState) FinalityBranch() ([][]byte, error) {
+	branch, err := b.GetLeafBranch(FinalizedCheckpointLeafIndex)
+	if err != nil {
+		return nil, err
+	}
+	// The checkpoint root sits next to the checkpoint epoch inside the checkpoint container.
+	epochLeaf := state_encoding.Uint64Root(b.finalizedCheckpoint.Epoch)
+	return append([][]byte{epochLeaf[:]}, branch...), nil
+}
@@ -0,0 +1,36 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateBranches(t *testing.T) {
+	base := getTestBeaconState()
+	base.FinalizedCheckpoint = &cltypes.Checkpoint{Epoch: 7, Root: [32]byte{1}}
+	base.NextSyncCommittee.AggregatePublicKey[0] = 2
+	s := state.FromBellatrixState(base)
+	root, err := s.HashTreeRoot()
+	require.NoError(t, err)
+
+	currentSyncCommitteeRoot, err := s.CurrentSyncCommittee().HashTreeRoot()
+	require.NoError(t, err)
+	branch, err := s.CurrentSyncCommitteeBranch()
+	require.NoError(t, err)
+	require.True(t, utils.IsValidMerkleBranch(currentSyncCommitteeRoot, branch, 5, uint64(state.CurrentSyncCommitteeLeafIndex), root))
+
+	nextSyncCommitteeRoot, err := s.NextSyncCommittee().HashTreeRoot()
+	require.NoError(t, err)
+	branch, err = s.NextSyncCommitteeBranch()
+	require.NoError(t, err)
+	require.True(t, utils.IsValidMerkleBranch(nextSyncCommitteeRoot, branch, 5, uint64(state.NextSyncCommitteeLeafIndex), root))
+	require.False(t, utils.IsValidMerkleBranch(currentSyncCommitteeRoot, branch, 5, uint64(state.NextSyncCommitteeLeafIndex), root))
+
+	branch, err = s.FinalityBranch()
+	require.NoError(t, err)
+	require.True(t, utils.IsValidMerkleBranch(s.FinalizedCheckpoint().Root, branch, 6, 41, root))
+}
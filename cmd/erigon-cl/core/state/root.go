@@ -1,6 +1,7 @@
 package state
 
 import (
+	"github.com/ledgerwatch/erigon/cl/utils"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state/state_encoding"
 	"github.com/ledgerwatch/erigon/common"
 )
@@ -57,7 +58,12 @@ func (b *BeaconState) computeDirtyLeaves() error {
 
 	// Field(5): BlockRoots
 	if b.isLeafDirty(BlockRootsLeafIndex) {
-		blockRootsRoot, err := state_encoding.ArraysRoot(b.blockRoots, state_encoding.BlockRootsLength)
+		if b.blockRootsTree == nil {
+			b.blockRootsTree = newMerkleTree(state_encoding.BlockRootsLength)
+		}
+		blockRootsRoot, err := b.blockRootsTree.computeRoot(len(b.blockRoots), func(idx int) ([32]byte, error) {
+			return b.blockRoots[idx], nil
+		})
 		if err != nil {
 			return err
 		}
@@ -66,7 +72,12 @@ func (b *BeaconState) computeDirtyLeaves() error {
 
 	// Field(6): StateRoots
 	if b.isLeafDirty(StateRootsLeafIndex) {
-		stateRootsRoot, err := state_encoding.ArraysRoot(b.stateRoots, state_encoding.StateRootsLength)
+		if b.stateRootsTree == nil {
+			b.stateRootsTree = newMerkleTree(state_encoding.StateRootsLength)
+		}
+		stateRootsRoot, err := b.stateRootsTree.computeRoot(len(b.stateRoots), func(idx int) ([32]byte, error) {
+			return b.stateRoots[idx], nil
+		})
 		if err != nil {
 			return err
 		}
@@ -107,25 +118,44 @@ func (b *BeaconState) computeDirtyLeaves() error {
 
 	// Field(11): Validators
 	if b.isLeafDirty(ValidatorsLeafIndex) {
-		vRoot, err := state_encoding.ValidatorsVectorRoot(b.validators)
+		if b.validatorsTree == nil {
+			b.validatorsTree = newMerkleTree(state_encoding.ValidatorRegistryLimit)
+		}
+		vRoot, err := b.validatorsTree.computeRoot(len(b.validators), func(idx int) ([32]byte, error) {
+			return b.validators[idx].HashTreeRoot()
+		})
 		if err != nil {
 			return err
 		}
-		b.updateLeaf(ValidatorsLeafIndex, vRoot)
+		b.updateLeaf(ValidatorsLeafIndex, mixInLength(vRoot, len(b.validators)))
 	}
 
 	// Field(12): Balances
 	if b.isLeafDirty(BalancesLeafIndex) {
-		balancesRoot, err := state_encoding.Uint64ListRootWithLimit(b.balances, state_encoding.ValidatorLimitForBalancesChunks())
+		if b.balancesTree == nil {
+			b.balancesTree = newMerkleTree(state_encoding.ValidatorLimitForBalancesChunks())
+		}
+		balancesRoot, err := b.balancesTree.computeRoot((len(b.balances)+3)/4, func(idx int) ([32]byte, error) {
+			end := idx*4 + 4
+			if end > len(b.balances) {
+				end = len(b.balances)
+			}
+			return state_encoding.PackUint64IntoChunks(b.balances[idx*4 : end])[0], nil
+		})
 		if err != nil {
 			return err
 		}
-		b.updateLeaf(BalancesLeafIndex, balancesRoot)
+		b.updateLeaf(BalancesLeafIndex, mixInLength(balancesRoot, len(b.balances)))
 	}
 
 	// Field(13): RandaoMixes
 	if b.isLeafDirty(RandaoMixesLeafIndex) {
-		randaoRootsRoot, err := state_encoding.ArraysRoot(b.randaoMixes, state_encoding.RandaoMixesLength)
+		if b.randaoMixesTree == nil {
+			b.randaoMixesTree = newMerkleTree(state_encoding.RandaoMixesLength)
+		}
+		randaoRootsRoot, err := b.randaoMixesTree.computeRoot(len(b.randaoMixes), func(idx int) ([32]byte, error) {
+			return b.randaoMixes[idx], nil
+		})
 		if err != nil {
 			return err
 		}
@@ -242,3 +272,9 @@ func (b *BeaconState) isLeafDirty(idx StateLeafIndex) bool {
 	touched, isInitialized := b.touchedLeaves[idx]
 	return !isInitialized || touched // change only if the leaf was touched or root is non-initialized.
 }
+
+// mixInLength computes the root of a list from the root of its elements.
+func mixInLength(root [32]byte, length int) [32]byte {
+	lengthRoot := state_encoding.Uint64Root(uint64(length))
+	return utils.Keccak256(root[:], lengthRoot[:])
+}
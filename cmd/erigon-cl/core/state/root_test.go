@@ -1,12 +1,16 @@
 package state_test
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/ledgerwatch/erigon/cl/cltypes"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/stretchr/testify/require"
 )
 
+const benchmarkValidatorsCount = 1 << 17
+
 func getTestBeaconState() *cltypes.BeaconStateBellatrix {
 	return &cltypes.BeaconStateBellatrix{
 		BlockRoots:        make([][32]byte, 8192),
@@ -35,6 +39,79 @@ func getTestBeaconState() *cltypes.BeaconStateBellatrix {
 	}
 }
 
+func getTestBeaconStateWithValidators(count int) *cltypes.BeaconStateBellatrix {
+	base := getTestBeaconState()
+	base.Validators = make([]*cltypes.Validator, count)
+	base.Balances = make([]uint64, count)
+	for i := range base.Validators {
+		base.Validators[i] = getTestValidator(i)
+		base.Balances[i] = uint64(i)
+	}
+	return base
+}
+
+func getTestValidator(i int) *cltypes.Validator {
+	validator := &cltypes.Validator{
+		WithdrawalCredentials: make([]byte, 32),
+		EffectiveBalance:      uint64(i),
+		ExitEpoch:             uint64(i) * 2,
+	}
+	validator.PublicKey[0] = byte(i)
+	validator.PublicKey[1] = byte(i >> 8)
+	return validator
+}
+
+func randomRoot(r *rand.Rand) (root [32]byte) {
+	r.Read(root[:])
+	return
+}
+
+// requireSameRoot checks the cached state root against a full recomputation of the SSZ object.
+func requireSameRoot(t *testing.T, s *state.BeaconState) {
+	expected, err := s.GetStateSSZObject().HashTreeRoot()
+	require.NoError(t, err)
+	actual, err := s.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
+
+func TestStateRootIncremental(t *testing.T) {
+	s := state.FromBellatrixState(getTestBeaconStateWithValidators(1000))
+	requireSameRoot(t, s)
+	r := rand.New(rand.NewSource(0))
+	for round := 0; round < 20; round++ {
+		for i := 0; i < 10; i++ {
+			idx := r.Intn(len(s.Validators()))
+			s.SetBalanceAt(idx, r.Uint64())
+			validator := *s.ValidatorAt(idx)
+			validator.EffectiveBalance = r.Uint64()
+			s.SetValidatorAt(idx, &validator)
+			s.SetBlockRootAt(r.Intn(len(s.BlockRoots())), randomRoot(r))
+			s.SetStateRootAt(r.Intn(len(s.StateRoots())), randomRoot(r))
+			s.SetRandaoMixAt(r.Intn(len(s.RandaoMixes())), randomRoot(r))
+		}
+		s.AddValidator(getTestValidator(len(s.Validators())))
+		s.AddBalance(r.Uint64())
+		requireSameRoot(t, s)
+	}
+}
+
+func TestStateRootReplacedFields(t *testing.T) {
+	s := state.FromBellatrixState(getTestBeaconStateWithValidators(100))
+	requireSameRoot(t, s)
+	// Shrinking lists and replacing vectors drops their caches.
+	s.SetValidators(getTestBeaconStateWithValidators(7).Validators)
+	s.SetBalances([]uint64{1, 2, 3, 4, 5, 6, 7})
+	s.SetRandaoMixes(make([][32]byte, 65536))
+	requireSameRoot(t, s)
+	s.SetValidators(nil)
+	s.SetBalances(nil)
+	requireSameRoot(t, s)
+	s.AddValidator(getTestValidator(1))
+	s.AddBalance(1)
+	requireSameRoot(t, s)
+}
+
 // Prev: 151849172
 // Curr: 5463452
 func BenchmarkStateRootNonCached(b *testing.B) {
@@ -54,3 +131,31 @@ func BenchmarkStateRootCached(b *testing.B) {
 		state.HashTreeRoot()
 	}
 }
+
+// BenchmarkStateRootFullRecomputation hashes a state with a large validator set from scratch.
+func BenchmarkStateRootFullRecomputation(b *testing.B) {
+	base := getTestBeaconStateWithValidators(benchmarkValidatorsCount)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		state.FromBellatrixState(base).HashTreeRoot()
+	}
+}
+
+// BenchmarkStateRootIncremental touches a few validators, balances and roots before every hash, as slot processing does.
+func BenchmarkStateRootIncremental(b *testing.B) {
+	s := state.FromBellatrixState(getTestBeaconStateWithValidators(benchmarkValidatorsCount))
+	s.HashTreeRoot()
+	r := rand.New(rand.NewSource(0))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 64; j++ {
+			idx := r.Intn(benchmarkValidatorsCount)
+			s.SetBalanceAt(idx, r.Uint64())
+			s.SetValidatorAt(idx, getTestValidator(idx))
+		}
+		s.SetBlockRootAt(i%len(s.BlockRoots()), randomRoot(r))
+		s.SetStateRootAt(i%len(s.StateRoots()), randomRoot(r))
+		s.SetRandaoMixAt(i%len(s.RandaoMixes()), randomRoot(r))
+		s.HashTreeRoot()
+	}
+}
@@ -34,11 +34,13 @@ func (b *BeaconState) SetLatestBlockHeader(header *cltypes.BeaconBlockHeader) {
 
 func (b *BeaconState) SetBlockRoots(blockRoots [][32]byte) {
 	b.touchedLeaves[BlockRootsLeafIndex] = true
+	b.blockRootsTree = nil
 	b.blockRoots = blockRoots
 }
 
 func (b *BeaconState) SetStateRoots(stateRoots [][32]byte) {
 	b.touchedLeaves[StateRootsLeafIndex] = true
+	b.stateRootsTree = nil
 	b.stateRoots = stateRoots
 }
 
@@ -49,11 +51,13 @@ func (b *BeaconState) SetHistoricalRoots(historicalRoots [][32]byte) {
 
 func (b *BeaconState) SetBlockRootAt(index int, root [32]byte) {
 	b.touchedLeaves[BlockRootsLeafIndex] = true
+	b.blockRootsTree.markLeafDirty(index)
 	b.blockRoots[index] = root
 }
 
 func (b *BeaconState) SetStateRootAt(index int, root [32]byte) {
 	b.touchedLeaves[StateRootsLeafIndex] = true
+	b.stateRootsTree.markLeafDirty(index)
 	b.stateRoots[index] = root
 }
 
@@ -62,6 +66,11 @@ func (b *BeaconState) SetHistoricalRootAt(index int, root [32]byte) {
 	b.historicalRoots[index] = root
 }
 
+func (b *BeaconState) AddHistoricalRoot(root [32]byte) {
+	b.touchedLeaves[HistoricalRootsLeafIndex] = true
+	b.historicalRoots = append(b.historicalRoots, root)
+}
+
 func (b *BeaconState) SetEth1Data(eth1Data *cltypes.Eth1Data) {
 	b.touchedLeaves[Eth1DataLeafIndex] = true
 	b.eth1Data = eth1Data
@@ -72,6 +81,11 @@ func (b *BeaconState) SetEth1DataVotes(eth1DataVotes []*cltypes.Eth1Data) {
 	b.eth1DataVotes = eth1DataVotes
 }
 
+func (b *BeaconState) AddEth1DataVote(vote *cltypes.Eth1Data) {
+	b.touchedLeaves[Eth1DataVotesLeafIndex] = true
+	b.eth1DataVotes = append(b.eth1DataVotes, vote)
+}
+
 func (b *BeaconState) SetEth1DepositIndex(eth1DepositIndex uint64) {
 	b.touchedLeaves[Eth1DepositIndexLeafIndex] = true
 	b.eth1DepositIndex = eth1DepositIndex
@@ -79,24 +93,63 @@ func (b *BeaconState) SetEth1DepositIndex(eth1DepositIndex uint64) {
 
 func (b *BeaconState) SetValidators(validators []*cltypes.Validator) {
 	b.touchedLeaves[ValidatorsLeafIndex] = true
+	b.validatorsTree = nil
 	b.validators = validators
 }
 
+func (b *BeaconState) SetValidatorAt(index int, validator *cltypes.Validator) {
+	b.touchedLeaves[ValidatorsLeafIndex] = true
+	b.validatorsTree.markLeafDirty(index)
+	b.validators[index] = validator
+}
+
+func (b *BeaconState) AddValidator(validator *cltypes.Validator) {
+	b.touchedLeaves[ValidatorsLeafIndex] = true
+	b.validators = append(b.validators, validator)
+}
+
 func (b *BeaconState) SetBalances(balances []uint64) {
 	b.touchedLeaves[BalancesLeafIndex] = true
+	b.balancesTree = nil
 	b.balances = balances
 }
 
+func (b *BeaconState) SetBalanceAt(index int, balance uint64) {
+	b.touchedLeaves[BalancesLeafIndex] = true
+	// Balances are packed 4 per leaf.
+	b.balancesTree.markLeafDirty(index / 4)
+	b.balances[index] = balance
+}
+
+func (b *BeaconState) AddBalance(balance uint64) {
+	b.touchedLeaves[BalancesLeafIndex] = true
+	// The last leaf may be only partially filled.
+	b.balancesTree.markLeafDirty(len(b.balances) / 4)
+	b.balances = append(b.balances, balance)
+}
+
 func (b *BeaconState) SetRandaoMixes(randaoMixes [][32]byte) {
 	b.touchedLeaves[RandaoMixesLeafIndex] = true
+	b.randaoMixesTree = nil
 	b.randaoMixes = randaoMixes
 }
 
+func (b *BeaconState) SetRandaoMixAt(index int, mix [32]byte) {
+	b.touchedLeaves[RandaoMixesLeafIndex] = true
+	b.randaoMixesTree.markLeafDirty(index)
+	b.randaoMixes[index] = mix
+}
+
 func (b *BeaconState) SetSlashings(slashings []uint64) {
 	b.touchedLeaves[SlashingsLeafIndex] = true
 	b.slashings = slashings
 }
 
+func (b *BeaconState) SetSlashingAt(index int, slashing uint64) {
+	b.touchedLeaves[SlashingsLeafIndex] = true
+	b.slashings[index] = slashing
+}
+
 func (b *BeaconState) SetPreviousEpochParticipation(previousEpochParticipation []byte) {
 	b.touchedLeaves[PreviousEpochParticipationLeafIndex] = true
 	b.previousEpochParticipation = previousEpochParticipation
@@ -127,6 +180,16 @@ func (b *BeaconState) SetFinalizedCheckpoint(finalizedCheckpoint *cltypes.Checkp
 	b.finalizedCheckpoint = finalizedCheckpoint
 }
 
+func (b *BeaconState) SetInactivityScores(inactivityScores []uint64) {
+	b.touchedLeaves[InactivityScoresLeafIndex] = true
+	b.inactivityScores = inactivityScores
+}
+
+func (b *BeaconState) SetInactivityScoreAt(index int, score uint64) {
+	b.touchedLeaves[InactivityScoresLeafIndex] = true
+	b.inactivityScores[index] = score
+}
+
 func (b *BeaconState) SetCurrentSyncCommittee(currentSyncCommittee *cltypes.SyncCommittee) {
 	b.touchedLeaves[CurrentSyncCommitteeLeafIndex] = true
 	b.currentSyncCommittee = currentSyncCommittee
@@ -39,6 +39,12 @@ type BeaconState struct {
 	version       clparams.StateVersion   // State version
 	leaves        [][32]byte              // Pre-computed leaves.
 	touchedLeaves map[StateLeafIndex]bool // Maps each leaf to whether they were touched or not.
+	// Merkle caches of the biggest fields, nil caches are rebuilt from scratch.
+	blockRootsTree  *merkleTree
+	stateRootsTree  *merkleTree
+	validatorsTree  *merkleTree
+	balancesTree    *merkleTree
+	randaoMixesTree *merkleTree
 }
 
 // FromBellatrixState initialize the beacon state as a bellatrix state.
@@ -74,7 +80,6 @@ func FromBellatrixState(state *cltypes.BeaconStateBellatrix) *BeaconState {
 		version:       clparams.BellatrixVersion,
 		leaves:        make([][32]byte, BellatrixLeavesSize),
 		touchedLeaves: map[StateLeafIndex]bool{},
-		// TODO: Make proper hasher
 	}
 }
 
@@ -98,6 +103,73 @@ func (b *BeaconState) UnmarshalSSZ(buf []byte) error {
 	panic("beacon state should be derived, use FromBellatrixState instead.")
 }
 
+// Copy returns a deep copy of the state, merkle caches included so that the copy only rehashes what changes afterwards.
+func (b *BeaconState) Copy() (*BeaconState, error) {
+	copied := &BeaconState{
+		genesisTime:                  b.genesisTime,
+		genesisValidatorsRoot:        b.genesisValidatorsRoot,
+		slot:                         b.slot,
+		fork:                         copyStruct(b.fork),
+		latestBlockHeader:            copyStruct(b.latestBlockHeader),
+		blockRoots:                   copySlice(b.blockRoots),
+		stateRoots:                   copySlice(b.stateRoots),
+		historicalRoots:              copySlice(b.historicalRoots),
+		eth1Data:                     copyStruct(b.eth1Data),
+		eth1DataVotes:                make([]*cltypes.Eth1Data, len(b.eth1DataVotes)),
+		eth1DepositIndex:             b.eth1DepositIndex,
+		validators:                   make([]*cltypes.Validator, len(b.validators)),
+		balances:                     copySlice(b.balances),
+		randaoMixes:                  copySlice(b.randaoMixes),
+		slashings:                    copySlice(b.slashings),
+		previousEpochParticipation:   copySlice(b.previousEpochParticipation),
+		currentEpochParticipation:    copySlice(b.currentEpochParticipation),
+		justificationBits:            copySlice(b.justificationBits),
+		previousJustifiedCheckpoint:  copyStruct(b.previousJustifiedCheckpoint),
+		currentJustifiedCheckpoint:   copyStruct(b.currentJustifiedCheckpoint),
+		finalizedCheckpoint:          copyStruct(b.finalizedCheckpoint),
+		inactivityScores:             copySlice(b.inactivityScores),
+		currentSyncCommittee:         copyStruct(b.currentSyncCommittee),
+		nextSyncCommittee:            copyStruct(b.nextSyncCommittee),
+		latestExecutionPayloadHeader: copyStruct(b.latestExecutionPayloadHeader),
+		version:                      b.version,
+		leaves:                       copySlice(b.leaves),
+		touchedLeaves:                make(map[StateLeafIndex]bool, len(b.touchedLeaves)),
+		blockRootsTree:               b.blockRootsTree.copy(),
+		stateRootsTree:               b.stateRootsTree.copy(),
+		validatorsTree:               b.validatorsTree.copy(),
+		balancesTree:                 b.balancesTree.copy(),
+		randaoMixesTree:              b.randaoMixesTree.copy(),
+	}
+	for i, vote := range b.eth1DataVotes {
+		copied.eth1DataVotes[i] = copyStruct(vote)
+	}
+	for i, validator := range b.validators {
+		copied.validators[i] = copyStruct(validator)
+	}
+	for idx, touched := range b.touchedLeaves {
+		copied.touchedLeaves[idx] = touched
+	}
+	return copied, nil
+}
+
+// copyStruct returns a shallow copy of the pointed value, the byte slices of our types are never modified in place.
+func copyStruct[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	copied := *v
+	return &copied
+}
+
+func copySlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	copied := make([]T, len(s))
+	copy(copied, s)
+	return copied
+}
+
 // BlockRoot computes the block root for the state.
 func (b *BeaconState) BlockRoot() ([32]byte, error) {
 	stateRoot, err := b.HashTreeRoot()
@@ -0,0 +1,36 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopy(t *testing.T) {
+	original := state.FromBellatrixState(getTestBeaconState())
+	original.SetValidators([]*cltypes.Validator{{WithdrawalCredentials: make([]byte, 32)}})
+	original.SetCurrentEpochParticipation([]byte{1})
+	originalRoot, err := original.HashTreeRoot()
+	require.NoError(t, err)
+
+	copied, err := original.Copy()
+	require.NoError(t, err)
+	copiedRoot, err := copied.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, originalRoot, copiedRoot)
+
+	// Changes to the copy do not leak into the original.
+	copied.SetSlot(1)
+	copied.ValidatorAt(0).Slashed = true
+	copied.CurrentEpochParticipation()[0] = 2
+	copied.LatestBlockHeader().Slot = 1
+	require.Equal(t, uint64(0), original.Slot())
+	require.False(t, original.ValidatorAt(0).Slashed)
+	require.Equal(t, []byte{1}, original.CurrentEpochParticipation())
+	require.Equal(t, uint64(0), original.LatestBlockHeader().Slot)
+	root, err := original.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, originalRoot, root)
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
+	"sort"
 
 	"github.com/Giulio2002/bls"
 	"github.com/ledgerwatch/erigon/cl/clparams"
@@ -106,11 +107,15 @@ func GetSeed(state *state.BeaconState, epoch uint64, domain [4]byte) []byte {
 	return hash.Sum(nil)
 }
 
+// IsActiveValidator tells whether the validator is active at the given epoch.
+func IsActiveValidator(validator *cltypes.Validator, epoch uint64) bool {
+	return validator.ActivationEpoch <= epoch && epoch < validator.ExitEpoch
+}
+
 func GetActiveValidatorIndices(state *state.BeaconState, epoch uint64) []uint64 {
 	indices := []uint64{}
 	for i := 0; i < len(state.Validators()); i++ {
-		v := state.ValidatorAt(i)
-		if v.ActivationEpoch <= epoch && epoch < v.ExitEpoch {
+		if IsActiveValidator(state.ValidatorAt(i), epoch) {
 			indices = append(indices, uint64(i))
 		}
 	}
@@ -121,10 +126,8 @@ func GetEpochAtSlot(slot uint64) uint64 {
 	return slot / SLOTS_PER_EPOCH
 }
 
+// GetDomain returns the signature domain of the given type at the given epoch.
 func GetDomain(state *state.BeaconState, domainType [4]byte, epoch uint64) ([]byte, error) {
-	if epoch == 0 {
-		epoch = GetEpochAtSlot(state.Slot())
-	}
 	var forkVersion [4]byte
 	if epoch < state.Fork().Epoch {
 		forkVersion = state.Fork().PreviousVersion
@@ -134,25 +137,45 @@ func GetDomain(state *state.BeaconState, domainType [4]byte, epoch uint64) ([]by
 	return fork.ComputeDomain(domainType[:], forkVersion, state.GenesisValidatorsRoot())
 }
 
-func ComputeSigningRootEpoch(epoch uint64, domain []byte) ([32]byte, error) {
-	b := make([]byte, 32)
-	binary.LittleEndian.PutUint64(b, epoch)
-	hash := utils.Keccak256(b)
+// ComputeSigningRoot returns the root signed for an object under the given domain.
+func ComputeSigningRoot(obj interface{ HashTreeRoot() ([32]byte, error) }, domain []byte) ([32]byte, error) {
+	objRoot, err := obj.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return ComputeSigningRootFromRoot(objRoot, domain)
+}
+
+// ComputeSigningRootFromRoot returns the root signed for an object root under the given domain.
+func ComputeSigningRootFromRoot(objRoot [32]byte, domain []byte) ([32]byte, error) {
 	sd := &cltypes.SigningData{
-		Root:   hash,
+		Root:   objRoot,
 		Domain: domain,
 	}
 	return sd.HashTreeRoot()
 }
 
+// ComputeSigningRootEpoch returns the root signed for an epoch, as in randao reveals.
+func ComputeSigningRootEpoch(epoch uint64, domain []byte) ([32]byte, error) {
+	var epochRoot [32]byte
+	binary.LittleEndian.PutUint64(epochRoot[:], epoch)
+	return ComputeSigningRootFromRoot(epochRoot, domain)
+}
+
 func GetBeaconProposerIndex(state *state.BeaconState) (uint64, error) {
-	epoch := GetEpochAtSlot(state.Slot())
+	return GetBeaconProposerIndexAtSlot(state, state.Slot())
+}
+
+// GetBeaconProposerIndexAtSlot computes the proposer of a slot using the shuffling of the given state.
+// The result is only meaningful for slots whose seed is already determined by the state.
+func GetBeaconProposerIndexAtSlot(state *state.BeaconState, slot uint64) (uint64, error) {
+	epoch := GetEpochAtSlot(slot)
 
 	hash := sha256.New()
 	// Input for the seed hash.
 	input := GetSeed(state, epoch, clparams.MainnetBeaconConfig.DomainBeaconProposer)
 	slotByteArray := make([]byte, 8)
-	binary.LittleEndian.PutUint64(slotByteArray, state.Slot())
+	binary.LittleEndian.PutUint64(slotByteArray, slot)
 
 	// Add slot to the end of the input.
 	inputWithSlot := append(input, slotByteArray...)
@@ -170,6 +193,174 @@ func GetBeaconProposerIndex(state *state.BeaconState) (uint64, error) {
 	return ComputeProposerIndex(state, indices, seedArray)
 }
 
+// GetCommitteeCountPerSlot returns the number of beacon committees per slot at the given epoch.
+func GetCommitteeCountPerSlot(state *state.BeaconState, epoch uint64) uint64 {
+	committeesPerSlot := uint64(len(GetActiveValidatorIndices(state, epoch))) / SLOTS_PER_EPOCH / clparams.MainnetBeaconConfig.TargetCommitteeSize
+	if committeesPerSlot > clparams.MainnetBeaconConfig.MaxCommitteesPerSlot {
+		return clparams.MainnetBeaconConfig.MaxCommitteesPerSlot
+	}
+	if committeesPerSlot == 0 {
+		return 1
+	}
+	return committeesPerSlot
+}
+
+// ComputeCommittee returns the index-th out of count committees from the shuffled indices.
+func ComputeCommittee(indices []uint64, seed [32]byte, index, count uint64) ([]uint64, error) {
+	total := uint64(len(indices))
+	start := (total * index) / count
+	end := (total * (index + 1)) / count
+	committee := make([]uint64, 0, end-start)
+	for i := start; i < end; i++ {
+		shuffled, err := ComputeShuffledIndex(i, total, seed)
+		if err != nil {
+			return nil, err
+		}
+		committee = append(committee, indices[shuffled])
+	}
+	return committee, nil
+}
+
+// GetBeaconCommittee returns the committee assigned to the given slot and committee index.
+func GetBeaconCommittee(state *state.BeaconState, slot, index uint64) ([]uint64, error) {
+	epoch := GetEpochAtSlot(slot)
+	committeesPerSlot := GetCommitteeCountPerSlot(state, epoch)
+	seedArray := [32]byte{}
+	copy(seedArray[:], GetSeed(state, epoch, clparams.MainnetBeaconConfig.DomainBeaconAttester))
+	return ComputeCommittee(
+		GetActiveValidatorIndices(state, epoch),
+		seedArray,
+		(slot%SLOTS_PER_EPOCH)*committeesPerSlot+index,
+		committeesPerSlot*SLOTS_PER_EPOCH,
+	)
+}
+
+// GetAttestingIndices returns the validator indices whose bits are set in the aggregation bitlist.
+// The bitlist must have exactly one bit per committee member.
+func GetAttestingIndices(state *state.BeaconState, data *cltypes.AttestationData, aggregationBits []byte) ([]uint64, error) {
+	committee, err := GetBeaconCommittee(state, data.Slot, data.Index)
+	if err != nil {
+		return nil, err
+	}
+	if length, ok := utils.GetBitlistLength(aggregationBits); !ok || length != len(committee) {
+		return nil, fmt.Errorf("aggregation bits length: %d, does not match committee of size: %d", length, len(committee))
+	}
+	attestingIndices := []uint64{}
+	for i, index := range committee {
+		if (aggregationBits[i/8]>>(i%8))&1 == 1 {
+			attestingIndices = append(attestingIndices, index)
+		}
+	}
+	return attestingIndices, nil
+}
+
+// GetIndexedAttestation returns the attestation with its attesting indices resolved and sorted.
+func GetIndexedAttestation(state *state.BeaconState, attestation *cltypes.Attestation) (*cltypes.IndexedAttestation, error) {
+	attestingIndices, err := GetAttestingIndices(state, attestation.Data, attestation.AggregationBits)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(attestingIndices, func(i, j int) bool {
+		return attestingIndices[i] < attestingIndices[j]
+	})
+	return &cltypes.IndexedAttestation{
+		AttestingIndices: attestingIndices,
+		Data:             attestation.Data,
+		Signature:        attestation.Signature,
+	}, nil
+}
+
+// IsValidIndexedAttestation checks that the attesting indices are sorted and unique, and verifies the aggregate signature.
+func IsValidIndexedAttestation(state *state.BeaconState, attestation *cltypes.IndexedAttestation) (bool, error) {
+	indices := attestation.AttestingIndices
+	if len(indices) == 0 {
+		return false, nil
+	}
+	pubKeys := make([][]byte, 0, len(indices))
+	for i, index := range indices {
+		if i > 0 && index <= indices[i-1] {
+			return false, nil
+		}
+		if index >= uint64(len(state.Validators())) {
+			return false, nil
+		}
+		pubKey := state.ValidatorAt(int(index)).PublicKey
+		pubKeys = append(pubKeys, pubKey[:])
+	}
+	domain, err := GetDomain(state, clparams.MainnetBeaconConfig.DomainBeaconAttester, attestation.Data.Target.Epoch)
+	if err != nil {
+		return false, err
+	}
+	signingRoot, err := ComputeSigningRoot(attestation.Data, domain)
+	if err != nil {
+		return false, err
+	}
+	return bls.VerifyAggregate(attestation.Signature[:], signingRoot[:], pubKeys)
+}
+
+// GetPreviousEpoch returns the epoch before the state epoch, or the genesis epoch.
+func GetPreviousEpoch(state *state.BeaconState) uint64 {
+	currentEpoch := GetEpochAtSlot(state.Slot())
+	if currentEpoch == clparams.MainnetBeaconConfig.GenesisEpoch {
+		return currentEpoch
+	}
+	return currentEpoch - 1
+}
+
+// GetBlockRootAtSlot returns the root of the latest block at the given slot, it must be in the recent past of the state.
+func GetBlockRootAtSlot(state *state.BeaconState, slot uint64) ([32]byte, error) {
+	if slot >= state.Slot() || state.Slot() > slot+clparams.MainnetBeaconConfig.SlotsPerHistoricalRoot {
+		return [32]byte{}, fmt.Errorf("block root of slot: %d, is not available at state slot: %d", slot, state.Slot())
+	}
+	return state.BlockRoots()[slot%clparams.MainnetBeaconConfig.SlotsPerHistoricalRoot], nil
+}
+
+// GetBlockRoot returns the root of the latest block at the start slot of the given epoch.
+func GetBlockRoot(state *state.BeaconState, epoch uint64) ([32]byte, error) {
+	return GetBlockRootAtSlot(state, epoch*SLOTS_PER_EPOCH)
+}
+
+// GetTotalBalance returns the sum of the effective balances of the given validators, at least one increment.
+func GetTotalBalance(state *state.BeaconState, indices []uint64) uint64 {
+	total := uint64(0)
+	for _, index := range indices {
+		total += state.ValidatorAt(int(index)).EffectiveBalance
+	}
+	if total < clparams.MainnetBeaconConfig.EffectiveBalanceIncrement {
+		return clparams.MainnetBeaconConfig.EffectiveBalanceIncrement
+	}
+	return total
+}
+
+// GetTotalActiveBalance returns the total effective balance of the validators active in the state epoch.
+func GetTotalActiveBalance(state *state.BeaconState) uint64 {
+	return GetTotalBalance(state, GetActiveValidatorIndices(state, GetEpochAtSlot(state.Slot())))
+}
+
+// GetUnslashedParticipatingIndices returns the active and unslashed validators with the given participation flag set in
+// the current or previous epoch.
+func GetUnslashedParticipatingIndices(state *state.BeaconState, flagIndex uint8, epoch uint64) ([]uint64, error) {
+	var participation []byte
+	switch epoch {
+	case GetEpochAtSlot(state.Slot()):
+		participation = state.CurrentEpochParticipation()
+	case GetPreviousEpoch(state):
+		participation = state.PreviousEpochParticipation()
+	default:
+		return nil, fmt.Errorf("participation of epoch: %d, is not available at state slot: %d", epoch, state.Slot())
+	}
+	indices := []uint64{}
+	for _, index := range GetActiveValidatorIndices(state, epoch) {
+		if index >= uint64(len(participation)) {
+			return nil, fmt.Errorf("no participation recorded for validator: %d", index)
+		}
+		if participation[index]&(1<<flagIndex) != 0 && !state.ValidatorAt(int(index)).Slashed {
+			indices = append(indices, index)
+		}
+	}
+	return indices, nil
+}
+
 func ProcessBlockHeader(state *state.BeaconState, block *cltypes.BeaconBlockBellatrix) error {
 	if block.Slot != state.Slot() {
 		return fmt.Errorf("state slot: %d, not equal to block slot: %d", state.Slot(), block.Slot)
@@ -237,6 +428,117 @@ func ProcessRandao(state *state.BeaconState, body *cltypes.BeaconBodyBellatrix)
 	for i := range mix {
 		mix[i] = randaoMixes[i] ^ randaoHash[i]
 	}
-	state.RandaoMixes()[epoch%EPOCHS_PER_HISTORICAL_VECTOR] = mix
+	state.SetRandaoMixAt(int(epoch%EPOCHS_PER_HISTORICAL_VECTOR), mix)
 	return nil
 }
+
+// IncreaseBalance adds delta to the balance of the validator.
+func IncreaseBalance(state *state.BeaconState, index uint64, delta uint64) {
+	state.SetBalanceAt(int(index), state.Balances()[index]+delta)
+}
+
+// DecreaseBalance subtracts delta from the balance of the validator, down to zero.
+func DecreaseBalance(state *state.BeaconState, index uint64, delta uint64) {
+	balance := state.Balances()[index]
+	if delta > balance {
+		balance = 0
+	} else {
+		balance -= delta
+	}
+	state.SetBalanceAt(int(index), balance)
+}
+
+// GetBaseRewardPerIncrement returns the base reward of an effective balance increment for the given total active balance.
+func GetBaseRewardPerIncrement(totalActiveBalance uint64) uint64 {
+	return clparams.MainnetBeaconConfig.EffectiveBalanceIncrement * clparams.MainnetBeaconConfig.BaseRewardFactor / utils.IntegerSquareRoot(totalActiveBalance)
+}
+
+// GetBaseReward returns the base reward of a validator.
+func GetBaseReward(state *state.BeaconState, index uint64, baseRewardPerIncrement uint64) uint64 {
+	increments := state.ValidatorAt(int(index)).EffectiveBalance / clparams.MainnetBeaconConfig.EffectiveBalanceIncrement
+	return increments * baseRewardPerIncrement
+}
+
+// ComputeActivationExitEpoch returns the epoch at which activations and exits initiated at the given epoch take effect.
+func ComputeActivationExitEpoch(epoch uint64) uint64 {
+	return epoch + 1 + clparams.MainnetBeaconConfig.MaxSeedLookahead
+}
+
+// GetValidatorChurnLimit returns the number of validators that can be activated or exited in the state epoch.
+func GetValidatorChurnLimit(state *state.BeaconState) uint64 {
+	activeCount := uint64(len(GetActiveValidatorIndices(state, GetEpochAtSlot(state.Slot()))))
+	churnLimit := activeCount / clparams.MainnetBeaconConfig.ChurnLimitQuotient
+	if churnLimit < clparams.MainnetBeaconConfig.MinPerEpochChurnLimit {
+		return clparams.MainnetBeaconConfig.MinPerEpochChurnLimit
+	}
+	return churnLimit
+}
+
+// InitiateValidatorExit queues the exit of the validator, unless it already exits.
+func InitiateValidatorExit(state *state.BeaconState, index uint64) {
+	validator := state.ValidatorAt(int(index))
+	if validator.ExitEpoch != clparams.MainnetBeaconConfig.FarFutureEpoch {
+		return
+	}
+	exitQueueEpoch := ComputeActivationExitEpoch(GetEpochAtSlot(state.Slot()))
+	for _, v := range state.Validators() {
+		if v.ExitEpoch != clparams.MainnetBeaconConfig.FarFutureEpoch && v.ExitEpoch > exitQueueEpoch {
+			exitQueueEpoch = v.ExitEpoch
+		}
+	}
+	exitQueueChurn := uint64(0)
+	for _, v := range state.Validators() {
+		if v.ExitEpoch == exitQueueEpoch {
+			exitQueueChurn++
+		}
+	}
+	if exitQueueChurn >= GetValidatorChurnLimit(state) {
+		exitQueueEpoch++
+	}
+	validator.ExitEpoch = exitQueueEpoch
+	validator.WithdrawableEpoch = exitQueueEpoch + clparams.MainnetBeaconConfig.MinValidatorWithdrawabilityDelay
+	state.SetValidatorAt(int(index), validator)
+}
+
+// IsSlashableValidator tells whether the validator can still be slashed at the given epoch.
+func IsSlashableValidator(validator *cltypes.Validator, epoch uint64) bool {
+	return !validator.Slashed && validator.ActivationEpoch <= epoch && epoch < validator.WithdrawableEpoch
+}
+
+// SlashValidator slashes the validator and rewards the block proposer, who is also the whistleblower.
+func SlashValidator(state *state.BeaconState, index uint64) error {
+	epoch := GetEpochAtSlot(state.Slot())
+	InitiateValidatorExit(state, index)
+	validator := state.ValidatorAt(int(index))
+	validator.Slashed = true
+	if withdrawableEpoch := epoch + clparams.MainnetBeaconConfig.EpochsPerSlashingsVector; withdrawableEpoch > validator.WithdrawableEpoch {
+		validator.WithdrawableEpoch = withdrawableEpoch
+	}
+	state.SetValidatorAt(int(index), validator)
+	slashingsIndex := int(epoch % clparams.MainnetBeaconConfig.EpochsPerSlashingsVector)
+	state.SetSlashingAt(slashingsIndex, state.Slashings()[slashingsIndex]+validator.EffectiveBalance)
+	DecreaseBalance(state, index, validator.EffectiveBalance/clparams.MainnetBeaconConfig.MinSlashingPenaltyQuotientBellatrix)
+
+	proposerIndex, err := GetBeaconProposerIndex(state)
+	if err != nil {
+		return err
+	}
+	whistleblowerReward := validator.EffectiveBalance / clparams.MainnetBeaconConfig.WhistleBlowerRewardQuotient
+	proposerReward := whistleblowerReward * clparams.MainnetBeaconConfig.ProposerWeight / clparams.MainnetBeaconConfig.WeightDenominator
+	IncreaseBalance(state, proposerIndex, proposerReward)
+	IncreaseBalance(state, proposerIndex, whistleblowerReward-proposerReward)
+	return nil
+}
+
+// participationFlagWeight returns the reward weight of a participation flag.
+func participationFlagWeight(flagIndex uint8) uint64 {
+	switch flagIndex {
+	case clparams.MainnetBeaconConfig.TimelySourceFlagIndex:
+		return clparams.MainnetBeaconConfig.TimelySourceWeight
+	case clparams.MainnetBeaconConfig.TimelyTargetFlagIndex:
+		return clparams.MainnetBeaconConfig.TimelyTargetWeight
+	case clparams.MainnetBeaconConfig.TimelyHeadFlagIndex:
+		return clparams.MainnetBeaconConfig.TimelyHeadWeight
+	}
+	return 0
+}
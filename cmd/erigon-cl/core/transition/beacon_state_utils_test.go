@@ -4,17 +4,36 @@ import (
 	"encoding/hex"
 	"testing"
 
+	"github.com/ledgerwatch/erigon/cl/clparams"
 	"github.com/ledgerwatch/erigon/cl/cltypes"
 	"github.com/ledgerwatch/erigon/cl/utils"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/supranational/blst/bindings/go"
 )
 
 var (
-	testPublicKeyRandao        = [48]byte{185, 225, 110, 228, 192, 192, 246, 253, 101, 180, 140, 141, 199, 89, 3, 139, 210, 238, 189, 151, 158, 72, 157, 8, 214, 152, 37, 190, 211, 42, 55, 195, 204, 105, 233, 224, 95, 87, 116, 69, 238, 39, 49, 151, 145, 131, 41, 97}
-	testSignatureRandao        = [96]byte{176, 25, 98, 55, 102, 11, 185, 56, 3, 22, 58, 233, 230, 117, 206, 195, 18, 104, 110, 224, 140, 189, 89, 124, 247, 110, 34, 24, 137, 244, 55, 83, 15, 8, 202, 57, 11, 60, 112, 92, 226, 219, 183, 237, 167, 236, 67, 192, 11, 6, 2, 84, 137, 7, 71, 232, 61, 13, 111, 99, 125, 174, 150, 122, 177, 219, 187, 234, 12, 60, 229, 15, 199, 29, 125, 30, 238, 123, 23, 138, 38, 232, 248, 31, 234, 1, 149, 77, 70, 111, 237, 19, 7, 35, 209, 236}
-	testInvalidSignatureRandao = [96]byte{184, 251, 223, 57, 162, 123, 12, 186, 44, 184, 215, 35, 161, 141, 224, 113, 181, 186, 115, 49, 235, 201, 109, 120, 232, 80, 255, 174, 134, 106, 251, 67, 181, 99, 103, 137, 52, 0, 32, 249, 129, 139, 187, 236, 191, 67, 189, 233, 1, 46, 128, 22, 101, 172, 228, 195, 232, 87, 204, 52, 44, 10, 62, 91, 250, 72, 104, 5, 160, 248, 0, 54, 135, 170, 198, 172, 15, 194, 222, 39, 74, 45, 5, 196, 225, 97, 99, 85, 253, 190, 142, 245, 16, 148, 29, 42}
+	testPublicKeyRandao = func() (pubKey [48]byte) {
+		copy(pubKey[:], new(blst.P1Affine).From(testSecretKey).Compress())
+		return
+	}()
+	// testInvalidSignatureRandao is a well formed signature of another message.
+	testInvalidSignatureRandao = testSign([]byte("not an epoch"))
 )
 
+// testSignatureRandao returns the randao reveal of the state epoch signed with testSecretKey.
+func testSignatureRandao(t *testing.T, s *state.BeaconState) [96]byte {
+	epoch := GetEpochAtSlot(s.Slot())
+	domain, err := GetDomain(s, clparams.MainnetBeaconConfig.DomainRandao, epoch)
+	if err != nil {
+		t.Fatalf("unable to get randao domain: %v", err)
+	}
+	signingRoot, err := ComputeSigningRootEpoch(epoch, domain)
+	if err != nil {
+		t.Fatalf("unable to compute randao signing root: %v", err)
+	}
+	return testSign(signingRoot[:])
+}
+
 func getTestState(t *testing.T) *state.BeaconState {
 	numVals := 2048
 	validators := make([]*cltypes.Validator, numVals)
@@ -348,7 +367,7 @@ func TestProcessRandao(t *testing.T) {
 	testStateSuccess.ValidatorAt(int(propInd)).PublicKey = testPublicKeyRandao
 
 	testBlock := getTestBlock(t)
-	testBlock.Body.RandaoReveal = testSignatureRandao
+	testBlock.Body.RandaoReveal = testSignatureRandao(t, testStateSuccess)
 	testBody := testBlock.Body
 
 	testBadStateNoVals := getTestState(t)
@@ -414,3 +433,52 @@ func TestProcessRandao(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessRandaoStateRoot(t *testing.T) {
+	testState := getTestState(t)
+	// Fill the fields left empty by getTestState, so that the whole state can be hashed.
+	testState.SetBlockRoots(make([][32]byte, 8192))
+	testState.SetStateRoots(make([][32]byte, 8192))
+	testState.SetBalances(make([]uint64, len(testState.Validators())))
+	testState.SetSlashings(make([]uint64, 8192))
+	testState.SetJustificationBits(make([]byte, 1))
+	testState.SetEth1Data(&cltypes.Eth1Data{})
+	testState.SetPreviousJustifiedCheckpoint(&cltypes.Checkpoint{})
+	testState.SetCurrentJustifiedCheckpoint(&cltypes.Checkpoint{})
+	testState.SetFinalizedCheckpoint(&cltypes.Checkpoint{})
+	testState.SetCurrentSyncCommittee(&cltypes.SyncCommittee{PubKeys: make([][48]byte, 512)})
+	testState.SetNextSyncCommittee(&cltypes.SyncCommittee{PubKeys: make([][48]byte, 512)})
+	testState.SetLatestExecutionPayloadHeader(&cltypes.ExecutionHeader{
+		LogsBloom:     make([]byte, 256),
+		BaseFeePerGas: make([]byte, 32),
+	})
+	for _, v := range testState.Validators() {
+		v.WithdrawalCredentials = make([]byte, 32)
+	}
+	propInd, err := GetBeaconProposerIndex(testState)
+	if err != nil {
+		t.Fatalf("unable to get proposer index: %v", err)
+	}
+	testState.ValidatorAt(int(propInd)).PublicKey = testPublicKeyRandao
+	// Populate the merkle caches before the mix changes.
+	if _, err := testState.HashTreeRoot(); err != nil {
+		t.Fatalf("unable to hash state: %v", err)
+	}
+
+	testBlock := getTestBlock(t)
+	testBlock.Body.RandaoReveal = testSignatureRandao(t, testState)
+	if err := ProcessRandao(testState, testBlock.Body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cachedRoot, err := testState.HashTreeRoot()
+	if err != nil {
+		t.Fatalf("unable to hash state: %v", err)
+	}
+	expectedRoot, err := testState.GetStateSSZObject().HashTreeRoot()
+	if err != nil {
+		t.Fatalf("unable to hash state: %v", err)
+	}
+	if cachedRoot != expectedRoot {
+		t.Errorf("cached state root: %x, differs from full state root: %x", cachedRoot, expectedRoot)
+	}
+}
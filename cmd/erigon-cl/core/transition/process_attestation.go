@@ -0,0 +1,114 @@
+package transition
+
+import (
+	"fmt"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+)
+
+// GetAttestationParticipationFlagIndices returns the participation flags earned by an attestation included with the given delay.
+func GetAttestationParticipationFlagIndices(state *state.BeaconState, data *cltypes.AttestationData, inclusionDelay uint64) ([]uint8, error) {
+	justifiedCheckpoint := state.PreviousJustifiedCheckpoint()
+	if data.Target.Epoch == GetEpochAtSlot(state.Slot()) {
+		justifiedCheckpoint = state.CurrentJustifiedCheckpoint()
+	}
+	if *data.Source != *justifiedCheckpoint {
+		return nil, fmt.Errorf("attestation source: %d, %x, does not match justified checkpoint: %d, %x", data.Source.Epoch, data.Source.Root, justifiedCheckpoint.Epoch, justifiedCheckpoint.Root)
+	}
+	targetRoot, err := GetBlockRoot(state, data.Target.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	headRoot, err := GetBlockRootAtSlot(state, data.Slot)
+	if err != nil {
+		return nil, err
+	}
+	isMatchingTarget := data.Target.Root == targetRoot
+	isMatchingHead := isMatchingTarget && data.BeaconBlockHash == headRoot
+
+	flagIndices := []uint8{}
+	if inclusionDelay <= utils.IntegerSquareRoot(SLOTS_PER_EPOCH) {
+		flagIndices = append(flagIndices, clparams.MainnetBeaconConfig.TimelySourceFlagIndex)
+	}
+	if isMatchingTarget && inclusionDelay <= SLOTS_PER_EPOCH {
+		flagIndices = append(flagIndices, clparams.MainnetBeaconConfig.TimelyTargetFlagIndex)
+	}
+	if isMatchingHead && inclusionDelay == clparams.MainnetBeaconConfig.MinAttestationInclusionDelay {
+		flagIndices = append(flagIndices, clparams.MainnetBeaconConfig.TimelyHeadFlagIndex)
+	}
+	return flagIndices, nil
+}
+
+// ProcessAttestation records the participation flags earned by the validators of an attestation included in a block
+// and rewards the proposer for the newly set flags.
+func ProcessAttestation(state *state.BeaconState, attestation *cltypes.Attestation) error {
+	data := attestation.Data
+	if data == nil || data.Source == nil || data.Target == nil {
+		return fmt.Errorf("attestation has no data")
+	}
+	currentEpoch := GetEpochAtSlot(state.Slot())
+	if data.Target.Epoch != currentEpoch && data.Target.Epoch != GetPreviousEpoch(state) {
+		return fmt.Errorf("attestation target epoch: %d, is neither current nor previous epoch", data.Target.Epoch)
+	}
+	if data.Target.Epoch != GetEpochAtSlot(data.Slot) {
+		return fmt.Errorf("attestation target epoch: %d, does not match slot: %d", data.Target.Epoch, data.Slot)
+	}
+	if data.Slot+clparams.MainnetBeaconConfig.MinAttestationInclusionDelay > state.Slot() || state.Slot() > data.Slot+SLOTS_PER_EPOCH {
+		return fmt.Errorf("attestation slot: %d, cannot be included at slot: %d", data.Slot, state.Slot())
+	}
+	if data.Index >= GetCommitteeCountPerSlot(state, data.Target.Epoch) {
+		return fmt.Errorf("attestation committee index: %d, out of range", data.Index)
+	}
+	flagIndices, err := GetAttestationParticipationFlagIndices(state, data, state.Slot()-data.Slot)
+	if err != nil {
+		return err
+	}
+	indexedAttestation, err := GetIndexedAttestation(state, attestation)
+	if err != nil {
+		return err
+	}
+	valid, err := IsValidIndexedAttestation(state, indexedAttestation)
+	if err != nil {
+		return fmt.Errorf("unable to verify attestation: %v", err)
+	}
+	if !valid {
+		return fmt.Errorf("invalid attestation")
+	}
+
+	participation := state.PreviousEpochParticipation()
+	if data.Target.Epoch == currentEpoch {
+		participation = state.CurrentEpochParticipation()
+	}
+	baseRewardPerIncrement := GetBaseRewardPerIncrement(GetTotalActiveBalance(state))
+	proposerRewardNumerator := uint64(0)
+	for _, index := range indexedAttestation.AttestingIndices {
+		if index >= uint64(len(participation)) {
+			return fmt.Errorf("no participation recorded for validator: %d", index)
+		}
+		for _, flagIndex := range flagIndices {
+			if participation[index]&(1<<flagIndex) != 0 {
+				continue
+			}
+			participation[index] |= 1 << flagIndex
+			proposerRewardNumerator += GetBaseReward(state, index, baseRewardPerIncrement) * participationFlagWeight(flagIndex)
+		}
+	}
+	if data.Target.Epoch == currentEpoch {
+		state.SetCurrentEpochParticipation(participation)
+	} else {
+		state.SetPreviousEpochParticipation(participation)
+	}
+
+	proposerIndex, err := GetBeaconProposerIndex(state)
+	if err != nil {
+		return err
+	}
+	weightDenominator := clparams.MainnetBeaconConfig.WeightDenominator
+	proposerWeight := clparams.MainnetBeaconConfig.ProposerWeight
+	proposerRewardDenominator := (weightDenominator - proposerWeight) * weightDenominator / proposerWeight
+	IncreaseBalance(state, proposerIndex, proposerRewardNumerator/proposerRewardDenominator)
+	return nil
+}
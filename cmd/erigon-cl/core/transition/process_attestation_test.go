@@ -0,0 +1,156 @@
+package transition
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/stretchr/testify/require"
+	"github.com/supranational/blst/bindings/go"
+)
+
+func getTestAttestation(slot uint64) *cltypes.Attestation {
+	epoch := GetEpochAtSlot(slot)
+	return &cltypes.Attestation{
+		// Both members of the committee, followed by the bitlist length bit.
+		AggregationBits: []byte{0b111},
+		Data: &cltypes.AttestationData{
+			Slot:            slot,
+			BeaconBlockHash: [32]byte{byte(epoch + 1)},
+			Source:          &cltypes.Checkpoint{},
+			Target:          &cltypes.Checkpoint{Epoch: epoch, Root: [32]byte{byte(epoch + 1)}},
+		},
+	}
+}
+
+// signTestAttestation signs the attestation with the keys of its attesters in a state from getTestActiveState.
+func signTestAttestation(t *testing.T, s *state.BeaconState, attestation *cltypes.Attestation) {
+	attestingIndices, err := GetAttestingIndices(s, attestation.Data, attestation.AggregationBits)
+	if err != nil {
+		// Left unsigned, the attestation is rejected anyway.
+		return
+	}
+	domain, err := GetDomain(s, clparams.MainnetBeaconConfig.DomainBeaconAttester, attestation.Data.Target.Epoch)
+	require.NoError(t, err)
+	signingRoot, err := ComputeSigningRoot(attestation.Data, domain)
+	require.NoError(t, err)
+	aggregate := new(blst.P2Aggregate)
+	for _, index := range attestingIndices {
+		require.True(t, aggregate.Add(new(blst.P2Affine).Sign(testActiveKeys[index], signingRoot[:], []byte(testSigningDST)), false))
+	}
+	copy(attestation.Signature[:], aggregate.ToAffine().Compress())
+}
+
+func TestProcessAttestation(t *testing.T) {
+	wrongSource := getTestAttestation(39)
+	wrongSource.Data.Source = &cltypes.Checkpoint{Epoch: 1}
+	wrongTarget := getTestAttestation(39)
+	wrongTarget.Data.Target.Root = [32]byte{9}
+	wrongTargetEpoch := getTestAttestation(39)
+	wrongTargetEpoch.Data.Target.Epoch = 0
+	wrongBitsLength := getTestAttestation(39)
+	wrongBitsLength.AggregationBits = []byte{0b1011}
+	testCases := []struct {
+		description      string
+		stateSlot        uint64
+		attestation      *cltypes.Attestation
+		unsigned         bool
+		expectedFlags    byte
+		expectedPrevious bool
+		wantErr          bool
+	}{
+		{
+			description:   "timely",
+			stateSlot:     40,
+			attestation:   getTestAttestation(39),
+			expectedFlags: 0b111,
+		},
+		{
+			description:   "late_source",
+			stateSlot:     45,
+			attestation:   getTestAttestation(39),
+			expectedFlags: 0b010,
+		},
+		{
+			description:   "wrong_target",
+			stateSlot:     40,
+			attestation:   wrongTarget,
+			expectedFlags: 0b001,
+		},
+		{
+			description:      "previous_epoch",
+			stateSlot:        65,
+			attestation:      getTestAttestation(39),
+			expectedFlags:    0b010,
+			expectedPrevious: true,
+		},
+		{
+			description: "error_wrong_source",
+			stateSlot:   40,
+			attestation: wrongSource,
+			wantErr:     true,
+		},
+		{
+			description: "error_target_epoch_mismatch",
+			stateSlot:   40,
+			attestation: wrongTargetEpoch,
+			wantErr:     true,
+		},
+		{
+			description: "error_same_slot",
+			stateSlot:   39,
+			attestation: getTestAttestation(39),
+			wantErr:     true,
+		},
+		{
+			description: "error_bad_signature",
+			stateSlot:   40,
+			attestation: getTestAttestation(39),
+			unsigned:    true,
+			wantErr:     true,
+		},
+		{
+			description: "error_bits_length",
+			stateSlot:   40,
+			attestation: wrongBitsLength,
+			wantErr:     true,
+		},
+		{
+			description: "error_too_old",
+			stateSlot:   72,
+			attestation: getTestAttestation(39),
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			s := getTestActiveState(tc.stateSlot)
+			if !tc.unsigned {
+				signTestAttestation(t, s, tc.attestation)
+			}
+			proposerIndex, err := GetBeaconProposerIndex(s)
+			require.NoError(t, err)
+			err = ProcessAttestation(s, tc.attestation)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			committee, err := GetBeaconCommittee(s, tc.attestation.Data.Slot, tc.attestation.Data.Index)
+			require.NoError(t, err)
+			participation := s.CurrentEpochParticipation()
+			if tc.expectedPrevious {
+				participation = s.PreviousEpochParticipation()
+			}
+			expected := make([]byte, testActiveValidators)
+			for _, index := range committee {
+				expected[index] = tc.expectedFlags
+			}
+			require.Equal(t, expected, participation)
+			// The proposer is rewarded for the new flags.
+			require.Greater(t, s.Balances()[proposerIndex], clparams.MainnetBeaconConfig.MaxEffectiveBalance)
+		})
+	}
+}
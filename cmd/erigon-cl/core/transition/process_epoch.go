@@ -0,0 +1,412 @@
+package transition
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/supranational/blst/bindings/go"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state/state_encoding"
+)
+
+// processEpoch is called on the last slot of each epoch.
+func (s *StateTransistor) processEpoch() error {
+	if err := ProcessJustificationAndFinalization(s.state); err != nil {
+		return fmt.Errorf("unable to process justification and finalization: %v", err)
+	}
+	if err := ProcessInactivityUpdates(s.state); err != nil {
+		return fmt.Errorf("unable to process inactivity updates: %v", err)
+	}
+	if err := ProcessRewardsAndPenalties(s.state); err != nil {
+		return fmt.Errorf("unable to process rewards and penalties: %v", err)
+	}
+	ProcessRegistryUpdates(s.state)
+	ProcessSlashings(s.state)
+	ProcessEth1DataReset(s.state)
+	ProcessEffectiveBalanceUpdates(s.state)
+	ProcessSlashingsReset(s.state)
+	ProcessRandaoMixesReset(s.state)
+	if err := ProcessHistoricalRootsUpdate(s.state); err != nil {
+		return fmt.Errorf("unable to process historical roots update: %v", err)
+	}
+	ProcessParticipationFlagUpdates(s.state)
+	if err := ProcessSyncCommitteeUpdates(s.state); err != nil {
+		return fmt.Errorf("unable to process sync committee updates: %v", err)
+	}
+	return nil
+}
+
+// ProcessJustificationAndFinalization justifies the previous and current epochs reaching a supermajority of target votes
+// and finalizes checkpoints accordingly.
+func ProcessJustificationAndFinalization(state *state.BeaconState) error {
+	currentEpoch := GetEpochAtSlot(state.Slot())
+	// Initial epochs are justified by default.
+	if currentEpoch <= clparams.MainnetBeaconConfig.GenesisEpoch+1 {
+		return nil
+	}
+	previousEpoch := GetPreviousEpoch(state)
+	previousIndices, err := GetUnslashedParticipatingIndices(state, clparams.MainnetBeaconConfig.TimelyTargetFlagIndex, previousEpoch)
+	if err != nil {
+		return err
+	}
+	currentIndices, err := GetUnslashedParticipatingIndices(state, clparams.MainnetBeaconConfig.TimelyTargetFlagIndex, currentEpoch)
+	if err != nil {
+		return err
+	}
+	return weighJustificationAndFinalization(
+		state,
+		GetTotalActiveBalance(state),
+		GetTotalBalance(state, previousIndices),
+		GetTotalBalance(state, currentIndices),
+	)
+}
+
+func weighJustificationAndFinalization(state *state.BeaconState, totalActiveBalance, previousTargetBalance, currentTargetBalance uint64) error {
+	currentEpoch := GetEpochAtSlot(state.Slot())
+	previousEpoch := GetPreviousEpoch(state)
+	oldPreviousJustified := state.PreviousJustifiedCheckpoint()
+	oldCurrentJustified := state.CurrentJustifiedCheckpoint()
+
+	// Bit i of the justification bits tells whether the epoch i epochs before the current one is justified.
+	var bits byte
+	if justificationBits := state.JustificationBits(); len(justificationBits) > 0 {
+		bits = justificationBits[0]
+	}
+	bits = (bits << 1) & 0x0f
+	state.SetPreviousJustifiedCheckpoint(oldCurrentJustified)
+	if previousTargetBalance*3 >= totalActiveBalance*2 {
+		root, err := GetBlockRoot(state, previousEpoch)
+		if err != nil {
+			return err
+		}
+		state.SetCurrentJustifiedCheckpoint(&cltypes.Checkpoint{Epoch: previousEpoch, Root: root})
+		bits |= 1 << 1
+	}
+	if currentTargetBalance*3 >= totalActiveBalance*2 {
+		root, err := GetBlockRoot(state, currentEpoch)
+		if err != nil {
+			return err
+		}
+		state.SetCurrentJustifiedCheckpoint(&cltypes.Checkpoint{Epoch: currentEpoch, Root: root})
+		bits |= 1
+	}
+	state.SetJustificationBits([]byte{bits})
+
+	// The 2nd/3rd/4th most recent epochs are justified, the 2nd using the 4th as source.
+	if bits&0x0e == 0x0e && oldPreviousJustified.Epoch+3 == currentEpoch {
+		state.SetFinalizedCheckpoint(oldPreviousJustified)
+	}
+	// The 2nd/3rd most recent epochs are justified, the 2nd using the 3rd as source.
+	if bits&0x06 == 0x06 && oldPreviousJustified.Epoch+2 == currentEpoch {
+		state.SetFinalizedCheckpoint(oldPreviousJustified)
+	}
+	// The 1st/2nd/3rd most recent epochs are justified, the 1st using the 3rd as source.
+	if bits&0x07 == 0x07 && oldCurrentJustified.Epoch+2 == currentEpoch {
+		state.SetFinalizedCheckpoint(oldCurrentJustified)
+	}
+	// The 1st/2nd most recent epochs are justified, the 1st using the 2nd as source.
+	if bits&0x03 == 0x03 && oldCurrentJustified.Epoch+1 == currentEpoch {
+		state.SetFinalizedCheckpoint(oldCurrentJustified)
+	}
+	return nil
+}
+
+// ProcessRandaoMixesReset carries the randao mix of the current epoch over to the next one.
+func ProcessRandaoMixesReset(state *state.BeaconState) {
+	currentEpoch := GetEpochAtSlot(state.Slot())
+	state.SetRandaoMixAt(int((currentEpoch+1)%EPOCHS_PER_HISTORICAL_VECTOR), GetRandaoMixes(state, currentEpoch))
+}
+
+// ProcessParticipationFlagUpdates rotates the epoch participation.
+func ProcessParticipationFlagUpdates(state *state.BeaconState) {
+	state.SetPreviousEpochParticipation(state.CurrentEpochParticipation())
+	state.SetCurrentEpochParticipation(make([]byte, len(state.Validators())))
+}
+
+// isInInactivityLeak tells whether finality is delayed enough for the inactivity leak to kick in.
+func isInInactivityLeak(state *state.BeaconState) bool {
+	return GetPreviousEpoch(state)-state.FinalizedCheckpoint().Epoch > clparams.MainnetBeaconConfig.MinEpochsToInactivityPenalty
+}
+
+// getEligibleValidatorIndices returns the validators that are rewarded or penalized for the previous epoch.
+func getEligibleValidatorIndices(state *state.BeaconState) []uint64 {
+	previousEpoch := GetPreviousEpoch(state)
+	indices := []uint64{}
+	for index, validator := range state.Validators() {
+		if IsActiveValidator(validator, previousEpoch) || (validator.Slashed && previousEpoch+1 < validator.WithdrawableEpoch) {
+			indices = append(indices, uint64(index))
+		}
+	}
+	return indices
+}
+
+// unslashedParticipatingSet returns the unslashed validators with the flag set in the previous epoch.
+func unslashedParticipatingSet(state *state.BeaconState, flagIndex uint8) (map[uint64]struct{}, uint64, error) {
+	indices, err := GetUnslashedParticipatingIndices(state, flagIndex, GetPreviousEpoch(state))
+	if err != nil {
+		return nil, 0, err
+	}
+	set := make(map[uint64]struct{}, len(indices))
+	for _, index := range indices {
+		set[index] = struct{}{}
+	}
+	return set, GetTotalBalance(state, indices), nil
+}
+
+// ProcessInactivityUpdates raises the inactivity score of validators that missed the previous target and lowers the
+// other ones, as well as everyone's outside of an inactivity leak.
+func ProcessInactivityUpdates(state *state.BeaconState) error {
+	if GetEpochAtSlot(state.Slot()) == clparams.MainnetBeaconConfig.GenesisEpoch {
+		return nil
+	}
+	targetParticipants, _, err := unslashedParticipatingSet(state, clparams.MainnetBeaconConfig.TimelyTargetFlagIndex)
+	if err != nil {
+		return err
+	}
+	inLeak := isInInactivityLeak(state)
+	for _, index := range getEligibleValidatorIndices(state) {
+		score := state.InactivityScores()[index]
+		if _, ok := targetParticipants[index]; ok {
+			score -= utils.Min64(1, score)
+		} else {
+			score += clparams.MainnetBeaconConfig.InactivityScoreBias
+		}
+		if !inLeak {
+			score -= utils.Min64(clparams.MainnetBeaconConfig.InactivityScoreRecoveryRate, score)
+		}
+		state.SetInactivityScoreAt(int(index), score)
+	}
+	return nil
+}
+
+// ProcessRewardsAndPenalties applies the participation flag rewards and penalties and the inactivity penalties of the
+// previous epoch.
+func ProcessRewardsAndPenalties(state *state.BeaconState) error {
+	if GetEpochAtSlot(state.Slot()) == clparams.MainnetBeaconConfig.GenesisEpoch {
+		return nil
+	}
+	eligible := getEligibleValidatorIndices(state)
+	totalActiveBalance := GetTotalActiveBalance(state)
+	baseRewardPerIncrement := GetBaseRewardPerIncrement(totalActiveBalance)
+	activeIncrements := totalActiveBalance / clparams.MainnetBeaconConfig.EffectiveBalanceIncrement
+	inLeak := isInInactivityLeak(state)
+	weightDenominator := clparams.MainnetBeaconConfig.WeightDenominator
+
+	flagIndices := []uint8{
+		clparams.MainnetBeaconConfig.TimelySourceFlagIndex,
+		clparams.MainnetBeaconConfig.TimelyTargetFlagIndex,
+		clparams.MainnetBeaconConfig.TimelyHeadFlagIndex,
+	}
+	for _, flagIndex := range flagIndices {
+		participants, participatingBalance, err := unslashedParticipatingSet(state, flagIndex)
+		if err != nil {
+			return err
+		}
+		weight := participationFlagWeight(flagIndex)
+		participatingIncrements := participatingBalance / clparams.MainnetBeaconConfig.EffectiveBalanceIncrement
+		for _, index := range eligible {
+			baseReward := GetBaseReward(state, index, baseRewardPerIncrement)
+			if _, ok := participants[index]; ok {
+				if !inLeak {
+					IncreaseBalance(state, index, baseReward*weight*participatingIncrements/(activeIncrements*weightDenominator))
+				}
+			} else if flagIndex != clparams.MainnetBeaconConfig.TimelyHeadFlagIndex {
+				DecreaseBalance(state, index, baseReward*weight/weightDenominator)
+			}
+		}
+	}
+
+	targetParticipants, _, err := unslashedParticipatingSet(state, clparams.MainnetBeaconConfig.TimelyTargetFlagIndex)
+	if err != nil {
+		return err
+	}
+	penaltyDenominator := clparams.MainnetBeaconConfig.InactivityScoreBias * clparams.MainnetBeaconConfig.InactivityPenaltyQuotientBellatrix
+	for _, index := range eligible {
+		if _, ok := targetParticipants[index]; ok {
+			continue
+		}
+		penaltyNumerator := state.ValidatorAt(int(index)).EffectiveBalance * state.InactivityScores()[index]
+		DecreaseBalance(state, index, penaltyNumerator/penaltyDenominator)
+	}
+	return nil
+}
+
+// ProcessRegistryUpdates queues eligible validators for activation, ejects the ones with too low a balance and activates
+// as many finalized queued validators as the churn limit allows.
+func ProcessRegistryUpdates(state *state.BeaconState) {
+	currentEpoch := GetEpochAtSlot(state.Slot())
+	for index, validator := range state.Validators() {
+		if validator.ActivationEligibilityEpoch == clparams.MainnetBeaconConfig.FarFutureEpoch && validator.EffectiveBalance == clparams.MainnetBeaconConfig.MaxEffectiveBalance {
+			validator.ActivationEligibilityEpoch = currentEpoch + 1
+			state.SetValidatorAt(index, validator)
+		}
+		if IsActiveValidator(validator, currentEpoch) && validator.EffectiveBalance <= clparams.MainnetBeaconConfig.EjectionBalance {
+			InitiateValidatorExit(state, uint64(index))
+		}
+	}
+
+	activationQueue := []uint64{}
+	for index, validator := range state.Validators() {
+		if validator.ActivationEligibilityEpoch <= state.FinalizedCheckpoint().Epoch && validator.ActivationEpoch == clparams.MainnetBeaconConfig.FarFutureEpoch {
+			activationQueue = append(activationQueue, uint64(index))
+		}
+	}
+	// Order by eligibility epoch first, then by index.
+	sort.SliceStable(activationQueue, func(i, j int) bool {
+		return state.ValidatorAt(int(activationQueue[i])).ActivationEligibilityEpoch < state.ValidatorAt(int(activationQueue[j])).ActivationEligibilityEpoch
+	})
+	churnLimit := GetValidatorChurnLimit(state)
+	if uint64(len(activationQueue)) > churnLimit {
+		activationQueue = activationQueue[:churnLimit]
+	}
+	for _, index := range activationQueue {
+		validator := state.ValidatorAt(int(index))
+		validator.ActivationEpoch = ComputeActivationExitEpoch(currentEpoch)
+		state.SetValidatorAt(int(index), validator)
+	}
+}
+
+// ProcessSlashings penalizes slashed validators halfway through their withdrawability delay, proportionally to the
+// total amount slashed around that time.
+func ProcessSlashings(state *state.BeaconState) {
+	epoch := GetEpochAtSlot(state.Slot())
+	totalBalance := GetTotalActiveBalance(state)
+	totalSlashings := uint64(0)
+	for _, slashing := range state.Slashings() {
+		totalSlashings += slashing
+	}
+	adjustedTotalSlashingBalance := utils.Min64(totalSlashings*clparams.MainnetBeaconConfig.ProportionalSlashingMultiplierBellatrix, totalBalance)
+	increment := clparams.MainnetBeaconConfig.EffectiveBalanceIncrement
+	for index, validator := range state.Validators() {
+		if !validator.Slashed || epoch+clparams.MainnetBeaconConfig.EpochsPerSlashingsVector/2 != validator.WithdrawableEpoch {
+			continue
+		}
+		// Factored out of the balance to avoid overflows.
+		penaltyNumerator := validator.EffectiveBalance / increment * adjustedTotalSlashingBalance
+		DecreaseBalance(state, uint64(index), penaltyNumerator/totalBalance*increment)
+	}
+}
+
+// ProcessEth1DataReset clears the eth1 data votes at the end of each voting period.
+func ProcessEth1DataReset(state *state.BeaconState) {
+	nextEpoch := GetEpochAtSlot(state.Slot()) + 1
+	if nextEpoch%clparams.MainnetBeaconConfig.EpochsPerEth1VotingPeriod == 0 {
+		state.SetEth1DataVotes([]*cltypes.Eth1Data{})
+	}
+}
+
+// ProcessEffectiveBalanceUpdates moves effective balances towards the actual balances, with hysteresis.
+func ProcessEffectiveBalanceUpdates(state *state.BeaconState) {
+	increment := clparams.MainnetBeaconConfig.EffectiveBalanceIncrement
+	hysteresisIncrement := increment / clparams.MainnetBeaconConfig.HysteresisQuotient
+	downwardThreshold := hysteresisIncrement * clparams.MainnetBeaconConfig.HysteresisDownwardMultiplier
+	upwardThreshold := hysteresisIncrement * clparams.MainnetBeaconConfig.HysteresisUpwardMultiplier
+	for index, validator := range state.Validators() {
+		balance := state.Balances()[index]
+		if balance+downwardThreshold < validator.EffectiveBalance || validator.EffectiveBalance+upwardThreshold < balance {
+			validator.EffectiveBalance = utils.Min64(balance-balance%increment, clparams.MainnetBeaconConfig.MaxEffectiveBalance)
+			state.SetValidatorAt(index, validator)
+		}
+	}
+}
+
+// ProcessSlashingsReset clears the slashings of the epoch the vector wraps around to.
+func ProcessSlashingsReset(state *state.BeaconState) {
+	nextEpoch := GetEpochAtSlot(state.Slot()) + 1
+	state.SetSlashingAt(int(nextEpoch%clparams.MainnetBeaconConfig.EpochsPerSlashingsVector), 0)
+}
+
+// ProcessHistoricalRootsUpdate appends the root of the block and state roots vectors once they are filled up.
+func ProcessHistoricalRootsUpdate(state *state.BeaconState) error {
+	nextEpoch := GetEpochAtSlot(state.Slot()) + 1
+	slotsPerHistoricalRoot := clparams.MainnetBeaconConfig.SlotsPerHistoricalRoot
+	if nextEpoch%(slotsPerHistoricalRoot/SLOTS_PER_EPOCH) != 0 {
+		return nil
+	}
+	blockRootsRoot, err := state_encoding.ArraysRoot(state.BlockRoots(), slotsPerHistoricalRoot)
+	if err != nil {
+		return err
+	}
+	stateRootsRoot, err := state_encoding.ArraysRoot(state.StateRoots(), slotsPerHistoricalRoot)
+	if err != nil {
+		return err
+	}
+	// Root of the historical batch container, made of the two vectors.
+	state.AddHistoricalRoot(utils.Keccak256(blockRootsRoot[:], stateRootsRoot[:]))
+	return nil
+}
+
+// ProcessSyncCommitteeUpdates rotates the sync committees at the end of each sync committee period.
+func ProcessSyncCommitteeUpdates(state *state.BeaconState) error {
+	nextEpoch := GetEpochAtSlot(state.Slot()) + 1
+	if nextEpoch%clparams.MainnetBeaconConfig.EpochsPerSyncCommitteePeriod != 0 {
+		return nil
+	}
+	nextSyncCommittee, err := GetNextSyncCommittee(state)
+	if err != nil {
+		return err
+	}
+	state.SetCurrentSyncCommittee(state.NextSyncCommittee())
+	state.SetNextSyncCommittee(nextSyncCommittee)
+	return nil
+}
+
+// GetNextSyncCommitteeIndices samples the validators of the next sync committee, weighted by effective balance.
+func GetNextSyncCommitteeIndices(state *state.BeaconState) ([]uint64, error) {
+	epoch := GetEpochAtSlot(state.Slot()) + 1
+	maxRandomByte := uint64(1<<8 - 1)
+	activeIndices := GetActiveValidatorIndices(state, epoch)
+	total := uint64(len(activeIndices))
+	if total == 0 {
+		return nil, fmt.Errorf("no active validators at epoch: %d", epoch)
+	}
+	var seed [32]byte
+	copy(seed[:], GetSeed(state, epoch, clparams.MainnetBeaconConfig.DomainSyncCommittee))
+	hash := sha256.New()
+	buf := make([]byte, 8)
+	indices := make([]uint64, 0, clparams.MainnetBeaconConfig.SyncCommitteeSize)
+	for i := uint64(0); uint64(len(indices)) < clparams.MainnetBeaconConfig.SyncCommitteeSize; i++ {
+		shuffled, err := ComputeShuffledIndex(i%total, total, seed)
+		if err != nil {
+			return nil, err
+		}
+		candidateIndex := activeIndices[shuffled]
+		binary.LittleEndian.PutUint64(buf, i/32)
+		hash.Reset()
+		hash.Write(seed[:])
+		hash.Write(buf)
+		randomByte := uint64(hash.Sum(nil)[i%32])
+		effectiveBalance := state.ValidatorAt(int(candidateIndex)).EffectiveBalance
+		if effectiveBalance*maxRandomByte >= clparams.MainnetBeaconConfig.MaxEffectiveBalance*randomByte {
+			indices = append(indices, candidateIndex)
+		}
+	}
+	return indices, nil
+}
+
+// GetNextSyncCommittee returns the sync committee of the next period with its aggregate public key.
+func GetNextSyncCommittee(state *state.BeaconState) (*cltypes.SyncCommittee, error) {
+	indices, err := GetNextSyncCommitteeIndices(state)
+	if err != nil {
+		return nil, err
+	}
+	pubKeys := make([][48]byte, len(indices))
+	compressed := make([][]byte, len(indices))
+	for i, index := range indices {
+		pubKeys[i] = state.ValidatorAt(int(index)).PublicKey
+		compressed[i] = pubKeys[i][:]
+	}
+	aggregate := new(blst.P1Aggregate)
+	if !aggregate.AggregateCompressed(compressed, false) {
+		return nil, fmt.Errorf("unable to aggregate sync committee public keys")
+	}
+	committee := &cltypes.SyncCommittee{PubKeys: pubKeys}
+	copy(committee.AggregatePublicKey[:], aggregate.ToAffine().Compress())
+	return committee, nil
+}
@@ -0,0 +1,287 @@
+package transition
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/stretchr/testify/require"
+	"github.com/supranational/blst/bindings/go"
+)
+
+const testActiveValidators = 64
+
+// testActiveKeys are the keys of the validators of getTestActiveState.
+var testActiveKeys = func() []*blst.SecretKey {
+	keys := make([]*blst.SecretKey, testActiveValidators)
+	for i := range keys {
+		ikm := make([]byte, 32)
+		ikm[0] = byte(i + 1)
+		keys[i] = blst.KeyGen(ikm)
+	}
+	return keys
+}()
+
+// getTestActiveState returns a state at the given slot with active validators, the block root of epoch e is {e + 1}.
+func getTestActiveState(slot uint64) *state.BeaconState {
+	res := getTestBeaconState()
+	validators := make([]*cltypes.Validator, testActiveValidators)
+	balances := make([]uint64, testActiveValidators)
+	for i := range validators {
+		validators[i] = &cltypes.Validator{
+			WithdrawalCredentials: make([]byte, 32),
+			EffectiveBalance:      clparams.MainnetBeaconConfig.MaxEffectiveBalance,
+			ExitEpoch:             clparams.MainnetBeaconConfig.FarFutureEpoch,
+			WithdrawableEpoch:     clparams.MainnetBeaconConfig.FarFutureEpoch,
+		}
+		copy(validators[i].PublicKey[:], new(blst.P1Affine).From(testActiveKeys[i]).Compress())
+		balances[i] = clparams.MainnetBeaconConfig.MaxEffectiveBalance
+	}
+	res.SetValidators(validators)
+	res.SetBalances(balances)
+	res.SetPreviousEpochParticipation(make([]byte, testActiveValidators))
+	res.SetCurrentEpochParticipation(make([]byte, testActiveValidators))
+	res.SetInactivityScores(make([]uint64, testActiveValidators))
+	for i := uint64(0); i < slot; i++ {
+		res.SetBlockRootAt(int(i), [32]byte{byte(GetEpochAtSlot(i) + 1)})
+	}
+	res.SetSlot(slot)
+	return res
+}
+
+func TestWeighJustificationAndFinalization(t *testing.T) {
+	epochRoot := func(epoch uint64) [32]byte { return [32]byte{byte(epoch + 1)} }
+	checkpoint := func(epoch uint64) *cltypes.Checkpoint {
+		return &cltypes.Checkpoint{Epoch: epoch, Root: epochRoot(epoch)}
+	}
+	testCases := []struct {
+		description       string
+		bits              byte
+		previousJustified *cltypes.Checkpoint
+		currentJustified  *cltypes.Checkpoint
+		previousBalance   uint64
+		currentBalance    uint64
+		expectedBits      byte
+		expectedJustified *cltypes.Checkpoint
+		expectedFinalized *cltypes.Checkpoint
+	}{
+		{
+			description:       "no_supermajority",
+			bits:              0b0001,
+			previousJustified: checkpoint(1),
+			currentJustified:  checkpoint(2),
+			previousBalance:   100,
+			currentBalance:    100,
+			expectedBits:      0b0010,
+			expectedJustified: checkpoint(2),
+			expectedFinalized: &cltypes.Checkpoint{},
+		},
+		{
+			description:       "current_justified_on_top_of_previous",
+			bits:              0b0001,
+			previousJustified: checkpoint(1),
+			currentJustified:  checkpoint(2),
+			previousBalance:   200,
+			currentBalance:    200,
+			expectedBits:      0b0011,
+			expectedJustified: checkpoint(3),
+			expectedFinalized: checkpoint(2),
+		},
+		{
+			description:       "previous_justified_on_top_of_two_epochs_ago",
+			bits:              0b0011,
+			previousJustified: checkpoint(1),
+			currentJustified:  checkpoint(2),
+			previousBalance:   200,
+			currentBalance:    0,
+			expectedBits:      0b0110,
+			expectedJustified: checkpoint(2),
+			expectedFinalized: checkpoint(1),
+		},
+		{
+			description:       "previous_justified_on_top_of_three_epochs_ago",
+			bits:              0b0111,
+			previousJustified: checkpoint(0),
+			currentJustified:  checkpoint(0),
+			previousBalance:   200,
+			currentBalance:    0,
+			expectedBits:      0b1110,
+			expectedJustified: checkpoint(2),
+			expectedFinalized: checkpoint(0),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			// Last slot of epoch 3.
+			s := getTestActiveState(4*SLOTS_PER_EPOCH - 1)
+			s.SetJustificationBits([]byte{tc.bits})
+			s.SetPreviousJustifiedCheckpoint(tc.previousJustified)
+			s.SetCurrentJustifiedCheckpoint(tc.currentJustified)
+			require.NoError(t, weighJustificationAndFinalization(s, 300, tc.previousBalance, tc.currentBalance))
+			require.Equal(t, []byte{tc.expectedBits}, s.JustificationBits())
+			require.Equal(t, tc.currentJustified, s.PreviousJustifiedCheckpoint())
+			require.Equal(t, tc.expectedJustified, s.CurrentJustifiedCheckpoint())
+			require.Equal(t, tc.expectedFinalized, s.FinalizedCheckpoint())
+		})
+	}
+}
+
+func TestProcessJustificationAndFinalization(t *testing.T) {
+	// Nothing is justified during the first epochs.
+	s := getTestActiveState(2*SLOTS_PER_EPOCH - 1)
+	for i := range s.CurrentEpochParticipation() {
+		s.CurrentEpochParticipation()[i] = 0xff
+	}
+	require.NoError(t, ProcessJustificationAndFinalization(s))
+	require.Equal(t, &cltypes.Checkpoint{}, s.CurrentJustifiedCheckpoint())
+
+	// Two thirds of the balance voted for the target of the current epoch, the previous epoch has no votes.
+	s = getTestActiveState(3*SLOTS_PER_EPOCH - 1)
+	participation := s.CurrentEpochParticipation()
+	for i := 0; i < testActiveValidators*2/3+1; i++ {
+		participation[i] = 1 << clparams.MainnetBeaconConfig.TimelyTargetFlagIndex
+	}
+	// Slashed validators do not count.
+	s.ValidatorAt(0).Slashed = true
+	require.NoError(t, ProcessJustificationAndFinalization(s))
+	require.Equal(t, &cltypes.Checkpoint{}, s.CurrentJustifiedCheckpoint())
+
+	s.ValidatorAt(0).Slashed = false
+	require.NoError(t, ProcessJustificationAndFinalization(s))
+	require.Equal(t, &cltypes.Checkpoint{Epoch: 2, Root: [32]byte{3}}, s.CurrentJustifiedCheckpoint())
+	require.Equal(t, []byte{0b0001}, s.JustificationBits())
+}
+
+func TestProcessEpochResets(t *testing.T) {
+	s := getTestActiveState(SLOTS_PER_EPOCH - 1)
+	s.SetRandaoMixAt(0, [32]byte{1})
+	s.CurrentEpochParticipation()[0] = 1
+	require.NoError(t, New(s, testBeaconConfig, nil).processEpoch())
+	require.Equal(t, [32]byte{1}, s.RandaoMixes()[1])
+	require.Equal(t, byte(1), s.PreviousEpochParticipation()[0])
+	require.Equal(t, make([]byte, testActiveValidators), s.CurrentEpochParticipation())
+}
+
+func TestProcessRewardsAndPenalties(t *testing.T) {
+	// Full participation in the previous epoch is rewarded.
+	s := getTestActiveState(3*SLOTS_PER_EPOCH - 1)
+	for i := range s.PreviousEpochParticipation() {
+		s.PreviousEpochParticipation()[i] = 0b111
+	}
+	require.NoError(t, ProcessRewardsAndPenalties(s))
+	for _, balance := range s.Balances() {
+		require.Greater(t, balance, clparams.MainnetBeaconConfig.MaxEffectiveBalance)
+	}
+
+	// Missing source and target votes is penalized, as well as inactivity.
+	s = getTestActiveState(3*SLOTS_PER_EPOCH - 1)
+	s.InactivityScores()[0] = 1 << 20
+	require.NoError(t, ProcessRewardsAndPenalties(s))
+	baseReward := GetBaseReward(s, 0, GetBaseRewardPerIncrement(GetTotalActiveBalance(s)))
+	flagPenalty := baseReward*clparams.MainnetBeaconConfig.TimelySourceWeight/clparams.MainnetBeaconConfig.WeightDenominator +
+		baseReward*clparams.MainnetBeaconConfig.TimelyTargetWeight/clparams.MainnetBeaconConfig.WeightDenominator
+	inactivityPenalty := clparams.MainnetBeaconConfig.MaxEffectiveBalance * (1 << 20) /
+		(clparams.MainnetBeaconConfig.InactivityScoreBias * clparams.MainnetBeaconConfig.InactivityPenaltyQuotientBellatrix)
+	require.Equal(t, clparams.MainnetBeaconConfig.MaxEffectiveBalance-flagPenalty-inactivityPenalty, s.Balances()[0])
+	require.Equal(t, clparams.MainnetBeaconConfig.MaxEffectiveBalance-flagPenalty, s.Balances()[1])
+}
+
+func TestProcessInactivityUpdates(t *testing.T) {
+	s := getTestActiveState(3*SLOTS_PER_EPOCH - 1)
+	s.PreviousEpochParticipation()[0] = 1 << clparams.MainnetBeaconConfig.TimelyTargetFlagIndex
+	s.InactivityScores()[0] = 10
+	require.NoError(t, ProcessInactivityUpdates(s))
+	// Out of an inactivity leak, scores also recover.
+	require.Equal(t, uint64(0), s.InactivityScores()[0])
+	require.Equal(t, uint64(0), s.InactivityScores()[1])
+
+	// Finality is late by more than MinEpochsToInactivityPenalty epochs.
+	s = getTestActiveState(7*SLOTS_PER_EPOCH - 1)
+	s.PreviousEpochParticipation()[0] = 1 << clparams.MainnetBeaconConfig.TimelyTargetFlagIndex
+	s.InactivityScores()[0] = 10
+	require.NoError(t, ProcessInactivityUpdates(s))
+	require.Equal(t, uint64(9), s.InactivityScores()[0])
+	require.Equal(t, clparams.MainnetBeaconConfig.InactivityScoreBias, s.InactivityScores()[1])
+}
+
+func TestProcessRegistryUpdates(t *testing.T) {
+	s := getTestActiveState(SLOTS_PER_EPOCH - 1)
+	// Validator 0 is ejected.
+	s.ValidatorAt(0).EffectiveBalance = clparams.MainnetBeaconConfig.EjectionBalance
+	// Validator 1 just made a deposit.
+	newValidator := s.ValidatorAt(1)
+	newValidator.ActivationEligibilityEpoch = clparams.MainnetBeaconConfig.FarFutureEpoch
+	newValidator.ActivationEpoch = clparams.MainnetBeaconConfig.FarFutureEpoch
+	// Validator 2 is eligible since a finalized epoch.
+	queuedValidator := s.ValidatorAt(2)
+	queuedValidator.ActivationEpoch = clparams.MainnetBeaconConfig.FarFutureEpoch
+
+	ProcessRegistryUpdates(s)
+	require.Equal(t, ComputeActivationExitEpoch(0), s.ValidatorAt(0).ExitEpoch)
+	require.Equal(t, uint64(1), newValidator.ActivationEligibilityEpoch)
+	require.Equal(t, clparams.MainnetBeaconConfig.FarFutureEpoch, newValidator.ActivationEpoch)
+	require.Equal(t, ComputeActivationExitEpoch(0), queuedValidator.ActivationEpoch)
+}
+
+func TestProcessSlashings(t *testing.T) {
+	s := getTestActiveState(SLOTS_PER_EPOCH - 1)
+	validator := s.ValidatorAt(0)
+	validator.Slashed = true
+	validator.WithdrawableEpoch = clparams.MainnetBeaconConfig.EpochsPerSlashingsVector / 2
+	s.SetSlashingAt(0, clparams.MainnetBeaconConfig.MaxEffectiveBalance)
+	ProcessSlashings(s)
+	// One validator out of 64 was slashed, the penalty is three times its share of the total balance.
+	increment := clparams.MainnetBeaconConfig.EffectiveBalanceIncrement
+	totalBalance := testActiveValidators * clparams.MainnetBeaconConfig.MaxEffectiveBalance
+	penalty := clparams.MainnetBeaconConfig.MaxEffectiveBalance / increment * 3 * clparams.MainnetBeaconConfig.MaxEffectiveBalance / totalBalance * increment
+	require.Equal(t, clparams.MainnetBeaconConfig.MaxEffectiveBalance-penalty, s.Balances()[0])
+	require.Equal(t, clparams.MainnetBeaconConfig.MaxEffectiveBalance, s.Balances()[1])
+}
+
+func TestProcessEffectiveBalanceUpdates(t *testing.T) {
+	s := getTestActiveState(SLOTS_PER_EPOCH - 1)
+	maxBalance := clparams.MainnetBeaconConfig.MaxEffectiveBalance
+	increment := clparams.MainnetBeaconConfig.EffectiveBalanceIncrement
+	// Within the hysteresis thresholds.
+	s.SetBalanceAt(0, maxBalance-increment/4)
+	// Below the downward threshold.
+	s.SetBalanceAt(1, maxBalance-increment/2)
+	// Above the upward threshold, but capped.
+	s.SetBalanceAt(2, maxBalance+2*increment)
+	s.ValidatorAt(3).EffectiveBalance = maxBalance - 2*increment
+	ProcessEffectiveBalanceUpdates(s)
+	require.Equal(t, maxBalance, s.ValidatorAt(0).EffectiveBalance)
+	require.Equal(t, maxBalance-increment, s.ValidatorAt(1).EffectiveBalance)
+	require.Equal(t, maxBalance, s.ValidatorAt(2).EffectiveBalance)
+	require.Equal(t, maxBalance, s.ValidatorAt(3).EffectiveBalance)
+}
+
+func TestProcessSyncCommitteeUpdates(t *testing.T) {
+	// Last slot of the epoch before the last one of the period.
+	s := getTestActiveState((clparams.MainnetBeaconConfig.EpochsPerSyncCommitteePeriod-1)*SLOTS_PER_EPOCH - 1)
+	require.NoError(t, ProcessSyncCommitteeUpdates(s))
+	require.Equal(t, make([][48]byte, clparams.MainnetBeaconConfig.SyncCommitteeSize), s.NextSyncCommittee().PubKeys)
+
+	nextSyncCommittee := s.NextSyncCommittee()
+	s.SetSlot(s.Slot() + SLOTS_PER_EPOCH)
+	require.NoError(t, ProcessSyncCommitteeUpdates(s))
+	require.Equal(t, nextSyncCommittee, s.CurrentSyncCommittee())
+	committee := s.NextSyncCommittee()
+	require.Len(t, committee.PubKeys, int(clparams.MainnetBeaconConfig.SyncCommitteeSize))
+	aggregate := new(blst.P1Aggregate)
+	for _, pubKey := range committee.PubKeys {
+		require.True(t, aggregate.AggregateCompressed([][]byte{pubKey[:]}, false))
+	}
+	require.Equal(t, aggregate.ToAffine().Compress(), committee.AggregatePublicKey[:])
+}
+
+func TestProcessHistoricalRootsUpdate(t *testing.T) {
+	s := getTestActiveState(SLOTS_PER_EPOCH - 1)
+	require.NoError(t, ProcessHistoricalRootsUpdate(s))
+	require.Empty(t, s.HistoricalRoots())
+	s.SetSlot(clparams.MainnetBeaconConfig.SlotsPerHistoricalRoot - 1)
+	require.NoError(t, ProcessHistoricalRootsUpdate(s))
+	require.Len(t, s.HistoricalRoots(), 1)
+}
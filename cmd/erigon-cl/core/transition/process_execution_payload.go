@@ -0,0 +1,89 @@
+package transition
+
+import (
+	"fmt"
+
+	ssz "github.com/prysmaticlabs/fastssz"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+)
+
+const (
+	maxTransactionsPerPayload = 1048576
+	maxBytesPerTransaction    = 1073741824
+)
+
+// IsMergeTransitionComplete tells whether the state already carries an execution payload header.
+func IsMergeTransitionComplete(state *state.BeaconState) bool {
+	header := state.LatestExecutionPayloadHeader()
+	return header != nil && header.BlockHash != [32]byte{}
+}
+
+// IsExecutionEnabled tells whether the block carries an execution payload to process.
+func IsExecutionEnabled(state *state.BeaconState, body *cltypes.BeaconBodyBellatrix) bool {
+	return IsMergeTransitionComplete(state) || (body.ExecutionPayload != nil && body.ExecutionPayload.BlockHash != [32]byte{})
+}
+
+// ComputeTimestampAtSlot returns the unix time of the start of the given slot.
+func ComputeTimestampAtSlot(state *state.BeaconState, slot uint64) uint64 {
+	return state.GenesisTime() + slot*clparams.MainnetBeaconConfig.SecondsPerSlot
+}
+
+// transactionsRoot returns the ssz root of the payload transactions, as found in the execution payload header.
+func transactionsRoot(transactions [][]byte) ([32]byte, error) {
+	if len(transactions) > maxTransactionsPerPayload {
+		return [32]byte{}, ssz.ErrIncorrectListSize
+	}
+	hh := ssz.NewHasher()
+	indx := hh.Index()
+	for _, transaction := range transactions {
+		if len(transaction) > maxBytesPerTransaction {
+			return [32]byte{}, ssz.ErrIncorrectListSize
+		}
+		elemIndx := hh.Index()
+		hh.AppendBytes32(transaction)
+		hh.MerkleizeWithMixin(elemIndx, uint64(len(transaction)), (maxBytesPerTransaction+31)/32)
+	}
+	hh.MerkleizeWithMixin(indx, uint64(len(transactions)), maxTransactionsPerPayload)
+	return hh.HashRoot()
+}
+
+// ProcessExecutionPayload checks the payload is consistent with the state and caches its header. The payload itself
+// is validated by the execution engine.
+func ProcessExecutionPayload(state *state.BeaconState, payload *cltypes.ExecutionPayload) error {
+	if payload == nil {
+		return fmt.Errorf("block has no execution payload")
+	}
+	if IsMergeTransitionComplete(state) && payload.ParentHash != state.LatestExecutionPayloadHeader().BlockHash {
+		return fmt.Errorf("execution payload parent hash: %x, does not match latest block hash: %x", payload.ParentHash, state.LatestExecutionPayloadHeader().BlockHash)
+	}
+	if randaoMix := GetRandaoMixes(state, GetEpochAtSlot(state.Slot())); payload.PrevRandao != randaoMix {
+		return fmt.Errorf("execution payload prev randao: %x, does not match randao mix: %x", payload.PrevRandao, randaoMix)
+	}
+	if timestamp := ComputeTimestampAtSlot(state, state.Slot()); payload.Timestamp != timestamp {
+		return fmt.Errorf("execution payload timestamp: %d, does not match slot timestamp: %d", payload.Timestamp, timestamp)
+	}
+	txRoot, err := transactionsRoot(payload.Transactions)
+	if err != nil {
+		return fmt.Errorf("unable to compute transactions root: %v", err)
+	}
+	state.SetLatestExecutionPayloadHeader(&cltypes.ExecutionHeader{
+		ParentHash:      payload.ParentHash,
+		FeeRecipient:    payload.FeeRecipient,
+		StateRoot:       payload.StateRoot,
+		ReceiptsRoot:    payload.ReceiptsRoot,
+		LogsBloom:       append([]byte{}, payload.LogsBloom...),
+		PrevRandao:      payload.PrevRandao,
+		BlockNumber:     payload.BlockNumber,
+		GasLimit:        payload.GasLimit,
+		GasUsed:         payload.GasUsed,
+		Timestamp:       payload.Timestamp,
+		ExtraData:       append([]byte{}, payload.ExtraData...),
+		BaseFeePerGas:   append([]byte{}, payload.BaseFeePerGas...),
+		BlockHash:       payload.BlockHash,
+		TransactionRoot: txRoot,
+	})
+	return nil
+}
@@ -0,0 +1,54 @@
+package transition
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessExecutionPayload(t *testing.T) {
+	s := getTestActiveState(40)
+	s.SetGenesisTime(1000)
+	s.SetLatestExecutionPayloadHeader(&cltypes.ExecutionHeader{
+		LogsBloom:     make([]byte, 256),
+		BaseFeePerGas: make([]byte, 32),
+		BlockHash:     [32]byte{1},
+	})
+	s.SetRandaoMixAt(1, [32]byte{2})
+	payload := func() *cltypes.ExecutionPayload {
+		return &cltypes.ExecutionPayload{
+			ParentHash:    [32]byte{1},
+			LogsBloom:     make([]byte, 256),
+			PrevRandao:    [32]byte{2},
+			BlockNumber:   2,
+			Timestamp:     1000 + 40*clparams.MainnetBeaconConfig.SecondsPerSlot,
+			BaseFeePerGas: make([]byte, 32),
+			BlockHash:     [32]byte{3},
+			Transactions:  [][]byte{{1, 2, 3}, make([]byte, 100)},
+		}
+	}
+	require.True(t, IsMergeTransitionComplete(s))
+
+	wrongParent := payload()
+	wrongParent.ParentHash = [32]byte{9}
+	require.Error(t, ProcessExecutionPayload(s, wrongParent))
+	wrongRandao := payload()
+	wrongRandao.PrevRandao = [32]byte{9}
+	require.Error(t, ProcessExecutionPayload(s, wrongRandao))
+	wrongTimestamp := payload()
+	wrongTimestamp.Timestamp++
+	require.Error(t, ProcessExecutionPayload(s, wrongTimestamp))
+
+	valid := payload()
+	require.NoError(t, ProcessExecutionPayload(s, valid))
+	header := s.LatestExecutionPayloadHeader()
+	require.Equal(t, valid.BlockHash, header.BlockHash)
+	// The header commits to the same data as the payload.
+	payloadRoot, err := valid.HashTreeRoot()
+	require.NoError(t, err)
+	headerRoot, err := header.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, payloadRoot, headerRoot)
+}
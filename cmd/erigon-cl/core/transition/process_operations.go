@@ -0,0 +1,302 @@
+package transition
+
+import (
+	"fmt"
+
+	"github.com/Giulio2002/bls"
+	ssz "github.com/prysmaticlabs/fastssz"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/fork"
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+)
+
+// ProcessEth1Data records the eth1 data vote of the block and adopts it once it has a majority of the voting period.
+func ProcessEth1Data(state *state.BeaconState, body *cltypes.BeaconBodyBellatrix) {
+	if body.Eth1Data == nil {
+		return
+	}
+	state.AddEth1DataVote(body.Eth1Data)
+	votes := uint64(0)
+	for _, vote := range state.Eth1DataVotes() {
+		if *vote == *body.Eth1Data {
+			votes++
+		}
+	}
+	if votes*2 > clparams.MainnetBeaconConfig.EpochsPerEth1VotingPeriod*SLOTS_PER_EPOCH {
+		state.SetEth1Data(body.Eth1Data)
+	}
+}
+
+// ProcessOperations runs the slashings, attestations, deposits and voluntary exits of the block body.
+func ProcessOperations(state *state.BeaconState, body *cltypes.BeaconBodyBellatrix) error {
+	// Blocks must include all the deposits pending in the eth1 data they agree on.
+	expectedDeposits := state.Eth1Data().DepositCount - state.Eth1DepositIndex()
+	if expectedDeposits > clparams.MainnetBeaconConfig.MaxDeposits {
+		expectedDeposits = clparams.MainnetBeaconConfig.MaxDeposits
+	}
+	if uint64(len(body.Deposits)) != expectedDeposits {
+		return fmt.Errorf("block has %d deposits, expected: %d", len(body.Deposits), expectedDeposits)
+	}
+	for _, slashing := range body.ProposerSlashings {
+		if err := ProcessProposerSlashing(state, slashing); err != nil {
+			return fmt.Errorf("unable to process proposer slashing: %v", err)
+		}
+	}
+	for _, slashing := range body.AttesterSlashings {
+		if err := ProcessAttesterSlashing(state, slashing); err != nil {
+			return fmt.Errorf("unable to process attester slashing: %v", err)
+		}
+	}
+	for _, attestation := range body.Attestations {
+		if err := ProcessAttestation(state, attestation); err != nil {
+			return fmt.Errorf("unable to process attestation: %v", err)
+		}
+	}
+	for _, deposit := range body.Deposits {
+		if err := ProcessDeposit(state, deposit); err != nil {
+			return fmt.Errorf("unable to process deposit: %v", err)
+		}
+	}
+	for _, exit := range body.VoluntaryExits {
+		if err := ProcessVoluntaryExit(state, exit); err != nil {
+			return fmt.Errorf("unable to process voluntary exit: %v", err)
+		}
+	}
+	return nil
+}
+
+// ProcessProposerSlashing slashes a proposer that signed two different headers for the same slot.
+func ProcessProposerSlashing(state *state.BeaconState, slashing *cltypes.ProposerSlashing) error {
+	if slashing.Header1 == nil || slashing.Header1.Header == nil || slashing.Header2 == nil || slashing.Header2.Header == nil {
+		return fmt.Errorf("proposer slashing has no headers")
+	}
+	header1 := slashing.Header1.Header
+	header2 := slashing.Header2.Header
+	if header1.Slot != header2.Slot {
+		return fmt.Errorf("non-matching slots: %d, %d", header1.Slot, header2.Slot)
+	}
+	if header1.ProposerIndex != header2.ProposerIndex {
+		return fmt.Errorf("non-matching proposers: %d, %d", header1.ProposerIndex, header2.ProposerIndex)
+	}
+	if *header1 == *header2 {
+		return fmt.Errorf("headers are the same")
+	}
+	if header1.ProposerIndex >= uint64(len(state.Validators())) {
+		return fmt.Errorf("proposer index: %d, out of range", header1.ProposerIndex)
+	}
+	proposer := state.ValidatorAt(int(header1.ProposerIndex))
+	if !IsSlashableValidator(proposer, GetEpochAtSlot(state.Slot())) {
+		return fmt.Errorf("proposer: %d, is not slashable", header1.ProposerIndex)
+	}
+	for _, signedHeader := range []*cltypes.SignedBeaconBlockHeader{slashing.Header1, slashing.Header2} {
+		domain, err := GetDomain(state, clparams.MainnetBeaconConfig.DomainBeaconProposer, GetEpochAtSlot(signedHeader.Header.Slot))
+		if err != nil {
+			return err
+		}
+		signingRoot, err := ComputeSigningRoot(signedHeader.Header, domain)
+		if err != nil {
+			return err
+		}
+		valid, err := bls.Verify(signedHeader.Signature[:], signingRoot[:], proposer.PublicKey[:])
+		if err != nil {
+			return fmt.Errorf("unable to verify header signature: %v", err)
+		}
+		if !valid {
+			return fmt.Errorf("invalid header signature")
+		}
+	}
+	return SlashValidator(state, header1.ProposerIndex)
+}
+
+// isSlashableAttestationData tells whether two attestations are a double vote or a surround vote.
+func isSlashableAttestationData(data1, data2 *cltypes.AttestationData) bool {
+	doubleVote := !attestationDataEqual(data1, data2) && data1.Target.Epoch == data2.Target.Epoch
+	surroundVote := data1.Source.Epoch < data2.Source.Epoch && data2.Target.Epoch < data1.Target.Epoch
+	return doubleVote || surroundVote
+}
+
+func attestationDataEqual(data1, data2 *cltypes.AttestationData) bool {
+	return data1.Slot == data2.Slot && data1.Index == data2.Index && data1.BeaconBlockHash == data2.BeaconBlockHash &&
+		*data1.Source == *data2.Source && *data1.Target == *data2.Target
+}
+
+// ProcessAttesterSlashing slashes the validators that took part in both conflicting attestations.
+func ProcessAttesterSlashing(state *state.BeaconState, slashing *cltypes.AttesterSlashing) error {
+	attestation1 := slashing.Attestation_1
+	attestation2 := slashing.Attestation_2
+	for _, attestation := range []*cltypes.IndexedAttestation{attestation1, attestation2} {
+		if attestation == nil || attestation.Data == nil || attestation.Data.Source == nil || attestation.Data.Target == nil {
+			return fmt.Errorf("attester slashing has no attestation data")
+		}
+	}
+	if !isSlashableAttestationData(attestation1.Data, attestation2.Data) {
+		return fmt.Errorf("attestations are not slashable")
+	}
+	for _, attestation := range []*cltypes.IndexedAttestation{attestation1, attestation2} {
+		valid, err := IsValidIndexedAttestation(state, attestation)
+		if err != nil {
+			return fmt.Errorf("unable to verify indexed attestation: %v", err)
+		}
+		if !valid {
+			return fmt.Errorf("invalid indexed attestation")
+		}
+	}
+
+	inSecond := make(map[uint64]struct{}, len(attestation2.AttestingIndices))
+	for _, index := range attestation2.AttestingIndices {
+		inSecond[index] = struct{}{}
+	}
+	currentEpoch := GetEpochAtSlot(state.Slot())
+	slashedAny := false
+	// Indices of valid indexed attestations are sorted, so validators are slashed in increasing order.
+	for _, index := range attestation1.AttestingIndices {
+		if _, ok := inSecond[index]; !ok {
+			continue
+		}
+		if !IsSlashableValidator(state.ValidatorAt(int(index)), currentEpoch) {
+			continue
+		}
+		if err := SlashValidator(state, index); err != nil {
+			return err
+		}
+		slashedAny = true
+	}
+	if !slashedAny {
+		return fmt.Errorf("no validator slashed")
+	}
+	return nil
+}
+
+// depositMessageRoot returns the root of the deposit data without its signature, which is what depositors sign.
+func depositMessageRoot(data *cltypes.DepositData) ([32]byte, error) {
+	hh := ssz.NewHasher()
+	indx := hh.Index()
+	hh.PutBytes(data.PubKey[:])
+	hh.PutBytes(data.WithdrawalCredentials)
+	hh.PutUint64(data.Amount)
+	hh.Merkleize(indx)
+	return hh.HashRoot()
+}
+
+// isValidMerkleBranch checks the proof of a leaf at the given index of a merkle tree with the given depth.
+func isValidMerkleBranch(leaf [32]byte, branch [][]byte, depth, index uint64, root [32]byte) bool {
+	if uint64(len(branch)) < depth {
+		return false
+	}
+	value := leaf
+	for i := uint64(0); i < depth; i++ {
+		if (index>>i)&1 == 1 {
+			value = utils.Keccak256(branch[i], value[:])
+		} else {
+			value = utils.Keccak256(value[:], branch[i])
+		}
+	}
+	return value == root
+}
+
+// ProcessDeposit checks the deposit proof against the eth1 deposit root and applies it, adding a validator for new keys.
+func ProcessDeposit(state *state.BeaconState, deposit *cltypes.Deposit) error {
+	if deposit.Data == nil {
+		return fmt.Errorf("deposit has no data")
+	}
+	depositRoot, err := deposit.Data.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	// The proof has an extra element for the deposit count mixed in the root.
+	if !isValidMerkleBranch(depositRoot, deposit.Proof, clparams.MainnetBeaconConfig.DepositContractTreeDepth+1, state.Eth1DepositIndex(), state.Eth1Data().Root) {
+		return fmt.Errorf("invalid deposit proof")
+	}
+	state.SetEth1DepositIndex(state.Eth1DepositIndex() + 1)
+
+	for index, validator := range state.Validators() {
+		if validator.PublicKey == deposit.Data.PubKey {
+			IncreaseBalance(state, uint64(index), deposit.Data.Amount)
+			return nil
+		}
+	}
+	// New validators must prove possession of the key, invalid deposits are skipped rather than invalidating the block.
+	messageRoot, err := depositMessageRoot(deposit.Data)
+	if err != nil {
+		return err
+	}
+	// Deposits are valid across forks, so the domain uses the genesis fork version and no validators root.
+	var genesisForkVersion [4]byte
+	copy(genesisForkVersion[:], clparams.MainnetBeaconConfig.GenesisForkVersion)
+	domain, err := fork.ComputeDomain(clparams.MainnetBeaconConfig.DomainDeposit[:], genesisForkVersion, [32]byte{})
+	if err != nil {
+		return err
+	}
+	signingRoot, err := ComputeSigningRootFromRoot(messageRoot, domain)
+	if err != nil {
+		return err
+	}
+	valid, err := bls.Verify(deposit.Data.Signature[:], signingRoot[:], deposit.Data.PubKey[:])
+	if err != nil || !valid {
+		return nil
+	}
+
+	effectiveBalance := deposit.Data.Amount - deposit.Data.Amount%clparams.MainnetBeaconConfig.EffectiveBalanceIncrement
+	if effectiveBalance > clparams.MainnetBeaconConfig.MaxEffectiveBalance {
+		effectiveBalance = clparams.MainnetBeaconConfig.MaxEffectiveBalance
+	}
+	state.AddValidator(&cltypes.Validator{
+		PublicKey:                  deposit.Data.PubKey,
+		WithdrawalCredentials:      append([]byte{}, deposit.Data.WithdrawalCredentials...),
+		EffectiveBalance:           effectiveBalance,
+		ActivationEligibilityEpoch: clparams.MainnetBeaconConfig.FarFutureEpoch,
+		ActivationEpoch:            clparams.MainnetBeaconConfig.FarFutureEpoch,
+		ExitEpoch:                  clparams.MainnetBeaconConfig.FarFutureEpoch,
+		WithdrawableEpoch:          clparams.MainnetBeaconConfig.FarFutureEpoch,
+	})
+	state.AddBalance(deposit.Data.Amount)
+	state.SetPreviousEpochParticipation(append(state.PreviousEpochParticipation(), 0))
+	state.SetCurrentEpochParticipation(append(state.CurrentEpochParticipation(), 0))
+	state.SetInactivityScores(append(state.InactivityScores(), 0))
+	return nil
+}
+
+// ProcessVoluntaryExit initiates the exit of a validator that signed a voluntary exit.
+func ProcessVoluntaryExit(state *state.BeaconState, signedExit *cltypes.SignedVoluntaryExit) error {
+	exit := signedExit.VolunaryExit
+	if exit == nil {
+		return fmt.Errorf("voluntary exit has no message")
+	}
+	if exit.ValidatorIndex >= uint64(len(state.Validators())) {
+		return fmt.Errorf("validator index: %d, out of range", exit.ValidatorIndex)
+	}
+	validator := state.ValidatorAt(int(exit.ValidatorIndex))
+	currentEpoch := GetEpochAtSlot(state.Slot())
+	if !IsActiveValidator(validator, currentEpoch) {
+		return fmt.Errorf("validator: %d, is not active", exit.ValidatorIndex)
+	}
+	if validator.ExitEpoch != clparams.MainnetBeaconConfig.FarFutureEpoch {
+		return fmt.Errorf("validator: %d, already exits", exit.ValidatorIndex)
+	}
+	if currentEpoch < exit.Epoch {
+		return fmt.Errorf("exit epoch: %d, is in the future", exit.Epoch)
+	}
+	if currentEpoch < validator.ActivationEpoch+clparams.MainnetBeaconConfig.ShardCommitteePeriod {
+		return fmt.Errorf("validator: %d, has not been active long enough", exit.ValidatorIndex)
+	}
+	domain, err := GetDomain(state, clparams.MainnetBeaconConfig.DomainVoluntaryExit, exit.Epoch)
+	if err != nil {
+		return err
+	}
+	signingRoot, err := ComputeSigningRoot(exit, domain)
+	if err != nil {
+		return err
+	}
+	valid, err := bls.Verify(signedExit.Signature[:], signingRoot[:], validator.PublicKey[:])
+	if err != nil {
+		return fmt.Errorf("unable to verify exit signature: %v", err)
+	}
+	if !valid {
+		return fmt.Errorf("invalid exit signature")
+	}
+	InitiateValidatorExit(state, exit.ValidatorIndex)
+	return nil
+}
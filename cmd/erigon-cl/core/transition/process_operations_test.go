@@ -0,0 +1,218 @@
+package transition
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/fork"
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/stretchr/testify/require"
+	"github.com/supranational/blst/bindings/go"
+)
+
+func signTestObject(t *testing.T, s *state.BeaconState, key *blst.SecretKey, obj interface{ HashTreeRoot() ([32]byte, error) }, domainType [4]byte, epoch uint64) (signature [96]byte) {
+	domain, err := GetDomain(s, domainType, epoch)
+	require.NoError(t, err)
+	signingRoot, err := ComputeSigningRoot(obj, domain)
+	require.NoError(t, err)
+	copy(signature[:], new(blst.P2Affine).Sign(key, signingRoot[:], []byte(testSigningDST)).Compress())
+	return
+}
+
+func TestProcessProposerSlashing(t *testing.T) {
+	s := getTestActiveState(40)
+	proposerIndex, err := GetBeaconProposerIndex(s)
+	require.NoError(t, err)
+	const slashedIndex = 5
+	header1 := &cltypes.BeaconBlockHeader{Slot: 39, ProposerIndex: slashedIndex, BodyRoot: [32]byte{1}}
+	header2 := &cltypes.BeaconBlockHeader{Slot: 39, ProposerIndex: slashedIndex, BodyRoot: [32]byte{2}}
+	slashing := &cltypes.ProposerSlashing{
+		Header1: &cltypes.SignedBeaconBlockHeader{
+			Header:    header1,
+			Signature: signTestObject(t, s, testActiveKeys[slashedIndex], header1, clparams.MainnetBeaconConfig.DomainBeaconProposer, 1),
+		},
+		Header2: &cltypes.SignedBeaconBlockHeader{
+			Header:    header2,
+			Signature: signTestObject(t, s, testActiveKeys[slashedIndex], header2, clparams.MainnetBeaconConfig.DomainBeaconProposer, 1),
+		},
+	}
+
+	// Headers signed by somebody else are rejected.
+	badSignature := *slashing
+	badSignature.Header2 = &cltypes.SignedBeaconBlockHeader{
+		Header:    header2,
+		Signature: signTestObject(t, s, testActiveKeys[slashedIndex+1], header2, clparams.MainnetBeaconConfig.DomainBeaconProposer, 1),
+	}
+	require.Error(t, ProcessProposerSlashing(s, &badSignature))
+	// The same header twice is not a slashable offence.
+	sameHeader := *slashing
+	sameHeader.Header2 = slashing.Header1
+	require.Error(t, ProcessProposerSlashing(s, &sameHeader))
+
+	require.NoError(t, ProcessProposerSlashing(s, slashing))
+	validator := s.ValidatorAt(slashedIndex)
+	require.True(t, validator.Slashed)
+	require.NotEqual(t, clparams.MainnetBeaconConfig.FarFutureEpoch, validator.ExitEpoch)
+	require.Equal(t, 1+clparams.MainnetBeaconConfig.EpochsPerSlashingsVector, validator.WithdrawableEpoch)
+	penalty := clparams.MainnetBeaconConfig.MaxEffectiveBalance / clparams.MainnetBeaconConfig.MinSlashingPenaltyQuotientBellatrix
+	require.Equal(t, clparams.MainnetBeaconConfig.MaxEffectiveBalance-penalty, s.Balances()[slashedIndex])
+	whistleblowerReward := clparams.MainnetBeaconConfig.MaxEffectiveBalance / clparams.MainnetBeaconConfig.WhistleBlowerRewardQuotient
+	require.Equal(t, clparams.MainnetBeaconConfig.MaxEffectiveBalance+whistleblowerReward, s.Balances()[proposerIndex])
+	require.Equal(t, clparams.MainnetBeaconConfig.MaxEffectiveBalance, s.Slashings()[1])
+
+	// A validator can only be slashed once.
+	require.Error(t, ProcessProposerSlashing(s, slashing))
+}
+
+func TestProcessAttesterSlashing(t *testing.T) {
+	s := getTestActiveState(40)
+	indexedAttestation := func(root byte, indices ...uint64) *cltypes.IndexedAttestation {
+		data := &cltypes.AttestationData{
+			Slot:            39,
+			BeaconBlockHash: [32]byte{root},
+			Source:          &cltypes.Checkpoint{},
+			Target:          &cltypes.Checkpoint{Epoch: 1},
+		}
+		aggregate := new(blst.P2Aggregate)
+		for _, index := range indices {
+			signature := signTestObject(t, s, testActiveKeys[index], data, clparams.MainnetBeaconConfig.DomainBeaconAttester, 1)
+			require.True(t, aggregate.AggregateCompressed([][]byte{signature[:]}, false))
+		}
+		attestation := &cltypes.IndexedAttestation{AttestingIndices: indices, Data: data}
+		copy(attestation.Signature[:], aggregate.ToAffine().Compress())
+		return attestation
+	}
+
+	// Same vote twice is not slashable.
+	require.Error(t, ProcessAttesterSlashing(s, &cltypes.AttesterSlashing{
+		Attestation_1: indexedAttestation(1, 2, 3),
+		Attestation_2: indexedAttestation(1, 3, 4),
+	}))
+	// Unsorted indices are invalid.
+	require.Error(t, ProcessAttesterSlashing(s, &cltypes.AttesterSlashing{
+		Attestation_1: indexedAttestation(1, 3, 2),
+		Attestation_2: indexedAttestation(2, 3, 4),
+	}))
+
+	// Only the validators in both attestations are slashed.
+	require.NoError(t, ProcessAttesterSlashing(s, &cltypes.AttesterSlashing{
+		Attestation_1: indexedAttestation(1, 2, 3),
+		Attestation_2: indexedAttestation(2, 3, 4),
+	}))
+	require.False(t, s.ValidatorAt(2).Slashed)
+	require.True(t, s.ValidatorAt(3).Slashed)
+	require.False(t, s.ValidatorAt(4).Slashed)
+}
+
+// getTestDeposit returns a deposit of a new key, with the eth1 data of the deposit tree made of it only.
+func getTestDeposit(t *testing.T) (*cltypes.Deposit, *cltypes.Eth1Data) {
+	key := blst.KeyGen(make([]byte, 32), []byte("deposit"))
+	data := &cltypes.DepositData{
+		WithdrawalCredentials: make([]byte, 32),
+		Amount:                clparams.MainnetBeaconConfig.MaxEffectiveBalance,
+	}
+	copy(data.PubKey[:], new(blst.P1Affine).From(key).Compress())
+	messageRoot, err := depositMessageRoot(data)
+	require.NoError(t, err)
+	domain, err := fork.ComputeDomain(clparams.MainnetBeaconConfig.DomainDeposit[:], [4]byte{}, [32]byte{})
+	require.NoError(t, err)
+	signingRoot, err := ComputeSigningRootFromRoot(messageRoot, domain)
+	require.NoError(t, err)
+	copy(data.Signature[:], new(blst.P2Affine).Sign(key, signingRoot[:], []byte(testSigningDST)).Compress())
+
+	// The deposit is the leftmost leaf, its siblings are empty subtrees.
+	depth := clparams.MainnetBeaconConfig.DepositContractTreeDepth
+	proof := make([][]byte, depth+1)
+	root, err := data.HashTreeRoot()
+	require.NoError(t, err)
+	zeroHash := [32]byte{}
+	for i := uint64(0); i < depth; i++ {
+		proof[i] = append([]byte{}, zeroHash[:]...)
+		root = utils.Keccak256(root[:], zeroHash[:])
+		zeroHash = utils.Keccak256(zeroHash[:], zeroHash[:])
+	}
+	// The deposit count is mixed in the root.
+	proof[depth] = make([]byte, 32)
+	binary.LittleEndian.PutUint64(proof[depth], 1)
+	root = utils.Keccak256(root[:], proof[depth])
+	return &cltypes.Deposit{Proof: proof, Data: data}, &cltypes.Eth1Data{Root: root, DepositCount: 1}
+}
+
+func TestProcessDeposit(t *testing.T) {
+	deposit, eth1Data := getTestDeposit(t)
+
+	s := getTestActiveState(40)
+	s.SetEth1Data(eth1Data)
+	badProof := *deposit
+	badProof.Proof = append([][]byte{make([]byte, 32)}, deposit.Proof[1:]...)
+	badProof.Proof[0][0] = 1
+	require.Error(t, ProcessDeposit(s, &badProof))
+
+	require.NoError(t, ProcessDeposit(s, deposit))
+	require.Equal(t, uint64(1), s.Eth1DepositIndex())
+	require.Len(t, s.Validators(), testActiveValidators+1)
+	validator := s.ValidatorAt(testActiveValidators)
+	require.Equal(t, deposit.Data.PubKey, validator.PublicKey)
+	require.Equal(t, clparams.MainnetBeaconConfig.MaxEffectiveBalance, validator.EffectiveBalance)
+	require.Equal(t, clparams.MainnetBeaconConfig.FarFutureEpoch, validator.ActivationEligibilityEpoch)
+	require.Equal(t, clparams.MainnetBeaconConfig.MaxEffectiveBalance, s.Balances()[testActiveValidators])
+	require.Len(t, s.CurrentEpochParticipation(), testActiveValidators+1)
+	require.Len(t, s.InactivityScores(), testActiveValidators+1)
+
+	// A top up of an existing validator needs no signature.
+	s = getTestActiveState(40)
+	s.SetEth1Data(eth1Data)
+	s.ValidatorAt(0).PublicKey = deposit.Data.PubKey
+	topUp := *deposit
+	topUp.Data = &cltypes.DepositData{
+		PubKey:                deposit.Data.PubKey,
+		WithdrawalCredentials: deposit.Data.WithdrawalCredentials,
+		Amount:                deposit.Data.Amount,
+		Signature:             deposit.Data.Signature,
+	}
+	require.NoError(t, ProcessDeposit(s, &topUp))
+	require.Len(t, s.Validators(), testActiveValidators)
+	require.Equal(t, 2*clparams.MainnetBeaconConfig.MaxEffectiveBalance, s.Balances()[0])
+}
+
+func TestProcessVoluntaryExit(t *testing.T) {
+	s := getTestActiveState(40)
+	// Validators must have been active for a while before exiting.
+	s.SetSlot(clparams.MainnetBeaconConfig.ShardCommitteePeriod * SLOTS_PER_EPOCH)
+	currentEpoch := GetEpochAtSlot(s.Slot())
+	exit := &cltypes.VoluntaryExit{Epoch: currentEpoch, ValidatorIndex: 7}
+	signedExit := &cltypes.SignedVoluntaryExit{
+		VolunaryExit: exit,
+		Signature:    signTestObject(t, s, testActiveKeys[7], exit, clparams.MainnetBeaconConfig.DomainVoluntaryExit, currentEpoch),
+	}
+	badSignature := &cltypes.SignedVoluntaryExit{
+		VolunaryExit: exit,
+		Signature:    signTestObject(t, s, testActiveKeys[8], exit, clparams.MainnetBeaconConfig.DomainVoluntaryExit, currentEpoch),
+	}
+	require.Error(t, ProcessVoluntaryExit(s, badSignature))
+
+	tooEarly := getTestActiveState(40)
+	require.Error(t, ProcessVoluntaryExit(tooEarly, signedExit))
+
+	require.NoError(t, ProcessVoluntaryExit(s, signedExit))
+	validator := s.ValidatorAt(7)
+	require.Equal(t, ComputeActivationExitEpoch(currentEpoch), validator.ExitEpoch)
+	require.Equal(t, validator.ExitEpoch+clparams.MainnetBeaconConfig.MinValidatorWithdrawabilityDelay, validator.WithdrawableEpoch)
+	// Exits are processed once.
+	require.Error(t, ProcessVoluntaryExit(s, signedExit))
+}
+
+func TestProcessEth1Data(t *testing.T) {
+	s := getTestActiveState(40)
+	vote := &cltypes.Eth1Data{Root: [32]byte{1}, DepositCount: 1}
+	majority := clparams.MainnetBeaconConfig.EpochsPerEth1VotingPeriod * SLOTS_PER_EPOCH / 2
+	for i := uint64(0); i < majority; i++ {
+		ProcessEth1Data(s, &cltypes.BeaconBodyBellatrix{Eth1Data: vote})
+	}
+	require.Equal(t, &cltypes.Eth1Data{}, s.Eth1Data())
+	ProcessEth1Data(s, &cltypes.BeaconBodyBellatrix{Eth1Data: vote})
+	require.Equal(t, vote, s.Eth1Data())
+}
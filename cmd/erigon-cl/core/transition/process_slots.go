@@ -4,12 +4,17 @@ import (
 	"fmt"
 
 	"github.com/Giulio2002/bls"
+	"github.com/ledgerwatch/erigon/cl/clparams"
 	"github.com/ledgerwatch/erigon/cl/cltypes"
 )
 
-func (s *StateTransistor) transitionState(block *cltypes.SignedBeaconBlockBellatrix, validate bool) error {
+// TransitionState applies the block to the state. Without validation the block signature and the resulting state root
+// are not checked, operations are always verified.
+func (s *StateTransistor) TransitionState(block *cltypes.SignedBeaconBlockBellatrix, validate bool) error {
 	currentBlock := block.Block
-	s.processSlots(currentBlock.Slot)
+	if err := s.ProcessSlots(currentBlock.Slot); err != nil {
+		return err
+	}
 	if validate {
 		valid, err := s.verifyBlockSignature(block)
 		if err != nil {
@@ -19,7 +24,9 @@ func (s *StateTransistor) transitionState(block *cltypes.SignedBeaconBlockBellat
 			return fmt.Errorf("block not valid")
 		}
 	}
-	// TODO add logic to process block and update state.
+	if err := s.processBlock(currentBlock); err != nil {
+		return err
+	}
 	if validate {
 		expectedStateRoot, err := s.state.HashTreeRoot()
 		if err != nil {
@@ -32,6 +39,32 @@ func (s *StateTransistor) transitionState(block *cltypes.SignedBeaconBlockBellat
 	return nil
 }
 
+// processBlock runs the block processing, the execution payload itself is left to the execution engine.
+func (s *StateTransistor) processBlock(block *cltypes.BeaconBlockBellatrix) error {
+	if block.Body == nil {
+		return fmt.Errorf("block has no body")
+	}
+	if err := ProcessBlockHeader(s.state, block); err != nil {
+		return fmt.Errorf("unable to process block header: %v", err)
+	}
+	if IsExecutionEnabled(s.state, block.Body) {
+		if err := ProcessExecutionPayload(s.state, block.Body.ExecutionPayload); err != nil {
+			return fmt.Errorf("unable to process execution payload: %v", err)
+		}
+	}
+	if err := ProcessRandao(s.state, block.Body); err != nil {
+		return fmt.Errorf("unable to process randao reveal: %v", err)
+	}
+	ProcessEth1Data(s.state, block.Body)
+	if err := ProcessOperations(s.state, block.Body); err != nil {
+		return err
+	}
+	if err := ProcessSyncAggregate(s.state, block.Body.SyncAggregate); err != nil {
+		return fmt.Errorf("unable to process sync aggregate: %v", err)
+	}
+	return nil
+}
+
 // transitionSlot is called each time there is a new slot to process
 func (s *StateTransistor) transitionSlot() error {
 	slot := s.state.Slot()
@@ -55,7 +88,8 @@ func (s *StateTransistor) transitionSlot() error {
 	return nil
 }
 
-func (s *StateTransistor) processSlots(slot uint64) error {
+// ProcessSlots advances the state to the given slot, running the epoch processing at epoch boundaries.
+func (s *StateTransistor) ProcessSlots(slot uint64) error {
 	stateSlot := s.state.Slot()
 	if slot <= stateSlot {
 		return fmt.Errorf("new slot: %d not greater than state slot: %d", slot, stateSlot)
@@ -66,7 +100,11 @@ func (s *StateTransistor) processSlots(slot uint64) error {
 		if err != nil {
 			return fmt.Errorf("unable to process slot transition: %v", err)
 		}
-		// TODO: add logic to process epoch updates.
+		if (stateSlot+1)%SLOTS_PER_EPOCH == 0 {
+			if err := s.processEpoch(); err != nil {
+				return err
+			}
+		}
 		stateSlot += 1
 		s.state.SetSlot(stateSlot)
 	}
@@ -74,8 +112,15 @@ func (s *StateTransistor) processSlots(slot uint64) error {
 }
 
 func (s *StateTransistor) verifyBlockSignature(block *cltypes.SignedBeaconBlockBellatrix) (bool, error) {
+	if block.Block.ProposerIndex >= uint64(len(s.state.Validators())) {
+		return false, fmt.Errorf("proposer index: %d, out of range", block.Block.ProposerIndex)
+	}
 	proposer := s.state.ValidatorAt(int(block.Block.ProposerIndex))
-	sigRoot, err := block.Block.Body.HashTreeRoot()
+	domain, err := GetDomain(s.state, clparams.MainnetBeaconConfig.DomainBeaconProposer, GetEpochAtSlot(s.state.Slot()))
+	if err != nil {
+		return false, err
+	}
+	sigRoot, err := ComputeSigningRoot(block.Block, domain)
 	if err != nil {
 		return false, err
 	}
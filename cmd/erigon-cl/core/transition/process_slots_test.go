@@ -9,6 +9,8 @@ import (
 	"github.com/ledgerwatch/erigon/cl/cltypes"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
 	"github.com/ledgerwatch/erigon/common"
+	"github.com/stretchr/testify/require"
+	"github.com/supranational/blst/bindings/go"
 )
 
 var (
@@ -35,11 +37,6 @@ var (
 		WithdrawalCredentials: make([]byte, 32),
 	}
 	testStateRoot = [32]byte{243, 188, 193, 154, 58, 176, 139, 235, 38, 219, 21, 196, 194, 30, 119, 102, 233, 246, 197, 228, 242, 75, 89, 204, 102, 150, 82, 251, 101, 124, 98, 78}
-
-	stateHashValidator0 = "8545b3e58b298e4b847eba8d6bbc7264df21e36f75924e970aea914bbee82cd9"
-	blockHashValidator0 = "f5b74f03650fb65362badf85660ab2f6e92e8df10af9a981a2b5a4df1d9f2479"
-	stateHashValidator1 = "5e8ab9f10c2afd511b3fd1079b40686716c449b7195e27ecdf393db2d36467e8"
-	blockHashValidator1 = "f5b74f03650fb65362badf85660ab2f6e92e8df10af9a981a2b5a4df1d9f2479"
 )
 
 func getEmptyState() *state.BeaconState {
@@ -283,7 +280,7 @@ func TestProcessSlots(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
 			s := New(tc.prevState, testBeaconConfig, nil)
-			err := s.processSlots(tc.startSlot + tc.numSlots)
+			err := s.ProcessSlots(tc.startSlot + tc.numSlots)
 			if tc.wantErr {
 				if err == nil {
 					t.Errorf("unexpected success, wanted error")
@@ -301,6 +298,8 @@ func TestProcessSlots(t *testing.T) {
 }
 
 func TestVerifyBlockSignature(t *testing.T) {
+	signedBlock := getTestBeaconBlock()
+	signTestBlock(t, getTestBeaconStateWithKey(), signedBlock)
 	badSigBlock := getTestBeaconBlock()
 	badSigBlock.Signature = badSignature
 	testCases := []struct {
@@ -312,8 +311,8 @@ func TestVerifyBlockSignature(t *testing.T) {
 	}{
 		{
 			description: "success",
-			state:       getTestBeaconStateWithValidator(),
-			block:       getTestBeaconBlock(),
+			state:       getTestBeaconStateWithKey(),
+			block:       signedBlock,
 			wantErr:     false,
 			wantValid:   true,
 		},
@@ -325,7 +324,7 @@ func TestVerifyBlockSignature(t *testing.T) {
 		},
 		{
 			description: "failure_bad_signature",
-			state:       getTestBeaconStateWithValidator(),
+			state:       getTestBeaconStateWithKey(),
 			block:       badSigBlock,
 			wantErr:     false,
 			wantValid:   false,
@@ -355,32 +354,99 @@ func TestVerifyBlockSignature(t *testing.T) {
 	}
 }
 
+// testSecretKey signs the blocks and randao reveals of the single validator of getTestBeaconStateWithKey.
+var testSecretKey = blst.KeyGen(make([]byte, 32))
+
+const testSigningDST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+
+func getTestBeaconStateWithKey() *state.BeaconState {
+	res := getTestBeaconState()
+	validator := &cltypes.Validator{
+		WithdrawalCredentials: make([]byte, 32),
+		EffectiveBalance:      clparams.MainnetBeaconConfig.MaxEffectiveBalance,
+		ExitEpoch:             clparams.MainnetBeaconConfig.FarFutureEpoch,
+	}
+	copy(validator.PublicKey[:], new(blst.P1Affine).From(testSecretKey).Compress())
+	res.SetValidators([]*cltypes.Validator{validator})
+	res.SetBalances([]uint64{validator.EffectiveBalance})
+	res.SetPreviousEpochParticipation([]byte{0})
+	res.SetCurrentEpochParticipation([]byte{0})
+	res.SetInactivityScores([]uint64{0})
+	// The validator fills up the sync committees.
+	syncCommittee := &cltypes.SyncCommittee{PubKeys: make([][48]byte, clparams.MainnetBeaconConfig.SyncCommitteeSize)}
+	for i := range syncCommittee.PubKeys {
+		syncCommittee.PubKeys[i] = validator.PublicKey
+	}
+	syncCommittee.AggregatePublicKey = validator.PublicKey
+	res.SetCurrentSyncCommittee(syncCommittee)
+	res.SetNextSyncCommittee(syncCommittee)
+	return res
+}
+
+func testSign(msg []byte) (signature [96]byte) {
+	copy(signature[:], new(blst.P2Affine).Sign(testSecretKey, msg, []byte(testSigningDST)).Compress())
+	return
+}
+
+// signTestBlock signs the block with testSecretKey, on top of a state from getTestBeaconStateWithKey.
+func signTestBlock(t *testing.T, s *state.BeaconState, block *cltypes.SignedBeaconBlockBellatrix) {
+	domain, err := GetDomain(s, clparams.MainnetBeaconConfig.DomainBeaconProposer, GetEpochAtSlot(block.Block.Slot))
+	require.NoError(t, err)
+	signingRoot, err := ComputeSigningRoot(block.Block, domain)
+	require.NoError(t, err)
+	block.Signature = testSign(signingRoot[:])
+}
+
+// getTestSignedBlock builds a valid block at the given slot on top of a state from getTestBeaconStateWithKey.
+func getTestSignedBlock(t *testing.T, preState *state.BeaconState, slot uint64) *cltypes.SignedBeaconBlockBellatrix {
+	advanced, err := preState.Copy()
+	require.NoError(t, err)
+	require.NoError(t, New(advanced, testBeaconConfig, nil).ProcessSlots(slot))
+	parentRoot, err := advanced.LatestBlockHeader().HashTreeRoot()
+	require.NoError(t, err)
+	epoch := GetEpochAtSlot(slot)
+	domain, err := GetDomain(advanced, clparams.MainnetBeaconConfig.DomainRandao, epoch)
+	require.NoError(t, err)
+	randaoRoot, err := ComputeSigningRootEpoch(epoch, domain)
+	require.NoError(t, err)
+
+	block := getTestBeaconBlock()
+	block.Block.Slot = slot
+	block.Block.ParentRoot = parentRoot
+	block.Block.StateRoot = [32]byte{}
+	block.Block.Body.RandaoReveal = testSign(randaoRoot[:])
+	// Nobody takes part in the sync aggregate.
+	block.Block.Body.SyncAggregate.SyncCommiteeSignature = infiniteSignature
+	postState, err := preState.Copy()
+	require.NoError(t, err)
+	require.NoError(t, New(postState, testBeaconConfig, nil).TransitionState(block, false))
+	block.Block.StateRoot, err = postState.HashTreeRoot()
+	require.NoError(t, err)
+	signTestBlock(t, advanced, block)
+	return block
+}
+
 func TestTransitionState(t *testing.T) {
-	slot2 := getTestBeaconBlock()
-	slot2.Block.Slot = 2
-	badSigBlock := getTestBeaconBlock()
+	slot2 := getTestSignedBlock(t, getTestBeaconStateWithKey(), 2)
+	badSigBlock := getTestSignedBlock(t, getTestBeaconStateWithKey(), 2)
 	badSigBlock.Signature = badSignature
-	badStateRootBlock := getTestBeaconBlock()
-	badStateRootBlock.Block.StateRoot = [32]byte{}
+	badStateRootBlock := getTestSignedBlock(t, getTestBeaconStateWithKey(), 2)
+	badStateRootBlock.Block.StateRoot = [32]byte{1}
+	badRandaoBlock := getTestSignedBlock(t, getTestBeaconStateWithKey(), 2)
+	badRandaoBlock.Block.Body.RandaoReveal = badSignature
+	badParentBlock := getTestSignedBlock(t, getTestBeaconStateWithKey(), 2)
+	badParentBlock.Block.ParentRoot = [32]byte{1}
 	testCases := []struct {
-		description   string
-		prevState     *state.BeaconState
-		block         *cltypes.SignedBeaconBlockBellatrix
-		expectedState *state.BeaconState
-		wantErr       bool
+		description string
+		prevState   *state.BeaconState
+		block       *cltypes.SignedBeaconBlockBellatrix
+		wantErr     bool
 	}{
 		{
 			description: "success_2_slots",
-			prevState:   getTestBeaconStateWithValidator(),
+			prevState:   getTestBeaconStateWithKey(),
 			block:       slot2,
-			expectedState: prepareNextBeaconState(
-				t,
-				[]uint64{0, 1},
-				[]string{stateHashValidator0, stateHashValidator1},
-				[]string{blockHashValidator0, blockHashValidator1},
-				getTestBeaconStateWithValidator(),
-			),
-			wantErr: false,
+			wantErr:     false,
 		},
 		{
 			description: "error_empty_block_body",
@@ -390,22 +456,34 @@ func TestTransitionState(t *testing.T) {
 		},
 		{
 			description: "error_bad_signature",
-			prevState:   getTestBeaconStateWithValidator(),
+			prevState:   getTestBeaconStateWithKey(),
 			block:       badSigBlock,
 			wantErr:     true,
 		},
 		{
 			description: "error_bad_state_root",
-			prevState:   getTestBeaconStateWithValidator(),
+			prevState:   getTestBeaconStateWithKey(),
 			block:       badStateRootBlock,
 			wantErr:     true,
 		},
+		{
+			description: "error_bad_randao_reveal",
+			prevState:   getTestBeaconStateWithKey(),
+			block:       badRandaoBlock,
+			wantErr:     true,
+		},
+		{
+			description: "error_bad_parent_root",
+			prevState:   getTestBeaconStateWithKey(),
+			block:       badParentBlock,
+			wantErr:     true,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
 			s := New(tc.prevState, testBeaconConfig, nil)
-			err := s.transitionState(tc.block, true)
+			err := s.TransitionState(tc.block, true)
 			if tc.wantErr {
 				if err == nil {
 					t.Errorf("unexpected success, wanted error")
@@ -417,7 +495,13 @@ func TestTransitionState(t *testing.T) {
 			if err != nil {
 				t.Errorf("unexpected error: %v", err)
 			}
-			assertStateEq(t, tc.prevState, tc.expectedState)
+			bodyRoot, err := tc.block.Block.Body.HashTreeRoot()
+			require.NoError(t, err)
+			require.Equal(t, &cltypes.BeaconBlockHeader{
+				Slot:       tc.block.Block.Slot,
+				ParentRoot: tc.block.Block.ParentRoot,
+				BodyRoot:   bodyRoot,
+			}, tc.prevState.LatestBlockHeader())
 		})
 	}
 }
@@ -0,0 +1,93 @@
+package transition
+
+import (
+	"fmt"
+
+	"github.com/Giulio2002/bls"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+)
+
+// infiniteSignature is the G2 point at infinity, the only valid signature of an aggregate without participants.
+var infiniteSignature = [96]byte{0xc0}
+
+// ProcessSyncAggregate verifies the sync committee signature of the previous block root and rewards or penalizes the
+// committee members and the proposer accordingly.
+func ProcessSyncAggregate(state *state.BeaconState, aggregate *cltypes.SyncAggregate) error {
+	if aggregate == nil {
+		return fmt.Errorf("block has no sync aggregate")
+	}
+	committee := state.CurrentSyncCommittee()
+	if committee == nil {
+		return fmt.Errorf("state has no current sync committee")
+	}
+	if len(aggregate.SyncCommiteeBits)*8 < len(committee.PubKeys) {
+		return fmt.Errorf("sync committee bits too short: %d", len(aggregate.SyncCommiteeBits))
+	}
+	participantKeys := [][]byte{}
+	for i := range committee.PubKeys {
+		if aggregate.SyncCommiteeBits[i/8]&(1<<(i%8)) != 0 {
+			participantKeys = append(participantKeys, committee.PubKeys[i][:])
+		}
+	}
+
+	previousSlot := uint64(0)
+	if state.Slot() > 0 {
+		previousSlot = state.Slot() - 1
+	}
+	if len(participantKeys) == 0 {
+		if aggregate.SyncCommiteeSignature != infiniteSignature {
+			return fmt.Errorf("sync aggregate without participants must have the infinite signature")
+		}
+	} else {
+		domain, err := GetDomain(state, clparams.MainnetBeaconConfig.DomainSyncCommittee, GetEpochAtSlot(previousSlot))
+		if err != nil {
+			return err
+		}
+		blockRoot, err := GetBlockRootAtSlot(state, previousSlot)
+		if err != nil {
+			return err
+		}
+		signingRoot, err := ComputeSigningRootFromRoot(blockRoot, domain)
+		if err != nil {
+			return err
+		}
+		valid, err := bls.VerifyAggregate(aggregate.SyncCommiteeSignature[:], signingRoot[:], participantKeys)
+		if err != nil {
+			return fmt.Errorf("unable to verify sync aggregate signature: %v", err)
+		}
+		if !valid {
+			return fmt.Errorf("invalid sync aggregate signature")
+		}
+	}
+
+	totalActiveIncrements := GetTotalActiveBalance(state) / clparams.MainnetBeaconConfig.EffectiveBalanceIncrement
+	totalBaseRewards := GetBaseRewardPerIncrement(GetTotalActiveBalance(state)) * totalActiveIncrements
+	maxParticipantRewards := totalBaseRewards * clparams.MainnetBeaconConfig.SyncRewardWeight / clparams.MainnetBeaconConfig.WeightDenominator / SLOTS_PER_EPOCH
+	participantReward := maxParticipantRewards / clparams.MainnetBeaconConfig.SyncCommitteeSize
+	proposerReward := participantReward * clparams.MainnetBeaconConfig.ProposerWeight / (clparams.MainnetBeaconConfig.WeightDenominator - clparams.MainnetBeaconConfig.ProposerWeight)
+
+	proposerIndex, err := GetBeaconProposerIndex(state)
+	if err != nil {
+		return err
+	}
+	validatorIndices := make(map[[48]byte]uint64, len(state.Validators()))
+	for index, validator := range state.Validators() {
+		validatorIndices[validator.PublicKey] = uint64(index)
+	}
+	for i, pubKey := range committee.PubKeys {
+		index, ok := validatorIndices[pubKey]
+		if !ok {
+			return fmt.Errorf("sync committee member: %x, is not a validator", pubKey)
+		}
+		if aggregate.SyncCommiteeBits[i/8]&(1<<(i%8)) != 0 {
+			IncreaseBalance(state, index, participantReward)
+			IncreaseBalance(state, proposerIndex, proposerReward)
+		} else {
+			DecreaseBalance(state, index, participantReward)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package transition
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/stretchr/testify/require"
+	"github.com/supranational/blst/bindings/go"
+)
+
+// getTestSyncState returns a state from getTestActiveState whose sync committee cycles through all the validators.
+func getTestSyncState(slot uint64) *state.BeaconState {
+	s := getTestActiveState(slot)
+	committee := &cltypes.SyncCommittee{PubKeys: make([][48]byte, clparams.MainnetBeaconConfig.SyncCommitteeSize)}
+	for i := range committee.PubKeys {
+		committee.PubKeys[i] = s.ValidatorAt(i % testActiveValidators).PublicKey
+	}
+	s.SetCurrentSyncCommittee(committee)
+	return s
+}
+
+func signTestSyncAggregate(t *testing.T, s *state.BeaconState, bits []byte) *cltypes.SyncAggregate {
+	previousSlot := s.Slot() - 1
+	domain, err := GetDomain(s, clparams.MainnetBeaconConfig.DomainSyncCommittee, GetEpochAtSlot(previousSlot))
+	require.NoError(t, err)
+	blockRoot, err := GetBlockRootAtSlot(s, previousSlot)
+	require.NoError(t, err)
+	signingRoot, err := ComputeSigningRootFromRoot(blockRoot, domain)
+	require.NoError(t, err)
+	aggregate := new(blst.P2Aggregate)
+	for i := 0; i < int(clparams.MainnetBeaconConfig.SyncCommitteeSize); i++ {
+		if bits[i/8]&(1<<(i%8)) != 0 {
+			require.True(t, aggregate.Add(new(blst.P2Affine).Sign(testActiveKeys[i%testActiveValidators], signingRoot[:], []byte(testSigningDST)), false))
+		}
+	}
+	syncAggregate := &cltypes.SyncAggregate{SyncCommiteeBits: bits}
+	copy(syncAggregate.SyncCommiteeSignature[:], aggregate.ToAffine().Compress())
+	return syncAggregate
+}
+
+func TestProcessSyncAggregate(t *testing.T) {
+	fullBits := make([]byte, clparams.MainnetBeaconConfig.SyncCommitteeSize/8)
+	for i := range fullBits {
+		fullBits[i] = 0xff
+	}
+
+	s := getTestSyncState(40)
+	proposerIndex, err := GetBeaconProposerIndex(s)
+	require.NoError(t, err)
+	require.NoError(t, ProcessSyncAggregate(s, signTestSyncAggregate(t, s, fullBits)))
+	for index, balance := range s.Balances() {
+		require.Greater(t, balance, clparams.MainnetBeaconConfig.MaxEffectiveBalance, "validator %d", index)
+	}
+	require.Greater(t, s.Balances()[proposerIndex], s.Balances()[(proposerIndex+1)%testActiveValidators])
+
+	// Without participants, only the infinite signature is valid and the whole committee is penalized.
+	s = getTestSyncState(40)
+	emptyBits := make([]byte, clparams.MainnetBeaconConfig.SyncCommitteeSize/8)
+	require.Error(t, ProcessSyncAggregate(s, &cltypes.SyncAggregate{SyncCommiteeBits: emptyBits}))
+	require.NoError(t, ProcessSyncAggregate(s, &cltypes.SyncAggregate{SyncCommiteeBits: emptyBits, SyncCommiteeSignature: infiniteSignature}))
+	for index, balance := range s.Balances() {
+		require.Less(t, balance, clparams.MainnetBeaconConfig.MaxEffectiveBalance, "validator %d", index)
+	}
+
+	// The signature must match the participants.
+	s = getTestSyncState(40)
+	syncAggregate := signTestSyncAggregate(t, s, fullBits)
+	syncAggregate.SyncCommiteeBits = append([]byte{0x7f}, fullBits[1:]...)
+	require.Error(t, ProcessSyncAggregate(s, syncAggregate))
+}
@@ -0,0 +1,139 @@
+package forkchoice
+
+import (
+	"fmt"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/transition"
+	"github.com/ledgerwatch/erigon/common"
+)
+
+// The methods below let the store act as the chain view of the sentinel gossip validation.
+// They are called for every gossiped message, so the head and the shufflings are cached.
+
+const shufflingCacheSize = 16
+
+// shufflingKey identifies the shuffling of an epoch by the last block before the epoch, which determines it.
+type shufflingKey struct {
+	epoch         uint64
+	dependentRoot common.Hash
+}
+
+// shuffling holds the proposers and the committees of an epoch, committees are computed on first use.
+type shuffling struct {
+	activeIndices     []uint64
+	seed              [32]byte
+	committeesPerSlot uint64
+	proposers         []uint64            // By slot in the epoch.
+	committees        map[uint64][]uint64 // By committee position in the epoch.
+}
+
+// headState returns the post state of the current head.
+func (f *Store) headState() (*state.BeaconState, error) {
+	return f.getBlockState(f.getHead())
+}
+
+// shufflingState returns a state able to compute the shuffling of an epoch on the chain of the given block, that is
+// the post state of the block advanced to the start of the epoch when the epoch is past the block.
+func (f *Store) shufflingState(root common.Hash, epoch uint64) (*state.BeaconState, error) {
+	blockState, err := f.getBlockState(root)
+	if err != nil {
+		return nil, err
+	}
+	if epoch <= f.computeEpochAtSlot(blockState.Slot()) {
+		return blockState, nil
+	}
+	return f.checkpointState(&cltypes.Checkpoint{Epoch: epoch, Root: root})
+}
+
+// getShuffling returns the shuffling of an epoch on the chain of the given block.
+func (f *Store) getShuffling(root common.Hash, epoch uint64) (*shuffling, error) {
+	dependentSlot := f.computeStartSlotAtEpoch(epoch)
+	if dependentSlot > 0 {
+		dependentSlot--
+	}
+	dependentRoot, ok := f.ancestor(root, dependentSlot)
+	if !ok {
+		return nil, fmt.Errorf("block %x is not known", root)
+	}
+	key := shufflingKey{epoch: epoch, dependentRoot: dependentRoot}
+	if cached, ok := f.shufflings.Get(key); ok {
+		return cached.(*shuffling), nil
+	}
+	shufflingState, err := f.shufflingState(root, epoch)
+	if err != nil {
+		return nil, err
+	}
+	s := &shuffling{
+		activeIndices:     transition.GetActiveValidatorIndices(shufflingState, epoch),
+		committeesPerSlot: transition.GetCommitteeCountPerSlot(shufflingState, epoch),
+		proposers:         make([]uint64, f.beaconCfg.SlotsPerEpoch),
+		committees:        map[uint64][]uint64{},
+	}
+	copy(s.seed[:], transition.GetSeed(shufflingState, epoch, f.beaconCfg.DomainBeaconAttester))
+	startSlot := f.computeStartSlotAtEpoch(epoch)
+	for i := range s.proposers {
+		if s.proposers[i], err = transition.GetBeaconProposerIndexAtSlot(shufflingState, startSlot+uint64(i)); err != nil {
+			return nil, err
+		}
+	}
+	f.shufflings.Add(key, s)
+	return s, nil
+}
+
+// BlockSlot returns the slot of a block known to the store.
+func (f *Store) BlockSlot(root common.Hash) (uint64, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	node, ok := f.blocks[root]
+	if !ok {
+		return 0, false
+	}
+	return node.slot, true
+}
+
+// ProposerIndex returns the proposer of a slot according to the shuffling of the chain of the parent block.
+func (f *Store) ProposerIndex(parentRoot common.Hash, slot uint64) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.getShuffling(parentRoot, f.computeEpochAtSlot(slot))
+	if err != nil {
+		return 0, fmt.Errorf("unable to resolve proposer of slot %d: %v", slot, err)
+	}
+	return s.proposers[slot%f.beaconCfg.SlotsPerEpoch], nil
+}
+
+// ValidatorPublicKey returns the public key of a validator registered in the head state.
+func (f *Store) ValidatorPublicKey(index uint64) ([48]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	headState, err := f.headState()
+	if err != nil || index >= uint64(len(headState.Validators())) {
+		return [48]byte{}, false
+	}
+	return headState.ValidatorAt(int(index)).PublicKey, true
+}
+
+// BeaconCommittee returns a committee according to the shuffling of the chain of the given block.
+func (f *Store) BeaconCommittee(root common.Hash, slot, committeeIndex uint64) ([]uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.getShuffling(root, f.computeEpochAtSlot(slot))
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve committees of slot %d: %v", slot, err)
+	}
+	if committeeIndex >= s.committeesPerSlot {
+		return nil, fmt.Errorf("committee index %d out of range, slot %d has %d committees", committeeIndex, slot, s.committeesPerSlot)
+	}
+	position := (slot%f.beaconCfg.SlotsPerEpoch)*s.committeesPerSlot + committeeIndex
+	if committee, ok := s.committees[position]; ok {
+		return committee, nil
+	}
+	committee, err := transition.ComputeCommittee(s.activeIndices, s.seed, position, s.committeesPerSlot*f.beaconCfg.SlotsPerEpoch)
+	if err != nil {
+		return nil, err
+	}
+	s.committees[position] = committee
+	return committee, nil
+}
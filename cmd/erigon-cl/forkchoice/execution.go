@@ -0,0 +1,85 @@
+package forkchoice
+
+import (
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/log/v3"
+)
+
+// ExecutionForkChoice is the execution layer view of the fork choice, as sent in engine_forkchoiceUpdated.
+type ExecutionForkChoice struct {
+	HeadBlockHash      common.Hash
+	SafeBlockHash      common.Hash
+	FinalizedBlockHash common.Hash
+}
+
+// TriggerExecutionFunc triggers block execution, hence: insert + validate + fcu. The payload of block, if any, is
+// inserted and validated, then the head, safe and finalized blocks of the execution layer are set to forkChoice,
+// unless its head is empty. It returns false if the payload is invalid, payloads that cannot be validated yet are
+// imported optimistically.
+type TriggerExecutionFunc func(block *cltypes.SignedBeaconBlockBellatrix, forkChoice ExecutionForkChoice) (bool, error)
+
+// SetTriggerExecution makes the store validate the payloads of new blocks and keep the execution fork choice up to date
+// through the given hook.
+func (f *Store) SetTriggerExecution(triggerExecution TriggerExecutionFunc) {
+	f.executionMu.Lock()
+	defer f.executionMu.Unlock()
+	f.triggerExecution = triggerExecution
+}
+
+// ExecutionForkChoice returns the execution block hashes of the head, the justified and the finalized blocks.
+func (f *Store) ExecutionForkChoice() ExecutionForkChoice {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return ExecutionForkChoice{
+		HeadBlockHash:      f.executionHash(f.getHead()),
+		SafeBlockHash:      f.executionHash(f.justifiedCheckpoint.Root),
+		FinalizedBlockHash: f.executionHash(f.finalizedCheckpoint.Root),
+	}
+}
+
+// executionHash returns the execution payload hash of the given block, or of its closest known ancestor with a payload.
+func (f *Store) executionHash(root common.Hash) common.Hash {
+	for {
+		node, ok := f.blocks[root]
+		if !ok {
+			return common.Hash{}
+		}
+		if node.executionHash != (common.Hash{}) {
+			return node.executionHash
+		}
+		root = node.parentRoot
+	}
+}
+
+// notifyNewPayload inserts and validates the payload of a block, blocks before the merge have nothing to validate.
+// The execution fork choice is only updated once the block is in the store.
+func (f *Store) notifyNewPayload(block *cltypes.SignedBeaconBlockBellatrix) (bool, error) {
+	f.executionMu.Lock()
+	triggerExecution := f.triggerExecution
+	f.executionMu.Unlock()
+	payload := block.Block.Body.ExecutionPayload
+	if triggerExecution == nil || payload == nil || payload.BlockHash == (common.Hash{}) {
+		return true, nil
+	}
+	return triggerExecution(block, ExecutionForkChoice{})
+}
+
+// updateExecutionForkChoice sends the execution fork choice whenever it changes.
+func (f *Store) updateExecutionForkChoice() {
+	f.executionMu.Lock()
+	defer f.executionMu.Unlock()
+	if f.triggerExecution == nil {
+		return
+	}
+	forkChoice := f.ExecutionForkChoice()
+	// Nothing to send before the merge.
+	if forkChoice == f.lastExecutionForkChoice || forkChoice.HeadBlockHash == (common.Hash{}) {
+		return
+	}
+	if _, err := f.triggerExecution(nil, forkChoice); err != nil {
+		log.Warn("[Fork Choice] Could not update execution fork choice", "head", forkChoice.HeadBlockHash, "err", err)
+		return
+	}
+	f.lastExecutionForkChoice = forkChoice
+}
@@ -0,0 +1,137 @@
+package forkchoice
+
+import (
+	"bytes"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/common"
+)
+
+// GetHead returns the head of the chain according to LMD-GHOST.
+func (f *Store) GetHead() common.Hash {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.getHead()
+}
+
+// getHead returns the cached head, changes to the block tree, the votes or the checkpoints must set headDirty.
+func (f *Store) getHead() common.Hash {
+	if f.headDirty {
+		f.head = f.computeHead()
+		f.headDirty = false
+	}
+	return f.head
+}
+
+func (f *Store) computeHead() common.Hash {
+	children := f.childrenMap()
+	viable := map[common.Hash]struct{}{}
+	f.filterBlockTree(f.justifiedCheckpoint.Root, children, viable)
+	weights := f.computeWeights()
+
+	head := common.Hash(f.justifiedCheckpoint.Root)
+	for {
+		var (
+			best       common.Hash
+			bestWeight uint64
+			found      bool
+		)
+		for _, child := range children[head] {
+			if _, ok := viable[child]; !ok {
+				continue
+			}
+			weight := weights[child]
+			// Ties are broken by favoring the block with the lexicographically higher root.
+			if !found || weight > bestWeight || (weight == bestWeight && bytes.Compare(child[:], best[:]) > 0) {
+				best, bestWeight, found = child, weight, true
+			}
+		}
+		if !found {
+			return head
+		}
+		head = best
+	}
+}
+
+// childrenMap indexes the block tree by parent root.
+func (f *Store) childrenMap() map[common.Hash][]common.Hash {
+	children := make(map[common.Hash][]common.Hash, len(f.blocks))
+	for root, node := range f.blocks {
+		if _, ok := f.blocks[node.parentRoot]; !ok {
+			continue
+		}
+		children[node.parentRoot] = append(children[node.parentRoot], root)
+	}
+	return children
+}
+
+// filterBlockTree collects the blocks whose subtree contains a leaf agreeing with the store checkpoints.
+func (f *Store) filterBlockTree(root common.Hash, children map[common.Hash][]common.Hash, viable map[common.Hash]struct{}) bool {
+	if len(children[root]) > 0 {
+		anyViable := false
+		for _, child := range children[root] {
+			if f.filterBlockTree(child, children, viable) {
+				anyViable = true
+			}
+		}
+		if anyViable {
+			viable[root] = struct{}{}
+		}
+		return anyViable
+	}
+	node := f.blocks[root]
+	if f.agreesWithCheckpoint(node.justified, f.justifiedCheckpoint) && f.agreesWithCheckpoint(node.finalized, f.finalizedCheckpoint) {
+		viable[root] = struct{}{}
+		return true
+	}
+	return false
+}
+
+// agreesWithCheckpoint tells whether the checkpoint of a block post state matches the store one.
+// The store checkpoints start at the anchor block, post state checkpoints up to the anchor epoch cannot be told apart from it.
+func (f *Store) agreesWithCheckpoint(blockCheckpoint, storeCheckpoint *cltypes.Checkpoint) bool {
+	if storeCheckpoint.Epoch == f.beaconCfg.GenesisEpoch || *blockCheckpoint == *storeCheckpoint {
+		return true
+	}
+	return storeCheckpoint.Root == f.anchorRoot && blockCheckpoint.Epoch <= storeCheckpoint.Epoch
+}
+
+// computeWeights returns the attesting balance of every block, proposer boost included.
+// Votes are propagated from the voted block to all of its ancestors.
+func (f *Store) computeWeights() map[common.Hash]uint64 {
+	weights := make(map[common.Hash]uint64, len(f.blocks))
+	addWeight := func(root common.Hash, weight uint64) {
+		for {
+			node, ok := f.blocks[root]
+			if !ok {
+				return
+			}
+			weights[root] += weight
+			root = node.parentRoot
+		}
+	}
+	for index, message := range f.latestMessages {
+		if _, equivocating := f.equivocatingIndices[index]; equivocating {
+			continue
+		}
+		if index >= uint64(len(f.justifiedBalances)) || f.justifiedBalances[index] == 0 {
+			continue
+		}
+		addWeight(message.root, f.justifiedBalances[index])
+	}
+	if f.proposerBoostRoot != (common.Hash{}) {
+		addWeight(f.proposerBoostRoot, f.proposerScore())
+	}
+	return weights
+}
+
+// proposerScore is the weight given to a timely proposal.
+func (f *Store) proposerScore() uint64 {
+	if f.activeJustifiedValidator == 0 {
+		return 0
+	}
+	averageBalance := f.totalJustifiedBalance / f.activeJustifiedValidator
+	committeeSize := f.activeJustifiedValidator / f.beaconCfg.SlotsPerEpoch
+	committeeWeight := committeeSize * averageBalance
+	return (committeeWeight * f.beaconCfg.ProposerScoreBoost) / 100
+}
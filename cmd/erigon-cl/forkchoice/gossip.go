@@ -0,0 +1,27 @@
+package forkchoice
+
+import (
+	"time"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/log/v3"
+)
+
+// ReceiveGossip feeds gossiped blocks, aggregates and slashings into the store.
+func (f *Store) ReceiveGossip(obj cltypes.ObjectSSZ) {
+	f.OnTick(uint64(time.Now().Unix()))
+	var err error
+	switch msg := obj.(type) {
+	case *cltypes.SignedBeaconBlockBellatrix:
+		err = f.OnBlock(msg)
+	case *cltypes.SignedAggregateAndProof:
+		if msg.Message != nil && msg.Message.Aggregate != nil {
+			err = f.OnAttestation(msg.Message.Aggregate)
+		}
+	case *cltypes.AttesterSlashing:
+		f.OnAttesterSlashing(msg)
+	}
+	if err != nil {
+		log.Debug("[Fork Choice] Could not process gossip", "err", err)
+	}
+}
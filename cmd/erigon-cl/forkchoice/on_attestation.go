@@ -0,0 +1,126 @@
+package forkchoice
+
+import (
+	"fmt"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/transition"
+)
+
+// OnAttestation processes an attestation received over the network.
+func (f *Store) OnAttestation(attestation *cltypes.Attestation) error {
+	f.mu.Lock()
+	err := f.onAttestation(attestation, false)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	f.updateExecutionForkChoice()
+	return nil
+}
+
+// OnAttesterSlashing marks the validators slashed by the given slashing as equivocating.
+func (f *Store) OnAttesterSlashing(slashing *cltypes.AttesterSlashing) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.onAttesterSlashing(slashing)
+}
+
+func (f *Store) onAttestation(attestation *cltypes.Attestation, isFromBlock bool) error {
+	if err := f.validateOnAttestation(attestation, isFromBlock); err != nil {
+		return err
+	}
+	targetState, err := f.checkpointState(attestation.Data.Target)
+	if err != nil {
+		return err
+	}
+	indexedAttestation, err := transition.GetIndexedAttestation(targetState, attestation)
+	if err != nil {
+		return err
+	}
+	// Attestations from blocks were verified by the state transition.
+	if !isFromBlock {
+		valid, err := transition.IsValidIndexedAttestation(targetState, indexedAttestation)
+		if err != nil {
+			return fmt.Errorf("unable to verify attestation: %v", err)
+		}
+		if !valid {
+			return fmt.Errorf("invalid attestation signature")
+		}
+	}
+	f.updateLatestMessages(indexedAttestation.AttestingIndices, attestation.Data)
+	return nil
+}
+
+func (f *Store) validateOnAttestation(attestation *cltypes.Attestation, isFromBlock bool) error {
+	data := attestation.Data
+	if data == nil || data.Target == nil {
+		return fmt.Errorf("attestation has no target")
+	}
+	target := data.Target
+	// Attestations from the network must be from the current or previous epoch.
+	if !isFromBlock {
+		currentEpoch := f.computeEpochAtSlot(f.currentSlot())
+		previousEpoch := currentEpoch
+		if previousEpoch > 0 {
+			previousEpoch--
+		}
+		if target.Epoch != currentEpoch && target.Epoch != previousEpoch {
+			return fmt.Errorf("attestation target epoch: %d, is neither current nor previous epoch", target.Epoch)
+		}
+	}
+	if target.Epoch != f.computeEpochAtSlot(data.Slot) {
+		return fmt.Errorf("attestation target epoch: %d, does not match slot: %d", target.Epoch, data.Slot)
+	}
+	if _, ok := f.blocks[target.Root]; !ok {
+		return fmt.Errorf("attestation target %x is not known", target.Root)
+	}
+	block, ok := f.blocks[data.BeaconBlockHash]
+	if !ok {
+		return fmt.Errorf("attestation block %x is not known", data.BeaconBlockHash)
+	}
+	if block.slot > data.Slot {
+		return fmt.Errorf("attestation for block at slot: %d, made at earlier slot: %d", block.slot, data.Slot)
+	}
+	// LMD vote must be consistent with FFG vote target.
+	if !f.isAncestor(data.BeaconBlockHash, f.computeStartSlotAtEpoch(target.Epoch), target.Root) {
+		return fmt.Errorf("attestation target %x is not an ancestor of the voted block", target.Root)
+	}
+	// Attestations can only affect the fork choice of subsequent slots.
+	if f.currentSlot() < data.Slot+1 {
+		return fmt.Errorf("attestation slot: %d, is not in the past", data.Slot)
+	}
+	return nil
+}
+
+func (f *Store) updateLatestMessages(attestingIndices []uint64, data *cltypes.AttestationData) {
+	for _, index := range attestingIndices {
+		if _, equivocating := f.equivocatingIndices[index]; equivocating {
+			continue
+		}
+		message, ok := f.latestMessages[index]
+		if !ok || data.Target.Epoch > message.epoch {
+			f.latestMessages[index] = &latestMessage{
+				epoch: data.Target.Epoch,
+				root:  data.BeaconBlockHash,
+			}
+			f.headDirty = true
+		}
+	}
+}
+
+func (f *Store) onAttesterSlashing(slashing *cltypes.AttesterSlashing) {
+	if slashing.Attestation_1 == nil || slashing.Attestation_2 == nil {
+		return
+	}
+	indices := make(map[uint64]struct{}, len(slashing.Attestation_1.AttestingIndices))
+	for _, index := range slashing.Attestation_1.AttestingIndices {
+		indices[index] = struct{}{}
+	}
+	for _, index := range slashing.Attestation_2.AttestingIndices {
+		if _, ok := indices[index]; ok {
+			f.equivocatingIndices[index] = struct{}{}
+			f.headDirty = true
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package forkchoice
+
+import (
+	"fmt"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/transition"
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/log/v3"
+)
+
+// OnBlock processes a new block and, if valid, adds it to the block tree.
+// The state transition and the payload validation run without holding the store lock.
+func (f *Store) OnBlock(signedBlock *cltypes.SignedBeaconBlockBellatrix) error {
+	block := signedBlock.Block
+	blockRoot, err := block.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	parentState, known, err := f.preprocessBlock(block, blockRoot)
+	if err != nil || known {
+		return err
+	}
+	postState, err := parentState.Copy()
+	if err != nil {
+		return err
+	}
+	if err := transition.New(postState, f.beaconCfg, f.genesisCfg).TransitionState(signedBlock, true); err != nil {
+		return fmt.Errorf("unable to apply block %x: %v", blockRoot, err)
+	}
+	valid, err := f.notifyNewPayload(signedBlock)
+	if err != nil {
+		// The block is imported optimistically, the execution layer may be syncing or unavailable.
+		log.Debug("[Fork Choice] Could not validate execution payload", "slot", block.Slot, "err", err)
+	} else if !valid {
+		return fmt.Errorf("block %x has an invalid execution payload", blockRoot)
+	}
+	if err := f.addBlock(signedBlock, blockRoot, postState); err != nil {
+		return err
+	}
+	f.updateExecutionForkChoice()
+	return nil
+}
+
+// preprocessBlock checks the block against the store and returns the post state of its parent, or whether the block is already known.
+func (f *Store) preprocessBlock(block *cltypes.BeaconBlockBellatrix, blockRoot common.Hash) (*state.BeaconState, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.blocks[blockRoot]; ok {
+		return nil, true, nil
+	}
+	if _, ok := f.blocks[block.ParentRoot]; !ok {
+		return nil, false, fmt.Errorf("parent block %x is not known", block.ParentRoot)
+	}
+	parentState, err := f.getBlockState(block.ParentRoot)
+	if err != nil {
+		return nil, false, err
+	}
+	// Blocks cannot be in the future.
+	if f.currentSlot() < block.Slot {
+		return nil, false, fmt.Errorf("block slot: %d, is in the future, current slot: %d", block.Slot, f.currentSlot())
+	}
+	if err := f.checkFinalizedDescendant(block, blockRoot); err != nil {
+		return nil, false, err
+	}
+	return parentState, false, nil
+}
+
+// checkFinalizedDescendant checks that the block is later than the finalized epoch slot and descends from it.
+func (f *Store) checkFinalizedDescendant(block *cltypes.BeaconBlockBellatrix, blockRoot common.Hash) error {
+	finalizedSlot := f.computeStartSlotAtEpoch(f.finalizedCheckpoint.Epoch)
+	if block.Slot <= finalizedSlot {
+		return fmt.Errorf("block slot: %d, not greater than finalized slot: %d", block.Slot, finalizedSlot)
+	}
+	if !f.isAncestor(block.ParentRoot, finalizedSlot, f.finalizedCheckpoint.Root) {
+		return fmt.Errorf("block %x does not descend from finalized checkpoint", blockRoot)
+	}
+	return nil
+}
+
+// addBlock inserts a processed block in the block tree and applies its effects on the store.
+func (f *Store) addBlock(signedBlock *cltypes.SignedBeaconBlockBellatrix, blockRoot common.Hash, postState *state.BeaconState) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	block := signedBlock.Block
+	if _, ok := f.blocks[blockRoot]; ok {
+		return nil
+	}
+	// The store may have moved on while the block was processed.
+	if err := f.checkFinalizedDescendant(block, blockRoot); err != nil {
+		return err
+	}
+	justified, finalized := postState.CurrentJustifiedCheckpoint(), postState.FinalizedCheckpoint()
+	var executionHash common.Hash
+	if block.Body.ExecutionPayload != nil {
+		executionHash = block.Body.ExecutionPayload.BlockHash
+	}
+	f.blocks[blockRoot] = &blockNode{
+		block:         signedBlock,
+		slot:          block.Slot,
+		parentRoot:    block.ParentRoot,
+		executionHash: executionHash,
+		justified:     justified,
+		finalized:     finalized,
+	}
+	f.blockStates[blockRoot] = postState
+	f.headDirty = true
+	// Add proposer score boost if the block is timely.
+	timeIntoSlot := (f.time - f.genesisTime) % f.beaconCfg.SecondsPerSlot
+	isBeforeAttestingInterval := timeIntoSlot < f.beaconCfg.SecondsPerSlot/f.beaconCfg.IntervalsPerSlot
+	if f.currentSlot() == block.Slot && isBeforeAttestingInterval {
+		f.proposerBoostRoot = blockRoot
+	}
+	if err := f.updateCheckpoints(justified, finalized); err != nil {
+		return err
+	}
+	// Attestations and slashings included in blocks count toward fork choice too.
+	for _, attestation := range block.Body.Attestations {
+		if err := f.onAttestation(attestation, true); err != nil {
+			log.Trace("[Fork Choice] Ignoring block attestation", "slot", block.Slot, "err", err)
+		}
+	}
+	for _, slashing := range block.Body.AttesterSlashings {
+		f.onAttesterSlashing(slashing)
+	}
+	f.pruneStates()
+	return nil
+}
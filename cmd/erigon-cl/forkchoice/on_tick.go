@@ -0,0 +1,43 @@
+package forkchoice
+
+import (
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/log/v3"
+)
+
+// OnTick updates the store time, it should be called at least once per slot.
+func (f *Store) OnTick(time uint64) {
+	f.mu.Lock()
+	newSlot := f.onTick(time)
+	f.mu.Unlock()
+	// The head may change with the proposer boost and justification updates of a new slot.
+	if newSlot {
+		f.updateExecutionForkChoice()
+	}
+}
+
+// onTick updates the store time and returns whether a new slot started.
+func (f *Store) onTick(time uint64) bool {
+	previousSlot := f.currentSlot()
+	f.time = time
+	currentSlot := f.currentSlot()
+	if currentSlot <= previousSlot {
+		return false
+	}
+	// Reset proposer boost at the beginning of each slot.
+	f.proposerBoostRoot = common.Hash{}
+	f.headDirty = true
+	// Update justification only at the epoch boundary.
+	if currentSlot%f.beaconCfg.SlotsPerEpoch != 0 {
+		return true
+	}
+	if f.bestJustifiedCheckpoint.Epoch > f.justifiedCheckpoint.Epoch {
+		finalizedSlot := f.computeStartSlotAtEpoch(f.finalizedCheckpoint.Epoch)
+		if f.isAncestor(f.bestJustifiedCheckpoint.Root, finalizedSlot, f.finalizedCheckpoint.Root) {
+			if err := f.setJustifiedCheckpoint(f.bestJustifiedCheckpoint); err != nil {
+				log.Debug("[Fork Choice] Could not update justified checkpoint", "err", err)
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,401 @@
+package forkchoice
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/transition"
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/log/v3"
+)
+
+const (
+	// maxHeadStates is the number of post states of recent leaves of the block tree kept in memory.
+	maxHeadStates = 4
+	// maxCheckpointStates is the number of checkpoint states kept in memory besides the store checkpoints.
+	maxCheckpointStates = 8
+)
+
+// blockNode is the fork choice view of a beacon block.
+type blockNode struct {
+	block         *cltypes.SignedBeaconBlockBellatrix // Replayed to rebuild the post state, nil for the anchor.
+	slot          uint64
+	parentRoot    common.Hash
+	executionHash common.Hash
+	// Checkpoints as seen in the post state of this block.
+	justified *cltypes.Checkpoint
+	finalized *cltypes.Checkpoint
+}
+
+// latestMessage is the most recent vote of a validator.
+type latestMessage struct {
+	epoch uint64
+	root  common.Hash
+}
+
+// Store implements the LMD-GHOST fork choice store as described in the consensus specs.
+type Store struct {
+	time                     uint64
+	genesisTime              uint64
+	justifiedCheckpoint      *cltypes.Checkpoint
+	finalizedCheckpoint      *cltypes.Checkpoint
+	bestJustifiedCheckpoint  *cltypes.Checkpoint
+	proposerBoostRoot        common.Hash
+	equivocatingIndices      map[uint64]struct{}
+	blocks                   map[common.Hash]*blockNode
+	latestMessages           map[uint64]*latestMessage
+	anchorRoot               common.Hash                               // Block the store was started from, its ancestors are not known.
+	blockStates              map[common.Hash]*state.BeaconState        // Post states of the checkpoint blocks and of recent leaves, see pruneStates.
+	checkpointStates         map[cltypes.Checkpoint]*state.BeaconState // States at the start slot of checkpoints, see pruneStates.
+	justifiedBalances        []uint64                                  // Effective balances of active validators, zero for inactive ones.
+	totalJustifiedBalance    uint64
+	activeJustifiedValidator uint64
+	head                     common.Hash // Cached result of getHead, recomputed when headDirty is set.
+	headDirty                bool
+	shufflings               *lru.Cache // Shufflings by shufflingKey.
+
+	genesisCfg *clparams.GenesisConfig
+	beaconCfg  *clparams.BeaconChainConfig
+
+	mu sync.Mutex
+
+	// The execution is triggered outside of mu, executionMu keeps the fork choice updates in order.
+	triggerExecution        TriggerExecutionFunc
+	lastExecutionForkChoice ExecutionForkChoice
+	executionMu             sync.Mutex
+}
+
+// NewStore creates a fork choice store anchored at the given state (usually the checkpoint state), the state is copied.
+func NewStore(anchorState *state.BeaconState, genesisCfg *clparams.GenesisConfig, beaconCfg *clparams.BeaconChainConfig) (*Store, error) {
+	anchorRoot, err := anchorState.BlockRoot()
+	if err != nil {
+		return nil, err
+	}
+	anchorState, err = anchorState.Copy()
+	if err != nil {
+		return nil, err
+	}
+	shufflings, err := lru.New(shufflingCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	anchorEpoch := anchorState.Slot() / beaconCfg.SlotsPerEpoch
+	anchorCheckpoint := &cltypes.Checkpoint{
+		Epoch: anchorEpoch,
+		Root:  anchorRoot,
+	}
+	var executionHash common.Hash
+	if header := anchorState.LatestExecutionPayloadHeader(); header != nil {
+		executionHash = header.BlockHash
+	}
+	s := &Store{
+		time:                    genesisCfg.GenesisTime + beaconCfg.SecondsPerSlot*anchorState.Slot(),
+		genesisTime:             genesisCfg.GenesisTime,
+		justifiedCheckpoint:     anchorCheckpoint,
+		finalizedCheckpoint:     anchorCheckpoint,
+		bestJustifiedCheckpoint: anchorCheckpoint,
+		equivocatingIndices:     map[uint64]struct{}{},
+		blocks: map[common.Hash]*blockNode{
+			anchorRoot: {
+				slot:          anchorState.LatestBlockHeader().Slot,
+				parentRoot:    anchorState.LatestBlockHeader().ParentRoot,
+				executionHash: executionHash,
+				justified:     anchorCheckpoint,
+				finalized:     anchorCheckpoint,
+			},
+		},
+		latestMessages:   map[uint64]*latestMessage{},
+		anchorRoot:       anchorRoot,
+		blockStates:      map[common.Hash]*state.BeaconState{anchorRoot: anchorState},
+		checkpointStates: map[cltypes.Checkpoint]*state.BeaconState{},
+		headDirty:        true,
+		shufflings:       shufflings,
+		genesisCfg:       genesisCfg,
+		beaconCfg:        beaconCfg,
+	}
+	if err := s.setJustifiedCheckpoint(anchorCheckpoint); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// checkpointState returns the state of the checkpoint block advanced to the start slot of the checkpoint epoch.
+func (f *Store) checkpointState(checkpoint *cltypes.Checkpoint) (*state.BeaconState, error) {
+	if checkpointState, ok := f.checkpointStates[*checkpoint]; ok {
+		return checkpointState, nil
+	}
+	blockState, err := f.getBlockState(checkpoint.Root)
+	if err != nil {
+		return nil, err
+	}
+	checkpointState, err := blockState.Copy()
+	if err != nil {
+		return nil, err
+	}
+	if startSlot := f.computeStartSlotAtEpoch(checkpoint.Epoch); checkpointState.Slot() < startSlot {
+		if err := transition.New(checkpointState, f.beaconCfg, f.genesisCfg).ProcessSlots(startSlot); err != nil {
+			return nil, err
+		}
+	}
+	f.checkpointStates[*checkpoint] = checkpointState
+	return checkpointState, nil
+}
+
+// getBlockState returns the post state of a block. States that are not kept are rebuilt by replaying the blocks since
+// the closest ancestor with a state, the finalized state is always kept.
+func (f *Store) getBlockState(root common.Hash) (*state.BeaconState, error) {
+	if blockState, ok := f.blockStates[root]; ok {
+		return blockState, nil
+	}
+	var (
+		replay    []*cltypes.SignedBeaconBlockBellatrix
+		baseState *state.BeaconState
+	)
+	for ancestor := root; baseState == nil; {
+		node, ok := f.blocks[ancestor]
+		if !ok || node.block == nil {
+			return nil, fmt.Errorf("state of block %x is not known", root)
+		}
+		replay = append(replay, node.block)
+		ancestor = node.parentRoot
+		baseState = f.blockStates[ancestor]
+	}
+	blockState, err := baseState.Copy()
+	if err != nil {
+		return nil, err
+	}
+	// The blocks were validated when they were added to the store.
+	for i := len(replay) - 1; i >= 0; i-- {
+		if err := transition.New(blockState, f.beaconCfg, f.genesisCfg).TransitionState(replay[i], false); err != nil {
+			return nil, fmt.Errorf("unable to rebuild state of block %x: %v", root, err)
+		}
+	}
+	f.blockStates[root] = blockState
+	return blockState, nil
+}
+
+// setJustifiedCheckpoint updates the justified checkpoint along with the balances weighing the votes.
+func (f *Store) setJustifiedCheckpoint(checkpoint *cltypes.Checkpoint) error {
+	justifiedState, err := f.checkpointState(checkpoint)
+	if err != nil {
+		return fmt.Errorf("unable to compute justified balances: %v", err)
+	}
+	f.justifiedCheckpoint = checkpoint
+	f.computeJustifiedBalances(justifiedState)
+	f.headDirty = true
+	return nil
+}
+
+// computeJustifiedBalances caches the effective balances of the validators active in the justified state epoch.
+func (f *Store) computeJustifiedBalances(justifiedState *state.BeaconState) {
+	epoch := f.computeEpochAtSlot(justifiedState.Slot())
+	validators := justifiedState.Validators()
+	f.justifiedBalances = make([]uint64, len(validators))
+	f.totalJustifiedBalance = 0
+	f.activeJustifiedValidator = 0
+	for i, v := range validators {
+		if v.ActivationEpoch <= epoch && epoch < v.ExitEpoch {
+			f.justifiedBalances[i] = v.EffectiveBalance
+			f.totalJustifiedBalance += v.EffectiveBalance
+			f.activeJustifiedValidator++
+		}
+	}
+}
+
+// currentSlot returns the slot of the store time.
+func (f *Store) currentSlot() uint64 {
+	if f.time < f.genesisTime {
+		return 0
+	}
+	return (f.time - f.genesisTime) / f.beaconCfg.SecondsPerSlot
+}
+
+func (f *Store) computeStartSlotAtEpoch(epoch uint64) uint64 {
+	return epoch * f.beaconCfg.SlotsPerEpoch
+}
+
+func (f *Store) computeEpochAtSlot(slot uint64) uint64 {
+	return slot / f.beaconCfg.SlotsPerEpoch
+}
+
+// ancestor returns the root of the ancestor of root at the given slot, or false if root is not known.
+// The oldest block of the store, the anchor or the finalized block once the tree is pruned, stands for the chain before
+// it, which is not known to the store.
+func (f *Store) ancestor(root common.Hash, slot uint64) (common.Hash, bool) {
+	node, ok := f.blocks[root]
+	if !ok {
+		return common.Hash{}, false
+	}
+	for node.slot > slot {
+		parent, ok := f.blocks[node.parentRoot]
+		if !ok {
+			break
+		}
+		root, node = node.parentRoot, parent
+	}
+	return root, true
+}
+
+// isAncestor tells whether ancestorRoot is the ancestor of root at the given slot.
+func (f *Store) isAncestor(root common.Hash, slot uint64, ancestorRoot common.Hash) bool {
+	ancestor, ok := f.ancestor(root, slot)
+	return ok && ancestor == ancestorRoot
+}
+
+// shouldUpdateJustifiedCheckpoint prevents bouncing attacks by only updating justification during the first slots of an epoch.
+func (f *Store) shouldUpdateJustifiedCheckpoint(newJustified *cltypes.Checkpoint) bool {
+	if f.currentSlot()%f.beaconCfg.SlotsPerEpoch < f.beaconCfg.SafeSlotsToUpdateJustified {
+		return true
+	}
+	justifiedSlot := f.computeStartSlotAtEpoch(f.justifiedCheckpoint.Epoch)
+	return f.isAncestor(newJustified.Root, justifiedSlot, f.justifiedCheckpoint.Root)
+}
+
+// updateCheckpoints updates store checkpoints according to the post-state checkpoints of a new block.
+func (f *Store) updateCheckpoints(justified, finalized *cltypes.Checkpoint) error {
+	if justified.Epoch > f.justifiedCheckpoint.Epoch {
+		if justified.Epoch > f.bestJustifiedCheckpoint.Epoch {
+			f.bestJustifiedCheckpoint = justified
+		}
+		if f.shouldUpdateJustifiedCheckpoint(justified) {
+			if err := f.setJustifiedCheckpoint(justified); err != nil {
+				return err
+			}
+		}
+	}
+	if finalized.Epoch > f.finalizedCheckpoint.Epoch {
+		f.finalizedCheckpoint = finalized
+		if err := f.setJustifiedCheckpoint(justified); err != nil {
+			return err
+		}
+		f.pruneBlocks()
+	}
+	return nil
+}
+
+// pruneBlocks drops the blocks that do not descend from the finalized block once a checkpoint is finalized.
+func (f *Store) pruneBlocks() {
+	finalizedNode, ok := f.blocks[f.finalizedCheckpoint.Root]
+	if !ok {
+		return
+	}
+	// The finalized state must be kept before the states of its ancestors are dropped.
+	if _, err := f.getBlockState(f.finalizedCheckpoint.Root); err != nil {
+		log.Warn("[Fork Choice] Could not rebuild finalized state", "err", err)
+		return
+	}
+	var pruned []common.Hash
+	for root := range f.blocks {
+		if !f.isAncestor(root, finalizedNode.slot, f.finalizedCheckpoint.Root) {
+			pruned = append(pruned, root)
+		}
+	}
+	for _, root := range pruned {
+		delete(f.blocks, root)
+		delete(f.blockStates, root)
+	}
+	f.headDirty = true
+	log.Debug("[Fork Choice] Pruned blocks", "finalized", f.finalizedCheckpoint.Epoch, "pruned", len(pruned), "blocks", len(f.blocks))
+}
+
+// pruneStates keeps the post states of the checkpoint blocks and of the most recent leaves of the block tree, the
+// others are rebuilt on demand. Checkpoint states before finality are dropped and the others are bounded.
+func (f *Store) pruneStates() {
+	storeCheckpoints := []*cltypes.Checkpoint{f.finalizedCheckpoint, f.justifiedCheckpoint, f.bestJustifiedCheckpoint}
+	kept := make(map[common.Hash]struct{}, len(storeCheckpoints)+maxHeadStates)
+	for _, checkpoint := range storeCheckpoints {
+		// Make sure the state is there before its descendants states are dropped.
+		if _, err := f.getBlockState(checkpoint.Root); err == nil {
+			kept[checkpoint.Root] = struct{}{}
+		}
+	}
+	children := f.childrenMap()
+	var leaves []common.Hash
+	for root := range f.blockStates {
+		if len(children[root]) == 0 {
+			leaves = append(leaves, root)
+		}
+	}
+	sort.Slice(leaves, func(i, j int) bool {
+		return f.blocks[leaves[i]].slot > f.blocks[leaves[j]].slot
+	})
+	for i := 0; i < len(leaves) && i < maxHeadStates; i++ {
+		kept[leaves[i]] = struct{}{}
+	}
+	for root := range f.blockStates {
+		if _, ok := kept[root]; !ok {
+			delete(f.blockStates, root)
+		}
+	}
+
+	var evictable []cltypes.Checkpoint
+	for checkpoint := range f.checkpointStates {
+		switch {
+		case checkpoint.Epoch < f.finalizedCheckpoint.Epoch:
+			delete(f.checkpointStates, checkpoint)
+		case checkpoint != *f.finalizedCheckpoint && checkpoint != *f.justifiedCheckpoint && checkpoint != *f.bestJustifiedCheckpoint:
+			evictable = append(evictable, checkpoint)
+		}
+	}
+	if len(evictable) > maxCheckpointStates {
+		sort.Slice(evictable, func(i, j int) bool {
+			return evictable[i].Epoch < evictable[j].Epoch
+		})
+		for _, checkpoint := range evictable[:len(evictable)-maxCheckpointStates] {
+			delete(f.checkpointStates, checkpoint)
+		}
+	}
+}
+
+// JustifiedCheckpoint returns the store justified checkpoint.
+func (f *Store) JustifiedCheckpoint() *cltypes.Checkpoint {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.justifiedCheckpoint
+}
+
+// FinalizedCheckpoint returns the store finalized checkpoint.
+func (f *Store) FinalizedCheckpoint() *cltypes.Checkpoint {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.finalizedCheckpoint
+}
+
+// ProposerBoostRoot returns the root of the block currently receiving proposer boost.
+func (f *Store) ProposerBoostRoot() common.Hash {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.proposerBoostRoot
+}
+
+// ContainsBlock returns whether the block root is known to the store.
+func (f *Store) ContainsBlock(root common.Hash) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.blocks[root]
+	return ok
+}
+
+// Ancestor returns the ancestor of root at the given slot, or false if root is not known.
+func (f *Store) Ancestor(root common.Hash, slot uint64) (common.Hash, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.ancestor(root, slot)
+}
+
+// BlockState returns a copy of the post state of a block known to the store.
+func (f *Store) BlockState(root common.Hash) (*state.BeaconState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	blockState, err := f.getBlockState(root)
+	if err != nil {
+		return nil, err
+	}
+	return blockState.Copy()
+}
@@ -0,0 +1,420 @@
+package forkchoice
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/transition"
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/stretchr/testify/require"
+	"github.com/supranational/blst/bindings/go"
+)
+
+const (
+	testValidatorsCount = 64
+	testSigningDST      = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+)
+
+// testKeys are the secret keys of the validators of the test anchor state.
+var testKeys = func() []*blst.SecretKey {
+	keys := make([]*blst.SecretKey, testValidatorsCount)
+	for i := range keys {
+		ikm := make([]byte, 32)
+		ikm[0] = byte(i)
+		keys[i] = blst.KeyGen(ikm)
+	}
+	return keys
+}()
+
+func getTestAnchorState() *state.BeaconState {
+	validators := make([]*cltypes.Validator, testValidatorsCount)
+	balances := make([]uint64, testValidatorsCount)
+	for i := range validators {
+		validators[i] = &cltypes.Validator{
+			WithdrawalCredentials: make([]byte, 32),
+			EffectiveBalance:      clparams.MainnetBeaconConfig.MaxEffectiveBalance,
+			ExitEpoch:             clparams.MainnetBeaconConfig.FarFutureEpoch,
+		}
+		copy(validators[i].PublicKey[:], new(blst.P1Affine).From(testKeys[i]).Compress())
+		balances[i] = clparams.MainnetBeaconConfig.MaxEffectiveBalance
+	}
+	// The sync committees cycle through the validators.
+	syncCommittee := &cltypes.SyncCommittee{PubKeys: make([][48]byte, clparams.MainnetBeaconConfig.SyncCommitteeSize)}
+	for i := range syncCommittee.PubKeys {
+		syncCommittee.PubKeys[i] = validators[i%testValidatorsCount].PublicKey
+	}
+	return state.FromBellatrixState(&cltypes.BeaconStateBellatrix{
+		BlockRoots:        make([][32]byte, 8192),
+		StateRoots:        make([][32]byte, 8192),
+		RandaoMixes:       make([][32]byte, 65536),
+		Slashings:         make([]uint64, 8192),
+		JustificationBits: make([]byte, 1),
+		Validators:        validators,
+		Balances:          balances,
+
+		PreviousEpochParticipation: make([]byte, testValidatorsCount),
+		CurrentEpochParticipation:  make([]byte, testValidatorsCount),
+		InactivityScores:           make([]uint64, testValidatorsCount),
+		CurrentSyncCommittee:       syncCommittee,
+		NextSyncCommittee:          syncCommittee,
+		LatestExecutionPayloadHeader: &cltypes.ExecutionHeader{
+			LogsBloom:     make([]byte, 256),
+			BaseFeePerGas: make([]byte, 32),
+		},
+		LatestBlockHeader:           &cltypes.BeaconBlockHeader{},
+		Fork:                        &cltypes.Fork{},
+		Eth1Data:                    &cltypes.Eth1Data{},
+		PreviousJustifiedCheckpoint: &cltypes.Checkpoint{},
+		CurrentJustifiedCheckpoint:  &cltypes.Checkpoint{},
+		FinalizedCheckpoint:         &cltypes.Checkpoint{},
+	})
+}
+
+func getTestStore(t *testing.T) (*Store, common.Hash) {
+	anchorState := getTestAnchorState()
+	store, err := NewStore(anchorState, &clparams.GenesisConfig{}, &clparams.MainnetBeaconConfig)
+	require.NoError(t, err)
+	anchorRoot, err := anchorState.BlockRoot()
+	require.NoError(t, err)
+	return store, anchorRoot
+}
+
+// newTestBlock returns a block that is not processed by the state transition.
+func newTestBlock(slot uint64, parentRoot common.Hash, graffiti byte, attestations ...*cltypes.Attestation) *cltypes.SignedBeaconBlockBellatrix {
+	graffitiBytes := make([]byte, 32)
+	graffitiBytes[0] = graffiti
+	return &cltypes.SignedBeaconBlockBellatrix{
+		Block: &cltypes.BeaconBlockBellatrix{
+			Slot:       slot,
+			ParentRoot: parentRoot,
+			Body: &cltypes.BeaconBodyBellatrix{
+				Eth1Data:     &cltypes.Eth1Data{},
+				Graffiti:     graffitiBytes,
+				Attestations: attestations,
+				// Nobody takes part in the sync aggregate.
+				SyncAggregate: &cltypes.SyncAggregate{
+					SyncCommiteeBits:      make([]byte, 64),
+					SyncCommiteeSignature: [96]byte{0xc0},
+				},
+				ExecutionPayload: &cltypes.ExecutionPayload{
+					LogsBloom:     make([]byte, 256),
+					BaseFeePerGas: make([]byte, 32),
+					BlockHash:     [32]byte{graffiti, byte(slot)},
+				},
+			},
+		},
+	}
+}
+
+// getTestBlock returns a block passing the state transition on top of a block known to the store.
+func getTestBlock(t *testing.T, store *Store, slot uint64, parentRoot common.Hash, graffiti byte, attestations ...*cltypes.Attestation) *cltypes.SignedBeaconBlockBellatrix {
+	store.mu.Lock()
+	parentState, err := store.getBlockState(parentRoot)
+	store.mu.Unlock()
+	require.NoError(t, err)
+	advanced, err := parentState.Copy()
+	require.NoError(t, err)
+	require.NoError(t, transition.New(advanced, &clparams.MainnetBeaconConfig, nil).ProcessSlots(slot))
+	proposerIndex, err := transition.GetBeaconProposerIndex(advanced)
+	require.NoError(t, err)
+	epoch := transition.GetEpochAtSlot(slot)
+	domain, err := transition.GetDomain(advanced, clparams.MainnetBeaconConfig.DomainRandao, epoch)
+	require.NoError(t, err)
+	randaoRoot, err := transition.ComputeSigningRootEpoch(epoch, domain)
+	require.NoError(t, err)
+
+	block := newTestBlock(slot, parentRoot, graffiti, attestations...)
+	block.Block.ProposerIndex = proposerIndex
+	copy(block.Block.Body.RandaoReveal[:], new(blst.P2Affine).Sign(testKeys[proposerIndex], randaoRoot[:], []byte(testSigningDST)).Compress())
+	payload := block.Block.Body.ExecutionPayload
+	payload.ParentHash = advanced.LatestExecutionPayloadHeader().BlockHash
+	payload.PrevRandao = transition.GetRandaoMixes(advanced, epoch)
+	payload.Timestamp = slotTime(slot)
+	postState, err := parentState.Copy()
+	require.NoError(t, err)
+	require.NoError(t, transition.New(postState, &clparams.MainnetBeaconConfig, nil).TransitionState(block, false))
+	block.Block.StateRoot, err = postState.HashTreeRoot()
+	require.NoError(t, err)
+	block.Signature = testSign(t, advanced, testKeys[proposerIndex], block.Block, clparams.MainnetBeaconConfig.DomainBeaconProposer, epoch)
+	return block
+}
+
+func testSign(t *testing.T, s *state.BeaconState, key *blst.SecretKey, obj interface{ HashTreeRoot() ([32]byte, error) }, domainType [4]byte, epoch uint64) (signature [96]byte) {
+	domain, err := transition.GetDomain(s, domainType, epoch)
+	require.NoError(t, err)
+	signingRoot, err := transition.ComputeSigningRoot(obj, domain)
+	require.NoError(t, err)
+	copy(signature[:], new(blst.P2Affine).Sign(key, signingRoot[:], []byte(testSigningDST)).Compress())
+	return
+}
+
+// signTestAttestation signs the attestation with the keys of its attesters, resolved from the target state.
+func signTestAttestation(t *testing.T, store *Store, attestation *cltypes.Attestation) *cltypes.Attestation {
+	store.mu.Lock()
+	targetState, err := store.checkpointState(attestation.Data.Target)
+	store.mu.Unlock()
+	require.NoError(t, err)
+	attestingIndices, err := transition.GetAttestingIndices(targetState, attestation.Data, attestation.AggregationBits)
+	require.NoError(t, err)
+	aggregate := new(blst.P2Aggregate)
+	for _, index := range attestingIndices {
+		signature := testSign(t, targetState, testKeys[index], attestation.Data, clparams.MainnetBeaconConfig.DomainBeaconAttester, attestation.Data.Target.Epoch)
+		require.True(t, aggregate.AggregateCompressed([][]byte{signature[:]}, false))
+	}
+	copy(attestation.Signature[:], aggregate.ToAffine().Compress())
+	return attestation
+}
+
+// getTestAttestations returns attestations of every committee member of the given slots.
+func getTestAttestations(t *testing.T, store *Store, fromSlot, toSlot uint64, head common.Hash, source, target *cltypes.Checkpoint) []*cltypes.Attestation {
+	var attestations []*cltypes.Attestation
+	for slot := fromSlot; slot <= toSlot; slot++ {
+		attestations = append(attestations, signTestAttestation(t, store, &cltypes.Attestation{
+			// The committees of the test state have two members.
+			AggregationBits: []byte{0b111},
+			Data: &cltypes.AttestationData{
+				Slot:            slot,
+				BeaconBlockHash: head,
+				Source:          source,
+				Target:          target,
+			},
+		}))
+	}
+	return attestations
+}
+
+// addTestBlock adds a block to the store and returns its root.
+func addTestBlock(t *testing.T, store *Store, block *cltypes.SignedBeaconBlockBellatrix) common.Hash {
+	require.NoError(t, store.OnBlock(block))
+	root, err := block.Block.HashTreeRoot()
+	require.NoError(t, err)
+	return root
+}
+
+func slotTime(slot uint64) uint64 {
+	return slot * clparams.MainnetBeaconConfig.SecondsPerSlot
+}
+
+func TestForkChoiceLinearChain(t *testing.T) {
+	store, anchorRoot := getTestStore(t)
+	store.OnTick(slotTime(4))
+	parent := anchorRoot
+	for slot := uint64(1); slot <= 3; slot++ {
+		parent = addTestBlock(t, store, getTestBlock(t, store, slot, parent, 0))
+	}
+	require.Equal(t, parent, store.GetHead())
+	executionForkChoice := store.ExecutionForkChoice()
+	require.Equal(t, common.Hash{0, 3}, executionForkChoice.HeadBlockHash)
+	require.Equal(t, common.Hash{}, executionForkChoice.FinalizedBlockHash)
+	headState, err := store.BlockState(parent)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), headState.Slot())
+	_, err = store.BlockState(common.Hash{1})
+	require.Error(t, err)
+
+	// Only the states of the checkpoint and of the leaves are kept, the others are rebuilt from them.
+	for slot := uint64(4); slot <= 20; slot++ {
+		store.OnTick(slotTime(slot))
+		parent = addTestBlock(t, store, getTestBlock(t, store, slot, parent, 0))
+	}
+	require.Len(t, store.blockStates, 2)
+	ancestor, ok := store.Ancestor(parent, 10)
+	require.True(t, ok)
+	ancestorState, err := store.BlockState(ancestor)
+	require.NoError(t, err)
+	ancestorStateRoot, err := ancestorState.HashTreeRoot()
+	require.NoError(t, err)
+	ancestorBlock := store.blocks[ancestor].block
+	require.Equal(t, ancestorBlock.Block.StateRoot, ancestorStateRoot)
+}
+
+// testExecution is a triggerExecution hook which rejects the payloads of the given hashes and records the fork choice updates.
+type testExecution struct {
+	invalid     map[common.Hash]struct{}
+	payloads    []common.Hash
+	forkChoices []ExecutionForkChoice
+}
+
+func (e *testExecution) trigger(block *cltypes.SignedBeaconBlockBellatrix, forkChoice ExecutionForkChoice) (bool, error) {
+	if block != nil {
+		hash := block.Block.Body.ExecutionPayload.BlockHash
+		e.payloads = append(e.payloads, hash)
+		if _, invalid := e.invalid[hash]; invalid {
+			return false, nil
+		}
+	}
+	if forkChoice.HeadBlockHash != (common.Hash{}) {
+		e.forkChoices = append(e.forkChoices, forkChoice)
+	}
+	return true, nil
+}
+
+func TestForkChoiceTriggerExecution(t *testing.T) {
+	store, anchorRoot := getTestStore(t)
+	execution := &testExecution{invalid: map[common.Hash]struct{}{{2, 2}: {}}}
+	store.SetTriggerExecution(execution.trigger)
+	store.OnTick(slotTime(3))
+	root := addTestBlock(t, store, getTestBlock(t, store, 1, anchorRoot, 1))
+	require.Equal(t, []common.Hash{{1, 1}}, execution.payloads)
+	require.Equal(t, []ExecutionForkChoice{{HeadBlockHash: common.Hash{1, 1}}}, execution.forkChoices)
+	// Blocks with invalid payloads are not imported.
+	require.Error(t, store.OnBlock(getTestBlock(t, store, 2, root, 2)))
+	require.False(t, store.ContainsBlock(common.Hash{2, 2}))
+	// The fork choice is only sent when it changes.
+	addTestBlock(t, store, getTestBlock(t, store, 1, anchorRoot, 1))
+	head := addTestBlock(t, store, getTestBlock(t, store, 2, root, 3))
+	require.Equal(t, head, store.GetHead())
+	require.Equal(t, []ExecutionForkChoice{{HeadBlockHash: common.Hash{1, 1}}, {HeadBlockHash: common.Hash{3, 2}}}, execution.forkChoices)
+}
+
+func TestForkChoiceRejectsInvalidBlocks(t *testing.T) {
+	store, anchorRoot := getTestStore(t)
+	store.OnTick(slotTime(2))
+	// Unknown parent.
+	require.Error(t, store.OnBlock(newTestBlock(1, common.Hash{1}, 0)))
+	// Future block.
+	require.Error(t, store.OnBlock(getTestBlock(t, store, 3, anchorRoot, 0)))
+}
+
+func TestForkChoiceProposerBoost(t *testing.T) {
+	store, anchorRoot := getTestStore(t)
+	store.OnTick(slotTime(2))
+	lateRoot := addTestBlock(t, store, getTestBlock(t, store, 1, anchorRoot, 1))
+	require.Equal(t, common.Hash{}, store.ProposerBoostRoot())
+	timelyRoot := addTestBlock(t, store, getTestBlock(t, store, 2, anchorRoot, 2))
+	require.Equal(t, timelyRoot, store.ProposerBoostRoot())
+	require.Equal(t, timelyRoot, store.GetHead())
+	// Boost is removed at the next slot, fork is then decided by root.
+	store.OnTick(slotTime(3))
+	require.Equal(t, common.Hash{}, store.ProposerBoostRoot())
+	if string(lateRoot[:]) > string(timelyRoot[:]) {
+		require.Equal(t, lateRoot, store.GetHead())
+	} else {
+		require.Equal(t, timelyRoot, store.GetHead())
+	}
+}
+
+func TestForkChoiceAttestationWeight(t *testing.T) {
+	store, anchorRoot := getTestStore(t)
+	store.OnTick(slotTime(2))
+	rootA := addTestBlock(t, store, getTestBlock(t, store, 1, anchorRoot, 1))
+	rootB := addTestBlock(t, store, getTestBlock(t, store, 1, anchorRoot, 2))
+	// Vote for the block that would lose the tie-break.
+	voted := rootA
+	if string(rootA[:]) > string(rootB[:]) {
+		voted = rootB
+	}
+	attestation := signTestAttestation(t, store, &cltypes.Attestation{
+		// The committees of the test state have two members.
+		AggregationBits: []byte{0b111},
+		Data: &cltypes.AttestationData{
+			Slot:            1,
+			BeaconBlockHash: voted,
+			Source:          &cltypes.Checkpoint{Root: anchorRoot},
+			Target:          &cltypes.Checkpoint{Root: anchorRoot},
+		},
+	})
+	// Attestations must be signed by their attesters.
+	unsigned := *attestation
+	unsigned.Signature = [96]byte{}
+	require.Error(t, store.OnAttestation(&unsigned))
+	require.NoError(t, store.OnAttestation(attestation))
+	require.Equal(t, voted, store.GetHead())
+	// Attestations for the current slot are not accepted yet.
+	attestation.Data.Slot = 2
+	require.Error(t, store.OnAttestation(signTestAttestation(t, store, attestation)))
+}
+
+func TestForkChoiceCheckpoints(t *testing.T) {
+	store, anchorRoot := getTestStore(t)
+	slotsPerEpoch := clparams.MainnetBeaconConfig.SlotsPerEpoch
+	store.OnTick(slotTime(4*slotsPerEpoch + 1))
+	genesisCheckpoint := &cltypes.Checkpoint{}
+	rootEpoch1 := addTestBlock(t, store, getTestBlock(t, store, slotsPerEpoch, anchorRoot, 0))
+	checkpoint1 := &cltypes.Checkpoint{Epoch: 1, Root: rootEpoch1}
+	// Every slot but the last one of each epoch is attested in the same epoch.
+	rootEpoch2 := addTestBlock(t, store, getTestBlock(t, store, 2*slotsPerEpoch-1, rootEpoch1, 0,
+		getTestAttestations(t, store, slotsPerEpoch, 2*slotsPerEpoch-2, rootEpoch1, genesisCheckpoint, checkpoint1)...))
+	checkpoint2 := &cltypes.Checkpoint{Epoch: 2, Root: rootEpoch2}
+	rootEpoch3 := addTestBlock(t, store, getTestBlock(t, store, 3*slotsPerEpoch-1, rootEpoch2, 0,
+		getTestAttestations(t, store, 2*slotsPerEpoch, 3*slotsPerEpoch-2, rootEpoch2, genesisCheckpoint, checkpoint2)...))
+	checkpoint3 := &cltypes.Checkpoint{Epoch: 3, Root: rootEpoch3}
+	// Epochs are justified at the end of epoch 2, in the post state of the first block after it.
+	anchorCheckpoint := &cltypes.Checkpoint{Root: anchorRoot}
+	require.Equal(t, anchorCheckpoint, store.JustifiedCheckpoint())
+	rootEpoch4 := addTestBlock(t, store, getTestBlock(t, store, 4*slotsPerEpoch-1, rootEpoch3, 0,
+		getTestAttestations(t, store, 3*slotsPerEpoch, 4*slotsPerEpoch-2, rootEpoch3, checkpoint2, checkpoint3)...))
+	require.Equal(t, checkpoint2, store.JustifiedCheckpoint())
+	require.Equal(t, anchorCheckpoint, store.FinalizedCheckpoint())
+	conflicting := getTestBlock(t, store, 2*slotsPerEpoch+1, rootEpoch1, 1)
+
+	// Justifying epoch 3 on top of epoch 2 finalizes epoch 2.
+	head := addTestBlock(t, store, getTestBlock(t, store, 4*slotsPerEpoch, rootEpoch4, 0))
+	require.Equal(t, checkpoint3, store.JustifiedCheckpoint())
+	require.Equal(t, checkpoint2, store.FinalizedCheckpoint())
+	require.Equal(t, head, store.GetHead())
+	// Blocks conflicting with finality are rejected, the blocks before finality are pruned.
+	require.Error(t, store.OnBlock(conflicting))
+	require.False(t, store.ContainsBlock(rootEpoch1))
+	require.False(t, store.ContainsBlock(anchorRoot))
+
+	ancestor, ok := store.Ancestor(head, 3*slotsPerEpoch)
+	require.True(t, ok)
+	require.Equal(t, rootEpoch3, ancestor)
+	// The finalized block stands for the chain before it.
+	ancestor, ok = store.Ancestor(head, 0)
+	require.True(t, ok)
+	require.Equal(t, rootEpoch2, ancestor)
+	_, ok = store.Ancestor(common.Hash{1}, 0)
+	require.False(t, ok)
+
+	executionForkChoice := store.ExecutionForkChoice()
+	require.Equal(t, common.Hash{0, byte(3*slotsPerEpoch - 1)}, executionForkChoice.SafeBlockHash)
+	require.Equal(t, common.Hash{0, byte(2*slotsPerEpoch - 1)}, executionForkChoice.FinalizedBlockHash)
+}
+
+func TestChainView(t *testing.T) {
+	store, anchorRoot := getTestStore(t)
+	store.OnTick(slotTime(40))
+	head := addTestBlock(t, store, getTestBlock(t, store, 40, anchorRoot, 0))
+	slot, ok := store.BlockSlot(head)
+	require.True(t, ok)
+	require.Equal(t, uint64(40), slot)
+
+	// Shufflings past the anchor epoch are resolved from the parent state.
+	for _, slot := range []uint64{41, 70} {
+		block := getTestBlock(t, store, slot, head, 0)
+		proposerIndex, err := store.ProposerIndex(head, slot)
+		require.NoError(t, err)
+		require.Equal(t, block.Block.ProposerIndex, proposerIndex)
+	}
+	store.mu.Lock()
+	headState, err := store.getBlockState(head)
+	require.NoError(t, err)
+	advanced, err := headState.Copy()
+	store.mu.Unlock()
+	require.NoError(t, err)
+	require.NoError(t, transition.New(advanced, &clparams.MainnetBeaconConfig, nil).ProcessSlots(64))
+	expectedCommittee, err := transition.GetBeaconCommittee(advanced, 70, 0)
+	require.NoError(t, err)
+	committee, err := store.BeaconCommittee(head, 70, 0)
+	require.NoError(t, err)
+	require.Equal(t, expectedCommittee, committee)
+	_, err = store.BeaconCommittee(head, 70, 1)
+	require.Error(t, err)
+	// Shufflings are computed once per epoch and dependent block.
+	require.Equal(t, 2, store.shufflings.Len())
+	_, err = store.BeaconCommittee(head, 71, 0)
+	require.NoError(t, err)
+	_, err = store.ProposerIndex(anchorRoot, 70)
+	require.NoError(t, err)
+	require.Equal(t, 3, store.shufflings.Len())
+
+	publicKey, ok := store.ValidatorPublicKey(testValidatorsCount - 1)
+	require.True(t, ok)
+	require.Equal(t, new(blst.P1Affine).From(testKeys[testValidatorsCount-1]).Compress(), publicKey[:])
+	_, ok = store.ValidatorPublicKey(testValidatorsCount)
+	require.False(t, ok)
+}
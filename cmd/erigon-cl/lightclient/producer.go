@@ -0,0 +1,247 @@
+package lightclient
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/rawdb"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/ledgerwatch/erigon/common"
+)
+
+const finalityBranchDepth = 6
+
+// attestedData is what we need to retain from an attested state in order to build updates signed by its children.
+type attestedData struct {
+	header                  *cltypes.BeaconBlockHeader
+	nextSyncCommittee       *cltypes.SyncCommittee
+	nextSyncCommitteeBranch [][]byte
+	finalizedCheckpoint     *cltypes.Checkpoint
+	finalityBranch          [][]byte
+}
+
+// Producer derives light client bootstraps and updates from processed blocks and states and persists them,
+// so that the sentinel can serve them over req/resp and gossip.
+type Producer struct {
+	beaconCfg *clparams.BeaconChainConfig
+
+	headers  map[common.Hash]*cltypes.BeaconBlockHeader // Recent block headers by block root.
+	attested map[common.Hash]*attestedData              // Data of processed states by block root.
+
+	mu sync.Mutex
+}
+
+func NewProducer(beaconCfg *clparams.BeaconChainConfig) *Producer {
+	return &Producer{
+		beaconCfg: beaconCfg,
+		headers:   make(map[common.Hash]*cltypes.BeaconBlockHeader),
+		attested:  make(map[common.Hash]*attestedData),
+	}
+}
+
+// AddState persists the bootstrap of the state's block and retains what is needed to build updates on top of it.
+// The state must be the post state of its latest block header, not advanced through empty slots.
+func (p *Producer) AddState(tx kv.RwTx, s *state.BeaconState) error {
+	stateRoot, err := s.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.addState(tx, s, stateRoot)
+}
+
+// AddBlockState is AddState for the post state of an imported block, the state is only used if its root is the one
+// claimed by the block.
+func (p *Producer) AddBlockState(tx kv.RwTx, signedBlock *cltypes.SignedBeaconBlockBellatrix, s *state.BeaconState) error {
+	stateRoot, err := s.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	if stateRoot != signedBlock.Block.StateRoot {
+		return fmt.Errorf("state root: %x, does not match the state root of block at slot %d: %x", stateRoot, signedBlock.Block.Slot, signedBlock.Block.StateRoot)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.addState(tx, s, stateRoot)
+}
+
+func (p *Producer) addState(tx kv.RwTx, s *state.BeaconState, stateRoot common.Hash) error {
+	header := *s.LatestBlockHeader()
+	if s.Slot() != header.Slot {
+		return fmt.Errorf("state at slot %d is not the post state of its latest block at slot %d", s.Slot(), header.Slot)
+	}
+	// The root of the latest block header is only filled at the next slot.
+	if header.Root != ([32]byte{}) && header.Root != stateRoot {
+		return fmt.Errorf("state root: %x, does not match latest block header: %x", stateRoot, header.Root)
+	}
+	header.Root = stateRoot
+	blockRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	currentSyncCommitteeBranch, err := s.CurrentSyncCommitteeBranch()
+	if err != nil {
+		return err
+	}
+	nextSyncCommitteeBranch, err := s.NextSyncCommitteeBranch()
+	if err != nil {
+		return err
+	}
+	finalityBranch, err := s.FinalityBranch()
+	if err != nil {
+		return err
+	}
+	if err := rawdb.WriteLightClientBootstrap(tx, blockRoot, &cltypes.LightClientBootstrap{
+		Header:                     &header,
+		CurrentSyncCommittee:       s.CurrentSyncCommittee(),
+		CurrentSyncCommitteeBranch: currentSyncCommitteeBranch,
+	}); err != nil {
+		return err
+	}
+	p.headers[blockRoot] = &header
+	p.attested[blockRoot] = &attestedData{
+		header:                  &header,
+		nextSyncCommittee:       s.NextSyncCommittee(),
+		nextSyncCommitteeBranch: nextSyncCommitteeBranch,
+		finalizedCheckpoint:     s.FinalizedCheckpoint(),
+		finalityBranch:          finalityBranch,
+	}
+	return nil
+}
+
+// OnBlock derives the updates signed by the sync aggregate of the given block and persists them, the block must have
+// gone through the state transition. Optimistic updates only need the parent header, full updates need the parent
+// state to have been added.
+func (p *Producer) OnBlock(tx kv.RwTx, signedBlock *cltypes.SignedBeaconBlockBellatrix) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	block := signedBlock.Block
+	bodyRoot, err := block.Body.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	header := &cltypes.BeaconBlockHeader{
+		Slot:          block.Slot,
+		ProposerIndex: block.ProposerIndex,
+		ParentRoot:    block.ParentRoot,
+		Root:          block.StateRoot,
+		BodyRoot:      bodyRoot,
+	}
+	blockRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	p.headers[blockRoot] = header
+	if block.Slot%p.beaconCfg.SlotsPerEpoch == 0 {
+		p.prune(block.Slot)
+	}
+
+	attestedHeader, ok := p.headers[block.ParentRoot]
+	syncAggregate := block.Body.SyncAggregate
+	if !ok || syncAggregate == nil || uint64(syncAggregate.Sum()) < p.beaconCfg.MinSyncCommitteeParticipants {
+		return nil
+	}
+	if err := p.writeOptimisticUpdate(tx, &cltypes.LightClientOptimisticUpdate{
+		AttestedHeader: attestedHeader,
+		SyncAggregate:  syncAggregate,
+		SignatureSlot:  block.Slot,
+	}); err != nil {
+		return err
+	}
+
+	attested, ok := p.attested[block.ParentRoot]
+	if !ok {
+		return nil
+	}
+	update := &cltypes.LightClientUpdate{
+		AttestedHeader:          attested.header,
+		NextSyncCommitee:        attested.nextSyncCommittee,
+		NextSyncCommitteeBranch: attested.nextSyncCommitteeBranch,
+		FinalizedHeader:         &cltypes.BeaconBlockHeader{},
+		FinalityBranch:          emptyBranch(finalityBranchDepth),
+		SyncAggregate:           syncAggregate,
+		SignatureSlot:           block.Slot,
+	}
+	if finalizedHeader, ok := p.headers[attested.finalizedCheckpoint.Root]; ok {
+		update.FinalizedHeader = finalizedHeader
+		update.FinalityBranch = attested.finalityBranch
+		if err := p.writeFinalityUpdate(tx, &cltypes.LightClientFinalityUpdate{
+			AttestedHeader:  update.AttestedHeader,
+			FinalizedHeader: update.FinalizedHeader,
+			FinalityBranch:  update.FinalityBranch,
+			SyncAggregate:   update.SyncAggregate,
+			SignatureSlot:   update.SignatureSlot,
+		}); err != nil {
+			return err
+		}
+	}
+	return p.writeBestUpdate(tx, update)
+}
+
+// writeBestUpdate stores the update if it is better than the one we have for its sync committee period.
+func (p *Producer) writeBestUpdate(tx kv.RwTx, update *cltypes.LightClientUpdate) error {
+	currentBest, err := rawdb.ReadLightClientUpdate(tx, uint32(utils.SlotToPeriod(update.SignatureSlot)))
+	if err != nil {
+		return err
+	}
+	if currentBest != nil && !cltypes.IsBetterUpdate(currentBest, update) {
+		return nil
+	}
+	return rawdb.WriteLightClientUpdate(tx, update)
+}
+
+// writeFinalityUpdate stores the update if it finalizes a later block or is more recent than the current one.
+func (p *Producer) writeFinalityUpdate(tx kv.RwTx, update *cltypes.LightClientFinalityUpdate) error {
+	latest, err := rawdb.ReadLightClientFinalityUpdate(tx)
+	if err != nil {
+		return err
+	}
+	if latest != nil && (update.FinalizedHeader.Slot < latest.FinalizedHeader.Slot ||
+		(update.FinalizedHeader.Slot == latest.FinalizedHeader.Slot && update.SignatureSlot <= latest.SignatureSlot)) {
+		return nil
+	}
+	return rawdb.WriteLightClientFinalityUpdate(tx, update)
+}
+
+// writeOptimisticUpdate stores the update if it attests a more recent header than the current one.
+func (p *Producer) writeOptimisticUpdate(tx kv.RwTx, update *cltypes.LightClientOptimisticUpdate) error {
+	latest, err := rawdb.ReadLightClientOptimisticUpdate(tx)
+	if err != nil {
+		return err
+	}
+	if latest != nil && update.AttestedHeader.Slot <= latest.AttestedHeader.Slot {
+		return nil
+	}
+	return rawdb.WriteLightClientOptimisticUpdate(tx, update)
+}
+
+// prune drops headers and states older than a historical roots period, finality updates are not built past that.
+func (p *Producer) prune(currentSlot uint64) {
+	if currentSlot < p.beaconCfg.SlotsPerHistoricalRoot {
+		return
+	}
+	minSlot := currentSlot - p.beaconCfg.SlotsPerHistoricalRoot
+	for root, header := range p.headers {
+		if header.Slot < minSlot {
+			delete(p.headers, root)
+		}
+	}
+	for root, data := range p.attested {
+		if data.header.Slot < minSlot {
+			delete(p.attested, root)
+		}
+	}
+}
+
+func emptyBranch(depth int) [][]byte {
+	branch := make([][]byte, depth)
+	for i := range branch {
+		branch[i] = make([]byte, 32)
+	}
+	return branch
+}
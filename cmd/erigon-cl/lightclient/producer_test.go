@@ -0,0 +1,185 @@
+package lightclient
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/rawdb"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestState(slot uint64, parentRoot common.Hash, finalizedRoot common.Hash) *state.BeaconState {
+	return state.FromBellatrixState(&cltypes.BeaconStateBellatrix{
+		Slot:              slot,
+		BlockRoots:        make([][32]byte, 8192),
+		StateRoots:        make([][32]byte, 8192),
+		RandaoMixes:       make([][32]byte, 65536),
+		Slashings:         make([]uint64, 8192),
+		JustificationBits: make([]byte, 1),
+		CurrentSyncCommittee: &cltypes.SyncCommittee{
+			PubKeys: make([][48]byte, 512),
+		},
+		NextSyncCommittee: &cltypes.SyncCommittee{
+			PubKeys: make([][48]byte, 512),
+		},
+		LatestExecutionPayloadHeader: &cltypes.ExecutionHeader{
+			LogsBloom:     make([]byte, 256),
+			BaseFeePerGas: make([]byte, 32),
+		},
+		LatestBlockHeader: &cltypes.BeaconBlockHeader{
+			Slot:       slot,
+			ParentRoot: parentRoot,
+		},
+		Fork:                        &cltypes.Fork{},
+		Eth1Data:                    &cltypes.Eth1Data{},
+		PreviousJustifiedCheckpoint: &cltypes.Checkpoint{},
+		CurrentJustifiedCheckpoint:  &cltypes.Checkpoint{},
+		FinalizedCheckpoint:         &cltypes.Checkpoint{Root: finalizedRoot},
+	})
+}
+
+func getTestBlock(slot uint64, parentRoot common.Hash, participants int) *cltypes.SignedBeaconBlockBellatrix {
+	syncCommitteeBits := make([]byte, 64)
+	for i := 0; i < participants; i++ {
+		syncCommitteeBits[i/8] |= 1 << (i % 8)
+	}
+	return &cltypes.SignedBeaconBlockBellatrix{
+		Block: &cltypes.BeaconBlockBellatrix{
+			Slot:       slot,
+			ParentRoot: parentRoot,
+			Body: &cltypes.BeaconBodyBellatrix{
+				Eth1Data: &cltypes.Eth1Data{},
+				Graffiti: make([]byte, 32),
+				SyncAggregate: &cltypes.SyncAggregate{
+					SyncCommiteeBits: syncCommitteeBits,
+				},
+				ExecutionPayload: &cltypes.ExecutionPayload{
+					LogsBloom:     make([]byte, 256),
+					BaseFeePerGas: make([]byte, 32),
+				},
+			},
+		},
+	}
+}
+
+func TestProducerUpdates(t *testing.T) {
+	_, tx := memdb.NewTestTx(t)
+	producer := NewProducer(&clparams.MainnetBeaconConfig)
+
+	anchorState := getTestState(0, common.Hash{}, common.Hash{})
+	require.NoError(t, producer.AddState(tx, anchorState))
+	anchorRoot, err := anchorState.BlockRoot()
+	require.NoError(t, err)
+	bootstrap, err := rawdb.ReadLightClientBootstrap(tx, anchorRoot)
+	require.NoError(t, err)
+	require.NotNil(t, bootstrap)
+	stateRoot, err := anchorState.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, stateRoot, bootstrap.Header.Root)
+	// States advanced past their latest block are not post states.
+	advancedState := getTestState(1, common.Hash{}, common.Hash{})
+	advancedState.SetLatestBlockHeader(&cltypes.BeaconBlockHeader{})
+	require.Error(t, producer.AddState(tx, advancedState))
+
+	// Attested state finalizing the anchor block.
+	attestedState := getTestState(1, anchorRoot, anchorRoot)
+	require.NoError(t, producer.AddState(tx, attestedState))
+	attestedRoot, err := attestedState.BlockRoot()
+	require.NoError(t, err)
+
+	// Not enough participants, nothing is derived.
+	require.NoError(t, producer.OnBlock(tx, getTestBlock(2, attestedRoot, 0)))
+	optimisticUpdate, err := rawdb.ReadLightClientOptimisticUpdate(tx)
+	require.NoError(t, err)
+	require.Nil(t, optimisticUpdate)
+
+	require.NoError(t, producer.OnBlock(tx, getTestBlock(2, attestedRoot, 100)))
+	optimisticUpdate, err = rawdb.ReadLightClientOptimisticUpdate(tx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), optimisticUpdate.AttestedHeader.Slot)
+	require.Equal(t, uint64(2), optimisticUpdate.SignatureSlot)
+
+	finalityUpdate, err := rawdb.ReadLightClientFinalityUpdate(tx)
+	require.NoError(t, err)
+	finalizedRoot, err := finalityUpdate.FinalizedHeader.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, anchorRoot, finalizedRoot)
+	require.True(t, utils.IsValidMerkleBranch(finalizedRoot, finalityUpdate.FinalityBranch, 6, 41, finalityUpdate.AttestedHeader.Root))
+
+	update, err := rawdb.ReadLightClientUpdate(tx, 0)
+	require.NoError(t, err)
+	require.Equal(t, 100, update.SyncAggregate.Sum())
+
+	// A better update for the same period replaces the stored one, a worse one does not.
+	require.NoError(t, producer.OnBlock(tx, getTestBlock(3, attestedRoot, 500)))
+	require.NoError(t, producer.OnBlock(tx, getTestBlock(4, attestedRoot, 50)))
+	update, err = rawdb.ReadLightClientUpdate(tx, 0)
+	require.NoError(t, err)
+	require.Equal(t, 500, update.SyncAggregate.Sum())
+}
+
+func TestProducerUpdatesAcrossPeriods(t *testing.T) {
+	_, tx := memdb.NewTestTx(t)
+	producer := NewProducer(&clparams.MainnetBeaconConfig)
+
+	// Start a few slots before the end of the first sync committee period.
+	const anchorSlot = 8188
+	anchorState := getTestState(anchorSlot, common.Hash{}, common.Hash{})
+	require.NoError(t, producer.AddState(tx, anchorState))
+	anchorRoot, err := anchorState.BlockRoot()
+	require.NoError(t, err)
+
+	// Blocks are imported like the stage loop does: updates are derived from the block, then its post state is added.
+	parentRoot := anchorRoot
+	for slot := uint64(anchorSlot + 1); slot <= anchorSlot+8; slot++ {
+		block := getTestBlock(slot, parentRoot, 400)
+		bodyRoot, err := block.Block.Body.HashTreeRoot()
+		require.NoError(t, err)
+		postState := getTestState(slot, parentRoot, anchorRoot)
+		postState.SetLatestBlockHeader(&cltypes.BeaconBlockHeader{
+			Slot:       slot,
+			ParentRoot: parentRoot,
+			BodyRoot:   bodyRoot,
+		})
+		// States whose root is not the one claimed by the block are not used.
+		require.Error(t, producer.AddBlockState(tx, block, postState))
+		block.Block.StateRoot, err = postState.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, producer.OnBlock(tx, block))
+		require.NoError(t, producer.AddBlockState(tx, block, postState))
+
+		parentRoot, err = block.Block.HashTreeRoot()
+		require.NoError(t, err)
+		bootstrap, err := rawdb.ReadLightClientBootstrap(tx, parentRoot)
+		require.NoError(t, err)
+		require.NotNil(t, bootstrap)
+		require.Equal(t, common.Hash(block.Block.StateRoot), common.Hash(bootstrap.Header.Root))
+	}
+
+	// Each period gets its own best update, signed within the period.
+	for period := utils.SlotToPeriod(anchorSlot); period <= utils.SlotToPeriod(anchorSlot+8); period++ {
+		update, err := rawdb.ReadLightClientUpdate(tx, uint32(period))
+		require.NoError(t, err)
+		require.NotNil(t, update)
+		require.Equal(t, period, utils.SlotToPeriod(update.SignatureSlot))
+		require.Equal(t, update.SignatureSlot-1, update.AttestedHeader.Slot)
+	}
+	require.Equal(t, uint64(0), utils.SlotToPeriod(anchorSlot))
+	require.Equal(t, uint64(1), utils.SlotToPeriod(anchorSlot+8))
+
+	// Finality and optimistic updates follow the latest imported block.
+	finalityUpdate, err := rawdb.ReadLightClientFinalityUpdate(tx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(anchorSlot+8), finalityUpdate.SignatureSlot)
+	finalizedRoot, err := finalityUpdate.FinalizedHeader.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, anchorRoot, finalizedRoot)
+	optimisticUpdate, err := rawdb.ReadLightClientOptimisticUpdate(tx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(anchorSlot+7), optimisticUpdate.AttestedHeader.Slot)
+}
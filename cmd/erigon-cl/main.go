@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	sentinelrpc "github.com/ledgerwatch/erigon-lib/gointerfaces/sentinel"
 	"github.com/ledgerwatch/erigon-lib/kv"
@@ -15,6 +16,8 @@ import (
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/rawdb"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/forkchoice"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/lightclient"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/network"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/stages"
 	lcCli "github.com/ledgerwatch/erigon/cmd/sentinel/cli"
@@ -22,6 +25,7 @@ import (
 	"github.com/ledgerwatch/erigon/cmd/sentinel/sentinel"
 	"github.com/ledgerwatch/erigon/cmd/sentinel/sentinel/handshake"
 	"github.com/ledgerwatch/erigon/cmd/sentinel/sentinel/service"
+	"github.com/ledgerwatch/erigon/cmd/sentinel/sentinel/validation"
 	sentinelapp "github.com/ledgerwatch/erigon/turbo/app"
 	"github.com/ledgerwatch/log/v3"
 	"github.com/urfave/cli/v2"
@@ -40,9 +44,12 @@ func main() {
 
 func runConsensusLayerNode(cliCtx *cli.Context) error {
 	ctx := context.Background()
-	cfg, _ := lcCli.SetupConsensusClientCfg(cliCtx)
+	cfg, err := lcCli.SetupConsensusClientCfg(cliCtx)
+	if err != nil {
+		log.Error("Could not initialize consensus client", "err", err)
+		return err
+	}
 	var db kv.RwDB
-	var err error
 	if cfg.Chaindata == "" {
 		db, err = mdbx.NewTemporaryMdbx()
 	} else {
@@ -53,7 +60,7 @@ func runConsensusLayerNode(cliCtx *cli.Context) error {
 	}
 	defer db.Close()
 	// Fetch the checkpoint state.
-	cpState, err := getCheckpointState(ctx, db)
+	cpState, cpBlock, err := getCheckpointState(ctx, db, cfg)
 	if err != nil {
 		log.Error("Could not get checkpoint", "err", err)
 		return err
@@ -64,20 +71,39 @@ func runConsensusLayerNode(cliCtx *cli.Context) error {
 	// Start the sentinel service
 	log.Root().SetHandler(log.LvlFilterHandler(log.Lvl(cfg.LogLvl), log.StderrHandler))
 	log.Info("[Sentinel] running sentinel with configuration", "cfg", cfg)
-	s, err := startSentinel(cliCtx, *cfg, cpState)
+	genesisCfg, _, beaconConfig := clparams.GetConfigsByNetwork(clparams.MainnetNetwork)
+	forkChoice, err := forkchoice.NewStore(cpState, genesisCfg, beaconConfig)
+	if err != nil {
+		return err
+	}
+	go runForkChoiceClock(ctx, forkChoice, beaconConfig)
+
+	// The fork choice store backs the gossip validation of the sentinel.
+	s, err := startSentinel(cliCtx, *cfg, db, cpState, forkChoice)
 	if err != nil {
 		log.Error("Could not start sentinel service", "err", err)
 	}
 
-	genesisCfg, _, beaconConfig := clparams.GetConfigsByNetwork(clparams.MainnetNetwork)
 	beaconRpc := rpc.NewBeaconRpcP2P(ctx, s, beaconConfig, genesisCfg)
 	downloader := network.NewForwardBeaconDownloader(ctx, beaconRpc)
 	bdownloader := network.NewBackwardBeaconDownloader(ctx, beaconRpc)
 
+	// Light client data is derived from the checkpoint state and the post states of the blocks imported on top of it.
+	lightClientProducer := lightclient.NewProducer(beaconConfig)
+	if err := db.Update(ctx, func(tx kv.RwTx) error {
+		return lightClientProducer.AddState(tx, cpState)
+	}); err != nil {
+		// Checkpoint states advanced through empty slots have no bootstrap.
+		log.Warn("Could not derive light client bootstrap from checkpoint", "err", err)
+	}
+
 	gossipManager := network.NewGossipReceiver(ctx, s)
 	gossipManager.AddReceiver(sentinelrpc.GossipType_BeaconBlockGossipType, downloader)
+	gossipManager.AddReceiver(sentinelrpc.GossipType_BeaconBlockGossipType, forkChoice)
+	gossipManager.AddReceiver(sentinelrpc.GossipType_AggregateAndProofGossipType, forkChoice)
+	gossipManager.AddReceiver(sentinelrpc.GossipType_AttesterSlashingGossipType, forkChoice)
 	go gossipManager.Loop()
-	stageloop, err := stages.NewConsensusStagedSync(ctx, db, downloader, bdownloader, genesisCfg, beaconConfig, cpState, nil, false)
+	stageloop, err := stages.NewConsensusStagedSync(ctx, db, downloader, bdownloader, genesisCfg, beaconConfig, cpState, cpBlock, forkChoice, lightClientProducer, nil, false)
 	if err != nil {
 		return err
 	}
@@ -95,7 +121,21 @@ Loop:
 	return nil
 }
 
-func startSentinel(cliCtx *cli.Context, cfg lcCli.ConsensusClientCliCfg, beaconState *state.BeaconState) (sentinelrpc.SentinelClient, error) {
+// runForkChoiceClock ticks the fork choice store every interval of the slot.
+func runForkChoiceClock(ctx context.Context, forkChoice *forkchoice.Store, beaconConfig *clparams.BeaconChainConfig) {
+	ticker := time.NewTicker(time.Duration(beaconConfig.SecondsPerSlot/beaconConfig.IntervalsPerSlot) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			forkChoice.OnTick(uint64(now.Unix()))
+		}
+	}
+}
+
+func startSentinel(cliCtx *cli.Context, cfg lcCli.ConsensusClientCliCfg, db kv.RoDB, beaconState *state.BeaconState, chainView validation.ChainView) (sentinelrpc.SentinelClient, error) {
 	forkDigest, err := fork.ComputeForkDigest(cfg.BeaconCfg, cfg.GenesisCfg)
 	if err != nil {
 		return nil, err
@@ -108,7 +148,8 @@ func startSentinel(cliCtx *cli.Context, cfg lcCli.ConsensusClientCliCfg, beaconS
 		NetworkConfig: cfg.NetworkCfg,
 		BeaconConfig:  cfg.BeaconCfg,
 		NoDiscovery:   cfg.NoDiscovery,
-	}, nil, &service.ServerConfig{Network: cfg.ServerProtocol, Addr: cfg.ServerAddr}, nil, &cltypes.Status{
+		ChainView:     chainView,
+	}, db, &service.ServerConfig{Network: cfg.ServerProtocol, Addr: cfg.ServerAddr}, nil, &cltypes.Status{
 		ForkDigest:     forkDigest,
 		FinalizedRoot:  beaconState.FinalizedCheckpoint().Root,
 		FinalizedEpoch: beaconState.FinalizedCheckpoint().Epoch,
@@ -123,26 +164,34 @@ func startSentinel(cliCtx *cli.Context, cfg lcCli.ConsensusClientCliCfg, beaconS
 	return s, nil
 }
 
-func getCheckpointState(ctx context.Context, db kv.RwDB) (*state.BeaconState, error) {
-
-	uri := clparams.GetCheckpointSyncEndpoint(clparams.MainnetNetwork)
-
-	state, err := core.RetrieveBeaconState(ctx, uri)
+// getCheckpointState retrieves the anchor state, either from local SSZ files together with its block, or over the network.
+func getCheckpointState(ctx context.Context, db kv.RwDB, cfg *lcCli.ConsensusClientCliCfg) (*state.BeaconState, *cltypes.SignedBeaconBlockBellatrix, error) {
+	var (
+		state *state.BeaconState
+		block *cltypes.SignedBeaconBlockBellatrix
+		err   error
+	)
+	if cfg.CheckpointState != "" {
+		state, block, err = core.RetrieveBeaconStateFromFiles(cfg.CheckpointState, cfg.CheckpointBlock, cfg.CheckpointTrustedRoot)
+	} else {
+		uri := clparams.GetCheckpointSyncEndpoint(clparams.MainnetNetwork)
+		state, err = core.RetrieveBeaconState(ctx, uri)
+	}
 	if err != nil {
 		log.Error("[Checkpoint Sync] Failed", "reason", err)
-		return nil, err
+		return nil, nil, err
 	}
 	tx, err := db.BeginRw(ctx)
 	if err != nil {
 		log.Error("[DB] Failed", "reason", err)
-		return nil, err
+		return nil, nil, err
 	}
 	defer tx.Rollback()
 
 	if err := rawdb.WriteBeaconState(tx, state); err != nil {
 		log.Error("[DB] Failed", "reason", err)
-		return nil, err
+		return nil, nil, err
 	}
 	log.Info("Checkpoint sync successful: hurray!")
-	return state, tx.Commit()
+	return state, block, tx.Commit()
 }
@@ -10,6 +10,8 @@ import (
 	"github.com/ledgerwatch/erigon/cl/utils"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/rawdb"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/forkchoice"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/lightclient"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/network"
 	"github.com/ledgerwatch/erigon/common"
 	"github.com/ledgerwatch/erigon/eth/stagedsync"
@@ -22,18 +24,23 @@ type StageBeaconsBlockCfg struct {
 	genesisCfg *clparams.GenesisConfig
 	beaconCfg  *clparams.BeaconChainConfig
 	state      *state.BeaconState
+	forkChoice *forkchoice.Store
+	// lightClientProducer derives light client updates from the blocks we process.
+	lightClientProducer *lightclient.Producer
 }
 
 const maxOptimisticDistance = 8
 
 func StageBeaconsBlock(db kv.RwDB, downloader *network.ForwardBeaconDownloader, genesisCfg *clparams.GenesisConfig,
-	beaconCfg *clparams.BeaconChainConfig, state *state.BeaconState) StageBeaconsBlockCfg {
+	beaconCfg *clparams.BeaconChainConfig, state *state.BeaconState, forkChoice *forkchoice.Store, lightClientProducer *lightclient.Producer) StageBeaconsBlockCfg {
 	return StageBeaconsBlockCfg{
-		db:         db,
-		downloader: downloader,
-		genesisCfg: genesisCfg,
-		beaconCfg:  beaconCfg,
-		state:      state,
+		db:                  db,
+		downloader:          downloader,
+		genesisCfg:          genesisCfg,
+		beaconCfg:           beaconCfg,
+		state:               state,
+		forkChoice:          forkChoice,
+		lightClientProducer: lightClientProducer,
 	}
 }
 
@@ -111,10 +118,31 @@ func SpawnStageBeaconsBlocks(cfg StageBeaconsBlockCfg, s *stagedsync.StageState,
 		if parentRoot != highestRootProcessed {
 			return
 		}
+		if cfg.forkChoice != nil {
+			cfg.forkChoice.OnTick(uint64(time.Now().Unix()))
+		}
 		for _, block := range newBlocks {
 			if err = rawdb.WriteBeaconBlock(tx, block); err != nil {
 				return
 			}
+			if cfg.forkChoice == nil {
+				continue
+			}
+			if err := cfg.forkChoice.OnBlock(block); err != nil {
+				log.Debug("[Beacon Downloading] Fork choice rejected block", "slot", block.Block.Slot, "err", err)
+				continue
+			}
+			// Light client data is only derived from blocks that went through the state transition.
+			if cfg.lightClientProducer == nil {
+				continue
+			}
+			if err := cfg.lightClientProducer.OnBlock(tx, block); err != nil {
+				log.Debug("[Beacon Downloading] Could not derive light client updates", "slot", block.Block.Slot, "err", err)
+			}
+			// The post state lets the children of the block build full light client updates.
+			if err := addLightClientState(tx, cfg.forkChoice, cfg.lightClientProducer, block); err != nil {
+				log.Debug("[Beacon Downloading] Could not add light client state", "slot", block.Block.Slot, "err", err)
+			}
 		}
 		// Checks done, update all internals accordingly
 		return lastSlotInSegment, lastRootInSegment, nil
@@ -152,3 +180,16 @@ func SpawnStageBeaconsBlocks(cfg StageBeaconsBlockCfg, s *stagedsync.StageState,
 	}
 	return nil
 }
+
+// addLightClientState hands the post state of an imported block over to the light client producer.
+func addLightClientState(tx kv.RwTx, forkChoice *forkchoice.Store, lightClientProducer *lightclient.Producer, block *cltypes.SignedBeaconBlockBellatrix) error {
+	blockRoot, err := block.Block.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	postState, err := forkChoice.BlockState(blockRoot)
+	if err != nil {
+		return err
+	}
+	return lightClientProducer.AddBlockState(tx, block, postState)
+}
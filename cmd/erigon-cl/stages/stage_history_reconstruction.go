@@ -17,24 +17,26 @@ import (
 )
 
 type StageHistoryReconstructionCfg struct {
-	db         kv.RwDB
-	genesisCfg *clparams.GenesisConfig
-	beaconCfg  *clparams.BeaconChainConfig
-	downloader *network.BackwardBeaconDownloader
-	state      *state.BeaconState
+	db          kv.RwDB
+	genesisCfg  *clparams.GenesisConfig
+	beaconCfg   *clparams.BeaconChainConfig
+	downloader  *network.BackwardBeaconDownloader
+	state       *state.BeaconState
+	anchorBlock *cltypes.SignedBeaconBlockBellatrix // Block of the anchor state, if it was provided locally.
 }
 
 const RecEnabled = true
 const DestinationSlot = 5100000
 const logIntervalTime = 30 * time.Second
 
-func StageHistoryReconstruction(db kv.RwDB, downloader *network.BackwardBeaconDownloader, genesisCfg *clparams.GenesisConfig, beaconCfg *clparams.BeaconChainConfig, state *state.BeaconState) StageHistoryReconstructionCfg {
+func StageHistoryReconstruction(db kv.RwDB, downloader *network.BackwardBeaconDownloader, genesisCfg *clparams.GenesisConfig, beaconCfg *clparams.BeaconChainConfig, state *state.BeaconState, anchorBlock *cltypes.SignedBeaconBlockBellatrix) StageHistoryReconstructionCfg {
 	return StageHistoryReconstructionCfg{
-		db:         db,
-		genesisCfg: genesisCfg,
-		beaconCfg:  beaconCfg,
-		downloader: downloader,
-		state:      state,
+		db:          db,
+		genesisCfg:  genesisCfg,
+		beaconCfg:   beaconCfg,
+		downloader:  downloader,
+		state:       state,
+		anchorBlock: anchorBlock,
 	}
 }
 
@@ -70,7 +72,7 @@ func SpawnStageHistoryReconstruction(cfg StageHistoryReconstructionCfg, s *stage
 	cfg.downloader.SetSlotToDownload(cfg.state.LatestBlockHeader().Slot)
 	cfg.downloader.SetExpectedRoot(blockRoot)
 	// Set up onNewBlock callback
-	cfg.downloader.SetOnNewBlock(func(blk *cltypes.SignedBeaconBlockBellatrix) (finished bool, err error) {
+	onNewBlock := func(blk *cltypes.SignedBeaconBlockBellatrix) (finished bool, err error) {
 		// Collect attestations
 		encodedAttestations, err := rawdb.EncodeAttestationsForStorage(blk.Block.Body.Attestations)
 		if err != nil {
@@ -89,7 +91,17 @@ func SpawnStageHistoryReconstruction(cfg StageHistoryReconstructionCfg, s *stage
 		}
 		// will arbitratly stop at slot 5.1M for testing reasons
 		return blk.Block.Slot <= 5300000, nil
-	})
+	}
+	cfg.downloader.SetOnNewBlock(onNewBlock)
+	// The anchor block was provided alongside the state, no need to download it.
+	finished := false
+	if cfg.anchorBlock != nil {
+		if finished, err = onNewBlock(cfg.anchorBlock); err != nil {
+			return err
+		}
+		cfg.downloader.SetSlotToDownload(cfg.anchorBlock.Block.Slot - 1)
+		cfg.downloader.SetExpectedRoot(cfg.anchorBlock.Block.ParentRoot)
+	}
 	prevProgress := cfg.downloader.Progress()
 
 	logInterval := time.NewTicker(30 * time.Second)
@@ -118,7 +130,7 @@ func SpawnStageHistoryReconstruction(cfg StageHistoryReconstructionCfg, s *stage
 			}
 		}
 	}()
-	for !cfg.downloader.Finished() {
+	for !finished && !cfg.downloader.Finished() {
 		cfg.downloader.RequestMore()
 	}
 	close(finishCh)
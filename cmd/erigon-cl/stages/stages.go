@@ -5,7 +5,10 @@ import (
 
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/forkchoice"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/lightclient"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/network"
 	"github.com/ledgerwatch/erigon/eth/stagedsync"
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
@@ -64,15 +67,22 @@ func NewConsensusStagedSync(ctx context.Context,
 	genesisCfg *clparams.GenesisConfig,
 	beaconCfg *clparams.BeaconChainConfig,
 	state *state.BeaconState,
-	triggerExecution triggerExecutionFunc,
+	anchorBlock *cltypes.SignedBeaconBlockBellatrix,
+	forkChoice *forkchoice.Store,
+	lightClientProducer *lightclient.Producer,
+	triggerExecution forkchoice.TriggerExecutionFunc,
 	clearEth1Data bool,
 ) (*stagedsync.Sync, error) {
+	// The fork choice store triggers the execution of the blocks it imports, with its head, safe and finalized blocks.
+	if forkChoice != nil && triggerExecution != nil {
+		forkChoice.SetTriggerExecution(triggerExecution)
+	}
 	return stagedsync.New(
 		ConsensusStages(
 			ctx,
-			StageHistoryReconstruction(db, backwardDownloader, genesisCfg, beaconCfg, state),
-			StageBeaconsBlock(db, forwardDownloader, genesisCfg, beaconCfg, state),
-			StageBeaconState(db, genesisCfg, beaconCfg, state, triggerExecution, clearEth1Data),
+			StageHistoryReconstruction(db, backwardDownloader, genesisCfg, beaconCfg, state, anchorBlock),
+			StageBeaconsBlock(db, forwardDownloader, genesisCfg, beaconCfg, state, forkChoice, lightClientProducer),
+			StageBeaconState(db, genesisCfg, beaconCfg, state, clearEth1Data),
 		),
 		ConsensusUnwindOrder,
 		ConsensusPruneOrder,
@@ -5,35 +5,30 @@ import (
 
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/erigon/cl/clparams"
-	"github.com/ledgerwatch/erigon/cl/cltypes"
-	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/rawdb"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state"
 	"github.com/ledgerwatch/erigon/eth/stagedsync"
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
 	"github.com/ledgerwatch/log/v3"
 )
 
-// This function will trigger block execution, hence: insert + validate + fcu.
-type triggerExecutionFunc func(*cltypes.SignedBeaconBlockBellatrix) error
-
+// Block execution (insert + validate + fcu) is driven by the fork choice store, through the triggerExecution hook
+// given to NewConsensusStagedSync.
 type StageBeaconStateCfg struct {
-	db               kv.RwDB
-	genesisCfg       *clparams.GenesisConfig
-	beaconCfg        *clparams.BeaconChainConfig
-	state            *state.BeaconState
-	clearEth1Data    bool // Whether we want to discard eth1 data.
-	triggerExecution triggerExecutionFunc
+	db            kv.RwDB
+	genesisCfg    *clparams.GenesisConfig
+	beaconCfg     *clparams.BeaconChainConfig
+	state         *state.BeaconState
+	clearEth1Data bool // Whether we want to discard eth1 data.
 }
 
 func StageBeaconState(db kv.RwDB, genesisCfg *clparams.GenesisConfig,
-	beaconCfg *clparams.BeaconChainConfig, state *state.BeaconState, triggerExecution triggerExecutionFunc, clearEth1Data bool) StageBeaconStateCfg {
+	beaconCfg *clparams.BeaconChainConfig, state *state.BeaconState, clearEth1Data bool) StageBeaconStateCfg {
 	return StageBeaconStateCfg{
-		db:               db,
-		genesisCfg:       genesisCfg,
-		beaconCfg:        beaconCfg,
-		state:            state,
-		clearEth1Data:    clearEth1Data,
-		triggerExecution: triggerExecution,
+		db:            db,
+		genesisCfg:    genesisCfg,
+		beaconCfg:     beaconCfg,
+		state:         state,
+		clearEth1Data: clearEth1Data,
 	}
 }
 
@@ -56,24 +51,6 @@ func SpawnStageBeaconState(cfg StageBeaconStateCfg, _ *stagedsync.StageState, tx
 	latestBlockHeader := cfg.state.LatestBlockHeader()
 
 	fromSlot := latestBlockHeader.Slot
-	for slot := fromSlot + 1; slot <= endSlot; slot++ {
-		block, err := rawdb.ReadBeaconBlock(tx, slot)
-		if err != nil {
-			return err
-		}
-		// Missed proposal are absent slot
-		if block == nil {
-			continue
-		}
-		// TODO: Pass this to state transition with the state
-		_ = block
-		// If successful call the insertion function
-		if cfg.triggerExecution != nil {
-			if err := cfg.triggerExecution(block); err != nil {
-				return err
-			}
-		}
-	}
 
 	// Clear all ETH1 data from CL db
 	if cfg.clearEth1Data {
@@ -0,0 +1,351 @@
+package commands
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	common2 "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/length"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	kv2 "github.com/ledgerwatch/erigon-lib/kv/mdbx"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dbStatsJSON       bool
+	dbStatsHistograms bool
+	dbStatsTop        int
+	dbStatsCompare    string
+)
+
+// dbStatsHistogramTables - history and index tables, with the length of the key prefix (address, topic or block range)
+// used for their histograms
+var dbStatsHistogramTables = map[string]int{
+	kv.AccountChangeSet: 6, // bigEndian(block) - ranges of 65536 blocks
+	kv.StorageChangeSet: 6,
+	kv.AccountsHistory:  length.Addr,
+	kv.StorageHistory:   length.Addr,
+	kv.LogAddressIndex:  length.Addr,
+	kv.LogTopicIndex:    length.Hash,
+	kv.CallFromIndex:    length.Addr,
+	kv.CallToIndex:      length.Addr,
+}
+
+var cmdDbStats = &cobra.Command{
+	Use:   "db_stats",
+	Short: "Report entries, b-tree depth, pages and size of each table, the freelist, and key-prefix histograms of the history and index tables",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		if err := dbStats(ctx); err != nil {
+			log.Error("Error", "err", err)
+			return
+		}
+	},
+}
+
+func init() {
+	withDataDir(cmdDbStats)
+	cmdDbStats.Flags().BoolVar(&dbStatsJSON, "json", false, "print the report as JSON")
+	cmdDbStats.Flags().BoolVar(&dbStatsHistograms, "histograms", true, "scan the history and index tables for key-prefix histograms (slow on big databases)")
+	cmdDbStats.Flags().IntVar(&dbStatsTop, "top", 10, "amount of the biggest key prefixes to report in histograms")
+	cmdDbStats.Flags().StringVar(&dbStatsCompare, "datadir.compare", "", "datadir of another node, to report the difference with it")
+	must(cmdDbStats.MarkFlagDirname("datadir.compare"))
+
+	rootCmd.AddCommand(cmdDbStats)
+}
+
+type DbStatsTable struct {
+	Name          string
+	Entries       uint64
+	Depth         uint
+	BranchPages   uint64
+	LeafPages     uint64
+	OverflowPages uint64
+	Size          uint64            // bytes, all pages of the table
+	Histogram     *DbStatsHistogram `json:",omitempty"`
+}
+
+type DbStatsPrefix struct {
+	Prefix  string // hex
+	Entries uint64
+	Bytes   uint64 // keys and values
+}
+
+// DbStatsHistogram - distribution of the entries among key prefixes
+type DbStatsHistogram struct {
+	PrefixLen int
+	Prefixes  uint64
+	// Log2Entries[i] - amount of prefixes with [2^i, 2^(i+1)) entries
+	Log2Entries []uint64
+	Top         []DbStatsPrefix // prefixes with most entries
+}
+
+type DbStatsReport struct {
+	Chaindata     string
+	PageSize      uint64
+	FileSize      uint64
+	FreelistPages uint64 // pages of the freelist (GC) table itself
+	FreePages     uint64 // estimated amount of free pages, listed by the freelist
+	Tables        []DbStatsTable
+}
+
+type DbStatsTableDiff struct {
+	Name    string
+	Entries int64
+	Size    int64
+}
+
+type DbStatsComparison struct {
+	Reports  [2]*DbStatsReport
+	FileSize int64
+	Tables   []DbStatsTableDiff // sorted by size difference
+}
+
+func dbStats(ctx context.Context) error {
+	report, err := collectDbStats(ctx, chaindata)
+	if err != nil {
+		return err
+	}
+	if dbStatsCompare == "" {
+		if dbStatsJSON {
+			return printJSON(report)
+		}
+		printDbStats(report)
+		return nil
+	}
+
+	other, err := collectDbStats(ctx, filepath.Join(dbStatsCompare, "chaindata"))
+	if err != nil {
+		return err
+	}
+	comparison := compareDbStats(report, other)
+	if dbStatsJSON {
+		return printJSON(comparison)
+	}
+	printDbStats(report)
+	printDbStats(other)
+	printDbStatsComparison(comparison)
+	return nil
+}
+
+func collectDbStats(ctx context.Context, path string) (*DbStatsReport, error) {
+	// read-only: db_stats runs beside a live node
+	db := openDB(dbCfg(kv.ChainDB, path).Readonly(), false)
+	defer db.Close()
+
+	report := &DbStatsReport{Chaindata: path}
+	if err := db.View(ctx, func(tx kv.Tx) error {
+		mdbxTx, ok := tx.(*kv2.MdbxTx)
+		if !ok {
+			return fmt.Errorf("db_stats supports only mdbx, got %T", tx)
+		}
+		var err error
+		if report.FileSize, err = mdbxTx.DBSize(); err != nil {
+			return err
+		}
+		gc, err := mdbxTx.BucketStat("gc")
+		if err != nil {
+			return err
+		}
+		report.PageSize = uint64(gc.PSize)
+		report.FreelistPages = gc.BranchPages + gc.LeafPages + gc.OverflowPages
+		// same estimate as the db_gc_pages metric
+		report.FreePages = (gc.LeafPages + gc.OverflowPages) * report.PageSize / 8
+
+		for _, name := range kv.ChaindataTables {
+			if kv.ChaindataTablesCfg[name].IsDeprecated {
+				continue
+			}
+			st, err := mdbxTx.BucketStat(name)
+			if err != nil {
+				return err
+			}
+			table := DbStatsTable{
+				Name:          name,
+				Entries:       st.Entries,
+				Depth:         st.Depth,
+				BranchPages:   st.BranchPages,
+				LeafPages:     st.LeafPages,
+				OverflowPages: st.OverflowPages,
+				Size:          (st.BranchPages + st.LeafPages + st.OverflowPages) * uint64(st.PSize),
+			}
+			if prefixLen, ok := dbStatsHistogramTables[name]; ok && dbStatsHistograms && st.Entries > 0 {
+				if table.Histogram, err = keyPrefixHistogram(ctx, tx, name, prefixLen, dbStatsTop); err != nil {
+					return err
+				}
+			}
+			report.Tables = append(report.Tables, table)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(report.Tables, func(i, j int) bool { return report.Tables[i].Size > report.Tables[j].Size })
+	return report, nil
+}
+
+// prefixHeap - min-heap of the prefixes with most entries
+type prefixHeap []DbStatsPrefix
+
+func (h prefixHeap) Len() int            { return len(h) }
+func (h prefixHeap) Less(i, j int) bool  { return h[i].Entries < h[j].Entries }
+func (h prefixHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *prefixHeap) Push(x interface{}) { *h = append(*h, x.(DbStatsPrefix)) }
+func (h *prefixHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// keyPrefixHistogram - keys are sorted, so the entries of a prefix are consecutive and the scan needs memory
+// only for the top prefixes
+func keyPrefixHistogram(ctx context.Context, tx kv.Tx, table string, prefixLen, top int) (*DbStatsHistogram, error) {
+	logEvery := time.NewTicker(30 * time.Second)
+	defer logEvery.Stop()
+
+	h := &DbStatsHistogram{PrefixLen: prefixLen, Log2Entries: make([]uint64, 64)}
+	topPrefixes := &prefixHeap{}
+	var current DbStatsPrefix
+	var currentPrefix []byte
+	flush := func() {
+		if current.Entries == 0 {
+			return
+		}
+		h.Prefixes++
+		h.Log2Entries[bits.Len64(current.Entries)-1]++
+		if top <= 0 {
+			return
+		}
+		if topPrefixes.Len() < top {
+			current.Prefix = hex.EncodeToString(currentPrefix)
+			heap.Push(topPrefixes, current)
+		} else if (*topPrefixes)[0].Entries < current.Entries {
+			current.Prefix = hex.EncodeToString(currentPrefix)
+			(*topPrefixes)[0] = current
+			heap.Fix(topPrefixes, 0)
+		}
+	}
+
+	var entries uint64
+	if err := tx.ForEach(table, nil, func(k, v []byte) error {
+		prefix := k
+		if len(prefix) > prefixLen {
+			prefix = prefix[:prefixLen]
+		}
+		if !bytes.Equal(prefix, currentPrefix) {
+			flush()
+			currentPrefix = append(currentPrefix[:0], prefix...)
+			current = DbStatsPrefix{}
+		}
+		current.Entries++
+		current.Bytes += uint64(len(k) + len(v))
+		entries++
+
+		select {
+		case <-logEvery.C:
+			log.Info("Scanning", "table", table, "entries", entries, "prefix", hex.EncodeToString(currentPrefix))
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	flush()
+
+	for i := len(h.Log2Entries) - 1; i >= 0 && h.Log2Entries[i] == 0; i-- {
+		h.Log2Entries = h.Log2Entries[:i]
+	}
+	h.Top = make([]DbStatsPrefix, topPrefixes.Len())
+	for i := len(h.Top) - 1; i >= 0; i-- {
+		h.Top[i] = heap.Pop(topPrefixes).(DbStatsPrefix)
+	}
+	return h, nil
+}
+
+func compareDbStats(a, b *DbStatsReport) *DbStatsComparison {
+	c := &DbStatsComparison{Reports: [2]*DbStatsReport{a, b}, FileSize: int64(b.FileSize) - int64(a.FileSize)}
+	tablesA := make(map[string]DbStatsTable, len(a.Tables))
+	for _, t := range a.Tables {
+		tablesA[t.Name] = t
+	}
+	for _, t := range b.Tables {
+		ta := tablesA[t.Name]
+		c.Tables = append(c.Tables, DbStatsTableDiff{Name: t.Name, Entries: int64(t.Entries) - int64(ta.Entries), Size: int64(t.Size) - int64(ta.Size)})
+	}
+	sort.Slice(c.Tables, func(i, j int) bool { return abs64(c.Tables[i].Size) > abs64(c.Tables[j].Size) })
+	return c
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func printDbStats(r *DbStatsReport) {
+	fmt.Printf("%s: file %s, page %d, freelist %d pages, ~%d free pages (%s)\n", r.Chaindata, common2.ByteCount(r.FileSize), r.PageSize,
+		r.FreelistPages, r.FreePages, common2.ByteCount(r.FreePages*r.PageSize))
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "table\tentries\tdepth\tbranch\tleaf\toverflow\tsize\t")
+	for _, t := range r.Tables {
+		if t.Entries == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%s\t\n", t.Name, t.Entries, t.Depth, t.BranchPages, t.LeafPages, t.OverflowPages, common2.ByteCount(t.Size))
+	}
+	_ = w.Flush()
+
+	for _, t := range r.Tables {
+		if t.Histogram == nil {
+			continue
+		}
+		h := t.Histogram
+		fmt.Printf("\n%s: %d prefixes of %d bytes\n", t.Name, h.Prefixes, h.PrefixLen)
+		buckets := make([]string, 0, len(h.Log2Entries))
+		for i, n := range h.Log2Entries {
+			if n > 0 {
+				buckets = append(buckets, fmt.Sprintf("%d-%d:%d", uint64(1)<<i, uint64(1)<<(i+1)-1, n))
+			}
+		}
+		fmt.Printf("  prefixes by entries %s\n", strings.Join(buckets, " "))
+		for _, p := range h.Top {
+			fmt.Printf("  %s %d entries %s\n", p.Prefix, p.Entries, common2.ByteCount(p.Bytes))
+		}
+	}
+	fmt.Println()
+}
+
+func printDbStatsComparison(c *DbStatsComparison) {
+	fmt.Printf("%s -> %s: file %+d bytes\n", c.Reports[0].Chaindata, c.Reports[1].Chaindata, c.FileSize)
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "table\tentries\tsize\t")
+	for _, t := range c.Tables {
+		if t.Entries == 0 && t.Size == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%+d\t%+d\t\n", t.Name, t.Entries, t.Size)
+	}
+	_ = w.Flush()
+}
@@ -19,6 +19,8 @@ var (
 	bucket                         string
 	datadirCli, toChaindata        string
 	migration                      string
+	migrationDryRun                bool
+	migrationBackup                bool
 	integrityFast, integritySlow   bool
 	file                           string
 	HeimdallURL                    string
@@ -27,6 +29,7 @@ var (
 	pruneH, pruneR, pruneT, pruneC uint64
 	pruneHBefore, pruneRBefore     uint64
 	pruneTBefore, pruneCBefore     uint64
+	pruneKeepAddresses             []string
 	experiments                    []string
 	chain                          string // Which chain to use (mainnet, rinkeby, goerli, etc.)
 
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/spf13/cobra"
+
+	"github.com/ledgerwatch/erigon/cmd/sentry/sentry"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/turbo/stages"
+)
+
+var cmdReplayCapture = &cobra.Command{
+	Use:   "replay_capture [capture files or directories]",
+	Short: "Feed the messages captured by a sentry (--p2p.capture.dir) to an in-memory staged sync of the chain",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var paths []string
+		for _, arg := range args {
+			info, err := os.Stat(arg)
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				paths = append(paths, arg)
+				continue
+			}
+			files, err := sentry.CaptureFiles(arg)
+			if err != nil {
+				return err
+			}
+			paths = append(paths, files...)
+		}
+
+		genesis := core.DefaultGenesisBlockByChainName(chain)
+		if genesis == nil {
+			return fmt.Errorf("unknown chain %s", chain)
+		}
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return err
+		}
+		m := stages.MockWithGenesis(nil, genesis, key, false)
+		defer m.Close()
+
+		reader := sentry.NewCaptureReader(paths...)
+		defer reader.Close()
+		stats, err := m.ReplayCapture(reader)
+		if err != nil {
+			return err
+		}
+
+		return m.DB.View(m.Ctx, func(tx kv.Tx) error {
+			head := rawdb.ReadHeadBlockHash(tx)
+			var number uint64
+			if n := rawdb.ReadHeaderNumber(tx, head); n != nil {
+				number = *n
+			}
+			log.Info("Replayed the capture", "files", len(paths), "messages", stats.Messages, "skipped", stats.Skipped,
+				"cycles", stats.Cycles, "head", number, "hash", head)
+			return nil
+		})
+	},
+}
+
+func init() {
+	withChain(cmdReplayCapture)
+
+	rootCmd.AddCommand(cmdReplayCapture)
+}
@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/c2h5oh/datasize"
 	"github.com/ledgerwatch/erigon-lib/kv"
@@ -75,3 +77,40 @@ func openDB(opts kv2.MdbxOpts, applyMigrations bool) kv.RwDB {
 	}
 	return db
 }
+
+func runMigrations(opts kv2.MdbxOpts) error {
+	opts = opts.Flags(func(f uint) uint { return f | mdbx.Accede })
+	migrator := migrations.NewMigrator(opts.GetLabel())
+	migrator.Backup = migrationBackup
+	if migrationDryRun {
+		db := opts.Readonly().MustOpen()
+		defer db.Close()
+		reports, err := migrator.DryRun(db)
+		if err != nil {
+			return err
+		}
+		if len(reports) == 0 {
+			log.Info("No pending migrations")
+		}
+		for _, report := range reports {
+			tables := make([]string, len(report.Tables))
+			for i, table := range report.Tables {
+				tables[i] = fmt.Sprintf("%s(%d)", table, report.Entries[i])
+			}
+			log.Info("Pending migration", "name", report.Name, "resumed", report.Resumed, "tables", strings.Join(tables, " "))
+		}
+		return nil
+	}
+
+	db := opts.Exclusive().MustOpen()
+	defer db.Close()
+	return migrator.Apply(db, datadirCli)
+}
+
+func rollbackMigration(opts kv2.MdbxOpts) error {
+	opts = opts.Flags(func(f uint) uint { return f | mdbx.Accede })
+	// The tables are restored in exclusive mode like the migrations are applied, nothing else may use them meanwhile
+	db := opts.Exclusive().MustOpen()
+	defer db.Close()
+	return migrations.RollbackMigration(db, datadirCli, migration)
+}
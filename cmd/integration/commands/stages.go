@@ -8,6 +8,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/c2h5oh/datasize"
 	common2 "github.com/ledgerwatch/erigon-lib/common"
@@ -253,13 +254,28 @@ var cmdRemoveMigration = &cobra.Command{
 	},
 }
 
+var cmdRollbackMigration = &cobra.Command{
+	Use:   "rollback_migration",
+	Short: "Restore the tables saved by run_migrations --backup before the migration was applied",
+	Long: `Restore the tables saved by run_migrations --backup before the migration was applied.
+The migration is marked as not applied, so it runs again on the next start of erigon or of a command
+applying the migrations: start only a binary without the migration, or fix the migration first.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := rollbackMigration(dbCfg(kv.ChainDB, chaindata)); err != nil {
+			log.Error("Error", "err", err)
+			return
+		}
+	},
+}
+
 var cmdRunMigrations = &cobra.Command{
 	Use:   "run_migrations",
 	Short: "",
 	Run: func(cmd *cobra.Command, args []string) {
-		db := openDB(dbCfg(kv.ChainDB, chaindata), true)
-		defer db.Close()
-		// Nothing to do, migrations will be applied automatically
+		if err := runMigrations(dbCfg(kv.ChainDB, chaindata)); err != nil {
+			log.Error("Error", "err", err)
+			return
+		}
 	},
 }
 
@@ -426,9 +442,15 @@ func init() {
 	withHeimdall(cmdRemoveMigration)
 	rootCmd.AddCommand(cmdRemoveMigration)
 
+	withDataDir(cmdRollbackMigration)
+	withMigration(cmdRollbackMigration)
+	rootCmd.AddCommand(cmdRollbackMigration)
+
 	withDataDir(cmdRunMigrations)
 	withChain(cmdRunMigrations)
 	withHeimdall(cmdRunMigrations)
+	cmdRunMigrations.Flags().BoolVar(&migrationDryRun, "dry-run", false, "only report the pending migrations and the tables they would modify")
+	cmdRunMigrations.Flags().BoolVar(&migrationBackup, "backup", false, "save the tables modified by each migration, for rollback_migration")
 	rootCmd.AddCommand(cmdRunMigrations)
 
 	withDataDir2(cmdSetSnap)
@@ -450,6 +472,7 @@ func init() {
 	cmdSetPrune.Flags().Uint64Var(&pruneRBefore, "prune.r.before", 0, "")
 	cmdSetPrune.Flags().Uint64Var(&pruneTBefore, "prune.t.before", 0, "")
 	cmdSetPrune.Flags().Uint64Var(&pruneCBefore, "prune.c.before", 0, "")
+	cmdSetPrune.Flags().StringSliceVar(&pruneKeepAddresses, "prune.keep.addresses", nil, "")
 	cmdSetPrune.Flags().StringSliceVar(&experiments, "experiments", nil, "Storage mode to override database")
 	rootCmd.AddCommand(cmdSetPrune)
 }
@@ -1099,6 +1122,17 @@ func printAppliedMigrations(db kv.RwDB, ctx context.Context) error {
 		}
 		slices.Sort(appliedStrs)
 		log.Info("Applied", "migrations", strings.Join(appliedStrs, " "))
+		for _, m := range migrations.NewMigrator(kv.ChainDB).Migrations {
+			result, err := migrations.ReadMigrationResult(tx, m.Name)
+			if err != nil {
+				return err
+			}
+			if result == nil {
+				continue
+			}
+			log.Info("Migration", "name", m.Name, "started", time.Unix(result.Started, 0), "took", time.Duration(result.Finished-result.Started)*time.Second,
+				"err", result.Err, "backup", result.Backup, "rolled_back", result.RolledBack != 0)
+		}
 		return nil
 	})
 }
@@ -1266,7 +1300,7 @@ func stage(st *stagedsync.Sync, tx kv.Tx, db kv.RoDB, stage stages.SyncStage) *s
 func overrideStorageMode(db kv.RwDB) error {
 	chainConfig := fromdb.ChainConfig(db)
 	pm, err := prune.FromCli(chainConfig.ChainID.Uint64(), pruneFlag, pruneH, pruneR, pruneT, pruneC,
-		pruneHBefore, pruneRBefore, pruneTBefore, pruneCBefore, experiments)
+		pruneHBefore, pruneRBefore, pruneTBefore, pruneCBefore, pruneKeepAddresses, experiments)
 	if err != nil {
 		return err
 	}
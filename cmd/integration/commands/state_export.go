@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/ledgerwatch/erigon-lib/common/datadir"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/kvcfg"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/spf13/cobra"
+
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/eth/stagedsync"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/turbo/services"
+)
+
+var cmdStateExport = &cobra.Command{
+	Use:   "state_export",
+	Short: "Write the plain state at the Execution stage progress to a file, which state_import can load into another database",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		db := openDB(dbCfg(kv.ChainDB, chaindata), true)
+		defer db.Close()
+
+		if err := stateExport(db, ctx); err != nil {
+			log.Error("Error", "err", err)
+			return
+		}
+	},
+}
+
+var cmdStateImport = &cobra.Command{
+	Use:   "state_import",
+	Short: "Replace the state with the one written by state_export, rebuild the hashed state and the intermediate hashes, and check the state root",
+	Long:  "The blocks up to the imported one are not executed, so there are no receipts, logs or call traces before it",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		db := openDB(dbCfg(kv.ChainDB, chaindata), true)
+		defer db.Close()
+
+		if err := stateImport(db, ctx); err != nil {
+			log.Error("Error", "err", err)
+			return
+		}
+	},
+}
+
+func init() {
+	withDataDir(cmdStateExport)
+	withFile(cmdStateExport)
+	withBlock(cmdStateExport)
+
+	rootCmd.AddCommand(cmdStateExport)
+
+	withDataDir(cmdStateImport)
+	withFile(cmdStateImport)
+
+	rootCmd.AddCommand(cmdStateImport)
+}
+
+func stateExport(db kv.RwDB, ctx context.Context) error {
+	if kvcfg.HistoryV3.FromDB(db) {
+		return fmt.Errorf("state export is not supported with history.v3")
+	}
+	tx, err := db.BeginRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	progress, err := stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		return err
+	}
+	if (block != 0) && (block != progress) {
+		return fmt.Errorf("the state is at block %d, unwind the state stages to export the state at block %d", progress, block)
+	}
+	header, err := getBlockReader(db).HeaderByNumber(ctx, tx, progress)
+	if err != nil {
+		return err
+	}
+	if header == nil {
+		return fmt.Errorf("header %d not found", progress)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var w io.Writer = f
+	var gz *gzip.Writer
+	if strings.HasSuffix(file, ".gz") {
+		gz = gzip.NewWriter(f)
+		w = gz
+	}
+	bw := bufio.NewWriterSize(w, int(4*datasize.MB))
+
+	log.Info("State export", "block", progress, "root", header.Root, "file", file)
+	if err := state.ExportState(ctx, "state_export", tx, state.StateExportHeader{
+		Block:     progress,
+		BlockHash: header.Hash(),
+		Root:      header.Root,
+	}, bw); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return err
+		}
+	}
+	return f.Close()
+}
+
+func stateImport(db kv.RwDB, ctx context.Context) error {
+	dirs, historyV3 := datadir.New(datadirCli), kvcfg.HistoryV3.FromDB(db)
+	if historyV3 {
+		return fmt.Errorf("state import is not supported with history.v3")
+	}
+	_, agg := allSnapshots(db)
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var r io.Reader = bufio.NewReaderSize(f, int(4*datasize.MB))
+	if strings.HasSuffix(file, ".gz") {
+		if r, err = gzip.NewReader(r); err != nil {
+			return err
+		}
+	}
+
+	tx, err := db.BeginRw(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	blockReader := getBlockReader(db)
+	header, err := state.ImportState(ctx, "state_import", tx, r, dirs.Tmp, func(header *state.StateExportHeader) error {
+		return checkStateImportBlock(ctx, tx, blockReader, header)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, table := range []string{kv.HashedAccounts, kv.HashedStorage, kv.ContractCode} {
+		if err := tx.ClearBucket(table); err != nil {
+			return err
+		}
+	}
+	if err := stagedsync.PromoteHashedStateCleanly("state_import", tx, stagedsync.StageHashStateCfg(db, dirs, historyV3, agg), ctx); err != nil {
+		return err
+	}
+	trieCfg := stagedsync.StageTrieCfg(db, true, true, false, dirs.Tmp, blockReader, nil, historyV3, agg)
+	root, err := stagedsync.RegenerateIntermediateHashes("state_import", tx, trieCfg, header.Root, ctx)
+	if err != nil {
+		return err
+	}
+	if root != header.Root {
+		return fmt.Errorf("wrong state root %x of block %d, expected %x", root, header.Block, header.Root)
+	}
+
+	// The changesets and the history are of the replaced state
+	for _, table := range []string{kv.AccountChangeSet, kv.StorageChangeSet, kv.AccountsHistory, kv.StorageHistory} {
+		if err := tx.ClearBucket(table); err != nil {
+			return err
+		}
+	}
+	// The receipts, the logs index and the call traces start at the imported block, there is nothing to build
+	// them from before it. Finish is set too, so that the imported block is served as the latest one.
+	for _, stage := range []stages.SyncStage{stages.Execution, stages.HashState, stages.IntermediateHashes, stages.AccountHistoryIndex, stages.StorageHistoryIndex, stages.LogIndex, stages.CallTraces, stages.Finish} {
+		if err := stages.SaveStageProgress(tx, stage, header.Block); err != nil {
+			return err
+		}
+	}
+	// There is no history before the imported state to unwind with
+	for _, stage := range []stages.SyncStage{stages.Execution, stages.HashState, stages.IntermediateHashes, stages.AccountHistoryIndex, stages.StorageHistoryIndex, stages.LogIndex, stages.CallTraces} {
+		if err := stages.SaveStageUnwindLimit(tx, stage, header.Block); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	log.Info("State imported", "block", header.Block, "hash", header.BlockHash, "root", root)
+	return nil
+}
+
+// checkStateImportBlock - the state can be imported only at a canonical block of the database, with the headers and
+// the bodies of the blocks up to it
+func checkStateImportBlock(ctx context.Context, tx kv.Tx, blockReader services.FullBlockReader, header *state.StateExportHeader) error {
+	for _, stage := range []stages.SyncStage{stages.Headers, stages.Bodies} {
+		progress, err := stages.GetStageProgress(tx, stage)
+		if err != nil {
+			return err
+		}
+		if progress < header.Block {
+			return fmt.Errorf("the state is at block %d, but %s are only up to block %d", header.Block, stage, progress)
+		}
+	}
+	hash, err := blockReader.CanonicalHash(ctx, tx, header.Block)
+	if err != nil {
+		return err
+	}
+	if hash != header.BlockHash {
+		return fmt.Errorf("the state is at block %d %x, but the canonical block %d is %x", header.Block, header.BlockHash, header.Block, hash)
+	}
+	return nil
+}
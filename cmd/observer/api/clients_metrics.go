@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/ledgerwatch/erigon/cmd/observer/database"
+	"github.com/ledgerwatch/erigon/cmd/observer/reports"
+	"github.com/ledgerwatch/erigon/cmd/observer/utils"
+	"github.com/ledgerwatch/log/v3"
+)
+
+// clientsMetricsLimit is large enough to give a gauge to every client name.
+const clientsMetricsLimit = 1000
+
+// ClientsMetrics are the Prometheus gauges of the node and client counts.
+// Every update replaces the set, so that the clients which are gone stop being reported.
+type ClientsMetrics struct {
+	set  *metrics.Set
+	lock sync.RWMutex
+}
+
+func NewClientsMetrics() *ClientsMetrics {
+	return &ClientsMetrics{set: metrics.NewSet()}
+}
+
+func (m *ClientsMetrics) Update(status *reports.StatusReport, clients *reports.ClientsReport) {
+	set := metrics.NewSet()
+	setGauge(set, "observer_nodes", status.TotalCount)
+	setGauge(set, "observer_ips", status.DistinctIPCount)
+	for _, client := range clients.Clients {
+		if (client.Name == "...") || (client.Name == "total") {
+			continue
+		}
+		setGauge(set, fmt.Sprintf(`observer_clients{client=%q}`, client.Name), client.Count)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.set = set
+}
+
+func (m *ClientsMetrics) WritePrometheus(w io.Writer) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	m.set.WritePrometheus(w)
+}
+
+func setGauge(set *metrics.Set, name string, value uint) {
+	set.NewGauge(name, func() float64 { return float64(value) })
+}
+
+func ClientsMetricsLoop(
+	ctx context.Context,
+	db database.DB,
+	m *ClientsMetrics,
+	maxPingTries uint,
+	networkID uint,
+	period time.Duration,
+	logger log.Logger,
+) {
+	for ctx.Err() == nil {
+		status, err := reports.CreateStatusReport(ctx, db, maxPingTries, networkID)
+		if err == nil {
+			var clients *reports.ClientsReport
+			clients, err = reports.CreateClientsReport(ctx, db, clientsMetricsLimit, maxPingTries, networkID)
+			if err == nil {
+				m.Update(status, clients)
+			}
+		}
+		if (err != nil) && !errors.Is(err, context.Canceled) {
+			logger.Error("Failed to update the clients metrics", "err", err)
+		}
+
+		utils.Sleep(ctx, period)
+	}
+}
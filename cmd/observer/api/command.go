@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ledgerwatch/erigon/cmd/utils"
+)
+
+type CommandFlags struct {
+	DataDir      string
+	Chain        string
+	ClientsLimit uint
+	MaxPingTries uint
+
+	ListenAddr    string
+	MetricsPeriod time.Duration
+	ErigonLogPath string
+}
+
+type Command struct {
+	command cobra.Command
+	flags   CommandFlags
+}
+
+func NewCommand() *Command {
+	command := cobra.Command{
+		Use:   "serve",
+		Short: "Serve the crawler database reports and nodes over HTTP/JSON",
+	}
+
+	instance := Command{
+		command: command,
+	}
+	instance.withDatadir()
+	instance.withChain()
+	instance.withClientsLimit()
+	instance.withMaxPingTries()
+	instance.withListenAddr()
+	instance.withMetricsPeriod()
+	instance.withErigonLogPath()
+
+	return &instance
+}
+
+func (command *Command) withDatadir() {
+	flag := utils.DataDirFlag
+	command.command.Flags().StringVar(&command.flags.DataDir, flag.Name, flag.Value.String(), flag.Usage)
+	must(command.command.MarkFlagDirname(utils.DataDirFlag.Name))
+}
+
+func (command *Command) withChain() {
+	flag := utils.ChainFlag
+	command.command.Flags().StringVar(&command.flags.Chain, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withClientsLimit() {
+	flag := cli.UintFlag{
+		Name:  "clients-limit",
+		Usage: "A default number of top clients to show",
+		Value: uint(10),
+	}
+	command.command.Flags().UintVar(&command.flags.ClientsLimit, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withMaxPingTries() {
+	flag := cli.UintFlag{
+		Name:  "max-ping-tries",
+		Usage: "A number of PING failures for a node to be considered dead",
+		Value: 3,
+	}
+	command.command.Flags().UintVar(&command.flags.MaxPingTries, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withListenAddr() {
+	flag := cli.StringFlag{
+		Name:  "addr",
+		Usage: "HTTP listening interface and port",
+		Value: "localhost:6070",
+	}
+	command.command.Flags().StringVar(&command.flags.ListenAddr, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withMetricsPeriod() {
+	flag := cli.DurationFlag{
+		Name:  "metrics-period",
+		Usage: "How often to update the client distribution metrics",
+		Value: time.Minute,
+	}
+	command.command.Flags().DurationVar(&command.flags.MetricsPeriod, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withErigonLogPath() {
+	flag := cli.StringFlag{
+		Name:  "erigon-log",
+		Usage: "Erigon log file path to serve the sentry candidates report",
+	}
+	command.command.Flags().StringVar(&command.flags.ErigonLogPath, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) RawCommand() *cobra.Command {
+	return &command.command
+}
+
+func (command *Command) OnRun(runFunc func(ctx context.Context, flags CommandFlags) error) {
+	command.command.RunE = func(cmd *cobra.Command, args []string) error {
+		return runFunc(cmd.Context(), command.flags)
+	}
+}
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
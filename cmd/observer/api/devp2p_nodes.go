@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/ledgerwatch/erigon/cmd/observer/database"
+	"github.com/ledgerwatch/erigon/p2p/enode"
+)
+
+// Devp2pNode is a node entry of the nodes.json file used by the devp2p tool (crawl, dns to-txt, etc).
+type Devp2pNode struct {
+	Seq           uint64      `json:"seq"`
+	N             *enode.Node `json:"record"`
+	Score         int         `json:"score,omitempty"`
+	FirstResponse time.Time   `json:"firstResponse,omitempty"`
+	LastResponse  time.Time   `json:"lastResponse,omitempty"`
+	LastCheck     time.Time   `json:"lastCheck,omitempty"`
+}
+
+type Devp2pNodeSet map[enode.ID]Devp2pNode
+
+// CreateDevp2pNodeSet returns the nodes with a valid ENR.
+func CreateDevp2pNodeSet(ctx context.Context, db database.DB, filter database.NodeFilter) (Devp2pNodeSet, error) {
+	nodes := make(Devp2pNodeSet)
+	err := db.EnumerateNodes(ctx, filter, func(node database.NodeInfo) {
+		if node.ENR == nil {
+			return
+		}
+		record, err := enode.Parse(enode.ValidSchemes, *node.ENR)
+		if err != nil {
+			return
+		}
+
+		entry := Devp2pNode{
+			Seq: record.Seq(),
+			N:   record,
+		}
+		if node.LastSeen != nil {
+			entry.LastResponse = node.LastSeen.UTC()
+			entry.LastCheck = entry.LastResponse
+		}
+		nodes[record.ID()] = entry
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
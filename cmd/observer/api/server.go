@@ -0,0 +1,271 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ledgerwatch/erigon/cmd/observer/database"
+	"github.com/ledgerwatch/erigon/cmd/observer/reports"
+	"github.com/ledgerwatch/log/v3"
+)
+
+type ServerConfig struct {
+	NetworkID     uint
+	ClientsLimit  uint
+	MaxPingTries  uint
+	ErigonLogPath string
+}
+
+type Server struct {
+	db      database.DB
+	config  ServerConfig
+	metrics *ClientsMetrics
+	log     log.Logger
+}
+
+type nodeJSON struct {
+	ID           database.NodeID `json:"id"`
+	IP           net.IP          `json:"ip,omitempty"`
+	PortDisc     uint16          `json:"portDisc,omitempty"`
+	PortRLPx     uint16          `json:"portRLPx,omitempty"`
+	IPv6         net.IP          `json:"ipV6,omitempty"`
+	IPv6PortDisc uint16          `json:"ipV6PortDisc,omitempty"`
+	IPv6PortRLPx uint16          `json:"ipV6PortRLPx,omitempty"`
+	ClientID     *string         `json:"clientID,omitempty"`
+	NetworkID    *uint           `json:"networkID,omitempty"`
+	EthVersion   *uint           `json:"ethVersion,omitempty"`
+	IsCompatFork *bool           `json:"compatFork,omitempty"`
+	LastSeen     *time.Time      `json:"lastSeen,omitempty"`
+	ENR          *string         `json:"enr,omitempty"`
+}
+
+func NewServer(db database.DB, config ServerConfig, metrics *ClientsMetrics, logger log.Logger) *Server {
+	return &Server{db, config, metrics, logger}
+}
+
+// Handler serves:
+//
+//	/status, /clients, /sentry-candidates - the reports as JSON
+//	/nodes - the nodes as JSON
+//	/enrs - the node records as a text list
+//	/nodes.json - the nodes in the devp2p crawler format
+//	/metrics - the client distribution Prometheus gauges
+//
+// The node endpoints accept the "network_id", "client", "seen_after" and "limit" query parameters.
+func (server *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", server.handleStatus)
+	mux.HandleFunc("/clients", server.handleClients)
+	mux.HandleFunc("/sentry-candidates", server.handleSentryCandidates)
+	mux.HandleFunc("/nodes", server.handleNodes)
+	mux.HandleFunc("/enrs", server.handleENRs)
+	mux.HandleFunc("/nodes.json", server.handleDevp2pNodes)
+	mux.HandleFunc("/metrics", server.handleMetrics)
+	return mux
+}
+
+func (server *Server) Run(ctx context.Context, addr string) error {
+	httpServer := http.Server{
+		Addr:              addr,
+		Handler:           server.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = httpServer.Close()
+	}()
+
+	server.log.Info("HTTP API started", "addr", addr)
+	err := httpServer.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return ctx.Err()
+	}
+	return err
+}
+
+func (server *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	networkID, err := server.parseNetworkID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	report, err := reports.CreateStatusReport(r.Context(), server.db, server.config.MaxPingTries, networkID)
+	server.writeJSON(w, report, err)
+}
+
+func (server *Server) handleClients(w http.ResponseWriter, r *http.Request) {
+	networkID, err := server.parseNetworkID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit, err := parseUintParam(r, "limit", server.config.ClientsLimit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	report, err := reports.CreateClientsReport(r.Context(), server.db, limit, server.config.MaxPingTries, networkID)
+	server.writeJSON(w, report, err)
+}
+
+func (server *Server) handleSentryCandidates(w http.ResponseWriter, r *http.Request) {
+	if server.config.ErigonLogPath == "" {
+		http.Error(w, "the sentry candidates report requires 'erigon-log'", http.StatusNotFound)
+		return
+	}
+	report, err := reports.CreateSentryCandidatesReport(r.Context(), server.db, server.config.ErigonLogPath)
+	server.writeJSON(w, report, err)
+}
+
+func (server *Server) handleNodes(w http.ResponseWriter, r *http.Request) {
+	filter, err := server.parseNodeFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	nodes := make([]nodeJSON, 0)
+	err = server.db.EnumerateNodes(r.Context(), filter, func(node database.NodeInfo) {
+		nodes = append(nodes, nodeJSON{
+			ID:           node.ID,
+			IP:           node.Addr.IP,
+			PortDisc:     node.Addr.PortDisc,
+			PortRLPx:     node.Addr.PortRLPx,
+			IPv6:         node.Addr.IPv6.IP,
+			IPv6PortDisc: node.Addr.IPv6.PortDisc,
+			IPv6PortRLPx: node.Addr.IPv6.PortRLPx,
+			ClientID:     node.ClientID,
+			NetworkID:    node.NetworkID,
+			EthVersion:   node.EthVersion,
+			IsCompatFork: node.IsCompatFork,
+			LastSeen:     node.LastSeen,
+			ENR:          node.ENR,
+		})
+	})
+	server.writeJSON(w, nodes, err)
+}
+
+func (server *Server) handleENRs(w http.ResponseWriter, r *http.Request) {
+	filter, err := server.parseNodeFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var builder strings.Builder
+	err = server.db.EnumerateNodes(r.Context(), filter, func(node database.NodeInfo) {
+		if node.ENR != nil {
+			builder.WriteString(*node.ENR)
+			builder.WriteRune('\n')
+		}
+	})
+	if err != nil {
+		server.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(builder.String()))
+}
+
+func (server *Server) handleDevp2pNodes(w http.ResponseWriter, r *http.Request) {
+	filter, err := server.parseNodeFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	nodes, err := CreateDevp2pNodeSet(r.Context(), server.db, filter)
+	server.writeJSON(w, nodes, err)
+}
+
+func (server *Server) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	server.metrics.WritePrometheus(w)
+}
+
+func (server *Server) writeJSON(w http.ResponseWriter, value interface{}, err error) {
+	if err != nil {
+		server.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		server.log.Debug("Failed to write a response", "err", err)
+	}
+}
+
+func (server *Server) writeError(w http.ResponseWriter, err error) {
+	if !errors.Is(err, context.Canceled) {
+		server.log.Error("HTTP API request failed", "err", err)
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+func (server *Server) parseNetworkID(r *http.Request) (uint, error) {
+	return parseUintParam(r, "network_id", server.config.NetworkID)
+}
+
+// parseNodeFilter reads the node filters from the query parameters.
+// "network_id" defaults to the chain network ID, and "any" disables the filter.
+// "seen_after" is either a unix time or a duration before now (e.g. "24h").
+func (server *Server) parseNodeFilter(r *http.Request) (database.NodeFilter, error) {
+	query := r.URL.Query()
+	filter := database.NodeFilter{
+		MaxPingTries:   server.config.MaxPingTries,
+		ClientIDPrefix: query.Get("client"),
+	}
+
+	if query.Get("network_id") != "any" {
+		networkID, err := server.parseNetworkID(r)
+		if err != nil {
+			return filter, err
+		}
+		filter.NetworkID = &networkID
+	}
+
+	if value := query.Get("seen_after"); value != "" {
+		seenAfter, err := parseSeenAfter(value, time.Now())
+		if err != nil {
+			return filter, err
+		}
+		filter.SeenAfter = &seenAfter
+	}
+
+	limit, err := parseUintParam(r, "limit", 0)
+	if err != nil {
+		return filter, err
+	}
+	filter.Limit = limit
+	return filter, nil
+}
+
+func parseSeenAfter(value string, now time.Time) (time.Time, error) {
+	if unixTime, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(unixTime, 0), nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid 'seen_after' %q: expected a unix time or a duration", value)
+	}
+	return now.Add(-duration), nil
+}
+
+func parseUintParam(r *http.Request, name string, defaultValue uint) (uint, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	result, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid '%s' %q: %w", name, value, err)
+	}
+	return uint(result), nil
+}
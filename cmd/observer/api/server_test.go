@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ledgerwatch/erigon/cmd/observer/database"
+	"github.com/ledgerwatch/erigon/cmd/observer/reports"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/p2p/enode"
+	"github.com/ledgerwatch/erigon/p2p/enr"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestServer(t *testing.T) (*Server, *enode.Node) {
+	ctx := context.Background()
+	db, err := database.NewDBSQLite(filepath.Join(t.TempDir(), "observer.sqlite"))
+	require.Nil(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	var record enr.Record
+	record.Set(enr.IP(net.ParseIP("10.0.1.1")))
+	record.Set(enr.TCP(30303))
+	require.Nil(t, enode.SignV4(&record, key))
+	node, err := enode.New(enode.ValidSchemes, &record)
+	require.Nil(t, err)
+
+	id1 := database.NodeID(node.ID().String())
+	var addr database.NodeAddr
+	addr.IP = net.ParseIP("10.0.1.1")
+	addr.PortRLPx = 30303
+	require.Nil(t, db.UpsertNodeAddr(ctx, id1, addr))
+	require.Nil(t, db.ResetPingError(ctx, id1))
+	require.Nil(t, db.UpdateClientID(ctx, id1, "erigon/v2.33.0"))
+	require.Nil(t, db.UpdateNetworkID(ctx, id1, 1))
+	require.Nil(t, db.UpsertNodeRecord(ctx, id1, node.String()))
+
+	var id2 database.NodeID = "ba85011c70bcc5c04d8607d3a0ed29aa6179c092cbdda10d5d32684fb33ed01bd94f588ca8f91ac48318087dcb02eaf36773a7a453f0eedd6742af668097b29c"
+	require.Nil(t, db.UpsertNodeAddr(ctx, id2, addr))
+	require.Nil(t, db.UpdateClientID(ctx, id2, "Geth/v1.10.26"))
+	require.Nil(t, db.UpdateNetworkID(ctx, id2, 5))
+
+	config := ServerConfig{
+		NetworkID:    1,
+		ClientsLimit: 10,
+		MaxPingTries: 3,
+	}
+	return NewServer(db, config, NewClientsMetrics(), log.New()), node
+}
+
+func get(t *testing.T, server *Server, url string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, url, nil))
+	return recorder
+}
+
+func TestServerNodes(t *testing.T) {
+	server, node := makeTestServer(t)
+
+	var nodes []nodeJSON
+	response := get(t, server, "/nodes")
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Nil(t, json.Unmarshal(response.Body.Bytes(), &nodes))
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, "erigon/v2.33.0", *nodes[0].ClientID)
+	assert.Equal(t, node.String(), *nodes[0].ENR)
+
+	response = get(t, server, "/nodes?network_id=any&client=geth")
+	require.Nil(t, json.Unmarshal(response.Body.Bytes(), &nodes))
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, uint(5), *nodes[0].NetworkID)
+
+	response = get(t, server, "/nodes?network_id=any&seen_after=1h")
+	require.Nil(t, json.Unmarshal(response.Body.Bytes(), &nodes))
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, "erigon/v2.33.0", *nodes[0].ClientID)
+
+	response = get(t, server, "/nodes?seen_after=yesterday")
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
+
+func TestServerENRs(t *testing.T) {
+	server, node := makeTestServer(t)
+
+	response := get(t, server, "/enrs?network_id=any")
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, node.String()+"\n", response.Body.String())
+
+	var nodes Devp2pNodeSet
+	response = get(t, server, "/nodes.json")
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Nil(t, json.Unmarshal(response.Body.Bytes(), &nodes))
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, node.String(), nodes[node.ID()].N.String())
+	assert.False(t, nodes[node.ID()].LastResponse.IsZero())
+}
+
+func TestServerReports(t *testing.T) {
+	server, _ := makeTestServer(t)
+
+	var status reports.StatusReport
+	response := get(t, server, "/status")
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Nil(t, json.Unmarshal(response.Body.Bytes(), &status))
+	assert.Equal(t, uint(1), status.TotalCount)
+
+	var clients reports.ClientsReport
+	response = get(t, server, "/clients?network_id=5")
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Nil(t, json.Unmarshal(response.Body.Bytes(), &clients))
+	assert.Equal(t, "Geth", clients.Clients[0].Name)
+
+	response = get(t, server, "/sentry-candidates")
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestClientsMetrics(t *testing.T) {
+	server, _ := makeTestServer(t)
+	server.metrics.Update(
+		&reports.StatusReport{TotalCount: 3, DistinctIPCount: 2},
+		&reports.ClientsReport{Clients: []reports.ClientsReportEntry{
+			{Name: "erigon", Count: 2},
+			{Name: "...", Count: 0},
+			{Name: "total", Count: 2},
+			{Name: "unknown", Count: 1},
+		}})
+
+	response := get(t, server, "/metrics")
+	require.Equal(t, http.StatusOK, response.Code)
+	body := response.Body.String()
+	assert.Contains(t, body, "observer_nodes 3\n")
+	assert.Contains(t, body, "observer_ips 2\n")
+	assert.Contains(t, body, `observer_clients{client="erigon"} 2`)
+	assert.Contains(t, body, `observer_clients{client="unknown"} 1`)
+	assert.False(t, strings.Contains(body, `client="total"`))
+}
+
+func TestParseSeenAfter(t *testing.T) {
+	now := time.Unix(10000, 0)
+
+	seenAfter, err := parseSeenAfter("5000", now)
+	require.Nil(t, err)
+	assert.Equal(t, time.Unix(5000, 0), seenAfter)
+
+	seenAfter, err = parseSeenAfter("1h", now)
+	require.Nil(t, err)
+	assert.Equal(t, time.Unix(10000-3600, 0), seenAfter)
+
+	_, err = parseSeenAfter("yesterday", now)
+	assert.NotNil(t, err)
+}
@@ -25,6 +25,28 @@ type HandshakeError struct {
 	Time       time.Time
 }
 
+// NodeFilter selects healthy nodes for EnumerateNodes.
+// Optional filters are skipped when nil or empty.
+type NodeFilter struct {
+	MaxPingTries   uint
+	NetworkID      *uint
+	ClientIDPrefix string
+	SeenAfter      *time.Time
+	// Limit is the maximum number of nodes, or 0 for no limit.
+	Limit uint
+}
+
+type NodeInfo struct {
+	ID           NodeID
+	Addr         NodeAddr
+	ClientID     *string
+	NetworkID    *uint
+	EthVersion   *uint
+	IsCompatFork *bool
+	LastSeen     *time.Time
+	ENR          *string
+}
+
 type DB interface {
 	io.Closer
 
@@ -39,6 +61,8 @@ type DB interface {
 	FindClientID(ctx context.Context, id NodeID) (*string, error)
 	UpdateNetworkID(ctx context.Context, id NodeID, networkID uint) error
 	UpdateEthVersion(ctx context.Context, id NodeID, ethVersion uint) error
+	// UpdateForkID saves the fork ID from the eth Status message, forkHash is hex encoded.
+	UpdateForkID(ctx context.Context, id NodeID, forkHash string, forkNext uint64) error
 	UpdateHandshakeTransientError(ctx context.Context, id NodeID, hasTransientErr bool) error
 	InsertHandshakeError(ctx context.Context, id NodeID, handshakeErr string) error
 	DeleteHandshakeErrors(ctx context.Context, id NodeID) error
@@ -52,6 +76,7 @@ type DB interface {
 	TakeHandshakeCandidates(ctx context.Context, limit uint) ([]NodeID, error)
 
 	UpdateForkCompatibility(ctx context.Context, id NodeID, isCompatFork bool) error
+	UpsertNodeRecord(ctx context.Context, id NodeID, enr string) error
 
 	UpdateNeighborBucketKeys(ctx context.Context, id NodeID, keys []string) error
 	FindNeighborBucketKeys(ctx context.Context, id NodeID) ([]string, error)
@@ -74,4 +99,8 @@ type DB interface {
 	CountClientsWithNetworkID(ctx context.Context, clientIDPrefix string, maxPingTries uint) (uint, error)
 	CountClientsWithHandshakeTransientError(ctx context.Context, clientIDPrefix string, maxPingTries uint) (uint, error)
 	EnumerateClientIDs(ctx context.Context, maxPingTries uint, networkID uint, enumFunc func(clientID *string)) error
+	// EnumerateNodeRecords yields the last known signed ENR (in the "enr:" text form) of each healthy node.
+	EnumerateNodeRecords(ctx context.Context, maxPingTries uint, networkID uint, enumFunc func(enr string)) error
+	EnumerateNodes(ctx context.Context, filter NodeFilter, enumFunc func(node NodeInfo)) error
+	EnumerateForkIDs(ctx context.Context, maxPingTries uint, networkID uint, enumFunc func(clientID *string, forkHash *string, forkNext *uint64)) error
 }
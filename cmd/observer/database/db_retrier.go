@@ -198,6 +198,20 @@ func (db DBRetrier) UpdateForkCompatibility(ctx context.Context, id NodeID, isCo
 	return err
 }
 
+func (db DBRetrier) UpsertNodeRecord(ctx context.Context, id NodeID, enr string) error {
+	_, err := db.retry(ctx, "UpsertNodeRecord", func(ctx context.Context) (interface{}, error) {
+		return nil, db.db.UpsertNodeRecord(ctx, id, enr)
+	})
+	return err
+}
+
+func (db DBRetrier) UpdateForkID(ctx context.Context, id NodeID, forkHash string, forkNext uint64) error {
+	_, err := db.retry(ctx, "UpdateForkID", func(ctx context.Context) (interface{}, error) {
+		return nil, db.db.UpdateForkID(ctx, id, forkHash, forkNext)
+	})
+	return err
+}
+
 func (db DBRetrier) UpdateNeighborBucketKeys(ctx context.Context, id NodeID, keys []string) error {
 	_, err := db.retry(ctx, "UpdateNeighborBucketKeys", func(ctx context.Context) (interface{}, error) {
 		return nil, db.db.UpdateNeighborBucketKeys(ctx, id, keys)
@@ -33,6 +33,7 @@ CREATE TABLE IF NOT EXISTS nodes (
     addr_updated INTEGER NOT NULL,
 
 	ping_try INTEGER NOT NULL DEFAULT 0,
+    last_seen INTEGER,
 
     compat_fork INTEGER,
     compat_fork_updated INTEGER,
@@ -40,6 +41,8 @@ CREATE TABLE IF NOT EXISTS nodes (
     client_id TEXT,
     network_id INTEGER,
     eth_version INTEGER,
+    fork_hash TEXT,
+    fork_next INTEGER,
     handshake_transient_err INTEGER NOT NULL DEFAULT 0,
     handshake_updated INTEGER,
     handshake_retry_time INTEGER,
@@ -55,6 +58,12 @@ CREATE TABLE IF NOT EXISTS handshake_errors (
     updated INTEGER NOT NULL
 );
 
+CREATE TABLE IF NOT EXISTS node_records (
+    id TEXT PRIMARY KEY,
+    enr TEXT NOT NULL,
+    updated INTEGER NOT NULL
+);
+
 CREATE TABLE IF NOT EXISTS sentry_candidates_intake (
     id INTEGER PRIMARY KEY,
     last_event_time INTEGER NOT NULL
@@ -101,10 +110,18 @@ SELECT
     ip_v6_port_rlpx
 FROM nodes
 WHERE id = ?
+`
+
+	sqlCountNodesColumn = `
+SELECT COUNT(*) FROM pragma_table_info('nodes') WHERE name = ?
+`
+
+	sqlCreateLastSeenIndex = `
+CREATE INDEX IF NOT EXISTS idx_nodes_last_seen ON nodes (last_seen);
 `
 
 	sqlResetPingError = `
-UPDATE nodes SET ping_try = 0 WHERE id = ?
+UPDATE nodes SET ping_try = 0, last_seen = ? WHERE id = ?
 `
 
 	sqlUpdatePingError = `
@@ -138,6 +155,14 @@ UPDATE nodes SET
 	eth_version = ?, 
 	handshake_updated = ?
 WHERE id = ?
+`
+
+	sqlUpdateForkID = `
+UPDATE nodes SET 
+	fork_hash = ?, 
+	fork_next = ?, 
+	handshake_updated = ?
+WHERE id = ?
 `
 
 	sqlUpdateHandshakeTransientError = `
@@ -196,6 +221,17 @@ UPDATE nodes SET handshake_retry_time = ? WHERE id IN (123)
 UPDATE nodes SET compat_fork = ?, compat_fork_updated = ? WHERE id = ?
 `
 
+	sqlUpsertNodeRecord = `
+INSERT INTO node_records(
+	id,
+	enr,
+	updated
+) VALUES (?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+	enr = excluded.enr,
+	updated = excluded.updated
+`
+
 	sqlUpdateNeighborBucketKeys = `
 UPDATE nodes SET neighbor_keys = ? WHERE id = ?
 `
@@ -284,8 +320,61 @@ WHERE (ping_try < ?)
     AND ((network_id = ?) OR (network_id IS NULL))
     AND ((compat_fork == TRUE) OR (compat_fork IS NULL))
 `
+
+	sqlEnumerateNodeRecords = `
+SELECT node_records.enr FROM nodes
+JOIN node_records ON node_records.id = nodes.id
+WHERE (nodes.ping_try < ?)
+    AND (nodes.network_id = ?)
+    AND ((nodes.compat_fork == TRUE) OR (nodes.compat_fork IS NULL))
+ORDER BY nodes.id
+`
+
+	sqlEnumerateNodes = `
+SELECT
+    nodes.id,
+    nodes.ip,
+    nodes.port_disc,
+    nodes.port_rlpx,
+    nodes.ip_v6,
+    nodes.ip_v6_port_disc,
+    nodes.ip_v6_port_rlpx,
+    nodes.client_id,
+    nodes.network_id,
+    nodes.eth_version,
+    nodes.compat_fork,
+    nodes.last_seen,
+    node_records.enr
+FROM nodes
+LEFT JOIN node_records ON node_records.id = nodes.id
+WHERE (nodes.ping_try < ?)
+    AND ((? IS NULL) OR (nodes.network_id = ?))
+    AND ((? = '') OR (nodes.client_id LIKE ?))
+    AND ((? IS NULL) OR (nodes.last_seen >= ?))
+    AND ((nodes.compat_fork == TRUE) OR (nodes.compat_fork IS NULL))
+ORDER BY nodes.id
+LIMIT ?
+`
+
+	sqlEnumerateForkIDs = `
+SELECT client_id, fork_hash, fork_next FROM nodes
+WHERE (ping_try < ?)
+    AND (network_id = ?)
+    AND ((compat_fork == TRUE) OR (compat_fork IS NULL))
+`
 )
 
+// nodesColumnMigrations are the nodes columns added after the initial schema.
+// They are in sqlCreateSchema for the new DBs, and added by migrateSchema to the existing ones.
+var nodesColumnMigrations = []struct {
+	name       string
+	definition string
+}{
+	{"last_seen", "INTEGER"},
+	{"fork_hash", "TEXT"},
+	{"fork_next", "INTEGER"},
+}
+
 func NewDBSQLite(filePath string) (*DBSQLite, error) {
 	db, err := sql.Open("sqlite", filePath)
 	if err != nil {
@@ -297,10 +386,32 @@ func NewDBSQLite(filePath string) (*DBSQLite, error) {
 		return nil, fmt.Errorf("failed to create the DB schema: %w", err)
 	}
 
+	if err = migrateSchema(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate the DB schema: %w", err)
+	}
+
 	instance := DBSQLite{db}
 	return &instance, nil
 }
 
+// migrateSchema adds the columns introduced after a DB was created.
+func migrateSchema(db *sql.DB) error {
+	for _, column := range nodesColumnMigrations {
+		var count int
+		if err := db.QueryRow(sqlCountNodesColumn, column.name).Scan(&count); err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		if _, err := db.Exec(fmt.Sprintf("ALTER TABLE nodes ADD COLUMN %s %s", column.name, column.definition)); err != nil {
+			return err
+		}
+	}
+	_, err := db.Exec(sqlCreateLastSeenIndex)
+	return err
+}
+
 func (db *DBSQLite) Close() error {
 	return db.db.Close()
 }
@@ -379,19 +490,34 @@ func (db *DBSQLite) FindNodeAddr(ctx context.Context, id NodeID) (*NodeAddr, err
 		return nil, fmt.Errorf("FindNodeAddr failed: %w", err)
 	}
 
+	addr, err := makeNodeAddr(ip, portDisc, portRLPx, ipV6, ipV6PortDisc, ipV6PortRLPx)
+	if err != nil {
+		return nil, fmt.Errorf("FindNodeAddr failed: %w", err)
+	}
+	return addr, nil
+}
+
+func makeNodeAddr(
+	ip sql.NullString,
+	portDisc sql.NullInt32,
+	portRLPx sql.NullInt32,
+	ipV6 sql.NullString,
+	ipV6PortDisc sql.NullInt32,
+	ipV6PortRLPx sql.NullInt32,
+) (*NodeAddr, error) {
 	var addr NodeAddr
 
 	if ip.Valid {
 		value := net.ParseIP(ip.String)
 		if value == nil {
-			return nil, errors.New("FindNodeAddr failed to parse IP")
+			return nil, errors.New("failed to parse IP")
 		}
 		addr.IP = value
 	}
 	if ipV6.Valid {
 		value := net.ParseIP(ipV6.String)
 		if value == nil {
-			return nil, errors.New("FindNodeAddr failed to parse IPv6")
+			return nil, errors.New("failed to parse IPv6")
 		}
 		addr.IPv6.IP = value
 	}
@@ -416,7 +542,9 @@ func (db *DBSQLite) FindNodeAddr(ctx context.Context, id NodeID) (*NodeAddr, err
 }
 
 func (db *DBSQLite) ResetPingError(ctx context.Context, id NodeID) error {
-	_, err := db.db.ExecContext(ctx, sqlResetPingError, id)
+	lastSeen := time.Now().Unix()
+
+	_, err := db.db.ExecContext(ctx, sqlResetPingError, lastSeen, id)
 	if err != nil {
 		return fmt.Errorf("ResetPingError failed: %w", err)
 	}
@@ -489,6 +617,16 @@ func (db *DBSQLite) UpdateEthVersion(ctx context.Context, id NodeID, ethVersion
 	return nil
 }
 
+func (db *DBSQLite) UpdateForkID(ctx context.Context, id NodeID, forkHash string, forkNext uint64) error {
+	updated := time.Now().Unix()
+
+	_, err := db.db.ExecContext(ctx, sqlUpdateForkID, forkHash, int64(forkNext), updated, id)
+	if err != nil {
+		return fmt.Errorf("UpdateForkID failed: %w", err)
+	}
+	return nil
+}
+
 func (db *DBSQLite) UpdateHandshakeTransientError(ctx context.Context, id NodeID, hasTransientErr bool) error {
 	updated := time.Now().Unix()
 
@@ -684,6 +822,16 @@ func (db *DBSQLite) UpdateForkCompatibility(ctx context.Context, id NodeID, isCo
 	return nil
 }
 
+func (db *DBSQLite) UpsertNodeRecord(ctx context.Context, id NodeID, enr string) error {
+	updated := time.Now().Unix()
+
+	_, err := db.db.ExecContext(ctx, sqlUpsertNodeRecord, id, enr, updated)
+	if err != nil {
+		return fmt.Errorf("UpsertNodeRecord failed to execute query: %w", err)
+	}
+	return nil
+}
+
 func (db *DBSQLite) UpdateNeighborBucketKeys(ctx context.Context, id NodeID, keys []string) error {
 	keysStr := strings.Join(keys, ",")
 
@@ -919,6 +1067,184 @@ func (db *DBSQLite) EnumerateClientIDs(
 	return nil
 }
 
+func (db *DBSQLite) EnumerateNodeRecords(
+	ctx context.Context,
+	maxPingTries uint,
+	networkID uint,
+	enumFunc func(enr string),
+) error {
+	cursor, err := db.db.QueryContext(ctx, sqlEnumerateNodeRecords, maxPingTries, networkID)
+	if err != nil {
+		return fmt.Errorf("EnumerateNodeRecords failed to query: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	for cursor.Next() {
+		var enr string
+		err := cursor.Scan(&enr)
+		if err != nil {
+			return fmt.Errorf("EnumerateNodeRecords failed to read data: %w", err)
+		}
+		enumFunc(enr)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("EnumerateNodeRecords failed to iterate: %w", err)
+	}
+	return nil
+}
+
+func (db *DBSQLite) EnumerateNodes(ctx context.Context, filter NodeFilter, enumFunc func(node NodeInfo)) error {
+	networkID := filter.NetworkID
+
+	var seenAfter *int64
+	if filter.SeenAfter != nil {
+		value := filter.SeenAfter.Unix()
+		seenAfter = &value
+	}
+
+	var clientIDPattern string
+	if filter.ClientIDPrefix != "" {
+		clientIDPattern = filter.ClientIDPrefix + "%"
+	}
+
+	limit := int64(-1)
+	if filter.Limit > 0 {
+		limit = int64(filter.Limit)
+	}
+
+	cursor, err := db.db.QueryContext(ctx, sqlEnumerateNodes,
+		filter.MaxPingTries,
+		networkID, networkID,
+		clientIDPattern, clientIDPattern,
+		seenAfter, seenAfter,
+		limit)
+	if err != nil {
+		return fmt.Errorf("EnumerateNodes failed to query: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	for cursor.Next() {
+		var id string
+		var ip sql.NullString
+		var portDisc sql.NullInt32
+		var portRLPx sql.NullInt32
+		var ipV6 sql.NullString
+		var ipV6PortDisc sql.NullInt32
+		var ipV6PortRLPx sql.NullInt32
+		var clientID sql.NullString
+		var nodeNetworkID sql.NullInt64
+		var ethVersion sql.NullInt64
+		var isCompatFork sql.NullBool
+		var lastSeen sql.NullInt64
+		var enr sql.NullString
+
+		err := cursor.Scan(
+			&id,
+			&ip,
+			&portDisc,
+			&portRLPx,
+			&ipV6,
+			&ipV6PortDisc,
+			&ipV6PortRLPx,
+			&clientID,
+			&nodeNetworkID,
+			&ethVersion,
+			&isCompatFork,
+			&lastSeen,
+			&enr)
+		if err != nil {
+			return fmt.Errorf("EnumerateNodes failed to read data: %w", err)
+		}
+
+		addr, err := makeNodeAddr(ip, portDisc, portRLPx, ipV6, ipV6PortDisc, ipV6PortRLPx)
+		if err != nil {
+			return fmt.Errorf("EnumerateNodes failed: %w", err)
+		}
+
+		node := NodeInfo{
+			ID:   NodeID(id),
+			Addr: *addr,
+		}
+		if clientID.Valid {
+			node.ClientID = &clientID.String
+		}
+		if nodeNetworkID.Valid {
+			value := uint(nodeNetworkID.Int64)
+			node.NetworkID = &value
+		}
+		if ethVersion.Valid {
+			value := uint(ethVersion.Int64)
+			node.EthVersion = &value
+		}
+		if isCompatFork.Valid {
+			node.IsCompatFork = &isCompatFork.Bool
+		}
+		if lastSeen.Valid {
+			value := time.Unix(lastSeen.Int64, 0)
+			node.LastSeen = &value
+		}
+		if enr.Valid {
+			node.ENR = &enr.String
+		}
+		enumFunc(node)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("EnumerateNodes failed to iterate: %w", err)
+	}
+	return nil
+}
+
+func (db *DBSQLite) EnumerateForkIDs(
+	ctx context.Context,
+	maxPingTries uint,
+	networkID uint,
+	enumFunc func(clientID *string, forkHash *string, forkNext *uint64),
+) error {
+	cursor, err := db.db.QueryContext(ctx, sqlEnumerateForkIDs, maxPingTries, networkID)
+	if err != nil {
+		return fmt.Errorf("EnumerateForkIDs failed to query: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	for cursor.Next() {
+		var clientID sql.NullString
+		var forkHash sql.NullString
+		var forkNext sql.NullInt64
+		err := cursor.Scan(&clientID, &forkHash, &forkNext)
+		if err != nil {
+			return fmt.Errorf("EnumerateForkIDs failed to read data: %w", err)
+		}
+
+		var clientIDPtr *string
+		if clientID.Valid {
+			clientIDPtr = &clientID.String
+		}
+		var forkHashPtr *string
+		if forkHash.Valid {
+			forkHashPtr = &forkHash.String
+		}
+		var forkNextPtr *uint64
+		if forkNext.Valid {
+			value := uint64(forkNext.Int64)
+			forkNextPtr = &value
+		}
+		enumFunc(clientIDPtr, forkHashPtr, forkNextPtr)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("EnumerateForkIDs failed to iterate: %w", err)
+	}
+	return nil
+}
+
 func stringsToAny(strValues []NodeID) []interface{} {
 	values := make([]interface{}, 0, len(strValues))
 	for _, value := range strValues {
@@ -5,6 +5,7 @@ import (
 	"net"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -39,3 +40,135 @@ func TestDBSQLiteInsertAndFind(t *testing.T) {
 	assert.Equal(t, addr.PortDisc, candidate.PortDisc)
 	assert.Equal(t, addr.PortRLPx, candidate.PortRLPx)
 }
+
+func TestDBSQLiteEnumerateNodeRecords(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewDBSQLite(filepath.Join(t.TempDir(), "observer.sqlite"))
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	var id NodeID = "ba85011c70bcc5c04d8607d3a0ed29aa6179c092cbdda10d5d32684fb33ed01bd94f588ca8f91ac48318087dcb02eaf36773a7a453f0eedd6742af668097b29c"
+	var addr NodeAddr
+	addr.IP = net.ParseIP("10.0.1.16")
+	require.Nil(t, db.UpsertNodeAddr(ctx, id, addr))
+	require.Nil(t, db.UpdateNetworkID(ctx, id, 1))
+	require.Nil(t, db.UpsertNodeRecord(ctx, id, "enr:old"))
+	require.Nil(t, db.UpsertNodeRecord(ctx, id, "enr:new"))
+
+	var records []string
+	enumFunc := func(enr string) { records = append(records, enr) }
+
+	require.Nil(t, db.EnumerateNodeRecords(ctx, 3, 1, enumFunc))
+	assert.Equal(t, []string{"enr:new"}, records)
+
+	// other networks and dead nodes are excluded
+	records = nil
+	require.Nil(t, db.EnumerateNodeRecords(ctx, 3, 5, enumFunc))
+	assert.Empty(t, records)
+
+	require.Nil(t, db.UpdateForkCompatibility(ctx, id, false))
+	require.Nil(t, db.EnumerateNodeRecords(ctx, 3, 1, enumFunc))
+	assert.Empty(t, records)
+}
+
+func TestDBSQLiteEnumerateNodes(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewDBSQLite(filepath.Join(t.TempDir(), "observer.sqlite"))
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	var id1 NodeID = "ba85011c70bcc5c04d8607d3a0ed29aa6179c092cbdda10d5d32684fb33ed01bd94f588ca8f91ac48318087dcb02eaf36773a7a453f0eedd6742af668097b29c"
+	var id2 NodeID = "ca85011c70bcc5c04d8607d3a0ed29aa6179c092cbdda10d5d32684fb33ed01bd94f588ca8f91ac48318087dcb02eaf36773a7a453f0eedd6742af668097b29c"
+	var addr NodeAddr
+	addr.IP = net.ParseIP("10.0.1.16")
+	addr.PortRLPx = 30303
+	require.Nil(t, db.UpsertNodeAddr(ctx, id1, addr))
+	require.Nil(t, db.UpsertNodeAddr(ctx, id2, addr))
+	require.Nil(t, db.ResetPingError(ctx, id1))
+	require.Nil(t, db.UpdateClientID(ctx, id1, "erigon/v2.33.0"))
+	require.Nil(t, db.UpdateNetworkID(ctx, id1, 1))
+	require.Nil(t, db.UpsertNodeRecord(ctx, id1, "enr:test"))
+	require.Nil(t, db.UpdateClientID(ctx, id2, "Geth/v1.10.26"))
+
+	enumerate := func(filter NodeFilter) []NodeInfo {
+		var nodes []NodeInfo
+		require.Nil(t, db.EnumerateNodes(ctx, filter, func(node NodeInfo) { nodes = append(nodes, node) }))
+		return nodes
+	}
+
+	nodes := enumerate(NodeFilter{MaxPingTries: 3})
+	require.Equal(t, 2, len(nodes))
+	assert.Equal(t, id1, nodes[0].ID)
+	assert.Equal(t, addr.IP, nodes[0].Addr.IP)
+	assert.Equal(t, addr.PortRLPx, nodes[0].Addr.PortRLPx)
+	assert.Equal(t, "erigon/v2.33.0", *nodes[0].ClientID)
+	assert.Equal(t, uint(1), *nodes[0].NetworkID)
+	assert.Equal(t, "enr:test", *nodes[0].ENR)
+	assert.NotNil(t, nodes[0].LastSeen)
+	assert.Nil(t, nodes[1].ENR)
+	assert.Nil(t, nodes[1].LastSeen)
+
+	networkID := uint(1)
+	nodes = enumerate(NodeFilter{MaxPingTries: 3, NetworkID: &networkID})
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, id1, nodes[0].ID)
+
+	nodes = enumerate(NodeFilter{MaxPingTries: 3, ClientIDPrefix: "geth"})
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, id2, nodes[0].ID)
+
+	seenAfter := time.Now().Add(-time.Hour)
+	nodes = enumerate(NodeFilter{MaxPingTries: 3, SeenAfter: &seenAfter})
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, id1, nodes[0].ID)
+
+	nodes = enumerate(NodeFilter{MaxPingTries: 3, Limit: 1})
+	assert.Equal(t, 1, len(nodes))
+}
+
+func TestDBSQLiteMigrateSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "observer.sqlite")
+	db, err := NewDBSQLite(path)
+	require.Nil(t, err)
+	_, err = db.db.Exec("DROP INDEX idx_nodes_last_seen; ALTER TABLE nodes DROP COLUMN last_seen; ALTER TABLE nodes DROP COLUMN fork_hash")
+	require.Nil(t, err)
+	require.Nil(t, db.Close())
+
+	db, err = NewDBSQLite(path)
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+	require.Nil(t, db.ResetPingError(context.Background(), "id"))
+	require.Nil(t, db.UpdateForkID(context.Background(), "id", "f0afd0e3", 0))
+}
+
+func TestDBSQLiteEnumerateForkIDs(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewDBSQLite(filepath.Join(t.TempDir(), "observer.sqlite"))
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	var id NodeID = "ba85011c70bcc5c04d8607d3a0ed29aa6179c092cbdda10d5d32684fb33ed01bd94f588ca8f91ac48318087dcb02eaf36773a7a453f0eedd6742af668097b29c"
+	require.Nil(t, db.UpsertNodeAddr(ctx, id, NodeAddr{}))
+	require.Nil(t, db.UpdateClientID(ctx, id, "erigon/v2.33.0"))
+	require.Nil(t, db.UpdateNetworkID(ctx, id, 1))
+
+	var forkHashes []*string
+	var forkNexts []*uint64
+	enumFunc := func(clientID *string, forkHash *string, forkNext *uint64) {
+		assert.Equal(t, "erigon/v2.33.0", *clientID)
+		forkHashes = append(forkHashes, forkHash)
+		forkNexts = append(forkNexts, forkNext)
+	}
+
+	require.Nil(t, db.EnumerateForkIDs(ctx, 3, 1, enumFunc))
+	require.Equal(t, 1, len(forkHashes))
+	assert.Nil(t, forkHashes[0])
+	assert.Nil(t, forkNexts[0])
+
+	require.Nil(t, db.UpdateForkID(ctx, id, "f0afd0e3", 1681338455))
+	forkHashes, forkNexts = nil, nil
+	require.Nil(t, db.EnumerateForkIDs(ctx, 3, 1, enumFunc))
+	require.Equal(t, 1, len(forkHashes))
+	assert.Equal(t, "f0afd0e3", *forkHashes[0])
+	assert.Equal(t, uint64(1681338455), *forkNexts[0])
+}
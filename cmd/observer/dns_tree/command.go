@@ -0,0 +1,161 @@
+package dns_tree
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ledgerwatch/erigon/cmd/utils"
+)
+
+type CommandFlags struct {
+	DataDir      string
+	Chain        string
+	MaxPingTries uint
+	ForkID       string
+
+	KeyFile string
+	Domain  string
+	Seq     uint
+	Links   []string
+
+	Format       string
+	OutputPath   string
+	PreviousPath string
+}
+
+type Command struct {
+	command cobra.Command
+	flags   CommandFlags
+}
+
+func NewCommand() *Command {
+	command := cobra.Command{
+		Use:   "dns-tree",
+		Short: "Build and sign an EIP-1459 DNS discovery tree from the crawler database",
+	}
+
+	instance := Command{
+		command: command,
+	}
+	instance.withDatadir()
+	instance.withChain()
+	instance.withMaxPingTries()
+	instance.withForkID()
+	instance.withKeyFile()
+	instance.withDomain()
+	instance.withSeq()
+	instance.withLinks()
+	instance.withFormat()
+	instance.withOutputPath()
+	instance.withPreviousPath()
+
+	return &instance
+}
+
+func (command *Command) withDatadir() {
+	flag := utils.DataDirFlag
+	command.command.Flags().StringVar(&command.flags.DataDir, flag.Name, flag.Value.String(), flag.Usage)
+	must(command.command.MarkFlagDirname(utils.DataDirFlag.Name))
+}
+
+func (command *Command) withChain() {
+	flag := utils.ChainFlag
+	command.command.Flags().StringVar(&command.flags.Chain, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withMaxPingTries() {
+	flag := cli.UintFlag{
+		Name:  "max-ping-tries",
+		Usage: "A number of PING failures for a node to be considered dead",
+		Value: 3,
+	}
+	command.command.Flags().UintVar(&command.flags.MaxPingTries, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withForkID() {
+	flag := cli.StringFlag{
+		Name:  "fork-id",
+		Usage: "Only include nodes announcing this fork ID hash in their ENR (e.g. 0xf0afd0e3)",
+	}
+	command.command.Flags().StringVar(&command.flags.ForkID, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withKeyFile() {
+	flag := cli.StringFlag{
+		Name:  "key",
+		Usage: "File with the hex-encoded secp256k1 private key to sign the tree with",
+	}
+	command.command.Flags().StringVar(&command.flags.KeyFile, flag.Name, flag.Value, flag.Usage)
+	must(command.command.MarkFlagRequired(flag.Name))
+	must(command.command.MarkFlagFilename(flag.Name))
+}
+
+func (command *Command) withDomain() {
+	flag := cli.StringFlag{
+		Name:  "domain",
+		Usage: "DNS domain of the tree root (e.g. all.mainnet.example.org)",
+	}
+	command.command.Flags().StringVar(&command.flags.Domain, flag.Name, flag.Value, flag.Usage)
+	must(command.command.MarkFlagRequired(flag.Name))
+}
+
+func (command *Command) withSeq() {
+	flag := cli.UintFlag{
+		Name:  "seq",
+		Usage: "Tree sequence number (default: the current unix time, but greater than the previous tree)",
+	}
+	command.command.Flags().UintVar(&command.flags.Seq, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withLinks() {
+	flag := cli.StringSliceFlag{
+		Name:  "link",
+		Usage: "enrtree:// URL of another tree to link from this one (can be repeated)",
+	}
+	command.command.Flags().StringSliceVar(&command.flags.Links, flag.Name, nil, flag.Usage)
+}
+
+func (command *Command) withFormat() {
+	flag := cli.StringFlag{
+		Name:  "format",
+		Usage: "Output format: 'zone' (BIND zone file TXT records) or 'json'",
+		Value: FormatZone,
+	}
+	command.command.Flags().StringVar(&command.flags.Format, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withOutputPath() {
+	flag := cli.StringFlag{
+		Name:  "output",
+		Usage: "Output file path (default: stdout)",
+	}
+	command.command.Flags().StringVar(&command.flags.OutputPath, flag.Name, flag.Value, flag.Usage)
+	must(command.command.MarkFlagFilename(flag.Name))
+}
+
+func (command *Command) withPreviousPath() {
+	flag := cli.StringFlag{
+		Name:  "previous",
+		Usage: "A previously generated JSON tree to diff against",
+	}
+	command.command.Flags().StringVar(&command.flags.PreviousPath, flag.Name, flag.Value, flag.Usage)
+	must(command.command.MarkFlagFilename(flag.Name))
+}
+
+func (command *Command) RawCommand() *cobra.Command {
+	return &command.command
+}
+
+func (command *Command) OnRun(runFunc func(ctx context.Context, flags CommandFlags) error) {
+	command.command.RunE = func(cmd *cobra.Command, args []string) error {
+		return runFunc(cmd.Context(), command.flags)
+	}
+}
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
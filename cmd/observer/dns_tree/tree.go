@@ -0,0 +1,194 @@
+package dns_tree
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ledgerwatch/erigon/cmd/observer/database"
+	"github.com/ledgerwatch/erigon/common/hexutil"
+	"github.com/ledgerwatch/erigon/eth/protocols/eth"
+	"github.com/ledgerwatch/erigon/p2p/dnsdisc"
+	"github.com/ledgerwatch/erigon/p2p/enode"
+)
+
+const (
+	FormatZone = "zone"
+	FormatJSON = "json"
+)
+
+// zoneTTL is the TTL of the generated TXT records.
+const zoneTTL = 3600
+
+// maxTXTStringLength is the maximum length of a single character-string in a TXT record.
+const maxTXTStringLength = 255
+
+type TreeMeta struct {
+	URL          string    `json:"url,omitempty"`
+	Seq          uint      `json:"seq"`
+	Sig          string    `json:"signature,omitempty"`
+	Links        []string  `json:"links"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+// TreeDefinition is the JSON form of a signed tree.
+type TreeDefinition struct {
+	Meta  TreeMeta `json:"meta"`
+	Nodes []string `json:"nodes"`
+}
+
+func ParseForkHash(value string) (*[4]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+	bytes, err := hexutil.Decode(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fork ID hash %q: %w", value, err)
+	}
+	if len(bytes) != 4 {
+		return nil, fmt.Errorf("invalid fork ID hash %q: expected 4 bytes, got %d", value, len(bytes))
+	}
+	var hash [4]byte
+	copy(hash[:], bytes)
+	return &hash, nil
+}
+
+// CollectNodes returns the healthy nodes with a known ENR.
+// If forkHash is set, only the nodes announcing it in the "eth" ENR entry are returned.
+// Records that can't be parsed or don't have a reachable TCP endpoint are skipped.
+func CollectNodes(
+	ctx context.Context,
+	db database.DB,
+	maxPingTries uint,
+	networkID uint,
+	forkHash *[4]byte,
+) ([]*enode.Node, error) {
+	var nodes []*enode.Node
+	err := db.EnumerateNodeRecords(ctx, maxPingTries, networkID, func(record string) {
+		node, err := enode.Parse(enode.ValidSchemes, record)
+		if (err != nil) || (node.IP() == nil) || (node.TCP() == 0) {
+			return
+		}
+		if forkHash != nil {
+			forkID, err := eth.LoadENRForkID(node.Record())
+			if (err != nil) || (forkID == nil) || (forkID.Hash != *forkHash) {
+				return
+			}
+		}
+		nodes = append(nodes, node)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// NextSeq returns the sequence number for a new tree:
+// the current unix time, or the next one after the previous tree if it is ahead.
+func NextSeq(previous *TreeDefinition, now time.Time) uint {
+	seq := uint(now.Unix())
+	if (previous != nil) && (previous.Meta.Seq >= seq) {
+		seq = previous.Meta.Seq + 1
+	}
+	return seq
+}
+
+func BuildTree(
+	nodes []*enode.Node,
+	links []string,
+	seq uint,
+	key *ecdsa.PrivateKey,
+	domain string,
+) (*dnsdisc.Tree, *TreeDefinition, error) {
+	tree, err := dnsdisc.MakeTree(seq, nodes, links)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to make a tree: %w", err)
+	}
+	url, err := tree.Sign(key, domain)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign a tree: %w", err)
+	}
+
+	definition := TreeDefinition{
+		Meta: TreeMeta{
+			URL:          url,
+			Seq:          tree.Seq(),
+			Sig:          tree.Signature(),
+			Links:        tree.Links(),
+			LastModified: time.Now().UTC(),
+		},
+	}
+	if definition.Meta.Links == nil {
+		definition.Meta.Links = []string{}
+	}
+	for _, node := range tree.Nodes() {
+		definition.Nodes = append(definition.Nodes, node.String())
+	}
+	return tree, &definition, nil
+}
+
+func LoadTreeDefinition(path string) (*TreeDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var definition TreeDefinition
+	if err := json.Unmarshal(data, &definition); err != nil {
+		return nil, fmt.Errorf("failed to parse a tree from %s: %w", path, err)
+	}
+	return &definition, nil
+}
+
+func WriteJSON(w io.Writer, definition *TreeDefinition) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(definition)
+}
+
+// WriteZone writes the TXT records of a tree in the BIND zone file format.
+// Record names are relative to the tree domain, which becomes the zone origin.
+func WriteZone(w io.Writer, tree *dnsdisc.Tree, domain string) error {
+	if domain == "" {
+		return errors.New("a zone requires a domain")
+	}
+	records := tree.ToTXT(domain)
+	names := make([]string, 0, len(records))
+	for name := range records {
+		if name != domain {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("$ORIGIN %s.\n", strings.TrimSuffix(domain, ".")))
+	builder.WriteString(fmt.Sprintf("$TTL %d\n", zoneTTL))
+	writeZoneRecord(&builder, "@", records[domain])
+	for _, name := range names {
+		writeZoneRecord(&builder, strings.TrimSuffix(name, "."+domain), records[name])
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func writeZoneRecord(builder *strings.Builder, name string, content string) {
+	builder.WriteString(name)
+	builder.WriteString(" IN TXT")
+	for len(content) > 0 {
+		n := len(content)
+		if n > maxTXTStringLength {
+			n = maxTXTStringLength
+		}
+		builder.WriteString(fmt.Sprintf(" %q", content[:n]))
+		content = content[n:]
+	}
+	builder.WriteRune('\n')
+}
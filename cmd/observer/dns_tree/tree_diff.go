@@ -0,0 +1,115 @@
+package dns_tree
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ledgerwatch/erigon/p2p/enode"
+)
+
+type TreeDiff struct {
+	PreviousSeq uint
+	Seq         uint
+
+	AddedNodes   []string
+	RemovedNodes []string
+	UpdatedNodes []string
+
+	AddedLinks   []string
+	RemovedLinks []string
+}
+
+// CreateTreeDiff compares the nodes and links of two trees.
+// Nodes are matched by their ID, and a node is updated if its record has changed.
+func CreateTreeDiff(previous *TreeDefinition, current *TreeDefinition) (*TreeDiff, error) {
+	previousNodes, err := nodesByID(previous.Nodes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid previous tree: %w", err)
+	}
+	currentNodes, err := nodesByID(current.Nodes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid current tree: %w", err)
+	}
+
+	diff := TreeDiff{
+		PreviousSeq: previous.Meta.Seq,
+		Seq:         current.Meta.Seq,
+	}
+	for id, record := range currentNodes {
+		previousRecord, ok := previousNodes[id]
+		if !ok {
+			diff.AddedNodes = append(diff.AddedNodes, record)
+		} else if previousRecord != record {
+			diff.UpdatedNodes = append(diff.UpdatedNodes, record)
+		}
+	}
+	for id, record := range previousNodes {
+		if _, ok := currentNodes[id]; !ok {
+			diff.RemovedNodes = append(diff.RemovedNodes, record)
+		}
+	}
+	diff.AddedLinks = subtractStrings(current.Meta.Links, previous.Meta.Links)
+	diff.RemovedLinks = subtractStrings(previous.Meta.Links, current.Meta.Links)
+
+	sort.Strings(diff.AddedNodes)
+	sort.Strings(diff.RemovedNodes)
+	sort.Strings(diff.UpdatedNodes)
+	return &diff, nil
+}
+
+func (diff *TreeDiff) IsEmpty() bool {
+	return (len(diff.AddedNodes) == 0) && (len(diff.RemovedNodes) == 0) && (len(diff.UpdatedNodes) == 0) &&
+		(len(diff.AddedLinks) == 0) && (len(diff.RemovedLinks) == 0)
+}
+
+func (diff *TreeDiff) String() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("seq: %d -> %d", diff.PreviousSeq, diff.Seq))
+	builder.WriteRune('\n')
+	builder.WriteString(fmt.Sprintf("nodes: +%d -%d ~%d", len(diff.AddedNodes), len(diff.RemovedNodes), len(diff.UpdatedNodes)))
+	builder.WriteRune('\n')
+	writeDiffLines(&builder, "+", diff.AddedNodes)
+	writeDiffLines(&builder, "-", diff.RemovedNodes)
+	writeDiffLines(&builder, "~", diff.UpdatedNodes)
+	builder.WriteString(fmt.Sprintf("links: +%d -%d", len(diff.AddedLinks), len(diff.RemovedLinks)))
+	builder.WriteRune('\n')
+	writeDiffLines(&builder, "+", diff.AddedLinks)
+	writeDiffLines(&builder, "-", diff.RemovedLinks)
+	return builder.String()
+}
+
+func writeDiffLines(builder *strings.Builder, prefix string, lines []string) {
+	for _, line := range lines {
+		builder.WriteString(prefix)
+		builder.WriteRune(' ')
+		builder.WriteString(line)
+		builder.WriteRune('\n')
+	}
+}
+
+func nodesByID(records []string) (map[enode.ID]string, error) {
+	nodes := make(map[enode.ID]string, len(records))
+	for _, record := range records {
+		node, err := enode.Parse(enode.ValidSchemes, record)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node record %q: %w", record, err)
+		}
+		nodes[node.ID()] = record
+	}
+	return nodes, nil
+}
+
+func subtractStrings(values []string, other []string) []string {
+	otherSet := make(map[string]bool, len(other))
+	for _, value := range other {
+		otherSet[value] = true
+	}
+	var result []string
+	for _, value := range values {
+		if !otherSet[value] {
+			result = append(result, value)
+		}
+	}
+	return result
+}
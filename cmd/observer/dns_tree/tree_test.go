@@ -0,0 +1,163 @@
+package dns_tree
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ledgerwatch/erigon/cmd/observer/database"
+	"github.com/ledgerwatch/erigon/core/forkid"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/p2p/dnsdisc"
+	"github.com/ledgerwatch/erigon/p2p/enode"
+	"github.com/ledgerwatch/erigon/p2p/enr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestNode(t *testing.T, ip string, forkHash *[4]byte) (*enode.Node, *ecdsa.PrivateKey) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	var record enr.Record
+	record.Set(enr.IP(net.ParseIP(ip)))
+	record.Set(enr.TCP(30303))
+	record.Set(enr.UDP(30303))
+	if forkHash != nil {
+		record.Set(enr.WithEntry("eth", []forkid.ID{{Hash: *forkHash}}))
+	}
+	require.Nil(t, enode.SignV4(&record, key))
+
+	node, err := enode.New(enode.ValidSchemes, &record)
+	require.Nil(t, err)
+	return node, key
+}
+
+func TestCollectNodes(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.NewDBSQLite(filepath.Join(t.TempDir(), "observer.sqlite"))
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	forkHash := [4]byte{0xf0, 0xaf, 0xd0, 0xe3}
+	otherForkHash := [4]byte{1, 2, 3, 4}
+	node1, _ := makeTestNode(t, "10.0.1.1", &forkHash)
+	node2, _ := makeTestNode(t, "10.0.1.2", &otherForkHash)
+	node3, _ := makeTestNode(t, "10.0.1.3", nil)
+	for _, node := range []*enode.Node{node1, node2, node3} {
+		id := database.NodeID(node.ID().String())
+		require.Nil(t, db.UpsertNodeAddr(ctx, id, database.NodeAddr{}))
+		require.Nil(t, db.UpdateNetworkID(ctx, id, 1))
+		require.Nil(t, db.UpsertNodeRecord(ctx, id, node.String()))
+	}
+
+	nodes, err := CollectNodes(ctx, db, 3, 1, nil)
+	require.Nil(t, err)
+	assert.Equal(t, 3, len(nodes))
+
+	nodes, err = CollectNodes(ctx, db, 3, 1, &forkHash)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, node1.ID(), nodes[0].ID())
+}
+
+func TestParseForkHash(t *testing.T) {
+	hash, err := ParseForkHash("0xf0afd0e3")
+	require.Nil(t, err)
+	assert.Equal(t, [4]byte{0xf0, 0xaf, 0xd0, 0xe3}, *hash)
+
+	hash, err = ParseForkHash("")
+	require.Nil(t, err)
+	assert.Nil(t, hash)
+
+	_, err = ParseForkHash("0xf0af")
+	assert.NotNil(t, err)
+}
+
+func TestNextSeq(t *testing.T) {
+	now := time.Unix(1000, 0)
+	assert.Equal(t, uint(1000), NextSeq(nil, now))
+	assert.Equal(t, uint(1000), NextSeq(&TreeDefinition{Meta: TreeMeta{Seq: 999}}, now))
+	assert.Equal(t, uint(1001), NextSeq(&TreeDefinition{Meta: TreeMeta{Seq: 1000}}, now))
+}
+
+func TestBuildTreeAndWriteZone(t *testing.T) {
+	node1, _ := makeTestNode(t, "10.0.1.1", nil)
+	node2, _ := makeTestNode(t, "10.0.1.2", nil)
+	signingKey, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	const domain = "nodes.example.org"
+	tree, definition, err := BuildTree([]*enode.Node{node1, node2}, nil, 5, signingKey, domain)
+	require.Nil(t, err)
+	assert.Equal(t, uint(5), definition.Meta.Seq)
+	assert.Equal(t, 2, len(definition.Nodes))
+	assert.Equal(t, []string{}, definition.Meta.Links)
+
+	urlDomain, urlKey, err := dnsdisc.ParseURL(definition.Meta.URL)
+	require.Nil(t, err)
+	assert.Equal(t, domain, urlDomain)
+	assert.Equal(t, signingKey.PublicKey, *urlKey)
+
+	var zone bytes.Buffer
+	require.Nil(t, WriteZone(&zone, tree, domain))
+	lines := strings.Split(strings.TrimSpace(zone.String()), "\n")
+	assert.Equal(t, "$ORIGIN nodes.example.org.", lines[0])
+	assert.True(t, strings.HasPrefix(lines[2], "@ IN TXT \"enrtree-root:v1 "))
+	// the root, a branch and two nodes
+	assert.Equal(t, 2+len(tree.ToTXT(domain)), len(lines))
+	for _, line := range lines[3:] {
+		assert.NotContains(t, line, domain)
+	}
+}
+
+func TestWriteZoneRecordSplitsLongStrings(t *testing.T) {
+	var builder strings.Builder
+	writeZoneRecord(&builder, "name", strings.Repeat("a", maxTXTStringLength+10))
+	expected := "name IN TXT \"" + strings.Repeat("a", maxTXTStringLength) + "\" \"" + strings.Repeat("a", 10) + "\"\n"
+	assert.Equal(t, expected, builder.String())
+}
+
+func TestCreateTreeDiff(t *testing.T) {
+	node1, _ := makeTestNode(t, "10.0.1.1", nil)
+	node2, key2 := makeTestNode(t, "10.0.1.2", nil)
+	node3, _ := makeTestNode(t, "10.0.1.3", nil)
+
+	var record enr.Record
+	record.SetSeq(node2.Seq() + 1)
+	record.Set(enr.IP(net.ParseIP("10.0.2.2")))
+	record.Set(enr.TCP(30303))
+	require.Nil(t, enode.SignV4(&record, key2))
+	node2Updated, err := enode.New(enode.ValidSchemes, &record)
+	require.Nil(t, err)
+
+	const link1 = "enrtree://AM5FCQLWIZX2QFPNJAP7VUERCCRNGRHWZG3YYHIUV7BVDQ5FDPRT2@snap.example.org"
+	const link2 = "enrtree://AM5FCQLWIZX2QFPNJAP7VUERCCRNGRHWZG3YYHIUV7BVDQ5FDPRT2@les.example.org"
+
+	previous := TreeDefinition{
+		Meta:  TreeMeta{Seq: 1, Links: []string{link1}},
+		Nodes: []string{node1.String(), node2.String()},
+	}
+	current := TreeDefinition{
+		Meta:  TreeMeta{Seq: 2, Links: []string{link2}},
+		Nodes: []string{node2Updated.String(), node3.String()},
+	}
+
+	diff, err := CreateTreeDiff(&previous, &current)
+	require.Nil(t, err)
+	assert.Equal(t, []string{node3.String()}, diff.AddedNodes)
+	assert.Equal(t, []string{node1.String()}, diff.RemovedNodes)
+	assert.Equal(t, []string{node2Updated.String()}, diff.UpdatedNodes)
+	assert.Equal(t, []string{link2}, diff.AddedLinks)
+	assert.Equal(t, []string{link1}, diff.RemovedLinks)
+	assert.False(t, diff.IsEmpty())
+
+	diff, err = CreateTreeDiff(&current, &current)
+	require.Nil(t, err)
+	assert.True(t, diff.IsEmpty())
+}
@@ -4,13 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon/cmd/observer/api"
 	"github.com/ledgerwatch/erigon/cmd/observer/database"
+	"github.com/ledgerwatch/erigon/cmd/observer/dns_tree"
 	"github.com/ledgerwatch/erigon/cmd/observer/observer"
 	"github.com/ledgerwatch/erigon/cmd/observer/reports"
 	"github.com/ledgerwatch/erigon/cmd/utils"
+	"github.com/ledgerwatch/erigon/crypto"
 	"github.com/ledgerwatch/erigon/params"
 	"github.com/ledgerwatch/log/v3"
 )
@@ -80,6 +86,15 @@ func reportWithFlags(ctx context.Context, flags reports.CommandFlags) error {
 		return nil
 	}
 
+	if flags.ForkReadiness {
+		report, err := reports.CreateForkReadinessReport(ctx, db, flags.ForkNext, flags.ClientsLimit, flags.MaxPingTries, networkID)
+		if err != nil {
+			return err
+		}
+		fmt.Println(report)
+		return nil
+	}
+
 	if flags.SentryCandidates {
 		report, err := reports.CreateSentryCandidatesReport(ctx, db, flags.ErigonLogPath)
 		if err != nil {
@@ -103,6 +118,103 @@ func reportWithFlags(ctx context.Context, flags reports.CommandFlags) error {
 	return nil
 }
 
+func dnsTreeWithFlags(ctx context.Context, flags dns_tree.CommandFlags) error {
+	if (flags.Format != dns_tree.FormatZone) && (flags.Format != dns_tree.FormatJSON) {
+		return fmt.Errorf("unknown output format %q", flags.Format)
+	}
+	forkHash, err := dns_tree.ParseForkHash(flags.ForkID)
+	if err != nil {
+		return err
+	}
+	key, err := crypto.LoadECDSA(flags.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the signing key: %w", err)
+	}
+
+	var previous *dns_tree.TreeDefinition
+	if flags.PreviousPath != "" {
+		previous, err = dns_tree.LoadTreeDefinition(flags.PreviousPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	db, err := database.NewDBSQLite(filepath.Join(flags.DataDir, "observer.sqlite"))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	networkID := uint(params.NetworkIDByChainName(flags.Chain))
+	nodes, err := dns_tree.CollectNodes(ctx, db, flags.MaxPingTries, networkID, forkHash)
+	if err != nil {
+		return err
+	}
+
+	seq := flags.Seq
+	if seq == 0 {
+		seq = dns_tree.NextSeq(previous, time.Now())
+	}
+	tree, definition, err := dns_tree.BuildTree(nodes, flags.Links, seq, key, flags.Domain)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	var reportOut io.Writer = os.Stdout
+	if flags.OutputPath != "" {
+		file, err := os.Create(flags.OutputPath)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+		out = file
+	} else {
+		reportOut = os.Stderr
+	}
+
+	if flags.Format == dns_tree.FormatJSON {
+		err = dns_tree.WriteJSON(out, definition)
+	} else {
+		err = dns_tree.WriteZone(out, tree, flags.Domain)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(reportOut, "%s (%d nodes)\n", definition.Meta.URL, len(definition.Nodes))
+	if previous != nil {
+		diff, err := dns_tree.CreateTreeDiff(previous, definition)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprint(reportOut, diff)
+	}
+	return nil
+}
+
+func serveWithFlags(ctx context.Context, flags api.CommandFlags) error {
+	db, err := database.NewDBSQLite(filepath.Join(flags.DataDir, "observer.sqlite"))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	networkID := uint(params.NetworkIDByChainName(flags.Chain))
+
+	clientsMetrics := api.NewClientsMetrics()
+	go api.ClientsMetricsLoop(ctx, db, clientsMetrics, flags.MaxPingTries, networkID, flags.MetricsPeriod, log.Root())
+
+	serverConfig := api.ServerConfig{
+		NetworkID:     networkID,
+		ClientsLimit:  flags.ClientsLimit,
+		MaxPingTries:  flags.MaxPingTries,
+		ErigonLogPath: flags.ErigonLogPath,
+	}
+	server := api.NewServer(db, serverConfig, clientsMetrics, log.Root())
+	return server.Run(ctx, flags.ListenAddr)
+}
+
 func main() {
 	ctx, cancel := common.RootContext()
 	defer cancel()
@@ -113,6 +225,14 @@ func main() {
 	reportCommand.OnRun(reportWithFlags)
 	command.AddSubCommand(reportCommand.RawCommand())
 
+	dnsTreeCommand := dns_tree.NewCommand()
+	dnsTreeCommand.OnRun(dnsTreeWithFlags)
+	command.AddSubCommand(dnsTreeCommand.RawCommand())
+
+	serveCommand := api.NewCommand()
+	serveCommand.OnRun(serveWithFlags)
+	command.AddSubCommand(serveCommand.RawCommand())
+
 	err := command.ExecuteContext(ctx, mainWithFlags)
 	if (err != nil) && !errors.Is(err, context.Canceled) {
 		utils.Fatalf("%v", err)
@@ -131,3 +131,13 @@ func NameFromClientID(clientID string) string {
 	parts := strings.SplitN(clientID, "/", 2)
 	return parts[0]
 }
+
+// VersionFromClientID returns the release version without a build suffix,
+// e.g. "v2.33.0" for "erigon/v2.33.0-stable-1e2d2f2c/linux-amd64/go1.19.2".
+func VersionFromClientID(clientID string) string {
+	parts := strings.SplitN(clientID, "/", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+	return strings.SplitN(parts[1], "-", 2)[0]
+}
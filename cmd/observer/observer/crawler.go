@@ -3,6 +3,7 @@ package observer
 import (
 	"context"
 	"crypto/ecdsa"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"sync/atomic"
@@ -461,6 +462,13 @@ func (crawler *Crawler) saveInterrogationResult(
 		}
 	}
 
+	if (result != nil) && (result.ENR != nil) {
+		dbErr := crawler.db.UpsertNodeRecord(ctx, id, result.ENR.String())
+		if dbErr != nil {
+			return dbErr
+		}
+	}
+
 	if clientID != nil {
 		dbErr := crawler.db.UpdateClientID(ctx, id, *clientID)
 		if dbErr != nil {
@@ -487,6 +495,14 @@ func (crawler *Crawler) saveInterrogationResult(
 		}
 	}
 
+	if (result != nil) && (result.HandshakeResult != nil) && (result.HandshakeResult.ForkID != nil) {
+		forkID := result.HandshakeResult.ForkID
+		dbErr := crawler.db.UpdateForkID(ctx, id, hex.EncodeToString(forkID.Hash[:]), forkID.Next)
+		if dbErr != nil {
+			return dbErr
+		}
+	}
+
 	if (result != nil) && (result.HandshakeResult != nil) && (result.HandshakeResult.HandshakeErr != nil) {
 		dbErr := crawler.db.InsertHandshakeError(ctx, id, result.HandshakeResult.HandshakeErr.StringCode())
 		if dbErr != nil {
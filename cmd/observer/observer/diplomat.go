@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ledgerwatch/erigon/cmd/observer/database"
+	"github.com/ledgerwatch/erigon/core/forkid"
 	"github.com/ledgerwatch/erigon/p2p"
 	"github.com/ledgerwatch/erigon/p2p/enode"
 	"github.com/ledgerwatch/log/v3"
@@ -28,6 +29,7 @@ type DiplomatResult struct {
 	ClientID        *string
 	NetworkID       *uint64
 	EthVersion      *uint32
+	ForkID          *forkid.ID
 	HandshakeErr    *HandshakeError
 	HasTransientErr bool
 }
@@ -83,6 +85,10 @@ func (diplomat *Diplomat) Run(ctx context.Context) DiplomatResult {
 		result.EthVersion = &status.ProtocolVersion
 		diplomat.log.Debug("Got eth version", "ethVersion", *result.EthVersion)
 	}
+	if (status != nil) && (status.ForkID != nil) {
+		result.ForkID = status.ForkID
+		diplomat.log.Debug("Got fork ID", "forkID", *result.ForkID)
+	}
 
 	return result
 }
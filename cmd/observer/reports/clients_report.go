@@ -10,12 +10,12 @@ import (
 )
 
 type ClientsReportEntry struct {
-	Name  string
-	Count uint
+	Name  string `json:"name"`
+	Count uint   `json:"count"`
 }
 
 type ClientsReport struct {
-	Clients []ClientsReportEntry
+	Clients []ClientsReportEntry `json:"clients"`
 }
 
 func CreateClientsReport(ctx context.Context, db database.DB, limit uint, maxPingTries uint, networkID uint) (*ClientsReport, error) {
@@ -16,6 +16,9 @@ type CommandFlags struct {
 	MaxPingTries uint
 	Estimate     bool
 
+	ForkReadiness bool
+	ForkNext      uint64
+
 	SentryCandidates bool
 	ErigonLogPath    string
 }
@@ -39,6 +42,8 @@ func NewCommand() *Command {
 	instance.withClientsLimit()
 	instance.withMaxPingTries()
 	instance.withEstimate()
+	instance.withForkReadiness()
+	instance.withForkNext()
 	instance.withSentryCandidates()
 	instance.withErigonLogPath()
 
@@ -82,6 +87,22 @@ func (command *Command) withEstimate() {
 	command.command.Flags().BoolVar(&command.flags.Estimate, flag.Name, false, flag.Usage)
 }
 
+func (command *Command) withForkReadiness() {
+	flag := cli.BoolFlag{
+		Name:  "fork-readiness",
+		Usage: "Show which share of nodes per client and version advertise the upcoming fork",
+	}
+	command.command.Flags().BoolVar(&command.flags.ForkReadiness, flag.Name, false, flag.Usage)
+}
+
+func (command *Command) withForkNext() {
+	flag := cli.Uint64Flag{
+		Name:  "fork-next",
+		Usage: "Block number or timestamp of the upcoming fork for 'fork-readiness' (default: the most advertised one)",
+	}
+	command.command.Flags().Uint64Var(&command.flags.ForkNext, flag.Name, flag.Value, flag.Usage)
+}
+
 func (command *Command) withSentryCandidates() {
 	flag := cli.BoolFlag{
 		Name:  "sentry-candidates",
@@ -0,0 +1,192 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ledgerwatch/erigon/cmd/observer/database"
+	"github.com/ledgerwatch/erigon/cmd/observer/observer"
+)
+
+type ForkReadinessReportEntry struct {
+	Name string `json:"name"`
+	// Count is the number of nodes with a known fork ID.
+	Count uint `json:"count"`
+	// ReadyCount is the number of nodes advertising the fork in the fork ID Next field.
+	ReadyCount uint `json:"ready"`
+	// ReadyLow and ReadyHigh are the bounds of the 95% confidence interval of the ready share.
+	ReadyLow  float64 `json:"readyLow"`
+	ReadyHigh float64 `json:"readyHigh"`
+
+	Versions []ForkReadinessReportEntry `json:"versions,omitempty"`
+}
+
+type ForkReadinessReport struct {
+	ForkNext     uint64                     `json:"forkNext"`
+	Total        ForkReadinessReportEntry   `json:"total"`
+	UnknownCount uint                       `json:"unknown"`
+	Clients      []ForkReadinessReportEntry `json:"clients"`
+}
+
+type forkReadinessCounter struct {
+	count      uint
+	readyCount uint
+}
+
+// CreateForkReadinessReport summarises which share of the nodes (per client and version)
+// advertise the upcoming fork at forkNext (a block number or a timestamp).
+// If forkNext is 0, the most advertised upcoming fork is used.
+// Nodes which haven't sent a fork ID in the eth Status message are counted as unknown.
+func CreateForkReadinessReport(
+	ctx context.Context,
+	db database.DB,
+	forkNext uint64,
+	limit uint,
+	maxPingTries uint,
+	networkID uint,
+) (*ForkReadinessReport, error) {
+	type node struct {
+		name    string
+		version string
+		next    uint64
+	}
+	var nodes []node
+	unknownCount := uint(0)
+	nextCounts := make(map[uint64]uint)
+
+	enumFunc := func(clientID *string, forkHash *string, next *uint64) {
+		if (clientID == nil) || observer.IsClientIDBlacklisted(*clientID) {
+			return
+		}
+		if (forkHash == nil) || (next == nil) {
+			unknownCount++
+			return
+		}
+		nodes = append(nodes, node{
+			observer.NameFromClientID(*clientID),
+			observer.VersionFromClientID(*clientID),
+			*next,
+		})
+		if *next != 0 {
+			nextCounts[*next]++
+		}
+	}
+	if err := db.EnumerateForkIDs(ctx, maxPingTries, networkID, enumFunc); err != nil {
+		return nil, err
+	}
+
+	if forkNext == 0 {
+		for next, count := range nextCounts {
+			if (count > nextCounts[forkNext]) || ((count == nextCounts[forkNext]) && (next < forkNext)) {
+				forkNext = next
+			}
+		}
+	}
+
+	var total forkReadinessCounter
+	clients := make(map[string]*forkReadinessCounter)
+	versions := make(map[string]map[string]*forkReadinessCounter)
+	for _, n := range nodes {
+		if _, ok := clients[n.name]; !ok {
+			clients[n.name] = new(forkReadinessCounter)
+			versions[n.name] = make(map[string]*forkReadinessCounter)
+		}
+		if _, ok := versions[n.name][n.version]; !ok {
+			versions[n.name][n.version] = new(forkReadinessCounter)
+		}
+
+		isReady := (forkNext != 0) && (n.next == forkNext)
+		for _, counter := range []*forkReadinessCounter{&total, clients[n.name], versions[n.name][n.version]} {
+			counter.count++
+			if isReady {
+				counter.readyCount++
+			}
+		}
+	}
+
+	report := ForkReadinessReport{
+		ForkNext:     forkNext,
+		Total:        makeForkReadinessReportEntry("total", total),
+		UnknownCount: unknownCount,
+	}
+
+	for _, name := range sortForkReadinessCounters(clients) {
+		if uint(len(report.Clients)) >= limit {
+			break
+		}
+		entry := makeForkReadinessReportEntry(name, *clients[name])
+		for _, version := range sortForkReadinessCounters(versions[name]) {
+			entry.Versions = append(entry.Versions, makeForkReadinessReportEntry(version, *versions[name][version]))
+		}
+		report.Clients = append(report.Clients, entry)
+	}
+
+	return &report, nil
+}
+
+func makeForkReadinessReportEntry(name string, counter forkReadinessCounter) ForkReadinessReportEntry {
+	entry := ForkReadinessReportEntry{
+		Name:       name,
+		Count:      counter.count,
+		ReadyCount: counter.readyCount,
+	}
+	if counter.count > 0 {
+		// 1 - (1 - p)/2 percentile for 95% confidence
+		const z = 1.96
+		low, high := waldInterval(counter.count, counter.readyCount, z)
+		entry.ReadyLow = math.Max(low, 0)
+		entry.ReadyHigh = math.Min(high, 1)
+	}
+	return entry
+}
+
+// sortForkReadinessCounters returns the keys ordered by the count descending.
+func sortForkReadinessCounters(counters map[string]*forkReadinessCounter) []string {
+	keys := make([]string, 0, len(counters))
+	for key := range counters {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ci, cj := counters[keys[i]].count, counters[keys[j]].count
+		if ci != cj {
+			return ci > cj
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func (entry *ForkReadinessReportEntry) String() string {
+	return fmt.Sprintf("%6d / %-6d %5.1f%% - %5.1f%%",
+		entry.ReadyCount,
+		entry.Count,
+		entry.ReadyLow*100,
+		entry.ReadyHigh*100)
+}
+
+func (report *ForkReadinessReport) String() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("fork next: %d", report.ForkNext))
+	builder.WriteRune('\n')
+	builder.WriteString(fmt.Sprintf("%s total", report.Total.String()))
+	builder.WriteRune('\n')
+	builder.WriteString(fmt.Sprintf("%6d unknown fork ID", report.UnknownCount))
+	builder.WriteRune('\n')
+	builder.WriteString("clients:")
+	builder.WriteRune('\n')
+	for _, client := range report.Clients {
+		builder.WriteString(fmt.Sprintf("%s %s", client.String(), client.Name))
+		builder.WriteRune('\n')
+		for _, version := range client.Versions {
+			builder.WriteString(fmt.Sprintf("    %s %s", version.String(), version.Name))
+			if version.ReadyCount == 0 {
+				builder.WriteString(" (outdated)")
+			}
+			builder.WriteRune('\n')
+		}
+	}
+	return builder.String()
+}
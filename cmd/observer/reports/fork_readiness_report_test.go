@@ -0,0 +1,68 @@
+package reports
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/ledgerwatch/erigon/cmd/observer/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForkReadinessReport(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.NewDBSQLite(filepath.Join(t.TempDir(), "observer.sqlite"))
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	const forkNext = 1681338455
+	nodes := []struct {
+		clientID string
+		forkNext *uint64
+	}{
+		{"erigon/v2.42.0-stable-beefcafe/linux-amd64/go1.19.2", new(uint64)},
+		{"erigon/v2.42.0-stable-beefcafe/linux-amd64/go1.19.2", new(uint64)},
+		{"erigon/v2.39.0-stable-deadbeef/linux-amd64/go1.19.2", new(uint64)},
+		{"Geth/v1.11.5-stable-a38f4108/linux-amd64/go1.20.2", new(uint64)},
+		{"Geth/v1.11.5-stable-a38f4108/linux-amd64/go1.20.2", nil},
+	}
+	*nodes[0].forkNext = forkNext
+	*nodes[1].forkNext = forkNext
+	*nodes[3].forkNext = forkNext
+
+	for i, node := range nodes {
+		id := database.NodeID(string(rune('a' + i)))
+		require.Nil(t, db.UpsertNodeAddr(ctx, id, database.NodeAddr{}))
+		require.Nil(t, db.UpdateClientID(ctx, id, node.clientID))
+		require.Nil(t, db.UpdateNetworkID(ctx, id, 1))
+		if node.forkNext != nil {
+			require.Nil(t, db.UpdateForkID(ctx, id, "f0afd0e3", *node.forkNext))
+		}
+	}
+
+	report, err := CreateForkReadinessReport(ctx, db, 0, 10, 3, 1)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(forkNext), report.ForkNext)
+	assert.Equal(t, uint(1), report.UnknownCount)
+	assert.Equal(t, uint(4), report.Total.Count)
+	assert.Equal(t, uint(3), report.Total.ReadyCount)
+	assert.Less(t, report.Total.ReadyLow, 0.75)
+	assert.Greater(t, report.Total.ReadyHigh, 0.75)
+
+	require.Equal(t, 2, len(report.Clients))
+	erigon := report.Clients[0]
+	assert.Equal(t, "erigon", erigon.Name)
+	assert.Equal(t, uint(3), erigon.Count)
+	assert.Equal(t, uint(2), erigon.ReadyCount)
+	require.Equal(t, 2, len(erigon.Versions))
+	assert.Equal(t, "v2.42.0", erigon.Versions[0].Name)
+	assert.Equal(t, uint(2), erigon.Versions[0].ReadyCount)
+	assert.Equal(t, "v2.39.0", erigon.Versions[1].Name)
+	assert.Equal(t, uint(0), erigon.Versions[1].ReadyCount)
+	assert.Contains(t, report.String(), "v2.39.0 (outdated)")
+
+	report, err = CreateForkReadinessReport(ctx, db, 1, 10, 3, 1)
+	require.Nil(t, err)
+	assert.Equal(t, uint(0), report.Total.ReadyCount)
+}
@@ -12,11 +12,11 @@ import (
 )
 
 type SentryCandidatesReport struct {
-	TotalCount       uint
-	SeenCount        uint
-	HandshakeCount   uint
-	UnknownClientIDs []string
-	UnseenClientIDs  []string
+	TotalCount       uint     `json:"total"`
+	SeenCount        uint     `json:"seen"`
+	HandshakeCount   uint     `json:"handshake"`
+	UnknownClientIDs []string `json:"unknownClientIDs"`
+	UnseenClientIDs  []string `json:"unseenClientIDs"`
 }
 
 func CreateSentryCandidatesReport(
@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/ledgerwatch/erigon/eth/stateaudit"
+)
+
+// StateAudit implements erigon_stateAudit. Returns nil if erigon runs without --state.audit.interval or the first audit
+// is not finished yet.
+func (api *ErigonImpl) StateAudit(ctx context.Context) (*stateaudit.Result, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	return stateaudit.ReadResult(tx)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -29,12 +30,25 @@ import (
 	"github.com/ledgerwatch/erigon/params"
 	"github.com/ledgerwatch/erigon/rpc"
 	"github.com/ledgerwatch/erigon/turbo/rpchelper"
+	"github.com/ledgerwatch/erigon/turbo/services"
 	"github.com/ledgerwatch/erigon/turbo/transactions"
 )
 
 func (api *BaseAPI) getReceipts(ctx context.Context, tx kv.Tx, chainConfig *params.ChainConfig, block *types.Block, senders []common.Address) (types.Receipts, error) {
-	if cached := rawdb.ReadReceipts(tx, block, senders); cached != nil {
-		return cached, nil
+	// the receipts of the db, or of the snapshots if they are pruned from the db, they are computed again if unavailable
+	cached, err := api._blockReader.RawReceipts(ctx, tx, block.NumberU64())
+	if err != nil && !errors.Is(err, services.ErrReceiptsUnavailable) {
+		return nil, err
+	}
+	if cached != nil {
+		if len(senders) > 0 {
+			block.SendersToTxs(senders)
+		}
+		err := cached.DeriveFields(block.Hash(), block.NumberU64(), block.Transactions(), senders)
+		if err == nil {
+			return cached, nil
+		}
+		log.Error("Failed to derive block receipts fields", "hash", block.Hash(), "number", block.NumberU64(), "err", err)
 	}
 	engine := api.engine()
 
@@ -134,6 +148,27 @@ func (api *APIImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria) (t
 		return api.getLogsV3(ctx, tx.(kv.TemporalTx), begin, end, crit)
 	}
 
+	// the logs index has no blocks with the receipts pruned from the db, their logs are read from the snapshots
+	receiptsFrom, err := rawdb.ReceiptsAvailableFrom(tx)
+	if err != nil {
+		return nil, err
+	}
+	if begin < receiptsFrom {
+		prunedEnd := end
+		if prunedEnd >= receiptsFrom {
+			prunedEnd = receiptsFrom - 1
+		}
+		prunedLogs, err := api.getLogsFromReceipts(ctx, tx, begin, prunedEnd, crit)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, prunedLogs...)
+		if end < receiptsFrom {
+			return logs, nil
+		}
+		begin = receiptsFrom
+	}
+
 	blockNumbers := bitmapdb.NewBitmap()
 	defer bitmapdb.ReturnToPool(blockNumbers)
 	blockNumbers.AddRange(begin, end+1) // [min,max)
@@ -235,6 +270,56 @@ func (api *APIImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria) (t
 	return logs, nil
 }
 
+// getLogsFromReceipts - the logs of the blocks [begin, end], read from the receipts of each block instead of the logs index
+func (api *APIImpl) getLogsFromReceipts(ctx context.Context, tx kv.Tx, begin, end uint64, crit filters.FilterCriteria) ([]*types.Log, error) {
+	addrMap := make(map[common.Address]struct{}, len(crit.Addresses))
+	for _, v := range crit.Addresses {
+		addrMap[v] = struct{}{}
+	}
+	var logs []*types.Log
+	for blockNumber := begin; blockNumber <= end; blockNumber++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		receipts, err := api._blockReader.RawReceipts(ctx, tx, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		var logIndex uint
+		var blockLogs []*types.Log
+		for txIndex, receipt := range receipts {
+			for _, log := range receipt.Logs {
+				log.Index = logIndex
+				log.TxIndex = uint(txIndex)
+				logIndex++
+			}
+			blockLogs = append(blockLogs, receipt.Logs.Filter(addrMap, crit.Topics)...)
+		}
+		if len(blockLogs) == 0 {
+			continue
+		}
+
+		blockHash, err := api._blockReader.CanonicalHash(ctx, tx, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		body, err := api._blockReader.BodyWithTransactions(ctx, tx, blockHash, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if body == nil {
+			return nil, fmt.Errorf("block not found %d", blockNumber)
+		}
+		for _, log := range blockLogs {
+			log.BlockNumber = blockNumber
+			log.BlockHash = blockHash
+			log.TxHash = body.Transactions[log.TxIndex].Hash()
+		}
+		logs = append(logs, blockLogs...)
+	}
+	return logs, nil
+}
+
 // The Topic list restricts matches to particular event topics. Each event has a list
 // of topics. Topics matches a prefix of that list. An empty element slice matches any
 // topic. Non-empty elements represent an alternative that matches any of the
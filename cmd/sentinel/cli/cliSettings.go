@@ -7,6 +7,7 @@ import (
 
 	"github.com/ledgerwatch/erigon/cl/clparams"
 	"github.com/ledgerwatch/erigon/cmd/sentinel/cli/flags"
+	"github.com/ledgerwatch/erigon/common"
 )
 
 type ConsensusClientCliCfg struct {
@@ -22,6 +23,10 @@ type ConsensusClientCliCfg struct {
 	NoDiscovery    bool                        `json:"noDiscovery"`
 	CheckpointUri  string                      `json:"checkpointUri"`
 	Chaindata      string                      `json:"chaindata"`
+	// Local checkpoint, used instead of CheckpointUri when set.
+	CheckpointState       string       `json:"checkpointState"`
+	CheckpointBlock       string       `json:"checkpointBlock"`
+	CheckpointTrustedRoot *common.Hash `json:"checkpointTrustedRoot"`
 }
 
 func SetupConsensusClientCfg(ctx *cli.Context) (*ConsensusClientCliCfg, error) {
@@ -43,5 +48,17 @@ func SetupConsensusClientCfg(ctx *cli.Context) (*ConsensusClientCliCfg, error) {
 	cfg.NoDiscovery = ctx.Bool(flags.NoDiscovery.Name)
 	cfg.CheckpointUri = clparams.GetCheckpointSyncEndpoint(network)
 	cfg.Chaindata = ctx.String(flags.ChaindataFlag.Name)
+	cfg.CheckpointState = ctx.String(flags.CheckpointStateFlag.Name)
+	cfg.CheckpointBlock = ctx.String(flags.CheckpointBlockFlag.Name)
+	if (cfg.CheckpointState == "") != (cfg.CheckpointBlock == "") {
+		return nil, fmt.Errorf("--%s and --%s must be provided together", flags.CheckpointStateFlag.Name, flags.CheckpointBlockFlag.Name)
+	}
+	if trustedRoot := ctx.String(flags.CheckpointTrustedRootFlag.Name); trustedRoot != "" {
+		root := common.HexToHash(trustedRoot)
+		if len(common.FromHex(trustedRoot)) != common.HashLength {
+			return nil, fmt.Errorf("invalid --%s %s", flags.CheckpointTrustedRootFlag.Name, trustedRoot)
+		}
+		cfg.CheckpointTrustedRoot = &root
+	}
 	return cfg, nil
 }
@@ -12,4 +12,7 @@ var CLDefaultFlags = []cli.Flag{
 	&SentinelTcpPort,
 	&NoDiscovery,
 	&ChaindataFlag,
+	&CheckpointStateFlag,
+	&CheckpointBlockFlag,
+	&CheckpointTrustedRootFlag,
 }
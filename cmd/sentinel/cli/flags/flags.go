@@ -48,4 +48,19 @@ var (
 		Usage: "chaindata of database",
 		Value: "",
 	}
+	CheckpointStateFlag = cli.StringFlag{
+		Name:  "checkpoint.state",
+		Usage: "path to a finalized beacon state SSZ file to start from instead of fetching it over the network",
+		Value: "",
+	}
+	CheckpointBlockFlag = cli.StringFlag{
+		Name:  "checkpoint.block",
+		Usage: "path to the SSZ file of the block of the checkpoint state, the state must be its post state at the block slot",
+		Value: "",
+	}
+	CheckpointTrustedRootFlag = cli.StringFlag{
+		Name:  "checkpoint.trusted-root",
+		Usage: "optional trusted block root the local checkpoint must match",
+		Value: "",
+	}
 )
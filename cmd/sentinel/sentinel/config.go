@@ -19,6 +19,7 @@ import (
 	"net"
 
 	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cmd/sentinel/sentinel/validation"
 	"github.com/ledgerwatch/log/v3"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/crypto"
@@ -43,6 +44,8 @@ type SentinelConfig struct {
 	HostAddress   string
 	HostDNS       string
 	NoDiscovery   bool
+	// ChainView enables the chain dependent gossip validation checks.
+	ChainView validation.ChainView
 }
 
 func convertToCryptoPrivkey(privkey *ecdsa.PrivateKey) (crypto.PrivKey, error) {
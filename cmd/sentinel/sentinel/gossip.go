@@ -58,6 +58,14 @@ func (sub *GossipSubscription) Listen() (err error) {
 	return nil
 }
 
+// Publish broadcasts an already encoded message to the topic.
+func (s *GossipSubscription) Publish(data []byte) error {
+	if s.topic == nil {
+		return fmt.Errorf("topic %s is closed", s.gossip_topic.Name)
+	}
+	return s.topic.Publish(s.ctx, data)
+}
+
 // calls the cancel func for the subscriber and closes the topic and sub
 func (s *GossipSubscription) Close() {
 	if s.cf != nil {
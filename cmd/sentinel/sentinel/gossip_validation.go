@@ -0,0 +1,68 @@
+/*
+   Copyright 2022 Erigon-Lightclient contributors
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+       http://www.apache.org/licenses/LICENSE-2.0
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package sentinel
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"github.com/ledgerwatch/erigon/cmd/sentinel/sentinel/validation"
+	"github.com/ledgerwatch/log/v3"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// topicValidator returns the libp2p validator of a gossip topic, or nil if the topic is not validated.
+func (s *Sentinel) topicValidator(topic GossipTopic) pubsub.ValidatorEx {
+	switch topic.Name {
+	case BeaconBlockTopic:
+		return s.wrapValidator(topic, func(msg cltypes.ObjectSSZ) (pubsub.ValidationResult, error) {
+			return s.gossipValidator.ValidateBeaconBlock(msg.(*cltypes.SignedBeaconBlockBellatrix))
+		})
+	case BeaconAggregateAndProofTopic:
+		return s.wrapValidator(topic, func(msg cltypes.ObjectSSZ) (pubsub.ValidationResult, error) {
+			return s.gossipValidator.ValidateAggregateAndProof(msg.(*cltypes.SignedAggregateAndProof))
+		})
+	}
+	return nil
+}
+
+// wrapValidator decodes gossip messages before validating them and penalizes the peers that forwarded invalid ones.
+func (s *Sentinel) wrapValidator(topic GossipTopic, validate func(cltypes.ObjectSSZ) (pubsub.ValidationResult, error)) pubsub.ValidatorEx {
+	return func(ctx context.Context, pid peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+		// Our own messages were produced by erigon-cl.
+		if pid == s.host.ID() {
+			return pubsub.ValidationAccept
+		}
+		val := topic.Typ.Clone().(cltypes.ObjectSSZ)
+		result, err := pubsub.ValidationReject, validation.ErrMalformedMessage
+		if utils.DecodeSSZSnappy(val, msg.Data) == nil {
+			result, err = validate(val)
+		}
+		switch result {
+		case pubsub.ValidationReject:
+			log.Trace("[Sentinel Gossip] Rejected message", "topic", topic.Name, "peer", pid, "err", err)
+			if errors.Is(err, validation.ErrInvalidSignature) || errors.Is(err, validation.ErrMalformedMessage) {
+				s.peers.BanBadPeer(pid)
+			} else {
+				s.peers.Penalize(pid)
+			}
+		case pubsub.ValidationIgnore:
+			log.Trace("[Sentinel Gossip] Ignored message", "topic", topic.Name, "peer", pid, "err", err)
+		}
+		return result
+	}
+}
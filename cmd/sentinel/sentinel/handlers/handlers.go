@@ -64,6 +64,8 @@ func NewConsensusHandlers(ctx context.Context, db kv.RoDB, host host.Host,
 		protocol.ID(communication.BeaconBlocksByRootProtocolV1):  c.beaconBlocksByRootHandler,
 		protocol.ID(communication.LightClientFinalityUpdateV1):   c.lightClientFinalityUpdateHandler,
 		protocol.ID(communication.LightClientOptimisticUpdateV1): c.lightClientOptimisticUpdateHandler,
+		protocol.ID(communication.LightClientBootstrapV1):        c.lightClientBootstrapHandler,
+		protocol.ID(communication.LightClientUpdatesByRangeV1):   c.lightClientUpdatesByRangeHandler,
 	}
 	return c
 }
@@ -14,28 +14,33 @@
 package handlers
 
 import (
+	"github.com/ledgerwatch/erigon/cl/cltypes"
 	"github.com/ledgerwatch/erigon/cl/fork"
 	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/rawdb"
+	"github.com/ledgerwatch/erigon/cmd/sentinel/sentinel/communication"
 	"github.com/ledgerwatch/erigon/cmd/sentinel/sentinel/communication/ssz_snappy"
-	"github.com/ledgerwatch/log/v3"
 	"github.com/libp2p/go-libp2p/core/network"
 )
 
+// lightClientResponsePrefix is the successful response code followed by the fork digest context bytes.
+func (c *ConsensusHandlers) lightClientResponsePrefix() ([]byte, error) {
+	forkDigest, err := fork.ComputeForkDigest(c.beaconConfig, c.genesisConfig)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{SuccessfulResponsePrefix}, forkDigest[:]...), nil
+}
+
 func (c *ConsensusHandlers) lightClientFinalityUpdateHandler(stream network.Stream) {
 	if c.db == nil {
 		stream.Write([]byte{ResourceUnavaiablePrefix})
 		return
 	}
-
-	forkDigest, err := fork.ComputeForkDigest(c.beaconConfig, c.genesisConfig)
+	prefix, err := c.lightClientResponsePrefix()
 	if err != nil {
 		stream.Close()
 		return
 	}
-	if _, err := stream.Write(forkDigest[:]); err != nil {
-		stream.Close()
-		return
-	}
 	// Read latest lightclient update
 	tx, err := c.db.BeginRo(c.ctx)
 	if err != nil {
@@ -48,8 +53,12 @@ func (c *ConsensusHandlers) lightClientFinalityUpdateHandler(stream network.Stre
 		stream.Close()
 		return
 	}
+	if update == nil {
+		stream.Write([]byte{ResourceUnavaiablePrefix})
+		return
+	}
 
-	ssz_snappy.EncodeAndWrite(stream, update, SuccessfulResponsePrefix)
+	ssz_snappy.EncodeAndWrite(stream, update, prefix...)
 }
 
 func (c *ConsensusHandlers) lightClientOptimisticUpdateHandler(stream network.Stream) {
@@ -57,36 +66,109 @@ func (c *ConsensusHandlers) lightClientOptimisticUpdateHandler(stream network.St
 		stream.Write([]byte{ResourceUnavaiablePrefix})
 		return
 	}
-	forkDigest, err := fork.ComputeForkDigest(c.beaconConfig, c.genesisConfig)
+	prefix, err := c.lightClientResponsePrefix()
+	if err != nil {
+		stream.Close()
+		return
+	}
+	// Read latest lightclient update
+	tx, err := c.db.BeginRo(c.ctx)
 	if err != nil {
 		stream.Close()
 		return
 	}
-	if _, err := stream.Write(forkDigest[:]); err != nil {
+	defer tx.Rollback()
+	update, err := rawdb.ReadLightClientOptimisticUpdate(tx)
+	if err != nil {
+		stream.Close()
+		return
+	}
+	if update == nil {
+		stream.Write([]byte{ResourceUnavaiablePrefix})
+		return
+	}
+
+	ssz_snappy.EncodeAndWrite(stream, update, prefix...)
+}
+
+func (c *ConsensusHandlers) lightClientBootstrapHandler(stream network.Stream) {
+	if c.db == nil {
+		stream.Write([]byte{ResourceUnavaiablePrefix})
+		return
+	}
+	req := &cltypes.SingleRoot{}
+	if err := ssz_snappy.DecodeAndReadNoForkDigest(stream, req); err != nil {
+		stream.Close()
+		return
+	}
+	prefix, err := c.lightClientResponsePrefix()
+	if err != nil {
 		stream.Close()
 		return
 	}
-	// Read latest lightclient update
 	tx, err := c.db.BeginRo(c.ctx)
 	if err != nil {
 		stream.Close()
 		return
 	}
 	defer tx.Rollback()
-	update, err := rawdb.ReadLightClientOptimisticUpdate(tx)
+	bootstrap, err := rawdb.ReadLightClientBootstrap(tx, req.Root)
 	if err != nil {
 		stream.Close()
 		return
 	}
+	if bootstrap == nil {
+		stream.Write([]byte{ResourceUnavaiablePrefix})
+		return
+	}
 
-	ssz_snappy.EncodeAndWrite(stream, update, SuccessfulResponsePrefix)
+	ssz_snappy.EncodeAndWrite(stream, bootstrap, prefix...)
 }
 
-func (c *ConsensusHandlers) lightClientUpdatesByRange(stream network.Stream) {
+func (c *ConsensusHandlers) lightClientUpdatesByRangeHandler(stream network.Stream) {
 	if c.db == nil {
 		stream.Write([]byte{ResourceUnavaiablePrefix})
 		return
 	}
-	log.Info("Got lightClientUpdatesByRange handler call")
-	// TODO: ADD Proper logic
+	req := &cltypes.LightClientUpdatesByRangeRequest{}
+	if err := ssz_snappy.DecodeAndReadNoForkDigest(stream, req); err != nil {
+		stream.Close()
+		return
+	}
+	prefix, err := c.lightClientResponsePrefix()
+	if err != nil {
+		stream.Close()
+		return
+	}
+	tx, err := c.db.BeginRo(c.ctx)
+	if err != nil {
+		stream.Close()
+		return
+	}
+	defer tx.Rollback()
+
+	count := req.Count
+	if count > communication.MaximumRequestClientUpdates {
+		count = communication.MaximumRequestClientUpdates
+	}
+	// Respond with one chunk per period, stopping at the first period we have no update for.
+	written := 0
+	for period := req.Period; period < req.Period+count; period++ {
+		update, err := rawdb.ReadLightClientUpdate(tx, uint32(period))
+		if err != nil {
+			stream.Close()
+			return
+		}
+		if update == nil {
+			break
+		}
+		if err := ssz_snappy.EncodeAndWrite(stream, update, prefix...); err != nil {
+			stream.Close()
+			return
+		}
+		written++
+	}
+	if written == 0 {
+		stream.Write([]byte{ResourceUnavaiablePrefix})
+	}
 }
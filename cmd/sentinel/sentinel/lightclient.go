@@ -0,0 +1,82 @@
+/*
+   Copyright 2022 Erigon-Lightclient contributors
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+       http://www.apache.org/licenses/LICENSE-2.0
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package sentinel
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/rawdb"
+	"github.com/ledgerwatch/log/v3"
+	ssz "github.com/prysmaticlabs/fastssz"
+)
+
+// publishLightClientUpdates gossips the latest finality and optimistic updates found in the database once per slot.
+func (s *Sentinel) publishLightClientUpdates() {
+	ticker := time.NewTicker(time.Duration(s.cfg.BeaconConfig.SecondsPerSlot) * time.Second)
+	defer ticker.Stop()
+	var lastFinalitySlot, lastOptimisticSlot uint64
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		tx, err := s.db.BeginRo(s.ctx)
+		if err != nil {
+			log.Debug("[Sentinel] Could not read light client updates", "err", err)
+			continue
+		}
+		finalityUpdate, err := rawdb.ReadLightClientFinalityUpdate(tx)
+		if err != nil {
+			tx.Rollback()
+			log.Debug("[Sentinel] Could not read light client finality update", "err", err)
+			continue
+		}
+		optimisticUpdate, err := rawdb.ReadLightClientOptimisticUpdate(tx)
+		tx.Rollback()
+		if err != nil {
+			log.Debug("[Sentinel] Could not read light client optimistic update", "err", err)
+			continue
+		}
+
+		if finalityUpdate != nil && finalityUpdate.SignatureSlot > lastFinalitySlot {
+			if err := s.publishGossip(LightClientFinalityUpdateSsz, finalityUpdate); err != nil {
+				log.Debug("[Sentinel] Could not publish light client finality update", "err", err)
+			} else {
+				lastFinalitySlot = finalityUpdate.SignatureSlot
+			}
+		}
+		if optimisticUpdate != nil && optimisticUpdate.SignatureSlot > lastOptimisticSlot {
+			if err := s.publishGossip(LightClientOptimisticUpdateSsz, optimisticUpdate); err != nil {
+				log.Debug("[Sentinel] Could not publish light client optimistic update", "err", err)
+			} else {
+				lastOptimisticSlot = optimisticUpdate.SignatureSlot
+			}
+		}
+	}
+}
+
+func (s *Sentinel) publishGossip(topic GossipTopic, msg ssz.Marshaler) error {
+	sub, ok := s.subManager.GetSubscription(s.getTopic(topic))
+	if !ok {
+		return fmt.Errorf("not subscribed to %s", topic.Name)
+	}
+	data, err := utils.EncodeSSZSnappy(msg)
+	if err != nil {
+		return err
+	}
+	return sub.Publish(data)
+}
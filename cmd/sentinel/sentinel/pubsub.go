@@ -200,6 +200,11 @@ func (s *Sentinel) SubscribeGossip(topic GossipTopic, opts ...pubsub.TopicOpt) (
 		ctx:          s.ctx,
 	}
 	path := s.getTopic(topic)
+	if validator := s.topicValidator(topic); validator != nil {
+		if err := s.pubsub.RegisterTopicValidator(path, validator); err != nil {
+			return nil, fmt.Errorf("failed to register validator for topic %s, err=%w", path, err)
+		}
+	}
 	sub.topic, err = s.pubsub.Join(path, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to join topic %s, err=%w", path, err)
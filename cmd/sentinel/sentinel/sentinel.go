@@ -27,6 +27,7 @@ import (
 	"github.com/ledgerwatch/erigon/cmd/sentinel/sentinel/handlers"
 	"github.com/ledgerwatch/erigon/cmd/sentinel/sentinel/handshake"
 	"github.com/ledgerwatch/erigon/cmd/sentinel/sentinel/peers"
+	"github.com/ledgerwatch/erigon/cmd/sentinel/sentinel/validation"
 	"github.com/ledgerwatch/erigon/crypto"
 	"github.com/ledgerwatch/erigon/p2p/discover"
 	"github.com/ledgerwatch/erigon/p2p/enode"
@@ -53,9 +54,10 @@ type Sentinel struct {
 
 	db kv.RoDB
 
-	discoverConfig discover.Config
-	pubsub         *pubsub.PubSub
-	subManager     *GossipManager
+	discoverConfig  discover.Config
+	pubsub          *pubsub.PubSub
+	subManager      *GossipManager
+	gossipValidator *validation.GossipValidator
 }
 
 func (s *Sentinel) createLocalNode(
@@ -217,6 +219,7 @@ func New(
 	if err != nil {
 		return nil, fmt.Errorf("[Sentinel] failed to subscribe to gossip err=%w", err)
 	}
+	s.gossipValidator = validation.NewGossipValidator(cfg.BeaconConfig, cfg.GenesisConfig, cfg.NetworkConfig, cfg.ChainView)
 
 	return s, nil
 }
@@ -246,6 +249,9 @@ func (s *Sentinel) Start() error {
 		go s.listenForPeers()
 	}
 	s.subManager = NewGossipManager(s.ctx)
+	if s.db != nil {
+		go s.publishLightClientUpdates()
+	}
 	return nil
 }
 
@@ -0,0 +1,320 @@
+/*
+   Copyright 2022 Erigon-Lightclient contributors
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+       http://www.apache.org/licenses/LICENSE-2.0
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package validation
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Giulio2002/bls"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/fork"
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"github.com/ledgerwatch/erigon/cmd/erigon-cl/core/state/state_encoding"
+	"github.com/ledgerwatch/erigon/common"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+const seenCacheSize = 8192
+
+var (
+	// ErrInvalidSignature is returned for messages carrying a bad signature, their sender should be banned.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrMalformedMessage is returned for messages which cannot be decoded, their sender should be banned.
+	ErrMalformedMessage = errors.New("malformed message")
+)
+
+// ChainView exposes the beacon chain data needed by gossip validation.
+// Messages referencing unknown blocks or validators, or whose proposer or committee cannot be resolved
+// yet, are ignored.
+type ChainView interface {
+	// FinalizedCheckpoint returns the latest finalized checkpoint.
+	FinalizedCheckpoint() *cltypes.Checkpoint
+	// BlockSlot returns the slot of an already processed block.
+	BlockSlot(root common.Hash) (uint64, bool)
+	// ProposerIndex returns the expected proposer of a block at the given slot on top of the given parent block.
+	ProposerIndex(parentRoot common.Hash, slot uint64) (uint64, error)
+	// ValidatorPublicKey returns the BLS public key of a validator.
+	ValidatorPublicKey(index uint64) ([48]byte, bool)
+	// BeaconCommittee returns the validator indices of a committee according to the chain of the given block.
+	BeaconCommittee(root common.Hash, slot, committeeIndex uint64) ([]uint64, error)
+}
+
+// seenKey identifies a proposal by (proposer, slot) or an aggregate by (aggregator, target epoch).
+type seenKey struct {
+	validatorIndex uint64
+	slotOrEpoch    uint64
+}
+
+// GossipValidator performs the p2p spec validation of gossiped blocks and aggregates.
+// Without a chain view only the stateless checks are performed.
+type GossipValidator struct {
+	beaconCfg  *clparams.BeaconChainConfig
+	genesisCfg *clparams.GenesisConfig
+	networkCfg *clparams.NetworkConfig
+	chain      ChainView
+
+	seenProposals   *lru.Cache
+	seenAggregators *lru.Cache
+
+	now func() time.Time
+}
+
+func NewGossipValidator(beaconCfg *clparams.BeaconChainConfig, genesisCfg *clparams.GenesisConfig,
+	networkCfg *clparams.NetworkConfig, chain ChainView) *GossipValidator {
+	seenProposals, err := lru.New(seenCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	seenAggregators, err := lru.New(seenCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	return &GossipValidator{
+		beaconCfg:       beaconCfg,
+		genesisCfg:      genesisCfg,
+		networkCfg:      networkCfg,
+		chain:           chain,
+		seenProposals:   seenProposals,
+		seenAggregators: seenAggregators,
+		now:             time.Now,
+	}
+}
+
+// slotStart returns the time at which the given slot begins.
+func (v *GossipValidator) slotStart(slot uint64) time.Time {
+	return time.Unix(int64(v.genesisCfg.GenesisTime+slot*v.beaconCfg.SecondsPerSlot), 0)
+}
+
+// isFutureSlot checks whether a slot has not started yet, accounting for clock disparity.
+func (v *GossipValidator) isFutureSlot(slot uint64) bool {
+	return v.now().Add(v.networkCfg.MaximumGossipClockDisparity).Before(v.slotStart(slot))
+}
+
+// currentSlot returns the earliest slot we may be in, accounting for clock disparity.
+func (v *GossipValidator) currentSlot() uint64 {
+	now := v.now().Add(-v.networkCfg.MaximumGossipClockDisparity).Unix()
+	if now < int64(v.genesisCfg.GenesisTime) {
+		return 0
+	}
+	return (uint64(now) - v.genesisCfg.GenesisTime) / v.beaconCfg.SecondsPerSlot
+}
+
+func (v *GossipValidator) domain(domainType [4]byte) ([]byte, error) {
+	return fork.ComputeDomain(domainType[:], fork.GetLastFork(v.beaconCfg, v.genesisCfg), v.genesisCfg.GenesisValidatorRoot)
+}
+
+// validatorPublicKey resolves the public key of a validator, messages from unknown validators are ignored.
+func (v *GossipValidator) validatorPublicKey(validatorIndex uint64) ([48]byte, error) {
+	publicKey, ok := v.chain.ValidatorPublicKey(validatorIndex)
+	if !ok {
+		return [48]byte{}, fmt.Errorf("unknown validator %d", validatorIndex)
+	}
+	return publicKey, nil
+}
+
+// verifySignature checks a signature over a signing root.
+func verifySignature(publicKey [48]byte, signature [96]byte, signingRoot [32]byte) error {
+	valid, err := bls.Verify(signature[:], signingRoot[:], publicKey[:])
+	if err != nil || !valid {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// ValidateBeaconBlock validates a block received on the beacon_block topic.
+func (v *GossipValidator) ValidateBeaconBlock(signedBlock *cltypes.SignedBeaconBlockBellatrix) (pubsub.ValidationResult, error) {
+	if signedBlock.Block == nil || signedBlock.Block.Body == nil {
+		return pubsub.ValidationReject, ErrMalformedMessage
+	}
+	block := signedBlock.Block
+	if v.isFutureSlot(block.Slot) {
+		return pubsub.ValidationIgnore, fmt.Errorf("block from future slot %d", block.Slot)
+	}
+	key := seenKey{validatorIndex: block.ProposerIndex, slotOrEpoch: block.Slot}
+	if v.seenProposals.Contains(key) {
+		return pubsub.ValidationIgnore, fmt.Errorf("already seen a proposal from %d at slot %d", block.ProposerIndex, block.Slot)
+	}
+	if v.chain == nil {
+		v.seenProposals.Add(key, struct{}{})
+		return pubsub.ValidationAccept, nil
+	}
+	finalizedSlot := v.chain.FinalizedCheckpoint().Epoch * v.beaconCfg.SlotsPerEpoch
+	if block.Slot <= finalizedSlot {
+		return pubsub.ValidationIgnore, fmt.Errorf("block slot %d is not later than finalized slot %d", block.Slot, finalizedSlot)
+	}
+	parentSlot, ok := v.chain.BlockSlot(block.ParentRoot)
+	if !ok {
+		return pubsub.ValidationIgnore, fmt.Errorf("unknown parent %x", block.ParentRoot)
+	}
+	if block.Slot <= parentSlot {
+		return pubsub.ValidationReject, fmt.Errorf("block slot %d is not later than parent slot %d", block.Slot, parentSlot)
+	}
+	// The shuffling of the parent chain may not be computed yet, the block may be valid.
+	expectedProposer, err := v.chain.ProposerIndex(block.ParentRoot, block.Slot)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	if expectedProposer != block.ProposerIndex {
+		return pubsub.ValidationReject, fmt.Errorf("unexpected proposer %d, expected %d", block.ProposerIndex, expectedProposer)
+	}
+	proposerPublicKey, err := v.validatorPublicKey(block.ProposerIndex)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	domain, err := v.domain(v.beaconCfg.DomainBeaconProposer)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	signingRoot, err := fork.ComputeSigningRoot(block, domain)
+	if err != nil {
+		return pubsub.ValidationReject, ErrMalformedMessage
+	}
+	if err := verifySignature(proposerPublicKey, signedBlock.Signature, signingRoot); err != nil {
+		return pubsub.ValidationReject, err
+	}
+	v.seenProposals.Add(key, struct{}{})
+	return pubsub.ValidationAccept, nil
+}
+
+// ValidateAggregateAndProof validates an aggregate received on the beacon_aggregate_and_proof topic.
+func (v *GossipValidator) ValidateAggregateAndProof(signedAggregate *cltypes.SignedAggregateAndProof) (pubsub.ValidationResult, error) {
+	if signedAggregate.Message == nil || signedAggregate.Message.Aggregate == nil || signedAggregate.Message.Aggregate.Data == nil ||
+		signedAggregate.Message.Aggregate.Data.Target == nil {
+		return pubsub.ValidationReject, ErrMalformedMessage
+	}
+	aggregateAndProof := signedAggregate.Message
+	aggregate := aggregateAndProof.Aggregate
+	data := aggregate.Data
+
+	if v.isFutureSlot(data.Slot) || data.Slot+v.networkCfg.AttestationPropagationSlotRange < v.currentSlot() {
+		return pubsub.ValidationIgnore, fmt.Errorf("aggregate slot %d out of propagation range", data.Slot)
+	}
+	if data.Target.Epoch != data.Slot/v.beaconCfg.SlotsPerEpoch {
+		return pubsub.ValidationReject, fmt.Errorf("target epoch %d does not match slot %d", data.Target.Epoch, data.Slot)
+	}
+	participants := 0
+	for _, b := range aggregate.AggregationBits {
+		for ; b != 0; b &= b - 1 {
+			participants++
+		}
+	}
+	// The bitlist length bit is always set, so an empty aggregate has a single bit.
+	if participants <= 1 {
+		return pubsub.ValidationReject, fmt.Errorf("aggregate has no participants")
+	}
+	key := seenKey{validatorIndex: aggregateAndProof.AggregatorIndex, slotOrEpoch: data.Target.Epoch}
+	if v.seenAggregators.Contains(key) {
+		return pubsub.ValidationIgnore, fmt.Errorf("already seen an aggregate from %d for epoch %d", aggregateAndProof.AggregatorIndex, data.Target.Epoch)
+	}
+	if v.chain == nil {
+		v.seenAggregators.Add(key, struct{}{})
+		return pubsub.ValidationAccept, nil
+	}
+	if _, ok := v.chain.BlockSlot(data.BeaconBlockHash); !ok {
+		return pubsub.ValidationIgnore, fmt.Errorf("unknown block %x", data.BeaconBlockHash)
+	}
+	committee, err := v.chain.BeaconCommittee(data.BeaconBlockHash, data.Slot, data.Index)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	if length, ok := utils.GetBitlistLength(aggregate.AggregationBits); !ok || length != len(committee) {
+		return pubsub.ValidationReject, fmt.Errorf("aggregation bits length %d does not match committee of size %d", length, len(committee))
+	}
+	inCommittee := false
+	attestingIndices := []uint64{}
+	for i, validatorIndex := range committee {
+		if validatorIndex == aggregateAndProof.AggregatorIndex {
+			inCommittee = true
+		}
+		if (aggregate.AggregationBits[i/8]>>(i%8))&1 == 1 {
+			attestingIndices = append(attestingIndices, validatorIndex)
+		}
+	}
+	if !inCommittee {
+		return pubsub.ValidationReject, fmt.Errorf("aggregator %d is not part of committee %d", aggregateAndProof.AggregatorIndex, data.Index)
+	}
+	if !isAggregator(uint64(len(committee)), v.beaconCfg.TargetAggregatorsPerCommittee, aggregateAndProof.SelectionProof) {
+		return pubsub.ValidationReject, fmt.Errorf("validator %d is not an aggregator", aggregateAndProof.AggregatorIndex)
+	}
+	aggregatorPublicKey, err := v.validatorPublicKey(aggregateAndProof.AggregatorIndex)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	attestingPublicKeys := make([][]byte, 0, len(attestingIndices))
+	for _, validatorIndex := range attestingIndices {
+		publicKey, err := v.validatorPublicKey(validatorIndex)
+		if err != nil {
+			return pubsub.ValidationIgnore, err
+		}
+		attestingPublicKeys = append(attestingPublicKeys, publicKey[:])
+	}
+
+	// Selection proof is a signature over the slot.
+	selectionDomain, err := v.domain(v.beaconCfg.DomainSelectionProof)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	selectionSigningRoot, err := (&cltypes.SigningData{
+		Root:   state_encoding.Uint64Root(data.Slot),
+		Domain: selectionDomain,
+	}).HashTreeRoot()
+	if err != nil {
+		return pubsub.ValidationReject, ErrMalformedMessage
+	}
+	if err := verifySignature(aggregatorPublicKey, aggregateAndProof.SelectionProof, selectionSigningRoot); err != nil {
+		return pubsub.ValidationReject, err
+	}
+	// Aggregator signature over the whole message.
+	aggregateAndProofDomain, err := v.domain(v.beaconCfg.DomainAggregateAndProof)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	aggregateAndProofSigningRoot, err := fork.ComputeSigningRoot(aggregateAndProof, aggregateAndProofDomain)
+	if err != nil {
+		return pubsub.ValidationReject, ErrMalformedMessage
+	}
+	if err := verifySignature(aggregatorPublicKey, signedAggregate.Signature, aggregateAndProofSigningRoot); err != nil {
+		return pubsub.ValidationReject, err
+	}
+	// Aggregate signature of the attesters.
+	attesterDomain, err := v.domain(v.beaconCfg.DomainBeaconAttester)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	attestationSigningRoot, err := fork.ComputeSigningRoot(data, attesterDomain)
+	if err != nil {
+		return pubsub.ValidationReject, ErrMalformedMessage
+	}
+	valid, err := bls.VerifyAggregate(aggregate.Signature[:], attestationSigningRoot[:], attestingPublicKeys)
+	if err != nil || !valid {
+		return pubsub.ValidationReject, ErrInvalidSignature
+	}
+	v.seenAggregators.Add(key, struct{}{})
+	return pubsub.ValidationAccept, nil
+}
+
+// isAggregator checks whether the selection proof selects its signer as an aggregator of a committee of the given size.
+func isAggregator(committeeSize, targetAggregatorsPerCommittee uint64, selectionProof [96]byte) bool {
+	modulo := committeeSize / targetAggregatorsPerCommittee
+	if modulo == 0 {
+		modulo = 1
+	}
+	selectionProofHash := utils.Keccak256(selectionProof[:])
+	return binary.LittleEndian.Uint64(selectionProofHash[:8])%modulo == 0
+}
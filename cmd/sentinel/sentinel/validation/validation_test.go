@@ -0,0 +1,204 @@
+package validation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/common"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/stretchr/testify/require"
+)
+
+const testCurrentSlot = 1000
+
+type mockChainView struct {
+	finalized  *cltypes.Checkpoint
+	blocks     map[common.Hash]uint64
+	proposers  map[uint64]uint64
+	committees map[uint64][]uint64 // Committee 0 of each slot.
+}
+
+func (m *mockChainView) FinalizedCheckpoint() *cltypes.Checkpoint {
+	return m.finalized
+}
+
+func (m *mockChainView) BlockSlot(root common.Hash) (uint64, bool) {
+	slot, ok := m.blocks[root]
+	return slot, ok
+}
+
+func (m *mockChainView) ProposerIndex(parentRoot common.Hash, slot uint64) (uint64, error) {
+	proposer, ok := m.proposers[slot]
+	if !ok {
+		return 0, fmt.Errorf("unknown shuffling")
+	}
+	return proposer, nil
+}
+
+func (m *mockChainView) ValidatorPublicKey(index uint64) ([48]byte, bool) {
+	return [48]byte{}, false
+}
+
+func (m *mockChainView) BeaconCommittee(root common.Hash, slot, committeeIndex uint64) ([]uint64, error) {
+	committee, ok := m.committees[slot]
+	if !ok || committeeIndex != 0 {
+		return nil, fmt.Errorf("unknown shuffling")
+	}
+	return committee, nil
+}
+
+func getTestValidator(chain ChainView) *GossipValidator {
+	genesisCfg, networkCfg, beaconCfg := clparams.GetConfigsByNetwork(clparams.MainnetNetwork)
+	v := NewGossipValidator(beaconCfg, genesisCfg, networkCfg, chain)
+	// Place the clock in the middle of the test slot.
+	now := v.slotStart(testCurrentSlot).Add(time.Duration(beaconCfg.SecondsPerSlot) * time.Second / 2)
+	v.now = func() time.Time { return now }
+	return v
+}
+
+func getTestBlock(slot uint64, parentRoot common.Hash) *cltypes.SignedBeaconBlockBellatrix {
+	return &cltypes.SignedBeaconBlockBellatrix{
+		Block: &cltypes.BeaconBlockBellatrix{
+			Slot:       slot,
+			ParentRoot: parentRoot,
+			Body:       &cltypes.BeaconBodyBellatrix{},
+		},
+	}
+}
+
+func getTestAggregate(slot, targetEpoch uint64, aggregationBits []byte) *cltypes.SignedAggregateAndProof {
+	return &cltypes.SignedAggregateAndProof{
+		Message: &cltypes.AggregateAndProof{
+			Aggregate: &cltypes.Attestation{
+				AggregationBits: aggregationBits,
+				Data: &cltypes.AttestationData{
+					Slot:   slot,
+					Source: &cltypes.Checkpoint{},
+					Target: &cltypes.Checkpoint{Epoch: targetEpoch},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateBeaconBlock(t *testing.T) {
+	parentRoot := common.HexToHash("0x01")
+	chain := &mockChainView{
+		finalized: &cltypes.Checkpoint{Epoch: 10},
+		blocks:    map[common.Hash]uint64{parentRoot: testCurrentSlot - 3},
+		proposers: map[uint64]uint64{testCurrentSlot: 0, testCurrentSlot - 1: 1},
+	}
+	v := getTestValidator(chain)
+	tests := []struct {
+		name   string
+		block  *cltypes.SignedBeaconBlockBellatrix
+		result pubsub.ValidationResult
+	}{
+		{"malformed", &cltypes.SignedBeaconBlockBellatrix{}, pubsub.ValidationReject},
+		{"future slot", getTestBlock(testCurrentSlot+2, parentRoot), pubsub.ValidationIgnore},
+		{"finalized slot", getTestBlock(10*32, parentRoot), pubsub.ValidationIgnore},
+		{"unknown parent", getTestBlock(testCurrentSlot, common.HexToHash("0x02")), pubsub.ValidationIgnore},
+		{"not after parent", getTestBlock(testCurrentSlot-3, parentRoot), pubsub.ValidationReject},
+		{"unresolved proposer", getTestBlock(testCurrentSlot-2, parentRoot), pubsub.ValidationIgnore},
+		{"wrong proposer", getTestBlock(testCurrentSlot-1, parentRoot), pubsub.ValidationReject},
+		// The proposer is not part of the chain view, so the signature cannot be checked yet.
+		{"unknown proposer", getTestBlock(testCurrentSlot, parentRoot), pubsub.ValidationIgnore},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := v.ValidateBeaconBlock(test.block)
+			require.Error(t, err)
+			require.Equal(t, test.result, result)
+		})
+	}
+}
+
+func TestValidateBeaconBlockDuplicates(t *testing.T) {
+	v := getTestValidator(nil)
+	result, err := v.ValidateBeaconBlock(getTestBlock(testCurrentSlot, common.Hash{}))
+	require.NoError(t, err)
+	require.Equal(t, pubsub.ValidationAccept, result)
+	// A second proposal from the same proposer at the same slot is ignored.
+	equivocation := getTestBlock(testCurrentSlot, common.HexToHash("0x01"))
+	result, err = v.ValidateBeaconBlock(equivocation)
+	require.Error(t, err)
+	require.Equal(t, pubsub.ValidationIgnore, result)
+	// Unless it comes from another proposer.
+	equivocation.Block.ProposerIndex = 1
+	result, err = v.ValidateBeaconBlock(equivocation)
+	require.NoError(t, err)
+	require.Equal(t, pubsub.ValidationAccept, result)
+}
+
+func TestValidateAggregateAndProof(t *testing.T) {
+	blockRoot := common.HexToHash("0x01")
+	chain := &mockChainView{
+		finalized: &cltypes.Checkpoint{},
+		blocks:    map[common.Hash]uint64{blockRoot: testCurrentSlot - 1},
+		// Small committees only have aggregators.
+		committees: map[uint64][]uint64{testCurrentSlot: {0, 1}, testCurrentSlot - 1: {1, 2}},
+	}
+	v := getTestValidator(chain)
+	knownBlockAggregate := func(slot, committeeIndex uint64, aggregationBits []byte) *cltypes.SignedAggregateAndProof {
+		aggregate := getTestAggregate(slot, slot/32, aggregationBits)
+		aggregate.Message.Aggregate.Data.BeaconBlockHash = blockRoot
+		aggregate.Message.Aggregate.Data.Index = committeeIndex
+		return aggregate
+	}
+	tests := []struct {
+		name      string
+		aggregate *cltypes.SignedAggregateAndProof
+		result    pubsub.ValidationResult
+	}{
+		{"malformed", &cltypes.SignedAggregateAndProof{}, pubsub.ValidationReject},
+		{"future slot", getTestAggregate(testCurrentSlot+2, (testCurrentSlot+2)/32, []byte{0x03}), pubsub.ValidationIgnore},
+		{"too old", getTestAggregate(testCurrentSlot-33, (testCurrentSlot-33)/32, []byte{0x03}), pubsub.ValidationIgnore},
+		{"wrong target", getTestAggregate(testCurrentSlot, testCurrentSlot/32+1, []byte{0x03}), pubsub.ValidationReject},
+		{"no participants", getTestAggregate(testCurrentSlot, testCurrentSlot/32, []byte{0x01}), pubsub.ValidationReject},
+		{"unknown block", getTestAggregate(testCurrentSlot, testCurrentSlot/32, []byte{0x03}), pubsub.ValidationIgnore},
+		// The aggregator is not part of the chain view, so the selection proof cannot be checked yet.
+		{"unknown aggregator", knownBlockAggregate(testCurrentSlot, 0, []byte{0x07}), pubsub.ValidationIgnore},
+		{"unresolved committee", knownBlockAggregate(testCurrentSlot, 1, []byte{0x07}), pubsub.ValidationIgnore},
+		{"bits longer than committee", knownBlockAggregate(testCurrentSlot, 0, []byte{0x0f}), pubsub.ValidationReject},
+		{"bits shorter than committee", knownBlockAggregate(testCurrentSlot, 0, []byte{0x03}), pubsub.ValidationReject},
+		{"not in committee", knownBlockAggregate(testCurrentSlot-1, 0, []byte{0x07}), pubsub.ValidationReject},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := v.ValidateAggregateAndProof(test.aggregate)
+			require.Error(t, err)
+			require.Equal(t, test.result, result)
+		})
+	}
+}
+
+func TestValidateAggregateAndProofDuplicates(t *testing.T) {
+	v := getTestValidator(nil)
+	aggregate := getTestAggregate(testCurrentSlot, testCurrentSlot/32, []byte{0x03})
+	result, err := v.ValidateAggregateAndProof(aggregate)
+	require.NoError(t, err)
+	require.Equal(t, pubsub.ValidationAccept, result)
+	// Aggregators only get to send one aggregate per epoch.
+	aggregate = getTestAggregate(testCurrentSlot+1, testCurrentSlot/32, []byte{0x05})
+	result, err = v.ValidateAggregateAndProof(aggregate)
+	require.Error(t, err)
+	require.Equal(t, pubsub.ValidationIgnore, result)
+}
+
+func TestIsAggregator(t *testing.T) {
+	// Small committees only have aggregators.
+	require.True(t, isAggregator(16, 16, [96]byte{}))
+	selected, notSelected := 0, 0
+	for i := 0; i < 256; i++ {
+		if isAggregator(512, 16, [96]byte{byte(i)}) {
+			selected++
+		} else {
+			notSelected++
+		}
+	}
+	require.NotZero(t, selected)
+	require.NotZero(t, notSelected)
+}
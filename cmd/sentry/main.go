@@ -27,12 +27,21 @@ var (
 	trustedPeers []string // trusted peers
 	discoveryDNS []string
 	nodiscover   bool // disable sentry's discovery mechanism
+	discoveryV5  bool // enable the V5 discovery
+	topicsV5     []string
 	protocol     uint
 	allowedPorts []uint
 	netRestrict  string // CIDR to restrict peering to
 	maxPeers     int
 	maxPendPeers int
 	healthCheck  bool
+
+	egressRate     string // limit of the bytes per second sent to all peers
+	peerEgressRate string // limit of the bytes per second sent to one peer
+
+	captureDir      string // directory of the captured messages
+	captureFileSize string
+	captureFiles    int
 )
 
 func init() {
@@ -46,12 +55,19 @@ func init() {
 	rootCmd.Flags().StringSliceVar(&trustedPeers, utils.TrustedPeersFlag.Name, []string{}, utils.TrustedPeersFlag.Usage)
 	rootCmd.Flags().StringSliceVar(&discoveryDNS, utils.DNSDiscoveryFlag.Name, []string{}, utils.DNSDiscoveryFlag.Usage)
 	rootCmd.Flags().BoolVar(&nodiscover, utils.NoDiscoverFlag.Name, false, utils.NoDiscoverFlag.Usage)
+	rootCmd.Flags().BoolVar(&discoveryV5, utils.DiscoveryV5Flag.Name, false, utils.DiscoveryV5Flag.Usage)
+	rootCmd.Flags().StringSliceVar(&topicsV5, utils.DiscoveryV5TopicsFlag.Name, []string{}, utils.DiscoveryV5TopicsFlag.Usage)
 	rootCmd.Flags().UintVar(&protocol, utils.P2pProtocolVersionFlag.Name, utils.P2pProtocolVersionFlag.Value.Value()[0], utils.P2pProtocolVersionFlag.Usage)
 	rootCmd.Flags().UintSliceVar(&allowedPorts, utils.P2pProtocolAllowedPorts.Name, utils.P2pProtocolAllowedPorts.Value.Value(), utils.P2pProtocolAllowedPorts.Usage)
 	rootCmd.Flags().StringVar(&netRestrict, utils.NetrestrictFlag.Name, utils.NetrestrictFlag.Value, utils.NetrestrictFlag.Usage)
 	rootCmd.Flags().IntVar(&maxPeers, utils.MaxPeersFlag.Name, utils.MaxPeersFlag.Value, utils.MaxPeersFlag.Usage)
 	rootCmd.Flags().IntVar(&maxPendPeers, utils.MaxPendingPeersFlag.Name, utils.MaxPendingPeersFlag.Value, utils.MaxPendingPeersFlag.Usage)
 	rootCmd.Flags().BoolVar(&healthCheck, utils.HealthCheckFlag.Name, false, utils.HealthCheckFlag.Usage)
+	rootCmd.Flags().StringVar(&egressRate, utils.P2pEgressRateFlag.Name, "", utils.P2pEgressRateFlag.Usage)
+	rootCmd.Flags().StringVar(&peerEgressRate, utils.P2pPeerEgressRateFlag.Name, "", utils.P2pPeerEgressRateFlag.Usage)
+	rootCmd.Flags().StringVar(&captureDir, utils.P2pCaptureDirFlag.Name, "", utils.P2pCaptureDirFlag.Usage)
+	rootCmd.Flags().StringVar(&captureFileSize, utils.P2pCaptureFileSizeFlag.Name, utils.P2pCaptureFileSizeFlag.Value, utils.P2pCaptureFileSizeFlag.Usage)
+	rootCmd.Flags().IntVar(&captureFiles, utils.P2pCaptureFilesFlag.Name, utils.P2pCaptureFilesFlag.Value, utils.P2pCaptureFilesFlag.Usage)
 
 	if err := rootCmd.MarkFlagDirname(utils.DataDirFlag.Name); err != nil {
 		panic(err)
@@ -89,6 +105,19 @@ var rootCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		p2pConfig.DiscoveryV5 = discoveryV5
+		p2pConfig.DiscoveryV5Topics = topicsV5
+		if p2pConfig.MaxEgressRate, err = utils.ParseByteRate(egressRate); err != nil {
+			return fmt.Errorf("bad option %s: %w", utils.P2pEgressRateFlag.Name, err)
+		}
+		if p2pConfig.MaxPeerEgressRate, err = utils.ParseByteRate(peerEgressRate); err != nil {
+			return fmt.Errorf("bad option %s: %w", utils.P2pPeerEgressRateFlag.Name, err)
+		}
+		p2pConfig.CaptureDir = captureDir
+		if p2pConfig.CaptureFileSize, err = utils.ParseByteRate(captureFileSize); err != nil {
+			return fmt.Errorf("bad option %s: %w", utils.P2pCaptureFileSizeFlag.Name, err)
+		}
+		p2pConfig.CaptureFiles = captureFiles
 
 		_ = logging2.GetLoggerCmd("sentry", cmd)
 		return sentry.Sentry(cmd.Context(), dirs, sentryAddr, discoveryDNS, p2pConfig, protocol, healthCheck)
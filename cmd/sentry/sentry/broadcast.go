@@ -55,7 +55,7 @@ func (cs *MultiClient) PropagateNewBlockHashes(ctx context.Context, announces []
 
 		switch sentry.Protocol() {
 
-		case eth.ETH66, eth.ETH67:
+		case eth.ETH66, eth.ETH67, eth.ETH68:
 			if req66 == nil {
 				req66 = &proto_sentry.OutboundMessageData{
 					Id:   proto_sentry.MessageId_NEW_BLOCK_HASHES_66,
@@ -96,7 +96,7 @@ func (cs *MultiClient) BroadcastNewBlock(ctx context.Context, block *types.Block
 
 		switch sentry.Protocol() {
 
-		case eth.ETH66, eth.ETH67:
+		case eth.ETH66, eth.ETH67, eth.ETH68:
 			if req66 == nil {
 				req66 = &proto_sentry.SendMessageToRandomPeersRequest{
 					MaxPeers: 1024,
@@ -154,7 +154,7 @@ func (cs *MultiClient) BroadcastLocalPooledTxs(ctx context.Context, txs []common
 			}
 
 			switch sentry.Protocol() {
-			case eth.ETH66, eth.ETH67:
+			case eth.ETH66, eth.ETH67, eth.ETH68:
 				if req66 == nil {
 					req66 = &proto_sentry.OutboundMessageData{
 						Id:   proto_sentry.MessageId_NEW_POOLED_TRANSACTION_HASHES_66,
@@ -214,7 +214,7 @@ func (cs *MultiClient) BroadcastRemotePooledTxs(ctx context.Context, txs []commo
 
 			switch sentry.Protocol() {
 
-			case eth.ETH66, eth.ETH67:
+			case eth.ETH66, eth.ETH67, eth.ETH68:
 				if req66 == nil {
 					req66 = &proto_sentry.SendMessageToRandomPeersRequest{
 						MaxPeers: 1024,
@@ -265,7 +265,7 @@ func (cs *MultiClient) PropagatePooledTxsToPeersList(ctx context.Context, peers
 			for _, peer := range peers {
 				switch sentry.Protocol() {
 
-				case eth.ETH66, eth.ETH67:
+				case eth.ETH66, eth.ETH67, eth.ETH68:
 					req66 := &proto_sentry.SendMessageByIdRequest{
 						PeerId: peer,
 						Data: &proto_sentry.OutboundMessageData{
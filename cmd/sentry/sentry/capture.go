@@ -0,0 +1,222 @@
+package sentry
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	proto_sentry "github.com/ledgerwatch/erigon-lib/gointerfaces/sentry"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/log/v3"
+)
+
+const (
+	captureFilePrefix     = "capture-"
+	captureFileExt        = ".rlp"
+	captureFileTimeFormat = "20060102-150405.000000"
+)
+
+// CaptureRecord is an eth-protocol message received from a peer or sent to it.
+// A capture file is a sequence of RLP encoded records.
+type CaptureRecord struct {
+	Time      uint64 // Unix time in nanoseconds
+	Inbound   bool
+	PeerID    [64]byte
+	MessageID uint64 // proto_sentry.MessageId
+	Data      []byte // RLP payload of the message
+}
+
+func (r *CaptureRecord) String() string {
+	direction := "out"
+	if r.Inbound {
+		direction = "in"
+	}
+	return fmt.Sprintf("%s %-3s %x %s %d bytes",
+		time.Unix(0, int64(r.Time)).UTC().Format(time.RFC3339Nano),
+		direction,
+		r.PeerID[:8],
+		proto_sentry.MessageId(r.MessageID),
+		len(r.Data))
+}
+
+// Capture writes the messages of the sentries to files in a directory.
+// A new file is started when the current one reaches the file size,
+// and only the given number of the most recent files are kept.
+type Capture struct {
+	dir      string
+	fileSize uint64
+	files    int
+
+	lock    sync.Mutex
+	file    *os.File
+	written uint64
+	started time.Time // of the current file, the names of the files have to differ
+}
+
+// OpenCapture starts capturing to a new file in dir.
+func OpenCapture(dir string, fileSize uint64, files int) (*Capture, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	c := &Capture{dir: dir, fileSize: fileSize, files: files}
+	if err := c.rotate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Write appends a message to the capture. Errors are logged, and stop the capture.
+func (c *Capture) Write(inbound bool, peerID [64]byte, msgID proto_sentry.MessageId, data []byte) {
+	b, err := rlp.EncodeToBytes(&CaptureRecord{
+		Time:      uint64(time.Now().UnixNano()),
+		Inbound:   inbound,
+		PeerID:    peerID,
+		MessageID: uint64(msgID),
+		Data:      data,
+	})
+	if err != nil {
+		log.Error("[sentry] Capture encoding failed", "err", err)
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.file == nil {
+		return
+	}
+	if (c.fileSize > 0) && (c.written > 0) && (c.written+uint64(len(b)) > c.fileSize) {
+		if err := c.rotate(); err != nil {
+			log.Error("[sentry] Capture rotation failed, capture stopped", "err", err)
+			return
+		}
+	}
+	if _, err := c.file.Write(b); err != nil {
+		log.Error("[sentry] Capture write failed, capture stopped", "file", c.file.Name(), "err", err)
+		_ = c.file.Close()
+		c.file = nil
+		return
+	}
+	c.written += uint64(len(b))
+}
+
+// rotate closes the current file, opens a new one, and removes the oldest files over the limit.
+func (c *Capture) rotate() error {
+	if c.file != nil {
+		if err := c.file.Close(); err != nil {
+			return err
+		}
+		c.file = nil
+	}
+
+	started := time.Now().UTC().Truncate(time.Microsecond)
+	if !started.After(c.started) {
+		started = c.started.Add(time.Microsecond)
+	}
+	name := captureFilePrefix + started.Format(captureFileTimeFormat) + captureFileExt
+	file, err := os.OpenFile(filepath.Join(c.dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	c.file = file
+	c.written = 0
+	c.started = started
+
+	if c.files <= 0 {
+		return nil
+	}
+	paths, err := CaptureFiles(c.dir)
+	if err != nil {
+		return err
+	}
+	for len(paths) > c.files {
+		if err := os.Remove(paths[0]); err != nil {
+			return err
+		}
+		paths = paths[1:]
+	}
+	return nil
+}
+
+// Close stops the capture. It can be called more than once, for a capture shared by several sentries.
+func (c *Capture) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.file == nil {
+		return nil
+	}
+	err := c.file.Close()
+	c.file = nil
+	return err
+}
+
+// CaptureFiles returns the paths of the capture files in dir, oldest first.
+func CaptureFiles(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, captureFilePrefix+"*"+captureFileExt))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// CaptureReader reads the records of capture files in order.
+type CaptureReader struct {
+	paths  []string
+	file   *os.File
+	stream *rlp.Stream
+}
+
+func NewCaptureReader(paths ...string) *CaptureReader {
+	return &CaptureReader{paths: paths}
+}
+
+// Next returns the next record, or io.EOF after the last record of the last file.
+// A truncated record at the end of a file, left by a crash, ends that file.
+func (r *CaptureReader) Next() (*CaptureRecord, error) {
+	for {
+		if r.stream == nil {
+			if len(r.paths) == 0 {
+				return nil, io.EOF
+			}
+			file, err := os.Open(r.paths[0])
+			if err != nil {
+				return nil, err
+			}
+			r.paths = r.paths[1:]
+			r.file = file
+			r.stream = rlp.NewStream(file, 0)
+		}
+
+		var record CaptureRecord
+		err := r.stream.Decode(&record)
+		if err == nil {
+			return &record, nil
+		}
+		if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("reading %s: %w", r.file.Name(), err)
+		}
+		if err := r.closeFile(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (r *CaptureReader) closeFile() error {
+	r.stream = nil
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+func (r *CaptureReader) Close() error {
+	r.paths = nil
+	return r.closeFile()
+}
@@ -0,0 +1,88 @@
+package sentry
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	proto_sentry "github.com/ledgerwatch/erigon-lib/gointerfaces/sentry"
+	"github.com/stretchr/testify/require"
+)
+
+func readCapture(t *testing.T, paths ...string) []*CaptureRecord {
+	reader := NewCaptureReader(paths...)
+	defer reader.Close()
+	var records []*CaptureRecord
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return records
+		}
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+}
+
+func TestCapture(t *testing.T) {
+	dir := t.TempDir()
+	capture, err := OpenCapture(dir, 0, 0)
+	require.NoError(t, err)
+	capture.Write(true, [64]byte{1}, proto_sentry.MessageId_BLOCK_HEADERS_66, []byte{0xc0})
+	capture.Write(false, [64]byte{2}, proto_sentry.MessageId_GET_BLOCK_BODIES_66, []byte{0xc1, 0x80})
+	require.NoError(t, capture.Close())
+	require.NoError(t, capture.Close())
+	capture.Write(true, [64]byte{3}, proto_sentry.MessageId_BLOCK_BODIES_66, []byte{0xc0})
+
+	paths, err := CaptureFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	records := readCapture(t, paths...)
+	require.Len(t, records, 2)
+	require.True(t, records[0].Inbound)
+	require.Equal(t, [64]byte{1}, records[0].PeerID)
+	require.Equal(t, uint64(proto_sentry.MessageId_BLOCK_HEADERS_66), records[0].MessageID)
+	require.Equal(t, []byte{0xc0}, records[0].Data)
+	require.False(t, records[1].Inbound)
+	require.Equal(t, []byte{0xc1, 0x80}, records[1].Data)
+	require.LessOrEqual(t, records[0].Time, records[1].Time)
+}
+
+func TestCaptureRotation(t *testing.T) {
+	dir := t.TempDir()
+	// Every record fills a file
+	capture, err := OpenCapture(dir, 100, 3)
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		capture.Write(true, [64]byte{byte(i)}, proto_sentry.MessageId_NEW_BLOCK_66, make([]byte, 80))
+	}
+	require.NoError(t, capture.Close())
+
+	paths, err := CaptureFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, paths, 3)
+	records := readCapture(t, paths...)
+	require.Len(t, records, 3)
+	for i, record := range records {
+		require.Equal(t, [64]byte{byte(i + 2)}, record.PeerID)
+	}
+}
+
+func TestCaptureTruncated(t *testing.T) {
+	dir := t.TempDir()
+	capture, err := OpenCapture(dir, 0, 0)
+	require.NoError(t, err)
+	capture.Write(true, [64]byte{1}, proto_sentry.MessageId_NEW_BLOCK_66, make([]byte, 10))
+	capture.Write(true, [64]byte{2}, proto_sentry.MessageId_NEW_BLOCK_66, make([]byte, 10))
+	require.NoError(t, capture.Close())
+
+	paths, err := CaptureFiles(dir)
+	require.NoError(t, err)
+	info, err := os.Stat(paths[0])
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(paths[0], info.Size()-5))
+
+	records := readCapture(t, paths...)
+	require.Len(t, records, 1)
+	require.Equal(t, [64]byte{1}, records[0].PeerID)
+}
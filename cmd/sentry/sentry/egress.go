@@ -0,0 +1,82 @@
+package sentry
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	proto_sentry "github.com/ledgerwatch/erigon-lib/gointerfaces/sentry"
+	"golang.org/x/time/rate"
+)
+
+// NewEgressLimiter returns a token bucket for the given bytes per second, holding one second worth of bytes.
+// It returns nil, which means unlimited, for a zero rate.
+func NewEgressLimiter(bytesPerSecond uint64) *rate.Limiter {
+	if bytesPerSecond == 0 {
+		return nil
+	}
+	burst := bytesPerSecond
+	if burst > math.MaxInt32 {
+		burst = math.MaxInt32
+	}
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), int(burst))
+}
+
+// waitEgress blocks until size bytes may be sent under all the given limiters, nil limiters are skipped.
+// Messages larger than the burst of a limiter are paid for in burst sized pieces.
+func waitEgress(ctx context.Context, size int, limiters ...*rate.Limiter) error {
+	for _, limiter := range limiters {
+		if limiter == nil {
+			continue
+		}
+		for left := size; left > 0; {
+			n := left
+			if n > limiter.Burst() {
+				n = limiter.Burst()
+			}
+			if err := limiter.WaitN(ctx, n); err != nil {
+				return err
+			}
+			left -= n
+		}
+	}
+	return nil
+}
+
+// allowEgress takes size bytes from all the given limiters if they can all be sent at once, nil limiters are
+// skipped. Nothing is taken when it returns false.
+func allowEgress(size int, limiters ...*rate.Limiter) bool {
+	now := time.Now()
+	reservations := make([]*rate.Reservation, 0, len(limiters))
+	for _, limiter := range limiters {
+		if limiter == nil {
+			continue
+		}
+		r := limiter.ReserveN(now, size)
+		if !r.OK() || r.DelayFrom(now) > 0 {
+			r.CancelAt(now)
+			for _, prev := range reservations {
+				prev.CancelAt(now)
+			}
+			return false
+		}
+		reservations = append(reservations, r)
+	}
+	return true
+}
+
+// countEgress updates the metrics of the messages sent to peers, by message ID
+func countEgress(msgID proto_sentry.MessageId, size int, throttled time.Duration) {
+	metrics.GetOrCreateCounter(fmt.Sprintf(`sentry_egress_bytes{message="%s"}`, msgID)).Add(size)
+	metrics.GetOrCreateCounter(fmt.Sprintf(`sentry_egress_messages{message="%s"}`, msgID)).Inc()
+	if throttled > 0 {
+		metrics.GetOrCreateCounter(fmt.Sprintf(`sentry_egress_throttled_ms{message="%s"}`, msgID)).Add(int(throttled.Milliseconds()))
+	}
+}
+
+// countEgressDropped updates the metrics of the messages not sent to peers because of the egress limits
+func countEgressDropped(msgID proto_sentry.MessageId) {
+	metrics.GetOrCreateCounter(fmt.Sprintf(`sentry_egress_dropped{message="%s"}`, msgID)).Inc()
+}
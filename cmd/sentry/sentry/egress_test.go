@@ -0,0 +1,40 @@
+package sentry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitEgress(t *testing.T) {
+	require.Nil(t, NewEgressLimiter(0))
+	require.NoError(t, waitEgress(context.Background(), 1<<30, nil, nil))
+
+	peer, global := NewEgressLimiter(1000), NewEgressLimiter(100_000)
+	start := time.Now()
+	// The first second is the burst, a message of 1.5 times the burst takes half a second more
+	require.NoError(t, waitEgress(context.Background(), 1500, peer, global))
+	require.InDelta(t, 500*time.Millisecond, time.Since(start), float64(200*time.Millisecond))
+
+	// Waiting stops with the peer
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Error(t, waitEgress(ctx, 1000, peer, global))
+}
+
+func TestAllowEgress(t *testing.T) {
+	require.True(t, allowEgress(1<<30, nil, nil))
+
+	peer, global := NewEgressLimiter(1000), NewEgressLimiter(1500)
+	require.True(t, allowEgress(800, peer, global))
+	// The peer limit is exceeded, nothing is taken from the global limit
+	require.False(t, allowEgress(800, peer, global))
+	require.True(t, allowEgress(700, global))
+	// The global limit is exceeded, nothing is taken from the peer limit
+	require.False(t, allowEgress(200, peer, global))
+	require.True(t, allowEgress(200, peer))
+	// Larger than the burst, never allowed at once
+	require.False(t, allowEgress(2000, nil, NewEgressLimiter(1000)))
+}
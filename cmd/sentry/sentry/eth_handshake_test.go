@@ -64,6 +64,15 @@ func TestCheckPeerStatusCompatibility(t *testing.T) {
 		assert.NotNil(t, err)
 		assert.Contains(t, err.Error(), "version is more")
 	})
+	t.Run("eth/68", func(t *testing.T) {
+		reply := goodReply
+		reply.ProtocolVersion = eth.ETH68
+		err := checkPeerStatusCompatibility(&reply, &status, eth.ETH68, eth.ETH68)
+		assert.Nil(t, err)
+		err = checkPeerStatusCompatibility(&reply, &status, eth.ETH67, eth.ETH67)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "version is more")
+	})
 	t.Run("genesis mismatch", func(t *testing.T) {
 		reply := goodReply
 		reply.Genesis = common.Hash{}
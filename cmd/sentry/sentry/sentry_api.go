@@ -45,7 +45,7 @@ func (cs *MultiClient) SendBodyRequest(ctx context.Context, req *bodydownload.Bo
 		}
 
 		switch cs.sentries[i].Protocol() {
-		case eth.ETH66, eth.ETH67:
+		case eth.ETH66, eth.ETH67, eth.ETH68:
 			//log.Info(fmt.Sprintf("Sending body request for %v", req.BlockNums))
 			var bytes []byte
 			var err error
@@ -87,7 +87,7 @@ func (cs *MultiClient) SendHeaderRequest(ctx context.Context, req *headerdownloa
 			continue
 		}
 		switch cs.sentries[i].Protocol() {
-		case eth.ETH66, eth.ETH67:
+		case eth.ETH66, eth.ETH67, eth.ETH68:
 			//log.Info(fmt.Sprintf("Sending header request {hash: %x, height: %d, length: %d}", req.Hash, req.Number, req.Length))
 			reqData := &eth.GetBlockHeadersPacket66{
 				RequestId: rand.Uint64(), // nolint: gosec
@@ -29,6 +29,7 @@ import (
 	"github.com/ledgerwatch/erigon/common"
 	"github.com/ledgerwatch/erigon/common/debug"
 	"github.com/ledgerwatch/erigon/core/forkid"
+	"github.com/ledgerwatch/erigon/crypto"
 	"github.com/ledgerwatch/erigon/eth/protocols/eth"
 	"github.com/ledgerwatch/erigon/p2p"
 	"github.com/ledgerwatch/erigon/p2p/dnsdisc"
@@ -36,6 +37,7 @@ import (
 	"github.com/ledgerwatch/erigon/params"
 	"github.com/ledgerwatch/erigon/rlp"
 	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -45,8 +47,11 @@ import (
 const (
 	// handshakeTimeout is the maximum allowed time for the `eth` handshake to
 	// complete before dropping the connection.= as malicious.
-	handshakeTimeout  = 5 * time.Second
-	maxPermitsPerPeer = 4 // How many outstanding requests per peer we may have
+	handshakeTimeout        = 5 * time.Second
+	maxPermitsPerPeer       = 4                // How many outstanding requests per peer we may have
+	maxQueuedRequests       = 256              // How many requests and responses may wait for the worker of the peer
+	maxQueuedRequestBytes   = 32 * 1024 * 1024 // Total size of the requests and responses which may wait for the worker of the peer
+	reputationFlushInterval = 10 * time.Second // How often the peer reputation updates are written to the node database
 )
 
 // PeerInfo collects various extra bits of information about the peer,
@@ -55,7 +60,12 @@ type PeerInfo struct {
 	peer          *p2p.Peer
 	lock          sync.RWMutex
 	deadlines     []time.Time // Request deadlines
+	requested     []time.Time // Times at which the requests with deadlines were sent
 	latestDealine time.Time
+	latencySum    time.Duration // Latency of the responses received since the last reputation update
+	latencyCount  uint64
+	servedBytes   uint64        // Size of the responses received since the last reputation update
+	egress        *rate.Limiter // Limits the bytes sent to the peer, unlimited if nil
 	height        uint64
 	rw            p2p.MsgReadWriter
 
@@ -67,6 +77,12 @@ type PeerInfo struct {
 	// if this queue is full (means peer is slow) - old messages will be dropped
 	// channel closed on peer remove
 	tasks chan func()
+	// requests are executed by the same worker before the tasks, they are never dropped,
+	// the peer is disconnected instead when it does not keep up with them
+	requests      []func()
+	requestsBytes []int
+	requestsSize  int
+	requestsReady chan struct{}
 }
 
 type PeerRef struct {
@@ -113,15 +129,26 @@ func (bp *PeersByMinBlock) Pop() interface{} {
 func NewPeerInfo(peer *p2p.Peer, rw p2p.MsgReadWriter) *PeerInfo {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	p := &PeerInfo{peer: peer, rw: rw, removed: make(chan struct{}), tasks: make(chan func(), 16), ctx: ctx, ctxCancel: cancel}
+	p := &PeerInfo{peer: peer, rw: rw, removed: make(chan struct{}), tasks: make(chan func(), 16), requestsReady: make(chan struct{}, 1), ctx: ctx, ctxCancel: cancel}
 
 	p.lock.RLock()
 	t := p.tasks
 	p.lock.RUnlock()
 
 	go func() { // each peer has own worker, then slow
-		for f := range t {
-			f()
+		for {
+			if f := p.nextRequest(); f != nil {
+				f()
+				continue
+			}
+			select {
+			case <-p.requestsReady:
+			case f, ok := <-t:
+				if !ok {
+					return
+				}
+				f()
+			}
 		}
 	}()
 	return p
@@ -141,13 +168,14 @@ func (pi *PeerInfo) ID() [64]byte {
 	return pi.peer.Pubkey()
 }
 
-// AddDeadline adds given deadline to the list of deadlines
+// AddDeadline adds given deadline of a request sent at the given time to the list of deadlines
 // Deadlines must be added in the chronological order for the function
 // ClearDeadlines to work correctly (it uses binary search)
-func (pi *PeerInfo) AddDeadline(deadline time.Time) {
+func (pi *PeerInfo) AddDeadline(requested, deadline time.Time) {
 	pi.lock.Lock()
 	defer pi.lock.Unlock()
 	pi.deadlines = append(pi.deadlines, deadline)
+	pi.requested = append(pi.requested, requested)
 	pi.latestDealine = deadline
 }
 
@@ -178,12 +206,31 @@ func (pi *PeerInfo) ClearDeadlines(now time.Time, givePermit bool) int {
 	})
 	cutOff := firstNotPassed
 	if cutOff < len(pi.deadlines) && givePermit {
+		pi.latencySum += now.Sub(pi.requested[cutOff])
+		pi.latencyCount++
 		cutOff++
 	}
 	pi.deadlines = pi.deadlines[cutOff:]
+	pi.requested = pi.requested[cutOff:]
 	return len(pi.deadlines)
 }
 
+// AddServedBytes counts the size of a response received from the peer
+func (pi *PeerInfo) AddServedBytes(size uint32) {
+	atomic.AddUint64(&pi.servedBytes, uint64(size))
+}
+
+// takeReputation moves the latency and the served bytes collected since the last call into the reputation
+func (pi *PeerInfo) takeReputation(rep *enode.Reputation) {
+	pi.lock.Lock()
+	defer pi.lock.Unlock()
+	if pi.latencyCount > 0 {
+		rep.AddLatency(pi.latencySum / time.Duration(pi.latencyCount))
+	}
+	pi.latencySum, pi.latencyCount = 0, 0
+	rep.ServedBytes += atomic.SwapUint64(&pi.servedBytes, 0)
+}
+
 func (pi *PeerInfo) LatestDeadline() time.Time {
 	pi.lock.RLock()
 	defer pi.lock.RUnlock()
@@ -227,6 +274,42 @@ func (pi *PeerInfo) Async(f func()) {
 	}
 }
 
+// AsyncRequest queues f for the worker of the peer like Async, but f is run before the tasks and never dropped.
+// size is the number of bytes f sends, false is returned when the queue of the peer is full and f is not queued
+func (pi *PeerInfo) AsyncRequest(size int, f func()) bool {
+	pi.lock.Lock()
+	defer pi.lock.Unlock()
+	if pi.tasks == nil || pi.Removed() {
+		return true
+	}
+	if len(pi.requests) >= maxQueuedRequests || pi.requestsSize+size > maxQueuedRequestBytes {
+		return false
+	}
+	pi.requests = append(pi.requests, f)
+	pi.requestsBytes = append(pi.requestsBytes, size)
+	pi.requestsSize += size
+	select {
+	case pi.requestsReady <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// nextRequest takes the oldest queued request, if any
+func (pi *PeerInfo) nextRequest() func() {
+	pi.lock.Lock()
+	defer pi.lock.Unlock()
+	if len(pi.requests) == 0 {
+		return nil
+	}
+	f := pi.requests[0]
+	pi.requests[0] = nil
+	pi.requests = pi.requests[1:]
+	pi.requestsSize -= pi.requestsBytes[0]
+	pi.requestsBytes = pi.requestsBytes[1:]
+	return f
+}
+
 func (pi *PeerInfo) Removed() bool {
 	select {
 	case <-pi.removed:
@@ -245,7 +328,7 @@ func ConvertH512ToPeerID(h512 *proto_types.H512) [64]byte {
 func makeP2PServer(
 	p2pConfig p2p.Config,
 	genesisHash common.Hash,
-	protocol p2p.Protocol,
+	protocols []p2p.Protocol,
 ) (*p2p.Server, error) {
 	var urls []string
 	chainConfig := params.ChainConfigByGenesisHash(genesisHash)
@@ -260,7 +343,7 @@ func makeP2PServer(
 		p2pConfig.BootstrapNodes = bootstrapNodes
 		p2pConfig.BootstrapNodesV5 = bootstrapNodes
 	}
-	p2pConfig.Protocols = []p2p.Protocol{protocol}
+	p2pConfig.Protocols = protocols
 	return &p2p.Server{Config: p2pConfig}, nil
 }
 
@@ -473,6 +556,19 @@ func runPeer(
 			if _, err := io.ReadFull(msg.Payload, b); err != nil {
 				log.Error(fmt.Sprintf("%s: reading msg into bytes: %v", peerID, err))
 			}
+			// eth/68 announcements carry transaction types and sizes, which must line up with the hashes.
+			// They are passed on as is, the txpool decides which transactions to fetch.
+			if protocol >= eth.ETH68 {
+				var announcement eth.NewPooledTransactionHashesPacket68
+				if err := rlp.DecodeBytes(b, &announcement); err != nil {
+					msg.Discard()
+					return fmt.Errorf("decoding NewPooledTransactionHashesMsg from %s: %w", peerID, err)
+				}
+				if err := announcement.Validate(); err != nil {
+					msg.Discard()
+					return fmt.Errorf("%s: %w", peerID, err)
+				}
+			}
 			send(eth.ToProto[protocol][msg.Code], peerID, b)
 		case eth.GetPooledTransactionsMsg:
 			if !hasSubscribers(eth.ToProto[protocol][msg.Code]) {
@@ -511,6 +607,9 @@ func runPeer(
 			log.Error(fmt.Sprintf("[p2p] Unknown message code: %d, peerID=%x", msg.Code, peerID))
 		}
 		msg.Discard()
+		if givePermit {
+			peerInfo.AddServedBytes(msg.Size)
+		}
 		peerInfo.ClearDeadlines(time.Now(), givePermit)
 	}
 }
@@ -553,8 +652,9 @@ func NewGrpcServer(ctx context.Context, dialCandidates enode.Iterator, readNodeI
 		p2p:          cfg,
 		peersStreams: NewPeersStreams(),
 	}
+	ss.EgressLimiter = NewEgressLimiter(cfg.MaxEgressRate)
 
-	if protocol != eth.ETH66 && protocol != eth.ETH67 {
+	if protocol != eth.ETH66 && protocol != eth.ETH67 && protocol != eth.ETH68 {
 		panic(fmt.Errorf("unexpected p2p protocol: %d", protocol))
 	}
 
@@ -573,6 +673,7 @@ func NewGrpcServer(ctx context.Context, dialCandidates enode.Iterator, readNodeI
 			log.Trace(fmt.Sprintf("[%s] Start with peer", printablePeerID))
 
 			peerInfo := NewPeerInfo(peer, rw)
+			peerInfo.egress = NewEgressLimiter(cfg.MaxPeerEgressRate)
 			defer peerInfo.Close()
 
 			defer ss.GoodPeers.Delete(peerID)
@@ -597,6 +698,7 @@ func NewGrpcServer(ctx context.Context, dialCandidates enode.Iterator, readNodeI
 			) // runPeer never returns a nil error
 			log.Trace(fmt.Sprintf("[%s] Error while running peer: %v", printablePeerID, err))
 			ss.sendGonePeerToClients(gointerfaces.ConvertHashToH512(peerID))
+			ss.updateReputation(peerID, peerInfo.takeReputation)
 			return nil
 		},
 		NodeInfo: func() interface{} {
@@ -608,6 +710,7 @@ func NewGrpcServer(ctx context.Context, dialCandidates enode.Iterator, readNodeI
 		},
 		//Attributes: []enr.Entry{eth.CurrentENREntry(chainConfig, genesisHash, headHeight)},
 	}
+	go ss.reputationsLoop(ctx)
 
 	return ss
 }
@@ -617,6 +720,13 @@ func Sentry(ctx context.Context, dirs datadir.Dirs, sentryAddr string, discovery
 	dir.MustExist(dirs.DataDir)
 	sentryServer := NewGrpcServer(ctx, nil, func() *eth.NodeInfo { return nil }, cfg, protocolVersion)
 	sentryServer.discoveryDNS = discoveryDNS
+	if cfg.CaptureDir != "" {
+		capture, err := OpenCapture(cfg.CaptureDir, cfg.CaptureFileSize, cfg.CaptureFiles)
+		if err != nil {
+			return fmt.Errorf("opening the capture: %w", err)
+		}
+		sentryServer.Capture = capture
+	}
 
 	grpcServer, err := grpcSentryServer(ctx, sentryAddr, sentryServer, healthCheck)
 	if err != nil {
@@ -633,6 +743,9 @@ type GrpcServer struct {
 	proto_sentry.UnimplementedSentryServer
 	ctx                  context.Context
 	Protocol             p2p.Protocol
+	SatelliteProtocols   []p2p.Protocol // Run next to eth by the same p2p server, such as snap
+	EgressLimiter        *rate.Limiter  // Limits the bytes sent to all peers, may be shared by several sentries
+	Capture              *Capture       // Writes the messages of the peers to files if not nil, may be shared by several sentries
 	discoveryDNS         []string
 	GoodPeers            sync.Map
 	statusData           *proto_sentry.StatusData
@@ -676,26 +789,71 @@ func (ss *GrpcServer) removePeer(peerID [64]byte) {
 	}
 }
 
+// gossipMsg reports whether the message is an announcement or a broadcast, which may be dropped for slow peers
+// and under the egress limits. Requests and responses of the core are always sent.
+func gossipMsg(msgcode uint64) bool {
+	switch msgcode {
+	case eth.NewBlockHashesMsg, eth.NewBlockMsg, eth.TransactionsMsg, eth.NewPooledTransactionHashesMsg:
+		return true
+	default:
+		return false
+	}
+}
+
 func (ss *GrpcServer) writePeer(logPrefix string, peerInfo *PeerInfo, msgcode uint64, data []byte, ttl time.Duration) {
-	peerInfo.Async(func() {
-		err := peerInfo.rw.WriteMsg(p2p.Msg{Code: msgcode, Size: uint32(len(data)), Payload: bytes.NewReader(data)})
-		if err != nil {
+	msgID := eth.ToProto[ss.Protocol.Version][msgcode]
+	if !gossipMsg(msgcode) {
+		// Requests wait for the egress limits in their own queue, so that they are not dropped with the gossip
+		queued := peerInfo.AsyncRequest(len(data), func() {
+			start := time.Now()
+			if err := waitEgress(peerInfo.ctx, len(data), peerInfo.egress, ss.EgressLimiter); err != nil {
+				return // The peer is gone
+			}
+			countEgress(msgID, len(data), time.Since(start))
+			ss.write(logPrefix, peerInfo, msgcode, data, ttl)
+		})
+		if !queued {
+			// The peer does not keep up with its requests and responses, it is disconnected rather than buffering them
+			log.Debug(logPrefix+" too many queued requests, disconnecting the peer", "name", peerInfo.peer.Name(), "msgcode", msgcode)
 			peerInfo.Remove()
 			ss.GoodPeers.Delete(peerInfo.ID())
-			if !errors.Is(err, p2p.ErrShuttingDown) {
-				log.Debug(logPrefix, "msgcode", msgcode, "err", err)
-			}
-		} else {
-			if ttl > 0 {
-				peerInfo.AddDeadline(time.Now().Add(ttl))
-			}
 		}
+		return
+	}
+	// Gossip is throttled before it is queued, what the egress limits do not allow now is dropped
+	if !allowEgress(len(data), peerInfo.egress, ss.EgressLimiter) {
+		countEgressDropped(msgID)
+		return
+	}
+	peerInfo.Async(func() {
+		countEgress(msgID, len(data), 0)
+		ss.write(logPrefix, peerInfo, msgcode, data, ttl)
 	})
 }
 
+// write sends the message to the peer from its worker, the peer is removed if it fails
+func (ss *GrpcServer) write(logPrefix string, peerInfo *PeerInfo, msgcode uint64, data []byte, ttl time.Duration) {
+	err := peerInfo.rw.WriteMsg(p2p.Msg{Code: msgcode, Size: uint32(len(data)), Payload: bytes.NewReader(data)})
+	if (err == nil) && (ss.Capture != nil) {
+		ss.Capture.Write(false, peerInfo.ID(), eth.ToProto[ss.Protocol.Version][msgcode], data)
+	}
+	if err != nil {
+		peerInfo.Remove()
+		ss.GoodPeers.Delete(peerInfo.ID())
+		if !errors.Is(err, p2p.ErrShuttingDown) {
+			log.Debug(logPrefix, "msgcode", msgcode, "err", err)
+		}
+	} else {
+		if ttl > 0 {
+			now := time.Now()
+			peerInfo.AddDeadline(now, now.Add(ttl))
+		}
+	}
+}
+
 func (ss *GrpcServer) startSync(ctx context.Context, bestHash common.Hash, peerID [64]byte) error {
 	switch ss.Protocol.Version {
-	case eth.ETH66, eth.ETH67:
+	case eth.ETH66, eth.ETH67, eth.ETH68:
 		b, err := rlp.EncodeToBytes(&eth.GetBlockHeadersPacket66{
 			RequestId: rand.Uint64(), // nolint: gosec
 			GetBlockHeadersPacket: &eth.GetBlockHeadersPacket{
@@ -721,10 +879,57 @@ func (ss *GrpcServer) startSync(ctx context.Context, bestHash common.Hash, peerI
 	return nil
 }
 
+// updateReputation queues update to the reputation of the peer kept in the node database, once the p2p server runs.
+// The node database applies it in memory at once, so a ban stops the peer from being redialed before the write.
+func (ss *GrpcServer) updateReputation(peerID [64]byte, update func(rep *enode.Reputation)) {
+	if ss.P2pServer == nil || ss.P2pServer.NodeDB() == nil {
+		return
+	}
+	ss.P2pServer.NodeDB().QueueReputationUpdate(peerIDToNodeID(peerID), update)
+}
+
+// flushReputations writes the queued reputation updates to the node database in one transaction
+func (ss *GrpcServer) flushReputations() {
+	if ss.P2pServer == nil || ss.P2pServer.NodeDB() == nil {
+		return
+	}
+	if err := ss.P2pServer.NodeDB().FlushReputations(); err != nil {
+		log.Debug("Failed to update peer reputations", "err", err)
+	}
+}
+
+// reputation returns the reputation of the peer kept in the node database
+func (ss *GrpcServer) reputation(id enode.ID) (enode.Reputation, bool) {
+	if ss.P2pServer == nil || ss.P2pServer.NodeDB() == nil {
+		return enode.Reputation{}, false
+	}
+	return ss.P2pServer.NodeDB().Reputation(id), true
+}
+
+// reputationsLoop periodically writes the queued reputation updates
+func (ss *GrpcServer) reputationsLoop(ctx context.Context) {
+	ticker := time.NewTicker(reputationFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ss.flushReputations()
+		}
+	}
+}
+
+// peerIDToNodeID converts the public key of a peer into its node ID
+func peerIDToNodeID(peerID [64]byte) enode.ID {
+	return enode.ID(crypto.Keccak256Hash(peerID[:]))
+}
+
 func (ss *GrpcServer) PenalizePeer(_ context.Context, req *proto_sentry.PenalizePeerRequest) (*emptypb.Empty, error) {
 	//log.Warn("Received penalty", "kind", req.GetPenalty().Descriptor().FullName, "from", fmt.Sprintf("%s", req.GetPeerId()))
 	peerID := ConvertH512ToPeerID(req.PeerId)
 	ss.removePeer(peerID)
+	ss.updateReputation(peerID, func(rep *enode.Reputation) { rep.Penalties++ })
 	return &emptypb.Empty{}, nil
 }
 
@@ -739,6 +944,7 @@ func (ss *GrpcServer) PeerMinBlock(_ context.Context, req *proto_sentry.PeerMinB
 func (ss *GrpcServer) PeerUseless(_ context.Context, req *proto_sentry.PeerUselessRequest) (*emptypb.Empty, error) {
 	peerID := ConvertH512ToPeerID(req.PeerId)
 	peerInfo := ss.getPeer(peerID)
+	ss.updateReputation(peerID, func(rep *enode.Reputation) { rep.Useless++ })
 	if ss.statusData != nil && !ss.statusData.PassivePeers && peerInfo != nil && !peerInfo.peer.Info().Network.Static && !peerInfo.peer.Info().Network.Trusted {
 		ss.removePeer(peerID)
 		log.Debug("Removed useless peer", "peerId", fmt.Sprintf("%x", peerID), "name", peerInfo.peer.Name())
@@ -918,6 +1124,8 @@ func (ss *GrpcServer) HandShake(context.Context, *emptypb.Empty) (*proto_sentry.
 		reply.Protocol = proto_sentry.Protocol_ETH66
 	case eth.ETH67:
 		reply.Protocol = proto_sentry.Protocol_ETH67
+	case eth.ETH68:
+		reply.Protocol = proto_sentry.Protocol_ETH68
 	}
 	return reply, nil
 }
@@ -943,7 +1151,7 @@ func (ss *GrpcServer) SetStatus(ctx context.Context, statusData *proto_sentry.St
 			}
 		}
 
-		srv, err := makeP2PServer(*ss.p2p, genesisHash, ss.Protocol)
+		srv, err := makeP2PServer(*ss.p2p, genesisHash, append([]p2p.Protocol{ss.Protocol}, ss.SatelliteProtocols...))
 		if err != nil {
 			return reply, err
 		}
@@ -988,12 +1196,25 @@ func (ss *GrpcServer) Peers(_ context.Context, _ *emptypb.Empty) (*proto_sentry.
 			ConnIsTrusted:  peer.Network.Trusted,
 			ConnIsStatic:   peer.Network.Static,
 		}
+		if id, err := enode.ParseID(peer.ID); err == nil {
+			if rep, ok := ss.reputation(id); ok {
+				setPeerReputation(&rpcPeer, rep)
+			}
+		}
 		reply.Peers = append(reply.Peers, &rpcPeer)
 	}
 
 	return &reply, nil
 }
 
+func setPeerReputation(rpcPeer *proto_types.PeerInfo, rep enode.Reputation) {
+	rpcPeer.Score = rep.Score()
+	rpcPeer.Penalties = rep.Penalties
+	rpcPeer.Useless = rep.Useless
+	rpcPeer.LatencyMs = rep.LatencyMs
+	rpcPeer.ServedBytes = rep.ServedBytes
+}
+
 func (ss *GrpcServer) SimplePeerCount() (pc int) {
 	ss.rangePeers(func(peerInfo *PeerInfo) bool {
 		pc++
@@ -1026,6 +1247,9 @@ func (ss *GrpcServer) PeerById(_ context.Context, req *proto_sentry.PeerByIdRequ
 			ConnIsTrusted:  peer.Network.Trusted,
 			ConnIsStatic:   peer.Network.Static,
 		}
+		if rep, ok := ss.reputation(sentryPeer.peer.ID()); ok {
+			setPeerReputation(rpcPeer, rep)
+		}
 	}
 
 	return &proto_sentry.PeerByIdReply{Peer: rpcPeer}, nil
@@ -1048,6 +1272,9 @@ func (ss *GrpcServer) GetStatus() *proto_sentry.StatusData {
 }
 
 func (ss *GrpcServer) send(msgID proto_sentry.MessageId, peerID [64]byte, b []byte) {
+	if ss.Capture != nil {
+		ss.Capture.Write(true, peerID, msgID, b)
+	}
 	ss.messageStreamsLock.RLock()
 	defer ss.messageStreamsLock.RUnlock()
 	req := &proto_sentry.InboundMessage{
@@ -1130,9 +1357,15 @@ func (ss *GrpcServer) Messages(req *proto_sentry.MessagesRequest, server proto_s
 
 // Close performs cleanup operations for the sentry
 func (ss *GrpcServer) Close() {
+	ss.flushReputations()
 	if ss.P2pServer != nil {
 		ss.P2pServer.Stop()
 	}
+	if ss.Capture != nil {
+		if err := ss.Capture.Close(); err != nil {
+			log.Warn("[sentry] Closing the capture", "err", err)
+		}
+	}
 }
 
 func (ss *GrpcServer) sendNewPeerToClients(peerID *proto_types.H512) {
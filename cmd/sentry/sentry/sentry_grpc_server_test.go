@@ -9,16 +9,21 @@ import (
 	"github.com/holiman/uint256"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces"
 	proto_sentry "github.com/ledgerwatch/erigon-lib/gointerfaces/sentry"
+	proto_types "github.com/ledgerwatch/erigon-lib/gointerfaces/types"
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	types2 "github.com/ledgerwatch/erigon-lib/types"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/ledgerwatch/erigon/common"
 	"github.com/ledgerwatch/erigon/core"
 	"github.com/ledgerwatch/erigon/core/forkid"
 	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types"
 	"github.com/ledgerwatch/erigon/eth/protocols/eth"
 	"github.com/ledgerwatch/erigon/p2p"
+	"github.com/ledgerwatch/erigon/p2p/enode"
 	"github.com/ledgerwatch/erigon/params"
 )
 
@@ -180,3 +185,146 @@ func TestSentryServerImpl_SetStatusInitPanic(t *testing.T) {
 		t.Fatalf("error expected")
 	}
 }
+
+func TestPeerInfoReputation(t *testing.T) {
+	pi := &PeerInfo{}
+	start := time.Now()
+	pi.AddDeadline(start, start.Add(time.Minute))
+	pi.AddDeadline(start.Add(time.Second), start.Add(time.Second+time.Minute))
+	pi.AddServedBytes(1000)
+	require.Equal(t, 1, pi.ClearDeadlines(start.Add(2*time.Second), true))
+	pi.AddServedBytes(500)
+	require.Equal(t, 0, pi.ClearDeadlines(start.Add(5*time.Second), true))
+
+	rep := enode.Reputation{Penalties: 1}
+	pi.takeReputation(&rep)
+	require.Equal(t, enode.Reputation{Penalties: 1, LatencyMs: 3000, ServedBytes: 1500}, rep)
+	// Nothing is counted twice
+	pi.takeReputation(&rep)
+	require.Equal(t, enode.Reputation{Penalties: 1, LatencyMs: 3000, ServedBytes: 1500}, rep)
+}
+
+func TestSetPeerReputation(t *testing.T) {
+	rep := enode.Reputation{Penalties: 6, Useless: 1, LatencyMs: 250, ServedBytes: 1 << 20}
+	var rpcPeer proto_types.PeerInfo
+	setPeerReputation(&rpcPeer, rep)
+	b, err := proto.Marshal(&rpcPeer)
+	require.NoError(t, err)
+
+	var decoded proto_types.PeerInfo
+	require.NoError(t, proto.Unmarshal(b, &decoded))
+	require.Equal(t, rep.Score(), decoded.Score)
+	require.Less(t, decoded.Score, int64(0))
+	require.Equal(t, rep.Penalties, decoded.Penalties)
+	require.Equal(t, rep.Useless, decoded.Useless)
+	require.Equal(t, rep.LatencyMs, decoded.LatencyMs)
+	require.Equal(t, rep.ServedBytes, decoded.ServedBytes)
+}
+
+func TestHandShake68(t *testing.T) {
+	ss := &GrpcServer{Protocol: p2p.Protocol{Version: eth.ETH68}}
+	reply, err := ss.HandShake(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, proto_sentry.Protocol_ETH68, reply.Protocol)
+
+	// eth/68 peers are announced transactions with their types and sizes only
+	_, err = ss.SendMessageToAll(context.Background(), &proto_sentry.OutboundMessageData{Id: proto_sentry.MessageId_NEW_POOLED_TRANSACTION_HASHES_68})
+	require.NoError(t, err)
+	_, err = ss.SendMessageToAll(context.Background(), &proto_sentry.OutboundMessageData{Id: proto_sentry.MessageId_NEW_POOLED_TRANSACTION_HASHES_66})
+	require.Error(t, err)
+}
+
+func TestRunPeerAnnouncements68(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rw, peerRw := p2p.MsgPipe()
+	defer rw.Close()
+	peerID := [64]byte{1}
+	peerInfo := NewPeerInfo(p2p.NewPeer(enode.ID{1}, peerID, "test", nil), rw)
+	defer peerInfo.Close()
+
+	type inbound struct {
+		id   proto_sentry.MessageId
+		data []byte
+	}
+	received := make(chan inbound, 1)
+	send := func(id proto_sentry.MessageId, _ [64]byte, b []byte) { received <- inbound{id, b} }
+	errc := make(chan error, 1)
+	go func() {
+		errc <- runPeer(ctx, peerID, eth.ETH68, rw, peerInfo, send, func(proto_sentry.MessageId) bool { return true })
+	}()
+
+	// Announcements are passed on with the types and sizes, the txpool decides what to fetch
+	announcement := &eth.NewPooledTransactionHashesPacket68{
+		Types:  []byte{types.LegacyTxType, types.DynamicFeeTxType, types.DynamicFeeTxType},
+		Sizes:  []uint32{200, 300, 1 << 20},
+		Hashes: []common.Hash{{1}, {2}, {3}},
+	}
+	require.NoError(t, p2p.Send(peerRw, eth.NewPooledTransactionHashesMsg, announcement))
+	msg := <-received
+	require.Equal(t, proto_sentry.MessageId_NEW_POOLED_TRANSACTION_HASHES_68, msg.id)
+	announcedTypes, announcedSizes, announcedHashes, _, err := types2.ParseAnnouncements(msg.data, 0)
+	require.NoError(t, err)
+	require.Equal(t, announcement.Types, announcedTypes)
+	require.Equal(t, announcement.Sizes, announcedSizes)
+	for i, hash := range announcement.Hashes {
+		require.Equal(t, hash[:], announcedHashes[i*32:(i+1)*32])
+	}
+
+	// Metadata not matching the hashes drops the peer
+	require.NoError(t, p2p.Send(peerRw, eth.NewPooledTransactionHashesMsg, &eth.NewPooledTransactionHashesPacket68{
+		Types:  []byte{types.LegacyTxType},
+		Hashes: []common.Hash{{1}},
+	}))
+	require.ErrorContains(t, <-errc, "invalid announcement")
+}
+
+func TestWritePeerThrottledRequests(t *testing.T) {
+	rw, peerRw := p2p.MsgPipe()
+	defer rw.Close()
+	peerInfo := NewPeerInfo(p2p.NewPeer(enode.ID{1}, [64]byte{1}, "test", nil), rw)
+	defer peerInfo.Close()
+	peerInfo.egress = NewEgressLimiter(10_000)
+	ss := &GrpcServer{Protocol: p2p.Protocol{Version: eth.ETH68}}
+
+	// More requests than the task queue holds, and twice the burst of the limiter, none of them is dropped
+	const requests = 20
+	for i := 0; i < requests; i++ {
+		data := make([]byte, 1000)
+		data[0] = byte(i)
+		ss.writePeer("test", peerInfo, eth.GetBlockHeadersMsg, data, 0)
+	}
+	for i := 0; i < requests; i++ {
+		msg, err := peerRw.ReadMsg()
+		require.NoError(t, err)
+		require.Equal(t, uint64(eth.GetBlockHeadersMsg), msg.Code)
+		require.Equal(t, uint32(1000), msg.Size)
+		var first [1]byte
+		_, err = msg.Payload.Read(first[:])
+		require.NoError(t, err)
+		require.Equal(t, byte(i), first[0])
+		msg.Discard()
+	}
+}
+
+func TestWritePeerRequestQueueFull(t *testing.T) {
+	rw, _ := p2p.MsgPipe()
+	defer rw.Close()
+	peerInfo := NewPeerInfo(p2p.NewPeer(enode.ID{1}, [64]byte{1}, "test", nil), rw)
+	defer peerInfo.Close()
+	ss := &GrpcServer{Protocol: p2p.Protocol{Version: eth.ETH68}}
+	ss.GoodPeers.Store(peerInfo.ID(), peerInfo)
+
+	// The peer does not read, so the responses pile up until the queue is over its size and the peer is dropped,
+	// one of them may be taken by the worker which is stuck writing it
+	data := make([]byte, 2*1024*1024)
+	for i := 0; i < maxQueuedRequestBytes/len(data); i++ {
+		ss.writePeer("test", peerInfo, eth.BlockBodiesMsg, data, 0)
+	}
+	require.False(t, peerInfo.Removed())
+	ss.writePeer("test", peerInfo, eth.BlockBodiesMsg, data, 0)
+	ss.writePeer("test", peerInfo, eth.BlockBodiesMsg, data, 0)
+	require.True(t, peerInfo.Removed())
+	_, ok := ss.GoodPeers.Load(peerInfo.ID())
+	require.False(t, ok)
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/ledgerwatch/erigon/common"
 	"github.com/ledgerwatch/erigon/consensus"
 	"github.com/ledgerwatch/erigon/core/forkid"
+	"github.com/ledgerwatch/erigon/core/rawdb"
 	"github.com/ledgerwatch/erigon/core/types"
 	"github.com/ledgerwatch/erigon/eth/ethconfig"
 	"github.com/ledgerwatch/erigon/eth/protocols/eth"
@@ -266,7 +267,8 @@ type MultiClient struct {
 	logPeerInfo   bool
 	passivePeers  bool
 
-	historyV3 bool
+	historyV3         bool
+	serveRecentBlocks uint64 // Bodies and receipts of older blocks are not served, 0 serves all
 }
 
 func NewMultiClient(
@@ -300,17 +302,18 @@ func NewMultiClient(
 	bd := bodydownload.NewBodyDownload(syncCfg.BlockDownloaderWindow /* outstandingLimit */, engine)
 
 	cs := &MultiClient{
-		nodeName:      nodeName,
-		Hd:            hd,
-		Bd:            bd,
-		sentries:      sentries,
-		db:            db,
-		Engine:        engine,
-		blockReader:   blockReader,
-		logPeerInfo:   logPeerInfo,
-		forkValidator: forkValidator,
-		historyV3:     historyV3,
-		passivePeers:  chainConfig.TerminalTotalDifficultyPassed,
+		nodeName:          nodeName,
+		Hd:                hd,
+		Bd:                bd,
+		sentries:          sentries,
+		db:                db,
+		Engine:            engine,
+		blockReader:       blockReader,
+		logPeerInfo:       logPeerInfo,
+		forkValidator:     forkValidator,
+		historyV3:         historyV3,
+		serveRecentBlocks: syncCfg.ServeRecentBlocks,
+		passivePeers:      chainConfig.TerminalTotalDifficultyPassed,
 	}
 	cs.ChainConfig = chainConfig
 	cs.heightForks, cs.timeForks = forkid.GatherForks(cs.ChainConfig)
@@ -620,7 +623,7 @@ func (cs *MultiClient) getBlockBodies66(ctx context.Context, inreq *proto_sentry
 		return err
 	}
 	defer tx.Rollback()
-	response := eth.AnswerGetBlockBodiesQuery(tx, query.GetBlockBodiesPacket)
+	response := eth.AnswerGetBlockBodiesQuery(tx, query.GetBlockBodiesPacket, cs.minServedBlock(tx))
 	tx.Rollback()
 	b, err := rlp.EncodeToBytes(&eth.BlockBodiesRLPPacket66{
 		RequestId:            query.RequestId,
@@ -647,6 +650,18 @@ func (cs *MultiClient) getBlockBodies66(ctx context.Context, inreq *proto_sentry
 	return nil
 }
 
+// minServedBlock returns the lowest block whose body and receipts are served to peers
+func (cs *MultiClient) minServedBlock(tx kv.Tx) uint64 {
+	if cs.serveRecentBlocks == 0 {
+		return 0
+	}
+	head := rawdb.ReadCurrentBlockNumber(tx)
+	if head == nil || *head < cs.serveRecentBlocks {
+		return 0
+	}
+	return *head - cs.serveRecentBlocks + 1
+}
+
 func (cs *MultiClient) getReceipts66(ctx context.Context, inreq *proto_sentry.InboundMessage, sentry direct.SentryClient) error {
 	if cs.historyV3 { // historyV3 doesn't store receipts in DB
 		return nil
@@ -661,7 +676,7 @@ func (cs *MultiClient) getReceipts66(ctx context.Context, inreq *proto_sentry.In
 		return err
 	}
 	defer tx.Rollback()
-	receipts, err := eth.AnswerGetReceiptsQuery(tx, query.GetReceiptsPacket)
+	receipts, err := eth.AnswerGetReceiptsQuery(tx, query.GetReceiptsPacket, cs.minServedBlock(tx))
 	if err != nil {
 		return err
 	}
@@ -480,6 +480,36 @@ var (
 		Usage: "Allowed ports to pick for different eth p2p protocol versions as follows <porta>,<portb>,..,<porti>",
 		Value: cli.NewUintSlice(uint(ListenPortFlag.Value), 30304, 30305, 30306, 30307),
 	}
+	P2pSnapServerFlag = cli.BoolFlag{
+		Name:  "p2p.snap-server",
+		Usage: "Serve the snap/1 protocol from the latest state. Not available with external sentries (--sentry.api.addr)",
+	}
+	P2pEgressRateFlag = cli.StringFlag{
+		Name:  "p2p.egress.rate",
+		Usage: "Limit of the bytes per second sent to all peers, example: 32mb. Unlimited if not set",
+	}
+	P2pPeerEgressRateFlag = cli.StringFlag{
+		Name:  "p2p.egress.peer-rate",
+		Usage: "Limit of the bytes per second sent to any one peer, example: 1mb. Unlimited if not set",
+	}
+	P2pCaptureDirFlag = cli.StringFlag{
+		Name:  "p2p.capture.dir",
+		Usage: "Write the eth protocol messages sent to and received from the peers to files in this directory, for debugging and replay. Disabled if not set",
+	}
+	P2pCaptureFileSizeFlag = cli.StringFlag{
+		Name:  "p2p.capture.file-size",
+		Usage: "Start a new capture file when the current one reaches this size",
+		Value: "256mb",
+	}
+	P2pCaptureFilesFlag = cli.IntFlag{
+		Name:  "p2p.capture.files",
+		Usage: "Number of the most recent capture files to keep, 0 keeps all files",
+		Value: 16,
+	}
+	P2pServeRecentBlocksFlag = cli.Uint64Flag{
+		Name:  "p2p.serve-recent-blocks",
+		Usage: "Only serve the bodies and receipts of this many most recent blocks to peers, 0 serves all blocks",
+	}
 	SentryAddrFlag = cli.StringFlag{
 		Name:  "sentry.api.addr",
 		Usage: "comma separated sentry addresses '<host>:<port>,<host>:<port>'",
@@ -537,6 +567,11 @@ var (
 		Name:  "v5disc",
 		Usage: "Enables the experimental RLPx V5 (Topic Discovery) mechanism",
 	}
+	DiscoveryV5TopicsFlag = cli.StringFlag{
+		Name:  "v5disc.topics",
+		Usage: "Comma separated topics to advertise and search with the V5 discovery, the nodes found by topic are dialed (requires --v5disc)",
+		Value: "",
+	}
 	NetrestrictFlag = cli.StringFlag{
 		Name:  "netrestrict",
 		Usage: "Restricts network communication to the given IP networks (CIDR masks)",
@@ -871,6 +906,8 @@ func NewP2PConfig(
 		enodeDBPath = filepath.Join(dirs.Nodes, "eth66")
 	case eth.ETH67:
 		enodeDBPath = filepath.Join(dirs.Nodes, "eth67")
+	case eth.ETH68:
+		enodeDBPath = filepath.Join(dirs.Nodes, "eth68")
 	default:
 		return nil, fmt.Errorf("unknown protocol: %v", protocol)
 	}
@@ -926,6 +963,18 @@ func nodeKey(datadir string) (*ecdsa.PrivateKey, error) {
 	return config.LoadOrGenerateAndSave(keyfile)
 }
 
+// ParseByteRate parses bytes per second such as 32mb, an empty value means unlimited and gives 0.
+func ParseByteRate(s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	var rate datasize.ByteSize
+	if err := rate.UnmarshalText([]byte(s)); err != nil {
+		return 0, err
+	}
+	return rate.Bytes(), nil
+}
+
 // setListenAddress creates a TCP listening address string from set command
 // line flags.
 func setListenAddress(ctx *cli.Context, cfg *p2p.Config) {
@@ -938,6 +987,19 @@ func setListenAddress(ctx *cli.Context, cfg *p2p.Config) {
 	if ctx.IsSet(SentryAddrFlag.Name) {
 		cfg.SentryAddr = SplitAndTrim(ctx.String(SentryAddrFlag.Name))
 	}
+	cfg.SnapServer = ctx.Bool(P2pSnapServerFlag.Name)
+	var err error
+	if cfg.MaxEgressRate, err = ParseByteRate(ctx.String(P2pEgressRateFlag.Name)); err != nil {
+		Fatalf("Option %s: %v", P2pEgressRateFlag.Name, err)
+	}
+	if cfg.MaxPeerEgressRate, err = ParseByteRate(ctx.String(P2pPeerEgressRateFlag.Name)); err != nil {
+		Fatalf("Option %s: %v", P2pPeerEgressRateFlag.Name, err)
+	}
+	cfg.CaptureDir = ctx.String(P2pCaptureDirFlag.Name)
+	if cfg.CaptureFileSize, err = ParseByteRate(ctx.String(P2pCaptureFileSizeFlag.Name)); err != nil {
+		Fatalf("Option %s: %v", P2pCaptureFileSizeFlag.Name, err)
+	}
+	cfg.CaptureFiles = ctx.Int(P2pCaptureFilesFlag.Name)
 	// TODO cli lib doesn't store defaults for UintSlice properly so we have to get value directly
 	cfg.AllowedPorts = P2pProtocolAllowedPorts.Value.Value()
 	if ctx.IsSet(P2pProtocolAllowedPorts.Name) {
@@ -1053,6 +1115,9 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config, nodeName, datadir string) {
 	if ctx.IsSet(DiscoveryV5Flag.Name) {
 		cfg.DiscoveryV5 = ctx.Bool(DiscoveryV5Flag.Name)
 	}
+	if ctx.IsSet(DiscoveryV5TopicsFlag.Name) {
+		cfg.DiscoveryV5Topics = SplitAndTrim(ctx.String(DiscoveryV5TopicsFlag.Name))
+	}
 
 	ethPeers := cfg.MaxPeers
 	cfg.Name = nodeName
@@ -1460,6 +1525,7 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	cfg.SentinelPort = ctx.Uint64(SentinelPortFlag.Name)
 
 	cfg.Sync.UseSnapshots = ctx.Bool(SnapshotFlag.Name)
+	cfg.Sync.ServeRecentBlocks = ctx.Uint64(P2pServeRecentBlocksFlag.Name)
 	cfg.Dirs = nodeConfig.Dirs
 	cfg.Snapshot.KeepBlocks = ctx.Bool(SnapKeepBlocksFlag.Name)
 	cfg.Snapshot.Produce = !ctx.Bool(SnapStopFlag.Name)
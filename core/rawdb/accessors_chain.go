@@ -1730,6 +1730,44 @@ func PruneTableDupSort(tx kv.RwTx, table string, logPrefix string, pruneTo uint6
 	return nil
 }
 
+// PruneTableKeep - deletes entries of blocks [from, pruneTo) of the table keyed by block number, except the ones
+// for which keep returns true. In DupSort tables, each value is checked separately.
+func PruneTableKeep(tx kv.RwTx, table string, logPrefix string, from, pruneTo uint64, logEvery *time.Ticker, ctx context.Context, keep func(k, v []byte) (bool, error)) error {
+	c, err := tx.RwCursor(table)
+	if err != nil {
+		return fmt.Errorf("failed to create cursor for pruning %w", err)
+	}
+	defer c.Close()
+
+	for k, v, err := c.Seek(common2.EncodeTs(from)); k != nil; k, v, err = c.Next() {
+		if err != nil {
+			return fmt.Errorf("failed to move %s cleanup cursor: %w", table, err)
+		}
+		blockNum := binary.BigEndian.Uint64(k)
+		if blockNum >= pruneTo {
+			break
+		}
+		select {
+		case <-logEvery.C:
+			log.Info(fmt.Sprintf("[%s]", logPrefix), "table", table, "block", blockNum)
+		case <-ctx.Done():
+			return common2.ErrStopped
+		default:
+		}
+		kept, err := keep(k, v)
+		if err != nil {
+			return err
+		}
+		if kept {
+			continue
+		}
+		if err = c.DeleteCurrent(); err != nil {
+			return fmt.Errorf("failed to remove for block %d: %w", blockNum, err)
+		}
+	}
+	return nil
+}
+
 type txNums struct{}
 
 var TxNums txNums
@@ -323,6 +323,9 @@ func clearStageProgress(tx kv.RwTx, stagesList ...stages.SyncStage) error {
 		if err := stages.SaveStagePruneProgress(tx, stage, 0); err != nil {
 			return err
 		}
+		if err := stages.SaveStageUnwindLimit(tx, stage, 0); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,217 @@
+package state
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"time"
+
+	"github.com/ledgerwatch/erigon-lib/etl"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/rlp"
+)
+
+// StateExportVersion is the version of the format written by ExportState.
+const StateExportVersion = 1
+
+const stateExportChunkSize = 4096 // entries per chunk
+
+// StateExportTables are the tables of the plain state written by ExportState.
+// The hashed state and the intermediate hashes are rebuilt from them on import.
+var StateExportTables = []string{
+	kv.PlainState,
+	kv.PlainContractCode,
+	kv.Code,
+	kv.IncarnationMap,
+}
+
+// StateExportHeader starts a state export: the block of the state and its state root.
+type StateExportHeader struct {
+	Version   uint64
+	Block     uint64
+	BlockHash common.Hash
+	Root      common.Hash
+}
+
+// stateExportChunk holds consecutive entries of a table. A chunk without a table ends
+// the export, its checksum covers the checksums of all the chunks before it.
+type stateExportChunk struct {
+	Table    string
+	Keys     [][]byte
+	Values   [][]byte
+	Checksum uint32
+}
+
+func (c *stateExportChunk) checksum() uint32 {
+	var lenBuf [4]byte
+	crc := crc32.ChecksumIEEE([]byte(c.Table))
+	for i := range c.Keys {
+		binary.BigEndian.PutUint32(lenBuf[:], uint32(len(c.Keys[i])))
+		crc = crc32.Update(crc, crc32.IEEETable, lenBuf[:])
+		crc = crc32.Update(crc, crc32.IEEETable, c.Keys[i])
+		binary.BigEndian.PutUint32(lenBuf[:], uint32(len(c.Values[i])))
+		crc = crc32.Update(crc, crc32.IEEETable, lenBuf[:])
+		crc = crc32.Update(crc, crc32.IEEETable, c.Values[i])
+	}
+	return crc
+}
+
+func updateTotalChecksum(total, chunk uint32) uint32 {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], chunk)
+	return crc32.Update(total, crc32.IEEETable, buf[:])
+}
+
+// ExportState streams the plain state of tx to w, as the state of the block in the header.
+// The caller is responsible for the state being the one of that block.
+func ExportState(ctx context.Context, logPrefix string, tx kv.Tx, header StateExportHeader, w io.Writer) error {
+	header.Version = StateExportVersion
+	if err := rlp.Encode(w, &header); err != nil {
+		return err
+	}
+
+	logEvery := time.NewTicker(30 * time.Second)
+	defer logEvery.Stop()
+
+	var total uint32
+	for _, table := range StateExportTables {
+		c, err := tx.Cursor(table)
+		if err != nil {
+			return err
+		}
+		var entries uint64
+		chunk := stateExportChunk{Table: table}
+		flush := func() error {
+			chunk.Checksum = chunk.checksum()
+			total = updateTotalChecksum(total, chunk.Checksum)
+			if err := rlp.Encode(w, &chunk); err != nil {
+				return err
+			}
+			chunk.Keys, chunk.Values = chunk.Keys[:0], chunk.Values[:0]
+			return nil
+		}
+		for k, v, err := c.First(); k != nil; k, v, err = c.Next() {
+			if err != nil {
+				c.Close()
+				return err
+			}
+			chunk.Keys = append(chunk.Keys, common.CopyBytes(k))
+			chunk.Values = append(chunk.Values, common.CopyBytes(v))
+			entries++
+			if len(chunk.Keys) < stateExportChunkSize {
+				continue
+			}
+			if err := flush(); err != nil {
+				c.Close()
+				return err
+			}
+
+			select {
+			case <-logEvery.C:
+				log.Info(fmt.Sprintf("[%s] Exporting", logPrefix), "table", table, "entries", entries)
+			case <-ctx.Done():
+				c.Close()
+				return ctx.Err()
+			default:
+			}
+		}
+		c.Close()
+		if len(chunk.Keys) > 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		log.Info(fmt.Sprintf("[%s] Exported", logPrefix), "table", table, "entries", entries)
+	}
+	return rlp.Encode(w, &stateExportChunk{Checksum: total})
+}
+
+// ImportState replaces the plain state of tx with the one read from r, checking the
+// checksums, and returns the header of the export. checkHeader (if not nil) can refuse the
+// export before anything is read. The hashed state and the intermediate hashes are not
+// touched, they have to be rebuilt by the caller.
+func ImportState(ctx context.Context, logPrefix string, tx kv.RwTx, r io.Reader, tmpdir string, checkHeader func(header *StateExportHeader) error) (*StateExportHeader, error) {
+	stream := rlp.NewStream(r, 0)
+	var header StateExportHeader
+	if err := stream.Decode(&header); err != nil {
+		return nil, fmt.Errorf("reading the header: %w", err)
+	}
+	if header.Version != StateExportVersion {
+		return nil, fmt.Errorf("unsupported state export version %d, expected %d", header.Version, StateExportVersion)
+	}
+	if checkHeader != nil {
+		if err := checkHeader(&header); err != nil {
+			return nil, err
+		}
+	}
+
+	collectors := make(map[string]*etl.Collector, len(StateExportTables))
+	for _, table := range StateExportTables {
+		collector := etl.NewCollector(logPrefix, tmpdir, etl.NewSortableBuffer(etl.BufferOptimalSize))
+		defer collector.Close()
+		collectors[table] = collector
+	}
+
+	logEvery := time.NewTicker(30 * time.Second)
+	defer logEvery.Stop()
+
+	var total uint32
+	var entries uint64
+	for {
+		var chunk stateExportChunk
+		if err := stream.Decode(&chunk); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, fmt.Errorf("state export truncated after %d entries", entries)
+			}
+			return nil, err
+		}
+		if chunk.Table == "" {
+			if chunk.Checksum != total {
+				return nil, fmt.Errorf("wrong state export checksum %x, expected %x", chunk.Checksum, total)
+			}
+			break
+		}
+		collector, ok := collectors[chunk.Table]
+		if !ok {
+			return nil, fmt.Errorf("unexpected table %s in the state export", chunk.Table)
+		}
+		if len(chunk.Keys) != len(chunk.Values) {
+			return nil, fmt.Errorf("%d keys and %d values in a chunk of %s", len(chunk.Keys), len(chunk.Values), chunk.Table)
+		}
+		if checksum := chunk.checksum(); checksum != chunk.Checksum {
+			return nil, fmt.Errorf("wrong checksum %x of a chunk of %s, expected %x", checksum, chunk.Table, chunk.Checksum)
+		}
+		total = updateTotalChecksum(total, chunk.Checksum)
+		for i := range chunk.Keys {
+			if err := collector.Collect(chunk.Keys[i], chunk.Values[i]); err != nil {
+				return nil, err
+			}
+		}
+		entries += uint64(len(chunk.Keys))
+
+		select {
+		case <-logEvery.C:
+			log.Info(fmt.Sprintf("[%s] Reading", logPrefix), "table", chunk.Table, "entries", entries)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+	}
+
+	for _, table := range StateExportTables {
+		if err := tx.ClearBucket(table); err != nil {
+			return nil, err
+		}
+		if err := collectors[table].Load(tx, table, etl.IdentityLoadFunc, etl.TransformArgs{Quit: ctx.Done()}); err != nil {
+			return nil, err
+		}
+	}
+	log.Info(fmt.Sprintf("[%s] Imported", logPrefix), "block", header.Block, "entries", entries)
+	return &header, nil
+}
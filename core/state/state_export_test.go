@@ -0,0 +1,81 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/common"
+)
+
+func readTables(t *testing.T, tx kv.Tx) map[string][][2][]byte {
+	t.Helper()
+	tables := make(map[string][][2][]byte)
+	for _, table := range StateExportTables {
+		require.NoError(t, tx.ForEach(table, nil, func(k, v []byte) error {
+			tables[table] = append(tables[table], [2][]byte{common.CopyBytes(k), common.CopyBytes(v)})
+			return nil
+		}))
+	}
+	return tables
+}
+
+func TestStateExportImport(t *testing.T) {
+	ctx := context.Background()
+	_, tx := memdb.NewTestTx(t)
+	for i := 0; i < 3*stateExportChunkSize; i++ {
+		var addr common.Address
+		binary.BigEndian.PutUint32(addr[:], uint32(i+1))
+		require.NoError(t, tx.Put(kv.PlainState, addr[:], []byte{byte(i)}))
+		storageKey := append(append(addr[:], 0, 0, 0, 0, 0, 0, 0, 1), common.Hash{byte(i)}.Bytes()...)
+		require.NoError(t, tx.Put(kv.PlainState, storageKey, []byte{1, byte(i)}))
+	}
+	code := []byte{0x60, 0x00}
+	require.NoError(t, tx.Put(kv.Code, common.Hash{1}.Bytes(), code))
+	require.NoError(t, tx.Put(kv.PlainContractCode, append(common.Address{1}.Bytes(), 0, 0, 0, 0, 0, 0, 0, 1), common.Hash{1}.Bytes()))
+	require.NoError(t, tx.Put(kv.IncarnationMap, common.Address{2}.Bytes(), []byte{0, 0, 0, 0, 0, 0, 0, 2}))
+
+	header := StateExportHeader{Block: 10, BlockHash: common.Hash{10}, Root: common.Hash{11}}
+	var buf bytes.Buffer
+	require.NoError(t, ExportState(ctx, "test", tx, header, &buf))
+	export := buf.Bytes()
+
+	_, tx2 := memdb.NewTestTx(t)
+	require.NoError(t, tx2.Put(kv.PlainState, common.Address{9}.Bytes(), []byte{9})) // replaced by the import
+	imported, err := ImportState(ctx, "test", tx2, bytes.NewReader(export), t.TempDir(), nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(StateExportVersion), imported.Version)
+	require.Equal(t, header.Block, imported.Block)
+	require.Equal(t, header.BlockHash, imported.BlockHash)
+	require.Equal(t, header.Root, imported.Root)
+	require.Equal(t, readTables(t, tx), readTables(t, tx2))
+
+	// A corrupted export is rejected
+	corrupted := common.CopyBytes(export)
+	corrupted[len(corrupted)/2] ^= 0xff
+	_, tx3 := memdb.NewTestTx(t)
+	_, err = ImportState(ctx, "test", tx3, bytes.NewReader(corrupted), t.TempDir(), nil)
+	require.Error(t, err)
+
+	// The header is checked before the state is read
+	_, tx4 := memdb.NewTestTx(t)
+	require.NoError(t, tx4.Put(kv.PlainState, common.Address{9}.Bytes(), []byte{9}))
+	_, err = ImportState(ctx, "test", tx4, bytes.NewReader(export), t.TempDir(), func(h *StateExportHeader) error {
+		require.Equal(t, header.BlockHash, h.BlockHash)
+		return fmt.Errorf("refused")
+	})
+	require.ErrorContains(t, err, "refused")
+	v, err := tx4.GetOne(kv.PlainState, common.Address{9}.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []byte{9}, v)
+
+	// A truncated export is rejected
+	_, err = ImportState(ctx, "test", tx3, bytes.NewReader(export[:len(export)-10]), t.TempDir(), nil)
+	require.Error(t, err)
+}
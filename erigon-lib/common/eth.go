@@ -0,0 +1,7 @@
+package common
+
+const (
+	Wei   = 1
+	GWei  = 1e9
+	Ether = 1e18
+)
@@ -0,0 +1,2546 @@
+#include "elliptic_curve_constants.h"
+
+#include "big_int.h"
+#include "prime_field_element.h"
+
+namespace starkware {
+
+const EllipticCurveConstants<PrimeFieldElement>& GetEcConstants() {
+  static auto* prime_field_ec0 = new EllipticCurveConstants<PrimeFieldElement>(
+      // k_alpha
+      0x1_Z,
+      // k_beta
+      0x6f21413efbe40de150e596d72f7a8c5609ad26c15c915c1f4cdfcb99cee9e89_Z,
+      // k_order
+      0x800000000000010ffffffffffffffffb781126dcae7b2321e66a241adc64d2f_Z,
+      // k_points
+      {
+          {0x49ee3eba8c1600700ee1b87eb599f16716b0b1022947733551fde4050ca6804_Z,
+           0x3ca0cfe4b3bc6ddf346d49d06ea0ed34e621062c0e056c1d0405d266e10268a_Z},
+          {0x1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca_Z,
+           0x5668060aa49730b7be4801df46ec62de53ecd11abe43a32873000c36e8dc1f_Z},
+          {0x234287dcbaffe7f969c748655fca9e58fa8120b6d56eb0c1080d17957ebe47b_Z,
+           0x3b056f100f96fb21e889527d41f4e39940135dd7a6c94cc6ed0268ee89e5615_Z},
+          {0x3909690e1123c80678a7ba0fde0e8447f6f02b3f6b960034d1e93524f8b476_Z,
+           0x7122e9063d239d89d4e336753845b76f2b33ca0d7f0c1acd4b9fe974994cc19_Z},
+          {0x40fd002e38ea01a01b2702eb7c643e9decc2894cbf31765922e281939ab542c_Z,
+           0x109f720a79e2a41471f054ca885efd90c8cfbbec37991d1b6343991e0a3e740_Z},
+          {0x2f52066635c139fc2f64eb0bd5e3fd7a705f576854ec4f00aa60361fddb981b_Z,
+           0x6d78a24d8a5f97fc600318ce16b3c840315979c3273078ec1a285f217ee6a26_Z},
+          {0x6a0767a1fd60d5b9027a35af1b68e57a1c366ebcde2006cdd07af27043ef674_Z,
+           0x606b72c0ca0498b8c1817ed7922d550894c324f5efdfc85a19a1ae382411ca2_Z},
+          {0x7fa463ee2a2d6a585d5c3358918270f6c28c66df1f86803374d1edf3819cc62_Z,
+           0xa996edf01598832e644e1cae9a37288865ad80e2787f9bf958aceccc99afae_Z},
+          {0x3d4da70d1540da597dbae1651d28487604a4e66a4a1823b97e8e9639393dbec_Z,
+           0x45cdef70c35d3b6f0a2273a9886ccb6306d813e8204bdfd30b4efee63c8a3f9_Z},
+          {0x1e448fdbcd9896c6fbf5f36cb7e7fcb77a751ff2d942593cae023363cc7750e_Z,
+           0x30c81da0f3a8cb64468eaa491c7ae7b4842b62cb4148820da211afc4caffb3a_Z},
+          {0x6531acf1a7cb90a4eb27de0b7f915e387a3b0fd063ba6e1289b91f48411be26_Z,
+           0x31330f5daa091889981a3ea782ae997f5f171336ed0487a03f051551a2cafa2_Z},
+          {0x54be016394d5662d67d7e82f5e889ed2f97ccf95d911f57dd2362c4040ed4f4_Z,
+           0xc6cb184053f054d6a59c1bf0986d17090d25089b3fdcdaf185edc87ef113e5_Z},
+          {0x35b9ecd0499ca1d5d42dcbb0c6b4042b3733c64b607ca711e706e786ef2afc6_Z,
+           0x5624b476a5b21c3a544f0712d4817b06ad380a5a6529d323bf64da8ef862d8d_Z},
+          {0x4ce0378e3ee8f77ed58f2ddbd8bb7676c8a38bfb1d3694c275254bd8ca38e23_Z,
+           0x5a16fcbff0769c9cf2b02c31621878ec819fff4b8231bff82c6183db2746820_Z},
+          {0x648d5c6f98680a1b926bfeb01c00224c56fdcf751b251c4449c8a94f425cfcf_Z,
+           0x72c05ac793cd1620a833fbe2214d36900ebe446e095c62fcb740937f98cca8c_Z},
+          {0xbd09be3e4e1af8a14189977e334f097c18e4a8bf42577ef5aafa0f807bd89b_Z,
+           0x6e0e72ed7eb65c86cee29c411fb4761122558ee81013344ba8509c49de9f9b6_Z},
+          {0x35ea4e339b44ae7724419bdfbe07022253137a4afb7cbaffad341ea61249357_Z,
+           0x3665d676a026a174f367bb4417780e53a7803cb02d0db32eb4545c267c42f14_Z},
+          {0x36457bc744f42e697b825c2d1afd8f4029d696a4514710f81da52d88e178643_Z,
+           0x7c93715896735492a68c7969a024b3a8fd538bffc1521538107de1a5f13ce9c_Z},
+          {0x5b3a08ebcf9c109cc9082f70d9df2b9c11b5428ee23917b4e790c4c10f6e661_Z,
+           0x9d7b42ab0c20f5510df7ea5e196eec99342739077e9a168198c89da859753_Z},
+          {0x21883ef8580fc06e59481955d52ece3aca6e82c8c9fc58e216dcf46f96990c6_Z,
+           0x51a6423543e6e8a43e71da34cd90f5b520b8d33b67c4bf857573ab9e301aa4c_Z},
+          {0x19e86b77f9b581e81092b305c852faf53940a8f15f0a6990c414f04c0fa7ef9_Z,
+           0x515630e35d4398c9c79fc4ee08e1023fa47d8e03c6e7819c6d2ccef45398fa_Z},
+          {0x888ab8eb4c31bb2ac5b54aa320dbe1a69c96b864e8a5f54d89c1d1a6b86c24_Z,
+           0x730e148467f6a55ce22c5296f5380df88f38de76ef0b2de844cd3094aaaf3ea_Z},
+          {0x75e79ff13a894e7120dac17b7429c0c32ce7828f726c9973728c0977a5f5977_Z,
+           0x4960526e59c1c736561a201bc56f7d762641b39f609d273cc996f5d9197cfb8_Z},
+          {0x640fe009249115d7254f72ecafb3006139e4bed7e9041af51458c737282d1d5_Z,
+           0x3cc6c978a575246e2ce4f7ef1fcc7f63085db9ff98a1b1f3fe374087c0332c_Z},
+          {0x6d6fd09ccab7c26de9b3906191235deb5c34685580c488275356a05e209ca96_Z,
+           0x7157f81a34213dd8f91dea4f6df1bcfabc4ee091a3049eeeb3b7923d39b8645_Z},
+          {0x5531ca1d00f151d71da820918f74caf2985b24dca20e124721fff507b5a5876_Z,
+           0x518529643d3f25e47f72c322223ba60a63d6bfe78cf3f612215d9c19bf29200_Z},
+          {0x6192d454e4f8fe212bdfccd5b15dd5056d7622ffe456c6c67e5a7265aea49c4_Z,
+           0x2377a45dc630017ae863cb968ddb38333a70c7946d8684e6d7a6213f634b7bc_Z},
+          {0x542fb44b4ef3640a64fdb22a2560fb26668065c069cf31d1df424819a39ff18_Z,
+           0x5dbae9b0948e0361aea443503840341c322aa1a1366ce5390e71bf161f78f8c_Z},
+          {0x299ff3e3412a7eb4cb4a3051b07b1be2e7b1c4b789f39ffb52cba3d048b71de_Z,
+           0x1951d3175c02761b291d86b6c0a08387ad5e2a2130ccc33c852530572cb3958_Z},
+          {0x628ce3f5367dadc1411133e55eb25e2e3c2880d6e28754a5cb1c5d109627e73_Z,
+           0xae3e9b7d50964e28bd15380400b7659b87affdef5d2586cbefcd9be7d67c0d_Z},
+          {0x6ea54aff064895eccf9db2283225d62044ae67621192b3346338948382f5933_Z,
+           0x6431507e51aadacfaf39f102a8ff387756e9b5e1bc8323d44acae55130d93db_Z},
+          {0x28097d50d175a6235320fe8cfe138dd9e46895d189582e472c38ad7a67d923a_Z,
+           0x7f9eab4133d7d09a7ff63368d6135c26262b62336eca1b5ca33f2096ce388ba_Z},
+          {0x619fd09cdd6ff4323973f256c2cbdcb224f7f25b8aef623af2d4a0105e62e02_Z,
+           0x2c95f0ae11d47eeae1bc7f1350f75f9185c5bc840382ceb38a797cae9c40308_Z},
+          {0x641c18982ced304512a3f2395942a38add0d6a7156229c2a7c8b8dfbe9beb96_Z,
+           0x6f6288c9c659b6af5ac975f4180deffe53d516399b2cc62f31732e9d4ba9837_Z},
+          {0x58ab546e51fe49fc5a382e4064a2bd6cfc268904412f86c26de14f28a71d0f2_Z,
+           0x124b7217943e7e328408e8afdfa7da00dcbc94a2bb85fd8e01fb162d2c2c0a9_Z},
+          {0xa82c2fdedbb26c3c762a12f7e86b0e01e65320e0a25a8399d665f6e266bf74_Z,
+           0x1a1de28e253f3e10f44d0111e8074f882d7f42e5900780ccbdc31da372d3fd8_Z},
+          {0x744c725a7455a992e3cf5bd007bc234dd4668dba285f553f38350ad94c1615b_Z,
+           0x7f721a87f48798bdc4a9c0eb88559e2ad7a74112fd901e70ea159e67a9c33f_Z},
+          {0x434df142ddaa60f7881b6348d91687de40457de7ccfb07f0304b9e820705d0c_Z,
+           0x7fae425e3b53f97dd1f5b20e49ed9fe24ff1efc341ba5e017ac89cf8df0cc39_Z},
+          {0x7a1e2b809dff46277021cbc376f79c37e1b683bbd6bca5317014f0dc0e1ae73_Z,
+           0x56790278a231912c334eff05281e08af1558e85516b4411ef64647c13bea431_Z},
+          {0x4931b7990348d41cf8907be79f45bb7991fd18f8a57868351c92fa7a34cbcd7_Z,
+           0xca35091815cdf0837d396e25aad6052ad32d497a33b123256cffdc008bc50e_Z},
+          {0x250b815d352fd89f8210b624b147ea7d0a4f47bcac49f3ac9b777840da93ebe_Z,
+           0x1173f10e9691948b7da7632f328520455aadcba46e017f891e0a1d7da2bef04_Z},
+          {0x2223b85032fa67292f6e1f822628e6756e5c3cc08fc252ab88d63d624e4dfb2_Z,
+           0x55619ba96a7dcec77832fcb22cd5c21c7dcebc0280d730cba0002b67e0a8c63_Z},
+          {0x249b131e04de73af9820d3e22492d9ec51bdc0c4c4f34d95352fa44dd61f245_Z,
+           0x7576d3b5d136368ff01170a77d8286d0d1c7c40688862fb40813b4af3c6065e_Z},
+          {0x6777915d9b4769027eb7e04733f8a2d669c84fe06080f55e8a55674dfbf9efb_Z,
+           0x640d0ff384c9635e1af364760f104e058e3c86209fa9d2320aeac887b2e02d8_Z},
+          {0x2abe3f237681052f002414399111cf07f8421535af41251edc427a36b5b19c9_Z,
+           0x636ce4deaf468a503ab20ccb2f7e5bdc98551656ebf53e9c7786b11dd9090be_Z},
+          {0x4d5cc5414758ea1be55be779bd7da296c7e11f1564d9e8797ceea347c16f8ea_Z,
+           0x1a680c4c410cf5ddc74e95ff2897c193edaaecce5b2cde4e96bbae5c0054eff_Z},
+          {0x46c375c684b30adf4d51de81e92afee52b1a3847e177403372c82109373edca_Z,
+           0x1eaadc5783c90a0261306423d52009e991126b3f620e9cb6cffca41ca096f4f_Z},
+          {0x2ddfb71f51205888118cbabba8fd07d460a810289bfdeeb7118707e310cb152_Z,
+           0x1fd905d07b3933be886f2518246bdafa6f33259a174668808223cd7c28183c7_Z},
+          {0x386f3879960713d41fdb3b1e41bbebf26b1c0e27a9a75bb1adcc1a0d3e8547b_Z,
+           0x2b21498c0f34ec6f17c720334dc0f36021c2f87afbbbc8847d0bd536eb265e5_Z},
+          {0x407eae62c6c4de3b942195afec3f45efec71ddb5e6edee3d427631bcdbf9b90_Z,
+           0x436e7f2d78268ef62c4172d2ff1469028bad1f1d0f97ab007064418e61caa8f_Z},
+          {0x1b881175e21201d17e095e9b3966b354f47de8c1acee5177f5909e0fd72328f_Z,
+           0x69954b1a9b8bfccf8ec384d32924518a935758f3d3662ef754bcc88f1f6f3ec_Z},
+          {0x7d545a82bff003b8115be32a0c437f7c0a98f776bcf7fddb0392822844f3c5e_Z,
+           0x34b6e53a9565a7daa010711f5bf72254a4e61da3e6a562210a9abc9e8b66d69_Z},
+          {0x299b9fcd4fadfc4b6141457a3036aaa68501c23df579de26df69d4def89b913_Z,
+           0xb95bf2c2bb303c38bb396382edc798ca6a4847e573ce19b7b08533d1912675_Z},
+          {0x551f5a4dae4a341a3e20336a7d2f365ddd45849351ec6dd4fcbedfe4806d5d5_Z,
+           0x5865c977a0ecf13ce85ae14c5c316872080bd36f0f614f56b6dfc7ece83792e_Z},
+          {0x7a1d69c08e68c80ad8b310736e6247a53bcba0183b9b8798833bc696a0fb6e2_Z,
+           0x3ce803a20ebb3b120d5eaf0ad64bed0522fad1a0f2ce39a5c5cbae98c4438f6_Z},
+          {0x28acacc0bc41d84e83663f02b36981a2c8272ecd72d3901164be2affb09c504_Z,
+           0x7a5aee0b160eaff5b5968ab1a0304ce58c3d5ae0148d9191c39e87668229e5b_Z},
+          {0x1f78cfdbcc767b68e69a224a077468cdfcb0afd6952b85bccbdb96d1fb8500b_Z,
+           0x4772ba173c6b583284eb001cfc2a124104833f464ff9df096443e10ef3e9dd4_Z},
+          {0x2774108962ca9897e7f22c064d2ccedac4fef5fc9569331c27cdc336c95774b_Z,
+           0x9e13d79b68e8dc8091c019618f5b07283a710ddf1733dc674a99fc32c12911_Z},
+          {0x770d116415cd2c4ace0d8b721dd77e4a2ef766591f9ec9fa0b61304548994ed_Z,
+           0x42165d93c82f687635aa2b68492b3adffd516beb4baa94520efa11467a209fd_Z},
+          {0x5e6e4ece6621e2275415e1fda1e7c4f496de498b77c0b913073c6a6099394b9_Z,
+           0x3d92ce044fc77fa227adc31f6fc17ef8b4ec1c5aafc44630c0d9195075bf56d_Z},
+          {0x6e69c717b5d98807ff1e404a5187a9ceaf0110b83aa15a84f930928b1171825_Z,
+           0x1ee7cfc3a9744d7fa380ba28604af9df33ac077724374c04588bd71fa16b177_Z},
+          {0x404318f2d2ceb44f549c80f9d7de9879d8f7da4b81e7350c00e974ebf2daef1_Z,
+           0x3934831b5af70d17a3f1da9d2931bd757e6acf2893236264fc7e0d92ff1a1cb_Z},
+          {0x20dcb6f394fea6d549b2e75748f61b7ec03b6e52319cb14163373a9c22bb9dc_Z,
+           0x106a8c96cfb95a331618b7416d1498554730499e194a58fbf63019890480fc7_Z},
+          {0x119000f277ccee013e6bb121194ec1ab5460fb6a96eb702a14079865f4170aa_Z,
+           0x1737a32f5415e8720a5606ec1dd4756f02e7c6817e3723b453d091f2d192773_Z},
+          {0x45d0fb5cd95db76d05dec3faa12e467a308eabaad363a062353db3cd2d9b749_Z,
+           0xae08691b5b0cdd19ec499132421638f470f493320e4003d123ab1da761b965_Z},
+          {0x1257b3e65cdfb6367c6d0942327e799bc66eb221e70c6573a9862889eb51c38_Z,
+           0x593309fd45755dd2cc4afd2b9316bc4638b0c5ddb3009694fcb7b250d0c8a2f_Z},
+          {0x186dcf9950f72e868014a8accf14aa36e82a7a2a29f86ba37f6632da4189db3_Z,
+           0x55684c9f7a043fc523ed78f756f834b4db823d5e4161bd79602c17d55a5cd8c_Z},
+          {0x58791d5569f282f5c3b01ecdc9388df7ba3ca223a2dc1eed5edaf2a1d302fb9_Z,
+           0x6298d7dd51561a045bb4089deda9f40b2865589ed433e56d54554f8b45e79f0_Z},
+          {0x13fd87144aa5aa4b24d5a7bf907d8280d15937fed262d41084898cb688fc28b_Z,
+           0x3fa54367770cc4479a857411ddcabe86627b405ce1cd14ad3b2863bde13abe4_Z},
+          {0x48118139445415f0c1879224e2dee744ed35280ff00537260402a1741ec3676_Z,
+           0x4dfa39dadaabecfc54ecb7a25319444f8e952782d863790e42a9887064fc0c1_Z},
+          {0x4ad031bb9eda84f2fe5d354c7948d41558ca657a04508654721810ee72ef158_Z,
+           0x620ebd5d0086b92c6009a42777b946a351c2c7ba852b57d3c9905fc337459ef_Z},
+          {0x4a34abb016ad8cb4575ea5bd28385d2348e5bcc0cbba90059f90f9c71f86e8b_Z,
+           0x4f781829ad83f9ed1e1b6de0e5f4ac60dfdfe7f23cb4411e815817e705e52c8_Z},
+          {0x7fc632d7512aab5356b7915dca854c8b12b369ab54f524fbce352f00eb9b9f9_Z,
+           0x2ce80b944fc9158005f630b34385d50c3ad84450a9e1e529925b3211dd2a1de_Z},
+          {0x65ed10347503cbc0216ca03f7536cca16b6abd18d332a9258685907f2e5c23f_Z,
+           0x3be1a18c6bfa6f2f4898ebefad5a8e844c74626d5baa04a820d407fe28bbca6_Z},
+          {0x1a8abba1be2e276cdd1f28c912280833a5ede1ec121738fcca47dc070dcc71d_Z,
+           0x21b724378bc029a5199799df005922590d4e59cae52976f8e437bf6693eec4a_Z},
+          {0x3a99c22dafcfe9004ebb674805736a26aeed7ed5d465ae37226dcbe270a972b_Z,
+           0x5bf67552af08e1e6e2a24bf562c23225e89869cab9bef8becb3669175a3c94f_Z},
+          {0x4a6a5e4b3501f2b7bbdd8da73ea81ffca347170bdfb6776a037cdd74c560fb4_Z,
+           0x5af167ebb259c2da88740ec559ee04052bb66480b836cadd0e2590c32d7111b_Z},
+          {0x6890d95308525f0bac9dc25cc1189eb92d29d4b3fe61bc8aee1c716ac17b1e8_Z,
+           0xe6f23f78e882026b53ea4fac6950e56e3da461e52339eb43d2fdb2dade7ca9_Z},
+          {0x748f4cf4f027efdeaed7c7f91ef3730ff2f2bb0bfc2db8f27aadde947f7d4d5_Z,
+           0x3a1cbc550699411052c76293b8c41a3a8a1ecf12cbbc029a1b2b6ea986fca93_Z},
+          {0x7321f3f581690922cd0dec40c9c352aae412ec2ccdf718f137f7786ab452cd3_Z,
+           0x5be5130c9277cdb76d7409452438ec15d246b211dd1e276ee58e82a81c98fd4_Z},
+          {0x6c4d6cb7e7ae70955224b8a912ff57ca218635a2436b36cee25dce8a5cdf51f_Z,
+           0x32f8c03c6db3246946e432e4148e69f5628b200c6d7d72449df6eeac0998039_Z},
+          {0x1dad5f2e795ea6fa5177f110989516eacf8fb37bd6a091c7c93f1d73a2fe309_Z,
+           0x56b2298c538180e99dea3e171dbb5c6fba0bd0a9ed40537277c0c2373a8e2c4_Z},
+          {0x1610605baacc9bc62c4cc923dc943347cfece7ae241e746fbe6c2c878221dbd_Z,
+           0x431a82d657e0d109d00dea88cf3fa9b999845221b7b5590a20c40fc71368c1c_Z},
+          {0x6a4f5c787fb09a5be2b04d2eafa1e6f3d3c863ee22960eb0b64f6eaf6659162_Z,
+           0x14dbc3eaea6146ee7eaace5a91ed9430dad3a47e9ca2f68b455171f8fe6a7b3_Z},
+          {0x738415b73e55412b0e582e45ff0d7bf4b1bf2922db581783fdcc75559f40e_Z,
+           0x33825aeb3fd8459999eb418d15102ba5864b069c6ea517f0c6e9eab8d9aca47_Z},
+          {0x2603e72ce53985c70782774057a17944f7b4ce224a809be4e2b5af3606aa1d8_Z,
+           0x92822921809c42318f42dac4d773325f41c43069e990adac7818a45e2554dc_Z},
+          {0x181cd967ab4615357cc96c82eae9152ce7598c1a1dfdd91a458bddb016ae9fe_Z,
+           0x5d562fdaeb0e12647e230e50eaf216bed52fa73c6b7378821a3bfc4cd66d4ff_Z},
+          {0x1121726069b9ef5954ba6490100b226e0be53fef3e071b7c58a1286174b789a_Z,
+           0x4b25594cf4e9eb2d14b3f52f2661a9992234fc222c0a0d44517cb77deb9c16f_Z},
+          {0xe543663969b915337f105f80995a77b356f1a51d8b4a4fb12d44364130e873_Z,
+           0x34b2e3c009fdab4cb7349a580df2e64c0098a123280078e5da6623a9ec6b44f_Z},
+          {0x4e2f8909bb62de5ef65600e61bbf969293815296b6e23702875e049b3ce5c45_Z,
+           0x3cb81f2c21f22a7add26fa38a9ce5d9cce1bb251bd2698f90c34ff0a84f7af_Z},
+          {0x37b546e403a1ba970c17b67c2f1361ab9c803f8d2b5cd93803014faa08861ed_Z,
+           0x37079184ea46272f5809b523d060686633f7995167897a153be1772fd6566f6_Z},
+          {0x27bddca77f7bd7f66b3693567a4238f2e6751d95b0bcb409f6b24d08f84798c_Z,
+           0x6417a85cbfd6fc02df560d3963a241a986baacdfa423f65d7227ce49a96c57d_Z},
+          {0x2de71a39aa043057d1bc66e45f804542acddf18f7a6d88c0d7fb0ca240debdf_Z,
+           0x306c1ce39ab46300f7cca0f3a2fbfa77296a27e24bc66b0b8044968ec0ee413_Z},
+          {0x307c877154364c0c03534e7327d5a88e1380ceef6481567ade37a14ee7c1a72_Z,
+           0x3404bc7dbfb33b95d922d0693aaf9358f77888d7d95e773c38d83dbe2e5f995_Z},
+          {0x79f09ff7c60850e5f5ea020722659a1ed27db4c95dca131f99552f785c8afbc_Z,
+           0x40429528c099349b426ddbf129497176951a64a53db5f9d8bd2be0252cb22b2_Z},
+          {0x4027dc6b56d446e5972f35464eeac85c5254ef377c902d9fe37aea841bb5292_Z,
+           0x7c3ea37689ef679fa2f5c7e031a78e23d484a8317990fd34d44d95cc1db3717_Z},
+          {0x645dbf78a3c228c4b7151450b5e65edb58e71f37e1e4bc5f471e0f1abd6d9c2_Z,
+           0x15cfe7850f327b256e23b00627451560c5c6ab60db78d45b7ab286afb6f13ab_Z},
+          {0x1503ca373757677ad1d911a2b599d01c46eb879d1ce21ae171c7e439846a85f_Z,
+           0x583eb269b7030da6a0c324026919de3f9489d2ff6ae0e6320c36f05469ad66c_Z},
+          {0x66e1819ba3ec4ad4ae9f7d7588d23baa004e29d3aad2393d52af204a81626ca_Z,
+           0x505249980cbe6273b82ad5038fe04a981896f4117345ac1abcc67e2525c0ee4_Z},
+          {0x5ec20dbb290254545f9292c0a8e4fbbfb80ad9aab0a0e0e9e9923f784d70ed1_Z,
+           0xbdb1ca3a859227cf5d00eaae1f22584e826ed83b7ccdb65483ed5213dc4323_Z},
+          {0xa5c1a5011f4b81c5c01ef0b07c0fbf0a166de77280f0ae241f2db6cba15194_Z,
+           0x4444521fb9b33d7dfeb1247d0ee1a2b854ad166cb663d9dd2e686909362a689_Z},
+          {0x1f35335de40e00c62642dac2fda8b30f071986ce4f11db849df11bc45ad4e0c_Z,
+           0x7801a2c761b90fd4477ba0be9a775003d5dfcd959b1ed198b4681f15e7acbf_Z},
+          {0x48db4798cf6821c1ffb8178b1d3bb6020e04186c96aaf4670972d367f4ed5f_Z,
+           0x781019494df95b888f1578f1b4a3f8e125ea60eca47ef9207a10630671217a3_Z},
+          {0x17f653d904210148a8e74d8e719a3061683c164aa6d79c902a19f185ab437bd_Z,
+           0x6780e97985932c3860d810af1e065d454b1cb4be0e7ffe2d8cea7d52526e223_Z},
+          {0x5c4d0c7432f9b0070436240f9855adae1467cdc9826952ae01b68cd52a3ad89_Z,
+           0x1c5747f968ed91261b7ae9bf1023c999da9816e37de602d6a1a50d397752bff_Z},
+          {0x6fedd7639fdaa2f7bad4ca0b391710f6f8a7e890250ae8ae4252bb8b39a1e58_Z,
+           0x436a215f655a3fd3778b2335ffdc9aca6b98474e43d764c1f8362830b084f0e_Z},
+          {0x7fbd45a889c5e9d127bb4f8474d6be7cb9796bbfff923b75e42a1ad4cae37d6_Z,
+           0x484bd12622a6ba81cd53049c550d9ed682a8e765b656b1cbff9bbea637bd1f4_Z},
+          {0x17d984d47937263f7966a3e7b1eea04071e678494bd749c9e02b48b3234f06d_Z,
+           0x7b341ff08722c4e161005d0037204a7a2001fdda7af2cc1a0b04a027f115a0f_Z},
+          {0x7f1822045db45ea07e1519c3ee1f7705915f35fe4dd8db1e8921b5d1c740edf_Z,
+           0x33d41e06b93320ad1b3d9580380ec797a05dac3f1cc8008899110ebefde2f78_Z},
+          {0x7b19453ecb74b7d0e2a66b9890ff73bfbbcd61a266abd6d82dbe665bf32f34d_Z,
+           0x6dba2355420dac582b1f349609ea1c89b89bba2d1a68a0642f1dd12d86e73cb_Z},
+          {0x273e82a15f395ddf2489a95685bec8bac62c4b459d1b28987d3cb27e4bc9128_Z,
+           0x653375b48a4cf5d5b101c9ef533039bedce5dbeef3f59e8f168bdc99b06ca5f_Z},
+          {0x3006c9e7fc6a553d8eb4e8a47ce9f10d1a39576ac255ae9e0a4ce3869e76212_Z,
+           0x65fe9e2ef2aae608be309332d464f57e28f1df5de1a6a519751b056971f932e_Z},
+          {0x5e8f384c8a4607fbe9789fcc52d54249d304d698562597d114c1d81452d3dee_Z,
+           0x3c8bc78066b5d947dc1e405e326ee55ea606c7988f666748d259850fa259a22_Z},
+          {0x7841b2102e9aa103fb53a642b3e167b21113ea44751ab38e0b5ef8312654db9_Z,
+           0x71bf5c8308fcf9c4a7847494cd9bdd946fddf7d3a37e8bb0b201ff2343deb8e_Z},
+          {0x40f68027420c11e3ade9aae041978dc18081c4f94943463aac92d887f922a62_Z,
+           0x499c6062594a6c7e21a3cb91ea451813393bff365a27a08f1a515439b83cf42_Z},
+          {0x6ce77a50d038b222634e87948df0590b79d66087b01e42b9b6d8fa30ebb1465_Z,
+           0x35f5c46bb1be8555a93f155a174d54ec048c2ac8676e7c743054ddc52709d37_Z},
+          {0x604f8b9f2dacb13d569262864063c2d4bb2b2cd716db6eeb2b1eeabc57746f6_Z,
+           0x68c6799e24f3b44eec3049973445174727a66970f1614a782efa2b91ab1e457_Z},
+          {0x73d620f3bfe77f672943d448d7dc05327adf64b8e7af50039c469d7f7c994c4_Z,
+           0x4859deb36eaf0c802f0d1514602368143a33ec6ce8fd55248b59025debc6afb_Z},
+          {0x3fd2bcd1c89d706a3647fbd354097f09c76636e93ae504973f944d8fc3bcc1_Z,
+           0x677ef842cf5eb2444941f527abec567725e469469192354ad509a26ebb3d0e0_Z},
+          {0x39222ea924ac17b533c72ffb2c47ffdc11d6a7f7c70fbde3a10fb0b8f35eb2f_Z,
+           0x20dc4bd1089019bc1d7379b4feb3eae6eb5af59e9f253845da9fd633057e952_Z},
+          {0x326f58994e1347f62e4102183215b5db956378d2f61f14aba4dec94577f53c_Z,
+           0x7a03284c296003bbe05178a1d82efdb7b8125511d63e20e50aed789c2e52e1_Z},
+          {0x53aa8939c74d4ee58f03bc88bace5a45c7bfcf27466201da05dc6723a5f5632_Z,
+           0x2e32535ca7732904a048183247b04b426ecf9b39fc393a9cebe92fb1dc7a7f1_Z},
+          {0x6cee1a03145e93b3e826e6067005f09c06099c98198c91c222407ba5c8c132e_Z,
+           0xbeaecad1274e7c6e5476a100c271aa1a6f86ee5a9fa5c2f26124d5886fa63_Z},
+          {0x3ec659b8175e1be1bd5a252108714776b813e330393f587814f5f1f32a73332_Z,
+           0x529a5cf9f8c237ae69a94217d173c8d19c156952041f5c980da557990863fa7_Z},
+          {0x3d66ec5963d0c534d4139c8cef2e1ac48b3e7965fafabf58be26f903318af4e_Z,
+           0x3d3f2de7a95f59b683725ee6283cbaf31f97c4b600df9a4621413223a468740_Z},
+          {0x7fb38ace8e0932fac2ea0d3eb676db8d684db1817e2e4d59da7996ce398b4a_Z,
+           0x68f92bd5768cdd4710249f9d49ef1d5654e497b9a4ba10bd2971366d83fb400_Z},
+          {0x1c4a49314d6b4969cdd142c76ceb7682bfb868ace7f7568b0fc8635bda5a9fb_Z,
+           0x5fc0519f1f4cc10b5771312458748c036313b87707ed0540026ac64a5955aa9_Z},
+          {0x3073c95d08d3b97caea5f0be16b2789bee766f76b7e5499f8ce8f96abb0f344_Z,
+           0x52a8974b4eb9a1f6a0ae2c83cb4715bf18d73f057255fcb3f63b74f7e78f590_Z},
+          {0x44485b16d597a5de3604df6f7ed7e00b8aeef9e7e8dea8688255153b8bb16aa_Z,
+           0x6cccb0ba170123266f24b5d93a744397dc2c44820edc4f8f5b9a0f5c9b3b940_Z},
+          {0x7618f77b7b32d512688dd62e0b48231d9574c6361e8be353a7dc04f7c3a115e_Z,
+           0x78ffcd16d80636381ca231aae70d99c9e20298b4f5388fd823ea9fa2b8ddfd9_Z},
+          {0x7dc82fee1ef95cf5b3720fcc07f63246654bfe39762627839da40e51c75654d_Z,
+           0x4c0ccdd70955da74558de20c88352df8a02aa97e4d5971c500e884740a8cb62_Z},
+          {0x7fa5d460dc10cbb418b444d9bde97e92c70a99a222b99f244dccee7e62cc04c_Z,
+           0x636163901baa5b7576c38c43407af578b8c4607e01e86011ae2dde587a89f84_Z},
+          {0x758930d46006623a756c89bd0cc378f6a3c1f43c9a0edbb42274c35e75c16d2_Z,
+           0x1d74dd9f81c2fec811b8cbd6168a745b0a111932b2a345265ef2853b50b6245_Z},
+          {0x7332ee0626b044d664ef228f8cb84df7c643e52f6a2591ae1c9007ad61ec16e_Z,
+           0x229bd8e630572cbdee54283234cf3e9f060e6382f99943bf234119d47b54470_Z},
+          {0x78a16ef803aa20a075bb2f66c61bb2dae5698bebb94a0995fa74c3d53de1614_Z,
+           0x246d588b68edb6fed96c128349908c42dcd64c46341b205e79f4aed9b5d3675_Z},
+          {0x6e1933939bd03b67bba753cc0cbe7d2f25bad68c993887ef8c9e2fcd59b0647_Z,
+           0x599413f7c204a11a5ce315eab11299ab7326603412bb00bc1c59ff75a37d6b4_Z},
+          {0x4a79957a5a1888ad063b51c69565a2b48e8eb917183e220a1c8d3374526d30e_Z,
+           0x1f092de0e069bba7fc5386e2e9a114c1618f88c4b95e220cd35ffe96f99fcad_Z},
+          {0x3148aa3df9ece39aca84f59489f2710522216f14be6055ee0027529d1d55e2d_Z,
+           0x617e9a52a92975db0ba1977f71116f7058a0d31b869ac7f3ee2fd80b0c5100c_Z},
+          {0x5c1188e72384160ae39d07328346cda4f6c12d227448e6236f04dc971625287_Z,
+           0x1643006eb3a3bc6aafd5f685cf054f2a572e6ca58c0118bcec0b833741f116d_Z},
+          {0x3f72efc93c9b71adc4c51d8fc69d3940b20d08733af2b7d05140fdb1d1c1004_Z,
+           0x7399259987c8f4ebfab46e522380707e58427d3962ee0c2a91760813f76d232_Z},
+          {0x3129b34c03c51aa8f611e91d5cfcc9bd3ef108ee66e6d3ee35a0e0e50055bb_Z,
+           0x563b18b5650085efb4cf179a029e6afff27b1d3091cd28eaa68d24fa1f801c6_Z},
+          {0x16eac0f9fb4c67cf89a7fa4ee615bbe731d8edcb709a1b9b50c7d873a530f52_Z,
+           0x7ff8288b6e199ca8f316192881424a37fb080c29daa76b1f0edaccaf580a80e_Z},
+          {0x75f6b6028c43ce832f65d7e8e620d43b16cba215b4b94df5b60fc24e9655ee4_Z,
+           0x35e9ccfaed2293a8b94b28de03bcb13eb64a26c831e26cc61a39b97969a2ff0_Z},
+          {0x3c6152fe093bd6316897917ec56a218640ec1b2148f21db9b14fc7a5ff362e8_Z,
+           0x6eef2df27ae7d63a28856b07b73e7aad7ca94f317201a1e675ffc6f9a1710dd_Z},
+          {0x54e01b5fe4fd96052aad55b3f26b1d254dfc7e2525fffb9ae0a77eb8cc5579_Z,
+           0x7c3d39232ab333675b219abc766ed9b4782c840e6b046614dedb8a619696eb0_Z},
+          {0xd1e63f8ea8a76429cf254a6d3b668761f0dc572d4bfac4fd56d9eaf58fb6c0_Z,
+           0x2bd0a84d3908a63085824c9329a0983913006ba155b56a58eb3f9becab29c45_Z},
+          {0x2d6122f2a702edd4da7385b1580796a71d13bd72be94cfb3fec01149c006c2d_Z,
+           0x70eb282fae992efa6f5915e578b640653549f23385ef3a29ab29b1b9b8ad63b_Z},
+          {0x752fec14beaadb5ddbba6b3a17fcb86579fa588ef407fad0ea07dbb22a640d3_Z,
+           0x3feb6728eca21a1e84e8f9f23010387a53a96a1cb62d86fb37996150a1299ef_Z},
+          {0x63f94a92f27acde8f5ed949b459506f51d70c85bcc61a34d647264ecc53c65e_Z,
+           0x37e5dce0646ee66f4fdb93b82d54d83a054948fa7d7fa74ab6b36246fc7383e_Z},
+          {0xd6aa909287a2f05b9528690c741702c4c5f4d486c19a46c38215f52ef79c7b_Z,
+           0x5ebe1128dd81093df4aca0df365d58adab848d1be1a94b95eeb649afd66a018_Z},
+          {0x12866812b3053e2f7a9572bdaf5ef2b48c6fb62a0eed9ff0356df50e7d05557_Z,
+           0x6785f7eb2cd1c120e4c7167b46861d10117040a2e9f2ca86a71e9d67df90613_Z},
+          {0x46a730d05330b1b13673cb8a1b8f45460035e4a9f1a1751cfba099c4355c1c_Z,
+           0x76fb0ec6cd16a8141cdcd875c8b2de9fce42d296072643d148ac7e7fa7472df_Z},
+          {0x4bd4380a22900bd34835e0a908eacf4b6edb61eda0cf483f9212453b37e7516_Z,
+           0x5e9551cd20d8d7ddbf4366880b7d5267385afa1966ff30da4baaf273b009d29_Z},
+          {0x71f1994ad40baa2922424ae222663a64f93d8b67929e9a10f9e4c1ab19f3833_Z,
+           0x85320fe68ec0d37cc19fdfd03589d66906ffa4046c80e1b094a85f27676346_Z},
+          {0x5a63b1bf5232f28f808765c6be7ce1f81c52145b39f01c879fae0f4303bee61_Z,
+           0x3bc5d6df68bb6d0577bf9ae2ae59ec0e9b2dc7dd56ea179fb38a41e853db950_Z},
+          {0x161ded55ff1087032381e6c1449704f63ad2d88df82dfc44a71890fa09b3941_Z,
+           0x78a52e0013842037274ea75daaf8eb4afc04ccc4b07bfaf3f5ee47d165e01b_Z},
+          {0x1bfce5229c5fbff5c0f452a22317fcfcd9262f23df41840f84fe7d44cfba1a1_Z,
+           0x66b387872c00e63c73006a955d42cf49c46c5708fc9d1579b9ae38341b24a3d_Z},
+          {0x56d47dadc9cbd1dcb2ee3efcd5d4af5e6aea71df10815c68b54a14e81d11b44_Z,
+           0x47e966ba54df48e9b612a903685e0060a67e4725402e8cb4cf654e54e813a3e_Z},
+          {0x4b1c44438afd4ddf20a2cf612df2ee494ce84c7274c5529e857693e73018491_Z,
+           0x430403bd31d8f0677e06abff7159384560f27b9622943fea1a3192f14bf40d4_Z},
+          {0x7f7281728fc2214aa1dbf13176a4624b53814734abd570eb6ef7c7e32379606_Z,
+           0x312da47be347fb3fa2c9089b38df372560dcace2effeeacab4d96ab11567295_Z},
+          {0x16a28884a1be8183e0d3fc0db84a9afbf47126fd3be548c2a584aaafbfa7dfe_Z,
+           0x7c3f57b3b895564ba562c1cd80b71fda6d2e611665c6ab87744f5390858fe24_Z},
+          {0x323339f37b327a731232a9580e79952063c7c232bd1380146d8a83c285f4b8b_Z,
+           0x4f16be1d983c7232f92cce6b9690695978d42cecc8eeb8c206e125d1098a265_Z},
+          {0x624d26cbaa197e104eb83cebf2adeed09a5cdad359993fe5e3529d4d0def21d_Z,
+           0x261b7da3cfb55c788977e0d8d640e3e93ae5a325d962ce85c816d7d32cfc430_Z},
+          {0xf24ecb7ee83a3e28dab54a330dc93d0429a7aea36412e922dce8fbff40d60d_Z,
+           0xb043e36a258d1df1d21b0cc7be9c4dcae1bd4ed326c110e668ac23d86805a6_Z},
+          {0x686cea46b710bde1231483bfdbc700cfa3da6ecd5841c0e0c782f9ea24328ec_Z,
+           0x7eb7407aa58edd6911c7c7e8d1e03bb52ead4a2415a0c33325872ff3a521dd6_Z},
+          {0x3866ee1186264549df3dfcdf8705c0380c9372eef6d4081c2454d3aded1720e_Z,
+           0x634c6d3e8eb8af652a4be73e3b613452c2213104ca875b66b4b15ee5b1716af_Z},
+          {0x484c687cd2969a1d20a58cdfb9a60f280a473284503b1ecff5de514aaf8206b_Z,
+           0x34d44d26b7427e51a646d1b924084762f5b461685450f21d6a472de565bebd8_Z},
+          {0x203561333771fa0fe22c4033349f7b877d15b0542a5598e81e067968768247a_Z,
+           0x2b6a533aff6e2163a36a2a89cb7415848bef48db40f952ffd380f47676707c2_Z},
+          {0x2ffa6cca6233695760251206fc5e34c8d3692498589478cdd3d5b09f0b7c05d_Z,
+           0x6c57d605478fa9626c4ed769554d075daa53e1a1d0bd4d94174d3bfeeb11ad6_Z},
+          {0x5dccf0fa46a5571f204d0b033b45f299cbb3d9f80fded57253ea4f1c64faaef_Z,
+           0x30a38e131ee8756ee5ea2a3e16618a5dbc28b5b9311308bf037ecc2039dfc7d_Z},
+          {0x57b0a2eaebeafd950221facdd24790d7d1ab8883e5c5d55635f0d14a1ee4741_Z,
+           0x7b41cc478fa6be38417271db8ed12efc0da6982552c1496025d2df0576bf4ad_Z},
+          {0x611b5725101f611c387ccaa13889ecf3bb5595071a179ce350029bfca4ad7f1_Z,
+           0x3129755977abc8995fec7eec1123a1561e429fde37ff36af002d3211831ecf4_Z},
+          {0x1c06bbd0c52fdab9fcaf680c7a93fb821e538a2ed79f00f3c34d5afb9ea6b31_Z,
+           0x3873d3bdfe0be0157bbc141198dc95497823cc222986d24c594b87bd48dc527_Z},
+          {0x275cdbabc989c615130d36dabfa55ca9d539ed5f67c187444b0a9a12e5b7234_Z,
+           0x2b7f723e68e579e551115d56f0ae71a3b787b843cc04a35b9f11084b006521_Z},
+          {0x6cc702eb20f8b5940c7da71f8b1801f55c8c2d8e2e4a3c6c983f00bc1ffdd95_Z,
+           0x5d15b3727bc66f3aba6d589acdd139fae115232eb845abe61fbdfc51341352e_Z},
+          {0x44defb418700cee8c9bd696b872adb005490512d8bba081f8f99a9f15cc981c_Z,
+           0x3b2072cdb1d919b2b65b5cb3557f0a3381d7ca293c267ca4a38f83e77bcc96e_Z},
+          {0xfd83ce77b1578b3a9b8c3cbeaddb1504d2fd4a19c901c21ac65961224e4966_Z,
+           0x110cbe64fc10c6b9c66f15ca406a35f50b723b35d83c5eb9797a57f8395f4f9_Z},
+          {0x9dc6ff90e341875e113bbfb507724dc7095a280d2f32cb6ba61a1e0c2d2aef_Z,
+           0x4aeb622896c852c2747454e8f172c9482955a42ecbe522d6ce07ecde79d0a51_Z},
+          {0x71c58b0e47b9dd9107ebd8a8c8fa9f0534e78231bac612c1ddc7a94edf33eb7_Z,
+           0x7f90edaf4792bf8334adbaa0f4ee7c654312725af188682d75f34874c4eccb9_Z},
+          {0x1f6de1f14988778ceb2dfe844f92394f1f1e72fd1581ceb3bf336c95ce50345_Z,
+           0x4f6007ed4e022d2ee9fe4ca8207c5f6c766c4f3b85260e941fb24ad0dcbf0bc_Z},
+          {0x3ddc3ac25ede4a67a97547ed27dc920239b585fb3624177e2e8d59eba678115_Z,
+           0xa9afd8f8bb759cbd1dff2addc63f47da4ba1291ea34229c09c0637dc5c8d24_Z},
+          {0xc56b0269d8431556e471cab9d70edda3a37b391696f107b2dc370631de51d_Z,
+           0x729c52f6b134f733eb750c14bd9f95c077f0f6f6ff4005701e5bedc6544599d_Z},
+          {0x44d32ce19ac6807cb22e4f25fe1486a36a13926f147fbfa054b63ff0446177d_Z,
+           0x212a21e8c124c9cd37c80d2dd66913ceaa6b6f666522f115c39382b2d5925e8_Z},
+          {0x35dfc16f3ae6ccc06a267bf6d931601e52f3e45359ffc513570b65b96adc4f_Z,
+           0x74311d10f4bece01b5ae65a6affe5c931463aa1b73a3320eeb41bbb7bb1ff62_Z},
+          {0xe0acd9d2d907031b319b80121dc90699d003d220ea785d50e5033cdb3b1a03_Z,
+           0x3911ba78d6e507485d6374b0f7d2e6198f6462a7d6d3cf046404a07af690357_Z},
+          {0x3c57918ca254c0cb7dac251ef4e10c7d82327969552eae15d26c4c52660922a_Z,
+           0x5fd5f5ff3f14e671548074114c72c48409df8a2e71fc8aa3c8acb506e2a88df_Z},
+          {0x222ad8b61e219ba2b581f606b7c996516850a46a3db72fe1f72b5a9be6c324c_Z,
+           0x72015a5e2db648112abd284fd867b59fc5606645177d26cf6e9a655c9912d42_Z},
+          {0x3c86d5d774bc614469768ad38f7be9a53e9a233942c5c553b82e49aae684764_Z,
+           0x480febea8229e130dedffff89c11f3c43e11724e6bd89d5566d78752859d41c_Z},
+          {0xadb73bb8352d0c10175df371f7868ef2c9e0c79ac788430c480c0f7d85c187_Z,
+           0x60b564785248111502e6f39c4994d6293fac22bc25f4d764b2fb1957d3c9bd8_Z},
+          {0x3836ab8b46cf4f453a22532c886940b982029b29c42adca90ded5bf77e6bcb9_Z,
+           0x7b15e91d6355f147b171a90b064a9d8b2d7bf3699bbf4987664c61c950d8996_Z},
+          {0x12ed96af1a97c45ec31f1531e96f6fb28a03ba52ab8484545fbe0dddc97bb32_Z,
+           0x6d1f522b6c6cad0940cff8e23decc72bb8d4164696af031415508b025aa8be1_Z},
+          {0x27382994ae5878223ef802e9b4882f481a1b4008f1eec8484483471f7aa742b_Z,
+           0xc31750d242b3975b0026a0e86ccdd17d0f680a8c6f53f197fc25eb1f777917_Z},
+          {0x431677eba3715455bc235557518a74f3b111a88844ef13e159ad44bc16de3e6_Z,
+           0x30000e1eb6a17d9df776981e65c6e500fded1ac12003adc9446b269812c9197_Z},
+          {0x4b563e6f42589671579eabfa2cda5502b361c46a5ac8d45c8ed44741a925b33_Z,
+           0x627bdb41678443fdd1aa607709e9699b652308615f4bea760a3b79ee0d9ab5c_Z},
+          {0x2932fd3f81fc973ca9def6b7f1bb50f980fe589187cfe9e9f52ba4d356cf2c8_Z,
+           0x1e6bfd00fa976c4770263a227048214c38850fe0f059e7b3d2c7871ef07d68f_Z},
+          {0xe44e4f3d96d9dec775b996be57e57fdc28e7c68023109b221c414a244a0dbc_Z,
+           0x58b1e52fa274812e5184e00e9ad812bec2463140adfb4bea3b2d665867dcc9_Z},
+          {0x7fcb89be1f4bec745887bb891e53fefd665c53d00a9e74de16b8a7e1f7adfb5_Z,
+           0x74af0b06633f779897e199609c71cc5649bbb65bc2c0abd4c678f0480c198d1_Z},
+          {0x62a381ffb904ea3ff4d451d4c8459457cdbc3dc2fd2da646a95d8c1e90c0b7b_Z,
+           0x1ba058658e09db9e319fa73de8ab4a992b71e4efc22c273725bdcab84e2a315_Z},
+          {0x1b0fbb7a84c67e668450a54449c7a46261a2d355589f8b84ebfbaf9a77ee938_Z,
+           0x44f8fffa33dd33a6146c35d196595e22cc4a215f61ee9197cd751400970a1b_Z},
+          {0x78fe920bd96a356d4d95ee34adafe8fecf071d3107c36f047b4024ddc4b3eea_Z,
+           0x6162f29607fdbec10181fbac6e57d5cb41b922c5791fb24bd28bcdd75d16c41_Z},
+          {0x5629b849e026e65d119ac11821d7ab7efd9c52226f75c7427505d6818bb0c8d_Z,
+           0x1539c0f90970ee8b490e45bbe5568170e5708521a0e59f976be680595906feb_Z},
+          {0x62bc853f349bac8c6e5921d27ba85dbd9ba20a375d70a7bc008928f3e123b04_Z,
+           0x6acfeb1de05ba43c3ef1a9110a983a320e77b3ca294abbc04aeca19b194f26f_Z},
+          {0x4cf4bed663464418285cbae359b5d84ec76b5997d24f3640984c7663421190f_Z,
+           0x941f818e3e3e8fb1568da85217d17f9250ebc948379014d900a7b1a848494_Z},
+          {0x52ff3d9ffe9a302f6dfaaf74bab57c08027d5cb699a69b30830540c0a2d47a1_Z,
+           0x987dd8876873778d933fbfed37aab2f7d6f669c37024f926b1edcb2ca55782_Z},
+          {0x1109ee32f0bc53de6bfa457060b366e909d7c18061ec9845f46ac715496897f_Z,
+           0x38f36f172bdfd454b9285f86e6bdece8fdffc95182c7d801b03c671cc55139b_Z},
+          {0x4b4482f1d84efe23dadf3bb10df3dcaa251312dcdd604f616f1eb540e1f3232_Z,
+           0x7c9c149dcae9135f940fb54482f9c3cd8193721643a6e23157b8020410d439c_Z},
+          {0x69cb459b9e415b7581ca163611c470d875971d5d7949de732d1f0f200544a73_Z,
+           0xa7136fa9dd00c0469863b7def3f83a5611ed628810d7e807e7a873da5a9897_Z},
+          {0xb66a4e32ac9a4baa8f64780acd94ed3628b2b0ea874ba4dece629af65f9e62_Z,
+           0x24328ba9996a24389658e3467b8b90dc3927ef8419fe28b3f55b1c1aaa51915_Z},
+          {0x5ecc3080062dd451236de0e4eb91c5c75100733364bc5469f5fa76f79021ecb_Z,
+           0x6da4abb9031a27b5be94529324fad8026e7d871570780081b0f424d4fe543c9_Z},
+          {0x1e3146f00880bb22486d5bc73e54367d54251f4002bcf342d0393b05a4b9ce0_Z,
+           0x23b6fb8e945d3205f633ba724202db5a99305f807137edf942cd60eef867699_Z},
+          {0x2e1da8013285598b899f026c6974185db12c97b4c63509769d3d4ad1d18a4e5_Z,
+           0x1e7e7b668674d1593c39d58bc7bccbf568208732b3519bc2cdf93db34366862_Z},
+          {0xd26c3f389d81709506f184b53871497c8d36c5c9eee8e3737358204c1acba3_Z,
+           0x34649c3d39f3b825947fedbca215ae30c5a5995e93b1c8efca4944cf85a082a_Z},
+          {0x91300478a83595d548f32f259033291fc7d083953b0b8bde88c7559660c563_Z,
+           0xe5d2bff57fc6551e9b80c06ac7314a71907cdcc66ce82f2cce721a670df10a_Z},
+          {0x1f7abcb9d462c63ffe92aa56619ae8590089cca4d93ee3e5f34a63882452cc7_Z,
+           0x7e9f85c7b7ca6e9a4f3a026d1048adbeef69ea9d876c6f647c257b879a81bdd_Z},
+          {0x4d2caa1323012e4c83b0ad387308b8aef5637bc35ddd882e7f5e41cf2ca410f_Z,
+           0x47150e808c81a540b6f8864e9d6636589cacaa516f82caaa96506edfbd6f0e_Z},
+          {0x3c10a6083c38351deb3e6d1b386827d0acf48979b66b95249eb8700ec26b069_Z,
+           0x47e34bfe561d903cffdd1d849b85aa3cbd31cb4a9bbd8cc2e5fd2f95016cabc_Z},
+          {0x758bd54868eec045d0b4d3d2bc415d24bce13fee47cefdfda46425c109b657_Z,
+           0x3392a7c66ea3bd7b044680bbe9f78ae86752097404c067e9d2572f55330df83_Z},
+          {0x19e718e0ca1d2d6fadbc6006ee7dda7a385430e29f5e239cdd4bb7c3fdcb2f8_Z,
+           0x5c68249b7fe03ea2e13481a63b6cd4bf74ce42009a89fee0b3f8f968b3ec709_Z},
+          {0x28077f57ea62401806367e6d54fe45d02de5b072db787ffdcc3854e12a3e855_Z,
+           0x14f3762689072f5fb41d03e94b01808c739f6d42b7b785b0e464100b150efd2_Z},
+          {0x3b8a8cefd017363ce867265af3293cec081fa589fe561830f0078778cbd338f_Z,
+           0x69ccf2383cb7b4f9c806d72535812483e7c5e9a1a5928529d64ca7e085e758d_Z},
+          {0x77878f388d22161a2953e5aca6bac1ea480e102f329574b4b201640d44a296b_Z,
+           0x7eb35706a90a03aff7c2fecca72659136547cee98038746db5aba16fd7178df_Z},
+          {0x97332e6da70961f2ef31b7b628f1018d21db8db015922a301fca7d6fc6a8e6_Z,
+           0x2e37b06f639fc7a82601b744570a2619e543cbfaf60e474107fcaf4686d3223_Z},
+          {0xa81518d452d3aac48bf0386c3ff170ef4e684a4def242c964e129c64f4d647_Z,
+           0x37506e44c85908ec7b7adda9547fbdcc2e3605151fefa77fbf127ce3bc938f2_Z},
+          {0xe80336b2220b1d666074f6b0dac85353d0e4c2e8bd0f37055a2236a6a9fadc_Z,
+           0x1cae76d73eda7a5964c5d9d3ad6748aff51f5543c56441d2fdb7b444a39846a_Z},
+          {0x2c01fd8430ecb44e066f352c4f697fc9fda177dbe162f82862d7b9ea8c918de_Z,
+           0x6e1dfa99640fdf5b30603d34c7c97c1aa6e6b7f3a2c52a21fc64b0fcac7d591_Z},
+          {0x744e37b511cd0ddcfe15f3581947014c159de81ed055d15a13c7a2d1fa39f0f_Z,
+           0x685caa8ff6979a6c63640ac638a3f9c75737f2031bd55322a47384357af164d_Z},
+          {0x40e627ff84e1a7a9068b4368770f5956128a4d9e9e33e9cf5e24d9a242149fd_Z,
+           0x2465bd6cb20bbdf810e2bc5c3c458cecf4f3aa163a7ac99c2579e5f33417f2e_Z},
+          {0x5f635af7f554a17bceb6ccb6e637abf89ab6dadd399189b0a0390e87b1896bc_Z,
+           0x2aa6238a69f89665646c0e3ca2ba5f709cc6e14351cf71e1b00ec45201417a2_Z},
+          {0x5edad3063c9fa8305978d7e6a4e037c9fa519b8023c7608dfc3b66e5c1e8985_Z,
+           0x49f405d07d7d01919da51159ecdad1031a5ac208c026fdfc14d38f633d92183_Z},
+          {0x2fdf2e8a45858c12926a1f25a62255fb2d02d0149a15ef669f859806683e649_Z,
+           0x61cfb686bb31e2524470d4ad2ae09e3cc91b16305a21d748098feb1d8ce3b3d_Z},
+          {0xecdbd7c37f1dffa3943977278da3bb429afdf948b4ea6cdebace3d3be82381_Z,
+           0x190b67fb34f7f3ad6afd3d6b6427aa327547d8ac0fb4deeb0feeba1f63d6c60_Z},
+          {0x233021b483f578dfa5222f8cccba5766ceee0ac65f6d4a3b1673b302a21fb3c_Z,
+           0x7d4b6d44d175d4b593f06f5a6dcba2cdbc4eaa2097abaf613123546866cf4ef_Z},
+          {0x42db4e953c2a7a743de9fe20c5798f2247f51db4eabc6f40e86c13909a310ce_Z,
+           0x12c1a0764a0b9f3666e431923ce15e7fcd0ded5ab153f0b48d362cca1604e65_Z},
+          {0x30d539e2b545fb957e40e2255f6463b52d227c9808472cee6a3d521aa283a44_Z,
+           0x5f9eccf747fe6313570f99e845db32b40070acee9ce9e34da7f3c29ca53a07a_Z},
+          {0x4bd64e5ade3e2733580a6116b4af328751198e7128f9acfe3a3496b545efb5a_Z,
+           0x4d584768900dabfc0dbaa086632b8051bb3905ef79b84d96c01514441d0cc93_Z},
+          {0x62d6e771f02e591557197d13c3e77dfa2d1794ac1808407bd8227c4be31b466_Z,
+           0x5c6f5607c1808e899ba36a425911fa8566b7ea9cc80de8a80538c0fceb837c0_Z},
+          {0x5ce406218cb2852b1d2fe1836b19462f664631785216e87ffbce26030e2101f_Z,
+           0x5225f107743c255ab50e7be4a090fe39478d1ef4ff558468559d8cfa87bb94_Z},
+          {0x670286486e8dda3dc66b0ed3149be7697d3e06c8279844079daa7e42d5af728_Z,
+           0x26becabe7430380c56e320f5ae3329569cae7b0af06fd5327ee23979d200eb0_Z},
+          {0x3ef448df33a4394c43e93e5850cd0c5a6dcb18ae1cd865d00fe8ede9336a9f5_Z,
+           0x56711f6ab7e0e4f7365ac34e284ac2879f40208c46f6febcc1dcf7146ecf015_Z},
+          {0x4b63fc130288e92f2d6ba238caa7a6364804e29829ac037c57df32fbf762bc3_Z,
+           0x1eb8c80af55278b4113286c038fff2bfad2da62763bb03426506b869139da0e_Z},
+          {0x4e7e998557b29a95f805a6e2e26efc1e970108272d4755738c04f28572295c0_Z,
+           0x97cfcc2f447bde61bde71049d8200a74a3028b21703bc139143d81a3623f09_Z},
+          {0x574b67898f02964c408f68e9470e7b615be037e40b824e6617f89cb56c21219_Z,
+           0x49392d5f8e6740a1b0b7444f56d7a17363f8656c6e4c628678c86223f2e46c8_Z},
+          {0x7e8cb50ea5d5c1b09e219e7305bcb601d99b6d7185b1c388aa8e36fe1e56554_Z,
+           0x47fefa308645455c12ccb5817da338f0c4f423b341aff4a9d158891a4fd69ba_Z},
+          {0x67266dea9e71b4ed2bf24a597a823dd048cf31e725db511edceac72998c9ef6_Z,
+           0x39babd65850befde1f7c28e41dbdbb4caf82bbcf3bcb5b33161f1c2960b2d8_Z},
+          {0x63e99c2cb9c74eb9227d48065e27abb8f606df8fc83b2c44e4ea38b046bad2b_Z,
+           0x60494a53dd13ecf34e08079d343c88fb655d6d810785af81f08d5aa9bcdcf9_Z},
+          {0x3cf0600b0f5a2a4eb78c487cd385350e8c7848e3f6983231881d7f1bbe28543_Z,
+           0x56dee4288528de609976ef6b903b652127c37b0590e91a2fdbebc3f11df2628_Z},
+          {0x758f09245fa4b8b23d290ee2b3bfcede199b4fdb11f3cf2502a8ceedd61b129_Z,
+           0x622d9baadfde781e985d9722e0a04715666769a4cc7a9bea0b96d6386be1746_Z},
+          {0x38e1a45b81492aa95d7abea2b08b8c14dc0b8a41108b036871fb737910ae18c_Z,
+           0x145c611262656385e5ed6243568cd3f9f59dbfed7a01ba11e22bb8bb272e08e_Z},
+          {0x206e54ca53a2f155bd4fc45bf2edb77798ae6623defd4cf22f2dd4a7d119dad_Z,
+           0x6c94e7f0825ad81680e4cdbcaaaf4df806d57a0d1fb2331926c3fe2b79d22e8_Z},
+          {0x56e98d2862893caebf66180e84badf19ffc8b53041eaaa313ae7286a8fac3d_Z,
+           0x526306f9c01afd6e0c1198ea5de17630f5a39c4ecd02d8e6f0d613c355995c6_Z},
+          {0x4fa56f376c83db33f9dab2656558f3399099ec1de5e3018b7a6932dba8aa378_Z,
+           0x3fa0984c931c9e38113e0c0e47e4401562761f92a7a23b45168f4e80ff5b54d_Z},
+          {0x450cfaadfecdb8a2fbd4b95c44cb1db723ee5ac9677c9c188b3d7c8eff4ca58_Z,
+           0x1a552bdfc0c81be734f1f6ca9a6dd3ab4daa61c11fb53ebb7046eee25d617c7_Z},
+          {0x6fe20e5c8a8004e33eafc84d16ef770f2f0b7bace19adaaa150f987d295a34d_Z,
+           0x28a35040a2ebe9a14a162d3208d5eabc6e2f3a8310f926bd80be65aa71775e2_Z},
+          {0x1bd65f45a35bf62ae8f9ffcbd7de2976b90518b6820c219f039c50043bb1edf_Z,
+           0xfb5f0f8659f9b6ed7cb0ddd7999506d0c20b26bbe69d1915a31842cfac41eb_Z},
+          {0x4ba4cc166be8dec764910f75b45f74b40c690c74709e90f3aa372f0bd2d6997_Z,
+           0x40301cf5c1751f4b971e46c4ede85fcac5c59a5ce5ae7c48151f27b24b219c_Z},
+          {0x21cfbc678f5a279ebb6ed124273c8df37eaf12a2d04180403ae6b5ec0b1e1ef_Z,
+           0x4478ed6a346d899ad7b0b10350270aad39ddd5b68529297e4c91a54357f0a7f_Z},
+          {0x350bfefbe3d864eaadac9cc1195c14159bb736be743aed7380d2384cadd2046_Z,
+           0x5e2a4b3ad0e1d7b9b8ef72b10d68a80e5ee691d7db591fcfbaad6240d41da8b_Z},
+          {0x529acd569127f73c8d34345f87e96cebfb48ee12a00a3861cda209337ed94e6_Z,
+           0x3120671a89b705e5bfd99b0e7fd2118b4914a3ac309b3d74527cacb5ad7491_Z},
+          {0x55d3d7956a97d10e65a4d8ffeba40deaf0db0b57f8e022cdb3df6df613f5c6d_Z,
+           0x159e59a6f92f48fcf85aa96c1a03749a4c4e2cf9e2bc94dd36796daebd9b8b9_Z},
+          {0x405f019ee8f2e972a005c549b0884b5051f63d1e78480b73208dc07d8c65a1f_Z,
+           0x4301a3d0c285ad309ff24a12c100ead7f48ba1368143712f32ac141ab4d9e8d_Z},
+          {0x376d59b298d982f02dccad0edd5bbd4e5e8fad7898750675ed0856850a7babe_Z,
+           0x5233b12bbc50564eb61cc098a17d3d97f06ec7a230380e4c5d3b725cc318eba_Z},
+          {0x2f55624af6109ef04b2ed035a44a904ace8627f55889f011f768aabf4de9a38_Z,
+           0x7f64209ce7dfb63337ccf3d8c14f4093295f86996cabfee23b1655549aca089_Z},
+          {0x3b8965e942bed2714bc2e685fb103496e1e3595ac6a343d6df45fb5ef6979ed_Z,
+           0x5b7cac7a165cb69ae103dd9052fb39c00ed0aad47989005aee53972d82d45b5_Z},
+          {0x7abfe3accdec1eae1a50049efdd9a8eb7c2921a08e8bf1fe606e9d5a4039ec4_Z,
+           0x3af178e7e831f8148244d2d2b284a32991852db6212ad0a9d77540ef648a5fe_Z},
+          {0x4983196df6ad7d6f0a8d76f86af3863ad8611374a03fc0fd00793181dbde9d_Z,
+           0x204c1f91b70f975a21d24a8face664e496f00f602daaafa69a3b56098a4cf89_Z},
+          {0x79e2b91c1531a3b16dbd53e72d94e16bf265cbec261658151acfaea3718ea72_Z,
+           0x3d9bdb47e8b148c1c5e9e694ffbc2cf71aac74ae1a85e8d8c3f77e580f962eb_Z},
+          {0x297efceec61b3be17565843cae465c52524b4ecd9331a4170f54f7de8c4556c_Z,
+           0x6ccef1733624cc8b973ac63dd54e7a53604929affe81c3439525ae5ed6af993_Z},
+          {0x44f04b1966264a23ccdc870c8563ad2efcd4c8087b5469b90e792287a5581c7_Z,
+           0x1c417f0e9829fa3d3cbb7c3cf4dc7aac04c5bf66ff3f86b833a42c533aed1fc_Z},
+          {0x6ff83f5d8b51db3be0bda80eed2e2adb7037f2f58f705e88f0f98197431ac26_Z,
+           0x64f59b8428894c2b7afd740866065ded42e716c7d48accd3f117f22768ed9fd_Z},
+          {0x14aa8187c9559f77cd1cf96b2dfc949182529936f2b0b4050ea56e134073b24_Z,
+           0x5f36508c68b1dc586f3fd3f4e2bd29c6d8258491b8a6aa19ede811ce0d3d0a1_Z},
+          {0x95e8882a68c5000d1c2be7c0b43e7f2a6f8de906485241f0285a5c73a27a83_Z,
+           0x1e4cb67207ab73bc1e5d19fa2146fde6d03021393b77a55df4ddda1fd28f5b1_Z},
+          {0x2ae0704dacb3da47d564514b4c3543505b403ba09a248c6e74593cba1867ff5_Z,
+           0x5a4b5818088dc9ef4066b90a8893ae80fc89584f987ec1928ef9d72cea2bd67_Z},
+          {0x61a10898a76fb99989e51c0e823cb60b95ec7ccccb917c42b2b28014f5fd94d_Z,
+           0x23d8ec1de45366d3b86c64c2da05a2ce3d171adf52ca5522e652ffd0eeee795_Z},
+          {0x79884133c879cf07734976fd64de220c5a972e04c2a3afb74c362d6c3beecbf_Z,
+           0x2aaa0e6d4891b792b5643fdf09873343cd0e3fbba3cbd0601b481a4083f32b6_Z},
+          {0x45f73d2fa82be6c5ccd0f62d2237efe8727c479967d27cce28e42b9a44bad5b_Z,
+           0x2fa4932215f72d56d8be5205c5851c9b3e5f2a14468e4a7acace5437c6b27dd_Z},
+          {0x37f53f771850f52f9c8f87b53c6bf0c93c2bed76f5fd1d5697356d0b2325007_Z,
+           0x50f1a052b79b446fbc7b93ffa1a4515f6c3be3a76a2b0bc5eb8ff327549960c_Z},
+          {0x71bd6d23e0d2f312d47582efa609101f15b9ccc571fca8ac4fe3457c67fbc9b_Z,
+           0x3b3fdf86bd4c7fc26d60540a6439b4d179dcbf7b91efb0ddc60dfbff9a148c6_Z},
+          {0x78219ba049438385b829c13a4993874a4a326c4143de0dd581c7b9956f99b06_Z,
+           0x5505f1268dcdd4ee01b77abac3bfdcbf3f0513ab097c69ff777b4a631aaf256_Z},
+          {0xb81e924a86536dcf68bc5a2ca2065a61103ba6c9eb0ae4cf8cce9dbe286f15_Z,
+           0x653a6dfb51acfe8a844fb8362795e5549d424aed88d3a090366a44f840b5b83_Z},
+          {0x441c0d7b7aa705046dc0e07ba5f33a7d9df23f694a05192ff8c2d7be2aa3fdc_Z,
+           0x4c06568c0902bb99d428bfa0a946ed0f0ca0a51fbf07cad88e06e9c78e38a59_Z},
+          {0x2569c8c78b6d6b92533f29f767c95720d377fa63ad5a3b9827ee0a74b0488aa_Z,
+           0x4b59c81d3cfe08834f946d9d57614f5366e0bcd9349475aaaebe01341196fe0_Z},
+          {0x3f2fa285a0471647b214eac652bbad9d58a9f2dd2e812aff0210d0d8a6eb32f_Z,
+           0x4cdb18e1c2848c2b52c1a6557165bd1a8f55c2f7562f5cc0b326f73c25b696c_Z},
+          {0x5bb5141ab4fcc5290ae9151b8045a2cd8391547ce7b3b33cbbb10f8fb538092_Z,
+           0x5a36bfd52acc6a83a9913b937ec086cc27fed030b5fa70dbc5d3c12c9515f56_Z},
+          {0x3f3fed272edf91aa7f8ca5d70005d390fbc67830ffc69c5fa3ae17582d2771_Z,
+           0x459057e0883c44d8776fa217405f443e5954f08c4a5db68e437becaa664a999_Z},
+          {0x5237ca6656237a717a739a4509f70db1b9dedbb6cd232f60c9bd8c4563a6b1f_Z,
+           0x56c7799dd02896dbe7d69dd8bb9718270549592099569d107b7b49c34bf5a49_Z},
+          {0x1cf6b8499ac881e0b2fc7def9bc1a28937033b2fc52de99e75909a620c7a281_Z,
+           0x5769cf4f735366fa386b6858043dc99a100f86fbc77b16d57d77766197ba27a_Z},
+          {0x1b74b8a6b86dbf9638cdb0601e1a332b8d880753423d38c3394902c57f15e40_Z,
+           0x6bb2dc10d2ecbb913219d0ebdc8d3337d644ed8b6c4e70637ef4c7e50887488_Z},
+          {0x61e4da415661bba52a4737e2bcde1a837787c4796b2e1854778534f1582c29b_Z,
+           0x27c43e632cb7652e8508c9c38e3b4ad0d3dd6ba748d42dc84ec2685e64b9aad_Z},
+          {0x7c460a204d23f20ce86596dae6ac9b36734e4a9f7c5b43262c97a36c6a41c6e_Z,
+           0x481a11f9300ab4c4bf6924c5ca884728cc361247377065920966785d043fbbf_Z},
+          {0x124ff5e55e4effa40daa5b9618d75c49c8b6fad95cbe8c0bfdd83cb9bed8316_Z,
+           0x33a2ea15d0f71f58a00de71acd7f22ccf9002115e49dd1f7631faa0d32f9987_Z},
+          {0x61c9f8fc86715e95ff43583a865c5a6515f93381839d557ef884a68637eaf4c_Z,
+           0x5877daaa42bbab9083b571e12648a9d62ced4470d71653092b6546f4a5acceb_Z},
+          {0x70a6b9a9e5d1fcc07dd9ebef6d8f5fcf04c6cb34932d0fe2335330ac6dc8d3d_Z,
+           0x3f0cbd332ac56922e886656bee74f6e9bb4bb88f7af7bba9098678af1f38fc_Z},
+          {0x41db8a0f1ea78443a39e08a54323743c8897eed1ddc28f41aec6f2655040d9f_Z,
+           0x7d4bf32f8f4719c2e4af8b7889f3b65cfdd033dc2f971798a12170f2b26efce_Z},
+          {0x62f035e01acdfe841104942d6c8c07f0fbd618cb85998ea24bcc24cfac1f8_Z,
+           0x1caa886104b7d753fda93645a746989794cd825c62473b526ea34b3d51b5771_Z},
+          {0x441c6f016d270e86c19843727b83b864cec060cafc813b23d7e41e5abb1a60a_Z,
+           0x29fece4e40400f3acae0586f4fc8ed535e805e472123ec38d662d8a0b01c086_Z},
+          {0x2c791ba0fb0b66177815c98191fa6188dba9c795e34a7c3c8a19086215e3cee_Z,
+           0x11123151389d4b330db6a665a560407e7cd8c3807c749e2b0cffd9c3074ba77_Z},
+          {0x5292da4ca71ae75ed0554c267747e39c7a129b3b863e1af3ebb3e368439c4ea_Z,
+           0x63af6a5016deea8cc674c44f16c63c1db31f09af4fb4d2ea7917c28116661fc_Z},
+          {0x3367388d5d1b7758dc3d92e244f227bb8a54e3d9909e7b7dd62ab5965e3efc7_Z,
+           0x7ffb4833071e4b03ea755ccb9938487a478248fe9b1158a08f1ac298801c092_Z},
+          {0x95c863314b7f18090f8eee602403be823a367a1b416d54c32e5f914e67d922_Z,
+           0x159c2824f899171deee23e0ed520d4825bd667983df0a8d45d3a1f7156d91f9_Z},
+          {0x621c6e08b3c57404644ad49ac7629832c141273fa1f323781b3395393fe985c_Z,
+           0x65d1eb0140652958c4371ebec791e03317d6b2e689d90e304666f1b610783dd_Z},
+          {0x54313129bf13993952cd2b31ed06013aba85e74c1b8a00e062031f32188a84e_Z,
+           0x680129efc9eb8ec07fc180e8f6877e5f0f9f44e3000a2c586ed4ce49d12a313_Z},
+          {0x21ea57a1c8286bb45872e78617853c47b89091670ba51c124afa3362e7260d_Z,
+           0x7087e5c1536df233ec9bfe2f983e8d7622892b9bf64c450c9823898e2cc2fc8_Z},
+          {0x3793b05b99e7a57d88db4ed0dbc3b771285abcd9052da50f88595354409f3f3_Z,
+           0x12164105041c056f127e737c7cd63981e05f246bd2b6b65d1f427019c7c3801_Z},
+          {0xbefd345cef5fcae22ac37dacd6b9128cc58cbba3e3fd774e11b421c2ba392_Z,
+           0x6209d25f24f88f7876ca604db23d05f78e6b3b67fb033f2f1bee221f352b8c8_Z},
+          {0x15fa536045fda4c65ff74f10b4e669ce88b9996c6772288289d3ad725987fa6_Z,
+           0x30e0c2124a35e265e931ccc66ce5ac3697d982814beb407144ff6762cb691df_Z},
+          {0x38b795bd77ac573576dc204857a488cac2cce19809882631ca2069598c577c8_Z,
+           0x786ba555d55ebef688b068bb9186a34a08cb00bdfef51619bbf911890ae9a13_Z},
+          {0x6c66853592196c3eb8d9526dc155205e2c64097adf8684bb0e15eb460ce1c72_Z,
+           0x1bb4ebf654f4250c8dd1061a4e1b464b31a8a9999ac9960446ef8108a66871a_Z},
+          {0x5b08dfbc87ad9c00b88e78816973ad2f9c10c70f2156908892cc7b7a2a1fd30_Z,
+           0x1151f407a77e2556073173d8f5c9ff561d8a23742121ca15f7d0ac391af50ea_Z},
+          {0x309190eba106aa6ead54b5ca5817969aa68b4b4c627700799a49fc6bdd32ba1_Z,
+           0x505b6a2bc7b0d78ca6ce2abe7dfb7312369918a4599cccf8a615f6701cfd851_Z},
+          {0x89cc205966af08acc8910d563af7443d5dfbb5d88dae79c013c678c65dcecc_Z,
+           0x1f8cf955694b246a423ac725791231257b88936e00347ecaa1e17045c0ab540_Z},
+          {0x480086b61a80c36cf1e1a350baf554e58ee8d9333186b70c9c512fb9e9d5a84_Z,
+           0x511edfe58f8d36a6170df743731da1ff525cfd5108be20e30ac4183d1281570_Z},
+          {0x3caf14fb1d2e90a13ad4eb091250fe37133aabf6029633e905e5a93ead41dbb_Z,
+           0x49122aff6059dfda19e4b973aba5ebe3804c91728936c6381c1ed1ea9380920_Z},
+          {0x66d1b8fb2cabc46cd79741ce1cb7326077ad8ea3227a6427244bdd3806bdadd_Z,
+           0x4a52eb74f4d5371ba3265dffd61c844f9e68d4ff0b44dc4936182f9280bb66b_Z},
+          {0x373330c5afd53c31257fcc9050fef873e15ea9f81d9810f30744309b04e02b3_Z,
+           0x5889806607b3dc97a9c5b0c8a2f16d1792099a22866b879ca480cb89a11ef5c_Z},
+          {0x26840d0ec69a22c6818ff64b8b14633b531508c866e21d1dc9239778ae9e8c7_Z,
+           0x157971f9a6e3a24d3b307be0e7c8cd352e2eb5cad33cf276270c0f309ee63fc_Z},
+          {0xebb84848f1c38c19a754d1b5d9460e39624dadbb30800987c9419c0f933b9f_Z,
+           0x517b297cf32f4064e6d6c8e761ba8db89809604a701c7b3aa1a9c6beb370ea7_Z},
+          {0x25780380bc0795ed0dca727c55240f1d63593e552d224adb40df2d3721c0f66_Z,
+           0x10215fb5a893e0275e9f1f66b217dde35addee91ed0e8f7d79531a2ff57b8c8_Z},
+          {0x243e1581cd1abfbf18c31c19a4c3d1cedfe69a40bb57b607c9af2717eefc742_Z,
+           0x1296c27929f14535718c3a4ebe045f00afdc60afc74c7d398d8ce1b6609dc0f_Z},
+          {0x48babb8649e054bc8e0b902c89e6940c265f48464520649502ef1064eb94562_Z,
+           0x3235be7852b0526d1a16f6969ec0e5b0e09cedaadc65863dea4e47f4f398264_Z},
+          {0x592db7c27e63489ef4bcef2eafce89f40067cd9a1ba48bc3dc76b5fc62ad9ca_Z,
+           0x48b7711b570cd9ac65910e75e752f4b751fdbfb4091a28f59b8c046d3d9f8bc_Z},
+          {0x31d133456222586ae42a9ec7ce8539ee04afbe0b2ed00a2564dab0798d9b55d_Z,
+           0xa77c52fa1fd718db5c83e7fda6d7d4d9aafef9ad95cad621470f2b753729e5_Z},
+          {0x4651668379883521e7983aafcb93811b4a72ef2975b3277773746708ef3e3fc_Z,
+           0x512507f3f544d80ba5d47f73b571881e8d70d7b1d305b9704bdad036b7abc47_Z},
+          {0x26069e359b2e847affaef604f772f36224608b7642245d0e643889ed231bddc_Z,
+           0x75ae1ec379f074ebc91270077c74b4d34347ce183b676b4dbe100bfff143b9e_Z},
+          {0x3196d01d1fa11dc3803b4813c4bbc6326869f61410f2bd14bc0f570d875aebe_Z,
+           0x20313217cac79875bd2a503db1e86d1e5559911667a02524759344468d9561d_Z},
+          {0x483256607f75f06fb126addc60cadddd602154cc4782bcc08351a48745d0b97_Z,
+           0x2950a7e500ebbe9775f08be37cc2e62ccf9030de18948d1bab07a4a9173f75d_Z},
+          {0x65f07b6050a2fc6eebe2c29ffa62f764060f7f9d3c82d2cb5e4e368aaa442c9_Z,
+           0x562c9654b646cb84a213b41de203c871b3eae0a05c9c105a66a53c319c06373_Z},
+          {0x284870f6181c43f3b01d94baa9c5b6ada0deb861145523ad9169580eb7bed35_Z,
+           0x5e03e6c40c1cfa3cafb01fd0622349871832a9d35499d06408a83edc1b76d02_Z},
+          {0x32229810a52137f0e6c3d37595c46f6132822d4b05f42674b48d7a7ac3ad85_Z,
+           0x7babde959a0cf2c53ee59fc52c77c3adf899453f077f441965629f9aead30cd_Z},
+          {0x1ea8b98a6b85e74e0a2fbc18b206e290f3ed94ce99ca665e8e2351dfade990a_Z,
+           0x478e93c4724115fb1648c8d5347422adbc1a0bbf962b2312e14aec80e1be742_Z},
+          {0x270cbaa08c79140c85b864475a0bf569cc03ac785e57f543dc444f37ce746cf_Z,
+           0x3a9b8d894016680ae9d1bf3deb931d8987d4d8d8bfed45b81ccc595ec79046b_Z},
+          {0x6943922708b8ae5b40dd7031ef2e487abc4ac39a3591368285e83d6c9c51f4d_Z,
+           0x5f157c37d09634e8cbfbef90ea50af59815d011e419a691c67ca3402b5efc33_Z},
+          {0x48ac6a80979fab4912cf0cb557d917a0bd68825d8658ec100496eaae6ff62e1_Z,
+           0x2b6931350ab183402e39476340eb1177b7006f7a552915581e29a79bd7203a0_Z},
+          {0xe3adf9517d92ef22d1e2a787740a292ba32d5ca69faa9e8675f63ed816dce5_Z,
+           0x36bccf69bb12dadd610145a3399213248d193660d8dc90a2e206f23bf2c7997_Z},
+          {0x5e6c8ae5afb2fa470f767581f3d578cf6a49547e4b78665edfd45776948bef8_Z,
+           0x6cbfc11953dd7e195d2ce74e52a60df524767b44c4608bdd755be4bc85eb74c_Z},
+          {0x15a576a1242d39300f0db3ad770983825988da0457718ecd596c63a0a0eb4a6_Z,
+           0x69a42e5f6f5a63349b57683a4609bba90f556a1680fa1ec3b02ee7d3211f903_Z},
+          {0x274cd14e4fbf2ed07402e8ad8075b320c5f76b7ea45ea36af523e95ed63ab50_Z,
+           0x6ca640f9557c5f2d8b27f6ce95b108880ff4e4816b26b70b6506114389ce656_Z},
+          {0x4d8284e132e2fe81c5f71be1e3c79ab51b229e2c56c323e207cda179999d123_Z,
+           0x116cfc00e9fbee1cf16af6282123cdf20eed13021c2037ef4c86f94eb6e6cba_Z},
+          {0x4056194fb5643e97991942ef5b63cadd89080bf57a01489c4398aca03f0980a_Z,
+           0x2e2cddb434fa6f6da7859c3d518f0ced8795eea043a6c9613fb3e020103339f_Z},
+          {0x5d119d5c5ce532afc0875e0ee9b026d878c8773d34237f90a0d0670da6f01b3_Z,
+           0x4a79fc025ce076b6a4742fbcc8cad313d0a8220c58024a41a5a674c0947e64b_Z},
+          {0x11800ce4061d99b9d53fd4138802335258f7798c5a935c9979f5a949ce1d483_Z,
+           0x36745a4741a5c7290eaa8f2a3f9ec955ccb7ca323272e5d35d35c2a724ffac8_Z},
+          {0x4302525bceb97fa642fd5560a4a39fba3d2c06f68e6aff3332ff1854439ebb3_Z,
+           0xe31edfd081ce82f8177b2d7d96e69851d09e908c2517114ffb37ee12c0ac64_Z},
+          {0x2f5fcbb96f0a66fd3bdfbcc78bda361cb812570f50e7c476533d56eee01c0e3_Z,
+           0x527428a34855b5695c479d8fb7e831a299f7897f36682a74169cc60d160df2d_Z},
+          {0x52167df045ad0dc999b98de3d035aced9da4434211149b8cf4bf20e774580cf_Z,
+           0x19051d2a1ad3fab190c5dfaf45188b49b4e90cca22aae54f0a785562d3d3f41_Z},
+          {0x541b5332491dbdb2b6f6bccceb7634970c046963891fae936dd950f4432b961_Z,
+           0x78fa54da996a51e3a9c06091d58c2405a806649da2bb1f323807c4eec50eda2_Z},
+          {0x5f11e973da659b7738f87ca5bd4f3bd02207dd3c8d978f0d3e83fe81030febd_Z,
+           0x137aba7027069f62d25caed416e13537687bb1428e71e5f0a0c52d52f2e65bc_Z},
+          {0x15ec941ee6c2110b819b5541be52981c09d83484c9dc735c43f39f5778718b4_Z,
+           0x4561826142dc5b56acfcf605a78a4090472bb61235bcd605a765e05d0a7e549_Z},
+          {0x68ba398736d659522f484406110b43c68158bf4992094acf797a38979c587a4_Z,
+           0x7c1d9e1702e28afddf22fed7a7a79df4315c174d0c6c4f4c75bc77d9b56777f_Z},
+          {0x67889cea31c81a429fbae643a4fce0ecd690a5c32b99397e39ed6d7a08702df_Z,
+           0x7ea277c80b671146c9e455b98f42f45b941ac95ca2d15c8fa9ea82ee9b45e01_Z},
+          {0x596f2c68390ac26505d3c2eca5c77d46f8f3acbed192a2649d8c525a58d2334_Z,
+           0x49f3bd8c62c610d5c19c52d970bde24b270c4ff7ae900453b909e72483974a0_Z},
+          {0x567779fb8b0afe592cea284629e3621ccfae3c4d7d3dc559c9fed750591a395_Z,
+           0x6010bdc33f1cdb374facefff537e7910b72a1120502f312a7ce41df0d552ddd_Z},
+          {0xcebed0233e810aa6a29a8b0829d28f1c92f303d14dd73d6b12da98117dfc7_Z,
+           0x4bdd51e1192a00df23aa8d0673e4915877ca41ddb8c9eaf21d39dd167fde7b7_Z},
+          {0x4c7085f066adeb6781596771972b188177e63f2e2b3788d03e033cdd5af1f06_Z,
+           0x2929ee89f525862b0cedb3ab9b5166e1680cb77fb4668f10a6a3d76b5434566_Z},
+          {0x760e341bd836899c226176f47685f69438270c150c6fe7744cd723cd1e72359_Z,
+           0x1bf09f2f1aac1a10ce8bdf20d5d178db747f01a4aa0aa8a5e4bfeef562cd94e_Z},
+          {0x6016b94c00b54920027ef64902c61478244b1936337d2ad41d9a8d43dd6a4b2_Z,
+           0x3bf3dd9bce7f6d6f120de87fcbce6219340b59c2c1d75ee0d45105d33aab1cd_Z},
+          {0x4929e44ff692eb944d1045bee96e750219cda3bda0500029f0df49a1db30b5b_Z,
+           0x2e138dcbd092242699004b4ce98764ffe4e892841f56830af298581cd1e523f_Z},
+          {0x5972d0e526311bacb70a04e88969b6c63c7399b578f0dc28bbd00d65ef01da7_Z,
+           0x76b22bca9ac12d26530e7b0757e646beb3bbc5680d0f3f82fb8ee57ed4b5e39_Z},
+          {0x2ca0a42a26e26934ca2d48db960b4719113d87c5e57fb437d557c5eb4e03ac7_Z,
+           0x62778c02561d4ec5d83a132afd7763a8349207c6b5d01fba70b56ba660cba2e_Z},
+          {0x5137ee53f076e21a2c23da09f63c0d275408c31e4634a6b6373be5cf13e6c00_Z,
+           0x14fb446c077beb78e04de3282a63bfde12f9af85caaca4ddfab506cee31c0c1_Z},
+          {0x7d944853d1627b63f560aeda33acf640d35a4ee4d23a744957a2dae9d5b7c6c_Z,
+           0xbcb411a210710acbcb9ea12680d89e3e4e652228b6786d3886e95f4d9e6970_Z},
+          {0x37d412c2ffb173a728477446b60b2b702d07a5243cb5fc8963e623a5ee75843_Z,
+           0x672c79968908f92cd0cb0b4c65ba86e8f359b015623a89441e1bf859bba84cb_Z},
+          {0x5b37f472aa80398bff12cc74c8ee784c4fc89757292580d3a498bff17e9f114_Z,
+           0x7d79da1aab9cfef58a5f3d1c9ec466956a45f8d2af0c1da6dd4c93f720fae6e_Z},
+          {0x25c09b3f1188c562571536202eb0f5fc4b9a7590417b8ea58b4343685d88a63_Z,
+           0x3d5b817c73b37e9a1d24ca923351359b42ced2f3cafbcac8c2d6322dc767bb_Z},
+          {0x32e60904e73f9756f71e0a918d302aeca17cad4acacc81bab15702ab5ff78f0_Z,
+           0xbcf4c0204f8275072f98a65b09ac58b87cdc9c70c4edfe99fe18870a3a5459_Z},
+          {0x49c35575996c1517d2daed90d2fe4a58e674d6b4aaa7288d0642c8bf59e562f_Z,
+           0x57eeee00adea4ca80eeabab57852cbf03f1a57e21872cd44221e0550b9193b8_Z},
+          {0x10e1776b4c2a867bf1b028c6edec224cc6616c747e272f49e69b67b02a893dd_Z,
+           0x8d45d62ec8e627b56950f2f7622a0438647f9e9f28e723e4a37cebc039a1b0_Z},
+          {0x79a93a75ecbe943acc964fd39ecfc971dc6555b2bc335e7b53f52f4eb16cd36_Z,
+           0x146132a68ce2ca8b48363612226771ac547eb3cf52b6eb7981718faac08aa3c_Z},
+          {0x6b22d32e0590e169504e7f19864fd646d0994e7ed3e578a5b88f6e095913439_Z,
+           0x68c3b22d859fb85e5c8fa0a8aea932285945b230957e603394333e9ad5acd82_Z},
+          {0x71ce5ec8286eb8c93b8481c6d19cf0a288ef4da4397e9c80f65023e516bc097_Z,
+           0x54470babc742780cd8a05499026e738ccbf81d4170d1731734de68a8e5b402c_Z},
+          {0x27beb13a43bc6a1f6ce046da438b0beac5899ff4d57962dcfb6476b563f74b_Z,
+           0x14074e9e93ee45394dfbe833998b9d1691961f8ba3166224b36404448c61bb3_Z},
+          {0x6b1de6c8f161aa6509a1dcacf2c0aa1bcf6ee9d9b40e032a9d72f77a6fa298c_Z,
+           0x5e9312eb5b59d6cbadd7d3dcbc39f1b5bd9a8346fdcfdf1107bada6f9cc048_Z},
+          {0x32670fc3fa43bf39974ba72ea51f0d045d92d084a81fe5282dfc8309aa900b9_Z,
+           0x518fee521bf1af62356aac3b7e53fdbf57121e030c6e9572b3de69912ca4eb4_Z},
+          {0x4b9ca363eabed9c66091a347375f7065cd28f49f914447de7cc1461f1375f1e_Z,
+           0x3a1a3a2e5e7e72476befe2571ece708052d740d02cbe6fed58740968ae609c4_Z},
+          {0x4cc6da42863a3deca62fa218b7a3b50e034eb4bafd393eccba3f4cbe192ef10_Z,
+           0x20bfa683c884f203713953b26d2821287ecd305fa2cb70570474533fc07f918_Z},
+          {0x87705353c44a5ccec8de65cf5433be6b3d9bd21eea49b60e6c907cf1a67a6a_Z,
+           0x112804b13eee56e3b01aff75fa08fa8374c44fc461aed8a30ad54acd09c24eb_Z},
+          {0x6cf6eeeb9d339c0a05f72fd5af73fc7588e6d957100ee8999109437bc126cae_Z,
+           0x54fa257cea22032eac272fcd034dadf2e00d602ef9e519cf7072023c130aad1_Z},
+          {0x19b32925048c5519d929650c833661b452ef7be7963fab0b6b328ab7dd7a28a_Z,
+           0x1bd0c14a10bf9b88ea61011c0b2e64d07da151c6203800d5a5d12063838a510_Z},
+          {0x12a5fc5559428bc3b4eff97b21b63668b866e0722807f1db1f19696bacd9b0d_Z,
+           0x4c2eb07f0c24047a3d73b560144f3fd32c99d6dbd9fc7cd2fd2a72a6e4b24c7_Z},
+          {0x13662b7a7d390aa76eb86a7c3bff6d9913eb28db6bd1a7c42de5cdad2e35ce2_Z,
+           0x40626aded7f56f82cc431ae30527b096f57fbfbc04d3e12a5abae3edf301cf1_Z},
+          {0x255825bd49b8a2cce114360bd9c8fe8c641af64c8e7710107213cfcb006f43d_Z,
+           0x3619cce4482335232f9e76a1460be9d296f2d468d26e4f95a78c71524fe59cc_Z},
+          {0x7f83009eeed4f12f54d341bbf06066480cfcdf51dda103ac54d4bcecf6b3b31_Z,
+           0x4269519d28faafd7fd68bebfd8404d71ba05d62c4bb6d65d24aa6802fb84ab6_Z},
+          {0x2f325650eb316646b4eec903fe44828fcb11054f1bd42ca3a77f7e734110b35_Z,
+           0x44f976082271016f9048e22c507d97d628722bb431f8d5cc1890524e6c386bf_Z},
+          {0x750b166bb6edc0ee80fae39c7c106879036738df2d79fb2294e1c21e9a24d6b_Z,
+           0x54f8aa297a1afafe2a17a3254f45861167414327e918d17003c6aad01d0b24c_Z},
+          {0x3aedb10db9cf3285cdeee375879396fac1fb50dd259e1716f8c01e66f67ca72_Z,
+           0x7feb9400f621f58c21601f23b7ec7c94a9b6b193c1cd74a8a60846aedadd359_Z},
+          {0x4ab7151702de76faa493e7a0b1ac20ee4d10c33b83fec9477547cb1236973eb_Z,
+           0x63f1f122e3ef3acc46b0915ac69c3f5772879799cad889a817f55f5853d1235_Z},
+          {0x1675ead0d20e5bc3a7a7331999a87ac4c916ae29669e54197bb02aa6364520f_Z,
+           0x4d1122da90d49e491922d9b533a6a668e2f65a2737ebb391ebb29fb7c1f8a9d_Z},
+          {0x2f7148111ef53c613157aeec12e16a20f13481da4390b6ce18a85d1d8547087_Z,
+           0x2eeda779ab395597651d2a0b833ccf53b10280750139916ae2baf4ec57c633d_Z},
+          {0x4439c7810e7b2ba772b701ec3acdca0b80c9df23047710b87f7dc3f13b337d3_Z,
+           0x5029cfe704c602a8a4662af0a5860ec03fb88f046d0e3400f2ce7638014c621_Z},
+          {0x2248eec40b5732a6a488b681f093643af7937071bc73118acae295a32b51b05_Z,
+           0x1577e4aec30a97b648de4d0b19cf8891151b4eb11f8de9c6d7312f091552e19_Z},
+          {0x4738424e558d4e0d87a3124ca02ea24f0adc6b7a9768b0d3945ed2a6104857c_Z,
+           0x33576f92aca3f0c8ae689c3c274c2de6b918940d86a6852e02fc99e35d1614_Z},
+          {0x7829edd8b866ebf7baaf604ed13d19a9797578f44bbc51b1cd67ca53803e96b_Z,
+           0x5559040a6083f2af1f9133ccaf5bc2ce06e56ddfc7dd410e9635c0116b62722_Z},
+          {0x7f927b881f2cdc05e1a69e40bb714af47b630d1425f08ab5d574ee698f33d51_Z,
+           0x26a465288e96572de303203bd38f4a03031e8158da0591cb037c0a5111d1056_Z},
+          {0x36a65598552f8753580d1655417d645a140966e10a1e1663015f9fdfae44881_Z,
+           0x33d5bbfaebf59eae72b89b1aea12ab2ba3c9617f8c3baed1ec16bdf668381b5_Z},
+          {0x403becfa545c826782026ff409cc16c9d4fe428f1b5b6e630c92439d2fa5fd_Z,
+           0x47bd6f2bf5d74f710ecb479c79b01fb774fbdad590e683a415cdedf33f71dc5_Z},
+          {0x3a747826d241b877d3d56b16e0b810cf088eda4fd6048da174c9991a942a5eb_Z,
+           0x2c7ba19b0a3486a2cdb84d4a388d34beb077a0e467ba44590166f93f6a09d2e_Z},
+          {0x3d60cd375842714b37bda89dd1f13a7e0f3ff133b522209617d031bce05a537_Z,
+           0xf77f216451ab01ad5226844d2162a7f32744688bcb4325445539e2ce5cec4_Z},
+          {0x235bf66f67c9100e7f0e22bb299cdfaa603644b240e0770aec7e7fd163e2a65_Z,
+           0x37110b3fa83ece3990afca2bea8d5ebb3c7aace60a0147f8e6ab733e2f2b4d5_Z},
+          {0x3b796d4eb69a55471fa86108f787b3604874e92b6887a7667a6c2bfbbd9a42b_Z,
+           0x4912d6dc0419732ef82cb3278415851d4e2d7ca89e0f4d7128cc9de51b810fe_Z},
+          {0x48d53516dd51e49faa7ab46c8c10db1befd10f23c6a9d9bc3640a2f0da44518_Z,
+           0x73a2fb3d064adadf21aa1362c04affc660598f38a9e069b3afb74d0a99ae9ee_Z},
+          {0x48c32cff161ed145da0d5b73084897647abb777adf65738559ceab6939cf3e0_Z,
+           0x3d99308978e828f857c382df32b472bda81e8ec8e30c8844077ba6d6d2ba903_Z},
+          {0x2947ff091a8ec9684affbc9a62e09e598841c4a6dc638088492aa47dea57097_Z,
+           0x19a2cc97975e547f97a4d02e42f89e6ced6f5a953cfccdec347867d26926541_Z},
+          {0x1960d85f30475615f82484eba0bdafb7ea7cac3809f0518a757d66f02b01676_Z,
+           0x36c8f77baabf0cc8805d993bbe62041fcf4e3239cf9d53278a4fbd91e75eeb7_Z},
+          {0x2765f28074d21d5a055340b6d40092d2bbef807e02009fabfa08ec0b9bdf38b_Z,
+           0x7fb189e0553d5df52b6843661814824b3f3cbebbd54988f042fb256c6bf30b_Z},
+          {0x348836cb2aaa00212f4b1a4e2d7fc5417f246bf2fe5c9a16ebabda449e2e08a_Z,
+           0x3f7276fd7d69e0d55ce5ee1d2d830534a27227fe0b6d8a36c93f9a78b872969_Z},
+          {0x7afb9d34b6a42ea8c6d870e4b8191c274201dc1f93a1a2219a2392b7e345a31_Z,
+           0x42bbc20dc7115e0758b364a110227b16b64ec58fc535ce5ff1a9ad8b8a09fdd_Z},
+          {0x2cae0c2afee1767fd4c66f52e1f176d217e92e89cc19eb36d5a6c1715f641a_Z,
+           0x5335efe2d9bc3667d25ea88bf76438a4d6ab9ba5c512f9da7d0529b79b62d83_Z},
+          {0x1cc5fde334707723c3a06f00c106db88664284a2df47bb6b144d9f960aea3e2_Z,
+           0xdbbf610d100316938bcd8bcd078513512ecb50d4579690dbefaa419c05980d_Z},
+          {0x54e90cb8f3a2998d2675c5780679e06c0556b1e618f8fdf07f9a4b2466fbf1e_Z,
+           0x16248676b6f06ec5e34994bc3115f85c8147b54f34d8500928f2fdc051e2089_Z},
+          {0x525c70a2ba0dbdd68d75640f47f13d0d415ea595f7030f533f4625c2a46523b_Z,
+           0x58292c8675e5e1a438f49e0c05648d9a7aa997f2f1fd77d5de1944afe5d7eea_Z},
+          {0x54726d78d099007393348787a03107ab492e59690a46c87fb02ec554f2353bd_Z,
+           0x53b54b77184ba75a3391e0ebfa6d6974db028f3f8e34bbd5460759a5848dd76_Z},
+          {0x4ac81a66903537769d3aac6c483ccc08535cb767b6b5e1ec8017a7393ab70ae_Z,
+           0x2cb22b77a8a05d26f11a4dec80eff292633aa05553a889c5ab16b6ac6e2ab17_Z},
+          {0x21d0175349e21114988a2930b9a607d43245783cb4a0c984ce27f4c4206708_Z,
+           0x59f1f49342cc5496213d3329bf4ca7fb0044337449c579bf53147a1dac9e67c_Z},
+          {0x167f821b381f4c8adcc39789475fb55ba639e5124fe75f26dd61be396dd5e66_Z,
+           0x22002c87d4cafb47ac9d27286d5cf5ff7a6715d69814118269b0729be9e4b3a_Z},
+          {0x31010666c6db83a9f9e4db4c48173afd405783ac53852a6e38a8ff925528843_Z,
+           0x1f466dc9b5d9094107c741dbf380f9fd98d8549cd50f67169901516f8cce74c_Z},
+          {0x1ad3875769a5053388a86edc85dd80fdffbbda6a456aea497ff81a0f1f6707b_Z,
+           0x2de7cdec5e2bad56a71bd2f33a4ae4c874e1ad4210a6ac32b443cfa34e85b1b_Z},
+          {0xc489650fb7f459ce09cd05a456fc5a46b849b38a671298ed645bcdaab168b0_Z,
+           0x45610d092b8af1c43ceed474cd17f7bbee65120aa6fa4d37f949e7e41f25327_Z},
+          {0x394256a5ef4d7af5459587a0bd2edb8acaf5ecfef2563c9a04daf34a4abe4c6_Z,
+           0x1ebee390dae1403c0c53994e1d064fa64e20fcb45392e209b2b99486a559ffd_Z},
+          {0x410a1511fead6151e9bedb089b9832d0fe01fab76d3f8459929f767525aeb27_Z,
+           0x361f0a5ffe09fcc3ad4eff3f5e89508ac247af80267100b69de3c59df561cfa_Z},
+          {0x38cd437c9f659e110a869605c182ee9fdc26de36baf559d9229e258267bb734_Z,
+           0x624b1128ea7739bf1cbd0e423af92a4884323c868d2ba0ee9d362946edee2d1_Z},
+          {0x78b126e50b7042d2a019f95cb87a3213c664ca1bafe345999b1e9e2dac1e608_Z,
+           0x19e398196b22f4488cbe854c614ad8c353839abc5ab3a4f3f5c03c16ba8a198_Z},
+          {0x6d3a5ce91132f385a91823c5c8046c4b638f5fe63357424410d901457cdb867_Z,
+           0x7b80bae16d2d487e122495174f7a70992bc5dafbed72bf84127ead7c57302bb_Z},
+          {0x32d053a904dc4d88fbe7d0b96e0cbeca22a00aa5c79c753d52b0b60abf31602_Z,
+           0x3af6a02e5cae6d6490354ae51185149e3fdb6d0d9caab90e95ff58aa0c40377_Z},
+          {0x49b1fbff5bdb0aa6938b066dde0ed772c0d81f9eff52e7fe038b0ccbd78adb5_Z,
+           0x1c6e57834eb14d507eed8b36c81ddf92fa91c242467061927a742fafa82b43d_Z},
+          {0x2f28b8994ca6f234d9293d26196b43b9d1d5306844348c4a638102c05de85f5_Z,
+           0x759cfb172eab065d477248b3569f4ff5791055f01e95fe71b94b8e615d73c96_Z},
+          {0x3c2ee954ff534f856f59188fa0f29ed8a022aee0cac52d634f6dc58cd514d70_Z,
+           0x22bd162e74925f0a876bd8a206b8767dfdd7c898576a73a490f138d9a7f99c6_Z},
+          {0x5763a7cab001e1aaeabf9ab5b9b2fffe6cc2b299ab04ec4933da74d960e1ab_Z,
+           0x715ee4f8ee93ab5a1dba00f0a6abc4eec47d49b61254cc27fc36a031e32f0f8_Z},
+          {0x19976ad8d7b7f47c785408243a227401996b36e47c7a78a7bc7d4256233ba9a_Z,
+           0x896b713c5d7777b0703821a73c1d9a4c3755501042120534ff13990975e1f5_Z},
+          {0x61674b992c29827186cab5ff454758dbbed8e89bc23d0bd33193afccc3a04bc_Z,
+           0x38e1020744c13903809ea30a0662fdb5226ae760cdcf10800faabec452e00f8_Z},
+          {0x2ea2d48bcb83c0c9cda4efe11f07165cfcbc9ccd26526e5fb12556316d4b1df_Z,
+           0x1d2d68b74ad384c5c4a9c85453104216357bfcdf635680b40215f0f800974cb_Z},
+          {0x7881212050264c40c336ed3a15dd2cd868ec9a558f5b728869eab66e8b8ed54_Z,
+           0x21aaefcc8ad8a161b8971d6880321781dbd939570c540da4c330922b8c81e9b_Z},
+          {0xb6be88ce0461d20f59c5199573cda0170b61decf6e8e69a6d32f1695adc4ed_Z,
+           0x5536e4808370716f2bb3423a9a49a38ddbfe91faf3b7a35eb53d3519238b6cf_Z},
+          {0xe5972af1655eb6dde2e8c77cc58044299922441b5ee41ceaf5cafedc765bcc_Z,
+           0x550282f37a4783dd60801c237045992d6fbe82a5902e7d837ea25f6f98c7b3a_Z},
+          {0x7efc1aad1f580d8f50274f1c114c40056be19a8c96fa8c4cb5bf85e1e7f3e4_Z,
+           0x2689f1c3898b114d668be6413643ee9f879913d40c262541fd0316264c60a4f_Z},
+          {0x7939db98037f59b0113e9d60051f75ac9c3cfd1a3eb535c73e2d945068c6c5c_Z,
+           0x410914ca8bbf3c65cdf3e9772ca790c19131c50068d34b7346c10260a578a8e_Z},
+          {0x225b77ad00a2b83d26690190b74867326eca4f55bfbc3a13be036225ca3b1b5_Z,
+           0x411faafef89042ce6beb64309fdaff70fa53e9d32d79a21e7f82f80e79ff05e_Z},
+          {0x1501e64c99c8b6658b0479f2c05c9142d246eaabfccf2fcec8dc4399539d8e1_Z,
+           0x3bab1e3339e42c9ee66c65b0b20236fdd9362d3ce786ad3a9779ab578af50a8_Z},
+          {0x59b907b941f24fb8ea2458153e55f07534b388e835af7b69f3c9f54392a335_Z,
+           0x1d5438c4f2f68a417f3d56f916d899a6ffe910f5f2989ca31687f1b10f60db8_Z},
+          {0x2887d08a26f484546f360e33abbf7a998b7170a5b30070938b84f072c676bf3_Z,
+           0x62a78e8d00e5d3a59e2fc424ffa08961567ba1ef24c8531cd7bceee6074a535_Z},
+          {0x6e3cc8076b3d45377929033af35aab0c6d19ae4fd47c0daf844079ca04c46eb_Z,
+           0x7b90f338e4d848aa8f19d0b5c3bca916a2a9024acbf14bddb278bca2aa39e5f_Z},
+          {0x34844dacdd3ec54a3af328bb9d67715ab33425e194ac9977ca02ef22e8f7a88_Z,
+           0x3c1affc6372f32a1634748124f9e1a03c4f0c993971da0dc28888b0801279d_Z},
+          {0x436b192e03a49796cf9bc5e93c88268b71c9c24f9c3a85322bba634ebea309d_Z,
+           0x67a8091ef69d62abcb28ce5df4dc7d53f8dc2b9690344f75ecd03a6d9386044_Z},
+          {0x592d25b68baff87a6d7fd41ff0dadbddc1bd1316683de3b2d677501c0eb14e4_Z,
+           0x27ad1e1099683f54589010faeefb19e38569ace43653be8787a42b0591e7bc5_Z},
+          {0x89a5111ae911512ba62e87b97f643c0219702f235c70f62c6678a129302009_Z,
+           0x557fa3d98e9ce7b83b47545013a4498f3de43787fb66b1a54521222242f7c1b_Z},
+          {0x1c9b5e53377e72da5066cb08566bbf9ec31ec1877f455d932cd9b1aa375d34e_Z,
+           0x72f79555a8bc207863f32d482fca54692825449fd8963fcea3de3a8183a739a_Z},
+          {0x574a6e05eb14591729515be239ea8c1fa9e12d4049d42876f76c8ff37bca03_Z,
+           0x5f99b3af43ca68c1c73e8190d5f73c8de162ba643d7d5f0cd73cfa8135db6d3_Z},
+          {0x513fc5c2e16505b2b25a2f284e167d5401194bcac0dc3ecf8b7c9acb560daa1_Z,
+           0x687ee7a1a8954d08d3856e1a16ded808e419e789736d3f55f79f7693bad69f5_Z},
+          {0x53d48bd1205274b1c2b0a0ceb3d21c5fcd7c8892a784931603240b288a598b9_Z,
+           0x35387abd7ea59c9b956de44d36533cad1f6668c438d666651695ff3862159be_Z},
+          {0x213eb1ea99e08825110dd61094eb6e8145119dc1c507636f068730b1e086d44_Z,
+           0x744f6853f4f02f4f042468d0739e0c9f64df720b87ed77d1979547084ef7a89_Z},
+          {0x735ef017d091ca23264ad0aa7bb9b2be3309b4539605e79ed4a652ccb2fbe3c_Z,
+           0x7f0ccc7a5747c4e921fff97d431169f690763427e2cfd1ad74d7a0308d7faa9_Z},
+          {0x3f36babc5a30070b610ed97db44997e6d9115c9c0579ad8f75d295a17130001_Z,
+           0x79047908a2474e32d5c712a07bf5c4ad522590bb5d6cefda410d30528e12ca8_Z},
+          {0x51c04907ae88a5926b242fb2862cb1f2c651a94e6caad5bff8601c079fded74_Z,
+           0x10a585a269f460aed43f54c7de13cdf623fc8de5957526997278be939ef32ad_Z},
+          {0xc1e1bd626a735aa2c065831317217ecce68e377eb1f67e54ce2e97bc2ef2dc_Z,
+           0x53c5af23a9b482f420be6dfd37b6886154cfd130794098e1f51c1885ac2556a_Z},
+          {0x5aff3b30775ae4758e604a4a6262803a545f5ef4e7855fa245ac6a6431a9ece_Z,
+           0x39a4799e5519047f29333bee9c86c99bfa8056d4aa381c396c4a44331fe795f_Z},
+          {0x3d753e9723701a8e9d99b91bb93dee2eda7ffa5072fb2cd5c5fd99aebcdb299_Z,
+           0x15798bf5c17d6d5880fed1553af32dd8d8baf2888c715a886575448a24c7975_Z},
+          {0x6593e5078466b07a4222d2e544da826d2c583c9cc5f2eaea148b129b00d4aa0_Z,
+           0x11b352b08a0a61d3cd67d1dc08069dec3bde907b3da0f56de5011b956bf8744_Z},
+          {0x7a6eb353c5be9ff03fe4a06c01fb71aad2b38144179a291ebcbb2c2417cca65_Z,
+           0x3de3ecb12f2fa699b46a9d399abf77ca17bebc3e491bfb2542dd0fba991e2bb_Z},
+          {0x2c7ead583d6c32162091034a9eddfa775b4e84b8bdbea939edb2a80dcf64f6_Z,
+           0x461790ce40d9c276d962b2a1e9a74d66e9d7335962e234e8a2fc6963d31722d_Z},
+          {0x34285af023d9b4c2c2b88e8704bf2c05a9b553b00b2e70ff05f8c2970cb134f_Z,
+           0x33fe678e7671760a83836107428dbade68c3593fbe568f3f8f1b2c568099c44_Z},
+          {0x6222f720a24466263db6a11842f117fc4bb78da6705f140e48869db3e087441_Z,
+           0x6eff5b9bf3aeedc962bc5a24b66e7bdad2153450ed53a058bf2c8dbf2907693_Z},
+          {0x17c6ec5ea206eb97cbf53851e37ce391080e0d2bf1e5395610f79ab0503f7ce_Z,
+           0x3adb71ca3523d88ceb1e365f12dfb24895453c14daf0046b2626cddadfdf5f7_Z},
+          {0x70859f9771a713e54974ce11cdaf44b0dcc3e9befa0c0834908d877eeaafd27_Z,
+           0xd18f794bf0cc0623b711e7450030424e52326c45ba9b03341883ae4828a5f8_Z},
+          {0x2a820cfd0fd4ab0871e7b303cd545a3086caf8fa818c087a4017197da74efbf_Z,
+           0x5f992683ff37f6c041b84bfc01503d333ac9763505cc8f69473da01812969d1_Z},
+          {0x5b0526de2c07fe7cd73e3884f642d57a0ac5e13c68590ed03a14e530616e8c1_Z,
+           0xeec69d0cbd92c9fca31ec967dba848bec368e792d6678797946a5e34fe3487_Z},
+          {0x6cf6b3efee707210cb3a72f1e885c3d0953aefb43e5e148c740aa1641725c61_Z,
+           0x911cb630b898e2c1a9115f9e45bafe3b819edfb1eab6e15612d14289939984_Z},
+          {0x74e913de55f1e46143cb2ecfc580f8d3d3908f200281322b84e21c989cda293_Z,
+           0x761d2736c9ac7670ba905bc2629c6c0dbe988820a4454ff415ba68710f7df92_Z},
+          {0x44084305e0c911a40b7cbefe5f13cffe9a99375d1a584c4a2200958050af7a9_Z,
+           0x249c83877371564708ea525b64b1e7e12785460d83364446531c9adcacba5f0_Z},
+          {0x2bf71ad4d1bee1a67fb300477029f54bdb0e09f78bf2ac2e8afc7465a7adbcc_Z,
+           0x6244dd6cad282539049be57487bfd9900bb0d5da805d02b535096368fcb4cd5_Z},
+          {0x3a62d8f763b62def36e4089458046a49c5ecb91b861549530773e0548ff2bb_Z,
+           0x6a10a03ba61e6ac657270465c09aa9526cf1ebe96bdecdf0e7000476a47b9eb_Z},
+          {0x284eed3a17c51e0677d4fe897f056abe9def8af07a4630e6ca5723e2aa6677_Z,
+           0x516a06ac1d5626ed03d2eee9de6f60f0311eca703a99b0fb31b9c66b01c27c7_Z},
+          {0x2a2c63b16cccd685f731d06fe93ce2cffb358d34d03dda9a7368185c1eb0c32_Z,
+           0x7180baca0ba81284809f92eca1654cd76b925a9242e5d5e0f18d0a55d13c6ec_Z},
+          {0x5f9466017ec09769611389ea5370ad68dda936d3f5816c9e928ff9574abf9a7_Z,
+           0x6619b5b145bb5f4f29deb7a4cd68ef4da3995312fa6537f0d01684da4267ece_Z},
+          {0x74f229babe01b4962b3307589c1a13019134b1db6822698388bebb55d21c30f_Z,
+           0x156ae857ab3279f754facba0db36398dffec8c31e5e160473198f2f891b7531_Z},
+          {0x334b9fe3a5fd99bc966ddd1309698fd32afd1f235062f2c275b6616a185de45_Z,
+           0x221a60053583cc0607f6f2e6966b62fc9dac00538bb7eb1148e007a92116d2_Z},
+          {0x7ad710ba002a67c731efbaba2149d16fec5d2f7aa3d126fd9886172e9f4ea30_Z,
+           0x3a10f8e902a7a13aec94d66415347e1314f9bac83a7db176096b809b25ffb86_Z},
+          {0x4306dd0a184a3283c3097ff8f7434cec80912e9dc04b7df21ba73fda9f8e6d8_Z,
+           0x6d42bd3d1a8dbddafd09e872e2aa3891ae79ec939dc1b382196bc21c4ab749_Z},
+          {0x1c3f2124e1135c32a426d1d14e471edd9e0f2c7bd703ee123cbbd608e8c4be7_Z,
+           0x3cc607a3c3f1ab68dd5fa56c65996002721b8ad8ad4b0dd9e5b1467d316583_Z},
+          {0x294af33272ffcee0b56a436de1b73759cbddebef4c07888b42c2f92b0b68e1_Z,
+           0xd837164311d5dca8d37b99ef9eb22708643c83d1cbdfe852f63ea07b06fbad_Z},
+          {0x753bdb5439a19bbffdfa02b1dc24e8368f22d0a8276b109c11e6feb26f56f39_Z,
+           0x6ed396231af93647633eab467f1a034f38e76823eb85baf97cae56e2dcd9f75_Z},
+          {0x5674f0cb892b733fc0b50e121d8679afed0a925c32594cc65ffe83bebe7748e_Z,
+           0x7fbf0325dd38dd94905adab2c52758552292a6a103d9edfcb11938828e828c8_Z},
+          {0x4a8f053573a0a74251059d0229d89b6660407ba0b491779fd10f87a5117c81f_Z,
+           0x21b70112485398bf67ec9d733df24a1df30dea718a93b786f41ed04e3ae3c5e_Z},
+          {0x726c01ec4a08df8fc8de173311f50d4f3b97c5a9cf68c1536146f827db95ae8_Z,
+           0x15013cafadefa7f1c4e4dfdd70bd4d3979dd18bd7f0332572ce2a3fd8773d12_Z},
+          {0x38ac0fbfa98937257460db7e6645d7e5112b6fce7234813fc8a704e8ade8da2_Z,
+           0x73c0109f86048aad08c443f781ae60ad13b99f7b9cfdf3128fe6d6eeb799a7b_Z},
+          {0x6f6d3a38621582ace092eb50ecfe9eff265df141ebdcab8653299116fcea291_Z,
+           0x4a1bf3f39bc919c8f1b720a0b1ce952cad17f2ba98308ee6b76dd9b6f3d7b75_Z},
+          {0x6a307fc28e1df8d9ad01766419e097797d65cb674436fa1c8f012d3de2c2a1f_Z,
+           0x26911a635ba824db004875d79dd84834a97ac12643e42829015bf88c1fd6f05_Z},
+          {0x2a74860e3336d6db916555894cc8028f41508812925db1925457afe40257155_Z,
+           0x5f8da573f4c39816ce2dba8a20224223a7cfec53117ec78973930c0e9b60244_Z},
+          {0x4d2b49e1ed0799f719b8269f092cb489a466a645bc0ccabafdc678864c176d7_Z,
+           0x5410083df7d256f18cbf5697ae5e52c31e075d8a3b27e21d6f5177ca882f6c1_Z},
+          {0x110ecb9fbf6c333d168cee473cc5ad98809b6cb9eb5d1f6cd28ab5fab504fd3_Z,
+           0x7e3c54d7533d9f8c3310f219dab0cc3ea4d39b418a748eeffd6bae2b8637a43_Z},
+          {0x5be4d711b80da70e6d3ac493250bbfd16f20b25f31919b3a91cf14ffbac1096_Z,
+           0x7f55a0919f082e8885f1515e83c5b39b6022404503507498e1b4422d79c43e2_Z},
+          {0x2605125b95ca4ba93a21cbbba5762898a7cf9e988f07ab9e64cb3868e3b139d_Z,
+           0x62f0ccf55b9fc0eaf9736fc8ee484e2acdbe259813af9803cf815829a5e9d3b_Z},
+          {0x1092bbbf206f2a3068167c3dd99a72de31e206f6c504c071c8214d105ff814d_Z,
+           0x309f489f68a62089f53b96df5d4fbc3ecc5a1a42eb7ece0e49bad17ad490ff4_Z},
+          {0x2abdee9409d9c92559ca3f4e6bddd649c31aa09b90bfcb4a612af491241e18d_Z,
+           0x3ffa8eac180a29de3f8a69efca84bac046f921f5725e96a6ff0530be1436aaf_Z},
+          {0x376313f27d00bb1aae7ec991745efe6ee28c6b50de0c6cd9845cc4bb4f83543_Z,
+           0x6a8e0a9389ba528b156fa94ac090a895d7b795818d4941c29415d9e2984c547_Z},
+          {0xa80380c71bd466a696b3f0fbf02817c9459d9798f4f3899cf32edf647fe066_Z,
+           0x6a09805e814e7cdfc76eba4b79f1df5ae559e0f0aba9f728d3cba4ea5c57471_Z},
+          {0x223694b921d247d989a79b9b2b2f07496036c40cb043eab074a9d6a2cd2ffed_Z,
+           0xc247217f1b1df35e30d9e15fdaadf42d6fb0edd3a5a7e265d4cdc426c120aa_Z},
+          {0x102333620df278c6714bbc880fc087db58c1b9b4d77ed4d61b32a74bfc7c3e2_Z,
+           0x6a77d37727ccf71c2caeb151faf4404d4b94e9047f9f0a7c3966367f3b53c65_Z},
+          {0x891626f466536929ee7eadcd18b41925706dedab7528ed5f0f7abf039eb9d2_Z,
+           0x5f73d11c141c933a35b2d0d06e5cbae614a20d17dc3b439f8bcdc3413c5ea37_Z},
+          {0x215c23fd3f073f870e5e80303967391bf173f8adcdbeec72d131c557babc203_Z,
+           0x10634332e9d9439a321597dc5b0fac9ff478834c3d6e281735f21a4a5e13266_Z},
+          {0x21ea0bdc1332bc36e6aeb43be9071651c27e4ea2eadec636c8d818d4af72a36_Z,
+           0x3a523d9643dccc6bb9c7c58413312caa3e60ba9c7c7f0177e0f3f469a3241e3_Z},
+          {0x60deaed1bffb6190beed40caaf2bfab5e43d3707aff7ad3f278d571aa247eae_Z,
+           0xe41f71ff254c1418e6a66992af307789fe04d6606fb2670900bb1a089fd879_Z},
+          {0x1e1fac4a1646253fb1332fadc21fbdd3e3a24a840d129400f520ae4116a4cf5_Z,
+           0x69c406f9f46576afad68808de0ab7e8922b6226af748e721d9097e21f1800f3_Z},
+          {0x5db0ddcdf79ffe74d6454c12d2bc60b06776db03c75dc413f5be42ea9a91b5e_Z,
+           0x134c3d6c699841f17306835bb193785228ffe7ab212a01a861c56b086a18cec_Z},
+          {0x626814e320fb5bea505b248fd1c1389ad586c1cfe04923fe2f83173e915f4f8_Z,
+           0x7ae407a926e887206a8b85cf485f1f327c9bb8ccbb6897024e2d122877d8ee0_Z},
+          {0x23186237dc7d3b570cea645282ad4c359731bbfa54e7f036426bf6493812cd_Z,
+           0x7d1fbab7e61a22d3b00993290d9f4cd5d820061573e787f66c2cff9a18e1eaf_Z},
+          {0x54302dcb0e6cc1c6e44cca8f61a63bb2ca65048d53fb325d36ff12c49a58202_Z,
+           0x1b77b3e37d13504b348046268d8ae25ce98ad783c25561a879dcc77e99c2426_Z},
+          {0x13961b56b9fc0e412e468c385c22bd0680a25624ec211ffbb6bc877b2a6926c_Z,
+           0x62f7f7792c77cd981fad13cb6863fe099c4d971c1374109185eae99943f16e9_Z},
+          {0x47abd7308c70659af3f00fafe6837298af3cb530b6c2ba710ffd07a6bc1ae98_Z,
+           0x75d0c8a7377aa9f0663d0c124a5659750847afabc29e39893fd27534a4a03cb_Z},
+          {0x2c6276b764fb398fa555857dbe0ce0ec18fab7a233bf23851295739801f0585_Z,
+           0x5d8f4897ce44007ec5bfcb9aeb78b8f6e1d40a514f72d213c9300d2770d2b8c_Z},
+          {0xbce48a9bf1ba2a868ccb5ec7a281b4adfb35f880a2217d3efc41fa17ec8430_Z,
+           0x436e8dd6339b88207b24aeb39c4497e4cecb1508e26706bd72c5450d7e362d_Z},
+          {0x7335f3501c51509f0ff19e0996eb27993d2ed57511d741de1039fac608efae1_Z,
+           0x3e0f9b7f92024f525bbe638105385ec8cadc3d9d0054c995d5f293c2ecaf2b_Z},
+          {0x2f00685b604089a426a0f8025bd4de158a3431d7a818f92a8d12ca3330cfbe4_Z,
+           0x3b78bfdc9be254998ac5bf09faf4b3ef85e12cc8392950f069de8d750ce2a6a_Z},
+          {0x75164bdac839e799a01b2c97a3c70a063710cbaa60b965fc68e1b7fa9321887_Z,
+           0x366a151b55dbbeb05372baa1b753340bab038b82a8457007519406fb005743d_Z},
+          {0x4cbad4f94c301d3110a57606374566457d6f2c089364e6636599a521cd52efb_Z,
+           0x70926c2c5d53c80bcee63dbd1fda1258006196a5e371bd7508b5c65abfe6d40_Z},
+          {0x53fa2bb938fb756579e7496527c6e65c47c59a6dd10c119a55d6cdbad565ff6_Z,
+           0x9eee73b8f85c216cc142fbb9ea7d9bbd7cb5c58d2ddcefc9e8a8bbfef55ed2_Z},
+          {0x313e19ce77eda23700db871d0a325e84f61ed923e4cf1882d745970a5c9f55a_Z,
+           0x64560398fbb3f03b5275bb78db48a7a93890962a9310ad5db0d6310c405141f_Z},
+          {0x14d6e814f77b60e99db467e3e137124eb796cb075b2a12a9a06353194a70780_Z,
+           0x7a56303bfe394ab06fd59708d58511c0dff923cc2a3f7c3a33e6bdae011ebd5_Z},
+          {0x4b98d86614db4ea0304ed019aeff950392b2c9a276f41143f48564138670bb9_Z,
+           0x543f62bd0110123b347b89ce1d9fbd794380311adca3cc99dd371fd071b670d_Z},
+          {0x3aca36203db64aa6f09b811d1635afe815ddd7451d00145838ccddee9aac4e5_Z,
+           0x4ae269ce42b4ccb03d1994aea01e15ba1b4d87709fd843c9dc9504074bb2b90_Z},
+          {0xefc778f6a5a796cd7469732da7cb16f8626ee1461a4c2dd62ec1ba0dcebaf_Z,
+           0x720e57f989ef2bbfe2b165f1d37fb6643c8de78c736617aab046056b08c0a80_Z},
+          {0x7416789c54a831ceca6e04e4c370c4bf66f86230550ffcb3792e726baaee2f0_Z,
+           0x7df1bc5ce8bdba2b3fdae7f786280186eb320e7e6f882c5079a155e641c4241_Z},
+          {0x376f7203f663be987ef0f2c2cba79b6c0034f42f425c0275540354b60899ac4_Z,
+           0x5511b4813e7efa8e0a3eb586258275b9ba47e3d0186cb980e5adfa74a2e7364_Z},
+          {0x19913b2836c5f13169f955ac17d5d1f67db6b81e763feac08dec4d3fd3bdd8d_Z,
+           0x1a76e77a6f09cdd668946bbabe23d99dd82a414cea788265d30d7c1a3fe1994_Z},
+          {0x246584d812cc7b30321272c346bb5a29fa29f923e293cca648986586e7b3a95_Z,
+           0x7fe28cb7cf2f3c11573dd09f892b435e9329d173440909a777fff250fdc0771_Z},
+          {0x1b0bd9e66e77b8141a657358264c78a4672ee0eab767f8b8992a088fc57982b_Z,
+           0x387fcfcb97824bf38cfe46106190a71b240999495d9d7caae0f9b8cf41188bf_Z},
+          {0x3f78596df9a080bbee9e98b7bf6c5b517afd962d47cf72b138aebaf656e3f70_Z,
+           0x6969d5e25f5a7f3b229cc3bbd5a4367bddb94621ac470e546863970a275c28d_Z},
+          {0x32126b03e8781a20a44a92e05c16be70c501bc4e0ee8c09cf0c997ed628c3a0_Z,
+           0x100904de59fcaf55c18b7e0866ec50715655793238fde686b9b8d8636fb80c1_Z},
+          {0x29bead2f77a4e4c744d4e83c7e439ecee03980a20006da9a7d3c57c7b714636_Z,
+           0x44be13d072f7c4f2396dea08a19cbe4acef8a2e072e0c038dde69804ebdeb40_Z},
+          {0x4e912475957c58f8ae120592076e6ffa50a4405ff41f5bfb8d20b2c0a28efd6_Z,
+           0x2be9f3c03d3696749096b85667fb5044bdc216474a9ad0d8cbd1eace70627ca_Z},
+          {0x15df84721fa5bb2994557ac6cab5444bb50d539f9627bd373e77b965c1d1690_Z,
+           0x45179abdad31f112c1cf42ef6f17e641d9eb6d19b32b3246c1465f2665fa840_Z},
+          {0x795e85f1015d6f85ff303321b38dcf77452f6fd2b5669df41d715fa115ac938_Z,
+           0x674da8ce723640f4aa81f3511a7d0a0d225997db7c581143bc009005b365d89_Z},
+          {0x2b4d941c72210bae832efc47665bae7cf783b4c1904f51bf5dd512d72bdf108_Z,
+           0x309a8300fd432c05f8092778078c26d13719e0354eafc4dae1ec512993c9491_Z},
+          {0x5d297cc4ff962982a39ce89842cd87ae01875bc7710524f263eee3ff5ed498d_Z,
+           0x36d5336a6f51e2ff5c6995bc8b87defc61a05251103eca8b32ede509374e9ec_Z},
+          {0x26815b43b017a41a5ce4f4971cb9fc9035c45f22703296a6996fb98adf65027_Z,
+           0x9433f389903812b8399cc6740ae13abdd4ae8aa0d38b12d1b0a5a3f90ad2d4_Z},
+          {0x4b3ad725435dd69c69101b3bd073f8ce3e8559ea73f4d9d944c88ec0460285f_Z,
+           0x19416e704d0a61305b5f1fefcf037438ae6872c409ed787af13f496eb5a94f9_Z},
+          {0x3c40dde269f0a840d857a2dba52023521e3889be597a843f062055fd79631c2_Z,
+           0xd4a04943ec16198cef1f05de15ecd6f1bcfe33a41c1502d12487e5244963ba_Z},
+          {0x7d418df02fc1dae693c9de50932bf1f182dcf58d6ec0695c20726520fbaaa1a_Z,
+           0x60a0699f233b5cb79e121ef4d060088e1262ccdcd6e471fc6d7ea0febc21c45_Z},
+          {0x1507ea3ce76d90dc3332dfea74d452b6fe76670eeff3ed208ab049c6ab12715_Z,
+           0x6095740c9a874b6242246e6a98816a239d8ea4d35cd08219c2c2f1870d68ff3_Z},
+          {0x5458ca1221c99bb056c14a0ac7f77ac45de5416a8639abdc70e567df7ce6f49_Z,
+           0x271b0470a1ae9fba5abd17a016e079684532a17c553cdfcd1c17dd07dbee098_Z},
+          {0x419375e9e0e03c5461ed4a1fb2a40b1c4f9ecb56947c44fd4ce47c69b2e6663_Z,
+           0x3b4f29ba97afc4e1f691cb8e1f3bfdc074334d7f9b2a464e10dd647468102fe_Z},
+          {0x25380328908ad9f12905c311507d8125dd586607b232ac544adde8338c6e130_Z,
+           0x6ecad58b36d5afdced17f889337a9cb1cffb919dd6dc03cde7500eb6197e4cd_Z},
+          {0x150fa7730e4ba5106eb903b351a0fe41fa5f0eca3b961ae4697c3946f5f111_Z,
+           0x44c787122ab138ddd4c02e1a61e7ea4b6e24a145f1f4cf7022ffdad4db999b5_Z},
+          {0x40bd0f774627782f59bd79a92ba928cefffd2f703771552384c2da1e278ef2d_Z,
+           0x134960affd67335df6e6ff910a4813fecab596aecff7a1e81a3a2e1ba838d2d_Z},
+          {0x5f254557c28f25ba86a7956aeb684c03ad4770d1e6e85b313ae414a200ef5d5_Z,
+           0x40d767bdeffafb30d40abad22ff189d1ab122eaae73ba64dd5ee2b84abc007a_Z},
+          {0x103e4a12d4c51f5f724051a2834c64dc8fe1a9a6cfc860deecfbc525c3432d0_Z,
+           0x3603dbdbd7e45020d7b5dfac3b7c0da26b10abbec47a771f7afe85e07da9f3a_Z},
+          {0x6129433d07b14f2ae1c71e332738f945c4d1536f9c89cf58eb9dd789578f8e0_Z,
+           0x2640cd3fbbc579cd64f64a87f9c63e49e27289951bc3640dc6f4799c47667b4_Z},
+          {0x5e731a8c7eab559932b816b92deeb7cf012183e3012c15ee9adbcd172625e18_Z,
+           0x1c9fc9522014434a5dc9513d5cefacd2f7eda4ba9cf46ca5f941db0fb9c6aea_Z},
+          {0x67cf7e4d99b15c3979a012ad4646d077b65507073c0dd0da901e3cc4039e6d1_Z,
+           0x13fc08992a882b0aca6af476a597c3022fa91af7477bebe4554d11af7fb172d_Z},
+          {0x3d954d3bb9b7f8b10655d0e2b471e3e3fbcb479a061a7604b92c94b99640494_Z,
+           0x54b9002f0e61354d6a9bee607f3aec9575b2fd227c20a131c92c3ebe847d461_Z},
+          {0x6761c711ab95f185943ca0dc50a11c00ee7d197fbe4b6f45d2a7dd81c641bec_Z,
+           0x540db2e2b21c6e22a1e7d7daeb47551101c9993b517b88afbc2d0c54d491a60_Z},
+          {0xd835e09e0c1b11265e33a218d5a0736353ee48b534a9a3caf3379509b3fb80_Z,
+           0x7a293b0f8e14d0e93822c446fd0d2cf2d6261ab61b187583b85456218ab354e_Z},
+          {0x5b2431a65cd0c76a94eff28d4d77257639c205b29e0372fc3613ec19650d46d_Z,
+           0x5f5508dae26adfd5feb5ee120eca9e086ef696fb2fcc57ce897d408a9210bf9_Z},
+          {0x217a6c9739f11f41625c4b0702d7122ec1c432898d1b0501692e3c021e6cbda_Z,
+           0x2d1712f78263d0b175c8743e7c77d8fb9d15469445055672d9be4cf259f76af_Z},
+          {0x70af5c202d525c1ca0af1db4105045874c30936850bd9590f3920bd135df01b_Z,
+           0xcbc6b907b3c70ffa773cfc09a9a2a6ad8e6a0b566119a922c609385dc8f010_Z},
+          {0x4583e0265583b943fc4e26643d9dac77ec5e784790e140cdce0690d8457dc3d_Z,
+           0x66761e99440da31940fcaf5c02e261c8512db629cd4ce83d5a6afedcbc6365d_Z},
+          {0x6fa066b6070a2e9bdad2e3c88f152af7d217cf14c4c0e2c0f0cb8fd748f2146_Z,
+           0x2463c1ada175cc6e3356bb9c96844ff6a67182e4d5fc26f334fe007bc7a8644_Z},
+          {0x58158da3994a8e95cb6aa3f638b6981644d4fde0dd1badcdaf5626ba2d4ab6e_Z,
+           0x3d3bbd4277c9793c45be600e8fedc66a8fe55becc2c65bcb7c11b49acf26a73_Z},
+          {0x2ca60069324a91e1a38d3663dfdb47a27f65c3b7e2d81de1f3f65905e842e09_Z,
+           0x3f2acbe89b8a75cc67a049d53e09e291540f7899908f4ce92c293fb0af144a4_Z},
+          {0x71e44d03108b6fa350f2a644ab2d845dd04c410cfbb14f9f72b7b54a52dc76_Z,
+           0x5a5ba61d27308a8ff7f0403c5c46e443960e7579622c75bd112299c54a434de_Z},
+          {0x7eb7d63173c028985094d2c4581b73ab150d0d3f2ba68203d8c639fc013758c_Z,
+           0x3cd7c33bddc8fd4342cb1239846a4679cb2b670d88d2d7f75360f887fe93b6b_Z},
+          {0x4ad4f2d6ec049bd21d655c633843af4a8ea1d12eb9352aaa044419d91b26296_Z,
+           0xbaa70201e2b2858a3508ebbb753617e4f49aab631ed2d18e8c3fe78dd29f4d_Z},
+          {0x3669acaf65b4422e1dea26d1dbd1b92f9ce238b61d12a29c3138a9dc5772048_Z,
+           0x657c3a618e530c8c3f57a5d6383474fb3158e5c2cfbe1592fabb6f3a22469d4_Z},
+          {0x6d4c0ab00ba75074173d4bce4fd82f8623d7a60444157632d975c2bde0b4923_Z,
+           0x140a60490c60f77b99ae87687a8025626bc8a61d084a546e0fe757e4c966c3d_Z},
+          {0x54681129618499a6409b1c52a7ff03ea56c95e4c5a8fa4d86f10358eb2e9707_Z,
+           0x58d39d95a230a8323bee498fcea3f15ee089be54cbeca2e63e54b764b08e890_Z},
+          {0x2b4930b3d47d9cba98980ad642c8cb0baf7d1e09c13a2d715d072380bf09400_Z,
+           0x6a46ab2f87f23b11d35a6ca43040c73543f53e972132277a76ef1eda32cdc34_Z},
+          {0x249c9c191d82d25b864e6debea7ccf5c39b4a355dc4761510253522ba8ace67_Z,
+           0x114dbb1963bd906cb8965f149d1d2db7cb86fd8be03591ae5f2dd3489f9f3f6_Z},
+          {0x3cc5f417362f8bb2791e0494d6bd2fe339fbf33c83ee7f70c484b43986dcf10_Z,
+           0x2c47a96a94993ed1cf1f07b8fc68b2ae45fe691e2ef4b2c4767b9027d645ec9_Z},
+          {0x4e55c8fde1b0dc2e2b6c5508f5718eb29edf0e97ae55c2470196dab114732bb_Z,
+           0x2eefc04348a85bf96bed3dc61a709cdb0a83294ff004d6813335cbb7aee7d31_Z},
+          {0x6e9d46468f414ed3dec19473dc189d1a39640acf616234d88a964a0c32cd86c_Z,
+           0x49dd61ffd5b0f96cb69d115649132f558256166a798b33c7545cc64882d3add_Z},
+          {0x78bc532b10fc07098403752011edcdb884ec456a2f8899edb88023bb5e43dbb_Z,
+           0xf8676f26b26f97d1dae5737b46d0dacf32c3aecb9db9df41a860f85c6c59e8_Z},
+          {0x3a2903e3dc45f773bc3ac72728fcb09878e95a0f36e7b798fba2c77fd8f2abe_Z,
+           0x369e5f8b59923476657a3d6767c6cc6645ea18a5853ce9705ac3c193b873d70_Z},
+          {0x5b715fe20bd24e17eaf74bc155e847a2a6e63e01374cc4853b469ed2304ed7a_Z,
+           0x31af84b404359c352663e76a58e5c98f08fdcc4b59990a74a3b81154ed372d9_Z},
+          {0x3510d1233edfc535959069b71fd8c510fb94caae7607a83f17a0cad1e9c277f_Z,
+           0x1eab9a957fa3a9df1f8d8afefcc98be4792a5bd61a55288dd1eae454ca66f6b_Z},
+          {0x87b84c4f139f80f8e52ce24393d895350a56383dce3aa6efbd9757efb70a4a_Z,
+           0x1b5032dc6eb8173c4372ccab7300f1c6bff5d52934058f2d6899c28971ecab0_Z},
+          {0x3192072e020733ddc8312a7819740b72d8dacf90a9aaefbe5e0a629ef95a25c_Z,
+           0x85fe2f9877c5f99fa170a6f2ca74c8f51fc5061f9817aa6c065bdc3834c9a0_Z},
+          {0x7e1240bfbfda43377c9c94a364fa768c95a1ace83f05641e30e7f55682d3bf2_Z,
+           0x162fe3ec40839cc13f342b0efe8edf4f46ad5557a945db40cfe1d59c8e1abd5_Z},
+          {0x70debbaf395d56b5283cb2e340e04fa9e7b67cdc910f15ad679a5f9da45689d_Z,
+           0x2dabf0bb233e012ed28c7fce1feee8182ca6b637f0ffd13388a26174e836e53_Z},
+          {0x7f1985327c7a51b43b0ecdc776e2af9c522546059309aacab74b3ddd51e65e3_Z,
+           0x24cffe79e071b15fd98e33e0449aab000d39a9141bcc6891135fd27c1a255b6_Z},
+          {0x5963fe0e56c20ba85f32067681d283f9c6beac6fbde2c38dd88bc5094a045d7_Z,
+           0x43b2a353e8dea35b097c7145efda102673de92d02c082511cbe905f5e2983d_Z},
+          {0x6b0765d38659e149a115c8e3b94c0287fc1ae2f191e03f08167825aae8b2499_Z,
+           0x23aa97ce8127273c38fc9242ce987968e074d6dee8f109144566f39a0f82e76_Z},
+          {0x96b91d630f4bb0e7d6c99efe035b17c3b91864fe890efe16bce192194b430b_Z,
+           0x2c35efe88ac0c3f0f75f0035fa2fdc84967c0768ad3ee4662572a2d2cbdcfc6_Z},
+          {0x32c8087df54545bf151c48a7561cc6626b5a8cee958159bd45bad5457799190_Z,
+           0x3fd90e79f86b4d62dec76bd049e89ceb796cf5edbf7dc7a5f590ad17757257b_Z},
+          {0xc4443e69dc789f85c674bfa98f6220e107c89a2ed56f39b06a0af6a2e0ab1a_Z,
+           0x17fda5fc4354a26a5dcae479028e62e361deee63f04fc42ce790481eebffd71_Z},
+          {0x6372a79060e52a49eb5b2b33a51bf4e9291656c70e6c8b86b970af8436f0cc0_Z,
+           0x2f25f69b54b8c99dae634912fcd0e4662a696bd9e84bc8680d950074e816e59_Z},
+          {0x1d1212f13fb36271dcbfdb08f91a518c27878b599971b6698124960ed84abde_Z,
+           0x6e882ce7bb6ec9a57af2adba1524ba41311b927a593c218a9b727965534bbc9_Z},
+          {0x411525f3c72367b57275e47a680853a1c7483f1cdeda5efa25792455c281941_Z,
+           0xab0aebfc77d7acb1e82cb5facb68156e19468e6de71a32799afb0873eaa861_Z},
+          {0xca0ae3bb1d6b50f26d8f08dd29d7ae1a4167ded349d7ad73456e50cb1ad1b_Z,
+           0x51ee2496bf3c6ef515c516fd82b4d248566c29142e4d7f6fa160a2c1427e354_Z},
+          {0x39f896b3cba0b8aeb8b7973b57adb975cf412acf51f4e2e0dba239d7ed3a131_Z,
+           0x762df591bd79c8ee306797c4ad13734937a89517bb53c406398f65b40bdba6d_Z},
+          {0x1bf896da13165fa9c669ead6929c44f61361a24cbee12b1024be5a0cff19864_Z,
+           0x6b411e8c0411077a328f621f8aa408bd4c9d73dad2b499402430795a7718b97_Z},
+          {0x9fda6699915b894342d50e584750703147ba4ed605ea5b2d1395b7111bdd7f_Z,
+           0x6faeffb7106923808f12a0ca3a85c64cddaad806dd969c90900c218528cb21a_Z},
+          {0x30c257bfb5a3f4cfc2406a1cf0da757bc5ba1fa7a5cc27abef7bcbc36afc9a7_Z,
+           0x275e30a10990c7273bc3e62bd3d9487c2d8ec436acfba6ba06a34d92761613e_Z},
+          {0x3c18d7011c060f09a93f833b195644cf118c046887385373a280bf82f4b70db_Z,
+           0x77391b5f2d28b7ef75fd811aa9fc97b1523d3f2467ceee79df261d325c5f125_Z},
+          {0x52e32781eae622e13e96c778d7bf25e9edbcaa8de5c85da593ada0d4380e5fe_Z,
+           0x171b3dfd6c8e1a3c2aa3765c38f9e1486e5baccb9451c228d8ea38f655cfb0b_Z},
+          {0x281545e88937786549efc352c25e2c994817d2b1aedcd003c79d0bd626514db_Z,
+           0x5dd931d55fe2724f164a275baeba8c2e5d61d44675c6697fa72dad05f85045a_Z},
+          {0x6a454b70bfd375c1ff4a5e9d2a7b500c3bdf6121b16f56a0ccdbb4073e12b99_Z,
+           0x5812b5a39850b16364cd61e949b5a02e285d96025a6376dce578bfb9700ce1b_Z},
+          {0x3432b1c8b9da9feb6098d296892842835854a61ba5934a0b78cd3b31c500a82_Z,
+           0x5f3b1ead34dcb6e87890ef8fb349f09931c3900d380069c96f25ed9942d177b_Z},
+          {0x1a40e292116c4c08eb3f7239b5f40c5d45a5a1fe67bcb09cc41d37235efef5c_Z,
+           0x71f125041481517ee5a8ceb9db852906657c2088a5bdb4ca4086931c9f17bdf_Z},
+          {0x157c1df7ea04cc62247a5c1f465e0dec62c985922997b12426742243b161e6d_Z,
+           0x21af16b389c76b3d067f500b0c7ae96323ff244a30214b671f46fb0e8a2ab15_Z},
+          {0x9e72f8c84f2c94f304bd24d110a191119d476f849b69e24e42be641f8d84c3_Z,
+           0x4181896441406cf25fdfd7ed916d280842ad6d2e93f703d28ba9d00284dec28_Z},
+          {0x4d21bc613726f30cd426926a49ae651a9a53b755bacb3f25c5442c22f91d07d_Z,
+           0x206e4d57c48d63ba6a25b942fcc6c4abba62d304f3895977f095a5b1d8b859_Z},
+          {0x6889df1f9a1ffe35e43804beb85ace5150146fc7d2937c5cc3e311098896564_Z,
+           0x3a2d46e249f860f3386710b3ed998198bc944fe1b1959e52afb53d991bd6063_Z},
+          {0x5f0c378443e572caff8eb4fd607752d171354ff9d025ab9ba0c3baafbcabba1_Z,
+           0x31d4361df3799be7c0502c958acfe18e399286435efb97da6e4ffdf277853ef_Z},
+          {0x7071b515f67b24060d9a3de742a54d84e82735deec91dc9dfa04026f612bea0_Z,
+           0x3a661a2dc55805508873ff0e5b6be5bf307f0a604630afdadf88c111cfc991b_Z},
+          {0x3827cdfa765694bd3b447bc1afef9c0c5594565c32497262013eb4b97f65ef0_Z,
+           0x24f7148d111016d6d2ab9f54f10f75c56d2aff9ce5da6db6fa14f7da285b882_Z},
+          {0x7e941bc3fe6568fd5f0049d3a2edd4aa4a021eeddf1676535d3d242c17508eb_Z,
+           0x2bc250721f54ec146071a7be83b55574f936a74e93b56e7341b0b927c6f37cf_Z},
+          {0x5a9bda630732c2f095a1f088b9acd7526f4d7657b588c57a34aca9eaef7b673_Z,
+           0x1938ba9061f7830e7994b213e56c761bc7efdf0372d0036b5130cedcd9c5789_Z},
+          {0x26e4e120764c9164fe4224ece58a87c4a5331116d29f38a9a7df4ef4392cfe3_Z,
+           0x79cb5a3f67a13051e0afc2fb22c816a02f6d728eb155f59e8d7685010e87ebb_Z},
+          {0x180a677de26e87c5b8c92a5f54615cef6a3e28ec45df72d2f56fd0848742c2e_Z,
+           0x2ebf878e85a7264c270cedf44c12331827faf56274698fe81bcf27f4c3d5872_Z},
+          {0x692cbd1845010fc701f0fda041f6f74567230e8d42c6dac0d214278adfa0bed_Z,
+           0x5ea8cd6361234bfb5551e9447b94b4926f928ab2f1b7a657d306ea79b67ed1b_Z},
+          {0x420e0fbd48935ac36a90a262c90affe020254a946fad0ea0a0642b61222070e_Z,
+           0x700cfbc7fe76f5f62a125ec467ee0fc69ed175c0fef9accd14c673c483c0f98_Z},
+          {0x7f33c220700c354e9a50cf7f5afb4146d810b428bb1f948632706b0a485e88c_Z,
+           0x507a3cb76d1d1d618e2d2a9e8683a6d81a87af36b32488997b941606fed9894_Z},
+          {0x1167220284cceaa7b11c56fb0395f0a55b628a5df7ec8dbfd7a103d9044c05f_Z,
+           0x656bc6e80c63d246f42c79dc5f4436a48c35c77217810e4dc77149bf197bdbc_Z},
+          {0x72ed3cb1f85fa3eff8e0c6b6b5f76e55d880657ddb63b28cc306659c0f43960_Z,
+           0x701677617ef5c6f78ccb3a2c5f05fb3fc01accaa1b0367a1fb988465507052d_Z},
+          {0x3c00590dc0c634acf60ca6b254243869e9d41beb438427538023956843cb827_Z,
+           0x3bd96f1f05814232828baa730719213b8eb29f0cde1e5f3c127f8efb690e5e8_Z},
+          {0x56a995ef7f75038c5746fb3bd1b1a77a22a026a8f1dac3a73af306d116b434c_Z,
+           0x48356a48c5d86011d80a2fd2981d4c1a745c504c8b881b423c31b8ef76aadb2_Z},
+          {0xfeadf0259835b42ce4853acda2db2a1e5d074ec7a9339b65490458697e9438_Z,
+           0x3ba16e49dd440a953518673e9a23b80395cc44e6ff22c40ed821915065272b6_Z},
+          {0x418b173df47595f35548c5f95f338d753a39bb76d53b85a32edbc577f6fce01_Z,
+           0x1110930e29fe4d70816a056e1eb93d2101615639878349bd516307bd290da3c_Z},
+          {0x3b58ab78f462084098ecf0af201b24835ff0a8abf189299be640a7fb5ffc16a_Z,
+           0x74041d90572f93d9d70c4ff296803a3df8bc803d8a32ca570165d4c5fe44f8f_Z},
+          {0x447cf5b60081e6b469e1954ea3aa9eaa6def674afc3a54f307d35ae01738f2d_Z,
+           0x82777c181b4e2b351ff0bed0674bfef8e40e68dd1622fed5a69d72f0446f7_Z},
+          {0x4099c98f120b2ca44314de29a169547abd2afc30f345a5c53cc8fd6b0c27019_Z,
+           0x720148fb6b50c90e64c4bbdb9729a81f5e3b54b22b9e31de1507c8158e9fbf_Z},
+          {0x578dac23670640909fd263caa2a66e7022a8c0c3620f44f75088df308db177c_Z,
+           0x4996ea791e650c820a2314d13775720332176e7af68118d017e6127c8ee01ce_Z},
+          {0x35dd513e684a32e7bc025663181e50083ddd2af8eb6e4ee0e6756b591d91d81_Z,
+           0x3551f2271841091f685d73580d01cb3cc862a0ceacdf4b45b19f4acb75a1565_Z},
+          {0x7fa77248b4ce371d0a655269bccf89c20dc28b79b4c55fd6f7b19b636692b51_Z,
+           0x2bc688eb8e85702b031d92951adeae37ff22ed8a8b23e1c89a145c5342adf2f_Z},
+          {0xe361deefc72b2a8c96070791093414ab741ff9a2791a45d8b58b6f06df4c3b_Z,
+           0x9ae31cce210ed516b72d092d173080b63a3472b8d9fc02a0ee13ab51dcbdc7_Z},
+          {0x7118de6e756cb435def84c500635de5f7c96645237d47faf912d95b0ec1ebdd_Z,
+           0xbbf8a8994c518fb18bfe2aa373b04b98520f2b9698cd43f5000b86ba2d52f0_Z},
+          {0x5e6292843027c1509017bd5aeb42ae42dbc4f57392fe45a44d35af3148698c6_Z,
+           0x5d3f2803337620eb19233dfebcdc601ef2771b8d2a0af945eb92390ddf375e5_Z},
+          {0x4279d92757255e47e88796ca9ff2d61fea8537d491f764278bbca797fc5cd85_Z,
+           0x3ffac2d366fd1b050d442098933117a309d766807f6e57dffbdacfd1957cdc6_Z},
+          {0x53d57cbf5be2ee853ac196d3e3869c8f3d099dc8de7e9f71372adbaf1febcd0_Z,
+           0x3819bfec3da20ed253f15529f902b1ebbe2247806711a81dd9e014e86dcf8b1_Z},
+          {0x634755815094f9314d217b6b20d852f3a8144667f4576dc45d6b9c0b9a35553_Z,
+           0x1951384679e12801076733678c5b3bf1bfb8d1242c36cf283a7d0fb662060a3_Z},
+          {0x29c37191896170f667d1a828e1ac4b4d0af93f4e99d881b331295a47945a174_Z,
+           0x702b884cb87ce396d83635d1f00c6e689f86dfc21c2ec72b81e3d03881e5a7e_Z},
+          {0x3ed8641f61929a350166f1910277fce32b723829b3ed16749328da154fa01a9_Z,
+           0x73258818d0a9589387e30d0f39eb636fd4c9511f1a0f7d14a83c148f991b834_Z},
+          {0x81db8474b1641f0be808e74e156c61ed82572138a2479f75aa012180b1ea9c_Z,
+           0x6553c3d64eda74afb828670a377f5e7d5228cf43219b46af5046664f11d8da5_Z},
+          {0x593ad9a9342864f04c172d2fcead3160449a6aec2e41c83042f9a71d3a700a6_Z,
+           0x2650f17d1c7f029f1d88f2cac113cc2874fcdf5ad907eeef82a48ab03d9f240_Z},
+          {0x19595dedbd11d08dd365dcffe37dfc0cc923f9fe702f6f92ea14588626f7277_Z,
+           0x71657a8b7e6237c9c4476491a4d6d5ba67bcc946a05f681a863bc143bf97a2f_Z},
+          {0x4b95feff1d5b9ab1f0dba108419e9c7033bd9646fd5e61ee17087a1e110133d_Z,
+           0x7689384f6146c35aa86b3224df6601fcd15cf8cb484cbc6bcb4c9ef9b694d00_Z},
+          {0x857e8722c42cb30c23e34af37fe4dc029d59d5258d817921c108dc6cfb6e1e_Z,
+           0x3ff63c305b549f1cc06ea56a7b7c2797aab60f216a83a2578f1ce46ad075366_Z},
+          {0xc62e4e1f2bd1156ca104b98ae8ae66d836285ac082b34c654841908b051b8f_Z,
+           0x5354c777759d8d740835bfe5a6fef8f1f68eca4e43bab151d52a74d673a9d12_Z},
+          {0x45048be3a993e349be630354c880c19edc59b40225c0fcf13813e95a5bd524e_Z,
+           0x4d4ab0f6c8762ab881695b8fd52c6c2a62fd34b2dfaf6f794dc1428e8a18b02_Z},
+          {0x479ad16fb6e221c46f11eaf2238f7e7bc612a85859724c907c844794a590f52_Z,
+           0x24a95fa796abf1adf48f3b0bc2a9805afad4f3597952bb6f600b7304e566c9f_Z},
+          {0x53c839c8a28f32af284a25b635f3c7664869e06fa937bc7562676468e7b7377_Z,
+           0x3ecf21c45ffe4648d1a2370ece904a566c599dc461b0e55628932887bccb08b_Z},
+          {0x798f7e55463a870f0ed5d97072996da2cd71df97305ffadcc4ceb09e7101979_Z,
+           0x614eacf5529226e69910a12204fbc916e8f4aaa7fd2dec7b41c51a7df8dc103_Z},
+          {0x7718564fda3ddbee0f34b8d830be5fcbbe21264d9682339ef48bdd65cea4c7b_Z,
+           0x6e0b482d1bdb8b6065f05ae9541862a186f5908da3aa6f684cacf22a5537be1_Z},
+          {0xd7fd4e51ea5bf3d0b1814f4befc30bc4b7cb141dcaca85c8be6b840ba95ed1_Z,
+           0x22d5096a0ae4950e437a69b82f83da0f0163806429fde5b8ec1eeb66ed0ab33_Z},
+          {0x449c1893267c5a83a8841c52dc13d6123d47db32b7e9c6b0dd713771301ce6f_Z,
+           0x777bec62dc6e8bed4fc9736925e70220537949191bc07d27fb20580a69857c4_Z},
+          {0x42afcd8e0e8698f20e7f3b777c4c3ccb20d2634ff535cbdb3d8a1decb4bc142_Z,
+           0x52b840f9b93f808ecf58274c15885952155ae91076106c027300d1afac2ca1a_Z},
+          {0x1f8bb9e993e0d60ca720cfed70a295c195da0abc1e7d8c22baf868995d5ecd3_Z,
+           0x53a69e2327476f90a9261fda7cf6e53cebb659cb134c2c7964649a46f92aea7_Z},
+          {0x3a3782a50a764ea48da8f6e45cd5dc26a319af1ef3dd413604ad76e0757221b_Z,
+           0x476ccc625b90b86f7539406327140e075d00e7ffd82585d8b2259582ea6e645_Z},
+          {0x37ec303f4008f11731a3ed8ea86d9dfc50d4531ebb9f2ba69a57ccc2f95a22e_Z,
+           0x75f6511cdb286d472cd840a512d6bb600207c096b9d3bfa4862e2b7cc1e7507_Z},
+          {0xdeea487783b19deb09eadf31b1a67808a24fe73a3a2be3038c6e948eaa1f78_Z,
+           0x39e784ede26db861e359134aa306c70ed30a8d3c205a2a9e3750958c1a1c393_Z},
+          {0x7d7cb0b35fbaf51b182cf49d446f6cec06c9956633e46b3d82a0e55e5e625ae_Z,
+           0x7dc3b01a79de9e48b18e734f05895860215555788fe2308e68822500243d5f1_Z},
+          {0x633132ae736272a3b396bd7df7964ba2d796c2280394d7b6a2e5ccdfe167c27_Z,
+           0x41184527848d33f60da4f25ceb955b6c33d8a254421ff1cb1db6efb9a2f62bf_Z},
+          {0x648ea258f205c068fffe4630b4138f2eef2ff7d7bd313828525f6384fde66c8_Z,
+           0x7b4a3f7c56944e49655fef1e57e62ee44cfe65268eff9e040f0280f2b15866f_Z},
+          {0x2586aaaa22a4496b4584dde931c62f9f0f429b671ebc5700e90e5f3be3a3501_Z,
+           0x266f5b481b199d162999fd5903ac43a60255ec3afe7125cb9a550b42a87eabb_Z},
+          {0x7b0fdc234e584225a99e972eb3234356302a5f38685491f135df05657e97fda_Z,
+           0xc8fceeea95d693872c5174d4468bea78aa87de092cbc8623375a4b3b16c313_Z},
+          {0x5da435d80df1e3f43cfc2fafe57066b6f5cccee278b13a66b300eff7b7c7944_Z,
+           0x231f2a772c0c9a50fb17ac251609022aa07881f988666d47daf1898f80c0829_Z},
+          {0xb9f6edadae537994f515178794ce527d8e836023a963807ea87d57a50efe41_Z,
+           0x64f5e4604a82a441e9f1c9a8c2a2815c741c0e5e2d0cb79be30889b82fe4068_Z},
+          {0x63b3313fcfb3f7c45244a8d2ad18a3904a739c49e338e7a06f55202090ad67c_Z,
+           0x45fb0e99c46700e4a89c80dcdb381a4a0499fc79c414ed7f839c6767a9fe588_Z},
+          {0x3901929f0caa74a6967e0ff50c8f45f8381b4db36682e87204a36ec9da2e63c_Z,
+           0x73021b4c55cc3165f2c1cac93f298d95c7f6e5306be9b26a2c705371b6df736_Z},
+          {0x3b65dacb517b90cfbc26d382b6db2d91e6de306dcc6a18cb9ee7cb6b0827a48_Z,
+           0x17eb8927906a696b313a4dd294db6ad80df7a9b88392408c0447c25ebc74361_Z},
+          {0x68194f5511068a47db0629a27160caae1cce6a881c6fed8cdead07cfc1a9425_Z,
+           0x1f1420e9cb1c6c4922eb6b40f10f24f15dada3dfdcddaca674e73383ccd78c7_Z},
+          {0x5e83fe32f18c97e8ae49712056b314aa66d932d5a77dee8e1b9eddf339da897_Z,
+           0x40f69dea65ce3a43675e75a263094f7a32364e08bf8a81991174c0e107f9d22_Z},
+          {0x3060eb23ac6a6caf9af47337f0b510f959b8bde88e9c61b0420ace71b7be022_Z,
+           0x74b621d1a9acb7d981737684822dc4eb1554c26128ba6ec7a597ffc4d566412_Z},
+          {0xee062abe0510b74de3247e25ee93af0ef6b4b4548fd1720b9b96e26385b505_Z,
+           0x9bf5b33c229c7c82513fc5cc572dc6e633fa53ee4b72c29387b3456a165395_Z},
+          {0x4059eee4397291986401d80515ab7a155c747981fe3a547f02eaf658d83315d_Z,
+           0x583b5472ddafeb00fede29a202d079f0a1599e898874e4dc90244e697bc5604_Z},
+          {0x30df889a489a4ea1c39631247a2588b100736664d5d9b468c4aa46927fd9a01_Z,
+           0x35f98a4e5569cda355997d7c05b92b1afebc0f0eb00c18d627644d4391f153b_Z},
+          {0x1b4a7b89426cad1f1139e4a95e424912b28e09ea4ef920f4290670557b340b4_Z,
+           0x6deeec12bebac8eb5244cfc774122475a7bc94aefaccb4bd45b676a09dbd5af_Z},
+          {0x63f7772aa09ac5c0a88d93f3a8cc60b0df16bb180e99ef00412c38179166e0c_Z,
+           0x33e86bf3634f4eca8c88beefdee250257c486ee48fd28e323f1394a5e1c1076_Z},
+          {0x247588c4f0c7b37c917b4f42e3b21658097ac7cd0e7644b086b912998d5af41_Z,
+           0x5e1c543b5b8561a1c59cdc8a7395f665433989085ffbe84c2a5b4cff3b99c48_Z},
+          {0x4b9e758b286080b937b428cbe678628bc992c1e6a6ffc6e244792b82150c35_Z,
+           0x53acf792531a89bedc300b80e5363744fd31af737d8dc04cb875af9a1f22cd7_Z},
+          {0x29acdc5609d77d4f6eaa984119918f30450143dc289571c0524816273eec65c_Z,
+           0xb4cafbf77273cacbaf450c9ae651e2f3dfee4fbbb9067effe47ca82f12ee58_Z},
+          {0x2f293cb26feabaf6274a37aee59bccb9efdce19554328c077c21eabd5729ff8_Z,
+           0xfd36c9fbad079f5bccc20eedda52b98fcbdcaf792face8d234d3d402542e04_Z},
+          {0x63f18e5b9e5ce0e8752aefda835704e5627f95f1b73790fbf815d33cd941acd_Z,
+           0x3630acaa86d47055810a49071a854036627aea14444945c81aa120e8505c9ea_Z},
+          {0x51b6a4d7a48d4aabd8f6d1355e6694a96e8e0259b58a175d56ca4259d7c95ad_Z,
+           0x4dd595ace960f4e10063d98a8476e4a2eec480d2ad2d80ba69196ad0279c470_Z},
+          {0x29f80454575d19baa77c9b505bc5f33f0577d8f2ce63780f9213fe7ab18f6cc_Z,
+           0x6848ae259cfd050fd46b04b4acc14d7af20b2cf1b3d0c9973fe068658d043f1_Z},
+          {0x4c89b6510677f513d789833e9d41694b91aa009ef2d147b5c7caf0ec36128a1_Z,
+           0x73d0c5696689fb0dd0a86e70fd2927929d60162d24ffedee43686534f3539fc_Z},
+          {0x3a249f87a20b1b5b4db2e26a256217abc47641b944ac4aa433098a5861e856e_Z,
+           0x26be6d948015c7b9030c6713c38768e7632320a38493000721d29ab91089ecd_Z},
+          {0x754a7f5db51874bad620d86b01cf462e18011e0cac314c9310787c266fc92c5_Z,
+           0x1b29e1cdca47382faf98f2588909a7dd593f878f766a6a7b109d4254b0ef8cf_Z},
+          {0x48d9fcc542ce46fd5ca669d9ef77747698b757918c6a0cf2d8282faf9ef09fe_Z,
+           0x2d6b8804befebfd2f4b9c6bd2eced32bb15ed8585e919cb46ad74ff11c0595e_Z},
+          {0x721afb3823d5e2f754bbd6b7a90daccf2dde0c1f9f1bbdee8de9e64cb52b0db_Z,
+           0x51e351ee5e73cc5e41be981183fa5a11527fdeed09e290531bfd83e574d534_Z},
+          {0x37a286f8bc5d0525c64f028710434d6f832c3e7057ee3f36fb5653ad6afebfd_Z,
+           0x50cde5410faa07c33d30188d6cad656590014044895e2bd6a940998dc7516ae_Z},
+          {0x3ef6f601b2a1d947ad6a3c6eafb3f21db71d63fbc5ed57a1f2b5418454a3f48_Z,
+           0x5783cbde55750bea5e6d93a2893df8fefe8f3491b359c35382883da0b5d5d8c_Z},
+          {0x65fa96cda15d5f3b10aac96ee6389477bafbabc0080f81fe336bab6421c733b_Z,
+           0x27d19fd7cd38d60baf5f20b064cb941a5d31f48ca8c36e42931b0617db5d780_Z},
+          {0x61bc6c2e82eea33335735aee11a976c2830ce6ca853456e1f462eb1caa08c1c_Z,
+           0x4251f89865abbd7b86f4f97c2e3c1592ca982a3e65a67d14baa391fd75746b2_Z},
+          {0xaade4a6822d0164045dd5f4787cff3a442b538d24f25fa1fc770f8f999c2f7_Z,
+           0x1eab702b2009863e2fd3f922e67e13d80f7ee1ec2552ad56d16f3a5fce61c4c_Z},
+          {0x6ba561370d1765819e5e3f6ff8bab69ebacb11d9b09e7af00c5b2c1aadbe95b_Z,
+           0x6fbb1a8372a5c79abd9a8e3c54365ffb95602eb47ec328f3664f0b90665d561_Z},
+          {0x5f6b00f02978e07dd51556c9045218a62ba6a9c5f758e8bd71f256c8c834a0e_Z,
+           0x9cf6eb5b793062dda8d59cfe8af5c8409d45f2a10d30d1ff7476a9130df5fb_Z},
+          {0x1283cf7179b31667bcd6e95bd9653b34fda5f86ad4f2d7655528b828789442c_Z,
+           0x6b40826a1923296e28e9fb1731fd38c0d33e3571836fcb4f44dd634f7515f3c_Z},
+          {0x1332b53eb582b13900572c6e7edc988477dc03710251c771b0bc03beb50602b_Z,
+           0x5c54c2a2b78840824a1df6e8d68e49e81d14c1a53d2f94b1a8790728453252f_Z},
+          {0x6d9daeb3548e6e20760cabf269230bda6d31c863e906ac94becd9da74d60168_Z,
+           0x1d7aa3f094beb180479b92a802021e13cb8a90d97e8275b04fc9a323f9b1d12_Z},
+          {0x2bb0eb48f444f53fbd8da7eb5c5d0679965557016596ceb1d122a81eec64ab1_Z,
+           0x58b80ab0399b6086b2c4a9ca531a58fd205956a2743f47f412cd308e4d1bbbf_Z},
+          {0x27520779c7fa5ccc80bcb568ce0f129094c368059cd5786e1f059af0cb247e0_Z,
+           0x70c52afde816e2838f6e9a9128b0ffc6f6170c115c5027469d3c42ea5a7613c_Z},
+          {0x6670ea7600b2696a3d79f2f537dd0dd654f35a9a400b78955f119a878e43c13_Z,
+           0x34193e913f773cec4f3ef2b981b64efd29282748b1651ac116d91ee9b3b7ad0_Z},
+          {0x10bb1558dbfbb9fdecce1eea2ac58c5114290f232b031e039293549ae639205_Z,
+           0x7540dd1fa8ebc532f8ffffa7eecd7c88f6733ae125d33a0757407d16f0bcdaa_Z},
+          {0x6a4a0272dbb1647b6b6b164926d08f58a5c5116e9241c15bc2734015ed33b70_Z,
+           0x7aaf4235a6014579b23aac9cb747d96b43e5c56dc7042c5ac6733a1edaf76cf_Z},
+          {0x75903abfb45f1af7fdf465aef84feae7043a7304922c6c3461ca54204c211e2_Z,
+           0x4ace274430c59d7c72b5c1c3ee701b44585462b0500b1640f0f126044ccd075_Z},
+          {0x772b1c338a673f3674f0fa675a6754335099558465d32f0b61d9fcc20a15768_Z,
+           0x37b22715631dc08d4fdd4969015fd36da3e9708c2a3eb93f0f9531867eb2c7e_Z},
+          {0x289344a7505528b7d83d7eb1e329a37dc3ad3ee62ae0d5bd6bbb3d7364f9365_Z,
+           0x464e8226cad85ab44f82297295682df6b7f29bcbfdccf77e33a63edab3482a2_Z},
+          {0x12ac4b94bdc9ba032fa6ef13ab4c3cd8f930aa3682a3e72b9b75ce521f17551_Z,
+           0x6e20ed9110acaa5e1fe831095b1d73e266be3a42a97fa35657869ef82f5039e_Z},
+          {0x312fc9b8952dce8d7400d971a14b9318647af65ded97e8ddb78b02a9b9e48ca_Z,
+           0x2509889cb2ae15e82a061e4a283d28825ba80cb04d4b396ae866b3d1f49cd4c_Z},
+          {0x4c3ebe4ba6e5943f2598e3d7fada73deef3eb04c1c0a33c6a30e7f9b0d57dd1_Z,
+           0x64d65f309326dc2874610c5a32d14b47f2fa589661795cfb35728486696bb6f_Z},
+          {0x4a24c797a0d2bedc68e0440895ac2e278939604342d6f17f2fe6910ffa02ce_Z,
+           0x65abf261f1f31499b309414385d835a922c639ed7abf111e7d9f9f5a93c8229_Z},
+          {0x1e32487c2390fc4e4ab2363c170987305bf649dd594f5fa2d665cdcb23b4b28_Z,
+           0x709f0b4d0b4cfaceba4c30c4d4f90b51c68e617813500236339cc7730f1e7e8_Z},
+          {0x347647dc2d309bdcfa4ba5258087ca7d5f1494ba7460d444f7eabb2fd7876ad_Z,
+           0x350048c6b8154ce3308fdefb5ca1a630b299eadee4e85f535e7eee2383b3b_Z},
+          {0x524c9c7da0674535f368ec82c959d01e183866eb1c2c39495a4b1585357bcdd_Z,
+           0x6e4108a34f33b515fac2a304078d66b2da3847be3b803d719a2071ef831a94b_Z},
+          {0x731e6b87e0ae824e0803c070240d5c9b8f55e0816a4dea1b594116df1ffc41f_Z,
+           0xe3d1241ad5cc299ca7bb8469cf5b03ef026e2fa82807874ea53a6c72ac5f94_Z},
+          {0x783802df2714b9606271b083c7fa045dc1136d9f3c04935c4e559f96d0b22b9_Z,
+           0x6a7890e392b5221f806aeca5f1f5df45f57b3b7845a1d362597dbb50bfaeb63_Z},
+          {0x2dfa2e588ecd358f55acd6ff3c298a20b187711adad7fb1de4ee6ea08e0e529_Z,
+           0x78098929df65ad32c914dae8edde692d2ea008d0d0a7c59dc46c2a408a98a34_Z},
+          {0x3469a5191de32126fdfae979621f81da116cdc49e4839b62371801bd951dbbf_Z,
+           0x49863114da9745b7d9c465545cb80c7b8f7b7b04c1d131c8a933b8493d646c7_Z},
+          {0x46117b535b2cfebdce8543469874111d856f97f60b4981c37f0b5f2577a1ddd_Z,
+           0x2d85b170f9888a4700df782518a878c091b1a308513ad1bda7138c82b8b6a02_Z},
+          {0x278dd235b1e06fe7b67e31b9067440ebaba3a7814b513575b8f7d77000f05a5_Z,
+           0x66c5569f2d71b2d4bb96347c2f41ef302deb8853d96f8026735acbfe73e17fd_Z},
+          {0x1d08e6eda54a930f51e6dea44310a50f6b3cc55fed4efa28cab8dd77946b37f_Z,
+           0x4ba19a92d5575b3332a98b831e82c10c54f94ff5895cad3d65d4e9d3d0b8e7f_Z},
+          {0x1c937fc16c5f636df5e5deeaa85854236e53eef17031e1f4f54709bbc1e31be_Z,
+           0x6f0a94ff110a7e82499d68c0bce06497320d494c5bba8d574a032024e86fa36_Z},
+          {0x3ac10559c70ec64e45ac2e1175e6445107278b17e14e85ed5e8572fdb7cab72_Z,
+           0x606f496889c861c343f0da76deab855ed401384ec82d789926e70372097a07b_Z},
+          {0x5360a9a6b226e690e25d3dea096a017d408f6c8d39f9040e1504e9ab43e2035_Z,
+           0x67cd680eb8eab3304f52ea7d07886eee1eeb2ef8374a06ca629e9b774700ae3_Z},
+          {0x1a441f62d9824483d34fd6b4665336413a0db92545f82a4bca26ed357f79a99_Z,
+           0x142c5f1d249874f407817cc3c59b22ef1b48a0ec2406a8dbbc421cca7504cef_Z},
+          {0x68a455849d29c68443021afd6cde779bc30df5cba50303c97913a7739ec4ce_Z,
+           0x62bb4f4c55d86545a01aa12f8b7f101793cd0b9584003efd0956b72c19e62d5_Z},
+          {0x497f65ab2ce7519b2f7f1724df34a5ffb76c448606b0a7249d19dbc7da2ce47_Z,
+           0x781bddc0bea5ae32cc9f80278d4bb069ac5766076ab340aa06d8d7448c4852_Z},
+          {0x39dbfce999a9f128d0ac6ee8a6e55976aa7ee7b85731bfd7f5dfd9af68f5034_Z,
+           0x1255d957f4d8e03141862aa3092ebf33e793346e5758ef186dfc6b87dce6457_Z},
+          {0x7a455afdbe1c2ae3833eb39a771963c89b4fff5017c33d284459fe9b1e0dc17_Z,
+           0x5b5caba4463a6cbeecdaa01569ce38e21b755ff5e18860e9fe9f79160c4d7e2_Z},
+          {0x52ac708678e9f0972df611994f5c4c31461f42d728c2140f388a658f03ec6a6_Z,
+           0x44f1b9e6bdd8918fb058a24af838cd8733f46d99e9f2615d8fafc8c47699579_Z},
+          {0x3439b57886902e6ce95cd98c71441dc3971432190e644cf0c43ae17eac79332_Z,
+           0x480e9a024b09e0895a82c2acefef7cfb4995146acb1ffce12cf971af0de8ce9_Z},
+          {0x4f1ca9d1c16157e8c1c1af8bca7407b9e7e7e461f920a82cfe61ee2ad0bf799_Z,
+           0x642cebdb864f19821b0ac2109ef3fabb9a4ef46f6e0738b4a64c477e4bf8089_Z},
+          {0x3f8ce9bb8dc5bb555e253ac32e05ce897e8340d0a840d9d2969203edeaf1ff7_Z,
+           0x3be0f06708673bdc03da6b1fdc98808ac48436dcaa72515d9a2961476571304_Z},
+          {0x75ce80956b7c379f47612153d071f529e1a532ab33add0fc30a12965110a936_Z,
+           0x5afd4cc391bf1a1e9965f46b670d01c547e2a874cdfab4e4941d57d8c19568e_Z},
+          {0x43bbb28447d013e0d5f6b87537112987552b40bca59410ddba5792dde0b358c_Z,
+           0x70ee7fcccba2a3a9c470f945dfe7f5f102f466528fa2e404279747188c56d3d_Z},
+          {0x437e18b727e23698b48cf6b2e4db9d0acf9c485afa02d6b4ef244f0db1ecae1_Z,
+           0x253a749c48b8712244991dd8f5e3b12c3e488acee188564c23ed8a629cc4308_Z},
+          {0x57dc4b3be50eb9f2c4c7f24e42f2a285685c7d7fdad973dad18e4a53c889cdf_Z,
+           0x199aa8211afe736e4a3405fe1c700db2d63f04e0f2b05540d5c034888d67e39_Z},
+          {0x6dc56b368eae5439ebacd1e8def5161a79d3ec2f77c7e4706911765e9e8e7fa_Z,
+           0x5078cdafd1ded955dc10f28b009bd501bf41451a771cc86c9fbc93f7f4cbb66_Z},
+          {0x36f4ddba0c7ebbf1845d87e7e0fda7a60c4d90157063ea2781745baefd1fc64_Z,
+           0x329d0d91cd2047ef0ed3166217375c9d8604c21c973ffb694c8c8c8da1007bd_Z},
+          {0x4ba56b88372f1fa0ac4e15dba3cdb987f04089a470266e79d9e00304c5a20a6_Z,
+           0x247c5879d9e9424639655f590b6cd7d9cc44e73c504554b44d1a5896db1a0cf_Z},
+          {0x51ee6486f9ef97868475986dc107f23b41c203210ccdb953221ee8be360ab99_Z,
+           0x4a9f8279ed1da580fe7c9234205bb0b15d74e5729fa5b8a182e33e501e33475_Z},
+          {0x122aaa53eec4eab1dabf34abb82f01e8e9ac01b013d7f868371d8fcee46fb02_Z,
+           0x5391b0ee19a008fd81c9a402be1026da730f345d359a5aeaabca7f68804f257_Z},
+          {0x5a2d0d278efb0d0db252e01f60e01d18ad9933676acfabafa79aac3613b6308_Z,
+           0x385cda0105caff7d04e511b18aa93573c9314d4669d377151828a8f3c523b09_Z},
+          {0x1ef156e976d5f3c956ec8abd7ef747c6e697d0ac7e17163980eef8cc92cd6b4_Z,
+           0x1e51351d6a5fd1c39510bad0dd0f46b4f0780a6d0cba3af716d5b4dcc3079bd_Z},
+          {0x6c9088ac52f0610f60c1f513d5d99e7d02583f7d3a95170bfe28604de6f633f_Z,
+           0x203b4475bb155b559ec3143ee76afbaa4ec9a76b20c33239f2f62102670844f_Z},
+          {0x6eb6a8059bbbad74a94ac5cf54260de510c38278eb9572c0c0afbe4ce99807f_Z,
+           0x1fb5f7bb2b258544443824d0c61edb7e74942c4d0c796583187726c4e2f63b0_Z},
+          {0x4aeb7c141dd8346a668da775b07cab6338f9f62540c559c5058328d1b839e23_Z,
+           0x41201d9c711f200cfc9ddf2767a664f3243449d05f9c68a6da6ab3d70f036ce_Z},
+          {0x5028392ea52427082e2e47a1178909cff59351ac67af08d2f53d98d7d7480d7_Z,
+           0x75223ee95a4c4769bd767f237408f101cf5a2fb7a311d583d4f5bcd00c6e9cd_Z},
+          {0x4009b3f66daf34172ce1c0dd7fc721dc84bd953b2805002ca34b31ff6848565_Z,
+           0xa9499f1d05eb4da0df4781861171e3f874497276c5d3861e3780a20d357392_Z},
+          {0x7da6f0ab777574d4edf4062659aa801800b1f1864e8bdd6aa0bc43cbff6f02c_Z,
+           0x67dcd8629e7d2fbcb15864cedd3797a58fbbf9cf83de3558345392c9309d123_Z},
+          {0x54f05827a66a3ac1a16dd144f5e6a725a46bdb619100d64af7b08ba9c769e5f_Z,
+           0x40abe38d69f62d7b4668599eca7f65c9757bae3110a1d92c7de5f8e9236a0d7_Z},
+          {0x1601b23cb99bae74cd4cc275c2101fc12130f1793c47d3db36db11c4c0ab7cd_Z,
+           0x508bbb0f401bae72aae1b9541518d7d42ddb4952a3f0da31ba2f8c7fbfa5a1a_Z},
+          {0x481ca7759003a055b135f503b007bec121ceff1c5cf8c7b85d778a739ebc37_Z,
+           0x46e13df3a4c1a837d617d92450f24445086eec02e7927e894b9ea6059b6954b_Z},
+          {0x781196d3ef74e292b0509add3bfc55c47cbff4fe914ff1ebefa385b25ec0ae1_Z,
+           0x5c711d611deec110e1afbbeccc4d1bbd2138a81f058802078e517503aa96d2f_Z},
+          {0x4c454d46137d2a8c17f2ae17089134c0f0fca5b5350a7281d1ee36d332086b_Z,
+           0x2f8e59da4ce16de7339f9cc726ac823ef12968e8af41955afc5e68114d85e6d_Z},
+          {0x7b4cfe1aad3fb7cc50e7d7abee6bd944287262c7cd3ec521f3e2f7409591778_Z,
+           0x41e80cc0d2c46450a68027881f55d08a78e3e8acbc85755cb42ac245469490_Z},
+          {0x5b121a76e83791be7a8c769e4a738e66176652e7b0f28e9421ae3e28632cd1b_Z,
+           0x3be93fcd4f5e5ec270f62748600bb8320dcc8d9d057deb5a09c641d59419c85_Z},
+          {0x38aba78d7303de1bb786cd90c16c35c069794a981f14501cdff81f04809ef36_Z,
+           0x61c93ae1d29af35ce83e04166275c460004b4fa6a44a2943892877c5a0b9e95_Z},
+          {0x238ff975534b39d4328d2fb09434828202953f0c8508ca1762e22b831db84f1_Z,
+           0x77b3f83a3515336fcefc9327b79e6bbd3bebff04b882aff661efca85059037_Z},
+          {0x4af8fa59b6d118b188b73af5bc8fe6c622c949ad176c8858570d9ce9c801a03_Z,
+           0x11f6bebd56701bb03d0c4d09abba3e9798919f4c91af2ab3e86cc290b4b97d7_Z},
+          {0x19ff31fffe94c26623b202805feef4c6b278c653f828bef4fe87026f79f99cc_Z,
+           0x3c89d9a61dbdda9993feca6ec58daa403cd9aba21579c336c9b911b2f54a4c5_Z},
+          {0x176663c53442e46b1a9ad40e37dd689f03f5f752dd97a7d61aa82b4d582a425_Z,
+           0xbe033ae1aaae81a4fb295051274d53690fdc1661e04f6a658594c00812549b_Z},
+          {0x4644b372ddfa2aec55326546728f3c2a5d9d8fdaa6df6ff5034116bdc440453_Z,
+           0x37cc7dc4842d88e252fe3f7bf177b95deb3b11d097f258ce5522a4fb476741d_Z},
+          {0x121aec6903ef3f52a533931b61f5eaa14891b693f37079e95f2da60c5078d23_Z,
+           0x1b11a440d1937267596491ec1668715d6efdf1a029e56449dff7d1d065fdac9_Z},
+          {0x47c71454919af69a1aa1a798fac0d600a848dcb6eb769e89e498700e0c5db84_Z,
+           0x7dce06b0b64fd23cc4b68f13aa5aa7cbba22d2e0d4c09d3008fcf7dd376b7ee_Z},
+          {0xbf74a7cf05fd18b3b7902c21563b3ff81b19f41edfb7a5fb06a3f041849950_Z,
+           0x3d9dadb167c7ad778af9dfbcadf0135d8df8ec6d9c1a7592c02783b061db55f_Z},
+          {0x535b5029d6e00b1afe0b8edce3a426615b188e6afec56a2a0eb0d6cb60d31bd_Z,
+           0xdf1105c3c9fc6d5e5710811c1d949d8e41d42f31e782c988658732b29cb4ae_Z},
+          {0x6005e128fd5a27b749a30fa56766ec34958d2116a479828ffdf30ffa22a8991_Z,
+           0x12158816d08f33bf1c2895eec0b5df2a4bdd2281349af68184834092e41af8f_Z},
+          {0x2545ba00a30adb19d1fb7bab616b1ea53c8765bd06c4b6f8f312b01b3284eef_Z,
+           0x79fd72dc8a0c42556398d471a3b98d4eca7cae3c0a3d2e26b4f69b63b0c5b1f_Z},
+          {0x5388a5709476b0627b3d828eae4fef9c57231da3db781c7324bc98df5484b3_Z,
+           0x6843447ef99c9e235f9e74983aec2d7e028ce8d5020d506e2d2a16dc8788bf_Z},
+          {0x4e9885148da09a82508eef0a58cddcdfaaa9bb5cb96d23785e00eca2bd26796_Z,
+           0x3d3232efde10a157594085ad96354015cd1184f55b739a7c5b576d7cd781221_Z},
+          {0x1c06a16f6f297e8d315f6b7ce5ed8b6cc3591b302d4563be99f26f78ce8280c_Z,
+           0x3db714410aebfd11faca0a7575258d78b8f1c546666c923aa420e75af637975_Z},
+          {0x55134f976dc28ac8268a63aa842b47c94c3be6bc0e36a0b1ed74f58d36f1097_Z,
+           0x677dbb3c83300c7b21b9d293c335be073f8e813c13b438b5cacb1ceead1917e_Z},
+          {0x3776fa5079139641d326fbc230447e84af274de750b8d727e25970e5f857eef_Z,
+           0x7fc740c13f075f45f818f7e68b2aca1b3e5a80bb77ed7b8f2ed92d82543208c_Z},
+          {0x3b7c92306b73facb18c97266c726d6441e635056d3a3b7782d85d31afa4fd0c_Z,
+           0x5d4446c08c5540b302094fabb709fda15d95732be4ea893bb3f7ddc6eee29b7_Z},
+          {0x4953cb98125af9e57906a09b7928162317342a136673b2ff747212b6d74c702_Z,
+           0x2089bc2bd5b2b24e7e1da8836b4d35d5360ad9122fdf6c23f1c56ee893c7aa9_Z},
+          {0x47a3cac8d25eae7c132aba000d01ab14bca53382552165354b14756124a1e11_Z,
+           0x2a82e196de1bdc1a659c98d009510feede1514fe5b7c21b76ede052faa0475d_Z},
+          {0x5dfbd7d85c400e3c79e39b72930bbb1e9bf0567904882419908dd84291ae507_Z,
+           0x2fb5d589ac6b7579b8478272e44dfa97194ad3b23c3ed9ae7919917ccaf8a36_Z},
+          {0x1fb14c5b1ce91a7e899431b2e48ca21721147bcb7b215270e0b5406e35429fa_Z,
+           0x56bdd8820fb76adb9c6557da4b38516beadfca90bd77032fe45bb4bfec8942c_Z},
+          {0x156783d5ae22b963b7ecc392d06e41d54d4c20041fb68f17dd06d36a3fab20_Z,
+           0x43bab810f9fe1c85ce9cb74a990382dc70038b668bc4ccb5519469edfc1c3a5_Z},
+          {0x67d7557635a26c4be097d53efbf464a5722b981fb433f4855d58c22378571bc_Z,
+           0x38dc41b7789665e99286d57b8e010b92c0414af4f8af86088c956bc11a0e4f8_Z},
+          {0x54e64e59b4791d1db806edbd0797a62ba57143ca694e5b530eee284ee51cec7_Z,
+           0x61529214c3f319a14ecb3cde2fde41169a8659aaf9c1c5edfabd1fae284f5c4_Z},
+          {0x5b5e045838c2b1155a097eb6550e1b81ea3b0f18f0a84ff2b6b5b16a6062704_Z,
+           0x5d8e957dfb2c1e4b6f59351280e914da35dac3aa95e13e3935ea9da73b5d1ac_Z},
+          {0xe73175a1afb0252c98cd90c75f4e7378159fa9348e1fc83d06b4728bc4f042_Z,
+           0x12607d43cb7b7e01676abf0d6187360f8e3d408927cbd1014037a201ce18ea_Z},
+          {0x5a79cd6ef96c3a08c214c903604353638f0cda3e6c3ed663feb21a197bbc55a_Z,
+           0x7dd8fdacc5e7ed304c925276381f56822e841285aee5db0295b0c11129020b2_Z},
+          {0x6ad9f0942d93041b7dd804a617994ef551afc9298488abe9efd96b1d4da2b1f_Z,
+           0xc2866244139576ad4e0e22b2bf6c4a84c921d3a924f5e27b92f2cd93d12593_Z},
+          {0x7906827c4573bc96eb6cffec41d0a946723f49ba033911f676cc103b81a7cbb_Z,
+           0xfcba35fc191a10da4263653e47a09e5209c21164c1367fdae933a9a2ee8eaf_Z},
+          {0x7ed61595b843e7563ea1187bd3038e1e597ecfc18366ccf656c81296cfaa8e8_Z,
+           0x15cc11c29d87fa4c5c03fb4b04b6cf5415582e50a2a40d5a4d3b37f4c3b8c80_Z},
+          {0x43338c31a36188b524713b623f4a9888010da4ca7df7f342115c31fa3e55ba_Z,
+           0xae5a37445464fa52b81d29120e50f58812089d1870bc52aa5a986421e57396_Z},
+          {0x3f5e18f1626b4f7b5f3b375597906f0ad7680a328ab4290a5f624a35581529e_Z,
+           0x71535e5adbd009ef6cfc0988265f48d7d1d504f008b072ba68fd78897c4946e_Z},
+          {0x6197cd3f3840e02889ca392e74f029f1274860d40c1e735978dcf9720394efc_Z,
+           0x4e7f7c332e85462a9b6c41475d8b680e3d325feee64e8c9fa5b97ea0514bde6_Z},
+          {0x45f006cb94c77c4b3ab7e05678bd5408cbd34e766a236b6a21b0bd86b5678d5_Z,
+           0x41b2369ec30edfd367cbee13eb4f637970007a83a20661e3df51726e585f88e_Z},
+          {0x4bb2055b575cb2cfc5cac5d1063583ae9ca5e8bdb2a3833aedd785ca390eba8_Z,
+           0x22ce4f112ef89b8a2d60d8f9e39a4a2c0c67ca68e689563ef2b350d0f2fcb40_Z},
+          {0xf62c995c3ad71d588164d287f09e869092108afa19ef1002554973d864f43a_Z,
+           0x2b40450b8e008bb7baf8010c404f64b1c6c637253ff9f3640b3652de2f9d757_Z},
+          {0x248d90bd9d5b7fb5ac15a1d9ac615da4e117205276bc057809493726104c738_Z,
+           0x42591f77ea99e056b4108d8d912abf78792d2f9858344d359a7b3a71f26bf9c_Z},
+          {0x7b7f301a2e9cc8c9c2f9033a688385b29a3f542e055e0c813658965deb5a465_Z,
+           0x707f0a147ae182e5dedaaf01ccc3704a9b86d99f76e0df26dad86cd2495dd70_Z},
+          {0x6cf31ce7646ccdbbb072d09042e930f2d3a2955d113340336bb83130a62d99e_Z,
+           0x5a675ebe7793cb8aa3b1e8f0cda248f2dda63860138e3f736f42f685d3fad17_Z},
+          {0x1b1cde48a059c857916e3dab95b4da00f65d574e461860a08e5345893b099_Z,
+           0x2134b26f97e85b83d73d54944b53782a54a5bfb296a01aa3b8021cb1b8a3a8e_Z},
+          {0x35a0f5b87c68d5f818f1b4248af2095afdcdee1671465d29c3ef20bd2b37f49_Z,
+           0x4d59435ac0c522f4488c0288e81436e781aaea592e69793d04056cb85353ec_Z},
+          {0x2a457e5ee4452f7b1c444902a6e641278d313a00651c96d226b9f80be534b89_Z,
+           0x7034d817fbfa5e537977caa1336183fcf638e3a76d23af14eeeada586af7e54_Z},
+          {0x18ef354603641b7fcb0e0c0e0bad8c80d8e4df58ed2b550faba9a04609c65a6_Z,
+           0x3185dbf54d0054b5341be6f46f91a86ee6cb5bc30c8e475d3bf6dc30146c421_Z},
+          {0x2fb25b811dc4c03317d6166cdb4336e874c39b7b4832e52efe03ba510b52ede_Z,
+           0x79143c126dbc695e975b12ece1edbbea18596275f3bc041251702a3c0fd44ba_Z},
+          {0x70d5652ca45eb6548b9461659eb3499f9e352755619a86749ff37c8437f1c5_Z,
+           0x524add209462b7887f152cd410779aef09420f2154614051d2bd5fbfd0f874b_Z},
+          {0x4988b924d574364981cc5c420a137b5f3aa3519e79b20e349299782e5a04c71_Z,
+           0x299559fd60b20e8f566901a324a6712e8de539231992e7ff0e0ef3a2305af7_Z},
+          {0x72fbce3530a5f33c615f6cab16ed5069e637a670350f46d42124713aba6804a_Z,
+           0x6c9f81497cef871b11e46c54b0f56fec69f20c39e1f7e6456c1d557846deea4_Z},
+          {0x6b94c5fd6397e833ccfecd5c0b0dd003e55513ed795e25d7525333b4249bc76_Z,
+           0x1c629a33bf6eb58df451dde2004227caae839acb61da0a84f6351c9b2b49e58_Z},
+          {0x1577b1d4c4f3b7eb2ef3fb779d0834d1cb3ac01f92a5c64f1f895a2e8c7809_Z,
+           0x11907f1bee9535ff99a928da4074e14032ced9ffc4bc7f22acf50ce5fc6b571_Z},
+          {0x29e423af260a41c87ef68f4a47fd60816a4fb834ac716b76d70d827ce2c60ec_Z,
+           0x19d9b631d1211cb4ac798954bd01c5c660e4581ddd091c2f87837a07576090c_Z},
+          {0x595c10636e5aca55664add69b7da7c1aee69e240449a3bc426f5d232f444118_Z,
+           0x6fd321bfaa65a91ff58c1e556ac3962d7626745a179374b8daa431f92391d27_Z},
+          {0x401fca5438170be38b64644e04a4b5e0eed041637d2a5d25db9d21c302e0405_Z,
+           0x640d4cf3fdb37827f13bbbd1a1d3e2aeed73fe3af7371cc0b390064e0fe807c_Z},
+          {0x7d27918e4da91411a3eff411949953c1d1ea92d3daae31985d13700cfbef388_Z,
+           0x6dca5b7aadd9e79bb7c80de3d16e1fbd089eae88f025c30e1a8e82b4d06ae98_Z},
+          {0x78cb92cceed4be55590b246b75e98edd40af4a08861695a5f8049153851fdc7_Z,
+           0x3e8fdf005c92b5bdc12b1934fe33e2ca8997bfdc447763a4ae585ed6c5ebe64_Z},
+          {0x72b7d90edd1b88ed7eff953047e0b35ef3ee69037e72cc14070e545d0efc62c_Z,
+           0x22d8c9dbba7421033477ad598d905e66dcf28cb298a31102c591aeb716a2be9_Z},
+          {0x62501d2a6e7547426e2422f9049468bfde28900cf35bc70496e834f08bcff48_Z,
+           0x40f46f6dc3c898ed57c445f42614c48933eefccb9478fe28f03725c3b73a7c5_Z},
+          {0x10769e00be1f52801db350f9cfa217f70052fe231a9e4e5f89a8e2826277357_Z,
+           0x3c1f18a367f91e6bc742e345fcdb21aeca8d3308f97cc9455375ae64bac8968_Z},
+          {0x6ac2a0514f082cb871d745fbaded0acd2b32173f9f305fda3e1263b3394a48f_Z,
+           0x6401676cbe781e0672e5db5e3247715e81c5d10be5c17eb15772ef20321d5fa_Z},
+          {0x2646f5231bd48c0a60711393f4638696a9fbfd26409efb7b9c3ca19a9c4a947_Z,
+           0x708863ee0a3316ff8c37f9ebdb998653dfe1694f2bcdd21725d923a03f6db9c_Z},
+          {0x652f896e06dba85a862034d63aa853f3e5f6680e2df8af0cf768687aaaefba6_Z,
+           0x58824fee6af2a79b5d147356523b9ff3b7c8b160025c3908878f81e70464777_Z},
+          {0x3d87f4f906d142cad9216a5d454498d176ac9130dd8ca93336c5b36d93810d6_Z,
+           0x52eb2a2d289c45e7f989dacd22004fb7c4c6c7c80b0ef1affe5860ecf545581_Z},
+          {0x75da6243ee773fc35a2fb8e71ede5e7e15804b9ae958d1dfdb83ae347dca5d4_Z,
+           0x67aa31147f05550d463b8e8a96bd3124dd64cf1f758fa87c6f60828ffdf3562_Z},
+          {0x2e959802e69a1ea0733783f4c8663596f1d9b364d5cc0429645280f271f5d65_Z,
+           0x6fae879ebe85bd36d1ffb4daeb1974bfc5fd0da26eb970828691fe879e9920a_Z},
+          {0x29a1ae1d2bbe2925e1228a9033b0aab3395fd5659a4d363119bdc26b743663_Z,
+           0x69cc6091e847aa3bf9c8675feb8982b556fd2653c5105318261e0d65398c567_Z},
+          {0x5c61f16dd6f8f4f319953544c48fa14725198552bebdc23381bb45ac57cb84_Z,
+           0x1122ee056b2b0c014c6d13e48d085232fb90714fc4c8529ac0f307dd07ba7b2_Z},
+          {0x311f7160881284a32cd3d898fa7506029e9700f89c13cb9f44683c5cf8ed5e2_Z,
+           0x304527cd8290a5efc46bb7f14f9a1424f9c9b66a3dcbfeabe46fec6d51f5db0_Z},
+          {0xdb90fad7ccfed0fc75cf97b35b6f1d22d7d510ab72c493967bc5a725e365a0_Z,
+           0x2c1246aafaa83af43709585fb58fe9268b835d2b3ba422d1810e1ce3b35b4fa_Z},
+          {0x5ef446efc4dd2342b4af2039d198e4fadffc6cc9a9df30c16344174754947ef_Z,
+           0x5c96520a785a3db8578eb44d6fec9b54301528843c6282fea2872abde592df_Z},
+          {0x520027a1b8ae39638dea6298cc3679c1353b9eda5621b741b6ef917129e3fae_Z,
+           0xb2a9db68052221e0b4960ceca79a04730f3fcdb4f8f1d3f8a0ebba35305677_Z},
+          {0x1faac1fca9a1be6419d7bbb22da16a1b01d85f879015ca263e3644e14ccdac3_Z,
+           0x4d8472d5216839936f2f625f8352974d5b1a56caa509697507a7faba9b34589_Z},
+          {0x17af7912a3dcfb148596af539bcf834bc610c107c70e8388ca29896304698ca_Z,
+           0x44af6671d3d058e2702d211a48367656da00712d2e860743fbd3d67069699b1_Z},
+          {0xddfcfdc8a516c64dacd20dcc6f1ad1eb71bb176ce3c1dfba6526b58be8055_Z,
+           0x5a7dae83d200cb51e2f05571275e9a97cf496dcf95ec8363224d397ce9598be_Z},
+          {0x416b64c265bf0fb88bbe0e228283f126fafd3a4366aa70d12f006e83830c8eb_Z,
+           0x40af9648af11d1531c953b5656bba6133589ed89aaad7a644ca8420a16a8b9a_Z},
+          {0x773d6a63ee6b87112dc6481856ab103e41db911af31106f1e85ff970d11ad6e_Z,
+           0x6cba5fdafee6f73fd4fd628c8e6bcaf912c54529f2e168ed4cb3a4f3234f09d_Z},
+          {0xb7b580595f4197afa3e47746aef12836ea3feb91f5866af0fc067c900e9437_Z,
+           0xb6657c903612318ad7fc133bae8db9c98d48400d5c293cd69205e3e2ca630e_Z},
+          {0x44c7b00a8ae7a0e009777433a04967afc00eda9ea58a5c1b606195a481dd34b_Z,
+           0x5c53bfc6507c4da87cc24c956c3d0c54e0c38408cece82015172dfddb917ffa_Z},
+          {0x5b808f27c93a36db266495a42568ae7cfe2b904db3ae024852d02b44c5f34b6_Z,
+           0x6664c42dd34e331f8f9d7e89b05b75062d0e0cf2f91c5b2dc72b87c663d1823_Z},
+          {0x6240cc3bf7700a871631e6c95823ed0ef8b523fec3a28821efd7a79315ff3a7_Z,
+           0x1a4d78dae58cc15e38e9b748cf74f209ff5870994efaacfec29b09a91e2928a_Z},
+          {0x396a6b34236c379c017fd6f81f1f17815279e3ec8528d485ade529c474dfbfb_Z,
+           0x7987bffa88967c22b2af6f83a282199d978c4f32dd71747e6e9422c36bc61ea_Z},
+          {0x77ac38b38b75d8105da36832a916ab51394f6050ab8a147855c8e66eb6f9a90_Z,
+           0xc896bd901b4cb1877e7f0aeb214b289a2f8d2a7a74fae464203556c6faa9b1_Z},
+          {0x33eea635a3989f5f0eb89acf4e61e8fafb5897e537833ec817cf5d82347f054_Z,
+           0xbace338a110dbf629ed80cc43b6a410a52bece49ad8c0916c0e811be5120fe_Z},
+          {0x1dbcddebb6192166a07411e8693136505b4ae35f9b398f6889b127137adf529_Z,
+           0x19f813783936268e2f4797ffcc5ca4d85e0ccfc61b8895872f03ed83aa2fcd7_Z},
+          {0x167c5342be653bcf694b5247d16268a55e7be3921e3495177a040a15a421502_Z,
+           0x5b9374664465a776833724c88e16cf3bfa0962e01907024d8ea572c62a7dc4f_Z},
+          {0x14c48695bda1ffb71ddf882b153d4495a254bbb952e957de36b1f4000d1b884_Z,
+           0x2ed2ba0d852244bd6b8b681b5040c02bc6f5df65e810f01330794314d6a4010_Z},
+          {0x43cdb4a3040d9a5bc6852a4fd61aff473be2f4309fac4f6d0015c76feeefb30_Z,
+           0x3b37352dbf40b38abdc8ed7531b9c8bd9e89cd7bb2cd4bda3496073617164a5_Z},
+          {0x5844e3cf59e3b6fd492e3ba8a2581040d75192f02e441f3eb0c18fc35bd541d_Z,
+           0x2605a965f9a10275737b2bfa16b1c73d80edfe7d307f197fbb3519a0499e298_Z},
+          {0x12c8679fe1aef0913290d120085aed4da0a9a7dfc7d98e78c2ef01ed9b2a8e0_Z,
+           0x38e54d038cfa5cf480d69caf84823a8e5f440ad04c276a29543f18fc2284918_Z},
+          {0x13dcaf8d1d3473ab2a5b66e98a69a7648adeb50fd55de898adf3b63e673cb3_Z,
+           0x4550b4af97821ee2a5736dac408b49735ef9a983798a460e2fc25bd73f12a75_Z},
+          {0x4e8b700ea960b81c264226fdff2067b1a6d3d0dafec18f4e61db56459bad40a_Z,
+           0x642b4d25e8eab539ccafc71c685de9f769c4d2d8696b28ffaa3c4d05faa530f_Z},
+          {0x205699b1c8ce7d29f7f65e821b03629aa31e02170d03bd700ebc8e56f02e879_Z,
+           0x5c8a942796ceb27cb13ccd2f25524b6942d0ac49bf881284e0461b91367e664_Z},
+          {0x2b89d0330449e735fd402d441796c70543793a112818ce0d4c811fedf044ad1_Z,
+           0x6b1d279bb22ba8e19a0d8f453e4e27ff19d5ee5d3c4662b35e9d8df0624cb22_Z},
+          {0x22a0d2c8a2d994324287433af77894c6a4aad150dce5641a194b6bf747eab01_Z,
+           0x14e3ffbb4560466a7e6ce69ff3920bcb6ecd41ef71e0c429b0c1d534b85f7bf_Z},
+          {0x218af58a5eab24e51876f288b7a8eb31669339af0af26c11723aa8689728df_Z,
+           0x550dd4c1e5c207eb53c545e7f44f39215eeb422fff565e2ea10d73349333a74_Z},
+          {0x690c6eea2e0b8fc38c21c3b124ab94a52831bddc8960d9c0261f28c4ddbee99_Z,
+           0x28bb0b5b9de86dc8d4ebef7a47af0bd3b8812c0a930687d6e416858a11bc6d6_Z},
+          {0x4644e2f3361876e6ed3ef41b51c81b4f1f2deebcd8de09e27cd790476980f17_Z,
+           0x1e396662f2fd58c2e7f318cf3a7a4876734b5ff482df84d05007bf96d3fdd3_Z},
+          {0x2adca4e762c7abc025cd2357383915677649764891e64bec49d0430d10ec3cb_Z,
+           0xe172edbdf1613cfa320c3038cb39dbb5d2a7b40a38b683c40b5bf4c1bd4051_Z},
+          {0x2ae5ec6b394e2f023595f4dc0f683857f9e9f182d4728481f91225f2e568100_Z,
+           0x1f3f7828e27b25d71157d4584bc3d05c13213ca6f7d3cb52b007dc899215e27_Z},
+          {0x9715519f4d9f5bc79485dc92f62ac47c75b0cf0ab8ab17699f02769fff0b76_Z,
+           0x6310eea2c61a3d20150098131f2d3a1a05764d11091caf226757a54f5c443d0_Z},
+          {0x7abe13e018a0d5be2e74b1b222458416e6e647299be3a40cba9faf989183546_Z,
+           0x5deada7defe77635dc940f02490dde55a5ee4a4f19245f7efc8229d58b23696_Z},
+          {0x7d1a26bc6666500dcf8584959afbf92590855d78f280368b23500c739d71677_Z,
+           0x109742f7ebc46dfd0518316589e9a83ea714b1d88a99cafa036e8693bd633af_Z},
+          {0x22d6a0611db5b8980d9c8aef66fa1f2397f2964b6212c03f345f5582376b836_Z,
+           0x62af598c1de730393503f75fb87e760ebcb36469d213db862036fe4bb5f4de5_Z},
+          {0x78638c51b590d5978f8a0771aff1bcb291e4c9e61baa69c69b916b3eeeda5cf_Z,
+           0x7ff81b9f75926a38b1a7ef12ebb34b86cb2d67d0a1a6f2aa01ca86387a5966_Z},
+          {0x3f7e43d034d544eba6cbbb64e2a10005fd78ecddc028a2e1af80216125d7953_Z,
+           0x2fc4847abb15e0177af2e9f81eb2ad392076752808db891008850e4a52e9c37_Z},
+          {0x7e3280ab174481222150f6aee632713060042cb4231e53fd4128826b1b778f9_Z,
+           0x502002e7fddff85e05e5c791870493cd47a718fbe6a3fdc451f72557dec3f27_Z},
+          {0x7cc4202b6e4606b49fe165dd8596933b0d6fac15915d3a88df3ab5751ce333e_Z,
+           0x72cb41fcb7022234c0c45ed291816d9f2005387404d333f0a0627448cdd59bc_Z},
+          {0x47bda44a5e33c31b73f816dd860c6097b8f4c68b09f161a227a30e0625861ea_Z,
+           0x6195fe7a34738e4c771e8aee6b21846204bdbcd043686554e2d9bf2ad7abcdc_Z},
+          {0x32f186deb230ffac000b2d025de7d3ec876e3148ac806ebbb9935934ec6f81c_Z,
+           0x3c636044487012dc195ae8bacfe987e1640099e2cbddf9e07f5f0d613a7571e_Z},
+          {0x1e756fa128be69cf8a5ad404fe3c8d194907157d5f3fa097c83c43ef72c95e0_Z,
+           0x75d6c9ec9c79ef2fff8ac6de42d4100a1d0b0601136ac9b367a1205c41d8698_Z},
+          {0x115b03bf41d9f5e106fdb98d94cc420e7b2251418a2150d94c2584bff93cfec_Z,
+           0x3bd42bd645d1bf03018cb9144fd6623137897536d95d73ca9129ed9e7dd87c7_Z},
+          {0x34f0578072bc7cfcfed2f8e201144ebbbff86718c9d22f5a4334c7ac002ef85_Z,
+           0x2c1c4b5d8189ff2fc6feb4a94a82841c8278f9374343da34e6bf0c74e1762d2_Z},
+          {0x461c569e453285487ea3588d59c8480ba0086d6af3d1eff56a27943b4010dba_Z,
+           0x4ceda82983567153544f2095b010f64fc1ac641afb1420cb44465d59a05560a_Z},
+          {0x4d1bb0fdb75923e420d527a9a3737c65c10d488de81382f19b11aff90bd802e_Z,
+           0x56ad20760a145eab94442c7400f033cd429d9d1af68187b6eb1b4491399eb2c_Z},
+          {0x50061acb65190ac9f20cf550c030978e30cca4d5c2d15932a61faa6fa509b6e_Z,
+           0x4249b6b48c871a77d0239eee6c17178d0a69c5e42e6550c4bc4f4a20e7fc5e6_Z},
+          {0x5353c52c6ff6ecb5b11a7819244b474ee7228d3656b4408441496c1993dedc1_Z,
+           0xbd2428698558a277a503c5692394c7fe71a6cd2aca0acddd33f5d87b7ac502_Z},
+          {0x54a7538c3d59d708cd58a3839d7c8df709bc4cf6d976e04e3fcc039cfeb31eb_Z,
+           0x325d079b25b64823f0a2879663988e51cf390b2f0bb1ed844f543a59e58a9ec_Z},
+          {0x22e5dd548e9122e72265570aae734e842ddca313b5a5bc7f8da4b64bd029d91_Z,
+           0x352e9b98bed95bb719cf77a57e161995a7765ae41f9883ca5deafba652d0865_Z},
+          {0x4b261a11ed1754c844bd355425017be06a2fb702b9998858fa81679e758da00_Z,
+           0x1791915ff61cb6e051e39095b2d2d2df5a6de6ea2fa93910274cb34ed0fa9c7_Z},
+          {0x6ec6fd1c5772bf015f6db5c6c1167cf6132c35ca7ca8df68f9538134b5ab86_Z,
+           0x79c6edb2e8ff788110d8b3012f5ab4663ebb72ce79c621629d4e05cf8be9a3d_Z},
+          {0x6bda9f06cc36cc49c4a922da29f2c6e8330682542422a463d403c774a99b8d6_Z,
+           0x32fe43ca15fe53fa3f1fcac2dfcd5b82a5516f21c19ec1c686fcf12df99c897_Z},
+          {0x5acf9cec77a323e4aed7e6babc95eb73efd608e06c7a68b71db2bd1b71acd52_Z,
+           0x2a55469622cb952c3184e08e95fee471d8d4dd7a2234f89f77de73f9a5f6f31_Z},
+          {0x675252f7625aad5a604622e19e6f78bfe282595cc4784b7e870e298e851afd7_Z,
+           0x7e39366c6ee08fb09420b32326837943e90c19710b190e77bb55e148f487c4d_Z},
+          {0x18d498f8062ddf66f61798007c5fefc456af2a92eccbaad4f5742f5c7bf9171_Z,
+           0x5142a6c4a107e043ba3d2d77a14533d427449773e978b3564753f4a782003c8_Z},
+          {0x143c4ed078ca1283342616bca92feaa5f318751d53cd6779dbc1ff9c225620b_Z,
+           0x487a1a075d2c7b8db2abf6f2800b45dc611075b04cb727e264e540e60b0b8d3_Z},
+          {0x7458a5863bd1ae4d3afba58528a7aa5d21d1584bb210bab466ebd7073e3a50c_Z,
+           0x297c1c02aa6f8621c8f9cf51470d743e0f94c1c57f57a513ad4acf8f059a89d_Z},
+          {0x79a0299f479ed1db5b4e6f5323ce86c95aa0eb02b01d61841390f502d1fc7f1_Z,
+           0x316b33e86fcb5bade46d50256ff2aecdea3706d47ad446a392a1a24c6bd5e6f_Z},
+          {0x4c8f3d7ae0535a3c1c5f8648705b0808de4a5b5189779bada9af3047ae38cf2_Z,
+           0xb2e1aafaf978fe082cdc8c577a0823d7fc21cd55611835d5a463d47b40809_Z},
+          {0x11bdf0480f6fa704690f4f746c72d55b59401840142f39a173163cfe3274ec7_Z,
+           0x17f675a570f644a665f47cdcfc639830bd29818a6c1c15eca156ad896b3ab0d_Z},
+          {0x2634ebbcd752b223e625e790776730ca3010d49b0aaacdc08bcf481ca2d8502_Z,
+           0x1d811c7554197eb1d67ed471ebe37445a9befcc8393bc6a96f91960dffec11f_Z},
+          {0x2e623c7397d52c754a883ba540c75161c518ec299fbf430fc919e4b63867c2e_Z,
+           0x5aa31e0574e2e083e7a02e779b834f115da926ea737ed5e33b0da7b6907f88_Z},
+          {0x1b59ad6781fcc6b8288071b4710c3eababc630114027967a5c8ab65a83e04f9_Z,
+           0x6ac976d1bc47507e5a1e5d09d27cb6fdb9d02ee724f623e4ed4869605a05bad_Z},
+          {0x1b09750a3898808f9c9e8b1e89d8b54249076976cccad25d4f567b4bcc1658b_Z,
+           0x6583e9acbc47da277e255860a25d3b014bcde160cb67ac1dc097dc785888025_Z},
+          {0x7b28e913a5287a629620edb25fa48688fc6f04fd4a2b1639968a27b61f0895c_Z,
+           0x6e9e1e2a4c436614edefefc208b5d5c83d47525fac35f4676ef561ccac03ea8_Z},
+          {0x7c8cc11b3836e0e77006b15a10d58baaa128b2849669b1e97cab277f5f974a2_Z,
+           0x2367dc364d06203dd257e35ef8503297ed976964ed35f5a0f17446181d979b0_Z},
+          {0x5d4b98d271e027e8580da0a9a2cf007a0481a73b2e59f79642a0b5c4f88bf26_Z,
+           0x594d75e358662af89b3d0bb908e8bc7b0f34a356ca449a762a1414b1449ee72_Z},
+          {0x4257b6082b933da2f30728a8713ba0d14503eb3fbf3b0364cdfa3a41a3d04fc_Z,
+           0x2f611777737d62bb56d77f090091ea03a6f9a6a92f9d78ca4e7c49b8e082255_Z},
+          {0x4ce5b109167f77ac01e4ac63c96fa2b22bf6c041993da3ce1b485a5e4eceb64_Z,
+           0x7c5003d57f3e1a744c9d866297d2ba0b9ac6901114b750a1bdff662a5f30078_Z},
+          {0x15124e3e13a9e69d52ca0a4141190e74d9671e8fe770e1183c03822b44193a7_Z,
+           0x7f74309a374574e6f4f732e81b06c714acf36b1d5dde258734a5267f658d3a5_Z},
+          {0x42333f4b49089cfee86175af162e66919755819b63dbfc7875639fc5ffef56f_Z,
+           0x20639625bb0d6cdc4fd6f386e949b3291fc6c4061c566bad953e96fabf606a6_Z},
+          {0x694efd01f4ebc915688c2398849b58e2dacf85b57e941eb24b9fa0c799bb561_Z,
+           0x6d2fcca13be65c1db7058e01d8be935834428aa365144b36e4e1d4bee169f97_Z},
+          {0x51beffd36160081e4a06fe0ceab50275a776fc3ec3e243eb356127cdd71e9b4_Z,
+           0x54f4df7f430434ad0f6022ee4960f57651fa3a14aaaa2a845886c3b9e3f6473_Z},
+          {0x34302a16edb497ddc1a13127f4afecf1b67e2efc3a30aa2571e0e367551943c_Z,
+           0x5d62cd1e97d96332c1ef1ae66200079e2c34dc0bf2322a8f8db0c9bc1bd9650_Z},
+          {0x3b7d7c38eb68b50855b50548b0961bb991668dd27434a98680b2e81e4761a97_Z,
+           0x3e4a6944456a6dca9e7cfdde738857d0e8db99526af3de35dcb6e02679349d4_Z},
+          {0x6b15707f3694fe19521d42eccbbc57e988d79d1ac65604c241c1fc0bd20b11e_Z,
+           0x1b5c76f58eb298bf99a6f916f658210b8034dd9a69143545ea2462b90c3a26b_Z},
+          {0x21de44099f98028bdf0363c4d6439902103f8f842a972b3cfa672312c074d2b_Z,
+           0x450b08f46348ab45f577aca3e90c5eed4b7190cda97d60b642a487b517af033_Z},
+          {0x6e8a30f52f23f6ed7eb4ea3d8b7752449d6df7e84486a89a286543b153b89f0_Z,
+           0x7704713a39e8601f7dfa4be91b9c63a47b148153e8eeb2bf54b8c70b67f1a33_Z},
+          {0x353dd999e948cfa9acb61f4582ebeab16e775606b5be03d29c3d2a13b4d5e29_Z,
+           0x1563a3e89c6b27baad5f2bd1e3bb864c1b59fcaa3cbf6700c3841383084ecc3_Z},
+          {0x35fdf8ab25827a3472f22e6fdef7b8e8d01d6d6c8ca2ec2e5d090981af65daf_Z,
+           0x6a42399f7f05b4bf5adf84e8331e28215173f372226ca710cd18b2b40f79454_Z},
+          {0x67f02aceb9907cee72e549d558c27d30a3776f7e46b6749b3c159d8b97be20b_Z,
+           0x8be1ac6d79d57cdaad49aa159fd36b80cba8572d1f5f765cb50985398155d0_Z},
+          {0x5554c980c9e9c1009f66adf554ec73b52eb25ae087afa6adf450514b84eacce_Z,
+           0x4c4275a2932733d1345d64981b4daab26e65190b613a27cf4e6034cf06c0fb3_Z},
+          {0x1eb0f99a2cb77c9fa69860b68a357b48577126d6c8da4a8f9bf84401cb6f81f_Z,
+           0x578a0d5f5fdbef207cbec740c5ab162fce0e07261e48a9ca6bf7613f8752604_Z},
+          {0x13d7bdd9e39aba8d49f8fc374b2abede20a1bc29fb671a054f45387f1a0e02d_Z,
+           0x70c6ad4bdc7277c1d72d9f11fec7df8658762f67fdfe1afcbd8f6e72900ec5a_Z},
+          {0x1d980c2825bd694db28240e0069db1e0f9dfd1c151a5668d9b5f42d4351903b_Z,
+           0x399db4c47fff7fc6c84708689e72c4edc2dfb29489397652e02f22a2c6babe7_Z},
+          {0x73230486ed47e678e76dec21f0ffe22dbcff88071d90f8914febe3cbf1ae6ae_Z,
+           0x6c3c1abd07106c220985829a2468088ab1bb4fb0a07b6ffe759f471eda00b8c_Z},
+          {0x5e3652d6926d6643ed7810aefc1b40e136d2c8f50d580bb806b1ac106370f97_Z,
+           0x7b508bed86cfc1ca3fab9f62b43ab426ae8d421fe2416bef38bdd992f06d946_Z},
+          {0x78b5dcc2feb7a50bf0615c6bc52183d462dd4fbf8f8fc8612894d7505ccf7ac_Z,
+           0x3281ce9088b825e7a58981bf3b12b6dd6067b298008601fe792f1075a47ab01_Z},
+          {0x4705cad2e383b00582da583006b49332f233c581aa9ce35648810e68eaa9c3b_Z,
+           0x129085212c231925bafb53736050b6cb6476bf6863c6ac3dec94566ffce3a4a_Z},
+          {0x5089efe433fe56d92c8efd50437eac928f1509ed6915db457b18188d439eaf3_Z,
+           0x610fb11cfed4a7a6f916853941be547cd2c14542dd6da253c7df5a39f6b4969_Z},
+          {0x71c33ba83d090327fcb4469784591ffe7d41909719b13aea7322369d9f4d5e2_Z,
+           0x58b3f6d6e61a8ccc48fc0ec43194be0925067b8414ef581c85005c678214bf1_Z},
+          {0x28fe0bb11238665014b97bf4c21e2df94bcd67bd5dbf07cbda6e13e1b1b3913_Z,
+           0x2b4afa2fedd517b2bd5b30779be6d5d1f0382ee535ae0b73b14130d13291746_Z},
+          {0x6913e3ac7ff9eb0f10c39dc746831fe4baaddf6c57b406e0de32f206c802680_Z,
+           0x600ca47989675ce328c6bf27813cb426273fc87708258447a721a3b953b9f9_Z},
+          {0x42cdfada4a07ff2256e2b7aa5798a6f711c564816d1befa774f8c0f8e8b3f87_Z,
+           0x6cb39531e0c7ca77ffad3f7d9c10c26788798baaf1052788008d7465594f1be_Z},
+          {0x4a895cb3ae6df92f81b9537c5ca87fa2fe3eccdce51eca7305d39cf94cc0fd_Z,
+           0x3076e7f66ef42a3a46488b80cba0c9b5b755ffb3acf50e99a53afe43ddc38df_Z},
+          {0x6ba92487422b7717e63c8d9ffced6b1057f0dd4b5d8581f8289fb79bff8f616_Z,
+           0x3c31c300acaac2f095416426e587c08fb4e9011df852471a2b4b54c139211f0_Z},
+          {0x3873c32471651ef319bef80fc7f885fda79d45341fb88ccf5ba26f542a57535_Z,
+           0x55dd960b1f5da0979beb7cfdc3581ff166c52c9dcbbe2ba34b98ade54336e45_Z},
+          {0x645f2e1629ef655ad9c6a502f8a13f01d0bbb8167238c3f2ad1814d34a0e863_Z,
+           0x7551e3bfef3c322cf0929a92ced1e8e5b13f10d36c656ab5c7480015ee2853c_Z},
+          {0x437684dae956f29f97ab427a0cf480800c5dabb074a068e96eae7e9513b7eca_Z,
+           0x75b8ff513a7fa3316575c1439f690f06542efac5d8fcccb67cc4b389db29c22_Z},
+          {0xb4294b9cfb659b568f79ef5f9384e837e476e406cab18ed7ad43773e616d6c_Z,
+           0x343970fccbb7f2678b79a96f9720891cafe6ed4cee2f718add8508bac06df55_Z},
+          {0x41558a0e4603aad44213cbcaeedc9da7710f5a069b563f8e4cb493ef701fb36_Z,
+           0x75c61fb3a1b07dd94fe1d876e9ee10f7b03cd662e3c89331042a855627d83c_Z},
+          {0x6ac9b8bda77b16bc042e45efcdeadbe70eb4ff0f12c06b02f76374661cb4c11_Z,
+           0x161d60e17f0e5e70a35437110d5c2f79cfa9722c04e4e92b14f70fdd1c18f6e_Z},
+          {0x43aa2b70ef660de80ad8f4c0d5d965633d8686c030b06b6bb6312c35e2ce2b_Z,
+           0x517f38a92e44f43eff094ba0b6f1df836ed5b30f23bf4975c2a0f8e19c6d04a_Z},
+          {0x5f82db6d6eab266e07179a877351dfbf7ab180ffb42767a9f0cf59aa4bcde05_Z,
+           0x67059d8ad9d65a4658381aa549495b1f7f24d167f8b3649656962ba3c56aded_Z},
+          {0x40ede50e36ba94099023deb88750cec92bf290a15e9db556f6ccee6d120e51f_Z,
+           0x74d312ac4c763ddeab678a5d2b7ab12ac3d0578af6366ce1c5297a881accc3e_Z},
+          {0x38f42922f6d1bf2c88834b6ebe4d87089934f4e0fabf22151ce144b72b5caf0_Z,
+           0x453d82bf1347c15912f3664c1c3ae8e9266ff67234de5d8e60bab927625dcb_Z},
+          {0xfd73c943851baea1781dc61f68b2d004e01d3bfc89af50cb5126095ba9f17e_Z,
+           0xfcebfaad59794aaea0e40e0a6d44f0a088374b09ffb4f73c0944bf1d1a0e23_Z},
+          {0x60317a7a06a2df8b3f58136510030a8808fdb9cd0c601e3c068af7a53712452_Z,
+           0x753e564871eca54c6101c9584a40851c18ae7058e239139cc069fb95bd34574_Z},
+          {0x29a3aae0e6484543a413f9013b571659adff12260e6bf0e778d41f0fd754106_Z,
+           0x885b9a319dc7b61d64a25bafaa231fa2908e2095dbdcc9bb80aa67cf731b04_Z},
+          {0x8bc9095b18a8f7b6154f2adb31b5b0adc38cf2168cd71d04fdd541d27ea768_Z,
+           0xdc8b1e50d421768d0be16796fb98a43e0c8cd3aad2131640cd7f6f67131c02_Z},
+          {0x2df1cd3162ad44b18fd0055f8e6f19de30856b911b1ee607550dc8a1343c60c_Z,
+           0x2417c31469a6572273f77cbbb41602e5028ff783fa12b5da8848ec5e638f21c_Z},
+          {0x5c643bc7516efe14436d1c8a5a25ff9f24641dc30b6a79469b922ed076fe9b0_Z,
+           0x60b3846dc2d887485b47250903c5bfd5958cfcad24c57b1ff057bc18ebe092d_Z},
+          {0x1f0b53787768fd5876ed1ad1b94b6ecce73ad7c75c5586bc7ba1e7463bc0f09_Z,
+           0x7850e21d3d691e46a7c1b7e2b5e4af4839cc6bd741ebf5f31311ce577a7384f_Z},
+          {0x7881e33114033f21627646bbd2d69a0d10a54f077e8ccf8ad4c95590b8624e4_Z,
+           0x6926c34d1fc46982a04d30d466d1cf38de7a2bc1783829bacd42cf27b88a062_Z},
+          {0x7e30b347b103a146d5eb9362f33bbec85c820a7a3344c31cc30d422248b8425_Z,
+           0x68e087a29ffcd414e29ce5f49b1b511edddcc1093546d2fd6ee1575dc88d941_Z},
+          {0x3c1e0130dbb356d5ea89ee6556e151cf22423c1157fbb19e2e055842754b7fe_Z,
+           0x6e5ee957943e6eaa0de7dcf400cdd7b131d147e560d1abdfb68acac457787fe_Z},
+          {0x4e9db25d49e5a62a5051bc80532fd6d2ca012aef53b21a733870cf854b78337_Z,
+           0x45d6d3a33c0afc00f1c7f05ad61c36192f2a2306b44ee8d92e813e99303f87e_Z},
+          {0x5c576f52989f45ab512736502917d4468535542437aca24245e786540034dc4_Z,
+           0xfeabcc9818622afb386fb06445a38e20ac703ae6c096cfaae8080850d2c24d_Z},
+          {0x66a7fe3667d0490bd3ab9a673d13f26f8ba7fcefa2efceb23ed6ec880df9e3a_Z,
+           0x1ba405adc3dbc009b17c991388ece076bca24c4fccf5c15d263ca929297a17b_Z},
+          {0x49e337be850275fa6ebcb7ce8c265e1569824521dae3bb2b51233ec625337fe_Z,
+           0x673d5bdbee6e8cc16a839993143d86d78ab9a93b6b1df3dff15272d58433e83_Z},
+          {0x1baa881896903dccfa0490e4b1721d265e6d135089a321f79c3643d89256745_Z,
+           0x2e2309caa98f46a21834f3777fae48dcdbfbc7b65c162704f824c4805396d6_Z},
+          {0x515eba84e1130dbe07c7f6e8ec6464efcc26981bf740f7c4507c6cddb261ddb_Z,
+           0x154955581b77b779b7b0fcbdcb5da3b33a8d23ca28c81051c9d7e7da9dda716_Z},
+          {0xf9f5456acc172c31c7333ef38617ad2279a4748968966948f1815ec258a509_Z,
+           0x5b54c6c39f90c4941bef128cd8aa62a831b0c4828de5f7c97d0968c16668ced_Z},
+          {0x1d77d149acb3f96e20e6613917d13b71f5cfd75761047b3ffac67241a20e66a_Z,
+           0x7e573d7b6b93fc261e86df335a8711c5ad678cf3a38485b833d9641457a3cdf_Z},
+          {0x2a0f076fa7bb20c9c201d3ef4632b05065a2cb2c0f77a1dc2c793f433c377a2_Z,
+           0x79d83b5b8839396eb9a8861d38f37de06443e3a4464f4c3018b3679bdb2a56b_Z},
+          {0x369185209d9ca7406512f7edc18de2edb3940553369b8fcbc63fe7cd1b89f56_Z,
+           0x469ab82138d8ab813ef58b834f651d4d941d586d3da412ae9bf23f153b18f51_Z},
+          {0x4e8f2d5232ee9352e49586f3850488661f3341f40044888661286ceee512429_Z,
+           0x619a5fb3c7d289bbf7ccf6142d3726458eddf6208f14d83ba02f59acb451def_Z},
+          {0x2747f91c91ab961494d5f5255c6f72103d785be6e272d73fe0a625229b3568e_Z,
+           0x2ac74dd2884255b1e3dac3451da96c6b83e284395735a6c642120a5c617b02f_Z},
+          {0x34fee10767e32de09259054204496bb26d0f341853778b17295622ef674971e_Z,
+           0x1a3587acf23d34eef9dbe6a6f90001080390c20e923d34f052ad9e680d27347_Z},
+          {0x4ad8e1a9e2e5b3e3227eca0d75a74043b7f74d232a792cd8f2357c55c930a2_Z,
+           0x1ed316966e5e3161367c6e140110fb27fc64061a91abb294b745f12bc540801_Z},
+          {0x57e7cfaf0f333c11a0ff343a0cdda6329608ca5d3c8eb9b34f96beb3747249c_Z,
+           0x560cc3daeb81fefe7d050522dd12e72300e12991669ed760c59f2f033f5b295_Z},
+          {0x51718e417055b3909d776460afa5af6ca100f4b5f27129f9a9cc6c584cb88c_Z,
+           0x42ce6a1d312ef5ef72370b6e472561f662d3fe28c07272682401dc14b506ae8_Z},
+          {0x2a3101e3602f98739bfda54eb5de107009925f9e736919ad817bd777ef23e89_Z,
+           0x4a4fdbecb1ed19b46976519dec4bb0ef6f89ce26bdb6297db1949294c8b48e4_Z},
+          {0x179cf026a61750887ec5c12fb9373f53cf50b783e548c0d30ce8f4f8aeb2657_Z,
+           0x324f92a1490079175ee248b860306b8abda8d67df16e6b8154171f0b7d2c7e1_Z},
+          {0x717d6a597afae02078d0fbb1f5a7d890912f7ae57b3d97daf7955dd4e2ec9e1_Z,
+           0x412011fc5eda080ebeb84a90c0b7d80a08d368c60cabed804af3a055c4f9b37_Z},
+          {0x4c16c7b9bd26ad227b063bf78565cceb6edb5057a30d281ed359a32610724e2_Z,
+           0x4a134c96a461137149e69f9d8834bdbddd9efed68ba1784776c7021e264586c_Z},
+          {0xe96fc44c79139addc82377706e7af3c82baf7a8a043907b98c43439e5d2e30_Z,
+           0x1032b759c03b49fad3799975ef781336b68da2f3f9a86b10c75d35f4c5b9516_Z},
+          {0x40ab909efdd9c9d929ec933ebb9de36bc388b5dc701a2aeca6e9e302151393a_Z,
+           0x510cb53bc0aaf2d49db23ae16a0c7653dedeec23dd196a61c00bdf18f32b8fd_Z},
+          {0x1f14f576df12a693e41f1e0df40316e80f267aaf9b73a6fc8b86a625b2c6b4e_Z,
+           0x5d77054a0d97fca6371c3741de544f7a1b03813b63cedac50b5123a4dfa7c55_Z},
+          {0x2dc023f3b323cbcd0702a1aac431498d65e689e27ca4dba0a12a31ccf49f2d0_Z,
+           0x5e4d739e15b2c7d031eec12fb308b588da6616cd99c1f7dec783b456684a0d3_Z},
+          {0x3080fc9f579d884e3d31482a7b38d3986012ca383d2eb1bc6ec237f4037a680_Z,
+           0x6976f6f475ee34406b50e0625deea7e57c1c94ac4abbd62c9e52028be3a1ee2_Z},
+          {0x500c83f78817833f09c4aa54e7559f1782b73343888980600ba92280f1400d_Z,
+           0x869e9196270ce2f9d7ad3fc51f4ff81245ab66bcfb1f6123a7ed78d8b01da8_Z},
+          {0x70ea074c1b2bbc9c17433068ab041eb9fc888e1e257b0e70e7fc1ef5c4f8992_Z,
+           0x27b443b728d2ff2765d414a4b63fb23e273b219da7cb37967804d9317e560a6_Z},
+          {0x5481f2e4803b7f2db575a267fe20694ba058b93cd6a7beb5cd33284a72dafbd_Z,
+           0x247b51d83ae103241196cd7fd9d0312ec3b49a9a6e32fbfdd03a066b6012dd2_Z},
+          {0x6a743026c6075fdacf0da6d8d717c577816fa9a78768c74e4ae0a289b394df1_Z,
+           0x67ea02eb85f1ceb26d8b90ec1efdc54c1fcb5724d9917280b282beeb6f1692b_Z},
+          {0x9e71414e336b991f37d0cd405fc4acc56e6f8f1e28dce6da5f3f114543e3cd_Z,
+           0x455ce2dba80c456118ddfc021b8548d12942689a4c49e0620d1ae57163f05db_Z},
+          {0x2ac6f01421c7dc90742bcf279bf4bf3413cfe57136ae224c3cae77c39499494_Z,
+           0x4a53485a82876c8cf990a685a856e55d7b44eb0c9e663c9c7ba6a58b3f0d164_Z},
+          {0x50fe102ffb5664c5da29704a2b23286150182da218db47eefec241fd6b5359a_Z,
+           0x82d2461ea8e50308c307be5050614f4ceede8cd5be93b9df8a12c25b46a919_Z},
+          {0x2516219050db1e69f5ea2c656ab38b07cb19df02b7313ecdd056ec4c9f4440_Z,
+           0x19ec20c066f9341555b616f4f3b2462afd57d5b78ebb4864d341545b819907d_Z},
+          {0xcefeb541bf9a77bc74fca9200354e3e8afe3df7fa483984dd539a7f9d6b040_Z,
+           0xada3483ddcb274d5c12c5d629dff3c8d61a00be2a478fd6d3e09eae061ffc5_Z},
+          {0x4937a380a265c8863f7efebc41a0b3d512861969efb537cf376bb92b383f0e1_Z,
+           0x12395864741ab7d8cddc37b3e9b28959110f8a90fe6f5088091528c28f0d722_Z},
+          {0x680f583ed47615783be15c08760edcaa27f1b5b605eaef85a48661f98cba480_Z,
+           0xb3aa31c239a881b465eef30a48c69d57a119b73c67a6921b4cc3d1a90da941_Z},
+          {0x25a56f1c0062bf748c6472fa11ccded695f2b7542562331ad36c4bc1cc755dd_Z,
+           0x6e73d0bb790978887d8381596a5f7c614094ed5aa8c4f196d44f32e12f2d9be_Z},
+          {0x3a1633fc2ca3a54f96ed0494e9859c4b0b81707b813fe02d6f80a6a2b2ed6af_Z,
+           0xaa91ff4b0d449b7b5d73741bc8da8611def01cb042d6147274a6a921b66ece_Z},
+          {0x13e2d5b2e6137be89d60b6220bb1f1b4a1f1b2f2768bbdf6ef2137f6b739cf8_Z,
+           0x3c87c29f436d2cc941e7f260be058c8fa0ce134446082a80076609d95bc8514_Z},
+          {0x27e3dddf634a4efd734b78e74395714143a3fc10c6390b3b89e4df15a573046_Z,
+           0xab8ef979cd7ca06d6fa4e670f855109dd70b628150736c627637fcf1689447_Z},
+          {0x49928876747da068fc49ec448101c0f65a3346c42555e81d4e11d1d057cd37b_Z,
+           0x37eb03ac2b0d0391da35aa19727b6f7ea045ff216a97b7a4bdb84998e0e4135_Z},
+          {0xa4e729b05c165101187cc49bf3a27ce854833c11eb8545a3f2f7f71df646c_Z,
+           0x442608af3c2dd023ef2773e79ee339be89e36a8ae94c60a9f587ce37bfabfb0_Z},
+          {0x5c20a597f2b94ee836aa31de6e21bc642747ee17b6c8c77615f9c29fce19f53_Z,
+           0x64a6fe6580cf7373d1f39a5846fc4f92beb636689f86533bee2a62caabfc465_Z},
+          {0x792c81a8c4eeac3fe4630bf2daa6a5e3045130fb1e392ec52489296adb614fa_Z,
+           0x3efd54cac4852e39d5a970407ecdaea6d6e5b5bcc21ddcc6a5349733d496cd6_Z},
+          {0x59748898c332e2208424b81e916f15c18136cc027cbec672197fcaf299debe3_Z,
+           0xd8c78205bc6868bd2822ccfc80a8f24301baa635bb34fcf1b4b635ad63639c_Z},
+          {0x7a9963851201f75a329351770c2ba63891d075538336574fdaf3ccb4fc7fcef_Z,
+           0x65c9b2560a9cfcc16784a2e12dd1fac05333122f660defadef3531796942aef_Z},
+          {0x56705874ce12c9cc1d57afc81f15015f3874322f60444f9de57a65fe12458da_Z,
+           0x1a82d5cf99ef79261a4e3d906073eb4e38a071ed5c67e8cbb64849e7eab5d95_Z},
+          {0x62f84bf5674eb90a848f0ef991f7ac988a3bbc2e217e7b3d6aefd9fd6a9c8f0_Z,
+           0xde054544753eeee1aaa29b381b24cac20b5856853d23d35df2091ce1dc610e_Z},
+          {0x75be937986547604c1030e45a493fb975e16d43b467586ad72a3fcafb306246_Z,
+           0x207163c590b95f3b0a6563bc6c07fc658c1b95319e70647a1bf5d3ec5410494_Z},
+          {0x735dbe43a0c9df1c0b85aceca32ed9a1bf6e3c56ea1731cd8e04b54b6777dbd_Z,
+           0x4d42712ca8cf1a95ada153bb272830bca5048268f95b3c959a25f5a28ac5783_Z},
+          {0x6363de5a4832ee73691d847c0eaf3204f0110a8f67870406f74480468ea6d78_Z,
+           0x3f6485799da1943c68602caca14c5bf4caff3958eb1c0e2342ab28e89aa85fc_Z},
+          {0x277c51c5f931f01c0893e8d3ae5bb8b5c91feeeab3dc4fa8bfdec413228ddd7_Z,
+           0x4230784805147c15e6b01297342b741365ceebb9bd3da1c45bb3f68958a9438_Z},
+          {0x331043b70bacaf8b38ecadc7e16a4417280ee97643c18194d401b12544267fd_Z,
+           0x53f15698992fdaefccf2f44d5c5393b62e1f511cc51f62c776c27cf89d34089_Z},
+          {0x60ea9a8a3246c8f369ba91d224517759f54549fb596aecb81dd47e27a6ddd0_Z,
+           0x69ab37615d78e8f00ac111dfe4f72fc02a55c464c72d4fe7e3e26d5b15b2f7a_Z},
+          {0x4ffb11a7884dd3c292109d84afb836c377a98c0a74da6bbdf6b42bb4eab237b_Z,
+           0x75e7280d170d7830b9207af8dcca064691fb7a99c42a8ed6743624d1794a9b8_Z},
+          {0x3f317f7931e8ae4617d1203ba1b6989fc1d938acfaddd1d09c878431e4249d6_Z,
+           0x3b2c6b25367c29a30c40b7e343205337d10bf2e003b8c35e7ed721b2580f492_Z},
+          {0x5a06c81351ed13de5c28ff1254efd779d2bd617d1387d7b22f7940e822942a8_Z,
+           0x58ea74de916e355ef7d1ff94c14a70d921245ced2c9b4e8132375d8146bc2fc_Z},
+          {0x57d2114ec52af5d112ca499de7564baa97ba04563655c46a838ea9ff4c73002_Z,
+           0x20b994314ce1b34309e3034d51ae32a615ffa13948a0e918a329de7c1dee9c8_Z},
+          {0x200f3266af4e0c2a0b3bec3a88ad5c6f138955d20cd6bcb906c337c95eb4415_Z,
+           0x176edb1ece81ecf7ef88ccdfdf8f964e64c961660d868d2c4ada454205dad50_Z},
+          {0x6a85478cf0ec78b3531dafd9b6c7c24644cc95908192ca4d8982f4c08e8aef4_Z,
+           0x4725edc324c9cbb414345b440a078bbb7962c2dab05ec91c766fee37d4857c0_Z},
+          {0x7bf511a54b0e0245f20a5824bb6e020c60ce0c7db030d5b955f5b337620f219_Z,
+           0x60e54196e1bd4d80a5ff5533f81d89d1221c0061f9d92916afea61367531710_Z},
+          {0x377e8b5155730171b79339476f3e23291613a6d4cd24449da37f2dcb18f0061_Z,
+           0x6c57475bdd1188891a56d0977185be9974bbb7435ee0c1f514f78080536810f_Z},
+          {0x1e6cd71a6ac437a2e610484f03c252581fbcc0382976c4f8d0b1945f9706ba7_Z,
+           0x62b49dbb88390c644bea307d87fbfa08ad6d0a81bab414a1de1c47e9d2febb3_Z},
+          {0x1f560c6ab420a1a0d2be2d1ea0de11964f6cdd97020a88143ec1e7bdb4c60d4_Z,
+           0x5659e102331b3004a5f79011419261b96a0013e0ca88dad953e41a49152b73c_Z},
+          {0x3fc7f5d294fa7ec108ba4b88eab8dd875307f2e59a1915c64e5319467494bb8_Z,
+           0x3c80befeea7821ac786292f123c616dc8fc321d61751911babaf404a13804b4_Z},
+          {0x444fc960b6685225ad8fe92d306aa50622fd3be5414281f16ef62ce799c7237_Z,
+           0xa14e5d2cf6089b7c38731e91dc8fb74c3effff69771934b1aecf37817d7921_Z},
+          {0x4037b6fe202f20beaa8166ad91d2645e50858982d9bf5dca5293825532f4d85_Z,
+           0x45fd440141e4db02d44981ccb9812e255c564cd7e8d2b1955e736fe06eb6f13_Z},
+          {0x762168996c3e77b068348dff3a79866a5363a768e58ac534f3f1679343705e5_Z,
+           0x5c10dcfbc98b71d73e6ae7c93c90eb4c3cdbf70c1894bc8bdf135fd4d30ae65_Z},
+          {0x76d7cd37e8ea31d72a62285defcb8363a39ff88a3d6867c3e8b8be5feeb154c_Z,
+           0x20968e6145313a493d459bb568db6d2af09dd1430afc9c4da5f3fcaaaa08223_Z},
+          {0x14e13a565d5bffad665aa6e1d93092a486fac7a707f39dbb3ac245355b0e34_Z,
+           0x11546bc481a890b7d88273e5722a00ec976ccb4c51c0090342cf0db1979f3b7_Z},
+          {0x75f4250929e028054217e764dc22e8eaf9beb8a0027e1f4beaae0a8b4da7caa_Z,
+           0x54e9097a3339ab63665e18e15c7f54763c5076d078c3197d7ace9994b286198_Z},
+          {0x2e00405441af4832b78d0aa70ea5bb0050ccfcfc819a7a2105f4c63a3bdaaa0_Z,
+           0x5a77448f7388085ea6978f40cf5ba8f18d0b0f2be350f4ec0b9d9a1bd6ea500_Z},
+          {0x5a420c4d98617112300faa59c23196531dbe77a5083ee6b413da88b103aedd4_Z,
+           0x2f5e1e16645838a4198bd8bb12f3461734841e6e591c3be6e849a2bbbe6e7ff_Z},
+          {0x4a2d8f8615824de98efcf0b77ea95315eb0bb163a713a19b816a81527dd7edc_Z,
+           0x1c337c2ffa49be75e7110d80dfb37866649aaa4f482a9e5d2af71beffa95ce0_Z},
+          {0x6c00424c9d851c2e2a922fac4ad52961457dadab9835254473a6a37eeb24ece_Z,
+           0x344c85b81b709fe6d701b269004a7b564f673ed8504a605652f30e5a0c9d47a_Z},
+          {0x3bff7590d4154411fb768b2a00fb2b5d17f07338c5fbcff9ad63d4247ba16d_Z,
+           0x16afe52d9340315d5a2fe2d9f0493fee0921bf37bebf11e4e03bdcdd902157c_Z},
+          {0xd0fe726a7c5057c891e955a42df5a33a1b5479a270ea075c8cde350c42b0b2_Z,
+           0x239cd33847e8eef3fba2e9a0eadc7f8c5f6a2c6a24aab87152231e24479da5d_Z},
+          {0x7d7d66bbbba8cb54a0ecfc732a6df87622c82cfdf9a244263fe3d554f8a0b51_Z,
+           0x592167da1bbe7d08362b9ef71bd5fa69f11d27434442db15ab507929103fdd2_Z},
+          {0x7bcee06abaa11251a294b041fc21102b1b60bb2dde33a164e9c246dec7de24c_Z,
+           0x16003366f985bef69b3b7ef9112f0dd7256f1501f8aa3e525d0f72de5885a32_Z},
+          {0x73b43dc6999f3c2c456934226cb7fae52e96c920e193c3c4aebabf38c31149a_Z,
+           0x2ee9846999a7809f6a26ba7c0e3ceece2a02e02e03be122fec782a424223e4f_Z},
+          {0x5080a7e441e7bd443c39cca3977e3ee001c3e81274c6ab02ab5d36cdeb896a6_Z,
+           0x596f2d72a412c6c6d42ae6812d591c89e490dd4e9e7a96315f5c04c3b65c65_Z},
+          {0x48c801de150490894a982f8a86f71af65f7c6b6e6cbbb2aef7df35d13c66de4_Z,
+           0x6d080bffe4f2214156a8acc6301d9df24dab03e692157ee170e7c6171b600d6_Z},
+          {0x2ccd344949a471541296e01e27a2b1edae8d3b8ace42a6e4e2a49ef62ffb9b4_Z,
+           0x30e28f3a7b4c218e473fed409187b45f884b6f35cef64188bfb6c22ffac852a_Z},
+          {0x6f56805519932fdce386a179a3d011ee48049d58731098235d3b950ef3ad786_Z,
+           0x35a1a2360c3fef160d3d2669e5fa01c86714e6a1ce94d9b8042d186b10d48a6_Z},
+          {0x3ac5cc607a7ee069c47eda5d753685455b6663fc35787c26933ccef7d3328bb_Z,
+           0x16def6807a4794bcf88e3426d4c5f17a5d2e171493e64541a0a4825c0257d41_Z},
+          {0x2ba89dfa5b15fe506a0afe4582ba05bfa6c9347e2e95c3089daf79e5518795_Z,
+           0x633c5714af166068799767833a573a498a4bef12137d14cde354eb5701b6de3_Z},
+          {0x421442d61cbceeae384d3cc37d6960d41388e03a620df5f1cb14c4f241b5600_Z,
+           0x2afd64270905442c3bcd12f362f93420ddce90c7e7c8381c2e74beb87a435e9_Z},
+          {0x5ce4e2101e5ab063bb01aebed2cca69a58417a139b569377c963f7dddad5cd0_Z,
+           0x364ff9b91882f05516eb4fee2f447447aa31af32dedb47b0b8c4c21d27cfbb8_Z},
+          {0x147b22050e955f58b85fa97f2d7e256aed0b5cc536f9628f1060acc48292e14_Z,
+           0x60e64217e98a5bf2b85a824796b9e45ec204a1e83a3e057b47238d43bc80e0f_Z},
+          {0x5a017a8af829b0f1d239e5cf1b5a55ca869c1ab4b3cf8a2f78ae4e560b97415_Z,
+           0x79e870c71f05b1dc05ef9f479d3a8c8afcc18574c453a9fc9a05b83894b6661_Z},
+          {0x38df2817db7c7d755fb1ad252c858d41ad39f0baadd850d8f0aa009c85a482d_Z,
+           0x6841085a5f5b3511fab46413461480cd08ec0fce43619994c795c23b2a64bf8_Z},
+          {0x35ab3a2b0db6b2e036da4f2bafec962ee8f967da06d79b11db052d7403ae03c_Z,
+           0x78bedea30ea8a832a3376e7502e319243f5df7e628b50a55bf3f17e7d03b60d_Z},
+          {0x1b357fa2259b7cbcc36dfdaf49340268d53ea4e0114018dd6c5dace59b5e852_Z,
+           0x39a5b269a391cf6b68110df5f9e8f1e0710e36064391bdf429ba82333124cbd_Z},
+          {0x4f7148152567c9af0149719bcd7bf7ff7fedb187e0526e93ed3cef310cd32a7_Z,
+           0x26a1a51aa8e013de698a0f644530dca83d3a4fc2daefd6e6c71c5e6c074ea64_Z},
+          {0x159a8c98e7616b8806a273a8e149e08f297ba52ec397a8e66329e5dfd004d9f_Z,
+           0x2bce0a59ddbeb0576ed6db48e65c72aa760cd04840edc07d9cbaceed05d47f1_Z},
+          {0x62bba8eb18225b139e96da547e0a4ff135a6b180d2ceff985165ad4c8aa3720_Z,
+           0x4c20a20866faf537239f82474cd0ea7dd91aed5e0086355acf285109ad54ae4_Z},
+          {0x186f0fe9cf69c8e320d168f98c49bde2074ca75d178c91e6b6e5216374a0bad_Z,
+           0xdb0224b7764586b26e8d08e000e18aee5f0d4f1c513c57ee6ef927d37c53fe_Z},
+          {0xef766c060dca040a1eb958f8cbf0a183972dc26ef35a1d42ee7745609a5a97_Z,
+           0x56ba478b6f35ef829dacc2e1bfd4f85a279d342c302563a5674856a57b79a11_Z},
+          {0x40027058e2663824cd5d692c1b6caa1208c70393f072a6b0df34ef90ff9cc7d_Z,
+           0x335e52580a582d146938ce928f2ba8e3ba9881bbf4d365289b0c75175f4f0b8_Z},
+          {0x50769d9e1b2e7f2a1e7fc679d3cc0831914fae2e0f00ed54b2850b3cfe737d7_Z,
+           0x1da30aec069c80bf1a33280d265d28664bc9ef996ce4cf7525217c6bb43083b_Z},
+          {0x1cf8fa795bcbe70f8e176dc4e0cbb82f108905a32280cc733a10c35df4c4c51_Z,
+           0x3bccecf7ee6d3faf0e50672ca19100b66bed3e43144b7356bf6fb752b8e400a_Z},
+          {0x3a1cf7b86267a04c9d018f1bd11976218b8a8097b5ee87a2a20297be723c7f9_Z,
+           0x3675846c670987be8b0463f4b745954fe7f38709d7318fd7c9300b350b857c6_Z},
+          {0x347ee661c8048c097a546f7a02ab089ad325960ebe3e4dd85143fdbe7ec6fdc_Z,
+           0x48602f8a71e65d83a2ef74e6b77e57e517a8baa38516ac58eeab5e6f78d411d_Z},
+          {0x701d24b02d5022d842ea70f164c208fc687706f6c2ec8eea58b2241232953ff_Z,
+           0x2a3642e63ae5cfdc5db90219332e058cb7437815fe7e5368cbcef039cc955ce_Z},
+          {0x24c1279e991ed23a43466d27cb77ea8c69117ae41a310e2d938b647213287c9_Z,
+           0x5c38e3df29e5c861334404ee136709bbc08b192f2fa65699afa1f7f91c61d06_Z},
+          {0xa9a3513a16a0ec1962072ad81fff8bc3c09e6272b07c2815725592e9dea7f8_Z,
+           0x7501eec6647e29f9210dca9862df6ac9a5ceeb49006cf500175cfb81334de02_Z},
+          {0xf92f5d803e091914e1633e9ddbe2c2287a6559342e2f1eb1c19ab33fa1a695_Z,
+           0x18d236ef77bc4a7e758c9a2084506f7f9f52284805827f411112f34c1518b09_Z},
+          {0x12a1d4f4461db0fcac6e15129c514627f3a3f2dac2c4a3b82899c5f02becf72_Z,
+           0x2987a7633228ccfb0675890d0f87f65657bb330807597b32958df87d0ce39ce_Z},
+          {0x2016475846c25a7e90a12a1a8df7ed4966f4f4eca3d824cb5e6c781b0530e6_Z,
+           0x601fdb808d4739bd398315b0d71ecac2569e84667242202a30d97b17ca6f489_Z},
+          {0x4254e6cd8e16ea49b9dc718ae9c2ad2de0205c6418e0b6214e55bb1a75f306_Z,
+           0x799b2f89383f0c99ac5ac061daaa400871833617852a3369d72a67c6bff860c_Z},
+          {0x76acede608b8a2dcea601766dd4bd9b7236703c2b144a4940b05e937fd536d5_Z,
+           0x3ed889fb9e591f92f8db79abe60598fadd30bb249bdb2c0c5789c69136cc81f_Z},
+          {0x1d176bef7f2a5d61c3a96d2b7115800054679b832c7f28855e56a594d7cfba6_Z,
+           0x1d0bcd12c94b5059c5a55bdce5c726563e21b2be58168974deaf4af1a7238bf_Z},
+          {0x2042f7df7e0a74d850b9f6aabe748df6dcb61dc747d353f4bf1c5e8e42f86dc_Z,
+           0x42069b11d90283d36bc9f0266bb6e436bb6377104751f74e10ec8d89249a8ff_Z},
+          {0x2df366d0ca086a2d963e72f3b4cf01de571e19175fa46f70543e37b18751a59_Z,
+           0x7565346573c0683e47796cbbc3482333abda0f3e25b0b26ddfa588f85225ef9_Z},
+          {0x58121cf9b2562db3e56d78cdfee787f75e09470406eee04ee1c987a84855f74_Z,
+           0x7aff5ab2427a9cb50cef8d4fd90826d7b1b85a2634d45dc8c79abacc847adf8_Z},
+          {0x349295e173993145173b5082fe5cb5786051fd00db330695b41966e3190ccad_Z,
+           0x6b0257ca8e49c67ff1a6208ef609d36aa6846f3637747d32fc8f31b9d288261_Z},
+          {0x4dcbab28ad63e729be5462418e8664954a75b67e1c57dd374721d90b72943e5_Z,
+           0x14aafbbfd8ea06c270ab90eb57a57c7247ee9350f68412dbeb85797451a111b_Z},
+          {0x1a5f2db1e215fb967f56bedc77daaeb192136f11042bdb812ca44423a5b77f7_Z,
+           0x2cf8b2742451ab857f642dd36c6c7ae02ff5f8544a683501598ce0f3bb6cb27_Z},
+          {0x35f6e6e50c4ba71bc912f6b6da6f71ae00b49ba69a0f4476f7b4bf62e0dd71a_Z,
+           0x36d494277dd4b4d74b325ff65510df72d64c333f5ef0a83805b9ce49a67b2eb_Z},
+          {0x48d962f990930bd54902ca5ec4e80f263b52a7bd95d70a9142c9534fdb52f78_Z,
+           0x6af3fb454883130df9f894527a738df0f188c353302389b317709796ccfe274_Z},
+          {0x398a3272127a5bbfad04ac9e9f0c82869c22a2ac486f76f20dad37601552c89_Z,
+           0x1987f05fe86d12fe6c57dddd4fb31093fb3fae8a9df1c876960eac2a157674f_Z},
+          {0x62c1c29ef8925e3f6f413d40a8e9a4b538f099520673f462dc30119c7554d04_Z,
+           0x756d90321c37d49b2f08182ac351eb9ad8d0220167dec9f901f6eaaba801edc_Z},
+          {0x3823eac94d69cc1d66b502b9ef270585c46285773a404124c6126c8dc515fe6_Z,
+           0x6a1e01483b55d86d3acef79a5977c5c47586758698e28c4a76066cc23c9e302_Z},
+          {0xe5cfb8d64ed97c741399d871ec03e6f3d61a4b3028506d36981c5e43ce1d7a_Z,
+           0x5a6c21c75aca6bef70da755d4fe14b0e094d51d24716427ac2e2831ce4cfd2e_Z},
+          {0xbcc984d2448acf454c19e80aa7e11ebba2b6676831b49c6b622d590ae2005a_Z,
+           0x4f730fffbceafeba9576ef2b5da0203a058781dcc0611b37cd6f598a1918b5b_Z},
+          {0x7425fc03e2322bd1a85bedf059eabb73ef1ab8980c071acdde4327f9104fb19_Z,
+           0x6d37d62b1caf99ebcb5ff7d7b4550edaf6f66b314275783e91458e568f7385e_Z},
+          {0xbe03b736e4ad5a955b009e5806e4914910a96c52ac210b0603f0eee2821b9d_Z,
+           0x1706994a79713b41bff06c3cfc555b50e232f4eed4e247e1fcf59404c674c86_Z},
+          {0x779a94655d788e4ce933ca5695e49d98f6d9a049b29b1f7ca926b7f08ce6931_Z,
+           0x1c77a3559d02027a390a03f7147507819b109f3f18c69e9d4924693b96e6984_Z},
+          {0x42cb005e2184594ebb065002e9949dc13ee0bb6177cd6fc6403c9ebe90f54ad_Z,
+           0x6f65f30bc85222993fb679f53031065c078a76630758007f19be35ef3212558_Z},
+          {0x1876c2ecd5c04c4b29e67c41d51ade19291a1775758e2029776c744868aa725_Z,
+           0x3bd48ed05be3b33d43bd692ecee9033b213d36df5473180422ab2fffa0578af_Z},
+          {0x541da15409bcc67208c9c276b76ac862d25319982816c0ffda209ed97c48a45_Z,
+           0x342df7468f749b8a32567aeed07cfd240a3c8d043856f85591b8aaecb78eef5_Z},
+          {0x491819c6c4c53f44f8863db3fd75b04ee897cd4955a16bc81c0c49f68d8ee9e_Z,
+           0x7c3f86f79dbef3ad22c5ce5a39b4ee40997e6733d96492b6ca145c9d5a737da_Z},
+          {0x20624d393ac86f79e72b4416a8bb2581cb7d553ea3873140ff72134354c6fb4_Z,
+           0x65149b1ca41b5eb78f3af456293548233940b12c1208ca2fce406a63893f1ce_Z},
+          {0x7ed55abc1380f3b690162beefe8561b7418a1bda08b463c6b4ed55488050f0b_Z,
+           0x4e7a36b0264aa4b436741539acf618dfe7f99f70893060a6f2bfc25ed77f9f5_Z},
+          {0x7da5d4f5154dfa51e72eaa41a8fc3cdc1eee525b93b7a4359071621a72e1979_Z,
+           0x4442f5778b290ba0a23497bccfe18f4a42322ba05f4c23ab19da40b5fa1193_Z},
+          {0x36f89f3fcf545e54af1f08cb2000dbf1e98f524084945d1f078832b588541ec_Z,
+           0x5af9ee0b3fd6ecedcbd753692448229c28cb97de6829f99f5a14b8449b32513_Z},
+          {0xd180132945f796820cd975a707b38ed7d01bd846855dd4b1acef48f1f0bbdc_Z,
+           0x12a72003fb075f6ab134168aa467066cdce8ef92c996757c959c56609b28e46_Z},
+          {0x482276846cd8ff9060dc5b085493f612c3855949102539a239a4d7f509256d4_Z,
+           0x98670c286bd93349c04f13ef5915748f6f6295273b23f6147f1a745c7af8f3_Z},
+          {0x77f923e5644fa39bd7be79cc3b6b8a1150c8bd14f8db07fa4c79ab7d362fe3a_Z,
+           0x720a694b788cd0d9a6f2680eba5f450d242af19797320b8c6279fc5b69a4b85_Z},
+          {0x232efd4818f50a538e938473722bcaf379111fe853625a7e52e44090f5927d1_Z,
+           0x73dce67bae268037507f4ed844b07b7c0be075650a0e4b31750fb7fa5ab6673_Z},
+          {0x51689112de7fe180e0880d5e6ebfbc243518b739b885484ad033895c5363063_Z,
+           0x19c4098654bb316cccc3208985f3d5e567a0c0c1bb5e2d504beee3e2fc9faf6_Z},
+          {0x5ca31e901478c60cf4bc836598068e107a74b32f6db923742a3322b031c5a1d_Z,
+           0x46fc9f1287e2304536d466ef830f0f2375907b0e00204bfe17d676793e91aae_Z},
+          {0x162a13e8319007f7b69aa99f36be6988375ba3676ab49c5e132fe5208666211_Z,
+           0x1440f2d022c68736e327e762daca9a6b2ba9c684e5b997b56a6cc8d1effb9ae_Z},
+          {0x38c47ae4c21bae11a3d83d7b29c174e1491f15914bb9fbc644718f8ef2b5209_Z,
+           0x47475f462b6f4f20e9ee9d5ec086dcd4c80d26b8d265b66c1bc637e974f1ec0_Z},
+          {0x63f536a51884d99b986637c4bbaf6a0c17303953eeb2cc4e4008b22f43bdc2e_Z,
+           0x1ac4127e35c3cd2c3d6e4100a352e6e02832e224df9219516361e9fbf06fc7b_Z},
+          {0x34b9f20d0ee806f8020473285b2704c7381d4cbed6145593bf2fa3c7e69ba16_Z,
+           0x1fdb8f07562a2e5839fe156c40777f47ca6113377a311e7add9f148f952a2e6_Z},
+          {0x444bb42fbfc8273fb814bad9a80e03bd4d6b98258083c4bc17f1f0dae282964_Z,
+           0x559fad63173159790b5f96c8991b9f596c756cea51825b452283579feadea4a_Z},
+          {0x2d4562ac577393eccf5a379eb70f975436a6e0f50b05b92a2bd2bf549a5cb69_Z,
+           0x626d6e2b7312b47a4a1e95728b84fe641220c54f0930ff61b3411795b652018_Z},
+          {0x2a10f944742ea5c61f24e03b650b854757e2484754cf83aa66bb6f0ef6055c3_Z,
+           0x4b5caaabfc838464fdea5cffdc8450398e7edb21f053a9c72cc6e0b45b3c867_Z},
+          {0x33177e04a73480472793505560b593a772b3d22b81b3609d405f685a9afc2d4_Z,
+           0x1dc382097614e03e2b55aed5989910c528f18bf6b0ab8288e2c01c6b5222fc_Z},
+          {0x528d5aeb02d7fd69c9871cc31d75244fe9cbd103ad7afe490f7aff089ac6555_Z,
+           0x375b90398c081c923aa132994fc1eedb206a207beabe523ab2cce1dd7c60083_Z},
+          {0x7e807d0ee79f3a0af94b62551827587b7595bafa990884da54675e246f4fe7f_Z,
+           0x451184fa0754e7ccae1329719bfd8ba2b5cfe13bec2a26c81e972da41fff646_Z},
+          {0x397c0aaa8a7e3f4e13f85ee36543dd873c08a0fe9577343a872a1aca2b445d1_Z,
+           0x643eaa4dccd4e4d8ea861c1eeb66d4c998752e5c7f107cca006060991010c2f_Z},
+          {0x430f5f9af135bb66cc330088037ff29690d6ee97ea1331a5227783aaa42e880_Z,
+           0x647f1dfb86fa9af7b6235b0db991d19d5866407db9868abb8863cc5f3a85203_Z},
+          {0x6a52081b70e1477c2078399c473c0073aff03fd2974262d87da6c74e809a98e_Z,
+           0x2f90ec03db8694e0b3038f0b96e32df6b265f8f0e426572b26ff9e9d300288c_Z},
+          {0x7e94a46e12bea7d5ca3a4dbccbe69b2c0c4cb3af07a814bbb52a46370ab8312_Z,
+           0x56ddff1d93bf5cfe557fc6351d0d0d3f52a8404aa46b6c27e5bca698e033790_Z},
+          {0x69e703d6a694784466c996c290a4b323b69ce5d84ce27d4509d4c627024904c_Z,
+           0x7f987c78c83280ed6a7b7365d5ed8668afd3f36950fac41cc1e807a5d29fd40_Z},
+          {0x2b9c6cc778e98fc04d1ee21bcc8a732aedde2870cbd674bd50c4c3a1ab5ba2e_Z,
+           0x6f1a2f2c705914c8b0f61baaef1acac04941084da5fc6eb6b2d1a066bf218a5_Z},
+          {0x550bd5cb197e0c90f383756968a0399a48557cbf248ccfb5b66983326c463d9_Z,
+           0xedd8ca110237e520274fa3bc3e86d9b325805120af8e48e2225ca99288d5a_Z},
+          {0xeb6131dcd4a57fd981ecde8d1b29cb19770e6de447967265540dc269b3519a_Z,
+           0x2e15b563260ba4d8522c0b5200c5ebfc24b9d0cabad0f659949f105c8ab4331_Z},
+          {0x154af063d5646e3b324c70059be76cf52152329cf971ab27bfdf4d6858bdc73_Z,
+           0x472e84e7c14a3d6eb7371f0d3f4d370c1803d5c60c8845e8c8a1e18e7b26c86_Z},
+          {0x2503fc9f91fb17e1626f5a8b806e55119210cc13abf76a566ef7741a948899f_Z,
+           0x117c2b932de38b7473c38397f3869cf11f915da065bba547e7929bda73a0b00_Z},
+          {0x704444a259a3a6a08cca78a53893f5764aa0ae7da9d4a330b625e5045c4a6ae_Z,
+           0x77c7ee65438d0e23bd2ed761cded7108f37e2f923c99e84221edb4179a2782d_Z},
+          {0x1f8965279e84378bcacfe78a3bf8c3ad47d20118c6c08bf36bde36078bc8d1e_Z,
+           0xaeed7b7a773cabb4288168f5ce9b5bf3054d0980a11c216a7c9ff130ca6aea_Z},
+          {0x6c95617f6cc3ca96850c0ba2a1c4cde0b34f9cec5ae355ba0384d52b2810da9_Z,
+           0x13f5984a200ab872f3013c5222640e2aa4ce12f0b99f2fb57406fa860b6621d_Z},
+          {0x5bc1f49337b7ae9ef7b1067abba63f36aa3afcc2b718204a7b511ec3984a96d_Z,
+           0x2e040a0b79ed82af190b0ad9455c40e10e0882c8fd46ceb9fd96707c7f28212_Z},
+          {0x1e365499420c6d9eaebc0469d0a27d1bcd23fb96512f382c37650431d19f1a8_Z,
+           0x2a428fce3a585e06168c24bd14f994f6648a07042646819a482d32c1b43b0de_Z},
+          {0x7b21d486b6d49a5b5a891e9522b08bac0cebd78dbb9b102d9cf3dd5ab2f9058_Z,
+           0x23e478948d098303c865d00d7ba42bc58a9285fd24b7519bcfbc5d0c1c209a8_Z},
+          {0x4287b5164f53147c0d83fc49de129b17e9543ffb9c2926b79c16118a2f6d3fc_Z,
+           0x91e17d6a5b2adba9a172fb90fc2002eb75d93f589123cbbf5a0a3d060ab4b5_Z},
+          {0x71d7c7b174a9ccbab5d191170bd16bbfa3728be318d20a3d0ebbcee7b3fa1c7_Z,
+           0x1af36cf8fb35e3a6b27bcab4338fc087cf869b661fa111cc3ba83a2f2bec253_Z},
+          {0x341aac79bb5d020e18b9bd79f411be7f53b99d3c37cb88d0ef77b0d884d9bb6_Z,
+           0x489cf69c1f5fb8e4c9808ac8a2c5b20dcb90eabef74069585e66533f1e7b21b_Z},
+          {0x2d6b4ea44f14119ee276f6810a315ab152503a14d5742cf299e2421c6d87ce2_Z,
+           0x62a7155036fac616bf0ec17622cf2160be27f94fc04b6a4d692b6def748f1ed_Z},
+          {0x5f623d2d57ac36a4f386468f07f67e82af1ca9a93b093f553a24971612c7ceb_Z,
+           0x44309b5973eabdc829d8105b317164d8bbb64d5a567c49fa9aab34f721ee215_Z},
+          {0x3ac65082cac12f934d07001e3fe633d43b332f1f14eeecece807679c7e539b_Z,
+           0x76f45e08a50db6a706198ab174658c86bdee5a0ce55d4087d542e6c8bcf15ad_Z},
+          {0x26e1c9c2abc417b9c095aaccf553892731a469edac08715c80bc03ddd230f9_Z,
+           0x4e62b15eba5b911b9ba6b5977addc0b1df71fbe3251d478f069230fc34b5018_Z},
+          {0x3e2c8b5fe7574adfaa9f252f847faf9137eebddda126d62964b707aae911a8f_Z,
+           0x606490867c30ef7ed9c3c85c50a648803f3a36a512ad89c082489da8dc9f5d1_Z},
+          {0x54f62dee981af130c2d2342ea48ae1c2176d762091e83359853b88c4b2baf4e_Z,
+           0x34ab726c86b8bdd2605f1a7a37a9deb90a4cecd8da6d73c7625579530a6d913_Z},
+          {0x6899430a29798bde6b02dc8a93a365c6248003510670ccda7bfa7708e4f5ffb_Z,
+           0x21919db4d4ec664a59b2f77659b8fd307f390464f509e13b751e12fa62da297_Z},
+          {0x9f33e056e4bdee7156c2ae18c936210d6b7ef3c452d17b5424c132b9a7f7c9_Z,
+           0x2aa8af6bb9a63adea95d46275f9693a4a697a45d6d1540aa3f3322cc600c4ca_Z},
+          {0x1263b93a9d44aa4fd1d384dca15b31182a4144ae3586fa3547c2f88c9d2cb98_Z,
+           0x2849e96fea308b36c677afd999c1b5b63c4d407dd7b7dddc45b5bff824fbd5a_Z},
+          {0x5b69efb0b59d7c3cb3e000f83bf5eee119c26d01784d0e9ca610ea257ad2bdb_Z,
+           0x45fd04cabf84be493dd9a47a90182ec4ae643e358db3a14b22053792464d67e_Z},
+          {0x2a1253a76225044b7570d52a0b01af8a40009566232463889d19fd5d635efc6_Z,
+           0x4355a45710a74ec1851ab2596e92a4c81faf0681fbd322f3d0f8f18856a6f0b_Z},
+          {0x7e296fae037055854c61cf8141f72ad1f62af7293190d2d0f7fe6737cd2629c_Z,
+           0x29ecab578a1dda484f2893b77efcc70702e6753b8c2cd40044b4015a7175f89_Z},
+          {0x7bfa8216ce89a4c360722735f13d484fd20fb221c2f6e34be9e6708ab5592ac_Z,
+           0x6df2d64f1aacc72a06fe75383d52e3328efc464716a96c47e47f69d7d5835cf_Z},
+          {0x571c6be7385e9acea1a8eedd70270086738480819d5a2ebb91bde58be06ddc1_Z,
+           0x2ba3d227d8ded5f4a98826cfa268d1abfbe97a7072e83382653de10e6d44378_Z},
+          {0x190a23a7480e1d4833e3662ce87ff618e0c437bdb54d7d82c8193f8819a3255_Z,
+           0x57f72e7e6713ff0ce96a1faa5dcd4c7c2412fedb49de63961d81b5377af33d3_Z},
+          {0x32d8e8b25dcd84d1cc223e0b7bdf99c04db3fb3955055499d4d91a9545ec5fb_Z,
+           0x2177c769e4c84ab8f053498527300bdc5611999945cf1ffc78a7d4cad593d3d_Z},
+          {0x3df1cfeb003596452518dadb133fb4449f49063e27bb0e3569e1a20b0a70c11_Z,
+           0x758c616eb3074b2f85a915a7696c5ba683fc0150e7fee84487fd566599a9971_Z},
+          {0x645dd6a836468b29829869e7f2359c8e95fe08e30f660523c850531356c0d8d_Z,
+           0x7b61e97bc7ca81e535fe795a750ce2a7ba792ffc1aa85fd7945ee2b3222eeef_Z},
+          {0x3b2b262b7568f12cc4291bdba92ac2fdfe21c3e8b89090d12b8f6bcffe04d89_Z,
+           0x340e826874ca13f76a71e60ed1f281839b53bca9e47eb88dae8f8ef9d8265b1_Z},
+          {0x432d29695922222abfb1d117951ce2c9dcc108e0a2fde6bab65fe82d9b673f3_Z,
+           0x541f4bb81ec0b86779953cc031f2d6753328d660cbfd0ac95f4ec0763895e62_Z},
+          {0x1559f1ff03020f8bd0f7704945f9653fa653d874b416e5aeab649c545ff928f_Z,
+           0x6c5e9520a2a5743f9ed939094214177c01ae54a766aec3ac0b2a2bfb150e1d6_Z},
+          {0x70285a537c5743ad18c507c9fc7b36f7c89d92f1f4b8838b14a3fcc674e3287_Z,
+           0x46044c8e9b3f2c3997eada3c6f2ab7850c5521250cd5c8be58b250e09d03cbf_Z},
+          {0x19e89768f95f21153f7d5761ea30e8c1e423b688dc7ad57cef583b8d9ba038b_Z,
+           0x5568d4089ec4493d5498b434e4c359b6172448d22d70948fa0aae37503abb1f_Z},
+          {0x3ca52895174255e5c604353552d0add7ac0383066c7a52a5a6e628bc71e3d73_Z,
+           0x4b7113d92fe45b2dba693c72125588d29841e283d53d3471f6aac16fdd92afb_Z},
+          {0x72b02492dc9313f4c685484b1a4e9e3a9d66d4d4048512956c8e4d2972268d0_Z,
+           0xda3d0bcaf753c75ed7434e0bf15db062e65a1b2ab9c8075ff7f0584f786db3_Z},
+          {0x6b65039005a452e7655b2191392c45bc4de40f54c8b0fea343ee8dc5e1ad2e8_Z,
+           0x345e91a226e39a6456460bf1d41a8e8aaacec83f7414ba4f37974d0d9e4ec96_Z},
+          {0x1912e5ee42b5ca2f8b5424a98202980753341047e9bb4552134f37fac14f676_Z,
+           0x7c6e7bfa7feb9171b895c0b1ce6f666a5739009e7a8474210b6c4bdb1bbd4b2_Z},
+          {0x48502deb034583aba6b2561fff8b79fe0575f6fdbea905b6aaa8534824adcaa_Z,
+           0x78c7d1400fa78bd65fe0228b0a9d39ef52a03ae0be17b3041ab8d62ea74bb62_Z},
+          {0x432403d50ea249943adac5035feb01ecb01ffc7afa15f57c4eef8a99eab1fc7_Z,
+           0x6e6585f10c8eeee417e39b428573beb81dfc18d21f51cf6bdbc4c96779d8eb4_Z},
+          {0x329e2012285ce7853b795c9b49391d5c8f840fa272b4b41ea31c0424d6873e6_Z,
+           0x64b7c9332ced6ebca8e286375f0a0a82042e7d18566560939345c14782b19ee_Z},
+          {0x6018f13d059ce660df1cf0e39848f7f98f6862122eca13f6d2a4894e4eb0c96_Z,
+           0x1351307604eea32271d80d7de43bd14d9193b8bb206721beff30b77cece1efe_Z},
+          {0x27d98d968d65a5e8499c93a8c07bd7d06015b7474f50a2da8c3fcb52172ef25_Z,
+           0x1b08032d41cc31370de64b7190e2786d8e2fef0089d2cd22dc625f027500f93_Z},
+          {0x75abce30534a0fa9dd1e47dec066129c1ed0eb7da1a158af6676e15899450d7_Z,
+           0x4b0d6f9ebd604381cd31e0e1aeca8c3eda68d9d0e8070fb461998727b628e8f_Z},
+          {0x5171c08605159938775bc44986710deb27da9d9b8c13be44363d64fa9332203_Z,
+           0xb8501685ff5a81abca64f511b26e781abd7654cb2d848595cd1eee0d4169e9_Z},
+          {0x628e25d795dc2f9e22cd26976c19d5a9426abfd0b32af5ee8e57d3356e6fd76_Z,
+           0x17a2b6c94f22377d3b90f9c7073916c98792037e020cb282e910d22ae0ec6c7_Z},
+          {0x2d830e65cf45fbff86a69510d1f81f9a25701a85233fa943ddb3f048ed760c4_Z,
+           0xe40ee450b343be503d6e0221dfff8300b86db28d3951cc8b92627444d78728_Z},
+          {0x33db16f15031a95e038b84aab7c3d811caaf60cdc86c2ac059f3392d9d82f2a_Z,
+           0x3e1c6ea96fc16cf45f964042085ba7ffd3939920bb2a8b63477a300e467b82_Z},
+          {0x478156135addad99f4d9836ae3679b88198b9618b245ca439ec6922952ddbb8_Z,
+           0x74be4449e9995725e3fd329631914837447a6994b5c0fd92a015d1016bb3ea3_Z},
+          {0xb078248c627348d14be00d67098ff502824ed0d000f01bc6137f53d57108ce_Z,
+           0x3af46e874248384d75b833aa7d6f96153fd1cd68a20be14ba1528e8e27e2b1d_Z},
+          {0x43436a0ac6e38ef524622233e962e010e4c3ea8f65d8937b9d0d19368e75f22_Z,
+           0x1dcb4bce500942e5e5a1255da1278063123568e5979f746fc09831b57c3c391_Z},
+          {0x5fd652881aa2693c6c3c5ce8297f39b1e9df6b1f76a8e7fa37fae4e42c67ac9_Z,
+           0x2d7ddb2b3a990fc2a84b239fb5b60e2589fa205bc5527caa7f85693ebd82a2c_Z},
+          {0x131bbdc5a18e78e7668cece6358454b59409018242abf5010d4a278489378df_Z,
+           0x596732c7866fbb1dae935d243ff370c00c713e7cf227ca08719ef57d418c6e3_Z},
+          {0x87eb4a6f86ce8db30cb357d6b312748849534e96fb2064e37bf0e23a2461dd_Z,
+           0x6efddd20c82c7bcc41ffd27c33ac2ddd323af83ab126a8b8de0523b76f71a0_Z},
+          {0x28036905e8ad4ad24082c3e6187cd37b978e60549ff033f852a2bf39b58d347_Z,
+           0x4f55aa9486996ecc55539161f0c00ca0bf1aaf63a3ca065bbd39fd56d0bf1c7_Z},
+          {0x2e9b202d9f3add0cc5ee7cf6e539640a2d8c021e0661f9f61fa8b2c0f3025c8_Z,
+           0x24fbf10584140f45ccd97b7bdd7e26f4cdc3830f4f1076eacb9c5368f9a1141_Z},
+          {0x4fe1d9cd818f16803ff8518096b357e98775d84cd55cef5f6561048a212ccf6_Z,
+           0x782ff1acacefb956bbb4db36b53e0abad7eb94c28f8a979e9bc64ee58be6e87_Z},
+          {0x5949ccb93fabcd906657018bc59bc7724c7806b02c16067260493e9ed0dfeb4_Z,
+           0x11df2be72526fb2c7998995aec224df748f1b01189b4f5cb751a9befffaa62d_Z},
+          {0x11bcaaa03c63fa34f4babbcfadcbef45b607ca99fee6cdc206c85772079d23a_Z,
+           0xcc0cab40822a3f803d733c1a538791c500143791d3dc95c47cec83aeabe878_Z},
+          {0x6a3b80b9f3f77053cfe7607d2a94526d7409361a20eab4601e723ac557400c4_Z,
+           0x50fe612b5cdb308da922f8b98426041407f799321544e2303355bb1490f5c43_Z},
+          {0x31880b42036960b5650a848ed9e86a67d9e947b8ef5c389a012e3536f679ff1_Z,
+           0x3502e2fbf039026aea3779a4d6b452f999520d915829a415e495c19f00b40eb_Z},
+          {0x3ba2f7e3ea919e1aeb00afe10bca5a7d940ed15e32877744f5afb7dd3307f72_Z,
+           0x7402cb75a1784097932623d7f5dc67aabe5e202fb5683bf144f97f25a1a75c0_Z},
+          {0x152a0d759e3d99c4ee1bae5ccb3add41cab2f0a915ebc7994fbe17e8c16c2a6_Z,
+           0x2546344519f6b35e94d41ac75d8fce5c48363ef744a849bd947b60dc4da531a_Z},
+          {0x6c67f02177664b56fb032c8296f54c738e020bb4a968d7fc74ce255aa2d76c2_Z,
+           0x160494da260ef06969ddd1069214d399c25a0cb8e9e796d73df73fd808bc55b_Z},
+          {0xca20f58bdd8b2cebf93f9373bc62d94a53325175dfdebcdf259d8df64e505e_Z,
+           0x376ad50a444a37554edb7733e9fcdce3f3f5b10e01954c3db1716d049a4586c_Z},
+          {0x7069deb975d5c2b160c9f943c403ac0be6df00ffe23606a6708e7abbbbc2110_Z,
+           0x3374f89a706fdb690c1e38fdc1e8a91ce2de01c8f4a2f96b748bc7dd7d44c5d_Z},
+          {0x7e3b2f6c449e105727a55b7875869acad18b05ef45646baef683c4017b60c24_Z,
+           0x3c25cdddf8f7904118a8ff9c81036706a4f39edc1d77ba770309c230a9d0c65_Z},
+          {0x16fba8459862e9deadaf846c4b5b92126485fff525e7486e75f2270bcf27dac_Z,
+           0x78568c1cfd5541cddb3b78bfae372d71d4094c83a4da2454b0830c88192295_Z},
+          {0x7b045f1eccd4828b79ae9f4dda9080f891d1d25dc1d1b7daf82325651880ed5_Z,
+           0x38beeee5e7e5339f21a5cc335fc1da4b5e2f265f7c36e541714fe9c328a0dac_Z},
+          {0xb0edf652c470bc9e555aa4d3eb66fa5aef80ba9daaf10abccdef3c7a784c1c_Z,
+           0x30592c9aaa613a965d224c5d80856cf63bef9a9ce9bb5f2e0ff55e10b4ca630_Z},
+          {0x2532a2b4b24dc6b0595c4b9d55e90ddcad124ccf37abe317caa293f233e7e3a_Z,
+           0x147ae8b445c1335d4b883d0dfaa3afc3f42d970777cdbd4af027236590e892c_Z},
+          {0x2d61d3a719d78ce751eef76cf84e1ed4f144d549cd6e82833e2dfab989787bc_Z,
+           0x292efb15f851b384dbaffdf9f5f392bc146f041da01546a60a9b0241251f5bc_Z},
+          {0x5c8853d75a7171147709bf2b2a61254bb4253283ce6c31567d0b54a7d4fd614_Z,
+           0x3844f4a9c9542c454f21426ce923317ca638536b0c8e21831be64a5f0673187_Z},
+          {0x7ed6bb72c544e32d39002f7f42ab053d5d3b4fd30efdc1714b8316e7371447a_Z,
+           0x78a39ae4fd507e9e1239559d08e63c2c643f66fa975079c773fe42432de1e9d_Z},
+          {0x5b0fb1cd21a15c1adcd1de4181ae9df92beaa790a70f8fe1dd4bb3dcccf0a56_Z,
+           0x72b1c419a1769015ebb4ec7574725e56b324adfac6b270c130bf992fc757d54_Z},
+          {0x6fcd2d13d76c14b93891bee2f29e41c05eea71bfad6d1a3bb41ae374c442d2e_Z,
+           0x6461615f730e6cd45acf5b9731389a6ad6f920aa9e00b5fb9771cddf3c6dcbc_Z},
+          {0x34689327933520a0c196345fb520e6357021ff285dbbdc27d011a45dba63c7e_Z,
+           0x326dbae561cc9a6e240beff736766d48b929c52951a1d17c378248bcb449bf9_Z},
+          {0x5d01c051059f7731f3516f148c6d8f748cd9dd27294e89d1dbfbb7d8719ddf8_Z,
+           0x51fc4755e0cb57d1a20ba0da40c1644aeb7e21fe133ae8c48d3a7189e6c4e62_Z},
+          {0xd669677be87d87a2aec188a0734ba8b36822d2050ea3ac9c08476332730f63_Z,
+           0x747f037ede3d72e0b8a8a61a33470436d2fe57a01e5366d6920abdb3c4241a0_Z},
+          {0xf2fcc3e4c01b6f88cec60a180ab39971031fe40037870f1231abbab46774a7_Z,
+           0x30a29e5032d74800d2bb64bbff2a7464b09faf3c1163dd60a5b956bf7d37e0_Z},
+          {0x30d5fa5a7deb5473bfdf96f48c62e2e74f1e20d725a8b8024e098754500937a_Z,
+           0x77ee292c9e674b67b02cb656fb5e5ed797963cde261077826569020f948a400_Z},
+          {0x353422847452595eeb42491eef7ff046a489ba204cc7713972d13985adc4799_Z,
+           0x43eaa6aee689e456fda5472c7842ae0ffdf34c69e016261329549215930b722_Z},
+          {0xff9c8bd9da0f9bba69339ffe26fa4f31d1c8deb61bfc2a0e53f542efa9894f_Z,
+           0x6e2956683dd63a6d43c6a63099c9f528d82a2d7a417e5559d95a51ee32741c9_Z},
+          {0x25519ad4974d3c51ea09edf3f4ad4992307453b513cac412185b6922190db0f_Z,
+           0x5f6e31f8f5218ddb86e47e3585e345a0e4e819d0b33aa3656b160e8ab54e2df_Z},
+          {0x534c696772e1677d412797c4ce9848f4940ec47a791868022c08a202afd80e4_Z,
+           0x48a1fd856ca958bb2306e6cb4ae2634b307723d58588579fede2f44836bfd4a_Z},
+          {0x28f36c7c4993063c0e3556219b0ba1d22a41614983b88734770ab52b86293ea_Z,
+           0x57fa579a22d122924386b1b2709ae82e44af5283523408a21854353a4fb7db3_Z},
+          {0x7a6bf283d8cb8ee332ccde05cfe39b79f9256e9b67c815731616995f04b37dc_Z,
+           0x52f2cc7e99f18aa74055fe27174dd98b13eb62b58820101d490cca785811d19_Z},
+          {0x43a3915601111e4b65875d627651162e35ada3ff800a7cd82b45401b437b7b0_Z,
+           0x514ea8c6d3d81de7d2f7da64b99ea4b3d513ee09cd0b0b35966c361943ab767_Z},
+          {0x6acf2d8696603cf4bd69ea84ceb8965bda071ead5fea9130c2f351af8805775_Z,
+           0x6bff1e20909266f70a8093392f06f90b752f0d4dfb3f0251cc197f3813230ec_Z},
+          {0xd730ffffba62a7cdd01cfc234a5bb6f0c9e103cff5e186ff21072666220008_Z,
+           0x1410f2db1f1b173876015ac4e82d5a7fc2dbbd89c572bdaff4843359c522a_Z},
+          {0x4e9176d3f7d8611e5b0dad5358c36c20b07f8f22161b9861fbe8580c2b94725_Z,
+           0x5f392ef87f97cf8ba8d16992cd44b5923d96bcf60d299bd0fde7d0c79bee558_Z},
+          {0x63ad76e0daf34503a99f189b720b741334846ae2eac1361510b04481635c8f_Z,
+           0x3aa055ceec2860c6da9f820a5cfb26ea5bec859c92a8ce79f3c9db82b8b2bdc_Z},
+          {0x755956b5bfaf8c29438a06974b51462abdff7ef87fea3cccf64da2d12ca5fc0_Z,
+           0xa38380c3c87baa2c6a9bd006ab41dee5e20fe429e2797fb3fccf700b526ade_Z},
+          {0x67d11353c4a408c5bc238f22eb8a587e4e45d9ccc0fa368eb58d983bba31b9_Z,
+           0x226df7784553b5b581e4b154b695f17f0aba64ed2576baac010deb26209a7d7_Z},
+          {0x74699ea52e3a3ddccc266e5103801fdf8f5244dd90a6e956a873e722d41000e_Z,
+           0x7bb47c7d17b634f63b4d03f708b213718be0a616a4328a1c2bcbf319d585fa1_Z},
+          {0x1aff3c42435c9a2d586951e484b1d99945ea9fdcf4ea4fe886caed837a4a781_Z,
+           0x74e136a3ff17c06c7d80636474bba9a3b69efc37d168da3005b9e0cf6d3a32f_Z},
+          {0x2af5cdc5d24d0ec821436b7c8d101b86680895e481342867a2f513f65acd588_Z,
+           0x5cdc0540c7f914cc74a8d780ae71588bf607ad8678740732483f6d75097383d_Z},
+          {0x12e55895598fed285a076a0b67be9a75a18e9782fba04bc3c4596f039d3f91_Z,
+           0x415e108362e4bfa184cb1217a86a10ef75a9c1735bd035705d132e135d1487f_Z},
+          {0x5b9bb03217304d6e21cb120a29b6b1a7c032fecfdb4267dd972865dd802bb2e_Z,
+           0x6744d60e969b91f7bc23023f081405bb630deeb5e7d5f891b6c4a16ce74fd24_Z},
+          {0xa19b50cdb68f3ba25914e9e0c256153de8a784983477e666fb9e35dc324b58_Z,
+           0x6c3742c8d889c6d78a4457eaf676891b4bb78d31d331556f1e1eb422e00e997_Z},
+          {0x1c41af23dd2d880d2cd9e00da34652ededa32d86abda28220d443a6d6d9a526_Z,
+           0x32bb392277d245a518ef1b1378dbf475e1971621e8d0b9296ec17e66c796e8f_Z},
+          {0x5696362d1996d3ab9590fe6efa5681402218ebfdb62b3823014ca6cb964500_Z,
+           0x41369fb45470626fcb91bcf2bb36b37072c099f6e0c02f1186714f3cb43a67e_Z},
+          {0x5de0528a7857369e377b7e3cc6a0242c37487fa8498bfe1d5cfbd23a173cef7_Z,
+           0x3ba72911f5f741e260b0dcfde93b2e9efa819135847083170911b8a0cc14457_Z},
+          {0x2b5f39b9680e1f3455f934ac5f82236c3bef8fbe2d82bb004f1a63ea9d0a078_Z,
+           0x7e8c9deb4ab5c7c49cca9b852d9ab01833455a0044be1ea2acd01e35f1d459c_Z},
+          {0x5a0fb4a941ea9fc92fada62a9bb70853b436c3bd40a6170703b05198c36ce99_Z,
+           0x72d3e0a41851c425a83a413448414214a35d9e785a870d1f9b5555105322bfd_Z},
+          {0x7b263af26400af6523cb5371808c2d9e328a8d64f8d64d658d01bc3a55f5896_Z,
+           0x1c87ef17d66a92ae07c55592cd75c474698348898dc778545c395a1931fd220_Z},
+          {0x3c6987eb0db2455329e2e1bbd3f4fe489d611e34949f18fae1159db5ac90a2c_Z,
+           0x7dfeade17a4de80690e4858d0bd2c2ed509a3cc24abf61adbfb1392b9259628_Z},
+          {0x41b0908f1b22462f50bea2821a41dfb63bb9c99f0b46a9ddb7e2d3c6e42624f_Z,
+           0x4ef9a0000b31d4e4fc6e5333ea3f75cc8c109dae8884ed0842d66bdab98c181_Z},
+          {0x5a97c5c22fe6126e616600b9290ca79bb1559c24d17d9be7e2897912627bebc_Z,
+           0x290aa7175f9a88bb521229156d95131962a4b9f97019d25cb4e035979b6b83e_Z},
+          {0x72fa1ac62d78ffd626c58700286869e0d83f2e654337ca0aba531f4d6c55fe9_Z,
+           0x28fdcd3b2f8a05df0b6472d2893f88f063847c558b32484f7a526aa176cde61_Z},
+          {0x6288b393a5ee49a2e681441a0000a6bbd93862f5014f8d6118da65b1cec7c3e_Z,
+           0x79a757687561f7d3e28c38ac52a5eb9abbad434d5602944f170973400a530b_Z},
+          {0x296c0885871770d82e4936ab13ff095106fcff5a6f6a717768404092d27438d_Z,
+           0x232357bef69ec966a35d68fb02c4d13a5041b91f97d8bddcb66ebbe8cdc92c0_Z},
+          {0x22b74938bc3c0f01bab7d8014f82ed15c2c9e3502584d68b23cc49b061cfeb4_Z,
+           0x1bd68eaf1fd0d25b20673cf2beefa40e719219e28027daafb4676186429bfe5_Z},
+          {0x6035419487a4c44584b35960f28314998775f92744d2950f12c4a09d850c35e_Z,
+           0x5c1dcff4d003601b869fd57606e879906d6f3c5ecc5dd131bf19e23f00c87dc_Z},
+          {0x3a8031bb37a07d1fc901f3899542835164d474bd0bbda3515a9927ac2099879_Z,
+           0x2975ee8fd8b03850d29b4a5300af7e8a6e6ced3aa92c8572448df80f1330b0_Z},
+          {0x3145991f434db6b85e7fe8e6c8b2f4bb86f6269f78bdac2ada40e9ecf3b1023_Z,
+           0x2a3456594fa0abdf683853ffcf5e076d0507946a66e8679bb2b975e0fc8c308_Z},
+          {0x18d88e5a10ca642a49f4ef6e0800306cda1e3fbdd6b0685252ed95d2214ea80_Z,
+           0x1e37cb95beed80d95d37e636b1b8ff544ed46fc2e1f31af943e1c22faeef286_Z},
+          {0x46ed465d5a716a91dc4e33e29571823fd3016199daf4ee4ef91dacfe146003b_Z,
+           0x7ead4cf3c80595dcf09e52ee7e20076f4dd83a923cc333294acae9434bec0d3_Z},
+          {0x7be5fb85294422ff899decf255f7cb2ab92badfda88becdd2c066f6f6f89318_Z,
+           0xdecf0e2f89110caf7821d23f74185775e2c083d65edfa14aa9267b18894640_Z},
+          {0x2a2370efa4c63b6645cb146952ab856a9043545ab10f48074e74e5b8b7a34ea_Z,
+           0x2ad8bda41768f3ec3d9f99e103a36fe49e54ca6c0484fcabfdaf0bd6e39ca1e_Z},
+          {0x7c6ae8e5927161976a566d4ded4c185cbf5cb8757f9fdba18ec50491bad075d_Z,
+           0x28cc01dbdbf255ba95cffee404953368e2b3dbe2966345ee8852d50eaf90fb1_Z},
+          {0x5518b2308ae8e88b9153c1b053ed3ce8ca53e726a45c507ba6fe400f8f1a443_Z,
+           0x1e3e5d6aecc7b847e5148b651199fcec653fa4684b2de5a6f0f84579e656a8_Z},
+          {0x677e92701efe79e503d601a86ac16316e5e54b8db6866335497d8558047b9e6_Z,
+           0x7602c024046aff921dfc9cfba35d4a5b063fbf5a814d02e5e4aa8c64f30a346_Z},
+          {0x7affb3f80cc5748494f05cd59e69f777df86443bd4ac7c64ad5c4e304998294_Z,
+           0x145eab080128dd81e6ca3937a8b175355ee65aec816237f55035c0859c717a0_Z},
+          {0x1feb81cdf33fa17f9cc8b7cab0d5ff3f8eda819f9533c1f96a8c6721ab8273b_Z,
+           0x1fc0267c7de843cf4571d69ee16b7e100c1aa5d58fea96014920976727f73e0_Z},
+          {0x30695dc5dc203e6dd0b86746db008fe3de796a36815952509bacefb43a969ae_Z,
+           0x7c14905354cb90fb75417ea8894ade13b72039df5effd2b1c7a549d75ef5e39_Z},
+          {0x17ac9e8bb6fc520b4b181ecb2a763490fb107f6825307fb6de962d1b96ecb15_Z,
+           0x5ccf93f17a1c0446f06d0b3e6c6e951a3bbc3754787dad6b13db72a970b24cd_Z},
+          {0x79fd139d6123dcc50a56e9bb79782170e660a959ae910195dabb342583ab994_Z,
+           0x20ca3d4e48b7091a687070e13840827f44ae3ba0d2cf57d3363cc6c4a105a71_Z},
+          {0x5901cc324168796096d09cb91154c574f7fcb2724604b534dee5f5705ff5ee3_Z,
+           0x16f8db7dd1294ef466e7cc75e5ade8c4db6bfdc8d3ca5375c3171c5b5602be5_Z},
+          {0x774991bfded5676421c6634b0708b57ad795284fdd9b35b2f3eebc9d825de76_Z,
+           0x4083e65888bdf72522753ecb6dbebfe515b9046188cdafe1baabb3c6acd1502_Z},
+          {0x44117de27481477452862c161e2ebf2f25643c2b153b46ed1cb5b06aa91d75d_Z,
+           0x4c82003c7b2c06d3d03ef38c85da2025d6c792008464835f89911d7b629eb79_Z},
+          {0x31af6e7496b9fda1af3d921f7c6bde1cc8b44ffd54e7c0bdea631669149029e_Z,
+           0x27f265f734c0748b6f27caf14f42b720fa10d3b0a01a9fc61c52b4bbd7db5f7_Z},
+          {0x58b80a14442bbca0a0c23e4441a0ff88402d0d8811c20d840590dd172f80f56_Z,
+           0x614965f7d864d6bcac190ba8386d45850a53c43d759ff595aa25fd47d819de7_Z},
+          {0x71948fef9cf0e2caf918883104ea7153009c305322870d504d44a1868bcb8db_Z,
+           0x68a65ec9bea022e156726f8e1c51e36b4a6b5465ebba54ddef0d2f114987886_Z},
+          {0x6f790944c01ec08aea6c0a8b8498e6fdebe2f723332c5d1734bd558f1e440_Z,
+           0x5e08f859e593bcc5654c918a96248f58e46471ff51ed4cc9a69598cf47d124a_Z},
+          {0x7ac66e970a3f7df1a59b6b46936891575d5452fa90f4b813917d644abda6e91_Z,
+           0x554090e6f5dd27f78e7c149565ebbe8afe1fc1ca7128e52dc7e2da1f5cf8ba7_Z},
+          {0x52c5264bbd83d671820dd2f9221d057ba050a501b6c721ec77404424f74ae7c_Z,
+           0x5a407e6a1e1e52609914a468d510f3ffa45be931b881b6fabf3154b86a3724d_Z},
+          {0x3e4e94af698b03793a6e0975fc6d4afff3204e1ad5d02f66602f1939942783a_Z,
+           0x3ae8774152d576d2c12a94fc347b788853568d4ad49946bc1d6745fbf5f735d_Z},
+          {0x3a23b51ef56eda14f929ae715832083da8d8ff1b2b1f40ecef539e60f04167b_Z,
+           0x494c7016ea479def3ccfa4c47e551215f29e8905723a69a96b93c0e496efc0e_Z},
+          {0x6d45acc5bbe1ccb6cdb703063c74cfa21c1f3a21a59f9cecf716831c54a28f9_Z,
+           0x70d4998663cb3042a7f3a278a14741185d97528c79f2583d6397d45125c71d5_Z},
+          {0x59d7bf8bf6be9a3e792a86d3b1693b79365354ccd66e3238a3d837e297e5e4c_Z,
+           0x6abab3fad87153ca6568efa386c94d7470ac8dc89abf726c0f8b72616009bf5_Z},
+          {0x6dac169a2b256442e21d8af8a5fb0db74c48aeabc7ccffed182486156c1a986_Z,
+           0x67aec17c79453728a3be44e0a1806d15e433d85bc5eb4eac0edc9153397d542_Z},
+          {0x699546888289aebe1795af7cc0d2c83b30327d019e4c9e01665d3450ea4f756_Z,
+           0xbdbf4295455701be1e648d03729c26b0bb8a5b4b60f726184bf858be24e46b_Z},
+          {0x7286faddf0403fed8fb16840e60f624e88d66dac90c8c8dc7536fcc8f682ab9_Z,
+           0x6aef44260d4dc77b5cfc05cab4005631b213939a69835f3523494367a03468c_Z},
+          {0x1859dcecf9b2ee187134d76caa6b40e55e699bf822cdbfe0db9c9a1db7f4db4_Z,
+           0x20dca845a62654643495067b8613045f3bdc8af69799eb888cc5fbe8e8a998d_Z},
+          {0x31d61264dc643821bcb0a6467500933a9418a5ad5d291156c8ab3b796175ab_Z,
+           0x33eba79d2c508bbf71b7f5f01903f4488e5e79b1de04ef0f3f9e21adc8ae950_Z},
+          {0x58d0811300bf3740fbf522d7d91b524da1fe6eb69bc4478340dc6c25c977f98_Z,
+           0x1fcb528cc898516f3ba25c56b9075af57b6ba921c04133df2f45b0f071e8a79_Z},
+          {0x6431f995f6050cb9bf592688e3334be4a15d36aa2d4293ad9d8951a0f6522f1_Z,
+           0x20d2c1654e7872bc764ae3932ed3e48913f363e95500b04b5213902825854ef_Z},
+          {0x7998d1e4b9df95112cde2774571ad4eb5d85b8c994d5cde7ff854f66d446a5b_Z,
+           0x65bd3baeec8420de17fb60c14c22f26d13102ae2ab4b40779289c89a3ef8af4_Z},
+          {0x6a10729d3426d0e7d4e4ba17ea5e662555b29f177ce8ce76360fe1162e61f4c_Z,
+           0x5ad6e4c49bc693224f497a35e29a1cd25d60744c4e072941e8364c9b6db0ba3_Z},
+          {0x5bb440cbb07b78c7e161d49302605d9a39c9df08b29866bd1f9460fc865693e_Z,
+           0x4f301f2f284b4dedc845dd0233ed2f0e10a831bf44949ce009d81ceeae5d3ad_Z},
+          {0x16e671569ddd634b235207a1dd7534fbaeeb1b9ff258666734e8fe854d5b0f0_Z,
+           0x29b9716f059c5f50f47f295d51ee9be2bc86781adb29c4777696e0c04515933_Z},
+          {0xf7ed43c69ae47d3fb1b003ea46662f99a40c00c3aabc5fc18ed19812e9ace_Z,
+           0x6b27b3bd78d428a39b17d14bb67f14eee9f7a9e4f47178fb13b3771b59920e4_Z},
+          {0x646b693faac93d996ec8073fdf9872db19164d3b429db0fea51a85f271e3b25_Z,
+           0x6c32faae574b9dfdf89c42cfcda3b29b77841928d9f6fd865282b5892a751ad_Z},
+          {0x46340adb23ac9d2854acd649d33a369cd0f3d44cf72df48f8d1a54c4ce7ba01_Z,
+           0x643607d669dd430b7a89d82a77a3f07c968a6c8ec46bc73fe45f9bce267008e_Z},
+          {0x577c3ceb96a6a0d000039fd9172bf1592cde479d6c7027dc3b734f2d93456a3_Z,
+           0x3ab8b4194e01015872812892f02ff2c57e1c5447bc974c0dc7e5f23f8ebaeab_Z},
+          {0x7e3a579884f8ebbcb037b4dce6fa7c8144abf4150aacf2c92baed923a4539bf_Z,
+           0x3b6ab45393c6905097f10a1ca2a2f15e4543654f0b729b6388430a87186bc08_Z},
+          {0x57278ccce2f700b98559d59ea2b24d9b710b28c0be45c9ad2b52f18759f4992_Z,
+           0x74e9c1347aa9ae6a574ddbeb4808bae7ebdd6d05d74b820dc811b2d3d4e1de3_Z},
+          {0x293851117a3c62e9ad3d584fc0271882ce9212c3550e18c0a3dc6435a08f804_Z,
+           0x487d363b24ea945d11b948be7c6d431b0f222a42a1643662bfa565b704d4b12_Z},
+          {0x2bb40c08016a28a012ada59f8326af6459b32c931369ff5eb0e66e2bfa91839_Z,
+           0x6d3c13cfe4442c3cf334bdea918421f0304f84fee0503b37fa254fa9695a7fd_Z},
+          {0x41b60152c072939d03382d16008e225296ef34ac904b2ca1b53117ac42a9fc0_Z,
+           0x2395c3c6b29c382e472f8a8cdfe115387ee92f0e9effb0950884d447729378c_Z},
+          {0x49c91a07842eb149de925554f5cc911d0622477f621af42dc661b6254d92149_Z,
+           0x6f6df3d40a3257d8cb1030182345ee33275a9729736559ae2b9493e71b13f66_Z},
+          {0x5327c310ff48d8828030be496b732a5c8d4b54d6d33359a55bf86f73338648c_Z,
+           0xc5496b84211e0e6c6bfca22b6fe4a90c9ab4b74f38f5aef24063e19f33807f_Z},
+          {0xc29502e40237c3cdfb3df12402e00b10ecd34196deb16b9847d16f6a722ea6_Z,
+           0x39aac26bf68523e8d0f71f4fe3b57370abb5e93a88b0be25d51933b2ce00ef_Z},
+          {0x29e6ff0160618de648e497c1bd71e29fa85e64c5de6384131a45a680106899e_Z,
+           0x44489c972cd0206777be0e3d538a50dc41129e9ef6a573ffca463817c4b56b8_Z},
+          {0x7abdf663a32846ce3589e251644a0392ae0b2331e2ae9ceee2a8121bbe2f377_Z,
+           0x1a6887de903db96dba6ea4ed4da968bf62b9b7cfc0794caf3fbfd8cd2b4e79f_Z},
+          {0x57bdd25e214e6d5ac0fcff6d136d883b26a58357a80c2292e734f989568bfcd_Z,
+           0x21a4ba863a96efdde95da050be9d1fea4696bd9b9b9c658f2f89a6335aebffc_Z},
+          {0x639c23ba45096922137df71352eb293c33b69bb8cab211560fd74337dff135e_Z,
+           0x52b4abdfb9884ec8a5e36501042d7bae523ea25ad9cf727b68acb34ed30f9bd_Z},
+          {0x296a39feb0d8f12f0338d21bf5d4e41879a2f0bb4e1115cfdf302b0c193efb9_Z,
+           0x44111a6871525d015a8953659c0a25c843e73b6c831f3ce0fd61bacebd8645a_Z},
+          {0xe0a5fbd6a3040de8fd18a69512df864c9f9a6bac714dd89f63c16dc98d0edc_Z,
+           0x5cd6903683197c35e29a53fa9dc7b2dc108d6d5d4c4c1b61bf0a36dc28281f1_Z},
+          {0x35b6b70b29b725bdd4abfaa9016424455fef75cf5db8ac105349c22a6cf0c79_Z,
+           0x26257545e6c40ce862da7092d0078574b354960625f899bb0459c5fb457b040_Z},
+          {0x4ad230e998d6327668c6efb8da326c3cccfaab8318068ede5c35b785f466972_Z,
+           0x71d065349d2a37db9c8da8696d6537c83ec5ad9da66d445adc85ad6d14ad4b7_Z},
+          {0x4b623eea766641c914601bd0e0dcb05118b99b5be46fcf4016d43270fcbb75b_Z,
+           0x4f279640e187ad4a28be5ff7c3f3ae0784c65242f9d8dd8c075f441f0ce6931_Z},
+          {0x1113b3c7239bd09c41d279ad7152ccff0846d8205fa9e0d15bf61d18346f4f7_Z,
+           0x2724c2af72e534450ed6eba9441674693f54bd82b3f205ae4fbb27bc4fecb7_Z},
+      });
+  return *prime_field_ec0;
+}
+
+}  // namespace starkware
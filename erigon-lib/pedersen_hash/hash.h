@@ -0,0 +1,9 @@
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+int CHash(const char* in1, const char* in2, char* out);
+
+#ifdef __cplusplus
+}
+#endif
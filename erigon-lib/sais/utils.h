@@ -0,0 +1,5 @@
+#ifndef _UTILS_H
+#define _UTILS_H 1
+
+extern int lcp_kasai(const unsigned char *T, int *SA, int *LCP, int *FTR, int *INV, int sa_size, int n);
+#endif /* _UTILS_H */
@@ -0,0 +1,213 @@
+/*
+   Copyright 2021 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package txpool
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ledgerwatch/erigon-lib/common/length"
+	"github.com/ledgerwatch/erigon-lib/direct"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/sentry"
+	types2 "github.com/ledgerwatch/erigon-lib/types"
+	"github.com/ledgerwatch/log/v3"
+	"google.golang.org/grpc"
+)
+
+type SentryClient interface {
+	sentry.SentryClient
+	Protocol() uint
+}
+
+// Send - does send concrete P2P messages to Sentry. Same as Fetch but for outbound traffic
+// does not initiate any messages by self
+type Send struct {
+	ctx           context.Context
+	pool          Pool
+	wg            *sync.WaitGroup
+	sentryClients []direct.SentryClient // sentry clients that will be used for accessing the network
+}
+
+func NewSend(ctx context.Context, sentryClients []direct.SentryClient, pool Pool) *Send {
+	return &Send{
+		ctx:           ctx,
+		pool:          pool,
+		sentryClients: sentryClients,
+	}
+}
+
+func (f *Send) SetWaitGroup(wg *sync.WaitGroup) {
+	f.wg = wg
+}
+
+const (
+	// This is the target size for the packs of transactions or announcements. A
+	// pack can get larger than this if a single transactions exceeds this size.
+	p2pTxPacketLimit = 100 * 1024
+)
+
+func (f *Send) notifyTests() {
+	if f.wg != nil {
+		f.wg.Done()
+	}
+}
+
+func (f *Send) BroadcastPooledTxs(rlps [][]byte) (txSentTo []int) {
+	defer f.notifyTests()
+	if len(rlps) == 0 {
+		return
+	}
+	txSentTo = make([]int, len(rlps))
+	var prev, size int
+	for i, l := 0, len(rlps); i < len(rlps); i++ {
+		size += len(rlps[i])
+		if i == l-1 || size >= p2pTxPacketLimit {
+			txsData := types2.EncodeTransactions(rlps[prev:i+1], nil)
+			var txs66 *sentry.SendMessageToRandomPeersRequest
+			for _, sentryClient := range f.sentryClients {
+				if !sentryClient.Ready() {
+					continue
+				}
+				switch sentryClient.Protocol() {
+				case direct.ETH66, direct.ETH67, direct.ETH68:
+					if txs66 == nil {
+						txs66 = &sentry.SendMessageToRandomPeersRequest{
+							Data: &sentry.OutboundMessageData{
+								Id:   sentry.MessageId_TRANSACTIONS_66,
+								Data: txsData,
+							},
+							MaxPeers: 100,
+						}
+					}
+					peers, err := sentryClient.SendMessageToRandomPeers(f.ctx, txs66)
+					if err != nil {
+						log.Debug("[txpool.send] BroadcastPooledTxs", "err", err)
+					}
+					if peers != nil {
+						for j := prev; j <= i; j++ {
+							txSentTo[j] = len(peers.Peers)
+						}
+					}
+				}
+			}
+			prev = i + 1
+			size = 0
+		}
+	}
+	return
+}
+
+func (f *Send) AnnouncePooledTxs(types []byte, sizes []uint32, hashes types2.Hashes) (hashSentTo []int) {
+	defer f.notifyTests()
+	hashSentTo = make([]int, len(types))
+	prev := 0
+	for prev < len(types) {
+		// Announcements are chunked by transaction, so that types, sizes and hashes stay aligned for eth/68 peers
+		next := prev + p2pTxPacketLimit/length.Hash
+		if next > len(types) {
+			next = len(types)
+		}
+		pending := hashes[prev*length.Hash : next*length.Hash]
+
+		var hashes66, hashes68 *sentry.OutboundMessageData
+		for _, sentryClient := range f.sentryClients {
+			if !sentryClient.Ready() {
+				continue
+			}
+			var req *sentry.OutboundMessageData
+			switch sentryClient.Protocol() {
+			case direct.ETH66, direct.ETH67:
+				if hashes66 == nil {
+					hashes66 = &sentry.OutboundMessageData{
+						Id:   sentry.MessageId_NEW_POOLED_TRANSACTION_HASHES_66,
+						Data: types2.EncodeHashes(pending, nil),
+					}
+				}
+				req = hashes66
+			case direct.ETH68:
+				if hashes68 == nil {
+					hashes68 = &sentry.OutboundMessageData{
+						Id:   sentry.MessageId_NEW_POOLED_TRANSACTION_HASHES_68,
+						Data: types2.EncodeAnnouncements(types[prev:next], sizes[prev:next], pending, nil),
+					}
+				}
+				req = hashes68
+			default:
+				continue
+			}
+			peers, err := sentryClient.SendMessageToAll(f.ctx, req, &grpc.EmptyCallOption{})
+			if err != nil {
+				log.Debug("[txpool.send] AnnouncePooledTxs", "err", err)
+			}
+			if peers != nil {
+				for j := prev; j < next; j++ {
+					hashSentTo[j] = len(peers.Peers)
+				}
+			}
+		}
+		prev = next
+	}
+	return
+}
+
+func (f *Send) PropagatePooledTxsToPeersList(peers []types2.PeerID, types []byte, sizes []uint32, hashes []byte) {
+	defer f.notifyTests()
+
+	for prev := 0; prev < len(types); {
+		next := prev + p2pTxPacketLimit/length.Hash
+		if next > len(types) {
+			next = len(types)
+		}
+		pending := hashes[prev*length.Hash : next*length.Hash]
+
+		var data66, data68 []byte
+		for _, sentryClient := range f.sentryClients {
+			if !sentryClient.Ready() {
+				continue
+			}
+
+			var data *sentry.OutboundMessageData
+			switch sentryClient.Protocol() {
+			case direct.ETH66, direct.ETH67:
+				if data66 == nil {
+					data66 = types2.EncodeHashes(pending, nil)
+				}
+				data = &sentry.OutboundMessageData{
+					Id:   sentry.MessageId_NEW_POOLED_TRANSACTION_HASHES_66,
+					Data: data66,
+				}
+			case direct.ETH68:
+				if data68 == nil {
+					data68 = types2.EncodeAnnouncements(types[prev:next], sizes[prev:next], pending, nil)
+				}
+				data = &sentry.OutboundMessageData{
+					Id:   sentry.MessageId_NEW_POOLED_TRANSACTION_HASHES_68,
+					Data: data68,
+				}
+			default:
+				continue
+			}
+			for _, peer := range peers {
+				req := &sentry.SendMessageByIdRequest{PeerId: peer, Data: data}
+				if _, err := sentryClient.SendMessageById(f.ctx, req, &grpc.EmptyCallOption{}); err != nil {
+					log.Debug("[txpool.send] PropagatePooledTxsToPeersList", "err", err)
+				}
+			}
+		}
+		prev = next
+	}
+}
@@ -87,8 +87,10 @@ import (
 	"github.com/ledgerwatch/erigon/eth/ethconsensusconfig"
 	"github.com/ledgerwatch/erigon/eth/ethutils"
 	"github.com/ledgerwatch/erigon/eth/protocols/eth"
+	snapproto "github.com/ledgerwatch/erigon/eth/protocols/snap"
 	"github.com/ledgerwatch/erigon/eth/stagedsync"
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/eth/stateaudit"
 	"github.com/ledgerwatch/erigon/ethdb/privateapi"
 	"github.com/ledgerwatch/erigon/ethdb/prune"
 	"github.com/ledgerwatch/erigon/ethstats"
@@ -146,6 +148,7 @@ type Ethereum struct {
 	unsubscribeEthstat func()
 
 	waitForStageLoopStop chan struct{}
+	unwindRequests       *stages2.UnwindRequests
 	waitForMiningStop    chan struct{}
 
 	txPool2DB               kv.RwDB
@@ -296,6 +299,9 @@ func New(stack *node.Node, config *ethconfig.Config, logger log.Logger) (*Ethere
 
 	var sentries []direct.SentryClient
 	if len(stack.Config().P2P.SentryAddr) > 0 {
+		if stack.Config().P2P.SnapServer {
+			log.Warn("snap/1 can only be served by the internal sentries, ignoring it")
+		}
 		for _, addr := range stack.Config().P2P.SentryAddr {
 			sentryClient, err := sentry.GrpcClient(backend.sentryCtx, addr)
 			if err != nil {
@@ -325,6 +331,15 @@ func New(stack *node.Node, config *ethconfig.Config, logger log.Logger) (*Ethere
 			return nil, err
 		}
 
+		// The egress limit and the capture are for all the sentries together
+		egressLimiter := sentry.NewEgressLimiter(refCfg.MaxEgressRate)
+		var capture *sentry.Capture
+		if refCfg.CaptureDir != "" {
+			if capture, err = sentry.OpenCapture(refCfg.CaptureDir, refCfg.CaptureFileSize, refCfg.CaptureFiles); err != nil {
+				return nil, err
+			}
+		}
+
 		var pi int // points to next port to be picked from refCfg.AllowedPorts
 		for _, protocol := range refCfg.ProtocolVersion {
 			cfg := refCfg
@@ -352,6 +367,11 @@ func New(stack *node.Node, config *ethconfig.Config, logger log.Logger) (*Ethere
 			cfg.ListenAddr = fmt.Sprintf("%s:%d", listenHost, listenPort)
 
 			server := sentry.NewGrpcServer(backend.sentryCtx, discovery, readNodeInfo, &cfg, protocol)
+			server.EgressLimiter = egressLimiter
+			server.Capture = capture
+			if cfg.SnapServer {
+				server.SatelliteProtocols = append(server.SatelliteProtocols, snapproto.NewProtocol(backend.sentryCtx, chainKv))
+			}
 			backend.sentryServers = append(backend.sentryServers, server)
 			sentries = append(sentries, direct.NewSentryClientDirect(protocol, server))
 		}
@@ -692,6 +712,13 @@ func New(stack *node.Node, config *ethconfig.Config, logger log.Logger) (*Ethere
 	}
 	apiList := commands.APIList(chainKv, borDb, ethRpcClient, txPoolRpcClient, miningRpcClient, ff, stateCache, blockReader, backend.agg, httpRpcCfg, backend.engine)
 	authApiList := commands.AuthAPIList(chainKv, ethRpcClient, txPoolRpcClient, miningRpcClient, ff, stateCache, blockReader, backend.agg, httpRpcCfg, backend.engine)
+	backend.unwindRequests = stages2.NewUnwindRequests(chainKv, allSnapshots)
+	authApiList = append(authApiList, rpc.API{
+		Namespace: "debug",
+		Public:    true,
+		Service:   stages2.NewSetHeadAPI(backend.unwindRequests),
+		Version:   "1.0",
+	})
 	go func() {
 		if err := cli.StartRpcServer(ctx, httpRpcCfg, apiList, authApiList); err != nil {
 			log.Error(err.Error())
@@ -699,6 +726,10 @@ func New(stack *node.Node, config *ethconfig.Config, logger log.Logger) (*Ethere
 		}
 	}()
 
+	if config.StateAuditInterval > 0 {
+		go stateaudit.Loop(ctx, chainKv, blockReader, tmpdir, config.StateAuditInterval)
+	}
+
 	// Register the backend on the node
 	stack.RegisterLifecycle(backend)
 	return backend, nil
@@ -1001,7 +1032,7 @@ func (s *Ethereum) Start() error {
 	s.sentriesClient.StartStreamLoops(s.sentryCtx)
 	time.Sleep(10 * time.Millisecond) // just to reduce logs order confusion
 
-	go stages2.StageLoop(s.sentryCtx, s.chainConfig, s.chainDB, s.stagedSync, s.sentriesClient.Hd, s.notifications, s.sentriesClient.UpdateHead, s.waitForStageLoopStop, s.config.Sync.LoopThrottle)
+	go stages2.StageLoop(s.sentryCtx, s.chainConfig, s.chainDB, s.stagedSync, s.sentriesClient.Hd, s.notifications, s.sentriesClient.UpdateHead, s.waitForStageLoopStop, s.config.Sync.LoopThrottle, s.unwindRequests)
 
 	return nil
 }
@@ -235,6 +235,8 @@ type Config struct {
 	WithoutHeimdall bool
 	// Ethstats service
 	Ethstats string
+	// StateAuditInterval - how often to recompute the state root from the plain state and compare it with the header, 0 disables
+	StateAuditInterval time.Duration
 	// Consensus layer
 	ExternalCL                  bool
 	LightClientDiscoveryAddr    string
@@ -255,6 +257,9 @@ type Sync struct {
 
 	BlockDownloaderWindow      int
 	BodyDownloadTimeoutSeconds int // TODO: change to duration
+
+	// ServeRecentBlocks limits the block bodies and receipts served to peers to this many most recent blocks, 0 serves all
+	ServeRecentBlocks uint64
 }
 
 // Chains where snapshots are enabled by default
@@ -142,3 +142,24 @@ func mockWithGenerator(t *testing.T, blocks int, generator func(int, *core.Block
 	}
 	return m
 }
+
+func TestAnswerGetBlockBodiesQueryRecent(t *testing.T) {
+	m := mockWithGenerator(t, 4, nil)
+	tx, err := m.DB.BeginRo(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	var hashes []common.Hash
+	for i := uint64(0); i <= 4; i++ {
+		hashes = append(hashes, rawdb.ReadHeaderByNumber(tx, i).Hash())
+	}
+	require.Len(t, eth.AnswerGetBlockBodiesQuery(tx, hashes, 0), 5)
+	// Older blocks are left out
+	bodies := eth.AnswerGetBlockBodiesQuery(tx, hashes, 3)
+	require.Len(t, bodies, 2)
+	require.Equal(t, rlp.RawValue(rawdb.ReadBodyRLP(tx, hashes[3], 3)), bodies[0])
+
+	receipts, err := eth.AnswerGetReceiptsQuery(tx, hashes, 3)
+	require.NoError(t, err)
+	require.Len(t, receipts, 2)
+}
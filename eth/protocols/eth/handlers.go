@@ -131,7 +131,8 @@ func AnswerGetBlockHeadersQuery(db kv.Tx, query *GetBlockHeadersPacket, blockRea
 	return headers, nil
 }
 
-func AnswerGetBlockBodiesQuery(db kv.Tx, query GetBlockBodiesPacket) []rlp.RawValue { //nolint:unparam
+// AnswerGetBlockBodiesQuery returns the requested bodies, skipping unknown blocks and the ones below minBlock.
+func AnswerGetBlockBodiesQuery(db kv.Tx, query GetBlockBodiesPacket, minBlock uint64) []rlp.RawValue { //nolint:unparam
 	// Gather blocks until the fetch or network limits is reached
 	var bytes int
 	bodies := make([]rlp.RawValue, 0, len(query))
@@ -142,7 +143,7 @@ func AnswerGetBlockBodiesQuery(db kv.Tx, query GetBlockBodiesPacket) []rlp.RawVa
 			break
 		}
 		number := rawdb.ReadHeaderNumber(db, hash)
-		if number == nil {
+		if number == nil || *number < minBlock {
 			continue
 		}
 		canonicalHash, err := rawdb.ReadCanonicalHash(db, *number)
@@ -164,7 +165,8 @@ func AnswerGetBlockBodiesQuery(db kv.Tx, query GetBlockBodiesPacket) []rlp.RawVa
 	return bodies
 }
 
-func AnswerGetReceiptsQuery(db kv.Tx, query GetReceiptsPacket) ([]rlp.RawValue, error) { //nolint:unparam
+// AnswerGetReceiptsQuery returns the requested receipts, skipping unknown blocks and the ones below minBlock.
+func AnswerGetReceiptsQuery(db kv.Tx, query GetReceiptsPacket, minBlock uint64) ([]rlp.RawValue, error) { //nolint:unparam
 	// Gather state data until the fetch or network limits is reached
 	var (
 		bytes    int
@@ -175,6 +177,11 @@ func AnswerGetReceiptsQuery(db kv.Tx, query GetReceiptsPacket) ([]rlp.RawValue,
 			lookups >= 2*maxReceiptsServe {
 			break
 		}
+		if minBlock > 0 {
+			if number := rawdb.ReadHeaderNumber(db, hash); number == nil || *number < minBlock {
+				continue
+			}
+		}
 		// Retrieve the requested block's receipts
 		results, err := rawdb.ReadReceiptsByHash(db, hash)
 		if err != nil {
@@ -281,3 +281,26 @@ func TestDecodePooledTx(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestNewPooledTransactionHashesPacket68(t *testing.T) {
+	var hash common.Hash
+	for i := range hash {
+		hash[i] = 0x11
+	}
+	packet := &NewPooledTransactionHashesPacket68{
+		Types:  []byte{types.DynamicFeeTxType},
+		Sizes:  []uint32{300},
+		Hashes: []common.Hash{hash},
+	}
+	encoded, err := rlp.EncodeToBytes(packet)
+	assert.NoError(t, err)
+	assert.Equal(t, common.FromHex("e702c382012ce1a0"+common.Bytes2Hex(hash[:])), encoded)
+
+	var decoded NewPooledTransactionHashesPacket68
+	assert.NoError(t, rlp.DecodeBytes(encoded, &decoded))
+	assert.Equal(t, packet, &decoded)
+	assert.NoError(t, decoded.Validate())
+
+	decoded.Sizes = append(decoded.Sizes, 1)
+	assert.Error(t, decoded.Validate())
+}
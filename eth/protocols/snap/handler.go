@@ -0,0 +1,554 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/common/dbutils"
+	"github.com/ledgerwatch/erigon/common/hexutil"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+	"github.com/ledgerwatch/erigon/eth/protocols/eth"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/p2p"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/turbo/trie"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// softResponseLimit is the target maximum size of replies to data retrievals.
+	softResponseLimit = 2 * 1024 * 1024
+
+	// maxCodeLookups is the maximum number of bytecodes to serve. This number is
+	// there to limit the number of disk lookups.
+	maxCodeLookups = 1024
+
+	// maxTrieNodeLookups is the maximum number of state trie nodes to serve. This
+	// number is there to limit the number of disk lookups.
+	maxTrieNodeLookups = 1024
+
+	// maxAccountLookups is the maximum number of accounts to serve in a range. Every
+	// account is resolved through the state trie, so this limits the trie loaded
+	// for a request.
+	maxAccountLookups = 4096
+
+	// trieLoadsPerSecond and trieLoadsBurst limit how often the state trie is
+	// loaded for the requests of one peer, loading it walks the state tables.
+	trieLoadsPerSecond = 2
+	trieLoadsBurst     = 4
+
+	// stateLookupSlack defines the ratio by how much a state response can exceed
+	// the requested limit in order to try and avoid breaking up contracts into
+	// multiple packages and proving them.
+	stateLookupSlack = 0.1
+)
+
+// NewProtocol returns the snap/1 protocol, serving the state held in the flat tables of db.
+func NewProtocol(ctx context.Context, db kv.RoDB) p2p.Protocol {
+	return p2p.Protocol{
+		Name:    ProtocolName,
+		Version: SNAP1,
+		Length:  ProtocolLength,
+		Run: func(peer *p2p.Peer, rw p2p.MsgReadWriter) error {
+			// snap is a satellite protocol, it is only served to the peers running eth as well
+			if !peer.RunningCap(eth.ProtocolName, []uint{eth.ETH66, eth.ETH67, eth.ETH68}) {
+				return errNoEth
+			}
+			return Handle(ctx, db, rw)
+		},
+	}
+}
+
+// Handle serves the snap requests of a peer until the connection is torn down.
+func Handle(ctx context.Context, db kv.RoDB, rw p2p.MsgReadWriter) error {
+	trieLoads := rate.NewLimiter(trieLoadsPerSecond, trieLoadsBurst)
+	for {
+		if err := HandleMessage(ctx, db, rw, trieLoads); err != nil {
+			return err
+		}
+	}
+}
+
+// HandleMessage reads the next request of a peer and answers it. Erigon only serves snap,
+// so responses are unexpected and end the connection. The requests which load the state
+// trie wait for trieLoads, unless it is nil.
+func HandleMessage(ctx context.Context, db kv.RoDB, rw p2p.MsgReadWriter, trieLoads *rate.Limiter) error {
+	msg, err := rw.ReadMsg()
+	if err != nil {
+		return err
+	}
+	defer msg.Discard()
+	if msg.Size > maxMessageSize {
+		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxMessageSize)
+	}
+
+	switch msg.Code {
+	case GetAccountRangeMsg:
+		var req GetAccountRangePacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		if err := waitTrieLoad(ctx, trieLoads); err != nil {
+			return err
+		}
+		res := &AccountRangePacket{ID: req.ID}
+		if err := db.View(ctx, func(tx kv.Tx) (err error) {
+			res.Accounts, res.Proof, err = ServiceGetAccountRangeQuery(tx, &req)
+			return err
+		}); err != nil {
+			return err
+		}
+		return p2p.Send(rw, AccountRangeMsg, res)
+
+	case GetStorageRangesMsg:
+		var req GetStorageRangesPacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		if err := waitTrieLoad(ctx, trieLoads); err != nil {
+			return err
+		}
+		res := &StorageRangesPacket{ID: req.ID}
+		if err := db.View(ctx, func(tx kv.Tx) (err error) {
+			res.Slots, res.Proof, err = ServiceGetStorageRangesQuery(tx, &req)
+			return err
+		}); err != nil {
+			return err
+		}
+		return p2p.Send(rw, StorageRangesMsg, res)
+
+	case GetByteCodesMsg:
+		var req GetByteCodesPacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		res := &ByteCodesPacket{ID: req.ID}
+		if err := db.View(ctx, func(tx kv.Tx) (err error) {
+			res.Codes, err = ServiceGetByteCodesQuery(tx, &req)
+			return err
+		}); err != nil {
+			return err
+		}
+		return p2p.Send(rw, ByteCodesMsg, res)
+
+	case GetTrieNodesMsg:
+		var req GetTrieNodesPacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		if err := waitTrieLoad(ctx, trieLoads); err != nil {
+			return err
+		}
+		res := &TrieNodesPacket{ID: req.ID}
+		if err := db.View(ctx, func(tx kv.Tx) (err error) {
+			res.Nodes, err = ServiceGetTrieNodesQuery(tx, &req)
+			return err
+		}); err != nil {
+			return err
+		}
+		return p2p.Send(rw, TrieNodesMsg, res)
+
+	default:
+		return fmt.Errorf("%w: %v", errInvalidMsgCode, msg.Code)
+	}
+}
+
+// waitTrieLoad blocks the handling of the peer until it may have the state trie loaded again.
+func waitTrieLoad(ctx context.Context, trieLoads *rate.Limiter) error {
+	if trieLoads == nil {
+		return nil
+	}
+	return trieLoads.Wait(ctx)
+}
+
+// servedStateRoot returns the state root which can be served, or an empty hash if there is none. Erigon only keeps
+// the latest state, and the state tables only match a block header once hashing and the intermediate trie hashes
+// caught up with execution.
+func servedStateRoot(tx kv.Tx) (common.Hash, error) {
+	execution, err := stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hashState, err := stages.GetStageProgress(tx, stages.HashState)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	intermediateHashes, err := stages.GetStageProgress(tx, stages.IntermediateHashes)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if hashState != execution || intermediateHashes != execution {
+		return common.Hash{}, nil
+	}
+	hash, err := rawdb.ReadCanonicalHash(tx, execution)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	header := rawdb.ReadHeader(tx, hash, execution)
+	if header == nil {
+		return common.Hash{}, nil
+	}
+	return header.Root, nil
+}
+
+// loadTrie builds the parts of the state trie leading to the retained keys.
+func loadTrie(tx kv.Tx, rl *trie.RetainList, root common.Hash) (*trie.Trie, error) {
+	loader := trie.NewFlatDBTrieLoader("snap")
+	if err := loader.Reset(rl, nil, nil, false); err != nil {
+		return nil, err
+	}
+	t, err := loader.CalcTrie(tx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if t.Hash() != root {
+		return nil, fmt.Errorf("state root mismatch: expected %x, got %x", root, t.Hash())
+	}
+	return t, nil
+}
+
+// readIncarnation returns the incarnation of the storage of an account, 0 if the account has no storage.
+func readIncarnation(tx kv.Tx, addrHash common.Hash) (uint64, error) {
+	enc, err := tx.GetOne(kv.HashedAccounts, addrHash[:])
+	if err != nil || len(enc) == 0 {
+		return 0, err
+	}
+	var acc accounts.Account
+	if err := acc.DecodeForStorage(enc); err != nil {
+		return 0, err
+	}
+	return acc.Incarnation, nil
+}
+
+// appendProof adds the nodes of a proof which are not part of the list yet.
+func appendProof(nodes [][]byte, proof [][]byte) [][]byte {
+	for _, node := range proof {
+		known := false
+		for _, n := range nodes {
+			if bytes.Equal(n, node) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// ServiceGetAccountRangeQuery assembles the response to an account range query.
+func ServiceGetAccountRangeQuery(tx kv.Tx, req *GetAccountRangePacket) ([]*AccountData, [][]byte, error) {
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	root, err := servedStateRoot(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if root == (common.Hash{}) || root != req.Root {
+		return nil, nil, nil
+	}
+	c, err := tx.Cursor(kv.HashedAccounts)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer c.Close()
+
+	// The storage roots are not part of the flat accounts, so collect the range first and
+	// read the account bodies from the trie built for the proofs.
+	rl := trie.NewRetainList(0)
+	rl.AddKey(req.Origin[:])
+	var (
+		keys []common.Hash
+		size uint64
+	)
+	for k, v, err := c.Seek(req.Origin[:]); k != nil; k, v, err = c.Next() {
+		if err != nil {
+			return nil, nil, err
+		}
+		hash := common.BytesToHash(k)
+		keys = append(keys, hash)
+		rl.AddKey(hash[:])
+		// Flat accounts miss the storage root, which is only counted for contracts
+		size += uint64(common.HashLength + len(v) + common.HashLength)
+		if bytes.Compare(hash[:], req.Limit[:]) >= 0 || size > req.Bytes || len(keys) >= maxAccountLookups {
+			break
+		}
+	}
+	t, err := loadTrie(tx, rl, root)
+	if err != nil {
+		return nil, nil, err
+	}
+	accs := make([]*AccountData, 0, len(keys))
+	for _, key := range keys {
+		acc, ok := t.GetAccount(key[:])
+		if !ok || acc == nil {
+			return nil, nil, fmt.Errorf("account %x missing from the state trie", key)
+		}
+		body, err := slimAccountRLP(acc)
+		if err != nil {
+			return nil, nil, err
+		}
+		accs = append(accs, &AccountData{Hash: key, Body: body})
+	}
+	// Generate the Merkle proofs for the first and last account
+	proof, err := t.Prove(req.Origin[:], 0, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(keys) > 0 {
+		lastProof, err := t.Prove(keys[len(keys)-1][:], 0, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		proof = appendProof(proof, lastProof)
+	}
+	return accs, proof, nil
+}
+
+// ServiceGetStorageRangesQuery assembles the response to a storage ranges query.
+func ServiceGetStorageRangesQuery(tx kv.Tx, req *GetStorageRangesPacket) ([][]*StorageData, [][]byte, error) {
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	root, err := servedStateRoot(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if root == (common.Hash{}) || root != req.Root {
+		return nil, nil, nil
+	}
+	c, err := tx.CursorDupSort(kv.HashedStorage)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer c.Close()
+
+	// Allow a bit of slack so that whole contracts can be served without proofs
+	hardLimit := uint64(float64(req.Bytes) * (1 + stateLookupSlack))
+	var (
+		slots [][]*StorageData
+		proof [][]byte
+		size  uint64
+	)
+	for _, account := range req.Accounts {
+		// If we've exceeded the requested data limit, abort without opening
+		// a new storage range (that we'd need to prove due to exceeded size)
+		if size >= req.Bytes {
+			break
+		}
+		// The first account might start from a different origin and end sooner
+		var origin common.Hash
+		if len(req.Origin) > 0 {
+			origin, req.Origin = common.BytesToHash(req.Origin), nil
+		}
+		var limit = common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+		if len(req.Limit) > 0 {
+			limit, req.Limit = common.BytesToHash(req.Limit), nil
+		}
+		incarnation, err := readIncarnation(tx, account)
+		if err != nil {
+			return nil, nil, err
+		}
+		var (
+			storage []*StorageData
+			abort   bool
+		)
+		if incarnation > 0 {
+			prefix := dbutils.GenerateStoragePrefix(account[:], incarnation)
+			for v, err := c.SeekBothRange(prefix, origin[:]); v != nil; _, v, err = c.NextDup() {
+				if err != nil {
+					return nil, nil, err
+				}
+				if size >= hardLimit {
+					abort = true
+					break
+				}
+				body, err := rlp.EncodeToBytes(v[common.HashLength:])
+				if err != nil {
+					return nil, nil, err
+				}
+				hash := common.BytesToHash(v[:common.HashLength])
+				size += uint64(common.HashLength + len(body))
+				storage = append(storage, &StorageData{Hash: hash, Body: body})
+				if bytes.Compare(hash[:], limit[:]) >= 0 {
+					break
+				}
+			}
+		}
+		if len(storage) > 0 {
+			slots = append(slots, storage)
+		}
+		// Generate the Merkle proofs for the first and last storage slot, but
+		// only if the response was capped. If the entire storage trie included
+		// in the response, no need for any proofs.
+		if origin != (common.Hash{}) || (abort && len(storage) > 0) {
+			keys := []common.Hash{origin}
+			if len(storage) > 0 {
+				keys = append(keys, storage[len(storage)-1].Hash)
+			}
+			if proof, err = storageProof(tx, root, account, incarnation, keys); err != nil {
+				return nil, nil, err
+			}
+			// Proof terminates the reply as proofs are only added if a node
+			// refuses to serve more data (exception when a contract fetch is
+			// finishing, but that's that).
+			break
+		}
+	}
+	return slots, proof, nil
+}
+
+// storageProof proves the given storage slots of an account.
+func storageProof(tx kv.Tx, root common.Hash, account common.Hash, incarnation uint64, keys []common.Hash) ([][]byte, error) {
+	rl := trie.NewRetainList(0)
+	rl.AddKey(account[:])
+	for _, key := range keys {
+		rl.AddKey(append(dbutils.GenerateStoragePrefix(account[:], incarnation), key[:]...))
+	}
+	t, err := loadTrie(tx, rl, root)
+	if err != nil {
+		return nil, err
+	}
+	var proof [][]byte
+	for _, key := range keys {
+		p, err := t.Prove(append(common.CopyBytes(account[:]), key[:]...), 2*common.HashLength, true)
+		if err != nil {
+			return nil, err
+		}
+		proof = appendProof(proof, p)
+	}
+	return proof, nil
+}
+
+// ServiceGetByteCodesQuery assembles the response to a byte codes query.
+func ServiceGetByteCodesQuery(tx kv.Tx, req *GetByteCodesPacket) ([][]byte, error) {
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	if len(req.Hashes) > maxCodeLookups {
+		req.Hashes = req.Hashes[:maxCodeLookups]
+	}
+	var (
+		codes [][]byte
+		size  uint64
+	)
+	for _, hash := range req.Hashes {
+		if hash == trie.EmptyCodeHash {
+			// Peers should not request the empty code, but if they do, at
+			// least sent them back a correct response without db lookups
+			codes = append(codes, []byte{})
+			continue
+		}
+		code, err := tx.GetOne(kv.Code, hash[:])
+		if err != nil {
+			return nil, err
+		}
+		if len(code) > 0 {
+			codes = append(codes, common.CopyBytes(code))
+			size += uint64(len(code))
+		}
+		if size > req.Bytes {
+			break
+		}
+	}
+	return codes, nil
+}
+
+// ServiceGetTrieNodesQuery assembles the response to a trie nodes query.
+func ServiceGetTrieNodesQuery(tx kv.Tx, req *GetTrieNodesPacket) ([][]byte, error) {
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	root, err := servedStateRoot(tx)
+	if err != nil {
+		return nil, err
+	}
+	if root == (common.Hash{}) || root != req.Root {
+		return nil, nil
+	}
+	// Collect the paths first, so that a single trie load resolves all of them
+	type lookup struct {
+		account []byte
+		path    []byte
+	}
+	var lookups []lookup
+	rl := trie.NewRetainList(0)
+	for _, pathset := range req.Paths {
+		if len(lookups) >= maxTrieNodeLookups {
+			break
+		}
+		switch len(pathset) {
+		case 0:
+			return nil, fmt.Errorf("%w: zero-item pathset requested", errBadRequest)
+		case 1:
+			path := trie.CompactToHex(pathset[0])
+			rl.AddHex(path)
+			lookups = append(lookups, lookup{path: path})
+		default:
+			account := common.BytesToHash(pathset[0])
+			incarnation, err := readIncarnation(tx, account)
+			if err != nil {
+				return nil, err
+			}
+			if incarnation == 0 {
+				continue
+			}
+			var prefix []byte
+			hexutil.DecompressNibbles(dbutils.GenerateStoragePrefix(account[:], incarnation), &prefix)
+			for _, p := range pathset[1:] {
+				if len(lookups) >= maxTrieNodeLookups {
+					break
+				}
+				path := trie.CompactToHex(p)
+				rl.AddHex(append(common.CopyBytes(prefix), path...))
+				lookups = append(lookups, lookup{account: account[:], path: path})
+			}
+		}
+	}
+	t, err := loadTrie(tx, rl, root)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		nodes [][]byte
+		size  uint64
+	)
+	for _, l := range lookups {
+		node, err := t.NodeRLP(l.account, l.path)
+		if err != nil {
+			return nil, err
+		}
+		// Nodes are matched to the requests by position, stop at the first unknown one
+		if node == nil {
+			break
+		}
+		nodes = append(nodes, node)
+		size += uint64(len(node))
+		if size > req.Bytes {
+			break
+		}
+	}
+	return nodes, nil
+}
@@ -0,0 +1,221 @@
+package snap
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/p2p"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/turbo/trie"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+const (
+	testAccounts = 100
+	testSlots    = 50
+)
+
+var testCode = []byte{0x60, 0x00, 0x60, 0x00, 0xf3}
+
+// newTestState writes a state with one contract to the hashed state tables, and a head block with its root.
+func newTestState(t *testing.T) (kv.RwTx, common.Hash, common.Hash) {
+	db := memdb.NewTestDB(t)
+	tx, err := db.BeginRw(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(tx.Rollback)
+
+	contract := crypto.Keccak256Hash([]byte{0})
+	for i := 0; i < testAccounts; i++ {
+		addrHash := crypto.Keccak256Hash([]byte{byte(i)})
+		acc := accounts.NewAccount()
+		acc.Initialised = true
+		acc.Nonce = uint64(i)
+		acc.Balance.SetUint64(uint64(i) * 1000)
+		if addrHash == contract {
+			acc.Incarnation = 1
+			acc.CodeHash = crypto.Keccak256Hash(testCode)
+			require.NoError(t, tx.Put(kv.Code, acc.CodeHash[:], testCode))
+			for j := 0; j < testSlots; j++ {
+				k := make([]byte, 72)
+				copy(k, addrHash[:])
+				binary.BigEndian.PutUint64(k[32:], acc.Incarnation)
+				storageHash := crypto.Keccak256Hash([]byte{byte(j)})
+				copy(k[40:], storageHash[:])
+				require.NoError(t, tx.Put(kv.HashedStorage, k, []byte{byte(j + 1)}))
+			}
+		}
+		buf := make([]byte, acc.EncodingLengthForStorage())
+		acc.EncodeForStorage(buf)
+		require.NoError(t, tx.Put(kv.HashedAccounts, addrHash[:], buf))
+	}
+	root, err := trie.CalcRoot("test", tx)
+	require.NoError(t, err)
+
+	header := &types.Header{Number: big.NewInt(1), Root: root, Difficulty: big.NewInt(1)}
+	rawdb.WriteHeader(tx, header)
+	require.NoError(t, rawdb.WriteCanonicalHash(tx, header.Hash(), 1))
+	for _, stage := range []stages.SyncStage{stages.Execution, stages.HashState, stages.IntermediateHashes} {
+		require.NoError(t, stages.SaveStageProgress(tx, stage, 1))
+	}
+	return tx, root, contract
+}
+
+func TestServiceGetAccountRangeQuery(t *testing.T) {
+	tx, root, contract := newTestState(t)
+	maxHash := common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+	accs, proof, err := ServiceGetAccountRangeQuery(tx, &GetAccountRangePacket{Root: root, Limit: maxHash, Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Len(t, accs, testAccounts)
+	require.Equal(t, root, crypto.Keccak256Hash(proof[0]))
+	for i := 1; i < len(accs); i++ {
+		require.Less(t, accs[i-1].Hash.String(), accs[i].Hash.String())
+	}
+	for _, acc := range accs {
+		var slim slimAccount
+		require.NoError(t, rlp.DecodeBytes(acc.Body, &slim))
+		if acc.Hash == contract {
+			require.Len(t, slim.Root, 32)
+			require.Equal(t, crypto.Keccak256(testCode), slim.CodeHash)
+		} else {
+			require.Empty(t, slim.Root)
+			require.Empty(t, slim.CodeHash)
+		}
+	}
+
+	// The size limit caps the response
+	accs, proof, err = ServiceGetAccountRangeQuery(tx, &GetAccountRangePacket{Root: root, Origin: accs[10].Hash, Limit: maxHash, Bytes: 1})
+	require.NoError(t, err)
+	require.Len(t, accs, 1)
+	require.NotEmpty(t, proof)
+
+	// Other state roots are not served
+	accs, proof, err = ServiceGetAccountRangeQuery(tx, &GetAccountRangePacket{Root: common.HexToHash("0x01"), Limit: maxHash, Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Empty(t, accs)
+	require.Empty(t, proof)
+
+	// Neither is a state which is being executed
+	require.NoError(t, stages.SaveStageProgress(tx, stages.Execution, 2))
+	accs, _, err = ServiceGetAccountRangeQuery(tx, &GetAccountRangePacket{Root: root, Limit: maxHash, Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Empty(t, accs)
+}
+
+func TestServiceGetStorageRangesQuery(t *testing.T) {
+	tx, root, contract := newTestState(t)
+
+	// A whole contract needs no proof
+	slots, proof, err := ServiceGetStorageRangesQuery(tx, &GetStorageRangesPacket{Root: root, Accounts: []common.Hash{contract, crypto.Keccak256Hash([]byte{1})}, Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Len(t, slots, 1)
+	require.Len(t, slots[0], testSlots)
+	require.Empty(t, proof)
+
+	// A range starting at an origin is proven
+	slots, proof, err = ServiceGetStorageRangesQuery(tx, &GetStorageRangesPacket{Root: root, Accounts: []common.Hash{contract}, Origin: slots[0][5].Hash[:], Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Len(t, slots, 1)
+	require.Len(t, slots[0], testSlots-5)
+	require.NotEmpty(t, proof)
+
+	accs, _, err := ServiceGetAccountRangeQuery(tx, &GetAccountRangePacket{Root: root, Origin: contract, Limit: contract, Bytes: softResponseLimit})
+	require.NoError(t, err)
+	var slim slimAccount
+	require.NoError(t, rlp.DecodeBytes(accs[0].Body, &slim))
+	require.Equal(t, slim.Root, crypto.Keccak256(proof[0]))
+}
+
+func TestServiceGetByteCodesQuery(t *testing.T) {
+	tx, _, _ := newTestState(t)
+	codes, err := ServiceGetByteCodesQuery(tx, &GetByteCodesPacket{
+		Hashes: []common.Hash{crypto.Keccak256Hash(testCode), trie.EmptyCodeHash, common.HexToHash("0x01")},
+		Bytes:  softResponseLimit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{testCode, {}}, codes)
+}
+
+func TestServiceGetTrieNodesQuery(t *testing.T) {
+	tx, root, contract := newTestState(t)
+	rootPath := []byte{0}
+	nodes, err := ServiceGetTrieNodesQuery(tx, &GetTrieNodesPacket{
+		Root:  root,
+		Paths: []TrieNodePathSet{{rootPath}, {contract[:], rootPath}},
+		Bytes: softResponseLimit,
+	})
+	require.NoError(t, err)
+	require.Len(t, nodes, 2)
+	require.Equal(t, root, crypto.Keccak256Hash(nodes[0]))
+
+	_, proof, err := ServiceGetStorageRangesQuery(tx, &GetStorageRangesPacket{Root: root, Accounts: []common.Hash{contract}, Origin: common.Hash{1}.Bytes(), Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Equal(t, proof[0], nodes[1])
+
+	_, err = ServiceGetTrieNodesQuery(tx, &GetTrieNodesPacket{Root: root, Paths: []TrieNodePathSet{{}}, Bytes: softResponseLimit})
+	require.ErrorIs(t, err, errBadRequest)
+}
+
+func TestHandleMessage(t *testing.T) {
+	db := memdb.NewTestDB(t)
+	tx, err := db.BeginRw(context.Background())
+	require.NoError(t, err)
+	hash := crypto.Keccak256Hash(testCode)
+	require.NoError(t, tx.Put(kv.Code, hash[:], testCode))
+	require.NoError(t, tx.Commit())
+
+	peer, server := p2p.MsgPipe()
+	defer peer.Close()
+	errc := make(chan error, 1)
+	go func() { errc <- Handle(context.Background(), db, server) }()
+
+	require.NoError(t, p2p.Send(peer, GetByteCodesMsg, &GetByteCodesPacket{ID: 7, Hashes: []common.Hash{hash}, Bytes: softResponseLimit}))
+	require.NoError(t, p2p.ExpectMsg(peer, ByteCodesMsg, &ByteCodesPacket{ID: 7, Codes: [][]byte{testCode}}))
+
+	// Responses are never requested by erigon
+	require.NoError(t, p2p.Send(peer, ByteCodesMsg, &ByteCodesPacket{ID: 7}))
+	require.ErrorIs(t, <-errc, errInvalidMsgCode)
+}
+
+func TestHandleMessageTrieLoads(t *testing.T) {
+	db := memdb.NewTestDB(t)
+	peer, server := p2p.MsgPipe()
+	defer peer.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	trieLoads := rate.NewLimiter(rate.Every(time.Hour), 1)
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			if err := HandleMessage(ctx, db, server, trieLoads); err != nil {
+				errc <- err
+				return
+			}
+		}
+	}()
+
+	// Without a served state the request is answered empty, but it still counts as a trie load
+	require.NoError(t, p2p.Send(peer, GetAccountRangeMsg, &GetAccountRangePacket{ID: 1, Bytes: softResponseLimit}))
+	require.NoError(t, p2p.ExpectMsg(peer, AccountRangeMsg, &AccountRangePacket{ID: 1}))
+
+	// The next one waits for the limiter
+	require.NoError(t, p2p.Send(peer, GetTrieNodesMsg, &GetTrieNodesPacket{ID: 2, Bytes: softResponseLimit}))
+	select {
+	case err := <-errc:
+		t.Fatalf("request not throttled: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	cancel()
+	require.ErrorIs(t, <-errc, context.Canceled)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/erigon/common"
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/ethdb/prune"
 )
 
 // ExecFunc is the execution function for the stage to move forward.
@@ -101,3 +102,12 @@ func (s *PruneState) Done(db kv.Putter) error {
 func (s *PruneState) DoneAt(db kv.Putter, blockNum uint64) error {
 	return stages.SaveStagePruneProgress(db, s.ID, blockNum)
 }
+
+// PrunedTo - blocks before it were pruned by the previous prune of this stage. Pruning with prune.Mode.KeepAddresses
+// leaves some data there, it starts from PrunedTo not to check it again.
+func (s *PruneState) PrunedTo(amount prune.BlockAmount) uint64 {
+	if s.PruneProgress == 0 {
+		return 0
+	}
+	return amount.PruneTo(s.PruneProgress)
+}
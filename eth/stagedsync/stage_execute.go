@@ -710,6 +710,26 @@ func unwindExecutionStage(u *UnwindState, s *StageState, tx kv.RwTx, ctx context
 	return nil
 }
 
+// pruneReceiptsKeep - prunes receipts and logs, except blocks with a log emitted by one of keep
+func pruneReceiptsKeep(tx kv.RwTx, logPrefix string, from, pruneTo uint64, keep prune.Addresses, logEvery *time.Ticker, ctx context.Context) error {
+	// receipts are read by block, so all logs of a kept block are kept with its receipts
+	keptBlocks, err := blocksWithLogsOf(tx, logPrefix, from, pruneTo, keep, ctx)
+	if err != nil {
+		return err
+	}
+	isKept := func(k, _ []byte) (bool, error) {
+		_, ok := keptBlocks[binary.BigEndian.Uint64(k)]
+		return ok, nil
+	}
+	if err = rawdb.PruneTableKeep(tx, kv.Log, logPrefix, from, pruneTo, logEvery, ctx, isKept); err != nil {
+		return err
+	}
+	if err = rawdb.PruneTableKeep(tx, kv.Receipts, logPrefix, from, pruneTo, logEvery, ctx, isKept); err != nil {
+		return err
+	}
+	return rawdb.PruneTable(tx, kv.BorReceipts, pruneTo, ctx, math.MaxUint32)
+}
+
 func recoverCodeHashPlain(acc *accounts.Account, db kv.Tx, key []byte) {
 	var address commonold.Address
 	copy(address[:], key)
@@ -746,7 +766,20 @@ func PruneExecutionStage(s *PruneState, tx kv.RwTx, cfg ExecuteBlockCfg, ctx con
 			}
 		}
 	} else {
-		if cfg.prune.History.Enabled() {
+		keep := cfg.prune.KeepAddresses
+		if cfg.prune.History.Enabled() && len(keep) > 0 {
+			from, pruneTo := s.PrunedTo(cfg.prune.History), cfg.prune.History.PruneTo(s.ForwardProgress)
+			if err = rawdb.PruneTableKeep(tx, kv.AccountChangeSet, logPrefix, from, pruneTo, logEvery, ctx, func(_, v []byte) (bool, error) {
+				return keep.Contains(v), nil
+			}); err != nil {
+				return err
+			}
+			if err = rawdb.PruneTableKeep(tx, kv.StorageChangeSet, logPrefix, from, pruneTo, logEvery, ctx, func(k, _ []byte) (bool, error) {
+				return keep.Contains(k[8:]), nil
+			}); err != nil {
+				return err
+			}
+		} else if cfg.prune.History.Enabled() {
 			if err = rawdb.PruneTableDupSort(tx, kv.AccountChangeSet, logPrefix, cfg.prune.History.PruneTo(s.ForwardProgress), logEvery, ctx); err != nil {
 				return err
 			}
@@ -755,7 +788,11 @@ func PruneExecutionStage(s *PruneState, tx kv.RwTx, cfg ExecuteBlockCfg, ctx con
 			}
 		}
 
-		if cfg.prune.Receipts.Enabled() {
+		if cfg.prune.Receipts.Enabled() && len(keep) > 0 {
+			if err = pruneReceiptsKeep(tx, logPrefix, s.PrunedTo(cfg.prune.Receipts), cfg.prune.Receipts.PruneTo(s.ForwardProgress), keep, logEvery, ctx); err != nil {
+				return err
+			}
+		} else if cfg.prune.Receipts.Enabled() {
 			if err = rawdb.PruneTable(tx, kv.Receipts, cfg.prune.Receipts.PruneTo(s.ForwardProgress), ctx, math.MaxInt32); err != nil {
 				return err
 			}
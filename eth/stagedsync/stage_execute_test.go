@@ -7,11 +7,14 @@ import (
 	"testing"
 	"time"
 
+	common2 "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/length"
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/erigon-lib/kv/memdb"
 	"github.com/ledgerwatch/erigon-lib/kv/temporal/historyv2"
 	libstate "github.com/ledgerwatch/erigon-lib/state"
 	"github.com/ledgerwatch/erigon/cmd/state/exec22"
+	"github.com/ledgerwatch/erigon/common"
 	"github.com/ledgerwatch/erigon/core/rawdb"
 	"github.com/ledgerwatch/erigon/core/state"
 	"github.com/ledgerwatch/erigon/eth/ethconfig"
@@ -123,6 +126,59 @@ func TestExec(t *testing.T) {
 		require.NoError(err)
 		require.Equal(uint64(15), available)
 	})
+
+	t.Run("PruneExecutionKeepAddresses", func(t *testing.T) {
+		require, tx := require.New(t), memdb.BeginRw(t, memdb.NewTestDB(t))
+
+		generateBlocks(t, 1, 20, plainWriterGen(tx), changeCodeIndepenentlyOfIncarnations)
+		genReceipts(t, tx, 20)
+		// the contract of generateBlocks, and the address of genReceipts emitting logs only in the second tx of blocks
+		contract := common.HexToAddress("0x12345678900")
+		keep := prune.Addresses{contract, {3}}
+
+		s := &PruneState{ID: stages.Execution, ForwardProgress: 20}
+		err := PruneExecutionStage(s, tx, ExecuteBlockCfg{prune: prune.Mode{History: prune.Distance(5),
+			Receipts: prune.Distance(5), CallTraces: prune.Distance(100), KeepAddresses: keep}}, ctx, false)
+		require.NoError(err)
+
+		// changesets before 15 are only of the contract
+		accountChanges, storageChanges := 0, 0
+		require.NoError(tx.ForEach(kv.AccountChangeSet, nil, func(k, v []byte) error {
+			if binary.BigEndian.Uint64(k) < 15 {
+				require.Equal(contract[:], v[:length.Addr])
+				accountChanges++
+			}
+			return nil
+		}))
+		require.NoError(tx.ForEach(kv.StorageChangeSet, nil, func(k, _ []byte) error {
+			if binary.BigEndian.Uint64(k) < 15 {
+				require.Equal(contract[:], k[8:8+length.Addr])
+				storageChanges++
+			}
+			return nil
+		}))
+		require.Equal(14, accountChanges)
+		require.Greater(storageChanges, 0)
+
+		// receipts and all logs are kept for the blocks with logs of {3}
+		for blockNum := uint64(0); blockNum < 15; blockNum++ {
+			receipts := rawdb.ReadRawReceipts(tx, blockNum)
+			logsCount := 0
+			require.NoError(tx.ForPrefix(kv.Log, common2.EncodeTs(blockNum), func(_, _ []byte) error {
+				logsCount++
+				return nil
+			}))
+			if blockNum%3 != 1 {
+				require.Nil(receipts, blockNum)
+				require.Zero(logsCount, blockNum)
+				continue
+			}
+			require.Len(receipts, 2, blockNum)
+			require.Len(receipts[0].Logs, 1, blockNum)
+			require.Len(receipts[1].Logs, 2, blockNum)
+			require.Equal(2, logsCount, blockNum)
+		}
+	})
 }
 
 func apply(tx kv.RwTx, agg *libstate.Aggregator22) (beforeBlock, afterBlock testGenHook, w state.StateWriter) {
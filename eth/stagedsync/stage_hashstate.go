@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"os"
 	"runtime"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/ledgerwatch/erigon-lib/common/length"
 	"github.com/ledgerwatch/erigon-lib/etl"
 	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/mdbx"
 	"github.com/ledgerwatch/erigon-lib/kv/temporal/historyv2"
 	"github.com/ledgerwatch/erigon-lib/state"
 	"github.com/ledgerwatch/erigon/common"
@@ -22,6 +24,7 @@ import (
 	"github.com/ledgerwatch/erigon/common/math"
 	"github.com/ledgerwatch/erigon/core/rawdb"
 	"github.com/ledgerwatch/erigon/core/types/accounts"
+	"github.com/ledgerwatch/erigon/turbo/trie"
 	"github.com/ledgerwatch/log/v3"
 	"go.uber.org/atomic"
 )
@@ -153,6 +156,7 @@ func PromoteHashedStateCleanly(logPrefix string, tx kv.RwTx, cfg HashStateCfg, c
 	if err := promotePlainState(
 		logPrefix,
 		tx,
+		tx,
 		cfg.dirs.Tmp,
 		etl.IdentityLoadFunc,
 		ctx.Done(),
@@ -174,9 +178,36 @@ func PromoteHashedStateCleanly(logPrefix string, tx kv.RwTx, cfg HashStateCfg, c
 	)
 }
 
+// CalcStateRootFromPlainState - computes the state root of kv.PlainState without the hashed state and the
+// intermediate hashes of tx: the hashed state is built from scratch in a temporary database in tmpdir
+func CalcStateRootFromPlainState(logPrefix string, tx kv.Tx, tmpdir string, quit <-chan struct{}) (common.Hash, error) {
+	dbDir, err := os.MkdirTemp(tmpdir, "stateroot-")
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer os.RemoveAll(dbDir)
+	db, err := mdbx.NewMDBX(log.New()).Path(dbDir).Label(kv.ChainDB).Open()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer db.Close()
+	hashedTx, err := db.BeginRw(context.Background())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer hashedTx.Rollback()
+
+	if err := promotePlainState(logPrefix, tx, hashedTx, tmpdir, etl.IdentityLoadFunc, quit); err != nil {
+		return common.Hash{}, err
+	}
+	return trie.CalcRoot(logPrefix, hashedTx)
+}
+
+// promotePlainState - writes the hashed state of kv.PlainState of tx to hashedTx
 func promotePlainState(
 	logPrefix string,
-	tx kv.RwTx,
+	tx kv.Tx,
+	hashedTx kv.RwTx,
 	tmpdir string,
 	loadFunc etl.LoadFunc,
 	quit <-chan struct{},
@@ -264,11 +295,11 @@ func promotePlainState(
 		Quit: quit,
 	}
 
-	if err := accCollector.Load(tx, kv.HashedAccounts, loadFunc, args); err != nil {
+	if err := accCollector.Load(hashedTx, kv.HashedAccounts, loadFunc, args); err != nil {
 		return err
 	}
 
-	if err := storageCollector.Load(tx, kv.HashedStorage, loadFunc, args); err != nil {
+	if err := storageCollector.Load(hashedTx, kv.HashedStorage, loadFunc, args); err != nil {
 		return err
 	}
 
@@ -10,6 +10,8 @@ import (
 	"github.com/ledgerwatch/erigon-lib/kv/memdb"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ledgerwatch/erigon/turbo/trie"
+
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 )
 
@@ -30,6 +32,28 @@ func TestPromoteHashedStateClearState(t *testing.T) {
 	compareCurrentState(t, tx1, tx2, kv.HashedAccounts, kv.HashedStorage, kv.ContractCode)
 }
 
+func TestCalcStateRootFromPlainState(t *testing.T) {
+	dirs := datadir.New(t.TempDir())
+	_, tx1 := memdb.NewTestTx(t)
+	db2, tx2 := memdb.NewTestTx(t)
+
+	generateBlocks(t, 1, 50, hashedWriterGen(tx1), changeCodeWithIncarnations)
+	generateBlocks(t, 1, 50, plainWriterGen(tx2), changeCodeWithIncarnations)
+
+	expected, err := trie.CalcRoot("test", tx1)
+	require.NoError(t, err)
+	root, err := CalcStateRootFromPlainState("test", tx2, t.TempDir(), nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+
+	// the hashed state of tx is not used
+	require.NoError(t, PromoteHashedStateCleanly("test", tx2, StageHashStateCfg(db2, dirs, false, nil), context.Background()))
+	require.NoError(t, tx2.Put(kv.HashedAccounts, make([]byte, 32), []byte{1}))
+	root, err = CalcStateRootFromPlainState("test", tx2, t.TempDir(), nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+}
+
 func TestPromoteHashedStateIncremental(t *testing.T) {
 	dirs := datadir.New(t.TempDir())
 	historyV3 := false
@@ -370,7 +370,11 @@ func PruneAccountHistoryIndex(s *PruneState, tx kv.RwTx, cfg HistoryCfg, ctx con
 	}
 
 	pruneTo := cfg.prune.History.PruneTo(s.ForwardProgress)
-	if err = pruneHistoryIndex(tx, kv.AccountChangeSet, logPrefix, cfg.tmpdir, pruneTo, ctx); err != nil {
+	var from uint64
+	if len(cfg.prune.KeepAddresses) > 0 {
+		from = s.PrunedTo(cfg.prune.History)
+	}
+	if err = pruneHistoryIndex(tx, kv.AccountChangeSet, logPrefix, cfg.tmpdir, from, pruneTo, cfg.prune.KeepAddresses, ctx); err != nil {
 		return err
 	}
 	if err = s.Done(tx); err != nil {
@@ -400,7 +404,11 @@ func PruneStorageHistoryIndex(s *PruneState, tx kv.RwTx, cfg HistoryCfg, ctx con
 		defer tx.Rollback()
 	}
 	pruneTo := cfg.prune.History.PruneTo(s.ForwardProgress)
-	if err = pruneHistoryIndex(tx, kv.StorageChangeSet, logPrefix, cfg.tmpdir, pruneTo, ctx); err != nil {
+	var from uint64
+	if len(cfg.prune.KeepAddresses) > 0 {
+		from = s.PrunedTo(cfg.prune.History)
+	}
+	if err = pruneHistoryIndex(tx, kv.StorageChangeSet, logPrefix, cfg.tmpdir, from, pruneTo, cfg.prune.KeepAddresses, ctx); err != nil {
 		return err
 	}
 	if err = s.Done(tx); err != nil {
@@ -415,14 +423,16 @@ func PruneStorageHistoryIndex(s *PruneState, tx kv.RwTx, cfg HistoryCfg, ctx con
 	return nil
 }
 
-func pruneHistoryIndex(tx kv.RwTx, csTable, logPrefix, tmpDir string, pruneTo uint64, ctx context.Context) error {
+// pruneHistoryIndex - history of keep is not pruned: its changesets are left by the Execution stage prune,
+// and its keys are not collected here
+func pruneHistoryIndex(tx kv.RwTx, csTable, logPrefix, tmpDir string, from, pruneTo uint64, keep prune.Addresses, ctx context.Context) error {
 	logEvery := time.NewTicker(logInterval)
 	defer logEvery.Stop()
 
 	collector := etl.NewCollector(logPrefix, tmpDir, etl.NewOldestEntryBuffer(etl.BufferOptimalSize))
 	defer collector.Close()
 
-	if err := changeset.ForRange(tx, csTable, 0, pruneTo, func(blockNum uint64, k, _ []byte) error {
+	if err := changeset.ForRange(tx, csTable, from, pruneTo, func(blockNum uint64, k, _ []byte) error {
 		select {
 		case <-logEvery.C:
 			log.Info(fmt.Sprintf("[%s]", logPrefix), "table", csTable, "block_num", blockNum)
@@ -431,6 +441,9 @@ func pruneHistoryIndex(tx kv.RwTx, csTable, logPrefix, tmpDir string, pruneTo ui
 		default:
 		}
 
+		if keep.Contains(k) {
+			return nil
+		}
 		return collector.Collect(k, nil)
 	}); err != nil {
 		return err
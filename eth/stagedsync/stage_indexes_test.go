@@ -160,18 +160,57 @@ func TestIndexGenerator_Truncate(t *testing.T) {
 		checkIndex(t, tx, indexBucket, hashes[2], expected[string(hashes[2])])
 
 		//})
-		err = pruneHistoryIndex(tx, csbucket, "", tmpDir, 128, ctx)
+		err = pruneHistoryIndex(tx, csbucket, "", tmpDir, 0, 128, nil, ctx)
 		assert.NoError(t, err)
 		expectNoHistoryBefore(t, tx, csbucket, 128)
 
 		// double prune is safe
-		err = pruneHistoryIndex(tx, csbucket, "", tmpDir, 128, ctx)
+		err = pruneHistoryIndex(tx, csbucket, "", tmpDir, 0, 128, nil, ctx)
 		assert.NoError(t, err)
 		expectNoHistoryBefore(t, tx, csbucket, 128)
 		tx.Rollback()
 	}
 }
 
+func TestPruneHistoryIndexKeepAddresses(t *testing.T) {
+	tmpDir, ctx := t.TempDir(), context.Background()
+	db := kv2.NewTestDB(t)
+	cfg := StageHistoryCfg(db, prune.DefaultMode, t.TempDir())
+	for _, csbucket := range []string{kv.AccountChangeSet, kv.StorageChangeSet} {
+		tx, err := db.BeginRw(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		hashes, expected := generateTestData(t, tx, csbucket, 2100)
+		require.NoError(t, promoteHistory("logPrefix", tx, csbucket, 0, uint64(2100), cfg, nil))
+		indexBucket := historyv2.Mapper[csbucket].IndexBucket
+
+		var keep prune.Addresses
+		keep = append(keep, common.BytesToAddress(hashes[1][:length.Addr]))
+		prefixLen := length.Addr
+		if csbucket == kv.StorageChangeSet {
+			prefixLen = length.Hash
+		}
+
+		for _, r := range [][2]uint64{{0, 1000}, {1000, 2000}} {
+			require.NoError(t, pruneHistoryIndex(tx, csbucket, "", tmpDir, r[0], r[1], keep, ctx))
+			checkIndex(t, tx, indexBucket, hashes[1], expected[string(hashes[1])])
+			// chunks before pruneTo of other keys are pruned
+			for _, k := range [][]byte{hashes[0], hashes[2]} {
+				k = dbutils.CompositeKeyWithoutIncarnation(k)
+				chunks := 0
+				require.NoError(t, tx.ForPrefix(indexBucket, k, func(k, _ []byte) error {
+					require.GreaterOrEqual(t, binary.BigEndian.Uint64(k[prefixLen:]), r[1])
+					chunks++
+					return nil
+				}))
+				require.Greater(t, chunks, 0)
+			}
+		}
+		tx.Rollback()
+	}
+}
+
 func expectNoHistoryBefore(t *testing.T, tx kv.Tx, csbucket string, prunedTo uint64) {
 	prefixLen := length.Addr
 	if csbucket == kv.StorageChangeSet {
@@ -351,7 +351,10 @@ func truncateBitmaps(tx kv.RwTx, bucket string, inMem map[string]struct{}, to ui
 	return nil
 }
 
-func pruneOldLogChunks(tx kv.RwTx, bucket string, inMem *etl.Collector, pruneTo uint64, ctx context.Context) error {
+// pruneOldLogChunks - if kept is nil, deletes the chunks before pruneTo. Otherwise removes only blocks [from, pruneTo)
+// from them and from the first chunk after pruneTo, except the blocks in kept: chunks may hold blocks kept by
+// previous prunes.
+func pruneOldLogChunks(tx kv.RwTx, bucket string, inMem *etl.Collector, from, pruneTo uint64, kept map[string]*roaring.Bitmap, ctx context.Context) error {
 	logEvery := time.NewTicker(logInterval)
 	defer logEvery.Stop()
 
@@ -362,15 +365,49 @@ func pruneOldLogChunks(tx kv.RwTx, bucket string, inMem *etl.Collector, pruneTo
 	defer c.Close()
 
 	if err := inMem.Load(tx, bucket, func(key, v []byte, table etl.CurrentTableReader, next etl.LoadNextFunc) error {
-		for k, _, err := c.Seek(key); k != nil; k, _, err = c.Next() {
+		var pruned *roaring.Bitmap
+		if kept != nil {
+			pruned = roaring.New()
+			pruned.AddRange(from, pruneTo)
+			if keptBlocks, ok := kept[string(key)]; ok {
+				pruned.AndNot(keptBlocks)
+			}
+		}
+		for k, v, err := c.Seek(key); k != nil; k, v, err = c.Next() {
 			if err != nil {
 				return err
 			}
 			blockNum := uint64(binary.BigEndian.Uint32(k[len(key):]))
-			if !bytes.HasPrefix(k, key) || blockNum >= pruneTo {
+			if !bytes.HasPrefix(k, key) || (blockNum >= pruneTo && pruned == nil) {
 				break
 			}
 
+			if pruned != nil {
+				chunk := roaring.New()
+				if err = chunk.UnmarshalBinary(v); err != nil {
+					return err
+				}
+				chunk.AndNot(pruned)
+				if chunk.IsEmpty() {
+					err = c.DeleteCurrent()
+				} else {
+					// the key is still an upper bound of the chunk
+					var buf []byte
+					if buf, err = chunk.ToBytes(); err != nil {
+						return err
+					}
+					err = c.Put(libcommon.Copy(k), buf)
+				}
+				if err != nil {
+					return fmt.Errorf("failed prune, block=%d: %w", blockNum, err)
+				}
+				// the first chunk after pruneTo may hold blocks below it: they are pruned too, so the next prune
+				// doesn't need to look below its from
+				if blockNum >= pruneTo {
+					break
+				}
+				continue
+			}
 			if err = c.DeleteCurrent(); err != nil {
 				return fmt.Errorf("failed delete, block=%d: %w", blockNum, err)
 			}
@@ -399,8 +436,12 @@ func PruneLogIndex(s *PruneState, tx kv.RwTx, cfg LogIndexCfg, ctx context.Conte
 		defer tx.Rollback()
 	}
 
+	var from uint64
+	if len(cfg.prune.KeepAddresses) > 0 {
+		from = s.PrunedTo(cfg.prune.Receipts)
+	}
 	pruneTo := cfg.prune.Receipts.PruneTo(s.ForwardProgress)
-	if err = pruneLogIndex(logPrefix, tx, cfg.tmpdir, pruneTo, ctx); err != nil {
+	if err = pruneLogIndex(logPrefix, tx, cfg.tmpdir, from, pruneTo, cfg.prune.KeepAddresses, ctx); err != nil {
 		return err
 	}
 	if err = s.Done(tx); err != nil {
@@ -415,7 +456,9 @@ func PruneLogIndex(s *PruneState, tx kv.RwTx, cfg LogIndexCfg, ctx context.Conte
 	return nil
 }
 
-func pruneLogIndex(logPrefix string, tx kv.RwTx, tmpDir string, pruneTo uint64, ctx context.Context) error {
+// pruneLogIndex - blocks with logs emitted by keep are not pruned: their receipts and all their logs are kept,
+// so the blocks remain in the chunks of the topics and addresses of these logs
+func pruneLogIndex(logPrefix string, tx kv.RwTx, tmpDir string, from, pruneTo uint64, keep prune.Addresses, ctx context.Context) error {
 	logEvery := time.NewTicker(logInterval)
 	defer logEvery.Stop()
 
@@ -425,6 +468,25 @@ func pruneLogIndex(logPrefix string, tx kv.RwTx, tmpDir string, pruneTo uint64,
 	addrs := etl.NewCollector(logPrefix, tmpDir, etl.NewOldestEntryBuffer(bufferSize))
 	defer addrs.Close()
 
+	// nil - prune whole chunks, as without keep
+	var keptTopics, keptAddrs map[string]*roaring.Bitmap
+	var keptBlocks map[uint64]struct{}
+	if len(keep) > 0 {
+		keptTopics, keptAddrs = map[string]*roaring.Bitmap{}, map[string]*roaring.Bitmap{}
+		var err error
+		if keptBlocks, err = blocksWithLogsOf(tx, logPrefix, from, pruneTo, keep, ctx); err != nil {
+			return err
+		}
+	}
+	addKept := func(kept map[string]*roaring.Bitmap, key []byte, blockNum uint64) {
+		m, ok := kept[string(key)]
+		if !ok {
+			m = roaring.New()
+			kept[string(key)] = m
+		}
+		m.Add(uint32(blockNum))
+	}
+
 	reader := bytes.NewReader(nil)
 	{
 		c, err := tx.Cursor(kv.Log)
@@ -433,7 +495,7 @@ func pruneLogIndex(logPrefix string, tx kv.RwTx, tmpDir string, pruneTo uint64,
 		}
 		defer c.Close()
 
-		for k, v, err := c.First(); k != nil; k, v, err = c.Next() {
+		for k, v, err := c.Seek(dbutils.LogKey(from, 0)); k != nil; k, v, err = c.Next() {
 			if err != nil {
 				return err
 			}
@@ -455,7 +517,15 @@ func pruneLogIndex(logPrefix string, tx kv.RwTx, tmpDir string, pruneTo uint64,
 				return fmt.Errorf("receipt unmarshal failed: %w, block=%d", err, binary.BigEndian.Uint64(k))
 			}
 
+			_, kept := keptBlocks[blockNum]
 			for _, l := range logs {
+				if kept {
+					for _, topic := range l.Topics {
+						addKept(keptTopics, topic[:], blockNum)
+					}
+					addKept(keptAddrs, l.Address[:], blockNum)
+					continue
+				}
 				for _, topic := range l.Topics {
 					if err := topics.Collect(topic.Bytes(), nil); err != nil {
 						return err
@@ -468,11 +538,66 @@ func pruneLogIndex(logPrefix string, tx kv.RwTx, tmpDir string, pruneTo uint64,
 		}
 	}
 
-	if err := pruneOldLogChunks(tx, kv.LogTopicIndex, topics, pruneTo, ctx); err != nil {
+	if err := pruneOldLogChunks(tx, kv.LogTopicIndex, topics, from, pruneTo, keptTopics, ctx); err != nil {
 		return err
 	}
-	if err := pruneOldLogChunks(tx, kv.LogAddressIndex, addrs, pruneTo, ctx); err != nil {
+	if err := pruneOldLogChunks(tx, kv.LogAddressIndex, addrs, from, pruneTo, keptAddrs, ctx); err != nil {
 		return err
 	}
 	return nil
 }
+
+// blocksWithLogsOf - blocks in [from, pruneTo) with logs emitted by one of addrs
+func blocksWithLogsOf(tx kv.Tx, logPrefix string, from, pruneTo uint64, addrs prune.Addresses, ctx context.Context) (map[uint64]struct{}, error) {
+	logEvery := time.NewTicker(logInterval)
+	defer logEvery.Stop()
+
+	c, err := tx.Cursor(kv.Log)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	blocks := map[uint64]struct{}{}
+	for k, v, err := c.Seek(dbutils.LogKey(from, 0)); k != nil; k, v, err = c.Next() {
+		if err != nil {
+			return nil, err
+		}
+		blockNum := binary.BigEndian.Uint64(k)
+		if blockNum >= pruneTo {
+			break
+		}
+		if _, ok := blocks[blockNum]; ok {
+			continue
+		}
+		select {
+		case <-logEvery.C:
+			log.Info(fmt.Sprintf("[%s] Looking for kept logs", logPrefix), "block", blockNum)
+		case <-ctx.Done():
+			return nil, libcommon.ErrStopped
+		default:
+		}
+		emitted, err := logsEmittedBy(v, addrs)
+		if err != nil {
+			return nil, fmt.Errorf("%w, block=%d", err, blockNum)
+		}
+		if emitted {
+			blocks[blockNum] = struct{}{}
+		}
+	}
+	return blocks, nil
+}
+
+// logsEmittedBy - any of the cbor encoded logs of a transaction is emitted by one of addrs
+func logsEmittedBy(v []byte, addrs prune.Addresses) (bool, error) {
+	var logs types.Logs
+	if err := cbor.Unmarshal(&logs, bytes.NewReader(v)); err != nil {
+		return false, fmt.Errorf("receipt unmarshal failed: %w", err)
+	}
+	for _, l := range logs {
+		if addrs.Contains(l.Address[:]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
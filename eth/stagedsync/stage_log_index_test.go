@@ -1,11 +1,13 @@
 package stagedsync
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"testing"
 	"time"
 
+	"github.com/RoaringBitmap/roaring"
 	"github.com/ledgerwatch/erigon-lib/common/length"
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/erigon-lib/kv/bitmapdb"
@@ -13,6 +15,7 @@ import (
 	"github.com/ledgerwatch/erigon/common"
 	"github.com/ledgerwatch/erigon/core/rawdb"
 	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/ethdb/cbor"
 	"github.com/ledgerwatch/erigon/ethdb/prune"
 
 	"github.com/stretchr/testify/require"
@@ -131,7 +134,7 @@ func TestPruneLogIndex(t *testing.T) {
 	require.NoError(err)
 
 	// Mode test
-	err = pruneLogIndex("", tx, tmpDir, 50, ctx)
+	err = pruneLogIndex("", tx, tmpDir, 0, 50, nil, ctx)
 	require.NoError(err)
 
 	{
@@ -170,7 +173,7 @@ func TestUnwindLogIndex(t *testing.T) {
 	require.NoError(err)
 
 	// Mode test
-	err = pruneLogIndex("", tx, tmpDir, 50, ctx)
+	err = pruneLogIndex("", tx, tmpDir, 0, 50, nil, ctx)
 	require.NoError(err)
 
 	// Unwind test
@@ -188,3 +191,93 @@ func TestUnwindLogIndex(t *testing.T) {
 		require.True(m.Maximum() <= 700)
 	}
 }
+
+func TestPruneLogIndexKeepAddresses(t *testing.T) {
+	require, tmpDir, ctx := require.New(t), t.TempDir(), context.Background()
+	_, tx := memdb.NewTestTx(t)
+
+	// Enough blocks for a few chunks of each index
+	expectAddrs, _ := genReceipts(t, tx, 5_000)
+	// {2} shares blocks with {3}, {1} has own blocks
+	keep := prune.Addresses{{2}}
+
+	// Blocks of the topics and addresses of all logs in blocks with logs emitted by keep
+	keptTopics, keptAddrs := map[string]*roaring.Bitmap{}, map[string]*roaring.Bitmap{}
+	add := func(kept map[string]*roaring.Bitmap, key []byte, blockNum uint64) {
+		if _, ok := kept[string(key)]; !ok {
+			kept[string(key)] = roaring.New()
+		}
+		kept[string(key)].Add(uint32(blockNum))
+	}
+	keptBlocks, err := blocksWithLogsOf(tx, "", 0, 10_000_000, keep, ctx)
+	require.NoError(err)
+	require.NoError(tx.ForEach(kv.Log, nil, func(k, v []byte) error {
+		blockNum := binary.BigEndian.Uint64(k)
+		if _, ok := keptBlocks[blockNum]; !ok {
+			return nil
+		}
+		var logs types.Logs
+		require.NoError(cbor.Unmarshal(&logs, bytes.NewReader(v)))
+		for _, l := range logs {
+			for _, topic := range l.Topics {
+				add(keptTopics, topic[:], blockNum)
+			}
+			add(keptAddrs, l.Address[:], blockNum)
+		}
+		return nil
+	}))
+	require.Len(keptAddrs, 2)
+
+	cfg := StageLogIndexCfg(nil, prune.DefaultMode, "")
+	require.NoError(promoteLogIndex("logPrefix", tx, 0, 0, cfg, ctx))
+
+	checkIndex := func(table string, keyLen int, keys [][]byte, kept map[string]*roaring.Bitmap, pruneTo uint64) (pruned int) {
+		for _, key := range keys {
+			keptBlocks, ok := kept[string(key)]
+			if !ok {
+				keptBlocks = roaring.New()
+			}
+			// chunks before pruneTo have only kept blocks
+			require.NoError(tx.ForPrefix(table, key, func(k, v []byte) error {
+				if uint64(binary.BigEndian.Uint32(k[keyLen:])) >= pruneTo {
+					return nil
+				}
+				chunk := roaring.New()
+				require.NoError(chunk.UnmarshalBinary(v))
+				require.Equal(chunk.GetCardinality(), roaring.And(chunk, keptBlocks).GetCardinality())
+				pruned++
+				return nil
+			}))
+			// and the blocks before pruneTo are the kept ones
+			m, err := bitmapdb.Get(tx, table, key, 0, uint32(pruneTo-1))
+			require.NoError(err)
+			m.RemoveRange(pruneTo, 10_000_000)
+			expect := keptBlocks.Clone()
+			expect.RemoveRange(pruneTo, 10_000_000)
+			require.True(expect.Equals(m), "%x", key)
+		}
+		return pruned
+	}
+	check := func(pruneTo uint64) {
+		var addrs, topics [][]byte
+		for addr := range expectAddrs {
+			addrs = append(addrs, common.CopyBytes(addr[:]))
+			if keep.Contains(addr[:]) {
+				m, err := bitmapdb.Get(tx, kv.LogAddressIndex, addr[:], 0, 10_000_000)
+				require.NoError(err)
+				require.Equal(expectAddrs[addr], m.GetCardinality())
+			}
+		}
+		for topic := range keptTopics {
+			topics = append(topics, []byte(topic))
+		}
+		// {3} has logs only in the blocks shared with {2}, {1} is pruned
+		checkIndex(kv.LogAddressIndex, length.Addr, addrs, keptAddrs, pruneTo)
+		require.Greater(checkIndex(kv.LogTopicIndex, length.Hash, topics, keptTopics, pruneTo), 0)
+	}
+	require.NoError(pruneLogIndex("", tx, tmpDir, 0, 2_000, keep, ctx))
+	check(2_000)
+	// Kept blocks of the previous prune remain
+	require.NoError(pruneLogIndex("", tx, tmpDir, 2_000, 4_000, keep, ctx))
+	check(4_000)
+}
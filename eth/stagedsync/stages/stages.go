@@ -102,6 +102,19 @@ func SaveStagePruneProgress(db kv.Putter, stage SyncStage, progress uint64) erro
 	return db.Put(kv.SyncStageProgress, []byte("prune_"+stage), marshalData(progress))
 }
 
+// GetStageUnwindLimit - the stage can't be unwound below this block, e.g. its history before the block of state_import is missing
+func GetStageUnwindLimit(db kv.Getter, stage SyncStage) (uint64, error) {
+	v, err := db.GetOne(kv.SyncStageProgress, []byte("unwind_limit_"+stage))
+	if err != nil {
+		return 0, err
+	}
+	return unmarshalData(v)
+}
+
+func SaveStageUnwindLimit(db kv.Putter, stage SyncStage, blockNum uint64) error {
+	return db.Put(kv.SyncStageProgress, []byte("unwind_limit_"+stage), marshalData(blockNum))
+}
+
 func marshalData(blockNumber uint64) []byte {
 	return encodeBigEndian(blockNumber)
 }
@@ -189,14 +189,71 @@ func (s *Sync) StageState(stage stages.SyncStage, tx kv.Tx, db kv.RoDB) (*StageS
 	return &StageState{s, stage, blockNum}, nil
 }
 
+// checkUnwindLimits - rejects the unwind before any stage is unwound, if a stage can't be unwound to the unwind point.
+// Only the explicit unwinds (RunUnwindWithProgress) are rejected, the reorgs are followed with a warning
+func (s *Sync) checkUnwindLimits(db kv.RoDB, tx kv.Tx) error {
+	check := func(tx kv.Tx) error {
+		for _, stage := range s.unwindOrder {
+			if stage == nil || stage.Disabled || stage.Unwind == nil {
+				continue
+			}
+			progress, err := stages.GetStageProgress(tx, stage.ID)
+			if err != nil {
+				return err
+			}
+			limit, err := stages.GetStageUnwindLimit(tx, stage.ID)
+			if err != nil {
+				return err
+			}
+			if progress > *s.unwindPoint && limit > *s.unwindPoint {
+				return fmt.Errorf("stage %s can't be unwound to %d, below %d: its history is missing", stage.ID, *s.unwindPoint, limit)
+			}
+		}
+		return nil
+	}
+	if tx != nil {
+		return check(tx)
+	}
+	return db.View(context.Background(), check)
+}
+
+// warnUnwindLimits - a reorg below the imported state can't be refused without stalling the sync for good,
+// it is followed instead, and the state below the limit has to be imported again
+func (s *Sync) warnUnwindLimits(db kv.RoDB, tx kv.Tx) {
+	if err := s.checkUnwindLimits(db, tx); err != nil {
+		log.Warn("Unwinding below the imported state, re-import the state or resync", "err", err)
+	}
+}
+
+// RunUnwind - unwinds a reorg, even below the unwind limits of the stages
 func (s *Sync) RunUnwind(db kv.RwDB, tx kv.RwTx) error {
 	if s.unwindPoint == nil {
 		return nil
 	}
+	s.warnUnwindLimits(db, tx)
+	return s.runUnwind(db, tx, nil)
+}
+
+// RunUnwindWithProgress - explicit unwind, rejected if a stage can't be unwound to the unwind point,
+// calling progress (if not nil) before each stage is unwound
+func (s *Sync) RunUnwindWithProgress(db kv.RwDB, tx kv.RwTx, progress func(stage stages.SyncStage)) error {
+	if s.unwindPoint == nil {
+		return nil
+	}
+	if err := s.checkUnwindLimits(db, tx); err != nil {
+		return err
+	}
+	return s.runUnwind(db, tx, progress)
+}
+
+func (s *Sync) runUnwind(db kv.RwDB, tx kv.RwTx, progress func(stage stages.SyncStage)) error {
 	for j := 0; j < len(s.unwindOrder); j++ {
 		if s.unwindOrder[j] == nil || s.unwindOrder[j].Disabled || s.unwindOrder[j].Unwind == nil {
 			continue
 		}
+		if progress != nil {
+			progress(s.unwindOrder[j].ID)
+		}
 		if err := s.unwindStage(false, s.unwindOrder[j], db, tx); err != nil {
 			return err
 		}
@@ -216,6 +273,7 @@ func (s *Sync) Run(db kv.RwDB, tx kv.RwTx, firstCycle bool, quiet bool) error {
 	for !s.IsDone() {
 		var badBlockUnwind bool
 		if s.unwindPoint != nil {
+			s.warnUnwindLimits(db, tx)
 			for j := 0; j < len(s.unwindOrder); j++ {
 				if s.unwindOrder[j] == nil || s.unwindOrder[j].Disabled || s.unwindOrder[j].Unwind == nil {
 					continue
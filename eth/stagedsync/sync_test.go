@@ -10,6 +10,7 @@ import (
 	"github.com/ledgerwatch/erigon/common"
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStagesSuccess(t *testing.T) {
@@ -337,6 +338,56 @@ func TestUnwind(t *testing.T) {
 
 }
 
+func TestUnwindBelowLimit(t *testing.T) {
+	flow := make([]stages.SyncStage, 0)
+	newStage := func(id stages.SyncStage) *Stage {
+		return &Stage{
+			ID: id,
+			Forward: func(firstCycle bool, badBlockUnwind bool, s *StageState, u Unwinder, tx kv.RwTx, quiet bool) error {
+				flow = append(flow, id)
+				if s.BlockNumber == 0 {
+					return s.Update(tx, 1000)
+				}
+				return nil
+			},
+			Unwind: func(firstCycle bool, u *UnwindState, s *StageState, tx kv.RwTx) error {
+				flow = append(flow, unwindOf(id))
+				return u.Done(tx)
+			},
+		}
+	}
+	s := []*Stage{newStage(stages.Headers), newStage(stages.Execution), newStage(stages.LogIndex)}
+	state := New(s, []stages.SyncStage{s[2].ID, s[1].ID, s[0].ID}, nil)
+	db, tx := memdb.NewTestTx(t)
+	require.NoError(t, state.Run(db, tx, true /* initialCycle */, false /* quiet */))
+	require.NoError(t, stages.SaveStageUnwindLimit(tx, stages.Execution, 600))
+
+	// No stage is unwound, not even the ones before Execution in the unwind order
+	flow = flow[:0]
+	state.UnwindTo(500, common.Hash{})
+	require.ErrorContains(t, state.RunUnwindWithProgress(db, tx, nil), "can't be unwound to 500")
+	require.Empty(t, flow)
+	for _, stage := range s {
+		progress, err := stages.GetStageProgress(tx, stage.ID)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1000), progress)
+	}
+
+	state.UnwindTo(600, common.Hash{})
+	require.NoError(t, state.RunUnwindWithProgress(db, tx, nil))
+	require.Equal(t, []stages.SyncStage{unwindOf(stages.LogIndex), unwindOf(stages.Execution), unwindOf(stages.Headers)}, flow)
+
+	// A reorg below the limit does not stall the sync
+	flow = flow[:0]
+	state.UnwindTo(500, common.Hash{})
+	require.NoError(t, state.RunUnwind(db, tx))
+	require.Equal(t, []stages.SyncStage{unwindOf(stages.LogIndex), unwindOf(stages.Execution), unwindOf(stages.Headers)}, flow)
+	flow = flow[:0]
+	state.UnwindTo(400, common.Hash{})
+	require.NoError(t, state.Run(db, tx, false /* initialCycle */, false /* quiet */))
+	require.Equal(t, []stages.SyncStage{unwindOf(stages.LogIndex), unwindOf(stages.Execution), unwindOf(stages.Headers), stages.Headers, stages.Execution, stages.LogIndex}, flow)
+}
+
 func TestUnwindEmptyUnwinder(t *testing.T) {
 	flow := make([]stages.SyncStage, 0)
 	unwound := false
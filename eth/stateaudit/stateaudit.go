@@ -0,0 +1,131 @@
+package stateaudit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/eth/stagedsync"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/turbo/services"
+)
+
+// resultKey - kv.DatabaseInfo key of the last Result, read by the erigon_stateAudit RPC
+var resultKey = []byte("stateAudit")
+
+var (
+	auditBlock       = metrics.GetOrCreateCounter("state_audit_block")
+	auditDivergences = metrics.GetOrCreateCounter("state_audit_divergences")
+	auditErrors      = metrics.GetOrCreateCounter("state_audit_errors")
+)
+
+// Result - state root of the plain state, computed from scratch, and the state root of the header of its block
+type Result struct {
+	Block    uint64      `json:"block"`
+	Hash     common.Hash `json:"hash"`
+	Expected common.Hash `json:"expected"` // state root of the header
+	Root     common.Hash `json:"root"`     // state root of kv.PlainState
+	Started  int64       `json:"started"`  // unix time
+	Finished int64       `json:"finished"` // unix time
+	Err      string      `json:"error,omitempty"`
+}
+
+// Diverged - the plain state does not match the header
+func (r *Result) Diverged() bool { return r.Err == "" && r.Root != r.Expected }
+
+// Audit - computes the state root of the plain state at the Execution stage progress, in a read-only transaction,
+// without the hashed state and the intermediate hashes
+func Audit(ctx context.Context, db kv.RoDB, headerReader services.HeaderReader, tmpdir string) (*Result, error) {
+	tx, err := db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result := &Result{Started: time.Now().Unix()}
+	if result.Block, err = stages.GetStageProgress(tx, stages.Execution); err != nil {
+		return nil, err
+	}
+	header, err := headerReader.HeaderByNumber(ctx, tx, result.Block)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("header %d not found", result.Block)
+	}
+	result.Hash, result.Expected = header.Hash(), header.Root
+
+	if result.Root, err = stagedsync.CalcStateRootFromPlainState("StateAudit", tx, tmpdir, ctx.Done()); err != nil {
+		return nil, err
+	}
+	result.Finished = time.Now().Unix()
+	return result, nil
+}
+
+// Loop - audits the state every interval until ctx is done, reporting divergences by logs, metrics and the result
+// written to the db
+func Loop(ctx context.Context, db kv.RwDB, headerReader services.HeaderReader, tmpdir string, interval time.Duration) {
+	log.Info("[StateAudit] Started", "interval", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		started := time.Now()
+		result, err := Audit(ctx, db, headerReader, tmpdir)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			auditErrors.Inc()
+			log.Warn("[StateAudit] Failed", "err", err)
+			result = &Result{Started: started.Unix(), Finished: time.Now().Unix(), Err: err.Error()}
+		} else {
+			auditBlock.Set(result.Block)
+			if result.Diverged() {
+				auditDivergences.Inc()
+				log.Error("[StateAudit] State root of the plain state differs from the header", "block", result.Block, "hash", result.Hash,
+					"root", result.Root, "expected", result.Expected)
+			} else {
+				log.Info("[StateAudit] State root matches", "block", result.Block, "root", result.Root, "took", time.Since(started))
+			}
+		}
+		if err := db.Update(ctx, func(tx kv.RwTx) error { return WriteResult(tx, result) }); err != nil {
+			log.Warn("[StateAudit] Failed to save result", "err", err)
+		}
+	}
+}
+
+func WriteResult(tx kv.Putter, result *Result) error {
+	v, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return tx.Put(kv.DatabaseInfo, resultKey, v)
+}
+
+// ReadResult - returns nil if the state was never audited
+func ReadResult(tx kv.Getter) (*Result, error) {
+	v, err := tx.GetOne(kv.DatabaseInfo, resultKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 {
+		return nil, nil
+	}
+	result := &Result{}
+	if err := json.Unmarshal(v, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package stateaudit
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+	"github.com/ledgerwatch/erigon/eth/stagedsync"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/turbo/snapshotsync"
+)
+
+func putAccount(t *testing.T, tx kv.RwTx, addr common.Address, balance uint64) {
+	acc := accounts.NewAccount()
+	acc.Initialised, acc.Balance = true, *uint256.NewInt(balance)
+	v := make([]byte, acc.EncodingLengthForStorage())
+	acc.EncodeForStorage(v)
+	require.NoError(t, tx.Put(kv.PlainState, addr[:], v))
+}
+
+func TestAudit(t *testing.T) {
+	ctx, db := context.Background(), memdb.NewTestDB(t)
+	tx, err := db.BeginRw(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	putAccount(t, tx, common.Address{1}, 1)
+	putAccount(t, tx, common.Address{2}, 2)
+	root, err := stagedsync.CalcStateRootFromPlainState("test", tx, t.TempDir(), nil)
+	require.NoError(t, err)
+	header := &types.Header{Number: big.NewInt(5), Root: root, Difficulty: big.NewInt(1)}
+	rawdb.WriteHeader(tx, header)
+	require.NoError(t, rawdb.WriteCanonicalHash(tx, header.Hash(), 5))
+	require.NoError(t, stages.SaveStageProgress(tx, stages.Execution, 5))
+	require.NoError(t, tx.Commit())
+
+	result, err := Audit(ctx, db, snapshotsync.NewBlockReader(), t.TempDir())
+	require.NoError(t, err)
+	require.False(t, result.Diverged())
+	require.Equal(t, uint64(5), result.Block)
+	require.Equal(t, header.Hash(), result.Hash)
+	require.Equal(t, root, result.Root)
+
+	require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error {
+		putAccount(t, tx, common.Address{2}, 3)
+		return nil
+	}))
+	result, err = Audit(ctx, db, snapshotsync.NewBlockReader(), t.TempDir())
+	require.NoError(t, err)
+	require.True(t, result.Diverged())
+	require.Equal(t, root, result.Expected)
+
+	require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error {
+		saved, err := ReadResult(tx)
+		require.NoError(t, err)
+		require.Nil(t, saved)
+		require.NoError(t, WriteResult(tx, result))
+		saved, err = ReadResult(tx)
+		require.NoError(t, err)
+		require.Equal(t, result, saved)
+		return nil
+	}))
+}
@@ -7,13 +7,22 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"sort"
 	"strings"
 
+	"github.com/ledgerwatch/erigon-lib/common/length"
 	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon/common"
 	"github.com/ledgerwatch/erigon/params"
 	"github.com/ledgerwatch/log/v3"
 )
 
+// keepAddressesKey - kv.DatabaseInfo key of Mode.KeepAddresses, concatenated addresses
+var keepAddressesKey = []byte("pruneKeepAddresses")
+
+// noKeepAddresses - value of keepAddressesKey for an empty list: an empty value can't be told from a missing key
+var noKeepAddresses = []byte{0}
+
 var DefaultMode = Mode{
 	Initialised: true,
 	History:     Distance(math.MaxUint64), // all off
@@ -33,7 +42,7 @@ type Experiments struct {
 }
 
 func FromCli(chainId uint64, flags string, exactHistory, exactReceipts, exactTxIndex, exactCallTraces,
-	beforeH, beforeR, beforeT, beforeC uint64, experiments []string) (Mode, error) {
+	beforeH, beforeR, beforeT, beforeC uint64, keepAddresses []string, experiments []string) (Mode, error) {
 	mode := DefaultMode
 
 	if flags != "default" && flags != "disabled" {
@@ -90,6 +99,11 @@ func FromCli(chainId uint64, flags string, exactHistory, exactReceipts, exactTxI
 		mode.CallTraces = Before(beforeC)
 	}
 
+	var err error
+	if mode.KeepAddresses, err = ParseAddresses(keepAddresses); err != nil {
+		return DefaultMode, err
+	}
+
 	for _, ex := range experiments {
 		switch ex {
 		case "":
@@ -150,16 +164,21 @@ func Get(db kv.Getter) (Mode, error) {
 		prune.CallTraces = blockAmount
 	}
 
+	if prune.KeepAddresses, err = getAddresses(db, keepAddressesKey); err != nil {
+		return prune, err
+	}
+
 	return prune, nil
 }
 
 type Mode struct {
-	Initialised bool // Set when the values are initialised (not default)
-	History     BlockAmount
-	Receipts    BlockAmount
-	TxIndex     BlockAmount
-	CallTraces  BlockAmount
-	Experiments Experiments
+	Initialised   bool // Set when the values are initialised (not default)
+	History       BlockAmount
+	Receipts      BlockAmount
+	TxIndex       BlockAmount
+	CallTraces    BlockAmount
+	KeepAddresses Addresses // History of these accounts, their receipts and logs are not pruned
+	Experiments   Experiments
 }
 
 type BlockAmount interface {
@@ -210,6 +229,57 @@ func (b Before) PruneTo(uint64) uint64 {
 	return uint64(b) - 1
 }
 
+// Addresses - sorted list of addresses without duplicates
+type Addresses []common.Address
+
+// ParseAddresses - returns nil for an empty list
+func ParseAddresses(list []string) (Addresses, error) {
+	var addrs Addresses
+	for _, s := range list {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("invalid address: %s", s)
+		}
+		addrs = append(addrs, common.HexToAddress(s))
+	}
+	return newAddresses(addrs), nil
+}
+
+func newAddresses(addrs []common.Address) Addresses {
+	if len(addrs) == 0 {
+		return nil
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i][:], addrs[j][:]) < 0 })
+	res := Addresses{addrs[0]}
+	for _, addr := range addrs[1:] {
+		if addr != res[len(res)-1] {
+			res = append(res, addr)
+		}
+	}
+	return res
+}
+
+// Contains - addr is the address, or the key starting with the address
+func (a Addresses) Contains(addr []byte) bool {
+	if len(a) == 0 || len(addr) < length.Addr {
+		return false
+	}
+	addr = addr[:length.Addr]
+	i := sort.Search(len(a), func(i int) bool { return bytes.Compare(a[i][:], addr) >= 0 })
+	return i < len(a) && bytes.Equal(a[i][:], addr)
+}
+
+func (a Addresses) String() string {
+	list := make([]string, len(a))
+	for i, addr := range a {
+		list[i] = addr.Hex()
+	}
+	return strings.Join(list, ",")
+}
+
 func (m Mode) String() string {
 	if !m.Initialised {
 		return "default"
@@ -245,6 +315,9 @@ func (m Mode) String() string {
 			long += fmt.Sprintf(" --prune.c.%s=%d", m.CallTraces.dbType(), m.CallTraces.toValue())
 		}
 	}
+	if len(m.KeepAddresses) > 0 {
+		long += fmt.Sprintf(" --prune.keep.addresses=%s", m.KeepAddresses)
+	}
 
 	return strings.TrimLeft(short+long, " ")
 }
@@ -274,6 +347,11 @@ func Override(db kv.RwTx, sm Mode) error {
 		return err
 	}
 
+	err = setAddresses(db, keepAddressesKey, sm.KeepAddresses)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -291,6 +369,9 @@ func EnsureNotChanged(tx kv.GetPut, pruneMode Mode) (Mode, error) {
 
 	if pruneMode.Initialised {
 		// If storage mode is not explicitly specified, we take whatever is in the database
+		if !reflect.DeepEqual(pm.KeepAddresses, pruneMode.KeepAddresses) {
+			return pm, errors.New("not allowed change of --prune.keep.addresses, history of other addresses may be pruned already, last time you used: " + pm.String())
+		}
 		if !reflect.DeepEqual(pm, pruneMode) {
 			if bytes.Equal(pm.Receipts.dbType(), kv.PruneTypeOlder) && bytes.Equal(pruneMode.Receipts.dbType(), kv.PruneTypeBefore) {
 				log.Error("--prune=r flag has been changed to mean pruning of receipts before the Beacon Chain genesis. Please re-sync Erigon from scratch. " +
@@ -310,6 +391,12 @@ func setIfNotExist(db kv.GetPut, pm Mode) error {
 		pm = DefaultMode
 	}
 
+	// a db pruned before the keep list was introduced has the other keys, but not the list
+	initialised, err := db.GetOne(kv.DatabaseInfo, kv.PruneHistory)
+	if err != nil {
+		return err
+	}
+
 	pruneDBData := map[string]BlockAmount{
 		string(kv.PruneHistory):    pm.History,
 		string(kv.PruneReceipts):   pm.Receipts,
@@ -324,6 +411,22 @@ func setIfNotExist(db kv.GetPut, pm Mode) error {
 		}
 	}
 
+	// The list is saved even if empty: addresses added after pruning started would have no history
+	addrs, err := db.GetOne(kv.DatabaseInfo, keepAddressesKey)
+	if err != nil {
+		return err
+	}
+	if len(addrs) == 0 {
+		keep := pm.KeepAddresses
+		if len(initialised) > 0 {
+			keep = nil
+		}
+		err = setAddresses(db, keepAddressesKey, keep)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -380,6 +483,35 @@ func set(db kv.Putter, key []byte, blockAmount BlockAmount) error {
 	return nil
 }
 
+func getAddresses(db kv.Getter, key []byte) (Addresses, error) {
+	v, err := db.GetOne(kv.DatabaseInfo, key)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(v, noKeepAddresses) {
+		return nil, nil
+	}
+	if len(v)%length.Addr != 0 {
+		return nil, fmt.Errorf("unexpected length of %s: %d", key, len(v))
+	}
+	addrs := make([]common.Address, len(v)/length.Addr)
+	for i := range addrs {
+		copy(addrs[i][:], v[i*length.Addr:])
+	}
+	return newAddresses(addrs), nil
+}
+
+func setAddresses(db kv.Putter, key []byte, addrs Addresses) error {
+	if len(addrs) == 0 {
+		return db.Put(kv.DatabaseInfo, key, noKeepAddresses)
+	}
+	v := make([]byte, 0, len(addrs)*length.Addr)
+	for _, addr := range addrs {
+		v = append(v, addr[:]...)
+	}
+	return db.Put(kv.DatabaseInfo, key, v)
+}
+
 func keyType(name []byte) []byte {
 	return append(name, []byte("Type")...)
 }
@@ -5,7 +5,9 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/ledgerwatch/erigon/common"
 	"github.com/ledgerwatch/erigon/common/math"
 	"github.com/stretchr/testify/assert"
 )
@@ -15,16 +17,67 @@ func TestSetStorageModeIfNotExist(t *testing.T) {
 	prune, err := Get(tx)
 	assert.NoError(t, err)
 	assert.Equal(t, Mode{true, Distance(math.MaxUint64), Distance(math.MaxUint64),
-		Distance(math.MaxUint64), Distance(math.MaxUint64), Experiments{}}, prune)
+		Distance(math.MaxUint64), Distance(math.MaxUint64), nil, Experiments{}}, prune)
 
 	err = setIfNotExist(tx, Mode{true, Distance(1), Distance(2),
-		Before(3), Before(4), Experiments{}})
+		Before(3), Before(4), nil, Experiments{}})
 	assert.NoError(t, err)
 
 	prune, err = Get(tx)
 	assert.NoError(t, err)
 	assert.Equal(t, Mode{true, Distance(1), Distance(2),
-		Before(3), Before(4), Experiments{}}, prune)
+		Before(3), Before(4), nil, Experiments{}}, prune)
+}
+
+func TestKeepAddresses(t *testing.T) {
+	_, err := ParseAddresses([]string{"0x01"})
+	assert.Error(t, err)
+	addrs, err := ParseAddresses([]string{""})
+	assert.NoError(t, err)
+	assert.Nil(t, addrs)
+
+	a, b := common.HexToAddress("0x0a"), common.HexToAddress("0x0b")
+	addrs, err = ParseAddresses([]string{b.Hex(), " " + a.Hex(), b.Hex()})
+	assert.NoError(t, err)
+	assert.Equal(t, Addresses{a, b}, addrs)
+	assert.Equal(t, a.Hex()+","+b.Hex(), addrs.String())
+	assert.True(t, addrs.Contains(append(b.Bytes(), 1, 2))) // key prefix
+	assert.False(t, addrs.Contains(common.HexToAddress("0x0c").Bytes()))
+	assert.False(t, addrs.Contains(a.Bytes()[:10]))
+
+	_, tx := memdb.NewTestTx(t)
+	mode := Mode{true, Distance(1), Distance(2), Before(3), Before(4), addrs, Experiments{}}
+	assert.NoError(t, setIfNotExist(tx, mode))
+	prune, err := Get(tx)
+	assert.NoError(t, err)
+	assert.Equal(t, addrs, prune.KeepAddresses)
+	_, err = EnsureNotChanged(tx, mode)
+	assert.NoError(t, err)
+	mode.KeepAddresses = addrs[:1]
+	_, err = EnsureNotChanged(tx, mode)
+	assert.Error(t, err)
+}
+
+func TestKeepAddressesAddedLater(t *testing.T) {
+	a := Addresses{common.HexToAddress("0x0a")}
+	withoutList := Mode{true, Distance(1), Distance(2), Before(3), Before(4), nil, Experiments{}}
+	withList := Mode{true, Distance(1), Distance(2), Before(3), Before(4), a, Experiments{}}
+
+	// the empty list is saved on first start
+	_, tx := memdb.NewTestTx(t)
+	_, err := EnsureNotChanged(tx, withoutList)
+	assert.NoError(t, err)
+	_, err = EnsureNotChanged(tx, withList)
+	assert.Error(t, err)
+	prune, err := Get(tx)
+	assert.NoError(t, err)
+	assert.Nil(t, prune.KeepAddresses)
+
+	// db initialised before the keep list existed
+	_, tx = memdb.NewTestTx(t)
+	assert.NoError(t, set(tx, kv.PruneHistory, Distance(1)))
+	_, err = EnsureNotChanged(tx, withList)
+	assert.Error(t, err)
 }
 
 var distanceTests = []struct {
@@ -0,0 +1,221 @@
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ledgerwatch/erigon-lib/common/dir"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/mdbx"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/ugorji/go/codec"
+)
+
+const resultKeyPrefix = "_result_"
+
+// MigrationResult - timing and outcome of the last attempt to apply a migration, stored in kv.Migrations
+type MigrationResult struct {
+	Started    int64  // unix time
+	Finished   int64  // unix time
+	Err        string // empty if the migration was applied
+	Backup     string // path of the backup of Migration.Tables, empty if there is no backup
+	RolledBack int64  // unix time of RollbackMigration, 0 if the migration was not rolled back
+}
+
+func writeMigrationResult(tx kv.Putter, name string, result *MigrationResult) error {
+	buf := bytes.NewBuffer(nil)
+	if err := codec.NewEncoder(buf, &codec.CborHandle{}).Encode(result); err != nil {
+		return err
+	}
+	return tx.Put(kv.Migrations, []byte(resultKeyPrefix+name), buf.Bytes())
+}
+
+// ReadMigrationResult - returns nil if the migration was never attempted, or was attempted before results were recorded
+func ReadMigrationResult(tx kv.Getter, name string) (*MigrationResult, error) {
+	v, err := tx.GetOne(kv.Migrations, []byte(resultKeyPrefix+name))
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 {
+		return nil, nil
+	}
+	result := &MigrationResult{}
+	if err := codec.NewDecoder(bytes.NewReader(v), &codec.CborHandle{}).Decode(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DryRunReport - what a pending migration would touch
+type DryRunReport struct {
+	Name    string
+	Resumed bool // the migration was interrupted and will continue from its saved progress
+	Tables  []string
+	Entries []uint64 // current amount of keys in each of Tables
+}
+
+// DryRun - reports the pending migrations and the tables they would modify, without applying them
+func (m *Migrator) DryRun(db kv.RoDB) ([]DryRunReport, error) {
+	var reports []DryRunReport
+	if err := db.View(context.Background(), func(tx kv.Tx) error {
+		pending, err := m.PendingMigrations(tx)
+		if err != nil {
+			return err
+		}
+		for _, v := range pending {
+			progress, err := tx.GetOne(kv.Migrations, []byte("_progress_"+v.Name))
+			if err != nil {
+				return err
+			}
+			report := DryRunReport{Name: v.Name, Resumed: progress != nil, Tables: v.Tables, Entries: make([]uint64, len(v.Tables))}
+			for i, table := range v.Tables {
+				c, err := tx.Cursor(table)
+				if err != nil {
+					return err
+				}
+				report.Entries[i], err = c.Count()
+				c.Close()
+				if err != nil {
+					return err
+				}
+			}
+			reports = append(reports, report)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("migrator.DryRun: %w", err)
+	}
+	return reports, nil
+}
+
+// BackupPath - where Migrator saves the tables of the migration before applying it
+func BackupPath(dataDir, name string) string {
+	return filepath.Join(dataDir, "migrations", "backup", name)
+}
+
+func openBackup(path string, readonly bool) (kv.RwDB, error) {
+	opts := mdbx.NewMDBX(log.New()).Path(path).Label(kv.ChainDB)
+	if readonly {
+		opts = opts.Readonly()
+	}
+	return opts.Open()
+}
+
+func copyTable(from kv.Tx, to kv.RwTx, table string) error {
+	dupSort := kv.ChaindataTablesCfg[table].Flags&kv.DupSort != 0
+	return from.ForEach(table, nil, func(k, v []byte) error {
+		if dupSort {
+			return to.AppendDup(table, k, v)
+		}
+		return to.Append(table, k, v)
+	})
+}
+
+// backupTables - copies tables of db to a new database at path. The name of the migration is written last,
+// it marks the backup complete. A complete backup of an interrupted migration is kept: it holds the tables
+// from before the migration started, unlike db.
+func backupTables(db kv.RoDB, path, name string, tables []string, resumed bool) error {
+	if resumed && dir.Exist(path) {
+		backup, err := openBackup(path, true)
+		if err != nil {
+			return err
+		}
+		var complete bool
+		err = backup.View(context.Background(), func(tx kv.Tx) error {
+			v, err := tx.GetOne(kv.Migrations, []byte(name))
+			complete = len(v) > 0
+			return err
+		})
+		backup.Close()
+		if err != nil {
+			return err
+		}
+		if complete {
+			log.Info("Keep backup of interrupted migration", "name", name, "path", path)
+			return nil
+		}
+	}
+	if resumed {
+		log.Warn("Backup of interrupted migration contains partially migrated tables", "name", name)
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return err
+	}
+
+	log.Info("Backup tables before migration", "name", name, "tables", tables, "path", path)
+	backup, err := openBackup(path, false)
+	if err != nil {
+		return err
+	}
+	defer backup.Close()
+	return db.View(context.Background(), func(tx kv.Tx) error {
+		return backup.Update(context.Background(), func(backupTx kv.RwTx) error {
+			for _, table := range tables {
+				if err := copyTable(tx, backupTx, table); err != nil {
+					return fmt.Errorf("%s: %w", table, err)
+				}
+			}
+			return backupTx.Put(kv.Migrations, []byte(name), []byte(strings.Join(tables, ",")))
+		})
+	})
+}
+
+// RollbackMigration - restores the tables saved before the migration was applied and marks the migration
+// as not applied. Changes made to these tables after the migration are lost. The DB schema version is not restored.
+// The migration is applied again by the next Migrator.Apply, i.e. on the next start of a binary which has it.
+func RollbackMigration(db kv.RwDB, dataDir, name string) error {
+	path := BackupPath(dataDir, name)
+	if !dir.Exist(path) {
+		return fmt.Errorf("no backup of migration %s at %s", name, path)
+	}
+	backup, err := openBackup(path, true)
+	if err != nil {
+		return err
+	}
+	defer backup.Close()
+
+	return backup.View(context.Background(), func(backupTx kv.Tx) error {
+		v, err := backupTx.GetOne(kv.Migrations, []byte(name))
+		if err != nil {
+			return err
+		}
+		if len(v) == 0 {
+			return fmt.Errorf("backup of migration %s at %s is incomplete", name, path)
+		}
+		tables := strings.Split(string(v), ",")
+		return db.Update(context.Background(), func(tx kv.RwTx) error {
+			for _, table := range tables {
+				if err := tx.ClearBucket(table); err != nil {
+					return err
+				}
+				if err := copyTable(backupTx, tx, table); err != nil {
+					return fmt.Errorf("%s: %w", table, err)
+				}
+			}
+			if err := tx.Delete(kv.Migrations, []byte(name)); err != nil {
+				return err
+			}
+			if err := tx.Delete(kv.Migrations, []byte("_progress_"+name)); err != nil {
+				return err
+			}
+			result, err := ReadMigrationResult(tx, name)
+			if err != nil {
+				return err
+			}
+			if result == nil {
+				result = &MigrationResult{Backup: path}
+			}
+			result.RolledBack = time.Now().Unix()
+			if err := writeMigrationResult(tx, name, result); err != nil {
+				return err
+			}
+			log.Info("Rolled back migration", "name", name, "tables", tables)
+			return nil
+		})
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/ledgerwatch/erigon-lib/common/datadir"
 	"github.com/ledgerwatch/erigon-lib/kv"
@@ -44,6 +45,8 @@ type Callback func(tx kv.RwTx, progress []byte, isDone bool) error
 type Migration struct {
 	Name string
 	Up   func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback) error
+	// Tables modified by the migration - reported by DryRun and saved before the migration if Migrator.Backup is set
+	Tables []string
 }
 
 var (
@@ -60,12 +63,14 @@ func NewMigrator(label kv.Label) *Migrator {
 
 type Migrator struct {
 	Migrations []Migration
+	// Backup - copy Migration.Tables to BackupPath before applying each migration, RollbackMigration restores them
+	Backup bool
 }
 
 func AppliedMigrations(tx kv.Tx, withPayload bool) (map[string][]byte, error) {
 	applied := map[string][]byte{}
 	err := tx.ForEach(kv.Migrations, nil, func(k []byte, v []byte) error {
-		if bytes.HasPrefix(k, []byte("_progress_")) {
+		if bytes.HasPrefix(k, []byte("_progress_")) || bytes.HasPrefix(k, []byte(resultKeyPrefix)) {
 			return nil
 		}
 		if withPayload {
@@ -201,6 +206,15 @@ func (m *Migrator) Apply(db kv.RwDB, dataDir string) error {
 			return fmt.Errorf("migrator.Apply: %w", err)
 		}
 
+		started := time.Now()
+		result := &MigrationResult{Started: started.Unix()}
+		if m.Backup && len(v.Tables) > 0 {
+			result.Backup = BackupPath(dirs.DataDir, v.Name)
+			if err := backupTables(db, result.Backup, v.Name, v.Tables, progress != nil); err != nil {
+				return fmt.Errorf("migrator.Apply.Backup: %s, %w", v.Name, err)
+			}
+		}
+
 		dirs.Tmp = filepath.Join(dirs.DataDir, "migrations", v.Name)
 		if err := v.Up(db, dirs, progress, func(tx kv.RwTx, key []byte, isDone bool) error {
 			if !isDone {
@@ -227,15 +241,22 @@ func (m *Migrator) Apply(db kv.RwDB, dataDir string) error {
 				return err
 			}
 
-			return nil
+			result.Finished = time.Now().Unix()
+			return writeMigrationResult(tx, v.Name, result)
 		}); err != nil {
+			result.Finished, result.Err = time.Now().Unix(), err.Error()
+			if resultErr := db.Update(context.Background(), func(tx kv.RwTx) error {
+				return writeMigrationResult(tx, v.Name, result)
+			}); resultErr != nil {
+				log.Warn("Failed to record migration result", "name", v.Name, "err", resultErr)
+			}
 			return fmt.Errorf("migrator.Apply.Up: %s, %w", v.Name, err)
 		}
 
 		if !callbackCalled {
 			return fmt.Errorf("%w: %s", ErrMigrationCommitNotCalled, v.Name)
 		}
-		log.Info("Applied migration", "name", v.Name)
+		log.Info("Applied migration", "name", v.Name, "took", time.Since(started))
 	}
 	// Write DB schema version
 	var version [12]byte
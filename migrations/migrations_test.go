@@ -16,8 +16,8 @@ func TestApplyWithInit(t *testing.T) {
 	require, db := require.New(t), memdb.NewTestDB(t)
 	m := []Migration{
 		{
-			"one",
-			func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback) (err error) {
+			Name: "one",
+			Up: func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback) (err error) {
 				tx, err := db.BeginRw(context.Background())
 				if err != nil {
 					return err
@@ -31,8 +31,8 @@ func TestApplyWithInit(t *testing.T) {
 			},
 		},
 		{
-			"two",
-			func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback) (err error) {
+			Name: "two",
+			Up: func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback) (err error) {
 				tx, err := db.BeginRw(context.Background())
 				if err != nil {
 					return err
@@ -80,15 +80,15 @@ func TestApplyWithoutInit(t *testing.T) {
 	require, db := require.New(t), memdb.NewTestDB(t)
 	m := []Migration{
 		{
-			"one",
-			func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback) (err error) {
+			Name: "one",
+			Up: func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback) (err error) {
 				t.Fatal("shouldn't been executed")
 				return nil
 			},
 		},
 		{
-			"two",
-			func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback) (err error) {
+			Name: "two",
+			Up: func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback) (err error) {
 				tx, err := db.BeginRw(context.Background())
 				if err != nil {
 					return err
@@ -144,8 +144,8 @@ func TestWhenNonFirstMigrationAlreadyApplied(t *testing.T) {
 	require, db := require.New(t), memdb.NewTestDB(t)
 	m := []Migration{
 		{
-			"one",
-			func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback) (err error) {
+			Name: "one",
+			Up: func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback) (err error) {
 				tx, err := db.BeginRw(context.Background())
 				if err != nil {
 					return err
@@ -159,8 +159,8 @@ func TestWhenNonFirstMigrationAlreadyApplied(t *testing.T) {
 			},
 		},
 		{
-			"two",
-			func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback) (err error) {
+			Name: "two",
+			Up: func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback) (err error) {
 				t.Fatal("shouldn't been executed")
 				return nil
 			},
@@ -295,3 +295,89 @@ func TestCommitCallRequired(t *testing.T) {
 	})
 	require.NoError(err)
 }
+
+func TestDryRunBackupRollback(t *testing.T) {
+	require, db, dataDir := require.New(t), memdb.NewTestDB(t), t.TempDir()
+	err := db.Update(context.Background(), func(tx kv.RwTx) error {
+		if err := tx.Put(kv.Headers, []byte{1}, []byte{1}); err != nil {
+			return err
+		}
+		return tx.Put(kv.Senders, []byte{1}, []byte{1})
+	})
+	require.NoError(err)
+
+	m := []Migration{
+		{
+			Name: "one",
+			Up: func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback) (err error) {
+				tx, err := db.BeginRw(context.Background())
+				if err != nil {
+					return err
+				}
+				defer tx.Rollback()
+
+				if err := tx.Put(kv.Headers, []byte{1}, []byte{2}); err != nil {
+					return err
+				}
+				if err := tx.Put(kv.Headers, []byte{2}, []byte{2}); err != nil {
+					return err
+				}
+				if err := BeforeCommit(tx, nil, true); err != nil {
+					return err
+				}
+				return tx.Commit()
+			},
+			Tables: []string{kv.Headers},
+		},
+	}
+	migrator := NewMigrator(kv.ChainDB)
+	migrator.Migrations = m
+	migrator.Backup = true
+
+	reports, err := migrator.DryRun(db)
+	require.NoError(err)
+	require.Equal([]DryRunReport{{Name: "one", Tables: []string{kv.Headers}, Entries: []uint64{1}}}, reports)
+
+	require.NoError(migrator.Apply(db, dataDir))
+	err = db.View(context.Background(), func(tx kv.Tx) error {
+		v, err := tx.GetOne(kv.Headers, []byte{1})
+		require.NoError(err)
+		require.Equal([]byte{2}, v)
+		result, err := ReadMigrationResult(tx, "one")
+		require.NoError(err)
+		require.Empty(result.Err)
+		require.NotZero(result.Finished)
+		require.Equal(BackupPath(dataDir, "one"), result.Backup)
+		applied, err := AppliedMigrations(tx, false)
+		require.NoError(err)
+		require.Equal(1, len(applied))
+		return nil
+	})
+	require.NoError(err)
+	reports, err = migrator.DryRun(db)
+	require.NoError(err)
+	require.Empty(reports)
+
+	require.NoError(RollbackMigration(db, dataDir, "one"))
+	err = db.View(context.Background(), func(tx kv.Tx) error {
+		v, err := tx.GetOne(kv.Headers, []byte{1})
+		require.NoError(err)
+		require.Equal([]byte{1}, v)
+		v, err = tx.GetOne(kv.Headers, []byte{2})
+		require.NoError(err)
+		require.Nil(v)
+		v, err = tx.GetOne(kv.Senders, []byte{1})
+		require.NoError(err)
+		require.Equal([]byte{1}, v)
+		result, err := ReadMigrationResult(tx, "one")
+		require.NoError(err)
+		require.NotZero(result.RolledBack)
+		applied, err := AppliedMigrations(tx, false)
+		require.NoError(err)
+		require.Equal(0, len(applied))
+		return nil
+	})
+	require.NoError(err)
+
+	require.Error(RollbackMigration(db, dataDir, "two"))
+}
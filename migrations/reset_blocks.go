@@ -128,4 +128,8 @@ var resetBlocks4 = Migration{
 		}
 		return tx.Commit()
 	},
+	Tables: []string{
+		kv.Headers, kv.HeaderCanonical, kv.HeaderTD, kv.HeadHeaderKey, kv.BlockBody, kv.EthTx, kv.NonCanonicalTxs, kv.MaxTxNum, kv.Sequence,
+		kv.Senders, kv.TxLookup, kv.SyncStageProgress,
+	},
 }
@@ -223,6 +223,7 @@ var txsBeginEnd = Migration{
 			return BeforeCommit(tx, nil, true)
 		})
 	},
+	Tables: []string{kv.BlockBody, kv.EthTx, kv.NonCanonicalTxs, kv.Headers, kv.HeaderTD, kv.HeaderNumber, kv.Sequence},
 }
 
 func writeRawBodyDeprecated(db kv.RwTx, hash common.Hash, number uint64, body *types.RawBody) error {
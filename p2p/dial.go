@@ -60,6 +60,11 @@ type nodeResolver interface {
 	Resolve(*enode.Node) *enode.Node
 }
 
+// reputationSource tells the reputation of peers, as kept by the node database.
+type reputationSource interface {
+	Reputation(enode.ID) enode.Reputation
+}
+
 // tcpDialer implements NodeDialer using real TCP connections.
 type tcpDialer struct {
 	d *net.Dialer
@@ -81,6 +86,8 @@ var (
 	errRecentlyDialed   = errors.New("recently dialed")
 	errNotWhitelisted   = errors.New("not contained in netrestrict whitelist")
 	errNoPort           = errors.New("node does not provide TCP port")
+	errBannedPeer       = errors.New("banned for its reputation")
+	errLowReputation    = errors.New("deprioritised for its reputation")
 )
 
 // dialer creates outbound connections and submits them into Server.
@@ -138,6 +145,7 @@ type dialConfig struct {
 	maxActiveDials int              // maximum number of active dials
 	netRestrict    *netutil.Netlist // IP whitelist, disabled if nil
 	resolver       nodeResolver
+	reputation     reputationSource // Skips badly behaving dial candidates, disabled if nil
 	dialer         NodeDialer
 	log            log.Logger
 	clock          mclock.Clock
@@ -251,7 +259,11 @@ loop:
 			break loop
 
 		case node := <-nodesCh:
-			if err := d.checkDial(node); err != nil {
+			err := d.checkDial(node)
+			if err == nil {
+				err = d.checkReputation(node)
+			}
+			if err != nil {
 				d.log.Trace("Discarding dial candidate", "id", node.ID(), "ip", node.IP(), "reason", err)
 			} else {
 				d.startDial(newDialTask(node, dynDialedConn))
@@ -416,6 +428,22 @@ func (d *dialScheduler) checkDial(n *enode.Node) error {
 	return nil
 }
 
+// checkReputation returns an error if dynamic dial candidate n should be skipped for its
+// reputation. Banned peers are never dialed, other peers with a negative score are skipped
+// with a probability growing with their penalty, so that better candidates go first.
+func (d *dialScheduler) checkReputation(n *enode.Node) error {
+	if d.reputation == nil {
+		return nil
+	}
+	switch score := d.reputation.Reputation(n.ID()).Score(); {
+	case score <= enode.ReputationBanScore:
+		return errBannedPeer
+	case score < 0 && d.rand.Int63n(-enode.ReputationBanScore) < -score:
+		return errLowReputation
+	}
+	return nil
+}
+
 // startStaticDials starts n static dial tasks.
 func (d *dialScheduler) startStaticDials() {
 	for len(d.staticPool) > 0 {
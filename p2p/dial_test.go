@@ -152,6 +152,46 @@ func TestDialSchedNetRestrict(t *testing.T) {
 	})
 }
 
+// This test checks that candidates banned for their reputation are not dialed, unless static.
+func TestDialSchedReputation(t *testing.T) {
+	t.Parallel()
+
+	banned := newNode(uintID(0x02), "127.0.0.2:30303")
+	config := dialConfig{
+		maxActiveDials: 10,
+		maxDialPeers:   10,
+		reputation: dialTestReputation{
+			banned.ID():  {Penalties: 5},
+			uintID(0x03): {ServedBytes: 1 << 30},
+		},
+	}
+	runDialTest(t, config, []dialTestRound{
+		{
+			discovered: []*enode.Node{
+				newNode(uintID(0x01), "127.0.0.1:30303"),
+				banned,
+				newNode(uintID(0x03), "127.0.0.3:30303"),
+			},
+			wantNewDials: []*enode.Node{
+				newNode(uintID(0x01), "127.0.0.1:30303"),
+				newNode(uintID(0x03), "127.0.0.3:30303"),
+			},
+		},
+		{
+			update: func(d *dialScheduler) {
+				d.addStatic(banned)
+			},
+			wantNewDials: []*enode.Node{banned},
+		},
+	})
+}
+
+type dialTestReputation map[enode.ID]enode.Reputation
+
+func (r dialTestReputation) Reputation(id enode.ID) enode.Reputation {
+	return r[id]
+}
+
 // This test checks that static dials work and obey the limits.
 func TestDialSchedStaticDial(t *testing.T) {
 	t.Parallel()
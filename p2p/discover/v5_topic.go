@@ -0,0 +1,488 @@
+package discover
+
+import (
+	"context"
+	"crypto/hmac"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ledgerwatch/erigon/common/mclock"
+	"github.com/ledgerwatch/erigon/p2p/discover/v5wire"
+	"github.com/ledgerwatch/erigon/p2p/enode"
+	"github.com/ledgerwatch/erigon/p2p/netutil"
+	"github.com/ledgerwatch/erigon/rlp"
+)
+
+const (
+	topicTableCapacity    = 10000            // max number of ads in the topic table
+	topicAdLifetime       = 15 * time.Minute // how long an ad stays in the topic table
+	topicRegWindow        = 10 * time.Second // how late a ticket can be used after its wait time
+	topicQueryResultLimit = 16               // applies in TOPICQUERY handler
+	topicRegistrarLimit   = 8                // number of registrars an advertiser registers with
+	topicRegRetryDelay    = 10 * time.Second // delay of the next registration round when no registrar was found
+	topicSearchInterval   = 30 * time.Second // min time between the search rounds of a topic iterator
+
+	// Parameters of the waiting time function.
+	topicOccupancyPower = 10   // how steeply the waiting time grows with the table occupancy
+	topicBaseModifier   = 1e-7 // waiting time for an empty table, relative to the ad lifetime
+)
+
+var (
+	errInvalidTicket = errors.New("invalid ticket")
+	errTicketTopic   = errors.New("ticket is for another topic or node")
+	errRegtopicENR   = errors.New("record doesn't match the sender")
+)
+
+// Topic identifies a service provided by a node, such as a sub-protocol.
+// It is the hash of the topic name, and the registrars of a topic are the nodes closest to it.
+type Topic [32]byte
+
+// NewTopic creates a topic from its name.
+func NewTopic(name string) Topic {
+	return sha256.Sum256([]byte(name))
+}
+
+func (topic Topic) String() string {
+	return hex.EncodeToString(topic[:8])
+}
+
+// topicTicket is the registration state which the registrar hands out to the registrant
+// in a TICKET message, and gets back with the next REGTOPIC. It is authenticated with
+// a secret key, so the registrar doesn't have to keep any state for waiting registrants.
+type topicTicket struct {
+	Topic     Topic
+	ID        enode.ID
+	Issued    uint64 // mclock time of the first registration attempt
+	WaitUntil uint64 // mclock time before which the registrant can't come back
+}
+
+// topicAd is an entry of the topic table.
+type topicAd struct {
+	node    *enode.Node
+	expires mclock.AbsTime
+}
+
+// topicTable keeps the ads placed by advertisers of topics, when this node acts as a registrar.
+// The time to wait for a registration grows with the table occupancy, the number of ads for
+// the same topic and from the same IP, so that no topic or IP can take over the table.
+//
+// It is only used by the dispatch goroutine.
+type topicTable struct {
+	clock     mclock.Clock
+	ads       map[Topic][]*topicAd // each queue is ordered by expiry
+	ipCounts  map[string]int
+	count     int
+	ticketKey []byte
+}
+
+func newTopicTable(clock mclock.Clock) (*topicTable, error) {
+	key := make([]byte, 32)
+	if _, err := crand.Read(key); err != nil {
+		return nil, fmt.Errorf("can't generate ticket key: %w", err)
+	}
+	return &topicTable{
+		clock:     clock,
+		ads:       make(map[Topic][]*topicAd),
+		ipCounts:  make(map[string]int),
+		ticketKey: key,
+	}, nil
+}
+
+// register places an ad for n, if it has waited long enough since its first attempt.
+// Otherwise it returns the time left to wait.
+func (tab *topicTable) register(topic Topic, n *enode.Node, waited time.Duration) time.Duration {
+	now := tab.clock.Now()
+	tab.expire(now)
+
+	for _, ad := range tab.ads[topic] {
+		if ad.node.ID() == n.ID() {
+			// Already registered, come back when the ad expires.
+			return time.Duration(ad.expires - now)
+		}
+	}
+
+	required := tab.waitTime(topic, n, now).Truncate(time.Second)
+	if waited < required {
+		return required - waited
+	}
+
+	tab.ads[topic] = append(tab.ads[topic], &topicAd{n, now.Add(topicAdLifetime)})
+	tab.ipCounts[n.IP().String()]++
+	tab.count++
+	return 0
+}
+
+// waitTime computes the total time an advertiser has to wait for its ad to be placed.
+func (tab *topicTable) waitTime(topic Topic, n *enode.Node, now mclock.AbsTime) time.Duration {
+	if tab.count >= topicTableCapacity {
+		// Wait for the next ad to expire.
+		next := now.Add(topicAdLifetime)
+		for _, queue := range tab.ads {
+			if queue[0].expires < next {
+				next = queue[0].expires
+			}
+		}
+		return time.Duration(next-now) + time.Second
+	}
+
+	occupancy := float64(tab.count) / topicTableCapacity
+	topicShare := float64(len(tab.ads[topic])) / topicTableCapacity
+	ipShare := float64(tab.ipCounts[n.IP().String()]) / topicTableCapacity
+	wait := float64(topicAdLifetime) * (topicShare + ipShare + topicBaseModifier) / math.Pow(1-occupancy, topicOccupancyPower)
+	if wait > float64(topicAdLifetime) {
+		return topicAdLifetime
+	}
+	return time.Duration(wait)
+}
+
+// expire removes the expired ads.
+func (tab *topicTable) expire(now mclock.AbsTime) {
+	for topic, queue := range tab.ads {
+		i := 0
+		for ; i < len(queue) && queue[i].expires <= now; i++ {
+			ip := queue[i].node.IP().String()
+			if tab.ipCounts[ip]--; tab.ipCounts[ip] == 0 {
+				delete(tab.ipCounts, ip)
+			}
+			tab.count--
+		}
+		if i == len(queue) {
+			delete(tab.ads, topic)
+		} else {
+			tab.ads[topic] = queue[i:]
+		}
+	}
+}
+
+// nodes returns a random sample of the nodes advertising the topic.
+func (tab *topicTable) nodes(topic Topic, limit int) []*enode.Node {
+	tab.expire(tab.clock.Now())
+	queue := tab.ads[topic]
+	nodes := make([]*enode.Node, 0, len(queue))
+	for _, ad := range queue {
+		nodes = append(nodes, ad.node)
+	}
+	rand.Shuffle(len(nodes), func(i, j int) { nodes[i], nodes[j] = nodes[j], nodes[i] })
+	if len(nodes) > limit {
+		nodes = nodes[:limit]
+	}
+	return nodes
+}
+
+// sealTicket encodes a ticket with its MAC.
+func (tab *topicTable) sealTicket(ticket *topicTicket) []byte {
+	enc, err := rlp.EncodeToBytes(ticket)
+	if err != nil {
+		panic(err)
+	}
+	mac := hmac.New(sha256.New, tab.ticketKey)
+	mac.Write(enc)
+	return mac.Sum(enc)
+}
+
+// openTicket verifies and decodes a ticket issued by sealTicket.
+func (tab *topicTable) openTicket(sealed []byte) (*topicTicket, error) {
+	if len(sealed) <= sha256.Size {
+		return nil, errInvalidTicket
+	}
+	enc, sum := sealed[:len(sealed)-sha256.Size], sealed[len(sealed)-sha256.Size:]
+	mac := hmac.New(sha256.New, tab.ticketKey)
+	mac.Write(enc)
+	if !hmac.Equal(sum, mac.Sum(nil)) {
+		return nil, errInvalidTicket
+	}
+	var ticket topicTicket
+	if err := rlp.DecodeBytes(enc, &ticket); err != nil {
+		return nil, errInvalidTicket
+	}
+	return &ticket, nil
+}
+
+// roundUpSeconds rounds a waiting time up to the TICKET message resolution.
+func roundUpSeconds(d time.Duration) time.Duration {
+	if rounded := d.Truncate(time.Second); rounded < d {
+		return rounded + time.Second
+	}
+	return d
+}
+
+// RegisterTopic starts advertising the topic. The local node is registered with the nodes
+// closest to the topic, and the registrations are renewed until StopRegisterTopic is called.
+func (t *UDPv5) RegisterTopic(topic Topic) {
+	t.reglock.Lock()
+	defer t.reglock.Unlock()
+	if _, ok := t.registrations[topic]; ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(t.closeCtx)
+	t.registrations[topic] = cancel
+	t.wg.Add(1)
+	go t.registerLoop(ctx, topic)
+}
+
+// StopRegisterTopic stops advertising the topic.
+// The placed ads stay in the topic tables of the registrars until they expire.
+func (t *UDPv5) StopRegisterTopic(topic Topic) {
+	t.reglock.Lock()
+	defer t.reglock.Unlock()
+	if cancel, ok := t.registrations[topic]; ok {
+		cancel()
+		delete(t.registrations, topic)
+	}
+}
+
+// TopicNodes returns an iterator that finds the nodes advertising the topic.
+func (t *UDPv5) TopicNodes(topic Topic) enode.Iterator {
+	ctx, cancel := context.WithCancel(t.closeCtx)
+	return &topicIterator{t: t, topic: topic, ctx: ctx, cancel: cancel}
+}
+
+// registerLoop runs the registration rounds of a topic.
+// A round lasts until the ads placed at all registrars expire.
+func (t *UDPv5) registerLoop(ctx context.Context, topic Topic) {
+	defer t.wg.Done()
+
+	for ctx.Err() == nil {
+		registrars := t.topicRegistrars(ctx, topic)
+		if len(registrars) == 0 {
+			t.sleep(ctx, topicRegRetryDelay)
+			continue
+		}
+
+		var wg sync.WaitGroup
+		for _, n := range registrars {
+			wg.Add(1)
+			go func(n *enode.Node) {
+				defer wg.Done()
+				t.registerAt(ctx, n, topic)
+			}(n)
+		}
+		wg.Wait()
+	}
+}
+
+// registerAt places an ad for the topic at the registrar n, and waits until the ad expires.
+func (t *UDPv5) registerAt(ctx context.Context, n *enode.Node, topic Topic) {
+	var ticket []byte
+	for {
+		resp, err := t.regtopic(n, topic, ticket)
+		if err != nil {
+			t.log.Trace("Topic registration failed", "topic", topic, "id", n.ID(), "err", err)
+			t.sleep(ctx, topicRegRetryDelay)
+			return
+		}
+		if resp == nil {
+			t.log.Trace("Topic registered", "topic", topic, "id", n.ID())
+			t.sleep(ctx, topicAdLifetime)
+			return
+		}
+
+		wait := time.Duration(resp.WaitTime) * time.Second
+		if wait > topicAdLifetime {
+			// A registrar doesn't ask to wait longer than the ad lifetime.
+			return
+		}
+		if !t.sleep(ctx, wait) {
+			return
+		}
+		ticket = resp.Ticket
+	}
+}
+
+// topicRegistrars returns the nodes closest to the topic.
+func (t *UDPv5) topicRegistrars(ctx context.Context, topic Topic) []*enode.Node {
+	nodes := t.newLookup(ctx, enode.ID(topic)).run()
+	if len(nodes) > topicRegistrarLimit {
+		nodes = nodes[:topicRegistrarLimit]
+	}
+	return nodes
+}
+
+// topicSearch queries the registrars of the topic for the nodes advertising it.
+func (t *UDPv5) topicSearch(ctx context.Context, topic Topic) []*enode.Node {
+	registrars := t.topicRegistrars(ctx, topic)
+	results := make(chan []*enode.Node, len(registrars))
+	for _, n := range registrars {
+		go func(n *enode.Node) {
+			nodes, err := t.topicQuery(n, topic)
+			if err != nil {
+				t.log.Trace("Topic query failed", "topic", topic, "id", n.ID(), "err", err)
+			}
+			results <- nodes
+		}(n)
+	}
+
+	var (
+		nodes []*enode.Node
+		seen  = map[enode.ID]struct{}{t.Self().ID(): {}}
+	)
+	for range registrars {
+		for _, n := range <-results {
+			if _, ok := seen[n.ID()]; !ok {
+				seen[n.ID()] = struct{}{}
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	return nodes
+}
+
+// regtopic calls REGTOPIC on a node and waits for a TICKET or REGCONFIRMATION response.
+// The returned ticket is nil if the registration was confirmed.
+func (t *UDPv5) regtopic(n *enode.Node, topic Topic, ticket []byte) (*v5wire.Ticket, error) {
+	req := &v5wire.Regtopic{Topic: topic, ENR: t.localNode.Node().Record(), Ticket: ticket}
+	resp := t.call(n, v5wire.TicketMsg, req)
+	defer t.callDone(resp)
+
+	select {
+	case respMsg := <-resp.ch:
+		if ticket, ok := respMsg.(*v5wire.Ticket); ok {
+			return ticket, nil
+		}
+		return nil, nil
+	case err := <-resp.err:
+		return nil, err
+	}
+}
+
+// topicQuery calls TOPICQUERY on a node and waits for responses.
+func (t *UDPv5) topicQuery(n *enode.Node, topic Topic) ([]*enode.Node, error) {
+	resp := t.call(n, v5wire.NodesMsg, &v5wire.TopicQuery{Topic: topic})
+	return t.waitForNodes(resp, nil)
+}
+
+// sleep waits for the duration, and returns false if the context is done first.
+func (t *UDPv5) sleep(ctx context.Context, d time.Duration) bool {
+	timer := t.clock.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// handleRegtopic places an ad in the topic table, or hands out a ticket to come back later.
+func (t *UDPv5) handleRegtopic(p *v5wire.Regtopic, fromID enode.ID, fromAddr *net.UDPAddr) {
+	if p.ENR == nil {
+		t.log.Trace("Invalid "+p.Name(), "id", fromID, "addr", fromAddr, "err", errRegtopicENR)
+		return
+	}
+	n, err := enode.New(t.validSchemes, p.ENR)
+	if err != nil {
+		t.log.Trace("Invalid "+p.Name(), "id", fromID, "addr", fromAddr, "err", err)
+		return
+	}
+	if (n.ID() != fromID) || !n.IP().Equal(fromAddr.IP) {
+		t.log.Trace("Invalid "+p.Name(), "id", fromID, "addr", fromAddr, "err", errRegtopicENR)
+		return
+	}
+
+	topic := Topic(p.Topic)
+	now := t.clock.Now()
+	issued := now
+	if len(p.Ticket) > 0 {
+		ticket, err := t.topics.openTicket(p.Ticket)
+		if (err == nil) && ((ticket.Topic != topic) || (ticket.ID != fromID)) {
+			err = errTicketTopic
+		}
+		if err != nil {
+			t.log.Trace("Invalid "+p.Name(), "id", fromID, "addr", fromAddr, "err", err)
+			return
+		}
+
+		waitUntil := mclock.AbsTime(ticket.WaitUntil)
+		if now < waitUntil {
+			// Too early, the ticket stays the same.
+			t.sendTicket(p.ReqID, ticket, fromID, fromAddr)
+			return
+		}
+		if now <= waitUntil.Add(topicRegWindow) {
+			issued = mclock.AbsTime(ticket.Issued)
+		}
+		// Otherwise the ticket is too late, and the registrant starts over.
+	}
+
+	wait := t.topics.register(topic, n, time.Duration(now-issued))
+	if wait == 0 {
+		resp := &v5wire.Regconfirmation{ReqID: p.ReqID, Topic: p.Topic}
+		t.sendResponse(fromID, fromAddr, resp) //nolint:errcheck
+		return
+	}
+	ticket := &topicTicket{
+		Topic:     topic,
+		ID:        fromID,
+		Issued:    uint64(issued),
+		WaitUntil: uint64(now.Add(roundUpSeconds(wait))),
+	}
+	t.sendTicket(p.ReqID, ticket, fromID, fromAddr)
+}
+
+// sendTicket sends a TICKET response with the time left to wait.
+func (t *UDPv5) sendTicket(reqID []byte, ticket *topicTicket, toID enode.ID, toAddr *net.UDPAddr) {
+	wait := roundUpSeconds(time.Duration(mclock.AbsTime(ticket.WaitUntil) - t.clock.Now()))
+	resp := &v5wire.Ticket{
+		ReqID:    reqID,
+		Ticket:   t.topics.sealTicket(ticket),
+		WaitTime: uint(wait / time.Second),
+	}
+	t.sendResponse(toID, toAddr, resp) //nolint:errcheck
+}
+
+// handleTopicQuery returns the nodes advertising the topic to the requester.
+func (t *UDPv5) handleTopicQuery(p *v5wire.TopicQuery, fromID enode.ID, fromAddr *net.UDPAddr) {
+	var nodes []*enode.Node
+	for _, n := range t.topics.nodes(Topic(p.Topic), topicQueryResultLimit) {
+		if netutil.CheckRelayIP(fromAddr.IP, n.IP()) == nil {
+			nodes = append(nodes, n)
+		}
+	}
+	for _, resp := range packNodes(p.ReqID, nodes) {
+		t.sendResponse(fromID, fromAddr, resp) //nolint:errcheck
+	}
+}
+
+// topicIterator is an enode.Iterator over the nodes advertising a topic.
+// It runs a search round when it runs out of nodes, at most once per topicSearchInterval.
+type topicIterator struct {
+	t         *UDPv5
+	topic     Topic
+	ctx       context.Context
+	cancel    context.CancelFunc
+	buffer    []*enode.Node
+	node      *enode.Node
+	nextRound mclock.AbsTime
+}
+
+func (it *topicIterator) Next() bool {
+	for len(it.buffer) == 0 {
+		if it.ctx.Err() != nil {
+			it.node = nil
+			return false
+		}
+		if wait := time.Duration(it.nextRound - it.t.clock.Now()); (wait > 0) && !it.t.sleep(it.ctx, wait) {
+			continue
+		}
+		it.nextRound = it.t.clock.Now().Add(topicSearchInterval)
+		it.buffer = it.t.topicSearch(it.ctx, it.topic)
+	}
+	it.node, it.buffer = it.buffer[0], it.buffer[1:]
+	return true
+}
+
+func (it *topicIterator) Node() *enode.Node {
+	return it.node
+}
+
+func (it *topicIterator) Close() {
+	it.cancel()
+}
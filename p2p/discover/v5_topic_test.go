@@ -0,0 +1,216 @@
+package discover
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/ledgerwatch/erigon/common/mclock"
+	"github.com/ledgerwatch/erigon/p2p/discover/v5wire"
+	"github.com/ledgerwatch/erigon/p2p/enode"
+)
+
+func TestTopicTable(t *testing.T) {
+	var clock mclock.Simulated
+	tab, err := newTopicTable(&clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	topic := NewTopic("eth")
+	nodes := nodesAtDistance(enode.ID{}, 255, 101)
+
+	// An empty table registers immediately.
+	if wait := tab.register(topic, nodes[0], 0); wait != 0 {
+		t.Fatalf("registration to an empty table has to wait %v", wait)
+	}
+	for _, n := range nodes[1:100] {
+		if wait := tab.register(topic, n, topicAdLifetime); wait != 0 {
+			t.Fatalf("registration has to wait %v after the ad lifetime", wait)
+		}
+	}
+	if len(tab.nodes(topic, 1000)) != 100 {
+		t.Fatalf("wrong number of topic nodes")
+	}
+	if len(tab.nodes(topic, topicQueryResultLimit)) != topicQueryResultLimit {
+		t.Fatalf("topic nodes over the limit")
+	}
+
+	// Many ads for the same topic make the next registrant wait.
+	wait := tab.register(topic, nodes[100], 0)
+	if wait < time.Second {
+		t.Fatalf("too short wait time %v", wait)
+	}
+	if wait2 := tab.register(topic, nodes[100], wait/2); wait2 != wait-wait/2 {
+		t.Fatalf("wrong remaining wait time %v, want %v", wait2, wait-wait/2)
+	}
+	if tab.register(topic, nodes[100], wait) != 0 {
+		t.Fatalf("registration failed after waiting")
+	}
+
+	// A registered node comes back when its ad expires.
+	clock.Run(time.Minute)
+	if wait := tab.register(topic, nodes[0], 0); wait != topicAdLifetime-time.Minute {
+		t.Fatalf("wrong wait time %v for a registered node", wait)
+	}
+
+	// Ads expire.
+	clock.Run(topicAdLifetime)
+	if len(tab.nodes(topic, 1000)) != 0 {
+		t.Fatalf("ads didn't expire")
+	}
+	if (tab.count != 0) || (len(tab.ipCounts) != 0) {
+		t.Fatalf("counters not reset: count %d, IPs %d", tab.count, len(tab.ipCounts))
+	}
+}
+
+func TestTopicTicket(t *testing.T) {
+	tab, err := newTopicTable(mclock.System{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ticket := &topicTicket{
+		Topic:     NewTopic("eth"),
+		ID:        enode.ID{1},
+		Issued:    10,
+		WaitUntil: 20,
+	}
+	sealed := tab.sealTicket(ticket)
+
+	opened, err := tab.openTicket(sealed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *opened != *ticket {
+		t.Fatalf("wrong ticket %v, want %v", opened, ticket)
+	}
+
+	sealed[0]++
+	if _, err := tab.openTicket(sealed); !errors.Is(err, errInvalidTicket) {
+		t.Fatalf("tampered ticket opened, err %v", err)
+	}
+	otherTab, err := newTopicTable(mclock.System{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := otherTab.openTicket(tab.sealTicket(ticket)); !errors.Is(err, errInvalidTicket) {
+		t.Fatalf("ticket opened with another key, err %v", err)
+	}
+}
+
+// This test checks that incoming REGTOPIC and TOPICQUERY calls are handled correctly.
+func TestUDPv5_topicHandling(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fix me on win please")
+	}
+	t.Parallel()
+	test := newUDPV5Test(t)
+	t.Cleanup(test.close)
+
+	topic := NewTopic("eth")
+	remote := test.getNode(test.remotekey, test.remoteaddr).Node()
+
+	// A record of another node is rejected.
+	test.packetIn(&v5wire.Regtopic{ReqID: []byte{0}, Topic: topic, ENR: test.udp.Self().Record()})
+
+	// The first registrant is confirmed.
+	test.packetIn(&v5wire.Regtopic{ReqID: []byte{1}, Topic: topic, ENR: remote.Record()})
+	test.waitPacketOut(func(p *v5wire.Regconfirmation, addr *net.UDPAddr, _ v5wire.Nonce) {
+		if !bytes.Equal(p.ReqID, []byte{1}) || (p.Topic != topic) {
+			t.Errorf("wrong confirmation %v", p)
+		}
+	})
+
+	test.packetIn(&v5wire.TopicQuery{ReqID: []byte{2}, Topic: topic})
+	test.expectNodes([]byte{2}, 1, []*enode.Node{remote})
+	test.packetIn(&v5wire.TopicQuery{ReqID: []byte{3}, Topic: NewTopic("other")})
+	test.expectNodes([]byte{3}, 1, nil)
+
+	// A full topic gives out tickets.
+	topic = NewTopic("full")
+	for _, n := range nodesAtDistance(enode.ID{}, 255, 100) {
+		test.udp.topics.register(topic, n, topicAdLifetime)
+	}
+	var ticket *v5wire.Ticket
+	test.packetIn(&v5wire.Regtopic{ReqID: []byte{4}, Topic: topic, ENR: remote.Record()})
+	test.waitPacketOut(func(p *v5wire.Ticket, addr *net.UDPAddr, _ v5wire.Nonce) {
+		ticket = p
+	})
+	if ticket.WaitTime == 0 {
+		t.Fatalf("zero wait time in ticket")
+	}
+
+	// Coming back too early gets the same ticket.
+	test.packetIn(&v5wire.Regtopic{ReqID: []byte{5}, Topic: topic, ENR: remote.Record(), Ticket: ticket.Ticket})
+	test.waitPacketOut(func(p *v5wire.Ticket, addr *net.UDPAddr, _ v5wire.Nonce) {
+		if !bytes.Equal(p.Ticket, ticket.Ticket) || (p.WaitTime > ticket.WaitTime) {
+			t.Errorf("wrong ticket %v, want %v", p, ticket)
+		}
+	})
+
+	// A ticket for another topic is rejected.
+	test.packetIn(&v5wire.Regtopic{ReqID: []byte{6}, Topic: NewTopic("eth"), ENR: remote.Record(), Ticket: ticket.Ticket})
+}
+
+// This test checks that outgoing REGTOPIC and TOPICQUERY calls work.
+func TestUDPv5_topicCalls(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fix me on win please")
+	}
+	t.Parallel()
+	test := newUDPV5Test(t)
+	t.Cleanup(test.close)
+
+	topic := NewTopic("eth")
+	remote := test.getNode(test.remotekey, test.remoteaddr).Node()
+
+	type regtopicResult struct {
+		ticket *v5wire.Ticket
+		err    error
+	}
+	done := make(chan regtopicResult, 1)
+	go func() {
+		ticket, err := test.udp.regtopic(remote, topic, nil)
+		done <- regtopicResult{ticket, err}
+	}()
+	test.waitPacketOut(func(p *v5wire.Regtopic, addr *net.UDPAddr, _ v5wire.Nonce) {
+		if (p.Topic != topic) || (len(p.Ticket) != 0) {
+			t.Errorf("wrong REGTOPIC %v", p)
+		}
+		test.packetIn(&v5wire.Ticket{ReqID: p.ReqID, Ticket: []byte{1}, WaitTime: 5})
+	})
+	if result := <-done; (result.err != nil) || (result.ticket == nil) || (result.ticket.WaitTime != 5) {
+		t.Fatalf("wrong REGTOPIC result %v, err %v", result.ticket, result.err)
+	}
+
+	go func() {
+		ticket, err := test.udp.regtopic(remote, topic, []byte{1})
+		done <- regtopicResult{ticket, err}
+	}()
+	test.waitPacketOut(func(p *v5wire.Regtopic, addr *net.UDPAddr, _ v5wire.Nonce) {
+		test.packetIn(&v5wire.Regconfirmation{ReqID: p.ReqID, Topic: topic})
+	})
+	if result := <-done; (result.err != nil) || (result.ticket != nil) {
+		t.Fatalf("wrong REGTOPIC result %v, err %v", result.ticket, result.err)
+	}
+
+	advertisers := nodesAtDistance(enode.ID{}, 255, 4)
+	nodes := make(chan []*enode.Node, 1)
+	go func() {
+		result, err := test.udp.topicQuery(remote, topic)
+		if err != nil {
+			t.Error(err)
+		}
+		nodes <- result
+	}()
+	test.waitPacketOut(func(p *v5wire.TopicQuery, addr *net.UDPAddr, _ v5wire.Nonce) {
+		for _, resp := range packNodes(p.ReqID, advertisers) {
+			test.packetIn(resp)
+		}
+	})
+	if result := <-nodes; len(result) != len(advertisers) {
+		t.Fatalf("wrong number of topic nodes %d, want %d", len(result), len(advertisers))
+	}
+}
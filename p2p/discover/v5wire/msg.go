@@ -42,9 +42,8 @@ const (
 	NodesMsg
 	TalkRequestMsg
 	TalkResponseMsg
-	RequestTicketMsg
-	TicketMsg
 	RegtopicMsg
+	TicketMsg
 	RegconfirmationMsg
 	TopicQueryMsg
 
@@ -113,35 +112,33 @@ type (
 		Message []byte
 	}
 
-	// REQUESTTICKET requests a ticket for a topic queue.
-	RequestTicket struct {
-		ReqID []byte
-		Topic []byte
-	}
-
-	// TICKET is the response to REQUESTTICKET.
-	Ticket struct {
+	// REGTOPIC registers the sender for a topic. The first attempt has an empty ticket,
+	// the following ones carry the last ticket issued by the registrar.
+	Regtopic struct {
 		ReqID  []byte
+		Topic  [32]byte
+		ENR    *enr.Record
 		Ticket []byte
 	}
 
-	// REGTOPIC registers the sender in a topic queue using a ticket.
-	Regtopic struct {
-		ReqID  []byte
-		Ticket []byte
-		ENR    *enr.Record
+	// TICKET is the reply to REGTOPIC when the registrant has to wait
+	// WaitTime seconds before trying again with the ticket.
+	Ticket struct {
+		ReqID    []byte
+		Ticket   []byte
+		WaitTime uint
 	}
 
-	// REGCONFIRMATION is the reply to REGTOPIC.
+	// REGCONFIRMATION is the reply to REGTOPIC when the registrant was placed in the topic table.
 	Regconfirmation struct {
-		ReqID      []byte
-		Registered bool
+		ReqID []byte
+		Topic [32]byte
 	}
 
-	// TOPICQUERY asks for nodes with the given topic.
+	// TOPICQUERY asks for nodes with the given topic, the reply is NODES.
 	TopicQuery struct {
 		ReqID []byte
-		Topic []byte
+		Topic [32]byte
 	}
 )
 
@@ -161,8 +158,6 @@ func DecodeMessage(ptype byte, body []byte) (Packet, error) {
 		dec = new(TalkRequest)
 	case TalkResponseMsg:
 		dec = new(TalkResponse)
-	case RequestTicketMsg:
-		dec = new(RequestTicket)
 	case TicketMsg:
 		dec = new(Ticket)
 	case RegtopicMsg:
@@ -223,11 +218,6 @@ func (*TalkResponse) Kind() byte               { return TalkResponseMsg }
 func (p *TalkResponse) RequestID() []byte      { return p.ReqID }
 func (p *TalkResponse) SetRequestID(id []byte) { p.ReqID = id }
 
-func (*RequestTicket) Name() string             { return "REQTICKET/v5" }
-func (*RequestTicket) Kind() byte               { return RequestTicketMsg }
-func (p *RequestTicket) RequestID() []byte      { return p.ReqID }
-func (p *RequestTicket) SetRequestID(id []byte) { p.ReqID = id }
-
 func (*Regtopic) Name() string             { return "REGTOPIC/v5" }
 func (*Regtopic) Kind() byte               { return RegtopicMsg }
 func (p *Regtopic) RequestID() []byte      { return p.ReqID }
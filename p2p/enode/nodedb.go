@@ -46,6 +46,7 @@ const (
 	dbVersionKey   = "version" // Version of the database to flush if changes
 	dbNodePrefix   = "n:"      // Identifier to prefix node entries with
 	dbLocalPrefix  = "local:"
+	dbRepPrefix    = "rep:" // Reputations are kept apart from node entries, they outlive them
 	dbDiscoverRoot = "v4"
 	dbDiscv5Root   = "v5"
 
@@ -62,9 +63,10 @@ const (
 )
 
 const (
-	dbNodeExpiration = 24 * time.Hour // Time after which an unseen node should be dropped.
-	dbCleanupCycle   = time.Hour      // Time period for running the expiration task.
-	dbVersion        = 10
+	dbNodeExpiration       = 24 * time.Hour     // Time after which an unseen node should be dropped.
+	dbReputationExpiration = 7 * 24 * time.Hour // Time after which the reputation of an unseen peer is forgotten.
+	dbCleanupCycle         = time.Hour          // Time period for running the expiration task.
+	dbVersion              = 10
 )
 
 var (
@@ -79,6 +81,11 @@ type DB struct {
 	kv     kv.RwDB       // Interface to the database itself
 	runner sync.Once     // Ensures we can start at most one expirer
 	quit   chan struct{} // Channel to signal the expiring thread to stop
+
+	reputations      map[ID]Reputation // Reputations read or updated since the last expiration, in memory so that dialing does not hit the database
+	dirtyReputations map[ID]struct{}   // Nodes whose cached reputation is updated but not yet written
+	reputationLock   sync.Mutex        // Protects reputations and dirtyReputations, never held during database access
+	flushLock        sync.Mutex        // Keeps the flushes in order, so that an older update is not written over a newer one
 }
 
 // OpenDB opens a node database for storing and retrieving infos about known peers in the
@@ -399,6 +406,7 @@ func (db *DB) expirer() {
 		select {
 		case <-tick.C:
 			db.expireNodes()
+			db.expireReputations()
 		case <-db.quit:
 			return
 		}
@@ -527,6 +535,203 @@ func (db *DB) UpdateFindFailsV5(id ID, ip net.IP, fails int) error {
 	return db.storeInt64(v5Key(id, ip, dbNodeFindFails), int64(fails))
 }
 
+// reputationKey returns the database key for the reputation of a node.
+func reputationKey(id ID) []byte {
+	return append([]byte(dbRepPrefix), id[:]...)
+}
+
+// Reputation is what the node database remembers about the behaviour of a peer, so that
+// bad peers are not redialed after a restart.
+type Reputation struct {
+	Penalties   uint64 // Number of times the peer was penalized
+	Useless     uint64 // Number of times the peer was found useless
+	LatencyMs   uint64 // Moving average of the response latency, in milliseconds
+	ServedBytes uint64 // Total size of the responses served by the peer
+	Updated     uint64 // Unix time of the last update
+}
+
+const (
+	// ReputationBanScore is the score at or below which a peer is no longer connected to.
+	ReputationBanScore = -50
+
+	penaltyScore       = 10
+	uselessScore       = 2
+	latencyScoreUnit   = 500              // Milliseconds of average latency costing one point
+	servedScoreUnit    = 16 * 1024 * 1024 // Served bytes earning one point
+	maxServedScore     = 20
+	maxLatencyPenalty  = 10
+	latencyAverageKeep = 3 // Weight of the old average latency against a new sample
+)
+
+// Score rates a peer from its reputation, negative scores belong to peers which did more
+// harm than good.
+func (r Reputation) Score() int64 {
+	score := -int64(r.Penalties)*penaltyScore - int64(r.Useless)*uselessScore
+	if served := r.ServedBytes / servedScoreUnit; served < maxServedScore {
+		score += int64(served)
+	} else {
+		score += maxServedScore
+	}
+	if latency := r.LatencyMs / latencyScoreUnit; latency < maxLatencyPenalty {
+		score -= int64(latency)
+	} else {
+		score -= maxLatencyPenalty
+	}
+	return score
+}
+
+// Banned reports whether the peer has a score too bad to connect to it.
+func (r Reputation) Banned() bool {
+	return r.Score() <= ReputationBanScore
+}
+
+// AddLatency folds a latency sample into the moving average.
+func (r *Reputation) AddLatency(latency time.Duration) {
+	ms := uint64(latency.Milliseconds())
+	if r.LatencyMs == 0 {
+		r.LatencyMs = ms
+		return
+	}
+	r.LatencyMs = (r.LatencyMs*latencyAverageKeep + ms) / (latencyAverageKeep + 1)
+}
+
+// Reputation retrieves the reputation of a node, which is empty for unknown nodes.
+func (db *DB) Reputation(id ID) Reputation {
+	db.loadReputation(id)
+	db.reputationLock.Lock()
+	defer db.reputationLock.Unlock()
+	return db.reputations[id]
+}
+
+// loadReputation caches the stored reputation of a node, unless it is already cached.
+// The database is read without holding the lock.
+func (db *DB) loadReputation(id ID) {
+	db.reputationLock.Lock()
+	_, ok := db.reputations[id]
+	db.reputationLock.Unlock()
+	if ok {
+		return
+	}
+	stored := db.storedReputation(id)
+	db.reputationLock.Lock()
+	defer db.reputationLock.Unlock()
+	if _, ok := db.reputations[id]; ok {
+		return // Loaded or updated meanwhile, which is newer than what was read
+	}
+	if db.reputations == nil {
+		db.reputations = map[ID]Reputation{}
+	}
+	db.reputations[id] = stored
+}
+
+// storedReputation reads the reputation of a node as written to the database.
+func (db *DB) storedReputation(id ID) Reputation {
+	var rep Reputation
+	if err := db.kv.View(context.Background(), func(tx kv.Tx) error {
+		blob, errGet := tx.GetOne(kv.Inodes, reputationKey(id))
+		if errGet != nil || blob == nil {
+			return errGet
+		}
+		return rlp.DecodeBytes(blob, &rep)
+	}); err != nil {
+		return Reputation{}
+	}
+	return rep
+}
+
+// QueueReputationUpdate applies update to the reputation of a node in memory, so that a ban
+// is effective immediately. The reputation is written by the next FlushReputations.
+func (db *DB) QueueReputationUpdate(id ID, update func(*Reputation)) {
+	db.loadReputation(id)
+	db.reputationLock.Lock()
+	defer db.reputationLock.Unlock()
+	rep := db.reputations[id]
+	update(&rep)
+	rep.Updated = uint64(time.Now().Unix())
+	db.reputations[id] = rep
+	if db.dirtyReputations == nil {
+		db.dirtyReputations = map[ID]struct{}{}
+	}
+	db.dirtyReputations[id] = struct{}{}
+}
+
+// FlushReputations writes the queued reputation updates to the database in a single transaction.
+// The updates are copied out under the lock, readers keep being served from memory during the write.
+func (db *DB) FlushReputations() error {
+	db.flushLock.Lock()
+	defer db.flushLock.Unlock()
+	db.reputationLock.Lock()
+	updates := make(map[ID]Reputation, len(db.dirtyReputations))
+	for id := range db.dirtyReputations {
+		updates[id] = db.reputations[id]
+	}
+	db.dirtyReputations = nil
+	db.reputationLock.Unlock()
+	if len(updates) == 0 {
+		return nil
+	}
+	if err := db.kv.Update(context.Background(), func(tx kv.RwTx) error {
+		for id, rep := range updates {
+			blob, err := rlp.EncodeToBytes(&rep)
+			if err != nil {
+				return err
+			}
+			if err := tx.Put(kv.Inodes, reputationKey(id), blob); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		// Not written, the next flush retries with what is in memory by then
+		db.reputationLock.Lock()
+		defer db.reputationLock.Unlock()
+		if db.dirtyReputations == nil {
+			db.dirtyReputations = map[ID]struct{}{}
+		}
+		for id := range updates {
+			db.dirtyReputations[id] = struct{}{}
+		}
+		return err
+	}
+	return nil
+}
+
+// expireReputations deletes the reputations which have not been updated for some time,
+// which also lifts the bans.
+func (db *DB) expireReputations() {
+	threshold := uint64(time.Now().Add(-dbReputationExpiration).Unix())
+	if err := db.kv.Update(context.Background(), func(tx kv.RwTx) error {
+		c, err := tx.RwCursor(kv.Inodes)
+		if err != nil {
+			return err
+		}
+		p := []byte(dbRepPrefix)
+		for k, v, err := c.Seek(p); k != nil && bytes.HasPrefix(k, p); k, v, err = c.Next() {
+			if err != nil {
+				return err
+			}
+			var rep Reputation
+			if err := rlp.DecodeBytes(v, &rep); err == nil && rep.Updated >= threshold {
+				continue
+			}
+			if err := c.DeleteCurrent(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		log.Warn("nodeDB.expireReputations failed", "err", err)
+	}
+	// The cache only keeps the updates not yet written, the rest is read again when needed
+	db.reputationLock.Lock()
+	defer db.reputationLock.Unlock()
+	for id := range db.reputations {
+		if _, dirty := db.dirtyReputations[id]; !dirty {
+			delete(db.reputations, id)
+		}
+	}
+}
+
 // LocalSeq retrieves the local record sequence counter.
 func (db *DB) localSeq(id ID) uint64 {
 	return db.fetchUint64(localItemKey(id, dbLocalSeq))
@@ -613,5 +818,8 @@ func (db *DB) Close() {
 		return
 	}
 	libcommon.SafeClose(db.quit)
+	if err := db.FlushReputations(); err != nil {
+		log.Warn("nodeDB.FlushReputations failed", "err", err)
+	}
 	db.kv.Close()
 }
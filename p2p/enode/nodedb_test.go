@@ -18,12 +18,16 @@ package enode
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net"
 	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon/rlp"
 )
 
 var keytestID = HexID("51232b8d7821617d2b29b54b81cdefb9b3e9c37d7fd5f63270bcc9e1a6f6a439")
@@ -481,3 +485,145 @@ func TestDBExpireV5(t *testing.T) {
 	db.UpdateFindFailsV5(ID{}, ip, 4)
 	db.expireNodes()
 }
+
+// This test checks that reputations persist, add up to a ban and are forgotten after a while.
+func TestDBReputation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "database")
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("failed to create persistent database: %v", err)
+	}
+	if rep := db.Reputation(keytestID); rep != (Reputation{}) {
+		t.Fatalf("unknown node has reputation %+v", rep)
+	}
+	for i := 0; i < 4; i++ {
+		db.QueueReputationUpdate(keytestID, func(rep *Reputation) { rep.Penalties++ })
+		if err := db.FlushReputations(); err != nil {
+			t.Fatalf("failed to flush reputations: %v", err)
+		}
+	}
+	db.QueueReputationUpdate(keytestID, func(rep *Reputation) {
+		rep.Useless++
+		rep.ServedBytes += 2 * servedScoreUnit
+		rep.AddLatency(1000 * time.Millisecond)
+		rep.AddLatency(3000 * time.Millisecond)
+	})
+	if err := db.FlushReputations(); err != nil {
+		t.Fatalf("failed to flush reputations: %v", err)
+	}
+	db.Close()
+
+	db, err = OpenDB(path)
+	if err != nil {
+		t.Fatalf("failed to open persistent database: %v", err)
+	}
+	defer db.Close()
+	rep := db.Reputation(keytestID)
+	if rep.Penalties != 4 || rep.Useless != 1 || rep.LatencyMs != 1500 || rep.ServedBytes != 2*servedScoreUnit {
+		t.Fatalf("reputation mismatch after reopening: %+v", rep)
+	}
+	if score := rep.Score(); score != -4*penaltyScore-uselessScore+2-3 {
+		t.Fatalf("wrong score %d", score)
+	}
+	if rep.Banned() {
+		t.Fatalf("peer banned with score %d", rep.Score())
+	}
+	db.QueueReputationUpdate(keytestID, func(rep *Reputation) { rep.Penalties++ })
+	if err := db.FlushReputations(); err != nil {
+		t.Fatalf("failed to flush reputations: %v", err)
+	}
+	if rep = db.Reputation(keytestID); !rep.Banned() {
+		t.Fatalf("peer not banned with score %d", rep.Score())
+	}
+
+	db.expireReputations()
+	if db.Reputation(keytestID) == (Reputation{}) {
+		t.Fatal("fresh reputation expired")
+	}
+	db.storeRawReputation(t, keytestID, Reputation{Penalties: 10, Updated: uint64(time.Now().Add(-dbReputationExpiration - time.Minute).Unix())})
+	db.expireReputations()
+	if rep = db.Reputation(keytestID); rep != (Reputation{}) {
+		t.Fatalf("stale reputation not expired: %+v", rep)
+	}
+}
+
+// This test checks that the updates queued between flushes are applied in order, to each node.
+func TestDBFlushReputations(t *testing.T) {
+	db, _ := OpenDB("")
+	defer db.Close()
+
+	other := ID{1}
+	db.QueueReputationUpdate(other, func(rep *Reputation) { rep.Useless = 2 })
+	if err := db.FlushReputations(); err != nil {
+		t.Fatalf("failed to flush reputations: %v", err)
+	}
+	penalize := func(rep *Reputation) { rep.Penalties++ }
+	db.QueueReputationUpdate(keytestID, penalize)
+	db.QueueReputationUpdate(other, penalize)
+	db.QueueReputationUpdate(keytestID, penalize)
+	db.QueueReputationUpdate(keytestID, func(rep *Reputation) { rep.ServedBytes = rep.Penalties })
+	if err := db.FlushReputations(); err != nil {
+		t.Fatalf("failed to flush reputations: %v", err)
+	}
+	if rep := db.storedReputation(keytestID); rep.Penalties != 2 || rep.ServedBytes != 2 || rep.Updated == 0 {
+		t.Fatalf("wrong stored reputation %+v", rep)
+	}
+	if rep := db.storedReputation(other); rep.Penalties != 1 || rep.Useless != 2 {
+		t.Fatalf("wrong stored reputation %+v", rep)
+	}
+	// Written reputations are served from memory until the expiration, which drops them from the cache
+	db.storeRawReputation(t, other, Reputation{Useless: 7, Updated: uint64(time.Now().Unix())})
+	if rep := db.Reputation(other); rep.Useless != 2 {
+		t.Fatalf("reputation not served from memory: %+v", rep)
+	}
+	db.expireReputations()
+	if rep := db.Reputation(other); rep.Useless != 7 {
+		t.Fatalf("reputation not read again after expiration: %+v", rep)
+	}
+}
+
+func TestDBQueueReputationUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "database")
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("failed to create persistent database: %v", err)
+	}
+	db.QueueReputationUpdate(keytestID, func(rep *Reputation) { rep.Penalties += 5 })
+	// The ban is seen before the reputation is written
+	if rep := db.Reputation(keytestID); !rep.Banned() {
+		t.Fatalf("peer not banned with score %d", rep.Score())
+	}
+	if rep := db.storedReputation(keytestID); rep != (Reputation{}) {
+		t.Fatalf("reputation written before flush: %+v", rep)
+	}
+	if err := db.FlushReputations(); err != nil {
+		t.Fatalf("failed to flush reputations: %v", err)
+	}
+	if rep := db.storedReputation(keytestID); rep.Penalties != 5 || rep.Updated == 0 {
+		t.Fatalf("wrong stored reputation %+v", rep)
+	}
+	// Queued updates build on the stored reputation, and are written on close
+	db.QueueReputationUpdate(keytestID, func(rep *Reputation) { rep.Useless++ })
+	db.Close()
+
+	db, err = OpenDB(path)
+	if err != nil {
+		t.Fatalf("failed to open persistent database: %v", err)
+	}
+	defer db.Close()
+	if rep := db.Reputation(keytestID); rep.Penalties != 5 || rep.Useless != 1 {
+		t.Fatalf("reputation mismatch after reopening: %+v", rep)
+	}
+}
+
+func (db *DB) storeRawReputation(t *testing.T, id ID, rep Reputation) {
+	blob, err := rlp.EncodeToBytes(&rep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.kv.Update(context.Background(), func(tx kv.RwTx) error {
+		return tx.Put(kv.Inodes, reputationKey(id), blob)
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -96,6 +96,10 @@ type Config struct {
 	// protocol should be started or not.
 	DiscoveryV5 bool `toml:",omitempty"`
 
+	// DiscoveryV5Topics are the topics advertised with the V5 discovery protocol.
+	// The nodes advertising the same topics are added to the dial candidates.
+	DiscoveryV5Topics []string `toml:",omitempty"`
+
 	// Name sets the node name of this server.
 	// Use common.MakeName to create a name that follows existing conventions.
 	Name string `toml:"-"`
@@ -146,6 +150,20 @@ type Config struct {
 	// eth/66, eth/67, etc
 	ProtocolVersion []uint
 
+	// SnapServer enables serving snap/1 next to eth, only possible for the sentries running inside erigon
+	SnapServer bool
+
+	// MaxEgressRate and MaxPeerEgressRate limit the bytes per second sent by the sentries to all peers
+	// and to any one peer, zero means unlimited
+	MaxEgressRate     uint64 `toml:",omitempty"`
+	MaxPeerEgressRate uint64 `toml:",omitempty"`
+
+	// CaptureDir enables writing the eth-protocol messages of the sentries to files in this directory,
+	// starting a new file every CaptureFileSize bytes, and keeping the CaptureFiles most recent files
+	CaptureDir      string `toml:",omitempty"`
+	CaptureFileSize uint64 `toml:",omitempty"`
+	CaptureFiles    int    `toml:",omitempty"`
+
 	SentryAddr []string
 
 	// If set to a non-nil value, the given NAT port mapper
@@ -395,6 +413,11 @@ func (srv *Server) SubscribeEvents(ch chan *PeerEvent) event.Subscription {
 	return srv.peerFeed.Subscribe(ch)
 }
 
+// NodeDB returns the node database, which is nil until the server is started.
+func (srv *Server) NodeDB() *enode.DB {
+	return srv.nodedb
+}
+
 // Self returns the local node's endpoint information.
 func (srv *Server) Self() *enode.Node {
 	srv.lock.Lock()
@@ -647,6 +670,11 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		for _, name := range srv.DiscoveryV5Topics {
+			topic := discover.NewTopic(name)
+			srv.DiscV5.RegisterTopic(topic)
+			srv.discmix.AddSource(srv.DiscV5.TopicNodes(topic))
+		}
 	}
 	return nil
 }
@@ -664,6 +692,9 @@ func (srv *Server) setupDialScheduler() {
 	if srv.ntab != nil {
 		config.resolver = srv.ntab
 	}
+	if srv.nodedb != nil {
+		config.reputation = srv.nodedb
+	}
 	if config.dialer == nil {
 		config.dialer = tcpDialer{&net.Dialer{Timeout: defaultDialTimeout}}
 	}
@@ -859,6 +890,8 @@ func (srv *Server) postHandshakeChecks(peers map[enode.ID]*Peer, inboundCount in
 		return DiscSelf
 	case (len(srv.Protocols) > 0) && (countMatchingProtocols(srv.Protocols, c.caps) == 0):
 		return DiscUselessPeer
+	case c.is(inboundConn) && !c.is(trustedConn) && srv.nodedb != nil && srv.nodedb.Reputation(c.node.ID()).Banned():
+		return DiscUselessPeer
 	default:
 		return nil
 	}
@@ -0,0 +1,169 @@
+package app
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/datadir"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/mdbx"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ledgerwatch/erigon/cmd/utils"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/turbo/debug"
+	"github.com/ledgerwatch/erigon/turbo/logging"
+	"github.com/ledgerwatch/erigon/turbo/services"
+	"github.com/ledgerwatch/erigon/turbo/snapshotsync"
+	"github.com/ledgerwatch/erigon/turbo/snapshotsync/snap"
+)
+
+var exportCommand = cli.Command{
+	Action:    exportChain,
+	Name:      "export",
+	Usage:     "Export the canonical blocks to a blockchain file",
+	ArgsUsage: "<filename>",
+	Before:    func(ctx *cli.Context) error { return debug.Setup(ctx) },
+	Flags: joinFlags([]cli.Flag{
+		&utils.DataDirFlag,
+		&SnapshotFromFlag,
+		&SnapshotToFlag,
+		&ExportAppendFlag,
+	}, debug.Flags, logging.Flags),
+	Category: "BLOCKCHAIN COMMANDS",
+	Description: `
+The export command writes the canonical blocks in the range [--from, --to] as a sequence
+of RLP-encoded blocks, which the import command reads back. If the file name ends with .gz,
+the output is gzip-compressed. The blocks are read from the database and the snapshots,
+so the node can keep running.`,
+}
+
+var ExportAppendFlag = cli.BoolFlag{
+	Name:  "append",
+	Usage: "Append to the file instead of overwriting it",
+}
+
+func exportChain(cliCtx *cli.Context) error {
+	if cliCtx.NArg() < 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	ctx, cancel := common.RootContext()
+	defer cancel()
+
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	db := mdbx.NewMDBX(log.New()).Label(kv.ChainDB).Path(dirs.Chaindata).Readonly().MustOpen()
+	defer db.Close()
+
+	var snapEnabled bool
+	if err := db.View(ctx, func(tx kv.Tx) (err error) {
+		snapEnabled, err = snap.Enabled(tx)
+		return err
+	}); err != nil {
+		return err
+	}
+	var blockReader services.FullBlockReader = snapshotsync.NewBlockReader()
+	if snapEnabled {
+		snapshots := snapshotsync.NewRoSnapshots(ethconfig.NewSnapCfg(true, true, false), dirs.Snap)
+		if err := snapshots.ReopenWithDB(db); err != nil {
+			return err
+		}
+		defer snapshots.Close()
+		blockReader = snapshotsync.NewBlockReaderWithSnapshots(snapshots)
+	}
+
+	return ExportChain(ctx, db, blockReader, cliCtx.Args().First(),
+		cliCtx.Uint64(SnapshotFromFlag.Name), cliCtx.Uint64(SnapshotToFlag.Name), cliCtx.Bool(ExportAppendFlag.Name))
+}
+
+// ExportChain writes the canonical blocks from..to to the file, up to the head block if to is 0.
+// The file is gzip-compressed if its name ends with .gz, appending adds another gzip stream.
+func ExportChain(ctx context.Context, db kv.RoDB, blockReader services.FullBlockReader, fn string, from, to uint64, appendFile bool) error {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if appendFile {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	fh, err := os.OpenFile(fn, flags, 0644)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	var writer io.Writer = fh
+	var gz *gzip.Writer
+	if strings.HasSuffix(fn, ".gz") {
+		gz = gzip.NewWriter(fh)
+		writer = gz
+	}
+	wr := bufio.NewWriterSize(writer, int(4*datasize.MB))
+
+	logEvery := time.NewTicker(30 * time.Second)
+	defer logEvery.Stop()
+
+	if err := db.View(ctx, func(tx kv.Tx) error {
+		if to == 0 {
+			head := rawdb.ReadHeaderNumber(tx, rawdb.ReadHeadBlockHash(tx))
+			if head == nil {
+				return fmt.Errorf("head block not found")
+			}
+			to = *head
+		}
+		if from > to {
+			return fmt.Errorf("nothing to export: from %d is above to %d", from, to)
+		}
+		log.Info("Exporting blockchain", "file", fn, "from", from, "to", to)
+
+		for n := from; n <= to; n++ {
+			hash, err := blockReader.CanonicalHash(ctx, tx, n)
+			if err != nil {
+				return err
+			}
+			block, _, err := blockReader.BlockWithSenders(ctx, tx, hash, n)
+			if err != nil {
+				return err
+			}
+			if block == nil {
+				return fmt.Errorf("canonical block %d %x not found", n, hash)
+			}
+			if err := rlp.Encode(wr, block); err != nil {
+				return err
+			}
+
+			select {
+			case <-logEvery.C:
+				progress := 100 * float64(n-from+1) / float64(to-from+1)
+				log.Info("[export] ", "block", n, "progress", fmt.Sprintf("%.2f%%", progress))
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := wr.Flush(); err != nil {
+		return err
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return err
+		}
+	}
+	if err := fh.Close(); err != nil {
+		return err
+	}
+	log.Info("Exported blockchain", "file", fn, "blocks", to-from+1)
+	return nil
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/turbo/snapshotsync"
+	"github.com/ledgerwatch/erigon/turbo/stages"
+)
+
+// readExported - decodes the blocks of the file as the import command does
+func readExported(t *testing.T, fn string) []*types.Block {
+	fh, err := os.Open(fn)
+	require.NoError(t, err)
+	defer fh.Close()
+
+	var reader io.Reader = fh
+	if strings.HasSuffix(fn, ".gz") {
+		reader, err = gzip.NewReader(reader)
+		require.NoError(t, err)
+	}
+	stream := rlp.NewStream(reader, 0)
+	var blocks []*types.Block
+	for {
+		var b types.Block
+		err := stream.Decode(&b)
+		if errors.Is(err, io.EOF) {
+			return blocks
+		}
+		require.NoError(t, err)
+		blocks = append(blocks, &b)
+	}
+}
+
+func TestExportChain(t *testing.T) {
+	m := stages.Mock(t)
+	signer := types.LatestSignerForChainID(m.ChainConfig.ChainID)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 10, func(i int, b *core.BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(b.TxNonce(m.Address), common.Address{1}, uint256.NewInt(1000), params.TxGas, nil, nil), *signer, m.Key)
+		require.NoError(t, err)
+		b.AddTx(tx)
+	}, false /* intermediateHashes */)
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chain))
+	blockReader := snapshotsync.NewBlockReaderWithSnapshots(m.BlockSnapshots)
+	expected := append([]*types.Block{m.Genesis}, chain.Blocks...)
+
+	for _, name := range []string{"chain.rlp", "chain.rlp.gz"} {
+		t.Run(name, func(t *testing.T) {
+			fn := filepath.Join(t.TempDir(), name)
+			require.NoError(t, ExportChain(m.Ctx, m.DB, blockReader, fn, 0, 5, false))
+			require.Len(t, readExported(t, fn), 6)
+
+			// up to the head, after the first range
+			require.NoError(t, ExportChain(m.Ctx, m.DB, blockReader, fn, 6, 0, true))
+			blocks := readExported(t, fn)
+			require.Len(t, blocks, len(expected))
+			for i, b := range blocks {
+				require.Equal(t, expected[i].Hash(), b.Hash(), i)
+				require.Equal(t, len(expected[i].Transactions()), len(b.Transactions()), i)
+			}
+
+			// overwrite
+			require.NoError(t, ExportChain(m.Ctx, m.DB, blockReader, fn, 3, 4, false))
+			blocks = readExported(t, fn)
+			require.Len(t, blocks, 2)
+			require.Equal(t, expected[3].Hash(), blocks[0].Hash())
+
+			require.ErrorContains(t, ExportChain(m.Ctx, m.DB, blockReader, fn, 11, 0, false), "nothing to export")
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/ledgerwatch/erigon-lib/common/datadir"
 	"github.com/ledgerwatch/erigon-lib/common/dir"
 	"github.com/ledgerwatch/erigon-lib/compress"
+	"github.com/ledgerwatch/erigon-lib/downloader/downloadergrpc"
 	"github.com/ledgerwatch/erigon-lib/downloader/snaptype"
 	"github.com/ledgerwatch/erigon-lib/etl"
 	"github.com/ledgerwatch/erigon-lib/kv"
@@ -38,6 +39,7 @@ import (
 	"github.com/ledgerwatch/erigon/turbo/debug"
 	"github.com/ledgerwatch/erigon/turbo/logging"
 	"github.com/ledgerwatch/erigon/turbo/snapshotsync"
+	"github.com/ledgerwatch/erigon/turbo/snapshotsync/snapcfg"
 )
 
 const ASSERT = false
@@ -76,6 +78,18 @@ var snapshotCommand = cli.Command{
 				&SnapshotRebuildFlag,
 			}, debug.Flags, logging.Flags),
 		},
+		{
+			Name:   "verify",
+			Action: doVerifyCommand,
+			Usage:  "Check the hashes, the contents and the indices of the snapshots",
+			Before: func(ctx *cli.Context) error { return debug.Setup(ctx) },
+			Flags: joinFlags([]cli.Flag{
+				&utils.DataDirFlag,
+				&utils.ChainFlag,
+				&SnapshotRedownloadFlag,
+				&utils.DownloaderAddrFlag,
+			}, debug.Flags, logging.Flags),
+		},
 		{
 			Name:   "retire",
 			Action: doRetireCommand,
@@ -137,6 +151,10 @@ var (
 		Usage: "Amount of blocks in each segment",
 		Value: snaptype.Erigon2SegmentSize,
 	}
+	SnapshotRedownloadFlag = cli.BoolFlag{
+		Name:  "redownload",
+		Usage: "Remove the broken preverified segments and download them again with the downloader at --downloader.api.addr",
+	}
 	SnapshotRebuildFlag = cli.BoolFlag{
 		Name:  "rebuild",
 		Usage: "Force rebuild",
@@ -245,6 +263,75 @@ func doIndicesCommand(cliCtx *cli.Context) error {
 	return nil
 }
 
+func doVerifyCommand(cliCtx *cli.Context) error {
+	ctx, cancel := common.RootContext()
+	defer cancel()
+
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	chain := cliCtx.String(utils.ChainFlag.Name)
+	chainConfig := params.ChainConfigByChainName(chain)
+	if chainConfig == nil {
+		return fmt.Errorf("unknown chain %s", chain)
+	}
+	chainID, _ := uint256.FromBig(chainConfig.ChainID)
+	preverified := snapcfg.KnownCfg(chain, nil, nil).Preverified
+
+	report, err := snapshotsync.VerifySnapshots(ctx, dirs.Snap, *chainID, preverified)
+	if err != nil {
+		return err
+	}
+	log.Info("[snapshots] Verified", "segments", report.Segments, "broken segments", len(report.BrokenSegments), "broken indices", len(report.BrokenIndices))
+	if report.Ok() {
+		return nil
+	}
+	for fName := range report.BrokenIndices {
+		log.Warn("[snapshots] Remove the broken index and run `erigon snapshots index` to build it again", "file", fName)
+	}
+	if (len(report.BrokenSegments) == 0) || !cliCtx.Bool(SnapshotRedownloadFlag.Name) {
+		return fmt.Errorf("%d broken segments, %d broken indices", len(report.BrokenSegments), len(report.BrokenIndices))
+	}
+
+	// The indices of the downloaded segments are built by the node, or by `erigon snapshots index`
+	hashes := make(map[string]string, len(preverified))
+	for _, p := range preverified {
+		hashes[p.Name] = p.Hash
+	}
+	var downloadRequest []snapshotsync.DownloadRequest
+	for fName := range report.BrokenSegments {
+		hash, ok := hashes[fName]
+		if !ok {
+			log.Warn("[snapshots] Not a preverified segment, can't download it", "file", fName)
+			continue
+		}
+		sn, err := snaptype.ParseFileName(dirs.Snap, fName)
+		if err != nil {
+			return err
+		}
+		toRemove := []string{sn.Path, sn.Path + ".torrent", filepath.Join(dirs.Snap, snaptype.IdxFileName(sn.From, sn.To, sn.T.String()))}
+		if sn.T == snaptype.Transactions {
+			toRemove = append(toRemove, filepath.Join(dirs.Snap, snaptype.IdxFileName(sn.From, sn.To, snaptype.Transactions2Block.String())))
+		}
+		for _, path := range toRemove {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+		downloadRequest = append(downloadRequest, snapshotsync.NewDownloadRequest(nil, fName, hash))
+	}
+	if len(downloadRequest) == 0 {
+		return fmt.Errorf("%d broken segments, none can be downloaded", len(report.BrokenSegments))
+	}
+	downloaderClient, err := downloadergrpc.NewClient(ctx, cliCtx.String(utils.DownloaderAddrFlag.Name))
+	if err != nil {
+		return err
+	}
+	if err := snapshotsync.RequestSnapshotsDownload(ctx, downloadRequest, downloaderClient); err != nil {
+		return err
+	}
+	log.Info("[snapshots] Requested the download of the broken segments", "segments", len(downloadRequest))
+	return nil
+}
+
 func doUncompress(cliCtx *cli.Context) error {
 	ctx, cancel := common.RootContext()
 	defer cancel()
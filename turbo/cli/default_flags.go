@@ -34,6 +34,7 @@ var DefaultFlags = []cli.Flag{
 	&PruneReceiptBeforeFlag,
 	&PruneTxIndexBeforeFlag,
 	&PruneCallTracesBeforeFlag,
+	&PruneKeepAddressesFlag,
 	&BatchSizeFlag,
 	&BlockDownloaderWindowFlag,
 	&DatabaseVerbosityFlag,
@@ -46,6 +47,7 @@ var DefaultFlags = []cli.Flag{
 	&TLSCACertFlag,
 	&StateStreamDisableFlag,
 	&SyncLoopThrottleFlag,
+	&StateAuditIntervalFlag,
 	&BadBlockFlag,
 
 	&utils.HTTPEnabledFlag,
@@ -92,9 +94,17 @@ var DefaultFlags = []cli.Flag{
 	&utils.ListenPortFlag,
 	&utils.P2pProtocolVersionFlag,
 	&utils.P2pProtocolAllowedPorts,
+	&utils.P2pSnapServerFlag,
+	&utils.P2pEgressRateFlag,
+	&utils.P2pPeerEgressRateFlag,
+	&utils.P2pCaptureDirFlag,
+	&utils.P2pCaptureFileSizeFlag,
+	&utils.P2pCaptureFilesFlag,
+	&utils.P2pServeRecentBlocksFlag,
 	&utils.NATFlag,
 	&utils.NoDiscoverFlag,
 	&utils.DiscoveryV5Flag,
+	&utils.DiscoveryV5TopicsFlag,
 	&utils.NetrestrictFlag,
 	&utils.NodeKeyFileFlag,
 	&utils.NodeKeyHexFlag,
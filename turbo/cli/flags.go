@@ -105,6 +105,10 @@ var (
 		Name:  "prune.c.before",
 		Usage: `Prune data before this block`,
 	}
+	PruneKeepAddressesFlag = cli.StringFlag{
+		Name:  "prune.keep.addresses",
+		Usage: `Comma separated list of addresses: their history (h), and receipts and logs they emitted (r) are not pruned`,
+	}
 
 	ExperimentsFlag = cli.StringFlag{
 		Name: "experiments",
@@ -145,6 +149,11 @@ var (
 		Value: "",
 	}
 
+	StateAuditIntervalFlag = cli.DurationFlag{
+		Name:  "state.audit.interval",
+		Usage: "Periodically recompute the state root from the plain state, in a read-only transaction, and compare it with the header (e.g. 12h, default is disabled). Needs space in tmpdir for the hashed state",
+	}
+
 	BadBlockFlag = cli.StringFlag{
 		Name:  "bad.block",
 		Usage: "Marks block with given hex string as bad and forces initial reorg before normal staged sync",
@@ -207,6 +216,7 @@ func ApplyFlagsForEthConfig(ctx *cli.Context, cfg *ethconfig.Config) {
 		ctx.Uint64(PruneReceiptBeforeFlag.Name),
 		ctx.Uint64(PruneTxIndexBeforeFlag.Name),
 		ctx.Uint64(PruneCallTracesBeforeFlag.Name),
+		strings.Split(ctx.String(PruneKeepAddressesFlag.Name), ","),
 		strings.Split(ctx.String(ExperimentsFlag.Name), ","),
 	)
 	if err != nil {
@@ -241,6 +251,8 @@ func ApplyFlagsForEthConfig(ctx *cli.Context, cfg *ethconfig.Config) {
 		cfg.Sync.LoopThrottle = syncLoopThrottle
 	}
 
+	cfg.StateAuditInterval = ctx.Duration(StateAuditIntervalFlag.Name)
+
 	if ctx.String(BadBlockFlag.Name) != "" {
 		bytes, err := hexutil.Decode(ctx.String(BadBlockFlag.Name))
 		if err != nil {
@@ -298,8 +310,12 @@ func ApplyFlagsForEthConfigCobra(f *pflag.FlagSet, cfg *ethconfig.Config) {
 		if v := f.Uint64(PruneCallTracesBeforeFlag.Name, PruneCallTracesBeforeFlag.Value, PruneCallTracesBeforeFlag.Usage); v != nil {
 			beforeC = *v
 		}
+		var keepAddresses []string
+		if v := f.StringSlice(PruneKeepAddressesFlag.Name, nil, PruneKeepAddressesFlag.Usage); v != nil {
+			keepAddresses = *v
+		}
 
-		mode, err := prune.FromCli(cfg.Genesis.Config.ChainID.Uint64(), *v, exactH, exactR, exactT, exactC, beforeH, beforeR, beforeT, beforeC, experiments)
+		mode, err := prune.FromCli(cfg.Genesis.Config.ChainID.Uint64(), *v, exactH, exactR, exactT, exactC, beforeH, beforeR, beforeT, beforeC, keepAddresses, experiments)
 		if err != nil {
 			utils.Fatalf(fmt.Sprintf("error while parsing mode: %v", err))
 		}
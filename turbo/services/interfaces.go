@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/erigon/common"
@@ -37,6 +38,15 @@ type TxnReader interface {
 	TxnLookup(ctx context.Context, tx kv.Getter, txnHash common.Hash) (uint64, bool, error)
 	TxnByIdxInBlock(ctx context.Context, tx kv.Getter, blockNum uint64, i int) (txn types.Transaction, err error)
 }
+
+// ErrReceiptsUnavailable - the receipts of the block are not in the db, and the reader can't read them from the snapshots
+var ErrReceiptsUnavailable = errors.New("receipts are not in the db and can't be read from the snapshots")
+
+type ReceiptsReader interface {
+	// RawReceipts - the receipts of the block, without the fields derived from the block
+	RawReceipts(ctx context.Context, tx kv.Tx, blockHeight uint64) (types.Receipts, error)
+}
+
 type HeaderAndCanonicalReader interface {
 	HeaderReader
 	CanonicalReader
@@ -54,4 +64,5 @@ type FullBlockReader interface {
 	HeaderReader
 	TxnReader
 	CanonicalReader
+	ReceiptsReader
 }
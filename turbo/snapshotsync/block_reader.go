@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"fmt"
 
+	common2 "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/common/dbg"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
@@ -15,6 +16,7 @@ import (
 	"github.com/ledgerwatch/erigon/core/rawdb"
 	"github.com/ledgerwatch/erigon/core/types"
 	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/turbo/services"
 )
 
 // BlockReader can read blocks from db and snapshots
@@ -115,6 +117,10 @@ func (back *BlockReader) TxnByIdxInBlock(ctx context.Context, tx kv.Getter, bloc
 	return txn, nil
 }
 
+func (back *BlockReader) RawReceipts(ctx context.Context, tx kv.Tx, blockHeight uint64) (types.Receipts, error) {
+	return rawdb.ReadRawReceipts(tx, blockHeight), nil
+}
+
 type RemoteBlockReader struct {
 	client remote.ETHBACKENDClient
 }
@@ -249,6 +255,23 @@ func (back *RemoteBlockReader) BodyRlp(ctx context.Context, tx kv.Getter, hash c
 	return bodyRlp, nil
 }
 
+// RawReceipts - only the receipts of the db are read: the remote backend does not serve the receipts of the
+// snapshots, so the receipts pruned from the db are reported as services.ErrReceiptsUnavailable
+func (back *RemoteBlockReader) RawReceipts(ctx context.Context, tx kv.Tx, blockHeight uint64) (types.Receipts, error) {
+	if receipts := rawdb.ReadRawReceipts(tx, blockHeight); receipts != nil {
+		return receipts, nil
+	}
+	// The blocks without receipts have an empty entry in the db
+	ok, err := tx.Has(kv.Receipts, common2.EncodeTs(blockHeight))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("block %d: %w", blockHeight, services.ErrReceiptsUnavailable)
+	}
+	return nil, nil
+}
+
 // BlockReaderWithSnapshots can read blocks from db and snapshots
 type BlockReaderWithSnapshots struct {
 	sn *RoSnapshots
@@ -601,6 +624,35 @@ func (back *BlockReaderWithSnapshots) bodyForStorageFromSnapshot(blockHeight uin
 	return b, buf, nil
 }
 
+func (back *BlockReaderWithSnapshots) receiptsFromSnapshot(blockHeight uint64, sn *ReceiptsSegment, buf []byte) (types.Receipts, []byte, error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			panic(fmt.Errorf("%+v, snapshot: %d-%d, trace: %s", rec, sn.ranges.from, sn.ranges.to, dbg.Stack()))
+		}
+	}() // avoid crash because Erigon's core does many things
+
+	if sn.idxReceiptsNumber == nil {
+		return nil, buf, nil
+	}
+	receiptsOffset := sn.idxReceiptsNumber.OrdinalLookup(blockHeight - sn.idxReceiptsNumber.BaseDataID())
+
+	gg := sn.seg.MakeGetter()
+	gg.Reset(receiptsOffset)
+	if !gg.HasNext() {
+		return nil, buf, nil
+	}
+	buf, _ = gg.Next(buf[:0])
+	var stored types.ReceiptsForStorage
+	if err := rlp.DecodeBytes(buf, &stored); err != nil {
+		return nil, buf, err
+	}
+	receipts := make(types.Receipts, len(stored))
+	for i, r := range stored {
+		receipts[i] = (*types.Receipt)(r)
+	}
+	return receipts, buf, nil
+}
+
 func (back *BlockReaderWithSnapshots) txsFromSnapshot(baseTxnID uint64, txsAmount uint32, txsSeg *TxnSegment, buf []byte) (txs []types.Transaction, senders []common.Address, err error) {
 	defer func() {
 		if rec := recover(); rec != nil {
@@ -798,3 +850,22 @@ func (back *BlockReaderWithSnapshots) TxnLookup(ctx context.Context, tx kv.Gette
 	}
 	return blockNum, true, nil
 }
+
+// RawReceipts - the receipts are read from the snapshots only if the db has no receipts of the block: they are
+// pruned there
+func (back *BlockReaderWithSnapshots) RawReceipts(ctx context.Context, tx kv.Tx, blockHeight uint64) (receipts types.Receipts, err error) {
+	if receipts = rawdb.ReadRawReceipts(tx, blockHeight); receipts != nil {
+		return receipts, nil
+	}
+	ok, err := back.sn.ViewReceipts(blockHeight, func(segment *ReceiptsSegment) error {
+		receipts, _, err = back.receiptsFromSnapshot(blockHeight, segment, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return receipts, nil
+}
@@ -74,6 +74,12 @@ type TxnSegment struct {
 	ranges              Range
 }
 
+type ReceiptsSegment struct {
+	seg               *compress.Decompressor // value: rlp(types.ReceiptsForStorage)
+	idxReceiptsNumber *recsplit.Index        // block_num_u64     -> receipts_segment_offset
+	ranges            Range
+}
+
 func (sn *HeaderSegment) closeIdx() {
 	if sn.idxHeaderHash != nil {
 		sn.idxHeaderHash.Close()
@@ -289,6 +295,67 @@ func (sn *TxnSegment) reopenIdxIfNeed(dir string, optimistic bool) (err error) {
 	return nil
 }
 
+func (sn *ReceiptsSegment) closeSeg() {
+	if sn.seg != nil {
+		sn.seg.Close()
+		sn.seg = nil
+	}
+}
+func (sn *ReceiptsSegment) closeIdx() {
+	if sn.idxReceiptsNumber != nil {
+		sn.idxReceiptsNumber.Close()
+		sn.idxReceiptsNumber = nil
+	}
+}
+func (sn *ReceiptsSegment) close() {
+	sn.closeSeg()
+	sn.closeIdx()
+}
+
+func (sn *ReceiptsSegment) reopenSeg(dir string) (err error) {
+	sn.closeSeg()
+	fileName := snaptype.SegmentFileName(sn.ranges.from, sn.ranges.to, snaptype.Receipts)
+	sn.seg, err = compress.NewDecompressor(path.Join(dir, fileName))
+	if err != nil {
+		return fmt.Errorf("%w, fileName: %s", err, fileName)
+	}
+	return nil
+}
+func (sn *ReceiptsSegment) reopenIdxIfNeed(dir string, optimistic bool) (err error) {
+	if sn.idxReceiptsNumber != nil {
+		return nil
+	}
+	err = sn.reopenIdx(dir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			if optimistic {
+				log.Warn("[snapshots] open index", "err", err)
+			} else {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (sn *ReceiptsSegment) reopenIdx(dir string) (err error) {
+	sn.closeIdx()
+	if sn.seg == nil {
+		return nil
+	}
+	fileName := snaptype.IdxFileName(sn.ranges.from, sn.ranges.to, snaptype.Receipts.String())
+	sn.idxReceiptsNumber, err = recsplit.OpenIndex(path.Join(dir, fileName))
+	if err != nil {
+		return fmt.Errorf("%w, fileName: %s", err, fileName)
+	}
+	if sn.idxReceiptsNumber.ModTime().Before(sn.seg.ModTime()) {
+		// Index has been created before the segment file, needs to be ignored (and rebuilt) as inconsistent
+		sn.idxReceiptsNumber.Close()
+		sn.idxReceiptsNumber = nil
+	}
+	return nil
+}
+
 type headerSegments struct {
 	lock     sync.RWMutex
 	segments []*HeaderSegment
@@ -355,13 +422,36 @@ func (s *txnSegments) ViewSegment(blockNum uint64, f func(*TxnSegment) error) (f
 	return false, nil
 }
 
+type receiptsSegments struct {
+	lock     sync.RWMutex
+	segments []*ReceiptsSegment
+}
+
+func (s *receiptsSegments) View(f func([]*ReceiptsSegment) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return f(s.segments)
+}
+func (s *receiptsSegments) ViewSegment(blockNum uint64, f func(*ReceiptsSegment) error) (found bool, err error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for _, seg := range s.segments {
+		if !(blockNum >= seg.ranges.from && blockNum < seg.ranges.to) {
+			continue
+		}
+		return true, f(seg)
+	}
+	return false, nil
+}
+
 type RoSnapshots struct {
 	indicesReady  atomic.Bool
 	segmentsReady atomic.Bool
 
-	Headers *headerSegments
-	Bodies  *bodySegments
-	Txs     *txnSegments
+	Headers  *headerSegments
+	Bodies   *bodySegments
+	Txs      *txnSegments
+	Receipts *receiptsSegments // optional: the ranges of the blocks don't depend on them
 
 	dir         string
 	segmentsMax atomic.Uint64 // all types of .seg files are available - up to this number
@@ -374,8 +464,9 @@ type RoSnapshots struct {
 //   - all snapshots of given blocks range must exist - to make this blocks range available
 //   - gaps are not allowed
 //   - segment have [from:to) semantic
+//   - receipts are the exception: they may be missing for any range, without making the blocks unavailable
 func NewRoSnapshots(cfg ethconfig.Snapshot, snapDir string) *RoSnapshots {
-	return &RoSnapshots{dir: snapDir, cfg: cfg, Headers: &headerSegments{}, Bodies: &bodySegments{}, Txs: &txnSegments{}}
+	return &RoSnapshots{dir: snapDir, cfg: cfg, Headers: &headerSegments{}, Bodies: &bodySegments{}, Txs: &txnSegments{}, Receipts: &receiptsSegments{}}
 }
 
 func (s *RoSnapshots) Cfg() ethconfig.Snapshot { return s.cfg }
@@ -409,6 +500,8 @@ func (s *RoSnapshots) DisableReadAhead() {
 	defer s.Bodies.lock.RUnlock()
 	s.Txs.lock.RLock()
 	defer s.Txs.lock.RUnlock()
+	s.Receipts.lock.RLock()
+	defer s.Receipts.lock.RUnlock()
 	for _, sn := range s.Headers.segments {
 		sn.seg.DisableReadAhead()
 	}
@@ -418,6 +511,9 @@ func (s *RoSnapshots) DisableReadAhead() {
 	for _, sn := range s.Txs.segments {
 		sn.Seg.DisableReadAhead()
 	}
+	for _, sn := range s.Receipts.segments {
+		sn.seg.DisableReadAhead()
+	}
 }
 func (s *RoSnapshots) EnableReadAhead() *RoSnapshots {
 	s.Headers.lock.RLock()
@@ -426,6 +522,8 @@ func (s *RoSnapshots) EnableReadAhead() *RoSnapshots {
 	defer s.Bodies.lock.RUnlock()
 	s.Txs.lock.RLock()
 	defer s.Txs.lock.RUnlock()
+	s.Receipts.lock.RLock()
+	defer s.Receipts.lock.RUnlock()
 	for _, sn := range s.Headers.segments {
 		sn.seg.EnableReadAhead()
 	}
@@ -435,6 +533,9 @@ func (s *RoSnapshots) EnableReadAhead() *RoSnapshots {
 	for _, sn := range s.Txs.segments {
 		sn.Seg.EnableReadAhead()
 	}
+	for _, sn := range s.Receipts.segments {
+		sn.seg.EnableReadAhead()
+	}
 	return s
 }
 func (s *RoSnapshots) EnableMadvWillNeed() *RoSnapshots {
@@ -444,6 +545,8 @@ func (s *RoSnapshots) EnableMadvWillNeed() *RoSnapshots {
 	defer s.Bodies.lock.RUnlock()
 	s.Txs.lock.RLock()
 	defer s.Txs.lock.RUnlock()
+	s.Receipts.lock.RLock()
+	defer s.Receipts.lock.RUnlock()
 	for _, sn := range s.Headers.segments {
 		sn.seg.EnableWillNeed()
 	}
@@ -453,6 +556,9 @@ func (s *RoSnapshots) EnableMadvWillNeed() *RoSnapshots {
 	for _, sn := range s.Txs.segments {
 		sn.Seg.EnableWillNeed()
 	}
+	for _, sn := range s.Receipts.segments {
+		sn.seg.EnableWillNeed()
+	}
 	return s
 }
 func (s *RoSnapshots) EnableMadvNormal() *RoSnapshots {
@@ -462,6 +568,8 @@ func (s *RoSnapshots) EnableMadvNormal() *RoSnapshots {
 	defer s.Bodies.lock.RUnlock()
 	s.Txs.lock.RLock()
 	defer s.Txs.lock.RUnlock()
+	s.Receipts.lock.RLock()
+	defer s.Receipts.lock.RUnlock()
 	for _, sn := range s.Headers.segments {
 		sn.seg.EnableMadvNormal()
 	}
@@ -471,6 +579,9 @@ func (s *RoSnapshots) EnableMadvNormal() *RoSnapshots {
 	for _, sn := range s.Txs.segments {
 		sn.Seg.EnableMadvNormal()
 	}
+	for _, sn := range s.Receipts.segments {
+		sn.seg.EnableMadvNormal()
+	}
 	return s
 }
 
@@ -517,6 +628,8 @@ func (s *RoSnapshots) Files() (list []string) {
 	defer s.Bodies.lock.RUnlock()
 	s.Txs.lock.RLock()
 	defer s.Txs.lock.RUnlock()
+	s.Receipts.lock.RLock()
+	defer s.Receipts.lock.RUnlock()
 	max := s.BlocksAvailable()
 	for _, seg := range s.Bodies.segments {
 		if seg.seg == nil {
@@ -548,6 +661,16 @@ func (s *RoSnapshots) Files() (list []string) {
 		_, fName := filepath.Split(seg.Seg.FilePath())
 		list = append(list, fName)
 	}
+	for _, seg := range s.Receipts.segments {
+		if seg.seg == nil {
+			continue
+		}
+		if seg.ranges.from > max {
+			continue
+		}
+		_, fName := filepath.Split(seg.seg.FilePath())
+		list = append(list, fName)
+	}
 	slices.Sort(list)
 	return list
 }
@@ -560,6 +683,8 @@ func (s *RoSnapshots) ReopenList(fileNames []string, optimistic bool) error {
 	defer s.Bodies.lock.Unlock()
 	s.Txs.lock.Lock()
 	defer s.Txs.lock.Unlock()
+	s.Receipts.lock.Lock()
+	defer s.Receipts.lock.Unlock()
 
 	s.closeWhatNotInList(fileNames)
 	var segmentsMax uint64
@@ -678,6 +803,33 @@ Loop:
 			if err := sn.reopenIdxIfNeed(s.dir, optimistic); err != nil {
 				return err
 			}
+		case snaptype.Receipts:
+			for _, sn := range s.Receipts.segments {
+				if sn.seg == nil {
+					continue
+				}
+				_, name := filepath.Split(sn.seg.FilePath())
+				if fName == name {
+					if err := sn.reopenIdxIfNeed(s.dir, optimistic); err != nil {
+						return err
+					}
+					continue Loop
+				}
+			}
+
+			// receipts are optional: a missing or broken file doesn't stop opening the blocks
+			sn := &ReceiptsSegment{ranges: Range{f.From, f.To}}
+			if err := sn.reopenSeg(s.dir); err != nil {
+				if !errors.Is(err, os.ErrNotExist) {
+					log.Warn("[snapshots] open segment", "err", err)
+				}
+				continue Loop
+			}
+			s.Receipts.segments = append(s.Receipts.segments, sn)
+			if err := sn.reopenIdxIfNeed(s.dir, optimistic); err != nil {
+				return err
+			}
+			continue Loop // they don't change the available blocks
 		}
 
 		if f.To > 0 {
@@ -741,6 +893,8 @@ func (s *RoSnapshots) Close() {
 	defer s.Bodies.lock.Unlock()
 	s.Txs.lock.Lock()
 	defer s.Txs.lock.Unlock()
+	s.Receipts.lock.Lock()
+	defer s.Receipts.lock.Unlock()
 	s.closeWhatNotInList(nil)
 }
 
@@ -787,6 +941,20 @@ Loop3:
 		sn.close()
 		s.Txs.segments[i] = nil
 	}
+Loop4:
+	for i, sn := range s.Receipts.segments {
+		if sn.seg == nil {
+			continue Loop4
+		}
+		_, name := filepath.Split(sn.seg.FilePath())
+		for _, fName := range l {
+			if fName == name {
+				continue Loop4
+			}
+		}
+		sn.close()
+		s.Receipts.segments[i] = nil
+	}
 	var i int
 	for i = 0; i < len(s.Headers.segments) && s.Headers.segments[i] != nil && s.Headers.segments[i].seg != nil; i++ {
 	}
@@ -820,6 +988,17 @@ Loop3:
 			tailC[i] = nil
 		}
 	}
+
+	for i = 0; i < len(s.Receipts.segments) && s.Receipts.segments[i] != nil && s.Receipts.segments[i].seg != nil; i++ {
+	}
+	tailD := s.Receipts.segments[i:]
+	s.Receipts.segments = s.Receipts.segments[:i]
+	for i = 0; i < len(tailD); i++ {
+		if tailD[i] != nil {
+			tailD[i].close()
+			tailD[i] = nil
+		}
+	}
 }
 
 func (s *RoSnapshots) PrintDebug() {
@@ -829,6 +1008,8 @@ func (s *RoSnapshots) PrintDebug() {
 	defer s.Bodies.lock.RUnlock()
 	s.Txs.lock.RLock()
 	defer s.Txs.lock.RUnlock()
+	s.Receipts.lock.RLock()
+	defer s.Receipts.lock.RUnlock()
 	fmt.Println("    == Snapshots, Header")
 	for _, sn := range s.Headers.segments {
 		fmt.Printf("%d,  %t\n", sn.ranges.from, sn.idxHeaderHash == nil)
@@ -841,6 +1022,10 @@ func (s *RoSnapshots) PrintDebug() {
 	for _, sn := range s.Txs.segments {
 		fmt.Printf("%d,  %t, %t\n", sn.ranges.from, sn.IdxTxnHash == nil, sn.IdxTxnHash2BlockNum == nil)
 	}
+	fmt.Println("    == Snapshots, Receipts")
+	for _, sn := range s.Receipts.segments {
+		fmt.Printf("%d,  %t\n", sn.ranges.from, sn.idxReceiptsNumber == nil)
+	}
 }
 func (s *RoSnapshots) ViewHeaders(blockNum uint64, f func(sn *HeaderSegment) error) (found bool, err error) {
 	if !s.indicesReady.Load() || blockNum > s.BlocksAvailable() {
@@ -860,6 +1045,12 @@ func (s *RoSnapshots) ViewTxs(blockNum uint64, f func(sn *TxnSegment) error) (fo
 	}
 	return s.Txs.ViewSegment(blockNum, f)
 }
+func (s *RoSnapshots) ViewReceipts(blockNum uint64, f func(sn *ReceiptsSegment) error) (found bool, err error) {
+	if !s.indicesReady.Load() || blockNum > s.BlocksAvailable() {
+		return false, nil
+	}
+	return s.Receipts.ViewSegment(blockNum, f)
+}
 
 func buildIdx(ctx context.Context, sn snaptype.FileInfo, chainID uint256.Int, tmpDir string, p *background.Progress, lvl log.Lvl) error {
 	_, fName := filepath.Split(sn.Path)
@@ -878,6 +1069,10 @@ func buildIdx(ctx context.Context, sn snaptype.FileInfo, chainID uint256.Int, tm
 		if err := TransactionsIdx(ctx, chainID, sn.From, sn.To, dir, tmpDir, p, lvl); err != nil {
 			return err
 		}
+	case snaptype.Receipts:
+		if err := ReceiptsIdx(ctx, sn.Path, sn.From, tmpDir, p, lvl); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -895,7 +1090,7 @@ func BuildMissedIndices(logPrefix string, ctx context.Context, dirs datadir.Dirs
 	startIndexingTime := time.Now()
 
 	g, gCtx := errgroup.WithContext(ctx)
-	for _, t := range snaptype.AllSnapshotTypes {
+	for _, t := range append(slices.Clone(snaptype.AllSnapshotTypes), snaptype.OptionalSnapshotTypes...) {
 		for index := range segments {
 			segment := segments[index]
 			if segment.T != t {
@@ -1035,6 +1230,17 @@ func Segments(dir string) (res []snaptype.FileInfo, missingSnapshots []Range, er
 		l, _ = noGaps(noOverlaps(allTypeOfSegmentsMustExist(dir, l)))
 		res = append(res, l...)
 	}
+	{
+		// receipts don't need the other types of the range, and may have gaps
+		var l []snaptype.FileInfo
+		for _, f := range list {
+			if f.T != snaptype.Receipts {
+				continue
+			}
+			l = append(l, f)
+		}
+		res = append(res, noOverlaps(l)...)
+	}
 
 	return res, missingSnapshots, nil
 }
@@ -1199,7 +1405,13 @@ func retireBlocks(ctx context.Context, blockFrom, blockTo uint64, chainID uint25
 	if downloader != nil && !reflect.ValueOf(downloader).IsNil() {
 		downloadRequest := make([]DownloadRequest, 0, len(rangesToMerge))
 		for i := range rangesToMerge {
-			downloadRequest = append(downloadRequest, NewDownloadRequest(&rangesToMerge[i], "", ""))
+			r := &rangesToMerge[i]
+			downloadRequest = append(downloadRequest, NewDownloadRequest(r, "", ""))
+			// the receipts are seeded only if the range has them
+			receiptsName := snaptype.SegmentFileName(r.from, r.to, snaptype.Receipts)
+			if r.to-r.from == snaptype.Erigon2SegmentSize && dir2.FileExist(filepath.Join(snapshots.Dir(), receiptsName)) {
+				downloadRequest = append(downloadRequest, NewDownloadRequest(nil, receiptsName, ""))
+			}
 		}
 
 		if err := RequestSnapshotsDownload(ctx, downloadRequest, downloader); err != nil {
@@ -1255,6 +1467,20 @@ func dumpBlocksRange(ctx context.Context, blockFrom, blockTo uint64, tmpDir, sna
 		return err
 	}
 
+	segName = snaptype.SegmentFileName(blockFrom, blockTo, snaptype.Receipts)
+	f, _ = snaptype.ParseFileName(snapDir, segName)
+	if err := DumpReceipts(ctx, chainDB, f.Path, tmpDir, blockFrom, blockTo, workers, lvl); err != nil {
+		if !errors.Is(err, ErrReceiptsMissed) {
+			return fmt.Errorf("DumpReceipts: %w", err)
+		}
+		log.Log(lvl, "[snapshots] Receipts are not retired", "range", fmt.Sprintf("%dk-%dk", blockFrom/1000, blockTo/1000), "err", err)
+		return nil
+	}
+	p = &background.Progress{}
+	if err := buildIdx(ctx, f, *chainId, tmpDir, p, lvl); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1278,7 +1504,7 @@ func hasIdxFile(sn *snaptype.FileInfo) bool {
 			result = false
 		}
 		_ = idx.Close()
-	case snaptype.Bodies:
+	case snaptype.Bodies, snaptype.Receipts:
 		idx, err := recsplit.OpenIndex(path.Join(dir, fName))
 		if err != nil {
 			return false
@@ -1618,6 +1844,71 @@ func DumpBodies(ctx context.Context, db kv.RoDB, segmentFilePath, tmpDir string,
 	return nil
 }
 
+// ErrReceiptsMissed - the database has no receipts of some blocks of the range: they are pruned, or the blocks
+// are not executed yet
+var ErrReceiptsMissed = errors.New("receipts missed in db")
+
+// DumpReceipts - [from, to)
+func DumpReceipts(ctx context.Context, db kv.RoDB, segmentFilePath, tmpDir string, blockFrom, blockTo uint64, workers int, lvl log.Lvl) error {
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+
+	f, err := compress.NewCompressor(ctx, "Snapshot Receipts", segmentFilePath, tmpDir, compress.MinPatternScore, workers, lvl)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	from := common2.EncodeTs(blockFrom)
+	if err := kv.BigChunks(db, kv.HeaderCanonical, from, func(tx kv.Tx, k, v []byte) (bool, error) {
+		blockNum := binary.BigEndian.Uint64(k)
+		if blockNum >= blockTo {
+			return false, nil
+		}
+		if has, err := tx.Has(kv.Receipts, k); err != nil {
+			return false, err
+		} else if !has {
+			return false, fmt.Errorf("%w: block_num=%d", ErrReceiptsMissed, blockNum)
+		}
+		receipts := rawdb.ReadRawReceipts(tx, blockNum)
+		stored := make(types.ReceiptsForStorage, len(receipts))
+		for i, r := range receipts {
+			stored[i] = (*types.ReceiptForStorage)(r)
+		}
+		dataRLP, err := rlp.EncodeToBytes(stored)
+		if err != nil {
+			return false, err
+		}
+		if err := f.AddWord(dataRLP); err != nil {
+			return false, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-logEvery.C:
+			var m runtime.MemStats
+			if lvl >= log.LvlInfo {
+				dbg.ReadMemStats(&m)
+			}
+			log.Log(lvl, "[snapshots] Dumping receipts", "block num", blockNum,
+				"alloc", common2.ByteCount(m.Alloc), "sys", common2.ByteCount(m.Sys),
+			)
+		default:
+		}
+		return true, nil
+	}); err != nil {
+		return err
+	}
+	if uint64(f.Count()) != blockTo-blockFrom {
+		return fmt.Errorf("%w: %d blocks of %d", ErrReceiptsMissed, f.Count(), blockTo-blockFrom)
+	}
+	if err := f.Compress(); err != nil {
+		return fmt.Errorf("compress: %w", err)
+	}
+	return nil
+}
+
 var EmptyTxHash = common.Hash{}
 
 func expectedTxsAmount(snapDir string, blockFrom, blockTo uint64) (firstTxID, expectedCount uint64, err error) {
@@ -1881,6 +2172,39 @@ func BodiesIdx(ctx context.Context, segmentFilePath string, firstBlockNumInSegme
 	return nil
 }
 
+func ReceiptsIdx(ctx context.Context, segmentFilePath string, firstBlockNumInSegment uint64, tmpDir string, p *background.Progress, lvl log.Lvl) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			_, fName := filepath.Split(segmentFilePath)
+			err = fmt.Errorf("ReceiptsIdx: at=%s, %v, %s", fName, rec, dbg.Stack())
+		}
+	}()
+
+	num := make([]byte, 8)
+
+	d, err := compress.NewDecompressor(segmentFilePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	_, fname := filepath.Split(segmentFilePath)
+	p.Name.Store(fname)
+	p.Total.Store(uint64(d.Count()))
+
+	if err := Idx(ctx, d, firstBlockNumInSegment, tmpDir, log.LvlDebug, func(idx *recsplit.RecSplit, i, offset uint64, word []byte) error {
+		p.Processed.Inc()
+		n := binary.PutUvarint(num, i)
+		if err := idx.AddKey(num[:n], offset); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("ReceiptsNumberIdx: %w", err)
+	}
+	return nil
+}
+
 // Idx - iterate over segment and building .idx file
 func Idx(ctx context.Context, d *compress.Decompressor, firstDataID uint64, tmpDir string, lvl log.Lvl, walker func(idx *recsplit.RecSplit, i, offset uint64, word []byte) error) error {
 	segmentFileName := d.FilePath()
@@ -2029,6 +2353,28 @@ func (m *Merger) filesByRange(snapshots *RoSnapshots, from, to uint64) (map[snap
 			})
 		})
 	})
+	if err != nil {
+		return nil, err
+	}
+	// receipts are merged only if all the range has them, otherwise they stay as they are
+	err = snapshots.Receipts.View(func(segments []*ReceiptsSegment) error {
+		var files []string
+		next := from
+		for _, sn := range segments {
+			if sn.ranges.from < from || sn.ranges.to > to {
+				continue
+			}
+			if sn.ranges.from != next {
+				return nil
+			}
+			files = append(files, sn.seg.FilePath())
+			next = sn.ranges.to
+		}
+		if next == to {
+			toMerge[snaptype.Receipts] = files
+		}
+		return nil
+	})
 	return toMerge, err
 }
 
@@ -2045,7 +2391,10 @@ func (m *Merger) Merge(ctx context.Context, snapshots *RoSnapshots, mergeRanges
 		if err != nil {
 			return err
 		}
-		for _, t := range snaptype.AllSnapshotTypes {
+		for _, t := range append(slices.Clone(snaptype.AllSnapshotTypes), snaptype.OptionalSnapshotTypes...) {
+			if len(toMerge[t]) == 0 {
+				continue
+			}
 			segName := snaptype.SegmentFileName(r.from, r.to, t)
 			f, _ := snaptype.ParseFileName(snapDir, segName)
 			if err := m.merge(ctx, toMerge[t], f.Path, logEvery); err != nil {
@@ -2066,7 +2415,7 @@ func (m *Merger) Merge(ctx context.Context, snapshots *RoSnapshots, mergeRanges
 			m.notifier.OnNewSnapshot()
 			time.Sleep(1 * time.Second) // i working on blocking API - to ensure client does not use old snapsthos - and then delete them
 		}
-		for _, t := range snaptype.AllSnapshotTypes {
+		for _, t := range append(slices.Clone(snaptype.AllSnapshotTypes), snaptype.OptionalSnapshotTypes...) {
 			m.removeOldFiles(toMerge[t], snapDir)
 		}
 	}
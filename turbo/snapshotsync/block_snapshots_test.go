@@ -7,13 +7,20 @@ import (
 	"testing/fstest"
 
 	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/erigon-lib/common/background"
+	dir2 "github.com/ledgerwatch/erigon-lib/common/dir"
 	"github.com/ledgerwatch/erigon-lib/compress"
 	"github.com/ledgerwatch/erigon-lib/downloader/snaptype"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
 	"github.com/ledgerwatch/erigon-lib/recsplit"
 	"github.com/ledgerwatch/erigon/common"
 	"github.com/ledgerwatch/erigon/common/math"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types"
 	"github.com/ledgerwatch/erigon/eth/ethconfig"
 	"github.com/ledgerwatch/erigon/params/networkname"
+	"github.com/ledgerwatch/erigon/turbo/services"
 	"github.com/ledgerwatch/erigon/turbo/snapshotsync/snapcfg"
 	"github.com/ledgerwatch/log/v3"
 	"github.com/stretchr/testify/require"
@@ -105,6 +112,112 @@ func TestMergeSnapshots(t *testing.T) {
 	require.Equal(1, a)
 }
 
+func TestMergeReceiptsSnapshots(t *testing.T) {
+	dir, require := t.TempDir(), require.New(t)
+	for i := uint64(0); i < 500_000; i += 100_000 {
+		for _, snT := range snaptype.AllSnapshotTypes {
+			createTestSegmentFile(t, i, i+100_000, snT, dir)
+		}
+	}
+	// the receipts of the first 200k are merged, of the last 300k are not: one part is missing
+	createTestSegmentFile(t, 0, 100_000, snaptype.Receipts, dir)
+	createTestSegmentFile(t, 100_000, 200_000, snaptype.Receipts, dir)
+	createTestSegmentFile(t, 200_000, 300_000, snaptype.Receipts, dir)
+	createTestSegmentFile(t, 400_000, 500_000, snaptype.Receipts, dir)
+
+	s := NewRoSnapshots(ethconfig.Snapshot{Enabled: true}, dir)
+	defer s.Close()
+	require.NoError(s.ReopenFolder())
+	require.Equal(4, len(s.Receipts.segments))
+
+	merger := NewMerger(dir, 1, log.LvlInfo, uint256.Int{}, nil)
+	require.NoError(merger.Merge(context.Background(), s, []Range{{0, 200_000}, {200_000, 500_000}}, s.Dir(), false))
+
+	require.True(dir2.FileExist(filepath.Join(dir, snaptype.SegmentFileName(0, 200_000, snaptype.Receipts))))
+	require.False(dir2.FileExist(filepath.Join(dir, snaptype.SegmentFileName(0, 100_000, snaptype.Receipts))))
+	require.False(dir2.FileExist(filepath.Join(dir, snaptype.SegmentFileName(200_000, 500_000, snaptype.Receipts))))
+	require.True(dir2.FileExist(filepath.Join(dir, snaptype.SegmentFileName(200_000, 300_000, snaptype.Receipts))))
+	require.True(dir2.FileExist(filepath.Join(dir, snaptype.SegmentFileName(400_000, 500_000, snaptype.Receipts))))
+
+	require.NoError(s.ReopenFolder())
+	require.Equal(2, len(s.Headers.segments))
+	require.Equal(3, len(s.Receipts.segments))
+}
+
+func TestDumpReceipts(t *testing.T) {
+	ctx, dir, require := context.Background(), t.TempDir(), require.New(t)
+	createVerifyTestRange(t, dir, 0, 1_000)
+
+	db := memdb.NewTestDB(t)
+	tx, err := db.BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	log1 := &types.Log{Address: common.HexToAddress("0x01"), Topics: []common.Hash{common.HexToHash("0x02")}, Data: []byte{3}}
+	for blockNum := uint64(0); blockNum < 1_000; blockNum++ {
+		require.NoError(rawdb.WriteCanonicalHash(tx, common.Hash{1}, blockNum))
+		var receipts types.Receipts
+		if blockNum == 5 {
+			receipts = types.Receipts{
+				{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21_000},
+				{Status: types.ReceiptStatusFailed, CumulativeGasUsed: 50_000, Logs: types.Logs{log1}},
+			}
+		}
+		if blockNum == 7 { // pruned
+			continue
+		}
+		require.NoError(rawdb.WriteReceipts(tx, blockNum, receipts))
+	}
+	require.NoError(tx.Commit())
+
+	segmentPath := filepath.Join(dir, snaptype.SegmentFileName(0, 1_000, snaptype.Receipts))
+	require.ErrorIs(DumpReceipts(ctx, db, segmentPath, dir, 0, 1_000, 1, log.LvlDebug), ErrReceiptsMissed)
+	require.False(dir2.FileExist(segmentPath))
+
+	require.NoError(db.Update(ctx, func(tx kv.RwTx) error { return rawdb.WriteReceipts(tx, 7, nil) }))
+	require.NoError(DumpReceipts(ctx, db, segmentPath, dir, 0, 1_000, 1, log.LvlDebug))
+	require.NoError(ReceiptsIdx(ctx, segmentPath, 0, dir, &background.Progress{}, log.LvlDebug))
+
+	s := NewRoSnapshots(ethconfig.Snapshot{Enabled: true}, dir)
+	defer s.Close()
+	require.NoError(s.ReopenFolder())
+	require.Equal(1, len(s.Receipts.segments))
+
+	// the db has the receipts, they are pruned, or the db has no blocks at all
+	reader := NewBlockReaderWithSnapshots(s)
+	require.NoError(db.Update(ctx, func(tx kv.RwTx) error { return rawdb.TruncateReceipts(tx, 0) }))
+	roTx, err := db.BeginRo(ctx)
+	require.NoError(err)
+	defer roTx.Rollback()
+	receipts, err := reader.RawReceipts(ctx, roTx, 5)
+	require.NoError(err)
+	require.Equal(2, len(receipts))
+	require.Equal(types.ReceiptStatusSuccessful, receipts[0].Status)
+	require.Equal(uint64(50_000), receipts[1].CumulativeGasUsed)
+	require.Equal(types.ReceiptStatusFailed, receipts[1].Status)
+	require.Equal(1, len(receipts[1].Logs))
+	require.Equal(log1.Address, receipts[1].Logs[0].Address)
+	require.Equal(log1.Topics, receipts[1].Logs[0].Topics)
+	require.Equal(log1.Data, receipts[1].Logs[0].Data)
+
+	receipts, err = reader.RawReceipts(ctx, roTx, 6)
+	require.NoError(err)
+	require.NotNil(receipts)
+	require.Equal(0, len(receipts))
+
+	receipts, err = reader.RawReceipts(ctx, roTx, 1_000)
+	require.NoError(err)
+	require.Nil(receipts)
+
+	// the remote reader can't read the snapshots, the pruned receipts are reported as unavailable
+	_, err = NewRemoteBlockReader(nil).RawReceipts(ctx, roTx, 5)
+	require.ErrorIs(err, services.ErrReceiptsUnavailable)
+
+	report, err := VerifySnapshots(ctx, dir, *uint256.NewInt(1), nil)
+	require.NoError(err)
+	require.True(report.Ok(), "%v %v", report.BrokenSegments, report.BrokenIndices)
+	require.Equal(4, report.Segments)
+}
+
 func TestCanRetire(t *testing.T) {
 	require := require.New(t)
 	cases := []struct {
@@ -190,6 +303,22 @@ func TestOpenAllSnapshot(t *testing.T) {
 	defer s.Close()
 	require.Equal(2, len(s.Headers.segments))
 
+	// receipts are optional, the blocks are available without them
+	createFile(500_000, 1_000_000, snaptype.Receipts)
+	s = NewRoSnapshots(cfg, dir)
+	err = s.ReopenFolder()
+	require.NoError(err)
+	defer s.Close()
+	require.Equal(2, len(s.Headers.segments))
+	require.Equal(1, len(s.Receipts.segments))
+	require.Equal(1_000_000-1, int(s.BlocksAvailable()))
+	ok, err = s.ViewReceipts(10, func(sn *ReceiptsSegment) error { return nil })
+	require.NoError(err)
+	require.False(ok)
+	ok, err = s.ViewReceipts(500_000, func(sn *ReceiptsSegment) error { return nil })
+	require.NoError(err)
+	require.True(ok)
+
 	createFile(500_000, 900_000, snaptype.Headers)
 	createFile(500_000, 900_000, snaptype.Bodies)
 	createFile(500_000, 900_000, snaptype.Transactions)
@@ -237,6 +366,10 @@ func TestParseCompressedFileName(t *testing.T) {
 	require.Equal(f.T, snaptype.Bodies)
 	require.Equal(1_000, int(f.From))
 	require.Equal(2_000, int(f.To))
+
+	f, err = snaptype.ParseFileName("", "v1-1-2-receipts.seg")
+	require.NoError(err)
+	require.Equal(f.T, snaptype.Receipts)
 }
 
 func BenchmarkName(b *testing.B) {
@@ -0,0 +1,423 @@
+package snapshotsync
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/anacrolix/torrent/bencode"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/erigon-lib/common/dbg"
+	"github.com/ledgerwatch/erigon-lib/compress"
+	"github.com/ledgerwatch/erigon-lib/downloader/downloadercfg"
+	"github.com/ledgerwatch/erigon-lib/downloader/snaptype"
+	"github.com/ledgerwatch/erigon-lib/recsplit"
+	types2 "github.com/ledgerwatch/erigon-lib/types"
+	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/exp/slices"
+
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/turbo/snapshotsync/snapcfg"
+)
+
+// VerifyReport lists the broken files found by VerifySnapshots, with the reasons.
+type VerifyReport struct {
+	Segments       int               // segments checked
+	BrokenSegments map[string]string // file name -> reason, fixed by downloading the file again
+	BrokenIndices  map[string]string // file name -> reason, fixed by building the index again
+}
+
+func (r *VerifyReport) Ok() bool { return (len(r.BrokenSegments) == 0) && (len(r.BrokenIndices) == 0) }
+
+func (r *VerifyReport) segment(path string, err error) {
+	_, fName := filepath.Split(path)
+	log.Warn("[snapshots] Broken segment", "file", fName, "err", err)
+	r.BrokenSegments[fName] = err.Error()
+}
+
+func (r *VerifyReport) index(path string, err error) {
+	_, fName := filepath.Split(path)
+	log.Warn("[snapshots] Broken index", "file", fName, "err", err)
+	r.BrokenIndices[fName] = err.Error()
+}
+
+// VerifySnapshots checks the block snapshots in snapDir:
+//   - the torrent hashes of the segments against the preverified ones, if the segments are known
+//   - every word of the segments decompresses and decodes
+//   - the headers, bodies and transactions of each range agree with each other, and the
+//     transaction IDs continue from one range to the next
+//   - the receipts segments, which are optional, have the receipts of every block of their range
+//   - the indices resolve every key to its word
+func VerifySnapshots(ctx context.Context, snapDir string, chainID uint256.Int, preverified snapcfg.Preverified) (*VerifyReport, error) {
+	segments, err := snaptype.Segments(snapDir)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]string, len(preverified))
+	for _, p := range preverified {
+		known[p.Name] = p.Hash
+	}
+	report := &VerifyReport{BrokenSegments: map[string]string{}, BrokenIndices: map[string]string{}}
+
+	type rangeFiles [snaptype.NumberOfTypes]*snaptype.FileInfo
+	var ranges []Range
+	byRange := map[Range]*rangeFiles{}
+	for i := range segments {
+		sn := &segments[i]
+		report.Segments++
+		_, fName := filepath.Split(sn.Path)
+		if hash, ok := known[fName]; ok {
+			if err := verifySegmentHash(sn.Path, hash); err != nil {
+				report.segment(sn.Path, err)
+				continue
+			}
+		} else {
+			log.Info("[snapshots] Not a preverified segment, skipping the hash check", "file", fName)
+		}
+		r := Range{from: sn.From, to: sn.To}
+		if byRange[r] == nil {
+			byRange[r] = &rangeFiles{}
+			ranges = append(ranges, r)
+		}
+		byRange[r][sn.T] = sn
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortFunc(ranges, func(i, j Range) bool {
+		if i.from != j.from {
+			return i.from < j.from
+		}
+		return i.to < j.to
+	})
+
+	var nextTxID *uint64 // expected first transaction ID of the next range
+	var prevTo uint64
+	for _, r := range ranges {
+		files := byRange[r]
+		headers, bodies, txs := files[snaptype.Headers], files[snaptype.Bodies], files[snaptype.Transactions]
+		if headers != nil {
+			if err := verifyHeaders(ctx, headers, report); err != nil {
+				report.segment(headers.Path, err)
+			}
+		}
+		if receipts := files[snaptype.Receipts]; receipts != nil {
+			if err := verifyReceipts(ctx, receipts, report); err != nil {
+				report.segment(receipts.Path, err)
+			}
+		}
+		if (headers == nil) && (bodies == nil) && (txs == nil) { // a range of receipts only
+			continue
+		}
+		if (nextTxID != nil) && (prevTo != r.from) {
+			nextTxID = nil
+		}
+		prevTo = r.to
+		if bodies == nil {
+			nextTxID = nil
+			continue
+		}
+		blocks, err := verifyBodies(ctx, bodies, report)
+		if err != nil {
+			report.segment(bodies.Path, err)
+			nextTxID = nil
+			continue
+		}
+		if (nextTxID != nil) && (blocks[0].BaseTxId != *nextTxID) {
+			report.segment(bodies.Path, fmt.Errorf("first transaction ID %d, the previous range ends at %d", blocks[0].BaseTxId, *nextTxID))
+		}
+		last := blocks[len(blocks)-1]
+		end := last.BaseTxId + uint64(last.TxAmount)
+		nextTxID = &end
+		if txs != nil {
+			if err := verifyTransactions(ctx, txs, blocks, chainID, report); err != nil {
+				report.segment(txs.Path, err)
+			}
+		}
+	}
+	return report, nil
+}
+
+// verifySegmentHash compares the torrent info hash of the file with the preverified hash.
+func verifySegmentHash(path string, expected string) error {
+	_, fName := filepath.Split(path)
+	info := &metainfo.Info{PieceLength: downloadercfg.DefaultPieceSize, Name: fName}
+	if err := info.BuildFromFilePath(path); err != nil {
+		return err
+	}
+	info.Name = fName
+	infoBytes, err := bencode.Marshal(info)
+	if err != nil {
+		return err
+	}
+	if hash := metainfo.HashBytes(infoBytes).HexString(); hash != expected {
+		return fmt.Errorf("torrent hash %s, expected %s", hash, expected)
+	}
+	return nil
+}
+
+// walkSegment calls f for every word of the segment, and turns the panics of a corrupted file into errors.
+func walkSegment(ctx context.Context, path string, f func(i, offset uint64, word []byte) error) (count uint64, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("decompressing word %d: %v, %s", count, rec, dbg.Stack())
+		}
+	}()
+	d, err := compress.NewDecompressor(path)
+	if err != nil {
+		return 0, err
+	}
+	defer d.Close()
+	defer d.EnableReadAhead().DisableReadAhead()
+
+	logEvery := time.NewTicker(30 * time.Second)
+	defer logEvery.Stop()
+
+	g := d.MakeGetter()
+	var offset, nextPos uint64
+	word := make([]byte, 0, 4096)
+	for g.HasNext() {
+		word, nextPos = g.Next(word[:0])
+		if err := f(count, offset, word); err != nil {
+			return count, fmt.Errorf("word %d: %w", count, err)
+		}
+		count++
+		offset = nextPos
+
+		select {
+		case <-logEvery.C:
+			_, fName := filepath.Split(path)
+			log.Info("[snapshots] Verify", "file", fName, "progress", fmt.Sprintf("%.2f%%", 100*float64(count)/float64(d.Count())))
+		case <-ctx.Done():
+			return count, ctx.Err()
+		default:
+		}
+	}
+	if count != uint64(d.Count()) {
+		return count, fmt.Errorf("%d words, the header says %d", count, d.Count())
+	}
+	return count, nil
+}
+
+// openVerifyIndex opens the index of the segment, if it exists, checking its base data ID and key count.
+func openVerifyIndex(sn *snaptype.FileInfo, idxType string, baseDataID, keyCount uint64, report *VerifyReport) (idx *recsplit.Index) {
+	path := filepath.Join(filepath.Dir(sn.Path), snaptype.IdxFileName(sn.From, sn.To, idxType))
+	if _, err := os.Stat(path); err != nil {
+		log.Info("[snapshots] Index not found", "file", filepath.Base(path))
+		return nil
+	}
+	defer func() {
+		if rec := recover(); rec != nil {
+			report.index(path, fmt.Errorf("opening: %v", rec))
+			idx = nil
+		}
+	}()
+	idx, err := recsplit.OpenIndex(path)
+	if err != nil {
+		report.index(path, err)
+		return nil
+	}
+	if idx.BaseDataID() != baseDataID {
+		report.index(path, fmt.Errorf("base data ID %d, expected %d", idx.BaseDataID(), baseDataID))
+		idx.Close()
+		return nil
+	}
+	if idx.KeyCount() != keyCount {
+		report.index(path, fmt.Errorf("%d keys, expected %d", idx.KeyCount(), keyCount))
+		idx.Close()
+		return nil
+	}
+	return idx
+}
+
+// indexCheck checks that an index resolves the keys of the words to their ordinals or values,
+// and the ordinals to the offsets. It stops at the first mismatch.
+type indexCheck struct {
+	idx    *recsplit.Index
+	reader *recsplit.IndexReader
+	report *VerifyReport
+}
+
+func newIndexCheck(idx *recsplit.Index, report *VerifyReport) *indexCheck {
+	if idx == nil {
+		return nil
+	}
+	return &indexCheck{idx: idx, reader: recsplit.NewIndexReader(idx), report: report}
+}
+
+func (c *indexCheck) fail(err error) {
+	c.report.index(c.idx.FilePath(), err)
+	c.idx.Close()
+	c.idx = nil
+}
+
+func (c *indexCheck) close() {
+	if (c != nil) && (c.idx != nil) {
+		c.idx.Close()
+	}
+}
+
+// word checks the word i at the offset with the key, for an index with the ordinals.
+func (c *indexCheck) word(i, offset uint64, key []byte) {
+	if (c == nil) || (c.idx == nil) {
+		return
+	}
+	if ordinal := c.reader.Lookup(key); ordinal != i {
+		c.fail(fmt.Errorf("key %x of word %d resolves to %d", key, i, ordinal))
+		return
+	}
+	if o := c.idx.OrdinalLookup(i); o != offset {
+		c.fail(fmt.Errorf("word %d at offset %d, the index says %d", i, offset, o))
+	}
+}
+
+// value checks the value of the key, for an index without the ordinals.
+func (c *indexCheck) value(key []byte, value uint64) {
+	if (c == nil) || (c.idx == nil) {
+		return
+	}
+	if v := c.reader.Lookup(key); v != value {
+		c.fail(fmt.Errorf("key %x resolves to %d, expected %d", key, v, value))
+	}
+}
+
+func verifyHeaders(ctx context.Context, sn *snaptype.FileInfo, report *VerifyReport) error {
+	check := newIndexCheck(openVerifyIndex(sn, snaptype.Headers.String(), sn.From, sn.To-sn.From, report), report)
+	defer check.close()
+	count, err := walkSegment(ctx, sn.Path, func(i, offset uint64, word []byte) error {
+		if len(word) == 0 {
+			return fmt.Errorf("empty header")
+		}
+		header := new(types.Header)
+		if err := rlp.DecodeBytes(word[1:], header); err != nil {
+			return err
+		}
+		if header.Number.Uint64() != sn.From+i {
+			return fmt.Errorf("header %d, expected %d", header.Number.Uint64(), sn.From+i)
+		}
+		hash := crypto.Keccak256(word[1:])
+		if hash[0] != word[0] {
+			return fmt.Errorf("header hash %x doesn't start with %x", hash, word[0])
+		}
+		check.word(i, offset, hash)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if count != sn.To-sn.From {
+		return fmt.Errorf("%d headers, expected %d", count, sn.To-sn.From)
+	}
+	return nil
+}
+
+// verifyBodies returns the base transaction ID and the number of transactions of the blocks.
+func verifyBodies(ctx context.Context, sn *snaptype.FileInfo, report *VerifyReport) ([]types.BodyForStorage, error) {
+	check := newIndexCheck(openVerifyIndex(sn, snaptype.Bodies.String(), sn.From, sn.To-sn.From, report), report)
+	defer check.close()
+	var blocks []types.BodyForStorage
+	num := make([]byte, binary.MaxVarintLen64)
+	if _, err := walkSegment(ctx, sn.Path, func(i, offset uint64, word []byte) error {
+		var body types.BodyForStorage
+		if err := rlp.DecodeBytes(word, &body); err != nil {
+			return err
+		}
+		if i > 0 {
+			prev := blocks[i-1]
+			if body.BaseTxId != prev.BaseTxId+uint64(prev.TxAmount) {
+				return fmt.Errorf("base transaction ID %d of block %d, the previous block ends at %d", body.BaseTxId, sn.From+i, prev.BaseTxId+uint64(prev.TxAmount))
+			}
+		}
+		blocks = append(blocks, types.BodyForStorage{BaseTxId: body.BaseTxId, TxAmount: body.TxAmount})
+		n := binary.PutUvarint(num, i)
+		check.word(i, offset, num[:n])
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if uint64(len(blocks)) != sn.To-sn.From {
+		return nil, fmt.Errorf("%d bodies, expected %d", len(blocks), sn.To-sn.From)
+	}
+	return blocks, nil
+}
+
+func verifyTransactions(ctx context.Context, sn *snaptype.FileInfo, blocks []types.BodyForStorage, chainID uint256.Int, report *VerifyReport) error {
+	if len(blocks) == 0 {
+		return fmt.Errorf("no bodies")
+	}
+	firstTxID := blocks[0].BaseTxId
+	last := blocks[len(blocks)-1]
+	expectedCount := last.BaseTxId + uint64(last.TxAmount) - firstTxID
+
+	hashCheck := newIndexCheck(openVerifyIndex(sn, snaptype.Transactions.String(), firstTxID, expectedCount, report), report)
+	defer hashCheck.close()
+	blockCheck := newIndexCheck(openVerifyIndex(sn, snaptype.Transactions2Block.String(), sn.From, expectedCount, report), report)
+	defer blockCheck.close()
+
+	parseCtx := types2.NewTxParseContext(chainID)
+	parseCtx.WithSender(false)
+	slot := types2.TxSlot{}
+	block := 0
+	count, err := walkSegment(ctx, sn.Path, func(i, offset uint64, word []byte) error {
+		for blocks[block].BaseTxId+uint64(blocks[block].TxAmount) <= firstTxID+i { // skip empty blocks
+			block++
+			if block == len(blocks) {
+				return fmt.Errorf("more transactions than the bodies have, expected %d", expectedCount)
+			}
+		}
+		if len(word) == 0 { // system-txs hash:pad32(txnID)
+			slot.IDHash = [32]byte{}
+			binary.BigEndian.PutUint64(slot.IDHash[:], firstTxID+i)
+		} else {
+			firstTxByteAndlengthOfAddress := 21
+			if len(word) < firstTxByteAndlengthOfAddress {
+				return fmt.Errorf("transaction of %d bytes", len(word))
+			}
+			if _, err := parseCtx.ParseTransaction(word[firstTxByteAndlengthOfAddress:], 0, &slot, nil, true /* hasEnvelope */, nil /* validateHash */); err != nil {
+				return err
+			}
+			if slot.IDHash[0] != word[0] {
+				return fmt.Errorf("transaction hash %x doesn't start with %x", slot.IDHash, word[0])
+			}
+		}
+		hashCheck.word(i, offset, slot.IDHash[:])
+		blockCheck.value(slot.IDHash[:], sn.From+uint64(block))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if count != expectedCount {
+		return fmt.Errorf("%d transactions, the bodies have %d", count, expectedCount)
+	}
+	return nil
+}
+
+func verifyReceipts(ctx context.Context, sn *snaptype.FileInfo, report *VerifyReport) error {
+	check := newIndexCheck(openVerifyIndex(sn, snaptype.Receipts.String(), sn.From, sn.To-sn.From, report), report)
+	defer check.close()
+	num := make([]byte, binary.MaxVarintLen64)
+	count, err := walkSegment(ctx, sn.Path, func(i, offset uint64, word []byte) error {
+		var receipts types.ReceiptsForStorage
+		if err := rlp.DecodeBytes(word, &receipts); err != nil {
+			return err
+		}
+		n := binary.PutUvarint(num, i)
+		check.word(i, offset, num[:n])
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if count != sn.To-sn.From {
+		return fmt.Errorf("%d blocks of receipts, expected %d", count, sn.To-sn.From)
+	}
+	return nil
+}
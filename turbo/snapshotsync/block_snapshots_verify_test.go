@@ -0,0 +1,90 @@
+package snapshotsync
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/erigon-lib/common/background"
+	"github.com/ledgerwatch/erigon-lib/compress"
+	"github.com/ledgerwatch/erigon-lib/downloader/snaptype"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/turbo/snapshotsync/snapcfg"
+)
+
+// createVerifyTestRange writes the segments and the indices of blocks with only the two system transactions.
+func createVerifyTestRange(t *testing.T, dir string, from, to uint64) {
+	ctx := context.Background()
+	addWords := func(segType snaptype.Type, word func(blockNum uint64) [][]byte) {
+		c, err := compress.NewCompressor(ctx, "test", filepath.Join(dir, snaptype.SegmentFileName(from, to, segType)), dir, 100, 1, log.LvlDebug)
+		require.NoError(t, err)
+		defer c.Close()
+		for blockNum := from; blockNum < to; blockNum++ {
+			for _, w := range word(blockNum) {
+				require.NoError(t, c.AddWord(w))
+			}
+		}
+		require.NoError(t, c.Compress())
+	}
+	addWords(snaptype.Headers, func(blockNum uint64) [][]byte {
+		header := &types.Header{Number: new(big.Int).SetUint64(blockNum), Difficulty: big.NewInt(1)}
+		b, err := rlp.EncodeToBytes(header)
+		require.NoError(t, err)
+		return [][]byte{append([]byte{header.Hash()[0]}, b...)}
+	})
+	addWords(snaptype.Bodies, func(blockNum uint64) [][]byte {
+		b, err := rlp.EncodeToBytes(&types.BodyForStorage{BaseTxId: 2 * blockNum, TxAmount: 2})
+		require.NoError(t, err)
+		return [][]byte{b}
+	})
+	addWords(snaptype.Transactions, func(blockNum uint64) [][]byte {
+		return [][]byte{{}, {}}
+	})
+
+	p := &background.Progress{}
+	require.NoError(t, HeadersIdx(ctx, filepath.Join(dir, snaptype.SegmentFileName(from, to, snaptype.Headers)), from, dir, p, log.LvlDebug))
+	require.NoError(t, BodiesIdx(ctx, filepath.Join(dir, snaptype.SegmentFileName(from, to, snaptype.Bodies)), from, dir, p, log.LvlDebug))
+	require.NoError(t, TransactionsIdx(ctx, *uint256.NewInt(1), from, to, dir, dir, p, log.LvlDebug))
+}
+
+func TestVerifySnapshots(t *testing.T) {
+	ctx, dir := context.Background(), t.TempDir()
+	createVerifyTestRange(t, dir, 0, 1_000)
+	createVerifyTestRange(t, dir, 1_000, 2_000)
+
+	report, err := VerifySnapshots(ctx, dir, *uint256.NewInt(1), nil)
+	require.NoError(t, err)
+	require.True(t, report.Ok(), "%v %v", report.BrokenSegments, report.BrokenIndices)
+	require.Equal(t, 6, report.Segments)
+
+	// A segment with another hash than the preverified one
+	headers := snaptype.SegmentFileName(0, 1_000, snaptype.Headers)
+	report, err = VerifySnapshots(ctx, dir, *uint256.NewInt(1), snapcfg.Preverified{{Name: headers, Hash: "0123"}})
+	require.NoError(t, err)
+	require.Contains(t, report.BrokenSegments, headers)
+	require.Len(t, report.BrokenSegments, 1)
+
+	// An index of another range
+	idx := snaptype.IdxFileName(1_000, 2_000, snaptype.Headers.String())
+	b, err := os.ReadFile(filepath.Join(dir, snaptype.IdxFileName(0, 1_000, snaptype.Headers.String())))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, idx), b, 0644))
+	// A truncated segment
+	txs := snaptype.SegmentFileName(1_000, 2_000, snaptype.Transactions)
+	info, err := os.Stat(filepath.Join(dir, txs))
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(filepath.Join(dir, txs), info.Size()/2))
+
+	report, err = VerifySnapshots(ctx, dir, *uint256.NewInt(1), nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{idx: report.BrokenIndices[idx]}, report.BrokenIndices)
+	require.Contains(t, report.BrokenSegments, txs)
+	require.Len(t, report.BrokenSegments, 1)
+}
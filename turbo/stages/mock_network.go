@@ -0,0 +1,396 @@
+package stages
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ledgerwatch/erigon-lib/gointerfaces"
+	proto_sentry "github.com/ledgerwatch/erigon-lib/gointerfaces/sentry"
+	ptypes "github.com/ledgerwatch/erigon-lib/gointerfaces/types"
+	"github.com/ledgerwatch/erigon-lib/kv"
+
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/eth/protocols/eth"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/rlp"
+)
+
+// MockNetwork simulates a network of Proof-of-Work MockSentry nodes in-process.
+//
+// The nodes are linked by in-memory pipes with a latency: the messages a node sends through
+// its sentry arrive to the inbound streams of the peers. When the head of a node changes, it
+// announces the new head block to the peers it can reach, and they download the missing
+// headers and bodies from their peers, running a sync cycle when block data arrives.
+// Links can be cut, and the nodes split into partitions, to script forks and reorgs.
+//
+// The network runs on a virtual clock: messages are delivered in the order of their
+// arrival time by Run and Settle, so the simulations are deterministic.
+type MockNetwork struct {
+	t      *testing.T
+	Nodes  []*MockSentry
+	now    time.Duration
+	links  map[[2]int]time.Duration // latency of the pipes between the nodes
+	groups []int                    // nodes in different groups are partitioned
+	lock   sync.Mutex               // the nodes send replies from the goroutines handling the messages
+	queue  mockPacketQueue
+	seq    uint64
+}
+
+// mockPacket is a message of a node in flight to a peer.
+type mockPacket struct {
+	arrival  time.Duration
+	seq      uint64
+	from, to int
+	message  *proto_sentry.InboundMessage
+}
+
+type mockPacketQueue []*mockPacket
+
+func (q mockPacketQueue) Len() int { return len(q) }
+func (q mockPacketQueue) Less(i, j int) bool {
+	if q[i].arrival != q[j].arrival {
+		return q[i].arrival < q[j].arrival
+	}
+	return q[i].seq < q[j].seq
+}
+func (q mockPacketQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *mockPacketQueue) Push(x interface{}) { *q = append(*q, x.(*mockPacket)) }
+func (q *mockPacketQueue) Pop() interface{} {
+	old := *q
+	p := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return p
+}
+
+// NewMockNetwork creates a network of the given nodes, which have to share the genesis.
+// The nodes get distinct peer IDs, and are not linked.
+func NewMockNetwork(t *testing.T, nodes ...*MockSentry) *MockNetwork {
+	net := &MockNetwork{
+		t:      t,
+		Nodes:  nodes,
+		links:  make(map[[2]int]time.Duration),
+		groups: make([]int, len(nodes)),
+	}
+	for i, node := range nodes {
+		i := i
+		node.PeerId = gointerfaces.ConvertHashToH512([64]byte{0x12, 0x34, 0x50, byte(i + 1)})
+		node.outbound = func(data *proto_sentry.OutboundMessageData, to mockPeers) []*ptypes.H512 {
+			return net.send(i, data, to)
+		}
+	}
+	return net
+}
+
+// MockNetworkOf creates a network of size nodes with the Mock defaults, all linked with the latency.
+func MockNetworkOf(t *testing.T, size int, latency time.Duration) *MockNetwork {
+	nodes := make([]*MockSentry, size)
+	for i := range nodes {
+		nodes[i] = Mock(t)
+	}
+	net := NewMockNetwork(t, nodes...)
+	net.ConnectAll(latency)
+	return net
+}
+
+func linkKey(i, j int) [2]int {
+	if i > j {
+		i, j = j, i
+	}
+	return [2]int{i, j}
+}
+
+// Connect links the nodes i and j with a pipe, or changes the latency of the existing pipe.
+// Newly linked nodes announce their heads to each other, as they would after a handshake.
+func (net *MockNetwork) Connect(i, j int, latency time.Duration) error {
+	if i == j {
+		return fmt.Errorf("can't connect node %d to itself", i)
+	}
+	_, linked := net.links[linkKey(i, j)]
+	net.links[linkKey(i, j)] = latency
+	if linked || !net.CanReach(i, j) {
+		return nil
+	}
+	if err := net.sendHead(i, j); err != nil {
+		return err
+	}
+	return net.sendHead(j, i)
+}
+
+// ConnectAll links every pair of nodes with the latency.
+func (net *MockNetwork) ConnectAll(latency time.Duration) {
+	for i := range net.Nodes {
+		for j := i + 1; j < len(net.Nodes); j++ {
+			if err := net.Connect(i, j, latency); err != nil {
+				net.t.Fatal(err)
+			}
+		}
+	}
+}
+
+// Disconnect cuts the pipe between the nodes i and j. The messages in flight are lost.
+func (net *MockNetwork) Disconnect(i, j int) {
+	delete(net.links, linkKey(i, j))
+}
+
+// Partition splits the network into the groups of nodes. The nodes not listed in
+// any group form another group. The messages in flight between the groups are lost.
+func (net *MockNetwork) Partition(groups ...[]int) {
+	for i := range net.groups {
+		net.groups[i] = 0
+	}
+	for g, group := range groups {
+		for _, i := range group {
+			net.groups[i] = g + 1
+		}
+	}
+}
+
+// Heal removes the partitions. The nodes which can reach each other again announce their heads to each other.
+func (net *MockNetwork) Heal() error {
+	reachable := make(map[[2]int]bool, len(net.links))
+	for link := range net.links {
+		reachable[link] = net.CanReach(link[0], link[1])
+	}
+	net.Partition()
+	for link := range reachable {
+		if reachable[link] {
+			continue
+		}
+		if err := net.sendHead(link[0], link[1]); err != nil {
+			return err
+		}
+		if err := net.sendHead(link[1], link[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CanReach checks whether the nodes i and j are linked and in the same partition.
+func (net *MockNetwork) CanReach(i, j int) bool {
+	_, linked := net.links[linkKey(i, j)]
+	return linked && (net.groups[i] == net.groups[j])
+}
+
+// Now returns the virtual time of the network.
+func (net *MockNetwork) Now() time.Duration {
+	return net.now
+}
+
+// InsertChain imports the chain into the node i, as if it was mined there,
+// and announces the new head to the peers.
+func (net *MockNetwork) InsertChain(i int, chain *core.ChainPack) error {
+	if err := net.Nodes[i].InsertChain(chain); err != nil {
+		return err
+	}
+	return net.announce(i)
+}
+
+// Run delivers the messages arriving within the duration, and advances the virtual time.
+func (net *MockNetwork) Run(d time.Duration) error {
+	end := net.now + d
+	for {
+		p := net.next(end)
+		if p == nil {
+			break
+		}
+		if err := net.deliver(p); err != nil {
+			return err
+		}
+	}
+	net.now = end
+	return nil
+}
+
+// Settle delivers the messages until there are none in flight.
+func (net *MockNetwork) Settle() error {
+	for {
+		p := net.next(math.MaxInt64)
+		if p == nil {
+			return nil
+		}
+		if err := net.deliver(p); err != nil {
+			return err
+		}
+	}
+}
+
+// InFlight returns the number of the messages sent and not yet delivered.
+func (net *MockNetwork) InFlight() int {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	return len(net.queue)
+}
+
+// next takes the first message arriving not later than end from the queue.
+func (net *MockNetwork) next(end time.Duration) *mockPacket {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	if (len(net.queue) == 0) || (net.queue[0].arrival > end) {
+		return nil
+	}
+	return heap.Pop(&net.queue).(*mockPacket)
+}
+
+func (net *MockNetwork) deliver(p *mockPacket) error {
+	net.now = p.arrival
+	if !net.CanReach(p.from, p.to) {
+		// The pipe was cut while the message was in flight.
+		return nil
+	}
+
+	node := net.Nodes[p.to]
+	_, oldHead, err := net.Head(p.to)
+	if err != nil {
+		return err
+	}
+	if err := node.receive(p.message); err != nil {
+		return fmt.Errorf("node %d receiving %s from node %d: %w", p.to, p.message.Id, p.from, err)
+	}
+	switch p.message.Id {
+	case proto_sentry.MessageId_NEW_BLOCK_66, proto_sentry.MessageId_NEW_BLOCK_HASHES_66,
+		proto_sentry.MessageId_BLOCK_HEADERS_66, proto_sentry.MessageId_BLOCK_BODIES_66:
+		if err := node.syncCycle(); err != nil {
+			return fmt.Errorf("node %d syncing after %s from node %d: %w", p.to, p.message.Id, p.from, err)
+		}
+	default:
+		// Requests are answered by the message handlers
+		return nil
+	}
+	_, head, err := net.Head(p.to)
+	if err != nil {
+		return err
+	}
+	if head != oldHead {
+		return net.announce(p.to)
+	}
+	return nil
+}
+
+// send puts the message of a node in the pipes to the peers it can reach, selected as by the sentry.
+func (net *MockNetwork) send(from int, data *proto_sentry.OutboundMessageData, to mockPeers) []*ptypes.H512 {
+	var peers []*ptypes.H512
+	for i, node := range net.Nodes {
+		if (i == from) || !net.CanReach(from, i) {
+			continue
+		}
+		if (to.id != nil) && (gointerfaces.ConvertH512ToHash(to.id) != gointerfaces.ConvertH512ToHash(node.PeerId)) {
+			continue
+		}
+		if to.minBlock > 0 {
+			number, _, err := net.Head(i)
+			if err != nil || number < to.minBlock {
+				continue
+			}
+		}
+		if (to.max > 0) && (uint64(len(peers)) >= to.max) {
+			break
+		}
+		net.push(from, i, &proto_sentry.InboundMessage{Id: data.Id, Data: data.Data, PeerId: net.Nodes[from].PeerId})
+		peers = append(peers, node.PeerId)
+	}
+	return peers
+}
+
+func (net *MockNetwork) push(from, to int, message *proto_sentry.InboundMessage) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	net.seq++
+	heap.Push(&net.queue, &mockPacket{
+		arrival: net.now + net.links[linkKey(from, to)],
+		seq:     net.seq,
+		from:    from,
+		to:      to,
+		message: message,
+	})
+}
+
+// announce sends the head block of the node to the peers it can reach.
+func (net *MockNetwork) announce(from int) error {
+	for to := range net.Nodes {
+		if (to != from) && net.CanReach(from, to) {
+			if err := net.sendHead(from, to); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sendHead puts the NewBlock message with the head block of a node in the pipe to the peer.
+func (net *MockNetwork) sendHead(from, to int) error {
+	node := net.Nodes[from]
+	number, hash, err := net.Head(from)
+	if err != nil {
+		return err
+	}
+	if number == 0 {
+		return nil
+	}
+	var packet eth.NewBlockPacket
+	if err := node.DB.View(node.Ctx, func(tx kv.Tx) error {
+		if packet.Block = rawdb.ReadBlock(tx, hash, number); packet.Block == nil {
+			return fmt.Errorf("head block %d %x of node %d not found", number, hash, from)
+		}
+		packet.TD, err = rawdb.ReadTd(tx, hash, number)
+		return err
+	}); err != nil {
+		return err
+	}
+	data, err := rlp.EncodeToBytes(&packet)
+	if err != nil {
+		return err
+	}
+	net.push(from, to, &proto_sentry.InboundMessage{Id: proto_sentry.MessageId_NEW_BLOCK_66, Data: data, PeerId: node.PeerId})
+	return nil
+}
+
+// Head returns the number and hash of the last executed block of the node i.
+func (net *MockNetwork) Head(i int) (number uint64, hash common.Hash, err error) {
+	node := net.Nodes[i]
+	err = node.DB.View(node.Ctx, func(tx kv.Tx) error {
+		if number, err = stages.GetStageProgress(tx, stages.Finish); err != nil {
+			return err
+		}
+		hash, err = rawdb.ReadCanonicalHash(tx, number)
+		return err
+	})
+	return number, hash, err
+}
+
+// Converged checks that all the nodes have the same head block.
+// If head is not the zero hash, it has to be that block.
+func (net *MockNetwork) Converged(head common.Hash) error {
+	heads := make([]string, len(net.Nodes))
+	converged := true
+	for i := range net.Nodes {
+		number, hash, err := net.Head(i)
+		if err != nil {
+			return err
+		}
+		if head == (common.Hash{}) {
+			head = hash
+		}
+		converged = converged && (hash == head)
+		heads[i] = fmt.Sprintf("%d: %d %x", i, number, hash)
+	}
+	if !converged {
+		return fmt.Errorf("heads didn't converge to %x: %s", head, strings.Join(heads, ", "))
+	}
+	return nil
+}
+
+// RequireConverged fails the test if the heads didn't converge, see Converged.
+func (net *MockNetwork) RequireConverged(head common.Hash) {
+	net.t.Helper()
+	if err := net.Converged(head); err != nil {
+		net.t.Fatal(err)
+	}
+}
@@ -0,0 +1,103 @@
+package stages_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/turbo/stages"
+	"github.com/stretchr/testify/require"
+)
+
+// generateFork creates a chain of n blocks from the genesis, sharing the first shared blocks
+// with the other forks. The forks differ by the coinbase.
+func generateFork(t *testing.T, m *stages.MockSentry, n int, shared int, coinbase byte) *core.ChainPack {
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, n, func(i int, b *core.BlockGen) {
+		if i < shared {
+			b.SetCoinbase(common.Address{1})
+		} else {
+			b.SetCoinbase(common.Address{coinbase})
+		}
+	}, false /* intermediateHashes */)
+	require.NoError(t, err)
+	return chain
+}
+
+func TestMockNetworkPropagation(t *testing.T) {
+	net := stages.NewMockNetwork(t, stages.Mock(t), stages.Mock(t), stages.Mock(t))
+	// A line: 0 - 1 - 2
+	require.NoError(t, net.Connect(0, 1, 100*time.Millisecond))
+	require.NoError(t, net.Connect(1, 2, 100*time.Millisecond))
+
+	chain := generateFork(t, net.Nodes[0], 5, 5, 1)
+	require.NoError(t, net.InsertChain(0, chain))
+	require.Equal(t, 1, net.InFlight()) // only the new head is announced
+
+	// The announcement, then the request and the reply of the missing blocks.
+	require.NoError(t, net.Run(250*time.Millisecond))
+	number, _, err := net.Head(1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), number)
+
+	require.NoError(t, net.Run(50*time.Millisecond))
+	number, _, err = net.Head(1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), number)
+	number, _, err = net.Head(2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), number)
+
+	require.NoError(t, net.Run(300*time.Millisecond))
+	net.RequireConverged(chain.TopBlock.Hash())
+	require.Equal(t, 600*time.Millisecond, net.Now())
+}
+
+func TestMockNetworkPartitionReorg(t *testing.T) {
+	net := stages.MockNetworkOf(t, 3, 10*time.Millisecond)
+
+	short := generateFork(t, net.Nodes[0], 5, 3, 2)
+	long := generateFork(t, net.Nodes[0], 7, 3, 3)
+
+	require.NoError(t, net.InsertChain(0, short.Slice(0, 3)))
+	require.NoError(t, net.Settle())
+	net.RequireConverged(short.Blocks[2].Hash())
+
+	// The partitions mine competing forks.
+	net.Partition([]int{0}, []int{1, 2})
+	require.NoError(t, net.InsertChain(0, short))
+	require.NoError(t, net.InsertChain(1, long))
+	require.NoError(t, net.Settle())
+	_, head, err := net.Head(0)
+	require.NoError(t, err)
+	require.Equal(t, short.TopBlock.Hash(), head)
+	_, head, err = net.Head(2)
+	require.NoError(t, err)
+	require.Equal(t, long.TopBlock.Hash(), head)
+	require.Error(t, net.Converged(common.Hash{}))
+
+	// The node 0 reorgs to the heavier fork when the partition heals.
+	require.NoError(t, net.Heal())
+	require.NoError(t, net.Settle())
+	net.RequireConverged(long.TopBlock.Hash())
+}
+
+func TestMockNetworkDisconnect(t *testing.T) {
+	net := stages.MockNetworkOf(t, 2, time.Second)
+
+	chain := generateFork(t, net.Nodes[0], 3, 3, 1)
+	require.NoError(t, net.InsertChain(0, chain))
+	require.Equal(t, 1, net.InFlight())
+
+	// The message in flight is lost.
+	net.Disconnect(0, 1)
+	require.NoError(t, net.Settle())
+	number, _, err := net.Head(1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), number)
+
+	// Reconnected nodes sync.
+	require.NoError(t, net.Connect(0, 1, time.Second))
+	require.NoError(t, net.Run(3*time.Second))
+	net.RequireConverged(chain.TopBlock.Hash())
+}
@@ -0,0 +1,74 @@
+package stages
+
+import (
+	"errors"
+	"io"
+
+	"github.com/ledgerwatch/erigon-lib/gointerfaces"
+	proto_sentry "github.com/ledgerwatch/erigon-lib/gointerfaces/sentry"
+
+	"github.com/ledgerwatch/erigon/cmd/sentry/sentry"
+)
+
+// ReplayStats counts what ReplayCapture did.
+type ReplayStats struct {
+	Messages int // inbound messages fed to the sync
+	Skipped  int // outbound messages, and messages the sync doesn't subscribe to
+	Cycles   int // sync cycles run
+}
+
+// ReplayCapture feeds the inbound messages of a sentry capture to the mock, as if they
+// were received from the captured peers, and runs a sync cycle after each batch of bodies
+// and at the end. The messages sent by the node are skipped, as the mock makes its own requests.
+//
+// Only the Proof-of-Work block download is driven by the messages, the Proof-of-Stake
+// sync needs the engine API calls, which are not captured.
+func (ms *MockSentry) ReplayCapture(reader *sentry.CaptureReader) (ReplayStats, error) {
+	var stats ReplayStats
+	ms.StreamWg.Wait()
+	pending := false
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return stats, err
+		}
+		id := proto_sentry.MessageId(record.MessageID)
+		subscribers := len(ms.streams[id])
+		if !record.Inbound || (subscribers == 0) {
+			stats.Skipped++
+			continue
+		}
+
+		ms.ReceiveWg.Add(subscribers)
+		for _, err := range ms.Send(&proto_sentry.InboundMessage{
+			Id:     id,
+			Data:   record.Data,
+			PeerId: gointerfaces.ConvertHashToH512(record.PeerID),
+		}) {
+			if err != nil {
+				return stats, err
+			}
+		}
+		ms.ReceiveWg.Wait()
+		stats.Messages++
+		pending = true
+
+		if id == proto_sentry.MessageId_BLOCK_BODIES_66 {
+			if err := ms.syncCycle(); err != nil {
+				return stats, err
+			}
+			stats.Cycles++
+			pending = false
+		}
+	}
+	if pending {
+		if err := ms.syncCycle(); err != nil {
+			return stats, err
+		}
+		stats.Cycles++
+	}
+	return stats, nil
+}
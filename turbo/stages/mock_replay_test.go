@@ -0,0 +1,60 @@
+package stages_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/gointerfaces"
+	proto_sentry "github.com/ledgerwatch/erigon-lib/gointerfaces/sentry"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cmd/sentry/sentry"
+	"github.com/ledgerwatch/erigon/eth/protocols/eth"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/turbo/stages"
+)
+
+func TestReplayCapture(t *testing.T) {
+	m := stages.Mock(t)
+	chain := generateFork(t, m, 5, 5, 1)
+	peerID := gointerfaces.ConvertH512ToHash(m.PeerId)
+
+	// Capture the messages of a peer sending the chain
+	dir := t.TempDir()
+	capture, err := sentry.OpenCapture(dir, 0, 0)
+	require.NoError(t, err)
+	b, err := rlp.EncodeToBytes(&eth.GetBlockHeadersPacket66{
+		RequestId:             1,
+		GetBlockHeadersPacket: &eth.GetBlockHeadersPacket{Origin: eth.HashOrNumber{Number: 1}, Amount: 5},
+	})
+	require.NoError(t, err)
+	capture.Write(false, peerID, proto_sentry.MessageId_GET_BLOCK_HEADERS_66, b)
+	b, err = rlp.EncodeToBytes(&eth.NewBlockPacket{Block: chain.TopBlock, TD: big.NewInt(1)})
+	require.NoError(t, err)
+	capture.Write(true, peerID, proto_sentry.MessageId_NEW_BLOCK_66, b)
+	b, err = rlp.EncodeToBytes(&eth.BlockHeadersPacket66{RequestId: 1, BlockHeadersPacket: chain.Headers})
+	require.NoError(t, err)
+	capture.Write(true, peerID, proto_sentry.MessageId_BLOCK_HEADERS_66, b)
+	bodies := make(eth.BlockBodiesPacket, chain.Length())
+	for i, block := range chain.Blocks {
+		bodies[i] = (*eth.BlockBody)(block.Body())
+	}
+	b, err = rlp.EncodeToBytes(&eth.BlockBodiesPacket66{RequestId: 1, BlockBodiesPacket: bodies})
+	require.NoError(t, err)
+	capture.Write(true, peerID, proto_sentry.MessageId_BLOCK_BODIES_66, b)
+	require.NoError(t, capture.Close())
+
+	paths, err := sentry.CaptureFiles(dir)
+	require.NoError(t, err)
+	reader := sentry.NewCaptureReader(paths...)
+	defer reader.Close()
+	stats, err := m.ReplayCapture(reader)
+	require.NoError(t, err)
+	require.Equal(t, stages.ReplayStats{Messages: 3, Skipped: 1, Cycles: 1}, stats)
+
+	net := stages.NewMockNetwork(t, m)
+	number, head, err := net.Head(0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), number)
+	require.Equal(t, chain.TopBlock.Hash(), head)
+}
@@ -77,6 +77,7 @@ type MockSentry struct {
 	UpdateHead     func(Ctx context.Context, headHeight, headTime uint64, hash common.Hash, td *uint256.Int)
 	streams        map[proto_sentry.MessageId][]proto_sentry.Sentry_MessagesServer
 	sentMessages   []*proto_sentry.OutboundMessageData
+	outbound       func(data *proto_sentry.OutboundMessageData, to mockPeers) []*ptypes.H512 // set by MockNetwork
 	StreamWg       sync.WaitGroup
 	ReceiveWg      sync.WaitGroup
 	Address        common.Address
@@ -131,21 +132,34 @@ func (ms *MockSentry) PeerMinBlock(context.Context, *proto_sentry.PeerMinBlockRe
 func (ms *MockSentry) HandShake(ctx context.Context, in *emptypb.Empty) (*proto_sentry.HandShakeReply, error) {
 	return &proto_sentry.HandShakeReply{Protocol: proto_sentry.Protocol_ETH66}, nil
 }
+
+// mockPeers selects the peers of an outbound message, as the SendMessage* methods of the sentry do
+type mockPeers struct {
+	id       *ptypes.H512 // only this peer
+	minBlock uint64       // peers having this block
+	max      uint64       // 0 - no limit
+}
+
+// send records the message, and routes it to the peers if the node is in a MockNetwork
+func (ms *MockSentry) send(data *proto_sentry.OutboundMessageData, to mockPeers) (*proto_sentry.SentPeers, error) {
+	ms.sentMessages = append(ms.sentMessages, data)
+	if ms.outbound == nil {
+		return nil, nil
+	}
+	return &proto_sentry.SentPeers{Peers: ms.outbound(data, to)}, nil
+}
+
 func (ms *MockSentry) SendMessageByMinBlock(_ context.Context, r *proto_sentry.SendMessageByMinBlockRequest) (*proto_sentry.SentPeers, error) {
-	ms.sentMessages = append(ms.sentMessages, r.Data)
-	return nil, nil
+	return ms.send(r.Data, mockPeers{minBlock: r.MinBlock, max: r.MaxPeers})
 }
 func (ms *MockSentry) SendMessageById(_ context.Context, r *proto_sentry.SendMessageByIdRequest) (*proto_sentry.SentPeers, error) {
-	ms.sentMessages = append(ms.sentMessages, r.Data)
-	return nil, nil
+	return ms.send(r.Data, mockPeers{id: r.PeerId})
 }
 func (ms *MockSentry) SendMessageToRandomPeers(_ context.Context, r *proto_sentry.SendMessageToRandomPeersRequest) (*proto_sentry.SentPeers, error) {
-	ms.sentMessages = append(ms.sentMessages, r.Data)
-	return nil, nil
+	return ms.send(r.Data, mockPeers{max: r.MaxPeers})
 }
 func (ms *MockSentry) SendMessageToAll(_ context.Context, r *proto_sentry.OutboundMessageData) (*proto_sentry.SentPeers, error) {
-	ms.sentMessages = append(ms.sentMessages, r)
-	return nil, nil
+	return ms.send(r, mockPeers{})
 }
 func (ms *MockSentry) SentMessage(i int) *proto_sentry.OutboundMessageData {
 	return ms.sentMessages[i]
@@ -267,14 +281,25 @@ func MockWithEverything(t *testing.T, gspec *core.Genesis, key *ecdsa.PrivateKey
 
 	mock.Address = crypto.PubkeyToAddress(mock.Key.PublicKey)
 
-	sendHeaderRequest := func(_ context.Context, r *headerdownload.HeaderRequest) ([64]byte, bool) { return [64]byte{}, false }
+	// The requests are sent only in a MockNetwork, the blocks of InsertChain are sent as if they were requested
+	sendHeaderRequest := func(ctx context.Context, r *headerdownload.HeaderRequest) ([64]byte, bool) {
+		if mock.outbound == nil {
+			return [64]byte{}, false
+		}
+		return mock.sentriesClient.SendHeaderRequest(ctx, r)
+	}
 	propagateNewBlockHashes := func(context.Context, []headerdownload.Announce) {}
 	penalize := func(context.Context, []headerdownload.PenaltyItem) {}
 
 	mock.SentryClient = direct.NewSentryClientDirect(eth.ETH66, mock)
 	sentries := []direct.SentryClient{mock.SentryClient}
 
-	sendBodyRequest := func(context.Context, *bodydownload.BodyRequest) ([64]byte, bool) { return [64]byte{}, false }
+	sendBodyRequest := func(ctx context.Context, r *bodydownload.BodyRequest) ([64]byte, bool) {
+		if mock.outbound == nil {
+			return [64]byte{}, false
+		}
+		return mock.sentriesClient.SendBodyRequest(ctx, r)
+	}
 	blockPropagator := func(Ctx context.Context, block *types.Block, td *big.Int) {}
 
 	if !cfg.DeprecatedTxPool.Disable {
@@ -504,40 +529,43 @@ func (ms *MockSentry) insertPoWBlocks(chain *core.ChainPack) error {
 		// No Proof-of-Work blocks
 		return nil
 	}
+	messages, err := encodePoWBlocks(ms.PeerId, chain.Blocks[0:n])
+	if err != nil {
+		return err
+	}
+	return ms.receivePoWBlocks(messages)
+}
 
-	// Send NewBlock message
+// encodePoWBlocks creates the messages of a peer announcing the last of the blocks,
+// and sending all their headers and bodies.
+func encodePoWBlocks(peerID *ptypes.H512, blocks []*types.Block) ([]*proto_sentry.InboundMessage, error) {
+	// NewBlock message
 	b, err := rlp.EncodeToBytes(&eth.NewBlockPacket{
-		Block: chain.Blocks[n-1],
+		Block: blocks[len(blocks)-1],
 		TD:    big.NewInt(1), // This is ignored anyway
 	})
 	if err != nil {
-		return err
-	}
-	ms.ReceiveWg.Add(1)
-	for _, err = range ms.Send(&proto_sentry.InboundMessage{Id: proto_sentry.MessageId_NEW_BLOCK_66, Data: b, PeerId: ms.PeerId}) {
-		if err != nil {
-			return err
-		}
+		return nil, err
 	}
+	messages := []*proto_sentry.InboundMessage{{Id: proto_sentry.MessageId_NEW_BLOCK_66, Data: b, PeerId: peerID}}
 
-	// Send all the headers
+	// All the headers
+	headers := make([]*types.Header, len(blocks))
+	for i, block := range blocks {
+		headers[i] = block.Header()
+	}
 	b, err = rlp.EncodeToBytes(&eth.BlockHeadersPacket66{
 		RequestId:          1,
-		BlockHeadersPacket: chain.Headers[0:n],
+		BlockHeadersPacket: headers,
 	})
 	if err != nil {
-		return err
-	}
-	ms.ReceiveWg.Add(1)
-	for _, err = range ms.Send(&proto_sentry.InboundMessage{Id: proto_sentry.MessageId_BLOCK_HEADERS_66, Data: b, PeerId: ms.PeerId}) {
-		if err != nil {
-			return err
-		}
+		return nil, err
 	}
+	messages = append(messages, &proto_sentry.InboundMessage{Id: proto_sentry.MessageId_BLOCK_HEADERS_66, Data: b, PeerId: peerID})
 
-	// Send all the bodies
-	packet := make(eth.BlockBodiesPacket, n)
-	for i, block := range chain.Blocks[0:n] {
+	// All the bodies
+	packet := make(eth.BlockBodiesPacket, len(blocks))
+	for i, block := range blocks {
 		packet[i] = (*eth.BlockBody)(block.Body())
 	}
 	b, err = rlp.EncodeToBytes(&eth.BlockBodiesPacket66{
@@ -545,21 +573,46 @@ func (ms *MockSentry) insertPoWBlocks(chain *core.ChainPack) error {
 		BlockBodiesPacket: packet,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	ms.ReceiveWg.Add(1)
-	for _, err = range ms.Send(&proto_sentry.InboundMessage{Id: proto_sentry.MessageId_BLOCK_BODIES_66, Data: b, PeerId: ms.PeerId}) {
+	messages = append(messages, &proto_sentry.InboundMessage{Id: proto_sentry.MessageId_BLOCK_BODIES_66, Data: b, PeerId: peerID})
+	return messages, nil
+}
+
+// receivePoWBlocks processes the messages created by encodePoWBlocks, and runs a sync cycle.
+func (ms *MockSentry) receivePoWBlocks(messages []*proto_sentry.InboundMessage) error {
+	for _, message := range messages {
+		ms.ReceiveWg.Add(1)
+		for _, err := range ms.Send(message) {
+			if err != nil {
+				return err
+			}
+		}
+	}
+	ms.ReceiveWg.Wait() // Wait for all messages to be processed before we proceed
+	return ms.syncCycle()
+}
+
+// receive passes the message to the streams subscribed to it, and waits until it is processed.
+func (ms *MockSentry) receive(message *proto_sentry.InboundMessage) error {
+	ms.StreamWg.Wait()
+	ms.ReceiveWg.Add(len(ms.streams[message.Id]))
+	for _, err := range ms.Send(message) {
 		if err != nil {
 			return err
 		}
 	}
-	ms.ReceiveWg.Wait() // Wait for all messages to be processed before we proceed
+	ms.ReceiveWg.Wait()
+	return nil
+}
 
+// syncCycle runs a non-initial sync cycle on the messages received so far.
+func (ms *MockSentry) syncCycle() error {
 	initialCycle := false
 	if ms.TxPool != nil {
 		ms.ReceiveWg.Add(1)
 	}
-	if _, err = StageLoopStep(ms.Ctx, ms.ChainConfig, ms.DB, ms.Sync, ms.Notifications, initialCycle, ms.UpdateHead); err != nil {
+	if _, err := StageLoopStep(ms.Ctx, ms.ChainConfig, ms.DB, ms.Sync, ms.Notifications, initialCycle, ms.UpdateHead); err != nil {
 		return err
 	}
 	if ms.TxPool != nil {
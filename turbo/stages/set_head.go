@@ -0,0 +1,289 @@
+package stages
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/common/hexutil"
+	"github.com/ledgerwatch/erigon/consensus/misc"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/stagedsync"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/ethdb/prune"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/turbo/shards"
+	"github.com/ledgerwatch/erigon/turbo/snapshotsync"
+	"github.com/ledgerwatch/erigon/turbo/stages/headerdownload"
+)
+
+const (
+	UnwindPending = "pending"
+	UnwindRunning = "unwinding"
+	UnwindDone    = "done"
+	UnwindFailed  = "failed"
+)
+
+const setHeadLogTitle = "[SetHead]"
+
+// UnwindStatus - progress of the last unwind requested by debug_setHead
+type UnwindStatus struct {
+	Block     uint64 `json:"block"` // unwind point
+	From      uint64 `json:"from"`  // progress of the Finish stage when the unwind was requested
+	State     string `json:"state"`
+	Stage     string `json:"stage,omitempty"` // stage being unwound, or the last unwound one
+	Requested int64  `json:"requested"`       // unix time
+	Finished  int64  `json:"finished,omitempty"`
+	Err       string `json:"error,omitempty"`
+}
+
+// UnwindRequests - unwinds requested over RPC. StageLoop serves them between sync cycles, in its own transaction,
+// then the sync continues from the unwind point.
+type UnwindRequests struct {
+	db        kv.RoDB
+	snapshots *snapshotsync.RoSnapshots
+
+	lock   sync.Mutex
+	status *UnwindStatus // nil if there were no requests since start
+}
+
+func NewUnwindRequests(db kv.RoDB, snapshots *snapshotsync.RoSnapshots) *UnwindRequests {
+	return &UnwindRequests{db: db, snapshots: snapshots}
+}
+
+// checkUnwindPoint - doesn't allow unwinds deeper than params.FullImmutabilityThreshold, into blocks of snapshots
+// (they can't be unwound) and into pruned history (execution can't be unwound without changesets)
+func (r *UnwindRequests) checkUnwindPoint(tx kv.Tx, unwindPoint uint64) (head uint64, err error) {
+	if head, err = stages.GetStageProgress(tx, stages.Finish); err != nil {
+		return 0, err
+	}
+	if unwindPoint >= head {
+		return 0, fmt.Errorf("block %d is not below the head %d", unwindPoint, head)
+	}
+	if head-unwindPoint > params.FullImmutabilityThreshold {
+		return 0, fmt.Errorf("block %d is deeper than %d blocks below the head %d", unwindPoint, params.FullImmutabilityThreshold, head)
+	}
+	if r.snapshots != nil && r.snapshots.Cfg().Enabled && unwindPoint < r.snapshots.BlocksAvailable() {
+		return 0, fmt.Errorf("block %d is in snapshots, they end at block %d", unwindPoint, r.snapshots.BlocksAvailable())
+	}
+	pm, err := prune.Get(tx)
+	if err != nil {
+		return 0, err
+	}
+	if pm.History.Enabled() && unwindPoint < pm.History.PruneTo(head) {
+		return 0, fmt.Errorf("block %d is in pruned history, it's available from block %d", unwindPoint, pm.History.PruneTo(head))
+	}
+	return head, nil
+}
+
+// Request - asks StageLoop to unwind all stages to unwindPoint. Only one unwind can be pending at a time.
+func (r *UnwindRequests) Request(ctx context.Context, unwindPoint uint64) (*UnwindStatus, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.status != nil && (r.status.State == UnwindPending || r.status.State == UnwindRunning) {
+		return nil, fmt.Errorf("unwind to block %d is %s", r.status.Block, r.status.State)
+	}
+	var head uint64
+	if err := r.db.View(ctx, func(tx kv.Tx) (err error) {
+		head, err = r.checkUnwindPoint(tx, unwindPoint)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("%s Unwind requested", setHeadLogTitle), "block", unwindPoint, "head", head)
+	r.status = &UnwindStatus{Block: unwindPoint, From: head, State: UnwindPending, Requested: time.Now().Unix()}
+	status := *r.status
+	return &status, nil
+}
+
+// Status - returns nil if there were no requests since start
+func (r *UnwindRequests) Status() *UnwindStatus {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.status == nil {
+		return nil
+	}
+	status := *r.status
+	return &status
+}
+
+func (r *UnwindRequests) update(f func(status *UnwindStatus)) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	f(r.status)
+}
+
+// Serve - runs the pending unwind. Unwind point is checked again: the head and snapshots may have moved since
+// the request. If an unwind of some stage fails, the sync cycle retries the unwind, as it does with its own unwinds.
+// After the commit the new head is announced as StageLoopStep does: to the sentries by updateHead, to the rpcdaemon
+// and the txpool by notifications, with the unwound state changes and the removed logs.
+func (r *UnwindRequests) Serve(ctx context.Context, db kv.RwDB, sync *stagedsync.Sync, hd *headerdownload.HeaderDownload,
+	notifications *shards.Notifications, chainConfig *params.ChainConfig,
+	updateHead func(ctx context.Context, headHeight, headTime uint64, hash common.Hash, td *uint256.Int),
+) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	if r.status == nil || r.status.State != UnwindPending {
+		r.lock.Unlock()
+		return
+	}
+	r.status.State = UnwindRunning
+	unwindPoint := r.status.Block
+	r.lock.Unlock()
+
+	start := time.Now()
+	var removedLogs []*remote.SubscribeLogsReply
+	err := db.Update(ctx, func(tx kv.RwTx) error {
+		if _, err := r.checkUnwindPoint(tx, unwindPoint); err != nil {
+			return err
+		}
+		if notifications != nil && notifications.Accumulator != nil {
+			stateVersion, err := rawdb.GetStateVersion(tx)
+			if err != nil {
+				return err
+			}
+			notifications.Accumulator.Reset(stateVersion)
+		}
+		if notifications != nil && notifications.Events != nil && notifications.Events.HasLogSubsriptions() {
+			// logs of the unwound blocks are deleted by the Execution stage
+			var err error
+			if removedLogs, err = stagedsync.ReadLogs(tx, unwindPoint+1, true /* isUnwind */); err != nil {
+				return err
+			}
+		}
+		sync.UnwindTo(unwindPoint, common.Hash{})
+		if err := sync.RunUnwindWithProgress(db, tx, func(stage stages.SyncStage) {
+			r.update(func(status *UnwindStatus) { status.Stage = string(stage) })
+		}); err != nil {
+			return err
+		}
+		return unwindHeaders(ctx, tx, unwindPoint)
+	})
+	if err == nil {
+		err = hd.RecoverFromDb(db)
+	}
+	if err == nil {
+		err = notifyUnwind(ctx, db, notifications, chainConfig, removedLogs, updateHead)
+	}
+	r.update(func(status *UnwindStatus) {
+		status.Finished = time.Now().Unix()
+		if err != nil {
+			status.State, status.Err = UnwindFailed, err.Error()
+			return
+		}
+		status.State = UnwindDone
+	})
+	if err != nil {
+		log.Error(fmt.Sprintf("%s Unwind failed", setHeadLogTitle), "block", unwindPoint, "err", err)
+		return
+	}
+	log.Info(fmt.Sprintf("%s Unwind done", setHeadLogTitle), "block", unwindPoint, "in", time.Since(start))
+}
+
+// notifyUnwind - sends the new head and the changes accumulated by the unwind, the next sync cycle resets them
+func notifyUnwind(ctx context.Context, db kv.RoDB, notifications *shards.Notifications, chainConfig *params.ChainConfig,
+	removedLogs []*remote.SubscribeLogsReply,
+	updateHead func(ctx context.Context, headHeight, headTime uint64, hash common.Hash, td *uint256.Int),
+) error {
+	var head *types.Header
+	var headRlp []byte
+	if err := db.View(ctx, func(tx kv.Tx) error {
+		if head = rawdb.ReadCurrentHeader(tx); head == nil {
+			return fmt.Errorf("head header not found")
+		}
+		headRlp = rawdb.ReadHeaderRLP(tx, head.Hash(), head.Number.Uint64())
+		td, err := rawdb.ReadTd(tx, head.Hash(), head.Number.Uint64())
+		if err != nil {
+			return err
+		}
+		if td != nil && updateHead != nil {
+			td256, overflow := uint256.FromBig(td)
+			if overflow {
+				return fmt.Errorf("headTds higher than 2^256-1")
+			}
+			updateHead(ctx, head.Number.Uint64(), head.Time, head.Hash(), td256)
+		}
+		if notifications == nil || notifications.Accumulator == nil {
+			return nil
+		}
+		plainStateVersion, err := rawdb.GetStateVersion(tx)
+		if err != nil {
+			return err
+		}
+		notifications.Accumulator.SetStateID(plainStateVersion)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if notifications == nil {
+		return nil
+	}
+	if notifications.Events != nil {
+		notifications.Events.OnNewHeader([][]byte{headRlp})
+		if len(removedLogs) > 0 {
+			notifications.Events.OnLogs(removedLogs)
+		}
+	}
+	if notifications.Accumulator != nil {
+		pendingBaseFee := misc.CalcBaseFee(chainConfig, head)
+		notifications.Accumulator.SendAndReset(ctx, notifications.StateChangesConsumer, pendingBaseFee.Uint64(), head.GasLimit)
+	}
+	return nil
+}
+
+// unwindHeaders - the Headers stage keeps its progress and the headers on unwinds without a bad block: it expects
+// a fork to be inserted next. Rewind it as `integration stage_headers --unwind` does, so the blocks are downloaded again.
+func unwindHeaders(ctx context.Context, tx kv.RwTx, unwindPoint uint64) error {
+	progress, err := stages.GetStageProgress(tx, stages.Headers)
+	if err != nil {
+		return err
+	}
+	if progress <= unwindPoint {
+		return nil
+	}
+	if err = stages.SaveStageProgress(tx, stages.Headers, unwindPoint); err != nil {
+		return err
+	}
+	if err = rawdb.TruncateBlocks(ctx, tx, unwindPoint+1); err != nil {
+		return err
+	}
+	if err = rawdb.TruncateTd(tx, unwindPoint+1); err != nil {
+		return err
+	}
+	hash, err := rawdb.ReadCanonicalHash(tx, unwindPoint)
+	if err != nil {
+		return err
+	}
+	return rawdb.WriteHeadHeaderHash(tx, hash)
+}
+
+// SetHeadAPI - debug_setHead and debug_setHeadStatus. Served only by erigon on the authenticated (engine) port:
+// the separate rpcdaemon has no access to the sync loop.
+type SetHeadAPI struct {
+	requests *UnwindRequests
+}
+
+func NewSetHeadAPI(requests *UnwindRequests) *SetHeadAPI {
+	return &SetHeadAPI{requests: requests}
+}
+
+// SetHead - unwinds all stages to the block at the next safe point of the sync loop. Returns before the unwind starts,
+// use SetHeadStatus for its progress.
+func (api *SetHeadAPI) SetHead(ctx context.Context, number hexutil.Uint64) (*UnwindStatus, error) {
+	return api.requests.Request(ctx, uint64(number))
+}
+
+// SetHeadStatus - returns the progress of the last unwind requested by SetHead, nil if there were none
+func (api *SetHeadAPI) SetHeadStatus() *UnwindStatus {
+	return api.requests.Status()
+}
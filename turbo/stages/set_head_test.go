@@ -0,0 +1,178 @@
+package stages_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/erigon-lib/compress"
+	"github.com/ledgerwatch/erigon-lib/downloader/snaptype"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/ledgerwatch/erigon-lib/recsplit"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	stages2 "github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/turbo/snapshotsync"
+	"github.com/ledgerwatch/erigon/turbo/stages"
+)
+
+type stateChangesRecorder struct {
+	batches []*remote.StateChangeBatch
+}
+
+func (r *stateChangesRecorder) SendStateChanges(_ context.Context, sc *remote.StateChangeBatch) {
+	r.batches = append(r.batches, sc)
+}
+
+func TestSetHead(t *testing.T) {
+	m := stages.Mock(t)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 10, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{1})
+	}, false /* intermediateHashes */)
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chain))
+
+	requests := stages.NewUnwindRequests(m.DB, nil)
+	require.Nil(t, requests.Status())
+	_, err = requests.Request(m.Ctx, 10)
+	require.ErrorContains(t, err, "not below the head")
+
+	status, err := requests.Request(m.Ctx, 6)
+	require.NoError(t, err)
+	require.Equal(t, stages.UnwindPending, status.State)
+	require.Equal(t, uint64(10), status.From)
+	_, err = requests.Request(m.Ctx, 5)
+	require.Error(t, err) // only one pending unwind
+
+	stateChanges := &stateChangesRecorder{}
+	m.Notifications.StateChangesConsumer = stateChanges
+	headers, unsubscribe := m.Notifications.Events.AddHeaderSubscription()
+	defer unsubscribe()
+	var headHeight uint64
+	updateHead := func(_ context.Context, height, _ uint64, _ common.Hash, _ *uint256.Int) { headHeight = height }
+
+	requests.Serve(m.Ctx, m.DB, m.Sync, m.HeaderDownload(), m.Notifications, m.ChainConfig, updateHead)
+	status = requests.Status()
+	require.Equal(t, stages.UnwindDone, status.State, status.Err)
+	require.NotEmpty(t, status.Stage)
+
+	require.NoError(t, m.DB.View(m.Ctx, func(tx kv.Tx) error {
+		for _, stage := range []stages2.SyncStage{stages2.Headers, stages2.Bodies, stages2.Execution, stages2.Finish} {
+			progress, err := stages2.GetStageProgress(tx, stage)
+			require.NoError(t, err)
+			require.Equal(t, uint64(6), progress, stage)
+		}
+		hash, err := rawdb.ReadCanonicalHash(tx, 7)
+		require.NoError(t, err)
+		require.Equal(t, common.Hash{}, hash)
+		return nil
+	}))
+
+	// The new head is announced to the sentries, the rpcdaemon and the txpool
+	require.Equal(t, uint64(6), headHeight)
+	select {
+	case headersRlp := <-headers:
+		require.Len(t, headersRlp, 1)
+		var header types.Header
+		require.NoError(t, rlp.DecodeBytes(headersRlp[0], &header))
+		require.Equal(t, chain.Headers[5].Hash(), header.Hash())
+	default:
+		t.Fatal("new head is not notified")
+	}
+	require.Len(t, stateChanges.batches, 1)
+	changes := stateChanges.batches[0].ChangeBatch
+	require.Len(t, changes, 1)
+	require.Equal(t, remote.Direction_UNWIND, changes[0].Direction)
+	require.Equal(t, uint64(6), changes[0].BlockHeight)
+	require.NotEmpty(t, changes[0].Changes) // coinbase rewards of the unwound blocks
+
+	// Nothing is pending
+	requests.Serve(m.Ctx, m.DB, m.Sync, m.HeaderDownload(), m.Notifications, m.ChainConfig, updateHead)
+	require.Equal(t, stages.UnwindDone, requests.Status().State)
+	require.Len(t, stateChanges.batches, 1)
+
+	// Sync resumes from the unwind point
+	require.NoError(t, m.InsertChain(chain))
+	require.NoError(t, m.DB.View(m.Ctx, func(tx kv.Tx) error {
+		progress, err := stages2.GetStageProgress(tx, stages2.Finish)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), progress)
+		return nil
+	}))
+}
+
+// createTestSegments - creates segments of all types with one word and their indices, as blocks [from, to)
+func createTestSegments(t *testing.T, dir string, from, to uint64) {
+	for _, segType := range []snaptype.Type{snaptype.Headers, snaptype.Bodies, snaptype.Transactions} {
+		c, err := compress.NewCompressor(context.Background(), "test", filepath.Join(dir, snaptype.SegmentFileName(from, to, segType)), dir, 100, 1, log.LvlDebug)
+		require.NoError(t, err)
+		require.NoError(t, c.AddWord([]byte{1}))
+		require.NoError(t, c.Compress())
+		c.Close()
+		idxNames := []string{segType.String()}
+		if segType == snaptype.Transactions {
+			idxNames = append(idxNames, snaptype.Transactions2Block.String())
+		}
+		for _, idxName := range idxNames {
+			idx, err := recsplit.NewRecSplit(recsplit.RecSplitArgs{
+				KeyCount:   1,
+				BucketSize: 10,
+				TmpDir:     dir,
+				IndexFile:  filepath.Join(dir, snaptype.IdxFileName(from, to, idxName)),
+				LeafSize:   8,
+			})
+			require.NoError(t, err)
+			require.NoError(t, idx.AddKey([]byte{1}, 0))
+			require.NoError(t, idx.Build())
+			idx.Close()
+		}
+	}
+}
+
+func TestSetHeadUnwindPoint(t *testing.T) {
+	ctx, db := context.Background(), memdb.NewTestDB(t)
+	// Checks read only the progress of the Finish stage: a long chain is simulated by it
+	head := uint64(params.FullImmutabilityThreshold + 600_000)
+	require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error {
+		return stages2.SaveStageProgress(tx, stages2.Finish, head)
+	}))
+
+	requests := stages.NewUnwindRequests(db, nil)
+	_, err := requests.Request(ctx, head)
+	require.ErrorContains(t, err, "not below the head")
+	_, err = requests.Request(ctx, head-params.FullImmutabilityThreshold-1)
+	require.ErrorContains(t, err, "deeper than")
+	status, err := requests.Request(ctx, head-params.FullImmutabilityThreshold)
+	require.NoError(t, err)
+	require.Equal(t, stages.UnwindPending, status.State)
+
+	dir := t.TempDir()
+	createTestSegments(t, dir, 0, 500_000)
+	createTestSegments(t, dir, 500_000, 1_000_000)
+	snapshots := snapshotsync.NewRoSnapshots(ethconfig.Snapshot{Enabled: true}, dir)
+	defer snapshots.Close()
+	require.NoError(t, snapshots.ReopenFolder())
+	require.Equal(t, uint64(999_999), snapshots.BlocksAvailable())
+
+	head = 1_000_100
+	require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error {
+		return stages2.SaveStageProgress(tx, stages2.Finish, head)
+	}))
+	requests = stages.NewUnwindRequests(db, snapshots)
+	_, err = requests.Request(ctx, 999_998)
+	require.ErrorContains(t, err, "in snapshots")
+	status, err = requests.Request(ctx, 999_999)
+	require.NoError(t, err)
+	require.Equal(t, head, status.From)
+}
@@ -75,6 +75,7 @@ func StageLoop(
 	updateHead func(ctx context.Context, headHeight, headTime uint64, hash common.Hash, td *uint256.Int),
 	waitForDone chan struct{},
 	loopMinTime time.Duration,
+	unwindRequests *UnwindRequests,
 ) {
 	defer close(waitForDone)
 	initialCycle := true
@@ -89,6 +90,9 @@ func StageLoop(
 			// continue
 		}
 
+		// Between cycles no transaction is open - safe point for unwinds requested by RPC
+		unwindRequests.Serve(ctx, db, sync, hd, notifications, chainConfig, updateHead)
+
 		// Estimate the current top height seen from the peer
 		headBlockHash, err := StageLoopStep(ctx, chainConfig, db, sync, notifications, initialCycle, updateHead)
 
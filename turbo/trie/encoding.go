@@ -58,6 +58,11 @@ func hexToCompact(hex []byte) []byte {
 	return buf
 }
 
+// CompactToHex translates from COMPACT to HEX encoding.
+func CompactToHex(compact []byte) []byte {
+	return compactToHex(compact)
+}
+
 func compactToHex(compact []byte) []byte {
 	if len(compact) == 0 {
 		return compact
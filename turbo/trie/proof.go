@@ -95,3 +95,65 @@ func (t *Trie) Prove(key []byte, fromLevel int, storage bool) ([][]byte, error)
 	}
 	return proof, nil
 }
+
+// NodeRLP returns the RLP encoding of the node at the given path of nibbles, or nil if the path does not
+// lead to a node. If accountKey is not nil, the path is walked through the storage trie of that account.
+func (t *Trie) NodeRLP(accountKey []byte, path []byte) ([]byte, error) {
+	tn := t.root
+	if accountKey != nil {
+		accNode, ok := t.getAccount(t.root, keybytesToHex(accountKey), 0)
+		if !ok || accNode == nil {
+			return nil, nil
+		}
+		tn = accNode.storage
+	}
+	if len(path) > 0 && path[len(path)-1] == 16 {
+		path = path[:len(path)-1]
+	}
+	for len(path) > 0 && tn != nil {
+		switch n := tn.(type) {
+		case *shortNode:
+			nKey := n.Key
+			if nKey[len(nKey)-1] == 16 {
+				nKey = nKey[:len(nKey)-1]
+			}
+			if len(path) < len(nKey) || !bytes.Equal(nKey, path[:len(nKey)]) {
+				return nil, nil
+			}
+			tn = n.Val
+			path = path[len(nKey):]
+		case *duoNode:
+			i1, i2 := n.childrenIdx()
+			switch path[0] {
+			case i1:
+				tn = n.child1
+			case i2:
+				tn = n.child2
+			default:
+				tn = nil
+			}
+			path = path[1:]
+		case *fullNode:
+			tn = n.Children[path[0]]
+			path = path[1:]
+		case hashNode:
+			return nil, fmt.Errorf("encountered hashNode unexpectedly, path %x", path)
+		default:
+			return nil, nil
+		}
+	}
+	switch tn.(type) {
+	case *shortNode, *duoNode, *fullNode:
+	case hashNode:
+		return nil, fmt.Errorf("encountered hashNode unexpectedly at the end of the path")
+	default:
+		return nil, nil
+	}
+	hasher := newHasher(false)
+	defer returnHasherToPool(hasher)
+	enc, err := hasher.hashChildren(tn, 0)
+	if err != nil {
+		return nil, err
+	}
+	return common.CopyBytes(enc), nil
+}
@@ -0,0 +1,97 @@
+package trie
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcTrieProofs(t *testing.T) {
+	db := memdb.NewTestDB(t)
+	tx, err := db.BeginRw(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	// The same state is written to the flat tables and to an in-memory trie
+	expected := New(common.Hash{})
+	var contract common.Hash
+	for i := 0; i < 50; i++ {
+		addrHash := crypto.Keccak256Hash([]byte{byte(i)})
+		acc := accounts.NewAccount()
+		acc.Initialised = true
+		acc.Nonce = uint64(i)
+		acc.Balance.SetUint64(uint64(i * 1000))
+		if i%10 == 0 {
+			acc.Incarnation = 1
+			contract = addrHash
+		}
+		buf := make([]byte, acc.EncodingLengthForStorage())
+		acc.EncodeForStorage(buf)
+		require.NoError(t, tx.Put(kv.HashedAccounts, addrHash[:], buf))
+		expected.UpdateAccount(addrHash[:], &acc)
+		if acc.Incarnation == 0 {
+			continue
+		}
+		for j := 0; j < 20; j++ {
+			storageHash := crypto.Keccak256Hash([]byte{byte(j)})
+			k := make([]byte, 72)
+			copy(k, addrHash[:])
+			binary.BigEndian.PutUint64(k[32:], acc.Incarnation)
+			copy(k[40:], storageHash[:])
+			require.NoError(t, tx.Put(kv.HashedStorage, k, []byte{byte(j + 1)}))
+			expected.Update(append(common.CopyBytes(addrHash[:]), storageHash[:]...), []byte{byte(j + 1)})
+		}
+	}
+
+	accountKey := crypto.Keccak256Hash([]byte{3})
+	missingKey := crypto.Keccak256Hash([]byte{100})
+	storageKey := crypto.Keccak256Hash([]byte{5})
+	contractWithInc := make([]byte, 40)
+	copy(contractWithInc, contract[:])
+	binary.BigEndian.PutUint64(contractWithInc[32:], 1)
+
+	rl := NewRetainList(0)
+	rl.AddKey(accountKey[:])
+	rl.AddKey(missingKey[:])
+	rl.AddKey(append(common.CopyBytes(contractWithInc), storageKey[:]...))
+	loader := NewFlatDBTrieLoader("test")
+	require.NoError(t, loader.Reset(rl, nil, nil, false))
+	tr, err := loader.CalcTrie(tx, nil)
+	require.NoError(t, err)
+	require.Equal(t, expected.Hash(), tr.Hash())
+
+	for _, key := range [][]byte{accountKey[:], missingKey[:]} {
+		proof, err := tr.Prove(key, 0, false)
+		require.NoError(t, err)
+		expectedProof, err := expected.Prove(key, 0, false)
+		require.NoError(t, err)
+		require.Equal(t, expectedProof, proof)
+	}
+	key := append(common.CopyBytes(contract[:]), storageKey[:]...)
+	proof, err := tr.Prove(key, 64, true)
+	require.NoError(t, err)
+	require.NotEmpty(t, proof)
+	expectedProof, err := expected.Prove(key, 64, true)
+	require.NoError(t, err)
+	require.Equal(t, expectedProof, proof)
+
+	// Nodes are looked up by path, the root of the account trie and of the storage trie first
+	node, err := tr.NodeRLP(nil, nil)
+	require.NoError(t, err)
+	accountProof, err := tr.Prove(accountKey[:], 0, false)
+	require.NoError(t, err)
+	require.Equal(t, accountProof[0], node)
+	node, err = tr.NodeRLP(contract[:], nil)
+	require.NoError(t, err)
+	require.Equal(t, proof[0], node)
+	node, err = tr.NodeRLP(contract[:], keybytesToHex(storageKey[:])[:1])
+	require.NoError(t, err)
+	require.Equal(t, proof[1], node)
+}
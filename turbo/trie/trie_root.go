@@ -124,6 +124,10 @@ type RootHashAggregator struct {
 	a              accounts.Account
 	leafData       GenStructStepLeafData
 	accData        GenStructStepAccountData
+
+	rd            RetainDecider // Set by CalcTrie, nodes of the retained prefixes are built instead of only hashed
+	rootNode      node
+	storagePrefix []byte
 }
 
 type StreamReceiver interface {
@@ -175,6 +179,23 @@ func (l *FlatDBTrieLoader) SetStreamReceiver(receiver StreamReceiver) {
 	l.receiver = receiver
 }
 
+// CalcTrie works like CalcTrieRoot, but also builds the nodes on the paths of the keys retained by the
+// RetainDecider given to Reset. Other sub-tries are replaced by their hashes, which is enough to produce
+// merkle proofs for the retained keys.
+func (l *FlatDBTrieLoader) CalcTrie(tx kv.Tx, quit <-chan struct{}) (*Trie, error) {
+	l.defaultReceiver.rd = l.rd
+	defer func() { l.defaultReceiver.rd = nil }()
+	root, err := l.CalcTrieRoot(tx, nil, quit)
+	if err != nil {
+		return nil, err
+	}
+	t := New(root)
+	if l.defaultReceiver.rootNode != nil {
+		t.root = l.defaultReceiver.rootNode
+	}
+	return t, nil
+}
+
 // CalcTrieRoot algo:
 //
 //		for iterateIHOfAccounts {
@@ -329,6 +350,20 @@ func (r *RootHashAggregator) RetainNothing(_ []byte) bool {
 	return false
 }
 
+func (r *RootHashAggregator) retainAccount(prefix []byte) bool {
+	return r.rd != nil && r.rd.Retain(prefix)
+}
+
+// retainStorage decides on storage prefixes, which are relative to the account being built
+func (r *RootHashAggregator) retainStorage(prefix []byte) bool {
+	if r.rd == nil {
+		return false
+	}
+	hexutil.DecompressNibbles(r.currAccK, &r.storagePrefix)
+	r.storagePrefix = append(r.storagePrefix, prefix...)
+	return r.rd.Retain(r.storagePrefix)
+}
+
 func (r *RootHashAggregator) Reset(hc HashCollector2, shc StorageHashCollector2, trace bool) {
 	r.hc = hc
 	r.shc = shc
@@ -346,6 +381,7 @@ func (r *RootHashAggregator) Reset(hc HashCollector2, shc StorageHashCollector2,
 	r.valueStorage = nil
 	r.wasIHStorage = false
 	r.root = common.Hash{}
+	r.rootNode = nil
 	r.trace = trace
 	r.hb.trace = trace
 }
@@ -495,6 +531,9 @@ func (r *RootHashAggregator) Receive(itemType StreamItem,
 		}
 		if r.hb.hasRoot() {
 			r.root = r.hb.rootHash()
+			if r.rd != nil {
+				r.rootNode = r.hb.root()
+			}
 		} else {
 			r.root = EmptyRoot
 		}
@@ -565,7 +604,7 @@ func (r *RootHashAggregator) genStructStorage() error {
 		r.leafData.Value = rlphacks.RlpSerializableBytes(r.valueStorage)
 		data = &r.leafData
 	}
-	r.groupsStorage, r.hasTreeStorage, r.hasHashStorage, err = GenStructStep(r.RetainNothing, r.currStorage.Bytes(), r.succStorage.Bytes(), r.hb, func(keyHex []byte, hasState, hasTree, hasHash uint16, hashes, rootHash []byte) error {
+	r.groupsStorage, r.hasTreeStorage, r.hasHashStorage, err = GenStructStep(r.retainStorage, r.currStorage.Bytes(), r.succStorage.Bytes(), r.hb, func(keyHex []byte, hasState, hasTree, hasHash uint16, hashes, rootHash []byte) error {
 		if r.shc == nil {
 			return nil
 		}
@@ -633,7 +672,7 @@ func (r *RootHashAggregator) genStructAccount() error {
 	r.currStorage.Reset()
 	r.succStorage.Reset()
 	var err error
-	if r.groups, r.hasTree, r.hasHash, err = GenStructStep(r.RetainNothing, r.curr.Bytes(), r.succ.Bytes(), r.hb, func(keyHex []byte, hasState, hasTree, hasHash uint16, hashes, rootHash []byte) error {
+	if r.groups, r.hasTree, r.hasHash, err = GenStructStep(r.retainAccount, r.curr.Bytes(), r.succ.Bytes(), r.hb, func(keyHex []byte, hasState, hasTree, hasHash uint16, hashes, rootHash []byte) error {
 		if r.hc == nil {
 			return nil
 		}